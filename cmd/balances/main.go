@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/wavesplatform/gowaves/pkg/logging"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+	"github.com/wavesplatform/gowaves/pkg/state"
+	"github.com/wavesplatform/gowaves/pkg/util/fdlimit"
+	"github.com/wavesplatform/gowaves/pkg/versioning"
+)
+
+const (
+	formatCSV   = "csv"
+	formatJSONL = "jsonl"
+)
+
+func main() {
+	if err := run(); err != nil {
+		zap.S().Error(err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	var (
+		logLevel = zap.LevelFlag("log-level", zapcore.InfoLevel,
+			"Logging level. Supported levels: DEBUG, INFO, WARN, ERROR, FATAL. Default logging level INFO.")
+		statePath          = flag.String("state-path", "", "Path to node's state directory")
+		blockchainType     = flag.String("blockchain-type", "mainnet", "Blockchain type: mainnet/testnet/stagenet")
+		cfgPath            = flag.String("cfg-path", "", "Path to configuration JSON file, only for custom blockchain.")
+		height             = flag.Uint64("height", 0, "Height to export balances at, defaults to the current height")
+		format             = flag.String("format", formatCSV, "Output format: csv/jsonl")
+		output             = flag.String("output", "", "Path to output file, defaults to standard output")
+		disableBloomFilter = flag.Bool("disable-bloom", false, "Disable bloom filter for state.")
+	)
+	flag.Parse()
+
+	logger := logging.SetupSimpleLogger(*logLevel)
+	defer func() {
+		err := logger.Sync()
+		if err != nil && errors.Is(err, os.ErrInvalid) {
+			panic(fmt.Sprintf("Failed to close logging subsystem: %v\n", err))
+		}
+	}()
+	zap.S().Infof("Gowaves Balances Export version: %s", versioning.Version)
+
+	if *statePath == "" {
+		return errors.New("empty path to state")
+	}
+	if *format != formatCSV && *format != formatJSONL {
+		return fmt.Errorf("unsupported output format '%s'", *format)
+	}
+	maxFDs, err := fdlimit.MaxFDs()
+	if err != nil {
+		return fmt.Errorf("failed to get max file descriptors: %w", err)
+	}
+	if _, err = fdlimit.RaiseMaxFDs(maxFDs); err != nil {
+		return fmt.Errorf("failed to raise max file descriptors: %w", err)
+	}
+	cfg, err := blockchainSettings(*cfgPath, *blockchainType)
+	if err != nil {
+		return err
+	}
+
+	params := state.DefaultStateParams()
+	params.DbParams.DisableBloomFilter = *disableBloomFilter
+	st, err := state.NewState(*statePath, false, params, cfg, false)
+	if err != nil {
+		return fmt.Errorf("failed to open state at '%s': %w", *statePath, err)
+	}
+	defer func() {
+		if clErr := st.Close(); clErr != nil {
+			zap.S().Errorf("Failed to close state: %v", clErr)
+		}
+	}()
+
+	h := *height
+	if h == 0 {
+		if h, err = st.Height(); err != nil {
+			return fmt.Errorf("failed to get current height: %w", err)
+		}
+	}
+
+	var out io.Writer = os.Stdout
+	if *output != "" {
+		f, fErr := os.Create(filepath.Clean(*output))
+		if fErr != nil {
+			return fmt.Errorf("failed to create output file: %w", fErr)
+		}
+		defer func() {
+			if clErr := f.Close(); clErr != nil {
+				zap.S().Errorf("Failed to close output file: %v", clErr)
+			}
+		}()
+		out = f
+	}
+	bw := bufio.NewWriter(out)
+	n, err := export(st, h, *format, bw)
+	if err != nil {
+		return fmt.Errorf("failed to export balances at height %d: %w", h, err)
+	}
+	if err = bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	zap.S().Infof("Exported %d balances at height %d", n, h)
+	return nil
+}
+
+func blockchainSettings(cfgPath, blockchainType string) (*settings.BlockchainSettings, error) {
+	if cfgPath == "" {
+		return settings.BlockchainSettingsByTypeName(blockchainType)
+	}
+	f, err := os.Open(filepath.Clean(cfgPath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open configuration file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	return settings.ReadBlockchainSettings(f)
+}
+
+type balanceLine struct {
+	Address proto.WavesAddress  `json:"address"`
+	AssetID proto.OptionalAsset `json:"assetId"`
+	Balance uint64              `json:"balance"`
+}
+
+func export(st state.StateInfo, height proto.Height, format string, w io.Writer) (int, error) {
+	var (
+		n     int
+		write func(r state.BalanceRecord) error
+	)
+	switch format {
+	case formatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write([]string{"address", "asset", "balance"}); err != nil {
+			return 0, err
+		}
+		defer cw.Flush()
+		write = func(r state.BalanceRecord) error {
+			return cw.Write([]string{r.Address.String(), r.Asset.String(), strconv.FormatUint(r.Balance, 10)})
+		}
+	default:
+		enc := json.NewEncoder(w)
+		write = func(r state.BalanceRecord) error {
+			return enc.Encode(balanceLine{Address: r.Address, AssetID: r.Asset, Balance: r.Balance})
+		}
+	}
+	err := st.BalancesAtHeight(height, func(r state.BalanceRecord) error {
+		n++
+		return write(r)
+	})
+	return n, err
+}
@@ -22,6 +22,9 @@ import (
 const (
 	defaultURL    = "https://nodes.wavesnodes.com"
 	defaultScheme = "http"
+
+	// shortForkLength is the length of fork which highly likely will be resolved by the node automatically.
+	shortForkLength = 10
 )
 
 var (
@@ -60,6 +63,9 @@ func run() error {
 	var reference string
 	var verbose bool
 	var silent bool
+	var monitorInterval time.Duration
+	var webhook string
+	var metricsAddr string
 
 	flag.StringVarP(&node, "node", "n", "", "URL of the node")
 	flag.StringVarP(&reference, "references", "r", defaultURL, "A list of space-separated URLs of reference nodes, for example \"http://127.0.0.1:6869 https://nodes.wavesnodes.com\"")
@@ -67,6 +73,9 @@ func run() error {
 	flag.BoolVarP(&showVersion, "version", "v", false, "Print version information and quit")
 	flag.BoolVar(&verbose, "verbose", false, "Logs additional information; incompatible with \"silent\"")
 	flag.BoolVar(&silent, "silent", false, "Produce no output except this help message; incompatible with \"verbose\"")
+	flag.DurationVar(&monitorInterval, "monitor", 0, "Run continuously and compare blockchains with the given interval, for example \"1m\"")
+	flag.StringVar(&webhook, "webhook", "", "URL to POST JSON alerts to when a fork is detected or resolved; used only with \"monitor\"")
+	flag.StringVar(&metricsAddr, "prometheus", "", "Address to serve Prometheus metrics on, for example \"127.0.0.1:9090\"; used only with \"monitor\"")
 	flag.Parse()
 
 	if showHelp {
@@ -106,7 +115,6 @@ func run() error {
 	zap.S().Debugf("Reference nodes (%d): %s", len(other), other)
 
 	urls := append([]string{node}, other...)
-	zap.S().Debugf("Requesting height from %d nodes", len(urls))
 
 	interrupt := interruptListener()
 
@@ -120,13 +128,47 @@ func run() error {
 		clients[i] = c
 	}
 
+	if monitorInterval > 0 {
+		m, err := newMonitor(node, clients, monitorInterval, webhook, metricsAddr)
+		if err != nil {
+			zap.S().Errorf("Failed to start monitor: %s", err)
+			return errInvalidParameters
+		}
+		return m.run(interrupt)
+	}
+
+	r, err := compare(interrupt, clients)
+	if err != nil {
+		return err
+	}
+	return report(node, r)
+}
+
+// comparison is the result of comparison of node's blockchain with blockchains of reference nodes.
+type comparison struct {
+	height          int // Height of the node
+	referenceHeight int // The lowest height of reference nodes
+	commonHeight    int // The last common height of all nodes
+}
+
+// forkLength returns the number of node's blocks after the last common block or zero if the node is not on fork.
+func (c comparison) forkLength() int {
+	if c.commonHeight < c.height && c.commonHeight < c.referenceHeight {
+		return c.height - c.commonHeight
+	}
+	return 0
+}
+
+// compare retrieves heights of all nodes and finds the last common block, the first client is the node to check.
+func compare(interrupt <-chan struct{}, clients []*client.Client) (comparison, error) {
+	zap.S().Debugf("Requesting height from %d nodes", len(clients))
 	hs, err := heights(interrupt, clients)
 	if err != nil {
 		zap.S().Errorf("Failed to retrieve heights from all nodes: %s", err)
 		if interrupted(interrupt) {
-			return errUserTermination
+			return comparison{}, errUserTermination
 		}
-		return errUnavailable
+		return comparison{}, errUnavailable
 	}
 	for i, h := range hs {
 		zap.S().Debugf("%d: Height = %d", i, h)
@@ -139,16 +181,19 @@ func run() error {
 	if err != nil {
 		zap.S().Errorf("Failed to find last common height: %s", err)
 		if interrupted(interrupt) {
-			return errUserTermination
+			return comparison{}, errUserTermination
 		}
-		return err
+		return comparison{}, err
 	}
 
-	h := hs[0]
-	zap.S().Debugf("Node height: %d", h)
-	refLowest := min(hs[1:])
-	zap.S().Debugf("The lowest height of reference nodes: %d", refLowest)
+	r := comparison{height: hs[0], referenceHeight: min(hs[1:]), commonHeight: ch}
+	zap.S().Debugf("Node height: %d", r.height)
+	zap.S().Debugf("The lowest height of reference nodes: %d", r.referenceHeight)
+	return r, nil
+}
 
+func report(node string, r comparison) error {
+	ch, h, refLowest := r.commonHeight, r.height, r.referenceHeight
 	switch {
 	case ch == h && ch < refLowest: // The node is behind the reference nodes
 		zap.S().Infof("Node '%s' is %d blocks behind the lowest reference node", node, refLowest-h)
@@ -157,10 +202,10 @@ func run() error {
 		zap.S().Infof("Node '%s' is %d blocks ahead of the lowest reference node", node, h-refLowest)
 		return nil
 	case ch < h && ch < refLowest:
-		fl := h - ch
+		fl := r.forkLength()
 		zap.S().Warnf("Node '%s' is on fork of length %d blocks since last common block at height %d", node, fl, ch)
 		switch {
-		case fl < 10:
+		case fl < shortForkLength:
 			zap.S().Infof("The fork is very short, highly likely the node is OK")
 			return nil
 		case fl < 100:
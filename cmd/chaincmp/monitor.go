@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+
+	"github.com/wavesplatform/gowaves/pkg/client"
+)
+
+const (
+	metricsNamespace = "chaincmp"
+	webhookTimeout   = 10 * time.Second
+	metricsTimeout   = 10 * time.Second
+
+	alertStatusFork     = "fork"
+	alertStatusResolved = "resolved"
+)
+
+// alert is the JSON body of webhook request.
+type alert struct {
+	Status           string    `json:"status"`
+	Node             string    `json:"node"`
+	ForkLength       int       `json:"forkLength"`
+	LastCommonHeight int       `json:"lastCommonHeight"`
+	Height           int       `json:"height"`
+	ReferenceHeight  int       `json:"referenceHeight"`
+	Timestamp        time.Time `json:"timestamp"`
+}
+
+// monitor periodically compares the blockchain of the node with reference nodes. It alerts when the node gets on
+// fork longer than shortForkLength blocks and when the fork is resolved.
+type monitor struct {
+	node        string
+	clients     []*client.Client
+	interval    time.Duration
+	webhook     string
+	metricsAddr string
+	httpClient  *http.Client
+	onFork      bool
+
+	registry         *prometheus.Registry
+	forkLength       prometheus.Gauge
+	height           prometheus.Gauge
+	referenceHeight  prometheus.Gauge
+	commonHeight     prometheus.Gauge
+	failures         prometheus.Counter
+	alerts           *prometheus.CounterVec
+	webhookFailures  prometheus.Counter
+	lastCheckSuccess prometheus.Gauge
+}
+
+func newMonitor(
+	node string, clients []*client.Client, interval time.Duration, webhook, metricsAddr string,
+) (*monitor, error) {
+	if webhook != "" {
+		u, err := checkAndUpdateURL(webhook)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid webhook URL")
+		}
+		webhook = u
+	}
+	gauge := func(name, help string) prometheus.Gauge {
+		return prometheus.NewGauge(prometheus.GaugeOpts{Namespace: metricsNamespace, Name: name, Help: help})
+	}
+	counter := func(name, help string) prometheus.Counter {
+		return prometheus.NewCounter(prometheus.CounterOpts{Namespace: metricsNamespace, Name: name, Help: help})
+	}
+	m := &monitor{
+		node:            node,
+		clients:         clients,
+		interval:        interval,
+		webhook:         webhook,
+		metricsAddr:     metricsAddr,
+		httpClient:      &http.Client{Timeout: webhookTimeout},
+		registry:        prometheus.NewRegistry(),
+		forkLength:      gauge("fork_length", "Number of node's blocks after the last common block, zero if not on fork"),
+		height:          gauge("node_height", "Height of the node"),
+		referenceHeight: gauge("reference_height", "The lowest height of reference nodes"),
+		commonHeight:    gauge("last_common_height", "Height of the last common block of all nodes"),
+		failures:        counter("failed_comparisons_total", "Number of failed comparisons of blockchains"),
+		alerts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "alerts_total",
+			Help:      "Number of fired alerts by status",
+		}, []string{"status"}),
+		webhookFailures:  counter("webhook_failures_total", "Number of failed webhook requests"),
+		lastCheckSuccess: gauge("last_check_success", "1 if the last comparison succeeded, 0 otherwise"),
+	}
+	m.registry.MustRegister(m.forkLength, m.height, m.referenceHeight, m.commonHeight, m.failures, m.alerts,
+		m.webhookFailures, m.lastCheckSuccess)
+	return m, nil
+}
+
+// run compares blockchains until interruption.
+func (m *monitor) run(interrupt <-chan struct{}) error {
+	if m.metricsAddr != "" {
+		srv := m.serveMetrics()
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), metricsTimeout)
+			defer cancel()
+			if err := srv.Shutdown(ctx); err != nil {
+				zap.S().Errorf("Failed to shutdown metrics server: %v", err)
+			}
+		}()
+	}
+	zap.S().Infof("Monitoring node '%s' every %s", m.node, m.interval)
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		if err := m.check(interrupt); errors.Is(err, errUserTermination) {
+			return nil
+		}
+		select {
+		case <-interrupt:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *monitor) check(interrupt <-chan struct{}) error {
+	r, err := compare(interrupt, m.clients)
+	if err != nil {
+		if errors.Is(err, errUserTermination) {
+			return err
+		}
+		m.failures.Inc()
+		m.lastCheckSuccess.Set(0)
+		return err
+	}
+	m.lastCheckSuccess.Set(1)
+	fl := r.forkLength()
+	m.forkLength.Set(float64(fl))
+	m.height.Set(float64(r.height))
+	m.referenceHeight.Set(float64(r.referenceHeight))
+	m.commonHeight.Set(float64(r.commonHeight))
+	switch {
+	case fl >= shortForkLength && !m.onFork:
+		m.onFork = true
+		zap.S().Warnf("Node '%s' is on fork of length %d blocks since last common block at height %d",
+			m.node, fl, r.commonHeight)
+		m.alert(alertStatusFork, r)
+	case fl < shortForkLength && m.onFork:
+		m.onFork = false
+		zap.S().Infof("Fork of node '%s' is resolved", m.node)
+		m.alert(alertStatusResolved, r)
+	case fl > 0:
+		zap.S().Infof("Node '%s' is on fork of length %d blocks", m.node, fl)
+	default:
+		zap.S().Debugf("Node '%s' is OK", m.node)
+	}
+	return nil
+}
+
+func (m *monitor) alert(status string, r comparison) {
+	m.alerts.WithLabelValues(status).Inc()
+	if m.webhook == "" {
+		return
+	}
+	a := alert{
+		Status:           status,
+		Node:             m.node,
+		ForkLength:       r.forkLength(),
+		LastCommonHeight: r.commonHeight,
+		Height:           r.height,
+		ReferenceHeight:  r.referenceHeight,
+		Timestamp:        time.Now().UTC(),
+	}
+	if err := m.sendWebhook(a); err != nil {
+		m.webhookFailures.Inc()
+		zap.S().Errorf("Failed to send alert to webhook: %v", err)
+	}
+}
+
+func (m *monitor) sendWebhook(a alert) error {
+	body, err := json.Marshal(a)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal alert")
+	}
+	resp, err := m.httpClient.Post(m.webhook, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to post alert")
+	}
+	defer func() {
+		if clErr := resp.Body.Close(); clErr != nil {
+			zap.S().Debugf("Failed to close webhook response body: %v", clErr)
+		}
+	}()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return errors.Errorf("unexpected webhook response status '%s'", resp.Status)
+	}
+	return nil
+}
+
+func (m *monitor) serveMetrics() *http.Server {
+	h := http.NewServeMux()
+	h.Handle("/metrics", promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
+	s := &http.Server{
+		Addr:              m.metricsAddr,
+		Handler:           h,
+		ReadHeaderTimeout: metricsTimeout,
+		ReadTimeout:       metricsTimeout,
+	}
+	go func() {
+		zap.S().Infof("Serving Prometheus metrics on '%s'", m.metricsAddr)
+		if err := s.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			zap.S().Errorf("Failed to serve Prometheus metrics: %v", err)
+		}
+	}()
+	return s
+}
@@ -7,7 +7,7 @@ import (
 	"fmt"
 	"log"
 	"os"
-	"strconv"
+	"path/filepath"
 	"strings"
 	"time"
 	"unicode"
@@ -17,7 +17,6 @@ import (
 
 	"github.com/wavesplatform/gowaves/pkg/crypto"
 	"github.com/wavesplatform/gowaves/pkg/proto"
-	"github.com/wavesplatform/gowaves/pkg/settings"
 	"github.com/wavesplatform/gowaves/pkg/util/genesis_generator"
 )
 
@@ -39,6 +38,8 @@ func run() error {
 		signature  string
 		config     bool
 		shift      time.Duration
+		template   string
+		scalaOut   string
 	)
 	flag.StringVar(&scheme, "scheme", "C", "Network scheme byte, defaults to 'C'")
 	flag.StringVar(&seed, "seed", "", "Master seed as Base58 string")
@@ -49,6 +50,8 @@ func run() error {
 	flag.StringVar(&signature, "signature", "", "Genesis block signature as Base58 string")
 	flag.BoolVar(&config, "config", false, "Generate configuration")
 	flag.DurationVar(&shift, "time-shift", 0, "Genesis block and transactions timestamp time shift in text format (eg: +1h, -2h3s)")
+	flag.StringVar(&template, "template", "", "Path to JSON or CSV file with initial balances and, for JSON, blockchain settings. Settings from JSON template override the command line parameters")
+	flag.StringVar(&scalaOut, "scala-config", "", "Path to file to write Scala node blockchain configuration (HOCON) to")
 
 	flag.Parse()
 
@@ -57,7 +60,24 @@ func run() error {
 		transactions []genesis_generator.GenesisTransactionInfo
 		bt           uint64
 		ts           uint64
+		tmpl         = &genesis_generator.Template{}
 	)
+	if template != "" {
+		t, err := readTemplate(template)
+		if err != nil {
+			return err
+		}
+		tmpl = t
+		if tmpl.Scheme != "" {
+			scheme = tmpl.Scheme
+		}
+		if tmpl.BaseTarget != 0 {
+			baseTarget = tmpl.BaseTarget
+		}
+		if tmpl.Timestamp != 0 {
+			timestamp = int64(tmpl.Timestamp)
+		}
+	}
 	if len(scheme) != 1 {
 		return errors.Errorf("invalid scheme '%s'", scheme)
 	}
@@ -70,13 +90,19 @@ func run() error {
 		ts = uint64(time.Now().Add(shift).UnixMilli())
 	}
 	switch {
-	case len(pairs) != 0 && len(seed) == 0 && len(amounts) == 0:
+	case template != "" && len(pairs) == 0 && len(seed) == 0 && len(amounts) == 0:
+		txs, err := tmpl.Transactions(sc, ts)
+		if err != nil {
+			return err
+		}
+		transactions = txs
+	case template == "" && len(pairs) != 0 && len(seed) == 0 && len(amounts) == 0:
 		txs, err := parsePairs(pairs, sc, ts)
 		if err != nil {
 			return err
 		}
 		transactions = txs
-	case len(pairs) == 0 && len(seed) != 0 && len(amounts) != 0:
+	case template == "" && len(pairs) == 0 && len(seed) != 0 && len(amounts) != 0:
 		as, err := parseAmounts(amounts)
 		if err != nil {
 			return err
@@ -87,7 +113,7 @@ func run() error {
 		}
 		transactions = txs
 	default:
-		return errors.New("invalid combination of 'pairs' or 'seed' and 'amounts' parameters")
+		return errors.New("invalid combination of 'template', 'pairs' or 'seed' and 'amounts' parameters")
 	}
 	if baseTarget == 0 {
 		return errors.New("no Base Target value")
@@ -119,11 +145,18 @@ func run() error {
 		}
 		block = b
 	}
+	cfg := tmpl.GoSettings(block, sc)
+	if scalaOut != "" {
+		hocon, err := genesis_generator.ScalaSettings(cfg)
+		if err != nil {
+			return errors.Wrap(err, "failed to generate Scala node configuration")
+		}
+		if wErr := os.WriteFile(scalaOut, []byte(hocon), 0600); wErr != nil {
+			return errors.Wrap(wErr, "failed to write Scala node configuration")
+		}
+	}
 	var js []byte
 	if config {
-		cfg := settings.MustDefaultCustomSettings()
-		cfg.Genesis = *block
-		cfg.AddressSchemeCharacter = sc
 		var err error
 		js, err = json.Marshal(cfg)
 		if err != nil {
@@ -150,29 +183,41 @@ func errorToLog(err error) string {
 	return string(msg)
 }
 
+func readTemplate(path string) (*genesis_generator.Template, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open template")
+	}
+	defer func() {
+		if clErr := f.Close(); clErr != nil {
+			log.Printf("[WARN] Failed to close template file: %v", clErr)
+		}
+	}()
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		return genesis_generator.ReadJSONTemplate(f)
+	case ".csv":
+		return genesis_generator.ReadCSVTemplate(f)
+	default:
+		return nil, errors.Errorf("unsupported template file extension '%s'", ext)
+	}
+}
+
 func parsePairs(s string, scheme byte, ts uint64) ([]genesis_generator.GenesisTransactionInfo, error) {
 	pairs := strings.Split(s, ",")
 	r := make([]genesis_generator.GenesisTransactionInfo, 0, len(pairs))
 	for _, pair := range pairs {
 		parts := strings.Split(pair, ":")
-		amount, err := strconv.ParseUint(strings.ReplaceAll(parts[1], "_", ""), 10, 64)
+		if len(parts) != 2 {
+			return nil, errors.Errorf("invalid pair '%s'", pair)
+		}
+		amount, err := genesis_generator.ParseAmount(parts[1])
 		if err != nil {
-			return nil, errors.Wrapf(err, "invalid amount '%s'", parts[1])
+			return nil, err
 		}
-		addr, err := proto.NewAddressFromString(parts[0])
+		addr, err := genesis_generator.RecipientAddress(parts[0], scheme)
 		if err != nil {
-			seed, err := crypto.NewDigestFromBase58(parts[0])
-			if err != nil {
-				return nil, errors.Wrapf(err, "failed to convert '%s' to address or account seed", parts[0])
-			}
-			_, pk, err := crypto.GenerateKeyPair(seed[:])
-			if err != nil {
-				return nil, errors.Wrapf(err, "failed to generate address from seed '%s'", seed.String())
-			}
-			addr, err = proto.NewAddressFromPublicKey(scheme, pk)
-			if err != nil {
-				return nil, errors.Wrapf(err, "failed to generate address from seed '%s'", seed.String())
-			}
+			return nil, err
 		}
 		r = append(r, genesis_generator.GenesisTransactionInfo{Address: addr, Amount: amount, Timestamp: ts})
 	}
@@ -183,9 +228,9 @@ func parseAmounts(s string) ([]uint64, error) {
 	parts := strings.Split(s, ",")
 	r := make([]uint64, 0, len(parts))
 	for _, p := range parts {
-		a, err := strconv.ParseUint(strings.ReplaceAll(p, "_", ""), 10, 64)
+		a, err := genesis_generator.ParseAmount(p)
 		if err != nil {
-			return nil, errors.Wrapf(err, "invalid amount '%s'", p)
+			return nil, err
 		}
 		r = append(r, a)
 	}
@@ -0,0 +1,312 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/howeyc/gopass"
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/api"
+)
+
+const (
+	attachCommand  = "attach"
+	consolePrompt  = "> "
+	consoleTimeout = time.Minute
+	// consoleBaseURL is the URL of the node API for the HTTP client, the host is ignored by the dialer of the socket.
+	consoleBaseURL = "http://ipc"
+)
+
+var (
+	errConsoleExit  = errors.New("exit")
+	errConsoleUsage = errors.New("invalid arguments")
+)
+
+// attach runs the interactive console connected to the node over the IPC socket set by 'ipc-path' flag of the node.
+func attach(args []string) int {
+	fs := flag.NewFlagSet(attachCommand, flag.ContinueOnError)
+	var command string
+	fs.StringVar(&command, "exec", "", "Execute the console command and exit.")
+	fs.Usage = func() {
+		_, _ = fmt.Fprintf(fs.Output(), "Usage: %s %s [-exec <command>] <ipc-path>\n", os.Args[0], attachCommand)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	c := newConsole(fs.Arg(0), os.Stdout)
+	if command != "" {
+		if err := c.execute(command); err != nil && !errors.Is(err, errConsoleExit) {
+			_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+	if err := c.execute("status"); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Failed to attach to the node at '%s': %v\n", fs.Arg(0), err)
+		return 1
+	}
+	_, _ = fmt.Fprintln(c.out, "Type 'help' to list commands, 'exit' to quit.")
+	c.run(os.Stdin)
+	return 0
+}
+
+type consoleCommand struct {
+	name  string
+	usage string
+	help  string
+	run   func(c *console, args []string) error
+}
+
+// consoleCommands are the commands of the console, each of them is the request to the REST API of the node.
+var consoleCommands = []consoleCommand{
+	{"status", "status", "Show the status of the node", func(c *console, _ []string) error {
+		return c.get("/node/status")
+	}},
+	{"height", "height", "Show the height of the blockchain", func(c *console, _ []string) error {
+		return c.get("/blocks/height")
+	}},
+	{"peers", "peers [connected|all|known|pinned|suspended|blacklisted]", "List peers, connected by default",
+		func(c *console, args []string) error {
+			paths := map[string]string{
+				"connected":   "/peers/connected",
+				"all":         "/peers/all",
+				"known":       "/go/peers/known",
+				"pinned":      "/go/peers/pinned",
+				"suspended":   "/peers/suspended",
+				"blacklisted": "/peers/blacklisted",
+			}
+			kind := "connected"
+			if len(args) > 0 {
+				kind = args[0]
+			}
+			path, ok := paths[kind]
+			if !ok || len(args) > 1 {
+				return errConsoleUsage
+			}
+			return c.get(path)
+		}},
+	{"connect", "connect <host> <port>", "Connect to the peer", peerCommand("/peers/connect")},
+	{"pin", "pin <host> <port>", "Pin the peer, the node keeps the connection to it", peerCommand("/go/peers/pin")},
+	{"unpin", "unpin <host> <port>", "Unpin the peer", peerCommand("/go/peers/unpin")},
+	{"utx", "utx [list]", "Show the size of the UTX pool or list its transactions",
+		func(c *console, args []string) error {
+			switch {
+			case len(args) == 0:
+				return c.get("/transactions/unconfirmed/size")
+			case len(args) == 1 && args[0] == "list":
+				return c.get("/go/pool/transactions")
+			default:
+				return errConsoleUsage
+			}
+		}},
+	{"wallet", "wallet [load]", "List accounts of the wallet or load the wallet with the password",
+		func(c *console, args []string) error {
+			switch {
+			case len(args) == 0:
+				return c.get("/go/wallet/accounts")
+			case len(args) == 1 && args[0] == "load":
+				password, err := c.password()
+				if err != nil {
+					return errors.Wrap(err, "failed to read password")
+				}
+				return c.post("/go/wallet/load", map[string]string{"password": string(password)})
+			default:
+				return errConsoleUsage
+			}
+		}},
+	{"rollback", "rollback <height>", "Roll the state back to the height", func(c *console, args []string) error {
+		if len(args) != 1 {
+			return errConsoleUsage
+		}
+		height, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			return errors.Errorf("invalid height '%s'", args[0])
+		}
+		return c.post("/debug/rollback", map[string]uint64{"rollbackTo": height})
+	}},
+	{"miner", "miner info|next|pause|resume", "Show the miner state, the next block to mine or pause and resume mining",
+		func(c *console, args []string) error {
+			if len(args) != 1 {
+				return errConsoleUsage
+			}
+			switch args[0] {
+			case "info":
+				return c.get("/go/miner/info")
+			case "next":
+				return c.get("/go/miner/next")
+			case "pause", "resume":
+				return c.post("/debug/miner/"+args[0], nil)
+			default:
+				return errConsoleUsage
+			}
+		}},
+}
+
+func peerCommand(path string) func(c *console, args []string) error {
+	return func(c *console, args []string) error {
+		if len(args) != 2 {
+			return errConsoleUsage
+		}
+		port, err := strconv.ParseUint(args[1], 10, 16)
+		if err != nil {
+			return errors.Errorf("invalid port '%s'", args[1])
+		}
+		return c.post(path, api.PeersConnectRequest{Host: args[0], Port: uint16(port)})
+	}
+}
+
+// console sends commands to the node and prints the responses.
+type console struct {
+	client   *http.Client
+	baseURL  string
+	out      io.Writer
+	password func() ([]byte, error)
+}
+
+func newConsole(socket string, out io.Writer) *console {
+	dialer := new(net.Dialer)
+	return &console{
+		client: &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					return dialer.DialContext(ctx, "unix", socket)
+				},
+			},
+			Timeout: consoleTimeout,
+		},
+		baseURL: consoleBaseURL,
+		out:     out,
+		password: func() ([]byte, error) {
+			_, _ = fmt.Fprint(out, "Enter password of the wallet: ")
+			return gopass.GetPasswd()
+		},
+	}
+}
+
+// run reads commands line by line until the end of the input or the 'exit' command.
+func (c *console) run(in io.Reader) {
+	s := bufio.NewScanner(in)
+	for {
+		_, _ = fmt.Fprint(c.out, consolePrompt)
+		if !s.Scan() {
+			_, _ = fmt.Fprintln(c.out)
+			return
+		}
+		err := c.execute(s.Text())
+		if errors.Is(err, errConsoleExit) {
+			return
+		}
+		if err != nil {
+			_, _ = fmt.Fprintf(c.out, "Error: %v\n", err)
+		}
+	}
+}
+
+func (c *console) execute(line string) error {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return nil
+	}
+	name, args := fields[0], fields[1:]
+	switch name {
+	case "exit", "quit":
+		return errConsoleExit
+	case "help":
+		c.help()
+		return nil
+	}
+	i := slices.IndexFunc(consoleCommands, func(cmd consoleCommand) bool { return cmd.name == name })
+	if i < 0 {
+		return errors.Errorf("unknown command '%s', type 'help' to list commands", name)
+	}
+	cmd := consoleCommands[i]
+	if err := cmd.run(c, args); err != nil {
+		if errors.Is(err, errConsoleUsage) {
+			return errors.Errorf("usage: %s", cmd.usage)
+		}
+		return err
+	}
+	return nil
+}
+
+func (c *console) help() {
+	w := 0
+	for _, cmd := range consoleCommands {
+		w = max(w, len(cmd.usage))
+	}
+	for _, cmd := range consoleCommands {
+		_, _ = fmt.Fprintf(c.out, "  %-*s  %s\n", w, cmd.usage, cmd.help)
+	}
+	_, _ = fmt.Fprintf(c.out, "  %-*s  %s\n", w, "exit", "Quit the console")
+}
+
+func (c *console) get(path string) error {
+	return c.do(http.MethodGet, path, nil)
+}
+
+func (c *console) post(path string, body any) error {
+	return c.do(http.MethodPost, path, body)
+}
+
+// do sends the request and prints the indented JSON response, error responses are returned as errors.
+func (c *console) do(method, path string, body any) error {
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(b)
+	}
+	req, err := http.NewRequest(method, c.baseURL+path, r)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read response")
+	}
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		if uErr := json.Unmarshal(data, &apiErr); uErr == nil && apiErr.Message != "" {
+			return errors.New(apiErr.Message)
+		}
+		return errors.Errorf("node responded with status %s", resp.Status)
+	}
+	var out bytes.Buffer
+	if iErr := json.Indent(&out, data, "", "  "); iErr != nil {
+		out.Reset()
+		out.Write(data)
+	}
+	out.WriteByte('\n')
+	_, err = out.WriteTo(c.out)
+	return err
+}
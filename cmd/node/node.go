@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
 	stderrs "errors"
@@ -13,11 +14,15 @@ import (
 	"net/http/pprof"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -25,6 +30,8 @@ import (
 
 	"github.com/wavesplatform/gowaves/pkg/api"
 	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/extensions"
+	"github.com/wavesplatform/gowaves/pkg/extensions/pgexport"
 	"github.com/wavesplatform/gowaves/pkg/grpc/server"
 	"github.com/wavesplatform/gowaves/pkg/libs/microblock_cache"
 	"github.com/wavesplatform/gowaves/pkg/libs/ntptime"
@@ -35,10 +42,16 @@ import (
 	"github.com/wavesplatform/gowaves/pkg/miner/utxpool"
 	"github.com/wavesplatform/gowaves/pkg/node"
 	"github.com/wavesplatform/gowaves/pkg/node/blocks_applier"
+	"github.com/wavesplatform/gowaves/pkg/node/events"
 	"github.com/wavesplatform/gowaves/pkg/node/messages"
 	"github.com/wavesplatform/gowaves/pkg/node/network"
 	"github.com/wavesplatform/gowaves/pkg/node/peers"
 	peersPersistentStorage "github.com/wavesplatform/gowaves/pkg/node/peers/storage"
+	"github.com/wavesplatform/gowaves/pkg/node/publisher"
+	"github.com/wavesplatform/gowaves/pkg/node/snapshots"
+	"github.com/wavesplatform/gowaves/pkg/node/watchlist"
+	"github.com/wavesplatform/gowaves/pkg/node/webhooks"
+	"github.com/wavesplatform/gowaves/pkg/p2p/conn"
 	"github.com/wavesplatform/gowaves/pkg/p2p/peer"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 	"github.com/wavesplatform/gowaves/pkg/services"
@@ -47,6 +60,7 @@ import (
 	"github.com/wavesplatform/gowaves/pkg/types"
 	"github.com/wavesplatform/gowaves/pkg/util/common"
 	"github.com/wavesplatform/gowaves/pkg/util/fdlimit"
+	"github.com/wavesplatform/gowaves/pkg/util/sdnotify"
 	"github.com/wavesplatform/gowaves/pkg/versioning"
 	"github.com/wavesplatform/gowaves/pkg/wallet"
 )
@@ -60,6 +74,9 @@ const (
 
 const profilerAddr = "localhost:6060"
 
+// apiKeysPasswordEnv is the environment variable with the password of API keys file.
+const apiKeysPasswordEnv = "GOWAVES_API_KEYS_PASSWORD"
+
 const utxPoolMaxSizeBytes = 1024 * mb
 
 var defaultPeers = map[string]string{
@@ -73,24 +90,41 @@ type config struct {
 
 	logLevel                   zapcore.Level
 	logDevelopment             bool
+	logFormat                  logging.Format
 	logNetwork                 bool
 	logNetworkData             bool
 	logFSM                     bool
 	statePath                  string
 	blockchainType             string
 	peerAddresses              string
+	pinnedPeers                string
 	declAddr                   string
 	nodeName                   string
 	cfgPath                    string
+	checkpointsPath            string
 	apiAddr                    string
 	apiKey                     string
 	apiMaxConnections          int
 	rateLimiterOptions         string
+	apiServerOptions           string
+	apiRouteTimeouts           string
+	apiKeyQuotas               string
+	apiKeysFile                string
+	apiKeysPasswordFile        string
+	apiAuditLog                string
+	ipcPath                    string
+	jwtIssuer                  string
+	jwtJWKSURL                 string
+	jwtAudience                string
+	jwtScope                   string
 	grpcAddr                   string
 	grpcAPIMaxConnections      int
 	enableMetaMaskAPI          bool
 	enableMetaMaskAPILog       bool
+	enableExplorer             bool
 	enableGrpcAPI              bool
+	enableGrpcWeb              bool
+	grpcWebAllowedOrigins      string
 	blackListResidenceTime     time.Duration
 	buildExtendedAPI           bool
 	serveExtendedAPI           bool
@@ -98,6 +132,7 @@ type config struct {
 	bindAddress                string
 	disableOutgoingConnections bool
 	minerVoteFeatures          string
+	minerVoteFile              string
 	disableBloomFilter         bool
 	reward                     int64
 	obsolescencePeriod         time.Duration
@@ -105,17 +140,54 @@ type config struct {
 	walletPassword             string
 	limitAllConnections        uint
 	minPeersMining             int
+	maxClockDrift              time.Duration
 	disableMiner               bool
+	minerPackingRules          string
 	profiler                   bool
 	prometheus                 string
 	metricsID                  int
 	metricsURL                 string
 	dropPeers                  bool
 	dbFileDescriptors          uint
+	recentBlocksCacheSize      int
+	recentBlocksCacheBytes     int
 	newConnectionsLimit        int
+	uploadLimit                int
+	downloadLimit              int
+	peerUploadLimit            int
+	peerDownloadLimit          int
 	disableNTP                 bool
 	microblockInterval         time.Duration
 	enableLightMode            bool
+	faucetAccount              string
+	faucetAmount               uint64
+	faucetAddressQuota         int
+	faucetIPQuota              int
+	faucetQuotaPeriod          time.Duration
+	faucetTrustedProxies       string
+	apiShutdownTimeout         time.Duration
+	apiDrainPeriod             time.Duration
+	nodeMode                   string
+	extensionPlugins           string
+	utxAdmissionRules          string
+	utxSenderComplexityLimit   uint64
+	utxTotalComplexityLimit    uint64
+	disableMigrations          bool
+	migrationsBackupDir        string
+	backupDir                  string
+	backupInterval             time.Duration
+	snapshotsDir               string
+	snapshotsEvery             uint64
+	snapshotsKeep              int
+	webhooksFile               string
+	watchlistFile              string
+	watchlistIndexSize         int
+	eventsNATSURL              string
+	eventsSubjectPrefix        string
+	eventsCursorFile           string
+	eventsFromHeight           uint64
+	pgExportDSN                string
+	pgExportFromHeight         uint64
 }
 
 var errConfigNotParsed = stderrs.New("config is not parsed")
@@ -137,37 +209,88 @@ func (c *config) StatePath() (string, error) {
 func (c *config) logParameters() {
 	zap.S().Debugf("log-level: %s", c.logLevel)
 	zap.S().Debugf("log-dev: %t", c.logDevelopment)
+	zap.S().Debugf("log-format: %s", c.logFormat)
 	zap.S().Debugf("log-network: %t", c.logNetwork)
 	zap.S().Debugf("log-fsm: %t", c.logFSM)
 	zap.S().Debugf("state-path: %s", c.statePath)
 	zap.S().Debugf("blockchain-type: %s", c.blockchainType)
+	zap.S().Debugf("checkpoints: %s", c.checkpointsPath)
 	zap.S().Debugf("peers: %s", c.peerAddresses)
+	zap.S().Debugf("pinned-peers: %s", c.pinnedPeers)
 	zap.S().Debugf("declared-address: %s", c.declAddr)
 	zap.S().Debugf("api-address: %s", c.apiAddr)
 	zap.S().Debugf("api-key: %s", crypto.MustKeccak256([]byte(c.apiKey)).Hex())
+	zap.S().Debugf("api-keys-file: %s", c.apiKeysFile)
+	zap.S().Debugf("api-keys-password-file: %s", c.apiKeysPasswordFile)
+	zap.S().Debugf("api-audit-log: %s", c.apiAuditLog)
+	zap.S().Debugf("ipc-path: %s", c.ipcPath)
+	zap.S().Debugf("jwt-issuer: %s", c.jwtIssuer)
+	zap.S().Debugf("jwt-jwks-url: %s", c.jwtJWKSURL)
+	zap.S().Debugf("jwt-audience: %s", c.jwtAudience)
+	zap.S().Debugf("jwt-scope: %s", c.jwtScope)
 	zap.S().Debugf("grpc-address: %s", c.grpcAddr)
 	zap.S().Debugf("enable-grpc-api: %t", c.enableGrpcAPI)
+	zap.S().Debugf("enable-grpc-web: %t", c.enableGrpcWeb)
+	zap.S().Debugf("grpc-web-allowed-origins: %s", c.grpcWebAllowedOrigins)
 	zap.S().Debugf("black-list-residence-time: %s", c.blackListResidenceTime)
 	zap.S().Debugf("build-extended-api: %t", c.buildExtendedAPI)
 	zap.S().Debugf("serve-extended-api: %t", c.serveExtendedAPI)
 	zap.S().Debugf("build-state-hashes: %t", c.buildStateHashes)
 	zap.S().Debugf("bind-address: %s", c.bindAddress)
 	zap.S().Debugf("vote: %s", c.minerVoteFeatures)
+	zap.S().Debugf("vote-file: %s", c.minerVoteFile)
 	zap.S().Debugf("reward: %d", c.reward)
 	zap.S().Debugf("obsolescence: %s", c.obsolescencePeriod)
 	zap.S().Debugf("disable-miner %t", c.disableMiner)
+	zap.S().Debugf("miner-packing-rules: %s", c.minerPackingRules)
 	zap.S().Debugf("wallet-path: %s", c.walletPath)
 	zap.S().Debugf("hashed wallet-password: %s", crypto.MustKeccak256([]byte(c.walletPassword)).Hex())
 	zap.S().Debugf("limit-connections: %d", c.limitAllConnections)
+	zap.S().Debugf("max-clock-drift: %s", c.maxClockDrift)
 	zap.S().Debugf("profiler: %t", c.profiler)
 	zap.S().Debugf("disable-bloom: %t", c.disableBloomFilter)
 	zap.S().Debugf("drop-peers: %t", c.dropPeers)
 	zap.S().Debugf("db-file-descriptors: %v", c.dbFileDescriptors)
+	zap.S().Debugf("recent-blocks-cache-size: %d", c.recentBlocksCacheSize)
+	zap.S().Debugf("recent-blocks-cache-bytes: %d", c.recentBlocksCacheBytes)
 	zap.S().Debugf("new-connections-limit: %v", c.newConnectionsLimit)
+	zap.S().Debugf("upload-limit: %d", c.uploadLimit)
+	zap.S().Debugf("download-limit: %d", c.downloadLimit)
+	zap.S().Debugf("peer-upload-limit: %d", c.peerUploadLimit)
+	zap.S().Debugf("peer-download-limit: %d", c.peerDownloadLimit)
 	zap.S().Debugf("enable-metamask: %t", c.enableMetaMaskAPI)
+	zap.S().Debugf("enable-explorer: %t", c.enableExplorer)
 	zap.S().Debugf("disable-ntp: %t", c.disableNTP)
 	zap.S().Debugf("microblock-interval: %s", c.microblockInterval)
 	zap.S().Debugf("enable-light-mode: %t", c.enableLightMode)
+	zap.S().Debugf("faucet-account: %s", c.faucetAccount)
+	zap.S().Debugf("faucet-amount: %d", c.faucetAmount)
+	zap.S().Debugf("faucet-address-quota: %d", c.faucetAddressQuota)
+	zap.S().Debugf("faucet-ip-quota: %d", c.faucetIPQuota)
+	zap.S().Debugf("faucet-quota-period: %s", c.faucetQuotaPeriod)
+	zap.S().Debugf("faucet-trusted-proxies: %s", c.faucetTrustedProxies)
+	zap.S().Debugf("api-shutdown-timeout: %s", c.apiShutdownTimeout)
+	zap.S().Debugf("api-drain-period: %s", c.apiDrainPeriod)
+	zap.S().Debugf("mode: %s", c.nodeMode)
+	zap.S().Debugf("extension-plugins: %s", c.extensionPlugins)
+	zap.S().Debugf("utx-admission-rules: %s", c.utxAdmissionRules)
+	zap.S().Debugf("utx-sender-complexity-limit: %d", c.utxSenderComplexityLimit)
+	zap.S().Debugf("utx-total-complexity-limit: %d", c.utxTotalComplexityLimit)
+	zap.S().Debugf("disable-migrations: %t", c.disableMigrations)
+	zap.S().Debugf("migrations-backup-dir: %s", c.migrationsBackupDir)
+	zap.S().Debugf("backup-dir: %s", c.backupDir)
+	zap.S().Debugf("backup-interval: %s", c.backupInterval)
+	zap.S().Debugf("snapshots-dir: %s", c.snapshotsDir)
+	zap.S().Debugf("snapshots-every: %d", c.snapshotsEvery)
+	zap.S().Debugf("snapshots-keep: %d", c.snapshotsKeep)
+	zap.S().Debugf("webhooks-file: %s", c.webhooksFile)
+	zap.S().Debugf("watchlist-file: %s", c.watchlistFile)
+	zap.S().Debugf("watchlist-index-size: %d", c.watchlistIndexSize)
+	zap.S().Debugf("events-nats-url: %s", c.eventsNATSURL)
+	zap.S().Debugf("events-subject-prefix: %s", c.eventsSubjectPrefix)
+	zap.S().Debugf("events-cursor-file: %s", c.eventsCursorFile)
+	zap.S().Debugf("events-from-height: %d", c.eventsFromHeight)
+	zap.S().Debugf("pg-export-from-height: %d", c.pgExportFromHeight)
 }
 
 func (c *config) parse() {
@@ -181,11 +304,15 @@ func (c *config) parse() {
 		defaultConnectionsLimit           = 60
 		defaultNewConnectionLimit         = 10
 		defaultMicroblockInterval         = 5 * time.Second
+		defaultMaxClockDrift              = 10 * time.Second
 	)
 	l := zap.LevelFlag("log-level", zapcore.InfoLevel,
 		"Logging level. Supported levels: DEBUG, INFO, WARN, ERROR, FATAL.")
 	flag.BoolVar(&c.logDevelopment, "log-dev", false,
 		"Log with development setup for the logger. Switched off by default.")
+	flag.TextVar(&c.logFormat, "log-format", logging.TextFormat,
+		"Format of log output: 'text' for human-readable lines or 'json' for one JSON object per event with "+
+			"time, level, subsystem, message and fields, suitable for log collectors like Loki or ELK.")
 	flag.BoolVar(&c.logNetwork, "log-network", false,
 		"Log the operation of network stack. Turned off by default.")
 	flag.BoolVar(&c.logNetworkData, "log-network-data", false,
@@ -196,10 +323,17 @@ func (c *config) parse() {
 	flag.StringVar(&c.blockchainType, "blockchain-type", "mainnet", "Blockchain type: mainnet/testnet/stagenet.")
 	flag.StringVar(&c.peerAddresses, "peers", "",
 		"Forces the node to connect to the provided peers. Format: \"ip:port,...,ip:port\".")
+	flag.StringVar(&c.pinnedPeers, "pinned-peers", "",
+		"Peers which connections are always maintained: re-established when lost, not limited, suspended or "+
+			"black listed, and the first to receive new blocks. Format: \"ip:port,...,ip:port\".")
 	flag.StringVar(&c.declAddr, "declared-address", "", "Address to listen on.")
 	flag.StringVar(&c.nodeName, "name", "gowaves", "Node name.")
 	flag.StringVar(&c.cfgPath, "cfg-path", "",
 		"Path to configuration JSON file, only for custom blockchain.")
+	flag.StringVar(&c.checkpointsPath, "checkpoints", "",
+		"Path to JSON file with trusted checkpoints, e.g. [{\"height\":100,\"block_id\":\"...\"}]. "+
+			"Blocks contradicting checkpoints are rejected, signatures of block headers below the last "+
+			"checkpoint are not verified.")
 	flag.StringVar(&c.apiAddr, "api-address", "", "Address for REST API.")
 	flag.StringVar(&c.apiKey, "api-key", "", "Api key.")
 	flag.IntVar(&c.apiMaxConnections, "api-max-connections", api.DefaultMaxConnections,
@@ -207,13 +341,53 @@ func (c *config) parse() {
 	flag.StringVar(&c.rateLimiterOptions, "rate-limiter-opts", "",
 		"Rate limiter options in form of URL query options, e.g. \"cache=1024&rps=10&burst=5\", keys 'cache' - "+
 			"rate limiter cache size in bytes, 'rps' - requests per second, 'burst' - available burst")
+	flag.StringVar(&c.apiServerOptions, "api-server-opts", "",
+		"REST API server options in form of URL query options, e.g. \"read=30s&write=1m&idle=2m&handler=20s\", "+
+			"keys 'read-header', 'read', 'write', 'idle' - timeouts of HTTP server, 'max-header-bytes' - max size of "+
+			"request headers, 'handler' - default timeout of request handlers. Zero duration disables the timeout")
+	flag.StringVar(&c.apiRouteTimeouts, "api-route-timeouts", "",
+		"Semicolon separated list of REST API handler timeouts by path prefix, e.g. \"/blocks/headers/seq=10s;"+
+			"/node/backup=0\". The longest matching prefix overrides the default 'handler' timeout")
+	flag.StringVar(&c.apiKeyQuotas, "api-key-quotas", "",
+		"Semicolon separated list of partners' API keys with rate limits, e.g. \"partner:key?rps=10&burst=20\". "+
+			"Requests with such key in X-API-Key header are limited by the quota of the key, "+
+			"usage per key is available at '/go/api-keys/usage'")
+	flag.StringVar(&c.apiKeysFile, "api-keys-file", "",
+		"Path to the encrypted file to keep API keys added with '/go/api-keys' in. "+
+			"If empty, API keys can't be added or revoked at runtime.")
+	flag.StringVar(&c.apiKeysPasswordFile, "api-keys-password-file", "",
+		"Path to the file with the password to encrypt API keys file with. If empty, the password is taken from "+
+			apiKeysPasswordEnv+" environment variable. The password is required if 'api-keys-file' is set.")
+	flag.StringVar(&c.apiAuditLog, "api-audit-log", "",
+		"Log every request to REST API methods protected by API key to the file, e.g. "+
+			"\"/var/log/gowaves/audit.log?max-size=100&backups=5\", where 'max-size' is the size in megabytes the file "+
+			"is rotated at and 'backups' is the number of rotated files to keep, or \"syslog\" to send the log to "+
+			"the local syslog daemon. Endpoint, fingerprint of the key, source IP and outcome are recorded")
+	flag.StringVar(&c.ipcPath, "ipc-path", "",
+		"Path to the unix socket to serve REST API for the local 'attach' console on. Requests over the socket "+
+			"don't require API key, the socket is accessible only by the user running the node. Disabled if empty.")
+	flag.StringVar(&c.jwtIssuer, "jwt-issuer", "",
+		"Issuer of JWTs accepted as bearer tokens by protected API methods in addition to API keys. "+
+			"Requires 'jwt-jwks-url' flag.")
+	flag.StringVar(&c.jwtJWKSURL, "jwt-jwks-url", "", "HTTPS URL of JSON Web Key Set of JWTs issuer.")
+	flag.StringVar(&c.jwtAudience, "jwt-audience", "", "Audience JWTs must be issued for, if set.")
+	flag.StringVar(&c.jwtScope, "jwt-scope", "", "Scope that must be granted to JWTs, if set.")
 	flag.StringVar(&c.grpcAddr, "grpc-address", "127.0.0.1:7475", "Address for gRPC API.")
 	flag.IntVar(&c.grpcAPIMaxConnections, "grpc-api-max-connections", server.DefaultMaxConnections,
 		"Max number of simultaneous connections for gRPC API.")
 	flag.BoolVar(&c.enableMetaMaskAPI, "enable-metamask", true, "Enables/disables metamask API.")
 	flag.BoolVar(&c.enableMetaMaskAPILog, "enable-metamask-log", false,
 		"Enables/disables metamask API logging.")
+	flag.BoolVar(&c.enableExplorer, "enable-explorer", false,
+		"Serve the block explorer UI at '/explorer/' on the REST API address.")
 	flag.BoolVar(&c.enableGrpcAPI, "enable-grpc-api", false, "Enables/disables gRPC API.")
+	flag.BoolVar(&c.enableGrpcWeb, "enable-grpc-web", false,
+		"Serve gRPC API with gRPC-Web protocol on the REST API address for browser clients. "+
+			"Requires 'enable-grpc-api' flag.")
+	flag.StringVar(&c.grpcWebAllowedOrigins, "grpc-web-allowed-origins", "",
+		"Comma separated list of origins of web pages allowed to make gRPC-Web requests, "+
+			"e.g. 'https://dapp.example.com'. Use '*' to allow any origin. "+
+			"Pages served from the node's REST API address are always allowed.")
 	flag.DurationVar(&c.blackListResidenceTime, "blacklist-residence-time", defaultBlacklistResidenceDuration,
 		"Period of time for which the information about external peer stays in the blacklist. "+
 			"Default value is 5 min. To disable blacklisting pass zero value.")
@@ -232,6 +406,9 @@ func (c *config) parse() {
 		"Disable outgoing network connections to known peers."+
 			"This flag DOES NOT disable outgoing connections to peers from the 'peers' option.")
 	flag.StringVar(&c.minerVoteFeatures, "vote", "", "Miner vote features.")
+	flag.StringVar(&c.minerVoteFile, "vote-file", "",
+		"Path to JSON file with features the miner votes for and against, e.g. {\"support\":[19],\"against\":[20]}. "+
+			"Overrides '-vote' flag. File is reloaded on SIGHUP signal.")
 	flag.BoolVar(&c.disableBloomFilter, "disable-bloom", false,
 		"Disable bloom filter. Less memory usage, but decrease performance.")
 	flag.Int64Var(&c.reward, "reward", 0, "Miner reward: for example 600000000.")
@@ -243,7 +420,15 @@ func (c *config) parse() {
 		"Total limit of network connections, both inbound and outbound. Divided in half to limit each direction.")
 	flag.IntVar(&c.minPeersMining, "min-peers-mining", 1,
 		"Minimum connected peers for allow mining.")
+	flag.DurationVar(&c.maxClockDrift, "max-clock-drift", defaultMaxClockDrift,
+		"Maximal offset of the local clock from NTP time, exceeding which mining is not allowed. Zero disables the check.")
 	flag.BoolVar(&c.disableMiner, "disable-miner", false, "Disable miner.")
+	flag.StringVar(&c.minerPackingRules, "miner-packing-rules", "",
+		"Path to JSON file with rules of selection of transactions into own blocks: 'excludedSenders' - list of "+
+			"addresses, transactions from which are not packed, 'excludedDApps' - list of dApps, invocations of "+
+			"which are not packed, 'minFeeMultiplier' - multiplier of the minimal base fee in Waves, "+
+			"'minFeeMultipliers' - multipliers by numeric transaction types. Transactions that don't match "+
+			"the rules are still accepted to UTX pool and relayed.")
 	flag.BoolVar(&c.profiler, "profiler", false,
 		fmt.Sprintf("Start built-in profiler on 'http://%s/debug/pprof/'.", profilerAddr))
 	flag.StringVar(&c.prometheus, "prometheus", "",
@@ -256,15 +441,95 @@ func (c *config) parse() {
 		"Drop peers storage before node start.")
 	flag.UintVar(&c.dbFileDescriptors, "db-file-descriptors", uint(state.DefaultOpenFilesCacheCapacity), // #nosec:G115
 		"Maximum allowed file descriptors count that will be used by state database.")
+	flag.IntVar(&c.recentBlocksCacheSize, "recent-blocks-cache-size", state.DefaultRecentBlocksCacheSize,
+		"Number of the most recent blocks kept decoded in memory, 0 disables the cache.")
+	flag.IntVar(&c.recentBlocksCacheBytes, "recent-blocks-cache-bytes", state.DefaultRecentBlocksCacheBytes,
+		"Estimated memory limit in bytes of the recent blocks cache, 0 means no limit.")
 	flag.IntVar(&c.newConnectionsLimit, "new-connections-limit", defaultNewConnectionLimit,
 		"Number of new outbound connections established simultaneously, defaults to 10. Should be positive. "+
 			"Big numbers can badly affect file descriptors consumption.")
+	flag.IntVar(&c.uploadLimit, "upload-limit", 0,
+		"Limit of the total network upload in bytes per second, 0 means no limit.")
+	flag.IntVar(&c.downloadLimit, "download-limit", 0,
+		"Limit of the total network download in bytes per second, 0 means no limit.")
+	flag.IntVar(&c.peerUploadLimit, "peer-upload-limit", 0,
+		"Limit of the network upload to a single peer in bytes per second, 0 means no limit.")
+	flag.IntVar(&c.peerDownloadLimit, "peer-download-limit", 0,
+		"Limit of the network download from a single peer in bytes per second, 0 means no limit. "+
+			"Messages not received in 15 seconds break the connection, so the limit shouldn't be too low.")
 	flag.BoolVar(&c.disableNTP, "disable-ntp", false,
 		"Disable NTP synchronization. Useful when running the node in a docker container.")
 	flag.DurationVar(&c.microblockInterval, "microblock-interval", defaultMicroblockInterval,
 		"Interval between microblocks.")
 	flag.BoolVar(&c.enableLightMode, "enable-light-mode", false,
 		"Start node in light mode")
+	flag.StringVar(&c.faucetAccount, "faucet-account", "",
+		"Address of the wallet account used by the faucet. Enables faucet API on non-MainNet networks.")
+	flag.Uint64Var(&c.faucetAmount, "faucet-amount", api.DefaultFaucetAmount,
+		"Amount of wavelets dispensed by the faucet per request.")
+	flag.IntVar(&c.faucetAddressQuota, "faucet-address-quota", api.DefaultFaucetAddressQuota,
+		"Number of faucet dispenses allowed per recipient address during the quota period. Zero disables the limit.")
+	flag.IntVar(&c.faucetIPQuota, "faucet-ip-quota", api.DefaultFaucetIPQuota,
+		"Number of faucet dispenses allowed per IP address during the quota period. Zero disables the limit.")
+	flag.DurationVar(&c.faucetQuotaPeriod, "faucet-quota-period", api.DefaultFaucetQuotaPeriod,
+		"Period of time for faucet quotas.")
+	flag.StringVar(&c.faucetTrustedProxies, "faucet-trusted-proxies", "",
+		"Comma separated list of IP addresses and subnets of reverse proxies which forwarding headers are trusted "+
+			"to get the client IP address for faucet quotas. By default the address of the connection is used.")
+	flag.DurationVar(&c.apiShutdownTimeout, "api-shutdown-timeout", api.DefaultShutdownTimeout,
+		"Time given to REST and gRPC APIs to finish in-flight requests on node shutdown.")
+	flag.DurationVar(&c.apiDrainPeriod, "api-drain-period", api.DefaultDrainPeriod,
+		"Time on node shutdown during which REST API doesn't accept new connections and answers requests on open "+
+			"connections with 503 status and 'Connection: close' header before the server is stopped.")
+	flag.StringVar(&c.nodeMode, "mode", string(settings.FullNodeMode),
+		"Node operating mode: 'full' - all features enabled, 'api' - mining and wallet endpoints are disabled, "+
+			"'validator' - only node status and API key protected endpoints are served, gRPC API is disabled.")
+	flag.StringVar(&c.extensionPlugins, "extension-plugins", "",
+		"Comma separated list of paths to Go plugins with node extensions.")
+	flag.StringVar(&c.utxAdmissionRules, "utx-admission-rules", "",
+		"Path to JSON file with rules of transactions admission to UTX pool: 'rejectedAddresses' - list of "+
+			"addresses, transactions from or to which are rejected, 'rejectedDApps' - list of dApps, invocations "+
+			"of which are rejected, 'minFeeMultiplier' - multiplier of the minimal base fee in Waves.")
+	flag.Uint64Var(&c.utxSenderComplexityLimit, "utx-sender-complexity-limit", 0,
+		"Limit of cumulative estimated complexity of scripts of one sender's transactions in UTX pool. "+
+			"Zero disables the limit.")
+	flag.Uint64Var(&c.utxTotalComplexityLimit, "utx-total-complexity-limit", 0,
+		"Limit of cumulative estimated complexity of scripts of all transactions in UTX pool. Zero disables the limit.")
+	flag.BoolVar(&c.disableMigrations, "disable-migrations", false,
+		"Fail on start if the state has an outdated storage version instead of migrating it.")
+	flag.StringVar(&c.migrationsBackupDir, "migrations-backup-dir", "",
+		"Path to an empty directory to store a copy of the state database into before migrations are applied.")
+	flag.StringVar(&c.backupDir, "backup-dir", "",
+		"Path to the directory to write scheduled state backups into, each backup is written to a new subdirectory.")
+	flag.DurationVar(&c.backupInterval, "backup-interval", 0,
+		"Interval between scheduled state backups, zero disables them. Requires 'backup-dir' to be set.")
+	flag.StringVar(&c.snapshotsDir, "snapshots-dir", "",
+		"Path to the directory to keep periodic state snapshots in. Requires 'snapshots-every' to be set.")
+	flag.Uint64Var(&c.snapshotsEvery, "snapshots-every", 0,
+		"Make a verified state snapshot each time the height reaches a multiple of the given number of blocks, "+
+			"zero disables snapshots.")
+	flag.IntVar(&c.snapshotsKeep, "snapshots-keep", snapshots.DefaultKeep,
+		"Number of the most recent state snapshots to keep, older ones are removed.")
+	flag.StringVar(&c.webhooksFile, "webhooks-file", "",
+		"Path to the file to keep the webhooks registered with API in. If empty, webhooks are lost on restart.")
+	flag.StringVar(&c.watchlistFile, "watchlist-file", "",
+		"Path to the file to keep the watched addresses registered with API in. If empty, they are lost on restart.")
+	flag.IntVar(&c.watchlistIndexSize, "watchlist-index-size", watchlist.DefaultIndexSize,
+		"Number of the most recent transactions of each watched address kept in memory.")
+	flag.StringVar(&c.eventsNATSURL, "events-nats-url", "",
+		"URL of the NATS server to publish blocks, transactions, state changes and rollbacks to. "+
+			"Empty value disables publishing.")
+	flag.StringVar(&c.eventsSubjectPrefix, "events-subject-prefix", publisher.DefaultPrefix,
+		"Prefix of the subjects blockchain updates are published to.")
+	flag.StringVar(&c.eventsCursorFile, "events-cursor-file", "",
+		"Path to the file to keep the height of the last published block in to resume publishing after restart.")
+	flag.Uint64Var(&c.eventsFromHeight, "events-from-height", 0,
+		"Height to start publishing from if there is no cursor file, zero means the next applied block.")
+	flag.StringVar(&c.pgExportDSN, "pg-export-dsn", "",
+		"PostgreSQL connection string to export blocks, transactions, transfers and invoke results to. "+
+			"Empty value disables the export.")
+	flag.Uint64Var(&c.pgExportFromHeight, "pg-export-from-height", 1,
+		"Height to start the export to PostgreSQL from if the database is empty.")
 	flag.Parse()
 	c.logLevel = *l
 }
@@ -272,6 +537,7 @@ func (c *config) parse() {
 func loggerSetup(nc *config) func() {
 	logger := logging.SetupLogger(nc.logLevel,
 		logging.DevelopmentFlag(nc.logDevelopment),
+		logging.OutputFormat(nc.logFormat),
 		logging.NetworkFilter(nc.logNetwork),
 		logging.NetworkDataFilter(nc.logNetworkData),
 		logging.FSMFilter(nc.logFSM),
@@ -294,19 +560,63 @@ func main() {
 }
 
 func realMain() int {
+	if len(os.Args) > 1 && os.Args[1] == attachCommand {
+		return attach(os.Args[2:])
+	}
 	nc := new(config)
 	nc.parse()
 	syncFn := loggerSetup(nc)
 	defer syncFn()
-	err := run(nc)
+	ctl := new(nodeControl)
+	err := run(nc, ctl)
 	if err != nil {
 		zap.S().Errorf("Failed to run: %v", err)
 		return 1
 	}
+	if ctl.restart.Load() {
+		zap.S().Info("Restarting the node...")
+		syncFn()
+		if rErr := restartProcess(); rErr != nil {
+			zap.S().Errorf("Failed to restart: %v", rErr)
+			return 1
+		}
+	}
 	return 0
 }
 
-func run(nc *config) (retErr error) {
+// nodeControl implements api.NodeControl. It triggers the same graceful shutdown as the termination signal does.
+type nodeControl struct {
+	cancel  context.CancelFunc
+	once    sync.Once
+	restart atomic.Bool
+}
+
+func (c *nodeControl) Stop(delay time.Duration) {
+	c.schedule(delay, false)
+}
+
+func (c *nodeControl) Restart(delay time.Duration) {
+	c.schedule(delay, true)
+}
+
+// schedule plans the shutdown after the delay, only the first request is taken into account.
+func (c *nodeControl) schedule(delay time.Duration, restart bool) {
+	c.once.Do(func() {
+		c.restart.Store(restart)
+		time.AfterFunc(delay, c.cancel)
+	})
+}
+
+// restartProcess replaces the current process with a new instance of the node started with the same arguments.
+func restartProcess() error {
+	exe, err := os.Executable()
+	if err != nil {
+		return errors.Wrap(err, "failed to get executable path")
+	}
+	return syscall.Exec(exe, os.Args, os.Environ())
+}
+
+func run(nc *config, ctl *nodeControl) (retErr error) {
 	errg, ctx := errgroup.WithContext(context.Background())
 	defer func() {
 		if wErr := errg.Wait(); !errors.Is(wErr, context.Canceled) {
@@ -315,6 +625,7 @@ func run(nc *config) (retErr error) {
 	}()
 	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
 	defer cancel()
+	ctl.cancel = cancel
 
 	if nc.profiler {
 		errg.Go(func() error {
@@ -329,7 +640,11 @@ func run(nc *config) (retErr error) {
 		})
 	}
 
-	zap.S().Infof("Gowaves Node version: %s", versioning.Version)
+	bi := versioning.Info()
+	zap.S().Infof("Gowaves Node version: %s (commit: %s, build date: %s, %s)",
+		bi.Version, bi.Commit, bi.BuildDate, bi.GoVersion)
+	zap.S().Debugf("Hash implementations: Blake2b %s, Keccak256 %s",
+		crypto.Blake2bBackend(), crypto.Keccak256Backend())
 
 	nc.logParameters() // print all parsed parameters
 
@@ -338,7 +653,7 @@ func run(nc *config) (retErr error) {
 	}
 
 	if nc.metricsURL != "" && nc.metricsID != -1 {
-		err := metrics.Start(ctx, nc.metricsID, nc.metricsURL)
+		err := metrics.Start(ctx, nc.metricsID, nc.metricsURL, nc.blockchainType, bi.Version)
 		if err != nil {
 			zap.S().Warnf("Metrics reporting failed to start: %v", err)
 			zap.S().Warn("Proceeding without reporting any metrics")
@@ -347,13 +662,25 @@ func run(nc *config) (retErr error) {
 		}
 	}
 
-	nodeCloser, err := runNode(ctx, nc)
+	if nc.pgExportDSN != "" {
+		extensions.Register(pgexport.New(pgexport.Config{DSN: nc.pgExportDSN, FromHeight: nc.pgExportFromHeight}))
+	}
+	if err := loadExtensionPlugins(nc.extensionPlugins); err != nil {
+		return errors.Wrap(err, "failed to load extensions")
+	}
+
+	nodeCloser, err := runNode(ctx, nc, ctl)
 	if err != nil {
 		return errors.Wrap(err, "failed to run node")
 	}
 
 	<-ctx.Done()
 	zap.S().Info("User termination in progress...")
+	if ctl.restart.Load() {
+		sdnotify.NotifyOrLog(sdnotify.Reloading + "\n" + sdnotify.Status("Restarting"))
+	} else {
+		sdnotify.NotifyOrLog(sdnotify.Stopping + "\n" + sdnotify.Status("Stopping"))
+	}
 	defer func() { <-time.After(1 * time.Second) }() // give some time to close internal node processes
 	if clErr := nodeCloser.Close(); clErr != nil {
 		return errors.Wrap(clErr, "failed to close node")
@@ -361,7 +688,20 @@ func run(nc *config) (retErr error) {
 	return nil
 }
 
-func runNode(ctx context.Context, nc *config) (_ io.Closer, retErr error) {
+func loadExtensionPlugins(paths string) error {
+	if paths == "" {
+		return nil
+	}
+	for _, path := range strings.Split(paths, ",") {
+		if err := extensions.LoadPlugin(strings.TrimSpace(path)); err != nil {
+			return err
+		}
+	}
+	zap.S().Infof("Registered extensions: %s", strings.Join(extensions.Registered(), ", "))
+	return nil
+}
+
+func runNode(ctx context.Context, nc *config, ctl api.NodeControl) (_ io.Closer, retErr error) {
 	cfg, err := blockchainSettings(nc)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get blockchain settings")
@@ -392,16 +732,29 @@ func runNode(ctx context.Context, nc *config) (_ io.Closer, retErr error) {
 		return nil, errors.Wrap(err, "failed to create state parameters")
 	}
 
+	sdnotify.NotifyOrLog(sdnotify.Status("Opening state"))
 	st, err := state.NewState(path, true, params, cfg, nc.enableLightMode)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to initialize node's state")
 	}
 	defer func() { retErr = closeIfErrorf(st, retErr, "failed to close state") }()
+	if nc.prometheus != "" {
+		prometheus.MustRegister(state.NewStorageCollector(st))
+	}
 
-	features, err := minerFeatures(st, nc.minerVoteFeatures)
+	votes, err := minerVotes(st, nc)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to parse and validate miner features")
 	}
+	if nc.minerVoteFile != "" {
+		go reloadMinerVotesOnSignal(ctx, st, votes, nc.minerVoteFile)
+	}
+	if nc.backupInterval > 0 {
+		if nc.backupDir == "" {
+			return nil, errors.New("'backup-dir' must be set to make scheduled backups")
+		}
+		go runScheduledBackups(ctx, st, nc.backupDir, nc.backupInterval)
+	}
 
 	// Check if we need to start serving extended API right now.
 	if eapiErr := node.MaybeEnableExtendedApi(st, ntpTime); eapiErr != nil {
@@ -418,7 +771,7 @@ func runNode(ctx context.Context, nc *config) (_ io.Closer, retErr error) {
 	defer func() { retErr = closeIfErrorf(peerManager, retErr, "failed to close peer manager") }()
 	go peerManager.Run(ctx)
 
-	minerScheduler, err := newMinerScheduler(nc, st, wal, cfg, ntpTime, peerManager)
+	minerScheduler, err := newMinerScheduler(nc, conf.Mode, st, wal, cfg, ntpTime, peerManager)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to initialize miner scheduler")
 	}
@@ -428,34 +781,108 @@ func runNode(ctx context.Context, nc *config) (_ io.Closer, retErr error) {
 		return nil, errors.Wrap(err, "failed to create services")
 	}
 
+	if eErr := extensions.Init(ctx, svs); eErr != nil {
+		return nil, errors.Wrap(eErr, "failed to initialize extensions")
+	}
+	go extensions.Run(ctx, svs.Events)
+	go svs.Webhooks.Run(ctx, svs.Events)
+	go svs.Watchlist.Run(ctx, svs.Events)
+
+	if nc.snapshotsEvery > 0 {
+		snapshotsCfg := snapshots.Config{Dir: nc.snapshotsDir, Every: nc.snapshotsEvery, Keep: nc.snapshotsKeep}
+		maker, mErr := snapshots.NewMaker(snapshotsCfg, st, cfg, params)
+		if mErr != nil {
+			return nil, errors.Wrap(mErr, "failed to initialize state snapshots")
+		}
+		go maker.Run(ctx, svs.Events)
+	}
+
+	if nc.eventsNATSURL != "" {
+		if pErr := runPublisher(ctx, nc, st, svs); pErr != nil {
+			return nil, errors.Wrap(pErr, "failed to initialize events publisher")
+		}
+	}
+
 	app, err := api.NewApp(nc.apiKey, minerScheduler, svs)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to initialize application")
 	}
+	app.SetMaxClockDrift(nc.maxClockDrift)
+	if nc.apiKeysFile != "" {
+		password, pErr := readAPIKeysPassword(nc.apiKeysPasswordFile)
+		if pErr != nil {
+			return nil, errors.Wrap(pErr, "failed to initialize API keys")
+		}
+		if kErr := app.SetAPIKeysStorage(nc.apiKeysFile, password); kErr != nil {
+			return nil, errors.Wrap(kErr, "failed to initialize API keys")
+		}
+	}
 
 	if pErr := spawnPeersByAddresses(ctx, conf.Addresses, peerManager); pErr != nil {
 		return nil, errors.Wrap(pErr, "failed to spawn peers by addresses")
 	}
+	if pErr := pinPeersByAddresses(nc.pinnedPeers, peerManager); pErr != nil {
+		return nil, errors.Wrap(pErr, "failed to pin peers by addresses")
+	}
 
-	if apiErr := runAPIs(ctx, nc, conf, app, svs); apiErr != nil {
+	apisDone, apiErr := runAPIs(ctx, nc, conf, app, svs, ctl)
+	if apiErr != nil {
 		return nil, errors.Wrap(apiErr, "failed to run APIs")
 	}
 
-	return startNode(ctx, nc, svs, features, minerScheduler, parent, declAddr), nil
+	n := startNode(ctx, nc, svs, votes, minerScheduler, parent, declAddr)
+	notifyStarted(ctx, st)
+	return &shutdownSequence{apisDone: apisDone, node: n}, nil
+}
+
+// notifyStarted tells systemd that the node is started and runs the watchdog if it's enabled for the service.
+// The node is considered alive while its state responds to requests.
+func notifyStarted(ctx context.Context, st state.StateInfo) {
+	sdnotify.NotifyOrLog(sdnotify.Ready + "\n" + sdnotify.Status("Running"))
+	interval, err := sdnotify.WatchdogInterval()
+	if err != nil {
+		zap.S().Warnf("Systemd watchdog is disabled: %v", err)
+		return
+	}
+	if interval == 0 {
+		return
+	}
+	zap.S().Infof("Systemd watchdog is enabled with timeout %s", interval)
+	go sdnotify.RunWatchdog(ctx, interval, func() error {
+		_, hErr := st.Height()
+		return hErr
+	})
+}
+
+// shutdownSequence closes node's subsystems in order. APIs are drained first, so no new requests
+// reach the node, then the node is halted, which closes the peer connections and the state storage.
+// The network protocol has no farewell message, so peers just see the connections closed. Unconfirmed
+// transactions are not persisted and are dropped with the UTX pool. The state is flushed after each applied
+// batch of blocks, so there are no pending writes to flush on halt.
+type shutdownSequence struct {
+	apisDone <-chan struct{}
+	node     io.Closer
+}
+
+func (s *shutdownSequence) Close() error {
+	zap.S().Info("Waiting for APIs to finish in-flight requests...")
+	<-s.apisDone
+	zap.S().Info("Halting the node...")
+	return s.node.Close()
 }
 
 func startNode(
 	ctx context.Context,
 	nc *config,
 	svs services.Services,
-	features miner.Features,
+	votes *miner.FeatureVotes,
 	minerScheduler Scheduler,
 	parent peer.Parent,
 	declAddr proto.TCPAddr,
 ) *node.Node {
 	bindAddr := proto.NewTCPAddrFromString(nc.bindAddress)
 
-	mine := miner.NewMicroblockMiner(svs, features, nc.reward)
+	mine := miner.NewMicroblockMiner(svs, votes, nc.reward)
 	go miner.Run(ctx, mine, minerScheduler, svs.InternalChannel)
 
 	ntw, networkInfoCh := network.NewNetwork(svs, parent, nc.obsolescencePeriod)
@@ -486,7 +913,37 @@ func raiseToMaxFDs(nc *config) error {
 	return nil
 }
 
-func blockchainSettings(nc *config) (_ *settings.BlockchainSettings, retErr error) {
+func blockchainSettings(nc *config) (*settings.BlockchainSettings, error) {
+	cfg, err := readBlockchainSettings(nc)
+	if err != nil {
+		return nil, err
+	}
+	if nc.checkpointsPath == "" {
+		return cfg, nil
+	}
+	cps, err := readCheckpoints(nc.checkpointsPath)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Checkpoints = cfg.Checkpoints.Merge(cps)
+	zap.S().Infof("Loaded %d checkpoints, the last one at height %d", len(cps), cfg.Checkpoints.LastHeight())
+	return cfg, nil
+}
+
+func readCheckpoints(path string) (_ settings.Checkpoints, retErr error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open checkpoints file")
+	}
+	defer func() {
+		if clErr := f.Close(); clErr != nil {
+			retErr = stderrs.Join(retErr, errors.Wrap(clErr, "failed to close checkpoints file"))
+		}
+	}()
+	return settings.ReadCheckpoints(io.LimitReader(f, mb))
+}
+
+func readBlockchainSettings(nc *config) (_ *settings.BlockchainSettings, retErr error) {
 	if nc.cfgPath == "" {
 		cfg, err := settings.BlockchainSettingsByTypeName(nc.blockchainType)
 		if err != nil {
@@ -524,6 +981,10 @@ func stateParams(nc *config, ntpTime types.Time) (state.StateParams, error) {
 	params.BuildStateHashes = nc.buildStateHashes
 	params.Time = ntpTime
 	params.DbParams.DisableBloomFilter = nc.disableBloomFilter
+	params.RecentBlocksCacheSize = nc.recentBlocksCacheSize
+	params.RecentBlocksCacheBytes = nc.recentBlocksCacheBytes
+	params.DisableMigrations = nc.disableMigrations
+	params.MigrationsBackupDir = nc.migrationsBackupDir
 	return params, nil
 }
 
@@ -596,17 +1057,25 @@ func runPrometheusMetricsServer(ctx context.Context, prometheusAddr string) <-ch
 	return done
 }
 
-func runGRPCServer(ctx context.Context, addr string, nc *config, svs services.Services) error {
+func runGRPCServer(
+	ctx context.Context, addr string, nc *config, svs services.Services,
+	checkKey server.APIKeyChecker, checkToken server.BearerTokenChecker,
+) (*server.Server, <-chan struct{}, error) {
 	srv, srvErr := server.NewServer(svs)
 	if srvErr != nil {
-		return errors.Wrap(srvErr, "failed to create gRPC server")
+		return nil, nil, errors.Wrap(srvErr, "failed to create gRPC server")
 	}
+	srv.SetAPIKeyChecker(checkKey)
+	srv.SetBearerTokenChecker(checkToken)
+	extensions.RegisterGRPCServices(srv)
+	done := make(chan struct{})
 	go func() {
+		defer close(done)
 		if runErr := srv.Run(ctx, addr, grpcAPIRunOptsFromCLIFlags(nc)); runErr != nil {
 			zap.S().Errorf("grpcServer.Run(): %v", runErr)
 		}
 	}()
-	return nil
+	return srv, done, nil
 }
 
 func nodeSettings(nc *config, scheme proto.Scheme) (*settings.NodeSettings, error) {
@@ -659,18 +1128,44 @@ func spawnPeersByAddresses(ctx context.Context, addressesByComma string, pm *pee
 	return nil
 }
 
+func pinPeersByAddresses(addressesByComma string, pm *peers.PeerManagerImpl) error {
+	if addressesByComma == "" {
+		return nil
+	}
+	for _, addr := range strings.Split(addressesByComma, ",") {
+		peerInfos, err := proto.NewPeerInfosFromString(addr)
+		if err != nil {
+			return errors.Wrapf(err, "failed to resolve TCP addresses from string %q", addr)
+		}
+		for _, pi := range peerInfos {
+			tcpAddr := proto.NewTCPAddr(pi.Addr, int(pi.Port))
+			if tcpAddr.Empty() {
+				return errors.Errorf("failed to create TCP address from IP %q and port %d",
+					fmt.Stringer(pi.Addr), pi.Port,
+				)
+			}
+			pm.PinTransient(tcpAddr)
+		}
+	}
+	return nil
+}
+
 func newMinerScheduler(
 	nc *config,
+	mode settings.NodeMode,
 	st state.State,
 	wal types.EmbeddedWallet,
 	cfg *settings.BlockchainSettings,
 	ntpTime types.Time,
 	peerManager peers.PeerManager,
 ) (Scheduler, error) {
-	if nc.disableMiner {
+	if nc.disableMiner || !mode.MiningAllowed() {
 		return scheduler.DisabledScheduler{}, nil
 	}
-	consensus := scheduler.NewMinerConsensus(peerManager, nc.minPeersMining)
+	var consensus types.MinerConsensus = scheduler.NewMinerConsensus(peerManager, nc.minPeersMining)
+	if clock, ok := ntpTime.(types.ClockDrift); ok && nc.maxClockDrift > 0 {
+		consensus = scheduler.NewClockDriftGuard(consensus, clock, nc.maxClockDrift)
+	}
 	ms, err := scheduler.NewScheduler(st, wal, cfg, ntpTime, consensus, nc.obsolescencePeriod)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to initialize miner scheduler")
@@ -678,8 +1173,18 @@ func newMinerScheduler(
 	return ms, nil
 }
 
-func minerFeatures(st state.State, minerVoteFeaturesByComma string) (miner.Features, error) {
-	features, err := miner.ParseVoteFeatures(minerVoteFeaturesByComma)
+func minerVotes(st state.State, nc *config) (*miner.FeatureVotes, error) {
+	if nc.minerVoteFile != "" {
+		if nc.minerVoteFeatures != "" {
+			zap.S().Warn("'-vote' flag is ignored because '-vote-file' is set")
+		}
+		support, against, err := miner.LoadFeatureVotes(st, nc.minerVoteFile)
+		if err != nil {
+			return nil, err
+		}
+		return miner.NewFeatureVotes(support, against)
+	}
+	features, err := miner.ParseVoteFeatures(nc.minerVoteFeatures)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to parse '-vote'")
 	}
@@ -688,7 +1193,49 @@ func minerFeatures(st state.State, minerVoteFeaturesByComma string) (miner.Featu
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to validate features")
 	}
-	return features, nil
+	return miner.NewFeatureVotes(features, nil)
+}
+
+// reloadMinerVotesOnSignal replaces the miner's voting configuration with the content of the file on SIGHUP.
+func reloadMinerVotesOnSignal(ctx context.Context, st state.State, votes *miner.FeatureVotes, path string) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGHUP)
+	defer signal.Stop(ch)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ch:
+			support, against, err := miner.LoadFeatureVotes(st, path)
+			if err != nil {
+				zap.S().Errorf("Failed to reload miner votes: %v", err)
+				continue
+			}
+			if err = votes.Set(support, against); err != nil {
+				zap.S().Errorf("Failed to reload miner votes: %v", err)
+				continue
+			}
+			zap.S().Infof("Miner votes reloaded: support %v, against %v", votes.Support(), votes.Against())
+		}
+	}
+}
+
+// runScheduledBackups writes state backups to new subdirectories of dir every interval.
+func runScheduledBackups(ctx context.Context, st state.State, dir string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			path := filepath.Join(dir, "backup-"+now.UTC().Format("20060102-150405"))
+			zap.S().Infof("Starting scheduled state backup to '%s'", path)
+			if err := st.Backup(path); err != nil {
+				zap.S().Errorf("Scheduled state backup failed: %v", err)
+			}
+		}
+	}
 }
 
 func closeIfErrorf(closer io.Closer, retErr error, format string, args ...interface{}) error {
@@ -718,6 +1265,10 @@ func createPeerManager(
 		nc.nodeName,
 		nodeNonce.Uint64(),
 		proto.ProtocolVersion(),
+		conn.NewThrottle(
+			conn.BandwidthLimits{Upload: nc.uploadLimit, Download: nc.downloadLimit},
+			conn.BandwidthLimits{Upload: nc.peerUploadLimit, Download: nc.peerDownloadLimit},
+		),
 	)
 	peerStorage, err := peersPersistentStorage.NewCBORStorage(nc.statePath, time.Now())
 	if err != nil {
@@ -751,16 +1302,48 @@ func createServices(
 	parent peer.Parent,
 	scheduler Scheduler,
 ) (services.Services, error) {
-	utxValidator, err := utxpool.NewValidator(st, ntpTime, nc.obsolescencePeriod)
+	stateValidator, err := utxpool.NewValidator(st, ntpTime, nc.obsolescencePeriod)
 	if err != nil {
 		return services.Services{}, errors.Wrap(err, "failed to initialize UTX")
 	}
+	var utxValidator utxpool.Validator = stateValidator
+	if nc.utxAdmissionRules != "" {
+		rules, rErr := readAdmissionRules(nc.utxAdmissionRules)
+		if rErr != nil {
+			return services.Services{}, rErr
+		}
+		utxValidator = utxpool.NewAdmissionValidator(utxValidator, rules, st, cfg.AddressSchemeCharacter)
+	}
+	utx := utxpool.New(utxPoolMaxSizeBytes, utxValidator, cfg)
+	metrics.WatchUtx(utx)
+	if nc.utxSenderComplexityLimit > 0 || nc.utxTotalComplexityLimit > 0 {
+		utx.SetComplexityLimits(utxpool.NewStateComplexityEstimator(st, cfg.AddressSchemeCharacter),
+			utxpool.ComplexityLimits{PerSender: nc.utxSenderComplexityLimit, Total: nc.utxTotalComplexityLimit})
+	}
+	bus := events.NewBus()
+	applier := blocks_applier.NewBlocksApplier()
+	hooks, err := webhooks.NewManager(nc.webhooksFile, st, cfg.AddressSchemeCharacter)
+	if err != nil {
+		return services.Services{}, errors.Wrap(err, "failed to initialize webhooks")
+	}
+	watched, err := watchlist.New(nc.watchlistFile, st, cfg.AddressSchemeCharacter, nc.watchlistIndexSize)
+	if err != nil {
+		return services.Services{}, errors.Wrap(err, "failed to initialize watch list")
+	}
+	var minerFilter services.TransactionFilter
+	if nc.minerPackingRules != "" {
+		rules, rErr := readPackingRules(nc.minerPackingRules)
+		if rErr != nil {
+			return services.Services{}, rErr
+		}
+		minerFilter = miner.NewPackingFilter(rules, cfg.AddressSchemeCharacter)
+	}
 	return services.Services{
-		State:           st,
+		State:           events.NewNotifyingState(st, bus),
 		Peers:           peerManager,
 		Scheduler:       scheduler,
-		BlocksApplier:   blocks_applier.NewBlocksApplier(),
-		UtxPool:         utxpool.New(utxPoolMaxSizeBytes, utxValidator, cfg),
+		BlocksApplier:   applier,
+		UtxPool:         utx,
 		Scheme:          cfg.AddressSchemeCharacter,
 		Time:            ntpTime,
 		Wallet:          wal,
@@ -768,30 +1351,176 @@ func createServices(
 		InternalChannel: messages.NewInternalChannel(),
 		MinPeersMining:  nc.minPeersMining,
 		SkipMessageList: parent.SkipMessageList,
+		Events:          bus,
+		Forks:           applier.Forks(),
+		Webhooks:        hooks,
+		Watchlist:       watched,
+		MinerFilter:     minerFilter,
 	}, nil
 }
 
+func runPublisher(ctx context.Context, nc *config, st state.State, svs services.Services) error {
+	cfg := publisher.Config{
+		Prefix:     nc.eventsSubjectPrefix,
+		CursorFile: nc.eventsCursorFile,
+		FromHeight: nc.eventsFromHeight,
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	sink, err := publisher.NewNATSSink(ctx, nc.eventsNATSURL, cfg.Prefix)
+	if err != nil {
+		return err
+	}
+	p, err := publisher.New(cfg, st, sink, svs.Scheme)
+	if err != nil {
+		_ = sink.Close()
+		return err
+	}
+	go p.Run(ctx, svs.Events)
+	return nil
+}
+
+func readAdmissionRules(path string) (*utxpool.AdmissionRules, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open UTX admission rules file")
+	}
+	defer func() { _ = f.Close() }()
+	return utxpool.ReadAdmissionRules(f)
+}
+
+func readPackingRules(path string) (*miner.PackingRules, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open miner packing rules file")
+	}
+	defer func() { _ = f.Close() }()
+	return miner.ReadPackingRules(f)
+}
+
+// runAPIs starts REST and gRPC APIs. The returned channel is closed when all APIs are stopped.
 func runAPIs(
 	ctx context.Context,
 	nc *config,
 	conf *settings.NodeSettings,
 	app *api.App,
 	svs services.Services,
-) error {
-	if nc.enableGrpcAPI {
-		if sErr := runGRPCServer(ctx, conf.GrpcAddr, nc, svs); sErr != nil {
-			return errors.Wrap(sErr, "failed to run gRPC server")
+	ctl api.NodeControl,
+) (<-chan struct{}, error) {
+	opts, err := apiRunOptsFromCLIFlags(nc, svs.Scheme)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid API options")
+	}
+	var grpcDone <-chan struct{}
+	if nc.enableGrpcAPI && conf.Mode == settings.ValidatorOnlyNodeMode {
+		zap.S().Warnf("gRPC API is disabled in '%s' node mode", conf.Mode)
+	}
+	if nc.enableGrpcWeb && !nc.enableGrpcAPI {
+		zap.S().Warn("'enable-grpc-web' flag requires activated 'enable-grpc-api' flag")
+	}
+	origins, err := server.ParseAllowedOrigins(nc.grpcWebAllowedOrigins)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid 'grpc-web-allowed-origins' flag value")
+	}
+	var checkToken server.BearerTokenChecker
+	if opts.JWTAuthOpts != nil {
+		c, cErr := api.NewBearerTokenChecker(*opts.JWTAuthOpts)
+		if cErr != nil {
+			return nil, errors.Wrap(cErr, "invalid JWT authentication flags")
 		}
+		checkToken = c
+	}
+	auditLog, err := openAuditLog(nc.apiAuditLog)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open API audit log")
+	}
+	var grpcWeb func(next http.Handler) http.Handler
+	if nc.enableGrpcAPI && conf.Mode != settings.ValidatorOnlyNodeMode {
+		srv, d, sErr := runGRPCServer(ctx, conf.GrpcAddr, nc, svs, app.CheckAPIKey, checkToken)
+		if sErr != nil {
+			if auditLog != nil {
+				sErr = closeIfErrorf(auditLog, sErr, "failed to close API audit log")
+			}
+			return nil, errors.Wrap(sErr, "failed to run gRPC server")
+		}
+		grpcDone = d
+		if nc.enableGrpcWeb {
+			grpcWeb = srv.GRPCWebMiddleware(server.GRPCWebOptions{
+				AllowedOrigins: origins,
+				MaxConnections: nc.grpcAPIMaxConnections,
+			})
+		}
+	} else {
+		closed := make(chan struct{})
+		close(closed)
+		grpcDone = closed
 	}
 
 	webAPI := api.NewNodeAPI(app, svs.State)
+	opts.Mode = conf.Mode
+	opts.NodeControl = ctl
+	opts.GRPCWeb = grpcWeb
+	opts.RegisterExtensionRoutes = extensions.RegisterRoutes
+	opts.AuditLog = auditLog
+	ipcDone := make(chan struct{})
+	if nc.ipcPath != "" {
+		go func() {
+			defer close(ipcDone)
+			zap.S().Infof("Starting node IPC API on '%s'", nc.ipcPath)
+			if runErr := api.RunIPC(ctx, nc.ipcPath, webAPI, opts); runErr != nil {
+				zap.S().Errorf("Failed to start IPC API: %v", runErr)
+			}
+		}()
+	} else {
+		close(ipcDone)
+	}
+	done := make(chan struct{})
 	go func() {
+		defer close(done)
 		zap.S().Infof("Starting node HTTP API on '%v'", conf.HttpAddr)
-		if runErr := api.Run(ctx, conf.HttpAddr, webAPI, apiRunOptsFromCLIFlags(nc)); runErr != nil {
+		if runErr := api.Run(ctx, conf.HttpAddr, webAPI, opts); runErr != nil {
 			zap.S().Errorf("Failed to start API: %v", runErr)
 		}
+		<-ipcDone
+		if auditLog != nil {
+			if clErr := auditLog.Close(); clErr != nil {
+				zap.S().Errorf("Failed to close API audit log: %v", clErr)
+			}
+		}
+		<-grpcDone
 	}()
-	return nil
+	return done, nil
+}
+
+// readAPIKeysPassword reads the password of API keys file from the file or from the environment variable.
+func readAPIKeysPassword(path string) ([]byte, error) {
+	var password []byte
+	if path != "" {
+		data, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read API keys password file")
+		}
+		password = bytes.TrimRight(data, "\r\n")
+	} else {
+		password = []byte(os.Getenv(apiKeysPasswordEnv))
+	}
+	if len(password) == 0 {
+		return nil, errors.Errorf("empty API keys password, set it with 'api-keys-password-file' flag or %s "+
+			"environment variable", apiKeysPasswordEnv)
+	}
+	return password, nil
+}
+
+func openAuditLog(s string) (*api.AuditLog, error) {
+	if s == "" {
+		return nil, nil
+	}
+	opts, err := api.NewAuditLogOptionsFromString(s)
+	if err != nil {
+		return nil, err
+	}
+	return api.NewAuditLog(*opts)
 }
 
 func FromArgs(scheme proto.Scheme, c *config) func(s *settings.NodeSettings) error {
@@ -804,14 +1533,22 @@ func FromArgs(scheme proto.Scheme, c *config) func(s *settings.NodeSettings) err
 		if c.peerAddresses == "" && !c.disableOutgoingConnections {
 			s.Addresses = defaultPeers[c.blockchainType]
 		}
+		mode, err := settings.NewNodeModeFromString(c.nodeMode)
+		if err != nil {
+			return errors.Wrap(err, "invalid 'mode' flag value")
+		}
+		s.Mode = mode
 		return nil
 	}
 }
 
-func apiRunOptsFromCLIFlags(c *config) *api.RunOptions {
+func apiRunOptsFromCLIFlags(c *config, scheme proto.Scheme) (*api.RunOptions, error) {
 	// TODO: add more run flags to CLI flags
 	opts := api.DefaultRunOptions()
 	opts.MaxConnections = c.apiMaxConnections
+	opts.ShutdownTimeout = c.apiShutdownTimeout
+	opts.DrainPeriod = c.apiDrainPeriod
+	opts.EnableExplorer = c.enableExplorer
 	if c.enableMetaMaskAPI {
 		if c.buildExtendedAPI {
 			opts.EnableMetaMaskAPI = c.enableMetaMaskAPI
@@ -828,12 +1565,67 @@ func apiRunOptsFromCLIFlags(c *config) *api.RunOptions {
 			zap.S().Errorf("Invalid rate limiter options '%s': %v", c.rateLimiterOptions, err)
 		}
 	}
-	return opts
+	if c.apiServerOptions != "" {
+		so, err := api.NewServerOptionsFromString(c.apiServerOptions)
+		if err == nil {
+			opts.ServerOpts = so
+		} else {
+			zap.S().Errorf("Invalid API server options '%s': %v", c.apiServerOptions, err)
+		}
+	}
+	if c.apiRouteTimeouts != "" {
+		rts, err := api.NewRouteTimeoutsFromString(c.apiRouteTimeouts)
+		if err == nil {
+			opts.ServerOpts.RouteTimeouts = rts
+		} else {
+			zap.S().Errorf("Invalid API route timeouts: %v", err)
+		}
+	}
+	if c.apiKeyQuotas != "" {
+		quotas, err := api.NewAPIKeyQuotasFromString(c.apiKeyQuotas)
+		if err == nil {
+			opts.APIKeyQuotas = quotas
+		} else {
+			zap.S().Errorf("Invalid API key quotas: %v", err)
+		}
+	}
+	if c.jwtIssuer != "" || c.jwtJWKSURL != "" {
+		opts.JWTAuthOpts = &api.JWTAuthOptions{
+			Issuer:          c.jwtIssuer,
+			JWKSURL:         c.jwtJWKSURL,
+			Audience:        c.jwtAudience,
+			Scope:           c.jwtScope,
+			RefreshInterval: api.DefaultJWKSRefreshInterval,
+			Leeway:          api.DefaultJWTLeeway,
+		}
+	}
+	if c.faucetAccount != "" {
+		addr, err := proto.NewAddressFromString(c.faucetAccount)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid faucet account '%s'", c.faucetAccount)
+		}
+		fo := api.DefaultFaucetOptions(addr)
+		fo.Amount = c.faucetAmount
+		fo.AddressQuota = c.faucetAddressQuota
+		fo.IPQuota = c.faucetIPQuota
+		fo.QuotaPeriod = c.faucetQuotaPeriod
+		proxies, pErr := api.ParseTrustedProxies(c.faucetTrustedProxies)
+		if pErr != nil {
+			return nil, errors.Wrap(pErr, "invalid faucet trusted proxies")
+		}
+		fo.TrustedProxies = proxies
+		if vErr := fo.Validate(scheme); vErr != nil {
+			return nil, errors.Wrap(vErr, "invalid faucet options")
+		}
+		opts.FaucetOpts = fo
+	}
+	return opts, nil
 }
 
 func grpcAPIRunOptsFromCLIFlags(c *config) *server.RunOptions {
 	opts := server.DefaultRunOptions()
 	opts.MaxConnections = c.grpcAPIMaxConnections
+	opts.ShutdownTimeout = c.apiShutdownTimeout
 	return opts
 }
 
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/wavesplatform/gowaves/pkg/logging"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+	"github.com/wavesplatform/gowaves/pkg/state"
+	"github.com/wavesplatform/gowaves/pkg/util/fdlimit"
+	"github.com/wavesplatform/gowaves/pkg/versioning"
+)
+
+func main() {
+	if err := run(); err != nil {
+		zap.S().Error(err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	var (
+		logLevel = zap.LevelFlag("log-level", zapcore.InfoLevel,
+			"Logging level. Supported levels: DEBUG, INFO, WARN, ERROR, FATAL. Default logging level INFO.")
+		statePath        = flag.String("state-path", "", "Path to node's state directory")
+		blockchainType   = flag.String("blockchain-type", "mainnet", "Blockchain type: mainnet/testnet/stagenet")
+		cfgPath          = flag.String("cfg-path", "", "Path to configuration JSON file, only for custom blockchain.")
+		buildExtendedAPI = flag.Bool("build-extended-api", false,
+			"Set if the state was imported with extended API data.")
+		buildStateHashes = flag.Bool("build-state-hashes", false,
+			"Set if the state was imported with state hashes.")
+		disableBloomFilter = flag.Bool("disable-bloom", false, "Disable bloom filter for state.")
+	)
+	flag.Parse()
+
+	logger := logging.SetupSimpleLogger(*logLevel)
+	defer func() {
+		err := logger.Sync()
+		if err != nil && errors.Is(err, os.ErrInvalid) {
+			panic(fmt.Sprintf("Failed to close logging subsystem: %v\n", err))
+		}
+	}()
+	zap.S().Infof("Gowaves Reindex version: %s", versioning.Version)
+
+	if *statePath == "" {
+		return errors.New("empty path to state")
+	}
+	maxFDs, err := fdlimit.MaxFDs()
+	if err != nil {
+		return fmt.Errorf("failed to get max file descriptors: %w", err)
+	}
+	if _, err = fdlimit.RaiseMaxFDs(maxFDs); err != nil {
+		return fmt.Errorf("failed to raise max file descriptors: %w", err)
+	}
+	cfg, err := blockchainSettings(*cfgPath, *blockchainType)
+	if err != nil {
+		return err
+	}
+
+	params := state.DefaultStateParams()
+	params.DbParams.DisableBloomFilter = *disableBloomFilter
+	params.BuildStateHashes = *buildStateHashes
+	params.StoreExtendedApiData = *buildExtendedAPI
+	if err = state.RebuildIndexes(*statePath, params, cfg); err != nil {
+		return fmt.Errorf("failed to rebuild indexes of state at '%s': %w", *statePath, err)
+	}
+	zap.S().Info("Indexes rebuilt successfully")
+	return nil
+}
+
+func blockchainSettings(cfgPath, blockchainType string) (*settings.BlockchainSettings, error) {
+	if cfgPath == "" {
+		return settings.BlockchainSettingsByTypeName(blockchainType)
+	}
+	f, err := os.Open(filepath.Clean(cfgPath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open configuration file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	return settings.ReadBlockchainSettings(f)
+}
@@ -0,0 +1,281 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+
+	"github.com/mr-tron/base58"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/wavesplatform/gowaves/pkg/logging"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+	"github.com/wavesplatform/gowaves/pkg/state"
+	"github.com/wavesplatform/gowaves/pkg/util/fdlimit"
+	"github.com/wavesplatform/gowaves/pkg/versioning"
+)
+
+const (
+	MB = 1024 * 1024
+)
+
+var errDivergence = errors.New("replayed state diverged from the original state")
+
+func main() {
+	if err := run(); err != nil {
+		zap.S().Error(err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	var (
+		logLevel = zap.LevelFlag("log-level", zapcore.InfoLevel,
+			"Logging level. Supported levels: DEBUG, INFO, WARN, ERROR, FATAL. Default logging level INFO.")
+		statePath      = flag.String("state-path", "", "Path to node's state directory")
+		blockchainType = flag.String("blockchain-type", "mainnet", "Blockchain type: mainnet/testnet/stagenet")
+		cfgPath        = flag.String("cfg-path", "", "Path to configuration JSON file, only for custom blockchain.")
+		from           = flag.Uint64("from", 1, "Height to start comparison of the replayed state from")
+		to             = flag.Uint64("to", 0, "Height to stop replay at, defaults to the top most height of the state")
+		tmpDir         = flag.String("tmp-dir", "",
+			"Directory to create the temporary state in, defaults to the system temporary directory")
+		batch              = flag.Int("batch", 100, "Number of blocks applied to the temporary state at once")
+		disableBloomFilter = flag.Bool("disable-bloom", false, "Disable bloom filter for state.")
+	)
+	flag.Parse()
+
+	logger := logging.SetupSimpleLogger(*logLevel)
+	defer func() {
+		err := logger.Sync()
+		if err != nil && errors.Is(err, os.ErrInvalid) {
+			panic(fmt.Sprintf("Failed to close logging subsystem: %v\n", err))
+		}
+	}()
+	zap.S().Infof("Gowaves Replay version: %s", versioning.Version)
+
+	if *statePath == "" {
+		return errors.New("empty path to state")
+	}
+	if *from == 0 {
+		return errors.New("invalid start height")
+	}
+	if *batch <= 0 {
+		return errors.New("invalid batch size")
+	}
+	maxFDs, err := fdlimit.MaxFDs()
+	if err != nil {
+		return fmt.Errorf("failed to get max file descriptors: %w", err)
+	}
+	if _, err = fdlimit.RaiseMaxFDs(maxFDs); err != nil {
+		return fmt.Errorf("failed to raise max file descriptors: %w", err)
+	}
+	cfg, err := blockchainSettings(*cfgPath, *blockchainType)
+	if err != nil {
+		return err
+	}
+
+	params := state.DefaultStateParams()
+	params.VerificationGoroutinesNum = 2 * runtime.NumCPU()
+	params.DbParams.WriteBuffer = 16 * MB
+	params.DbParams.DisableBloomFilter = *disableBloomFilter
+	params.BuildStateHashes = true
+	params.ProvideExtendedApi = false
+
+	original, err := state.NewState(*statePath, false, params, cfg, false)
+	if err != nil {
+		return fmt.Errorf("failed to open state at '%s': %w", *statePath, err)
+	}
+	defer closeState(original)
+
+	top, err := original.Height()
+	if err != nil {
+		return fmt.Errorf("failed to get height of the state: %w", err)
+	}
+	if *to == 0 || *to > top {
+		*to = top
+	}
+	if *from > *to {
+		return fmt.Errorf("start height %d is above the end height %d", *from, *to)
+	}
+
+	dir, err := os.MkdirTemp(*tmpDir, "gowaves-replay-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer func() {
+		if rErr := os.RemoveAll(dir); rErr != nil {
+			zap.S().Errorf("Failed to remove temporary state at '%s': %v", dir, rErr)
+		}
+	}()
+	replayed, err := state.NewState(dir, false, params, cfg, false)
+	if err != nil {
+		return fmt.Errorf("failed to create temporary state at '%s': %w", dir, err)
+	}
+	defer closeState(replayed)
+
+	r := &replayer{original: original, replayed: replayed, scheme: cfg.AddressSchemeCharacter}
+	if err = r.replay(*from, *to, *batch); err != nil {
+		return err
+	}
+	zap.S().Infof("[OK] Replayed state is equal to the original state at heights [%d, %d]", *from, *to)
+	return nil
+}
+
+type replayer struct {
+	original state.State
+	replayed state.State
+	scheme   proto.Scheme
+}
+
+// replay applies the blocks of the original state up to the height to the replayed state by batches and compares
+// states after every applied block starting from the height from. Genesis block is applied on state creation.
+func (r *replayer) replay(from, to proto.Height, batch int) error {
+	if from == 1 {
+		if err := r.compare(1); err != nil {
+			return err
+		}
+	}
+	var blocks []*proto.Block
+	for start := proto.Height(2); start <= to; start += proto.Height(len(blocks)) {
+		blocks = make([]*proto.Block, 0, batch)
+		for h := start; h <= to && len(blocks) < batch; h++ {
+			b, err := r.original.BlockByHeight(h)
+			if err != nil {
+				return fmt.Errorf("failed to get block at height %d: %w", h, err)
+			}
+			blocks = append(blocks, b)
+		}
+		if _, err := r.replayed.AddDeserializedBlocks(blocks); err != nil {
+			return fmt.Errorf("failed to replay blocks at heights [%d, %d]: %w",
+				start, start+proto.Height(len(blocks))-1, err)
+		}
+		for i := range blocks {
+			if h := start + proto.Height(i); h >= from {
+				if err := r.compare(h); err != nil {
+					return err
+				}
+			}
+		}
+		zap.S().Debugf("Replayed blocks up to height %d", start+proto.Height(len(blocks))-1)
+	}
+	return nil
+}
+
+func (r *replayer) compare(h proto.Height) error {
+	osh, err := r.original.SnapshotStateHashAtHeight(h)
+	if err != nil {
+		return fmt.Errorf("failed to get original snapshot state hash at height %d: %w", h, err)
+	}
+	rsh, err := r.replayed.SnapshotStateHashAtHeight(h)
+	if err != nil {
+		return fmt.Errorf("failed to get replayed snapshot state hash at height %d: %w", h, err)
+	}
+	olsh, err := r.original.LegacyStateHashAtHeight(h)
+	if err != nil {
+		zap.S().Debugf("Legacy state hashes are not compared at height %d: %v", h, err)
+	}
+	var rlsh *proto.StateHash
+	if err == nil {
+		rlsh, err = r.replayed.LegacyStateHashAtHeight(h)
+		if err != nil {
+			return fmt.Errorf("failed to get replayed legacy state hash at height %d: %w", h, err)
+		}
+	}
+	if osh == rsh && (olsh == nil || *olsh == *rlsh) {
+		return nil
+	}
+	zap.S().Warnf("[NOT OK] State hashes are different at height %d", h)
+	zap.S().Infof("Original snapshot state hash: %s", osh.String())
+	zap.S().Infof("Replayed snapshot state hash: %s", rsh.String())
+	if olsh != nil {
+		zap.S().Infof("Original legacy state hash: %s", stateHashToString(olsh))
+		zap.S().Infof("Replayed legacy state hash: %s", stateHashToString(rlsh))
+	}
+	if dErr := r.findDivergentTransaction(h); dErr != nil {
+		zap.S().Errorf("Failed to find divergent transaction at height %d: %v", h, dErr)
+	}
+	return errDivergence
+}
+
+// findDivergentTransaction compares the snapshots of transactions of the block at the height and reports the first
+// transaction with different snapshots.
+func (r *replayer) findDivergentTransaction(h proto.Height) error {
+	block, err := r.original.BlockByHeight(h)
+	if err != nil {
+		return fmt.Errorf("failed to get block: %w", err)
+	}
+	orig, err := r.original.SnapshotsAtHeight(h)
+	if err != nil {
+		return fmt.Errorf("failed to get original snapshots: %w", err)
+	}
+	rs, err := r.replayed.SnapshotsAtHeight(h)
+	if err != nil {
+		return fmt.Errorf("failed to get replayed snapshots: %w", err)
+	}
+	n := max(len(orig.TxSnapshots), len(rs.TxSnapshots))
+	for i := 0; i < n; i++ {
+		var ots, rts []proto.AtomicSnapshot
+		if i < len(orig.TxSnapshots) {
+			ots = orig.TxSnapshots[i]
+		}
+		if i < len(rs.TxSnapshots) {
+			rts = rs.TxSnapshots[i]
+		}
+		if reflect.DeepEqual(ots, rts) {
+			continue
+		}
+		id := "<unknown>"
+		if i < len(block.Transactions) {
+			if bts, idErr := block.Transactions[i].GetID(r.scheme); idErr == nil {
+				id = base58.Encode(bts)
+			}
+		}
+		zap.S().Warnf("First divergent transaction %s at position %d of block %s", id, i, block.BlockID().String())
+		zap.S().Infof("Original transaction snapshot:\n%s", snapshotToString(ots))
+		zap.S().Infof("Replayed transaction snapshot:\n%s", snapshotToString(rts))
+		return nil
+	}
+	zap.S().Warnf("Transaction snapshots of block %s are equal", block.BlockID().String())
+	return nil
+}
+
+func snapshotToString(ts []proto.AtomicSnapshot) string {
+	s := ""
+	for _, as := range ts {
+		s += fmt.Sprintf("\t%T %+v\n", as, as)
+	}
+	return s
+}
+
+func stateHashToString(sh *proto.StateHash) string {
+	js, err := json.Marshal(sh)
+	if err != nil {
+		zap.S().Fatalf("Failed to render state hash to text: %v", err)
+	}
+	return string(js)
+}
+
+func closeState(st state.State) {
+	if err := st.Close(); err != nil {
+		zap.S().Errorf("Failed to close state: %v", err)
+	}
+}
+
+func blockchainSettings(cfgPath, blockchainType string) (*settings.BlockchainSettings, error) {
+	if cfgPath == "" {
+		return settings.BlockchainSettingsByTypeName(blockchainType)
+	}
+	f, err := os.Open(filepath.Clean(cfgPath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open configuration file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	return settings.ReadBlockchainSettings(f)
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/wavesplatform/gowaves/pkg/client"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+// mismatch is the record about different state hashes of nodes at the same height. Diverged contains the names of
+// state hash sections which differ from the state hash of the first node.
+type mismatch struct {
+	Height   uint64                      `json:"height"`
+	Time     time.Time                   `json:"time"`
+	Diverged []string                    `json:"diverged"`
+	Hashes   map[string]*proto.StateHash `json:"hashes"`
+}
+
+// divergedSections returns the names of state hash sections that differ.
+func divergedSections(a, b proto.FieldsHashes) []string {
+	sections := []struct {
+		name string
+		a, b [32]byte
+	}{
+		{"dataEntryHash", a.DataEntryHash, b.DataEntryHash},
+		{"accountScriptHash", a.AccountScriptHash, b.AccountScriptHash},
+		{"assetScriptHash", a.AssetScriptHash, b.AssetScriptHash},
+		{"leaseStatusHash", a.LeaseStatusHash, b.LeaseStatusHash},
+		{"sponsorshipHash", a.SponsorshipHash, b.SponsorshipHash},
+		{"aliasHash", a.AliasesHash, b.AliasesHash},
+		{"wavesBalanceHash", a.WavesBalanceHash, b.WavesBalanceHash},
+		{"assetBalanceHash", a.AssetBalanceHash, b.AssetBalanceHash},
+		{"leaseBalanceHash", a.LeaseBalanceHash, b.LeaseBalanceHash},
+	}
+	var r []string
+	for _, s := range sections {
+		if s.a != s.b {
+			r = append(r, s.name)
+		}
+	}
+	return r
+}
+
+// follower watches for new blocks on the first node and compares its state hashes with the state hashes of other
+// nodes at the same heights. The top block is skipped, because its state hash changes with every micro block.
+type follower struct {
+	nodes    []string
+	clients  []*client.Client
+	interval time.Duration
+	tries    int
+	out      io.Writer
+}
+
+func (f *follower) run(ctx context.Context) error {
+	last, err := f.height(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get initial height")
+	}
+	zap.S().Infof("Following node %s from height %d", f.nodes[0], last)
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		h, hErr := f.height(ctx)
+		if hErr != nil {
+			zap.S().Warnf("Failed to get height of node %s: %v", f.nodes[0], hErr)
+			continue
+		}
+		if h < last { // Rollback happened, compare the new blocks again.
+			last = h
+		}
+		for ; last < h; last++ {
+			if cErr := f.compare(ctx, last); cErr != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				zap.S().Warnf("Failed to compare state hashes at height %d: %v", last, cErr)
+				break // Retry the same height on the next tick.
+			}
+		}
+	}
+}
+
+func (f *follower) height(ctx context.Context) (uint64, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.interval)
+	defer cancel()
+	bh, _, err := f.clients[0].Blocks.Height(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return bh.Height, nil
+}
+
+// compare loads state hashes of all nodes at the given height. Reference nodes could be behind, so loading is
+// retried with delay.
+func (f *follower) compare(ctx context.Context, height uint64) error {
+	hashes := make([]*proto.StateHash, len(f.clients))
+	for i, cl := range f.clients {
+		var err error
+		for try := 0; try < f.tries; try++ {
+			if hashes[i], err = loadStateHash(ctx, cl, height, 1); err == nil {
+				break
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(f.interval):
+			}
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to load state hash from node %s", f.nodes[i])
+		}
+	}
+	diverged := make(map[string]struct{})
+	for _, sh := range hashes[1:] {
+		for _, s := range divergedSections(hashes[0].FieldsHashes, sh.FieldsHashes) {
+			diverged[s] = struct{}{}
+		}
+	}
+	if len(diverged) == 0 {
+		zap.S().Debugf("Height %d: state hashes are equal", height)
+		return nil
+	}
+	m := mismatch{
+		Height: height,
+		Time:   time.Now().UTC(),
+		Hashes: make(map[string]*proto.StateHash, len(hashes)),
+	}
+	for s := range diverged {
+		m.Diverged = append(m.Diverged, s)
+	}
+	slices.Sort(m.Diverged)
+	for i, sh := range hashes {
+		m.Hashes[f.nodes[i]] = sh
+	}
+	zap.S().Warnf("Height %d: state hashes are different, diverged sections: %v", height, m.Diverged)
+	if err := f.record(m); err != nil {
+		zap.S().Errorf("Failed to record mismatch at height %d: %v", height, err)
+	}
+	return nil
+}
+
+func (f *follower) record(m mismatch) error {
+	if f.out == nil {
+		return nil
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal mismatch record")
+	}
+	if _, err := f.out.Write(append(b, '\n')); err != nil {
+		return errors.Wrap(err, "failed to write mismatch record")
+	}
+	return nil
+}
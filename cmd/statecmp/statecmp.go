@@ -8,8 +8,11 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"os/signal"
+	"path/filepath"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/pkg/errors"
@@ -177,6 +180,7 @@ func main() {
 		defaultGoroutinesNumber = 15
 		defaultTriesNumber      = 5
 		minNodesNumber          = 2
+		defaultFollowInterval   = 5 * time.Second
 	)
 	var (
 		logLevel = zap.LevelFlag("log-level", zapcore.InfoLevel,
@@ -186,7 +190,13 @@ func main() {
 		endHeight     = flag.Int("end-height", defaultEndHeight, "End height.")
 		goroutinesNum = flag.Int("goroutines-num", defaultGoroutinesNumber,
 			"Number of goroutines that will run for downloading state hashes.")
-		tries = flag.Int("tries-num", defaultTriesNumber, "Number of tries to download.")
+		tries  = flag.Int("tries-num", defaultTriesNumber, "Number of tries to download.")
+		follow = flag.Bool("follow", false,
+			"Continuously compare state hashes of new blocks of the first node with other nodes; "+
+				"start and end heights are ignored.")
+		interval       = flag.Duration("interval", defaultFollowInterval, "Interval of polling new blocks in follow mode.")
+		mismatchesPath = flag.String("mismatches", "",
+			"Path to the file to append records about different state hashes in JSON lines format in follow mode.")
 	)
 
 	flag.Parse()
@@ -198,7 +208,7 @@ func main() {
 			panic(fmt.Sprintf("Failed to close logging subsystem: %v\n", err))
 		}
 	}()
-	if *endHeight <= *startHeight {
+	if !*follow && *endHeight <= *startHeight {
 		zap.S().Fatal("End height must be greater than start height.")
 	}
 
@@ -218,6 +228,11 @@ func main() {
 		}
 	}
 
+	if *follow {
+		runFollower(nodes, clients, *interval, *tries, *mismatchesPath)
+		return
+	}
+
 	heightChan := make(chan uint64)
 	errChan := make(chan error, *goroutinesNum)
 	var wg sync.WaitGroup
@@ -241,3 +256,26 @@ func main() {
 	wg.Wait()
 	zap.S().Info("Finished to compare states.")
 }
+
+func runFollower(nodes []string, clients []*client.Client, interval time.Duration, tries int, mismatchesPath string) {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	f := &follower{nodes: nodes, clients: clients, interval: interval, tries: tries}
+	if mismatchesPath != "" {
+		file, err := os.OpenFile(filepath.Clean(mismatchesPath), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			zap.S().Fatalf("Failed to open mismatches file: %v", err)
+		}
+		defer func() {
+			if clErr := file.Close(); clErr != nil {
+				zap.S().Errorf("Failed to close mismatches file: %v", clErr)
+			}
+		}()
+		f.out = file
+	}
+	if err := f.run(ctx); err != nil {
+		zap.S().Errorf("Follower failed: %v", err)
+		return
+	}
+	zap.S().Info("Finished to follow states.")
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/wavesplatform/gowaves/pkg/logging"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+	"github.com/wavesplatform/gowaves/pkg/state"
+	"github.com/wavesplatform/gowaves/pkg/util/fdlimit"
+	"github.com/wavesplatform/gowaves/pkg/versioning"
+)
+
+var errStatesDiffer = errors.New("states are different")
+
+// keySpace loads records of one kind from the state as pairs of unique key and value, which are compared as is.
+type keySpace struct {
+	name string
+	load func(st state.StateInfo, height proto.Height, fn func(key, value string) error) error
+}
+
+var keySpaces = []keySpace{
+	{name: "balances", load: loadBalances},
+	{name: "data entries", load: loadDataEntries},
+	{name: "leases", load: loadLeases},
+}
+
+func main() {
+	if err := run(); err != nil {
+		zap.S().Error(err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	var (
+		logLevel = zap.LevelFlag("log-level", zapcore.InfoLevel,
+			"Logging level. Supported levels: DEBUG, INFO, WARN, ERROR, FATAL. Default logging level INFO.")
+		statePath      = flag.String("state-path", "", "Path to the first node's state directory")
+		otherStatePath = flag.String("other-state-path", "", "Path to the second node's state directory")
+		blockchainType = flag.String("blockchain-type", "mainnet", "Blockchain type: mainnet/testnet/stagenet")
+		cfgPath        = flag.String("cfg-path", "", "Path to configuration JSON file, only for custom blockchain.")
+		height         = flag.Uint64("height", 0,
+			"Height to compare states at, defaults to the lowest of the states heights. "+
+				"Height must be in the retained history of both states")
+		limit              = flag.Int("limit", 100, "Maximum number of reported differences in every key space")
+		disableBloomFilter = flag.Bool("disable-bloom", false, "Disable bloom filter for state.")
+	)
+	flag.Parse()
+
+	logger := logging.SetupSimpleLogger(*logLevel)
+	defer func() {
+		err := logger.Sync()
+		if err != nil && errors.Is(err, os.ErrInvalid) {
+			panic(fmt.Sprintf("Failed to close logging subsystem: %v\n", err))
+		}
+	}()
+	zap.S().Infof("Gowaves State Diff version: %s", versioning.Version)
+
+	if *statePath == "" || *otherStatePath == "" {
+		return errors.New("empty path to state")
+	}
+	maxFDs, err := fdlimit.MaxFDs()
+	if err != nil {
+		return fmt.Errorf("failed to get max file descriptors: %w", err)
+	}
+	if _, err = fdlimit.RaiseMaxFDs(maxFDs); err != nil {
+		return fmt.Errorf("failed to raise max file descriptors: %w", err)
+	}
+	cfg, err := blockchainSettings(*cfgPath, *blockchainType)
+	if err != nil {
+		return err
+	}
+
+	params := state.DefaultStateParams()
+	params.DbParams.DisableBloomFilter = *disableBloomFilter
+	first, err := state.NewState(*statePath, false, params, cfg, false)
+	if err != nil {
+		return fmt.Errorf("failed to open state at '%s': %w", *statePath, err)
+	}
+	defer closeState(first)
+	second, err := state.NewState(*otherStatePath, false, params, cfg, false)
+	if err != nil {
+		return fmt.Errorf("failed to open state at '%s': %w", *otherStatePath, err)
+	}
+	defer closeState(second)
+
+	if *height == 0 {
+		h1, hErr := first.Height()
+		if hErr != nil {
+			return fmt.Errorf("failed to get height of the first state: %w", hErr)
+		}
+		h2, hErr := second.Height()
+		if hErr != nil {
+			return fmt.Errorf("failed to get height of the second state: %w", hErr)
+		}
+		*height = min(h1, h2)
+	}
+	if err = compareBlocks(first, second, *height); err != nil {
+		return err
+	}
+
+	same := true
+	for _, ks := range keySpaces {
+		zap.S().Infof("Comparing %s at height %d", ks.name, *height)
+		n, cErr := compareKeySpace(ks, first, second, *height, *limit)
+		if cErr != nil {
+			return fmt.Errorf("failed to compare %s: %w", ks.name, cErr)
+		}
+		if n != 0 {
+			zap.S().Warnf("[NOT OK] %d differences in %s", n, ks.name)
+			same = false
+			continue
+		}
+		zap.S().Infof("[OK] %s are equal", ks.name)
+	}
+	if !same {
+		return errStatesDiffer
+	}
+	return nil
+}
+
+func compareBlocks(first, second state.StateInfo, height proto.Height) error {
+	id1, err := first.HeightToBlockID(height)
+	if err != nil {
+		return fmt.Errorf("failed to get block ID of the first state at height %d: %w", height, err)
+	}
+	id2, err := second.HeightToBlockID(height)
+	if err != nil {
+		return fmt.Errorf("failed to get block ID of the second state at height %d: %w", height, err)
+	}
+	if id1 != id2 {
+		zap.S().Warnf("States are on different chains, blocks at height %d are '%s' and '%s'",
+			height, id1.String(), id2.String())
+	}
+	return nil
+}
+
+// compareKeySpace loads records of the first state to memory and matches them with the records of the second state.
+// It reports up to limit differences and returns the total number of differences.
+func compareKeySpace(ks keySpace, first, second state.StateInfo, height proto.Height, limit int) (int, error) {
+	records := make(map[string]string)
+	err := ks.load(first, height, func(key, value string) error {
+		records[key] = value
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	report := func(format string, args ...interface{}) {
+		n++
+		if n <= limit {
+			zap.S().Infof(format, args...)
+		}
+	}
+	err = ks.load(second, height, func(key, value string) error {
+		v, ok := records[key]
+		switch {
+		case !ok:
+			report("Only in the second state %s: %s", key, value)
+		case v != value:
+			report("Different %s:\n\tfirst:  %s\n\tsecond: %s", key, v, value)
+		}
+		delete(records, key)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	keys := make([]string, 0, len(records))
+	for k := range records {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		report("Only in the first state %s: %s", k, records[k])
+	}
+	return n, nil
+}
+
+func loadBalances(st state.StateInfo, height proto.Height, fn func(key, value string) error) error {
+	return st.BalancesAtHeight(height, func(r state.BalanceRecord) error {
+		return fn(fmt.Sprintf("balance of '%s' in '%s'", r.Address.String(), r.Asset.String()),
+			strconv.FormatUint(r.Balance, 10))
+	})
+}
+
+func loadDataEntries(st state.StateInfo, height proto.Height, fn func(key, value string) error) error {
+	return st.DataEntriesAtHeight(height, func(r state.DataEntryRecord) error {
+		js, err := json.Marshal(r.Entry)
+		if err != nil {
+			return err
+		}
+		return fn(fmt.Sprintf("entry '%s' of '%s'", r.Entry.GetKey(), r.Address.String()), string(js))
+	})
+}
+
+func loadLeases(st state.StateInfo, height proto.Height, fn func(key, value string) error) error {
+	return st.LeasesAtHeight(height, func(l *proto.LeaseDetails) error {
+		js, err := json.Marshal(l)
+		if err != nil {
+			return err
+		}
+		return fn(fmt.Sprintf("lease '%s'", l.ID.String()), string(js))
+	})
+}
+
+func closeState(st state.State) {
+	if err := st.Close(); err != nil {
+		zap.S().Errorf("Failed to close state: %v", err)
+	}
+}
+
+func blockchainSettings(cfgPath, blockchainType string) (*settings.BlockchainSettings, error) {
+	if cfgPath == "" {
+		return settings.BlockchainSettingsByTypeName(blockchainType)
+	}
+	f, err := os.Open(filepath.Clean(cfgPath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open configuration file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	return settings.ReadBlockchainSettings(f)
+}
@@ -0,0 +1,211 @@
+package clients
+
+import (
+	"context"
+	stderrs "errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/wavesplatform/gowaves/itests/config"
+	d "github.com/wavesplatform/gowaves/itests/docker"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+// ClusterNode describes the node of mixed cluster.
+type ClusterNode struct {
+	Name           string
+	Implementation Implementation
+	Ports          *d.PortConfig
+}
+
+// ClusterClients holds clients of the arbitrary number of nodes of different implementations and versions.
+// The first node is the reference one, state of other nodes is compared with it.
+type ClusterClients struct {
+	Nodes   []ClusterNode
+	Clients []*NodeUniversalClient
+}
+
+func NewClusterClients(ctx context.Context, t *testing.T, nodes ...ClusterNode) *ClusterClients {
+	require.NotEmpty(t, nodes, "no nodes in cluster")
+	peers := make([]proto.PeerInfo, len(nodes))
+	for i, n := range nodes {
+		p, err := proto.NewPeerInfoFromString(config.DefaultIP + ":" + n.Ports.BindPort)
+		require.NoErrorf(t, err, "failed to create peer info of node %q", n.Name)
+		peers[i] = p
+	}
+	cls := make([]*NodeUniversalClient, len(nodes))
+	for i, n := range nodes {
+		cls[i] = NewNodeUniversalClient(
+			ctx, t, n.Implementation, n.Ports.RESTAPIPort, n.Ports.GRPCPort, n.Ports.BindPort, peers,
+		)
+	}
+	return &ClusterClients{Nodes: nodes, Clients: cls}
+}
+
+func (c *ClusterClients) Handshake() {
+	for _, cl := range c.Clients {
+		cl.Handshake()
+	}
+}
+
+func (c *ClusterClients) SendStartMessage(t *testing.T) {
+	for _, cl := range c.Clients {
+		cl.SendStartMessage(t)
+	}
+}
+
+func (c *ClusterClients) SendEndMessage(t *testing.T) {
+	for _, cl := range c.Clients {
+		cl.SendEndMessage(t)
+	}
+}
+
+func (c *ClusterClients) Close(t *testing.T) {
+	for _, cl := range c.Clients {
+		cl.Close(t)
+	}
+}
+
+// Reference returns the client of the first node of the cluster.
+func (c *ClusterClients) Reference() *NodeUniversalClient {
+	return c.Clients[0]
+}
+
+// WaitForHeight waits for all nodes to get on given height and returns the minimal height of nodes.
+func (c *ClusterClients) WaitForHeight(t *testing.T, height uint64) uint64 {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+	heights := make([]uint64, len(c.Clients))
+	g, ctx := errgroup.WithContext(ctx)
+	for i, cl := range c.Clients {
+		g.Go(func() error {
+			for {
+				heights[i] = cl.HTTPClient.GetHeight(t).Height
+				if heights[i] >= height {
+					return nil
+				}
+				select {
+				case <-ctx.Done():
+					return errors.Wrapf(ctx.Err(), "node %q", c.Nodes[i].Name)
+				case <-time.After(time.Second):
+				}
+			}
+		})
+	}
+	if err := g.Wait(); err != nil {
+		t.Logf("Error while waiting for height: %v", err)
+	}
+	return slices.Min(heights)
+}
+
+// WaitForNewHeight waits for nodes to generate new block. Returns the height that was *before* generation of new block.
+func (c *ClusterClients) WaitForNewHeight(t *testing.T) uint64 {
+	initialHeight := c.Reference().HTTPClient.GetHeight(t).Height
+	c.WaitForHeight(t, initialHeight+1)
+	return initialHeight
+}
+
+// WaitForTransaction waits for the transaction to appear on all nodes, errors of all nodes are joined.
+func (c *ClusterClients) WaitForTransaction(id crypto.Digest, timeout time.Duration) error {
+	errs := make([]error, len(c.Clients))
+	g := errgroup.Group{}
+	for i, cl := range c.Clients {
+		g.Go(func() error {
+			err := Retry(timeout, func() error {
+				_, _, err := cl.HTTPClient.TransactionInfoRaw(id)
+				return err
+			})
+			if err != nil {
+				errs[i] = errors.Wrapf(err, "node %q", c.Nodes[i].Name)
+			}
+			return nil
+		})
+	}
+	_ = g.Wait() // errors are collected separately
+	return stderrs.Join(errs...)
+}
+
+func (c *ClusterClients) WaitForConnectedPeers(ctx context.Context, timeout time.Duration) error {
+	eg, ctx := errgroup.WithContext(ctx)
+	for i, cl := range c.Clients {
+		eg.Go(func() error {
+			err := RetryCtx(ctx, timeout, func() error {
+				cp, _, err := cl.HTTPClient.ConnectedPeersCtx(ctx)
+				if len(cp) < len(c.Clients)-1 && err == nil {
+					err = errors.Errorf("only %d peers of %d connected", len(cp), len(c.Clients)-1)
+				}
+				return err
+			})
+			return errors.Wrapf(err, "node %q", c.Nodes[i].Name)
+		})
+	}
+	return eg.Wait()
+}
+
+// StateHashes returns state hashes of all nodes at the given height and the names of nodes which state hashes
+// differ from the state hash of the reference node.
+func (c *ClusterClients) StateHashes(t *testing.T, height uint64) ([]*proto.StateHash, []string) {
+	shs := make([]*proto.StateHash, len(c.Clients))
+	for i, cl := range c.Clients {
+		shs[i] = cl.HTTPClient.StateHash(t, height)
+	}
+	var diverged []string
+	for i := 1; i < len(shs); i++ {
+		if shs[i].BlockID != shs[0].BlockID || shs[i].SumHash != shs[0].SumHash {
+			diverged = append(diverged, c.Nodes[i].Name)
+		}
+	}
+	return shs, diverged
+}
+
+// WaitForStateHashEquality checks that all nodes have the same state hash at the last common height.
+func (c *ClusterClients) WaitForStateHashEquality(t *testing.T) {
+	var (
+		shs      []*proto.StateHash
+		diverged []string
+	)
+	h := c.WaitForNewHeight(t)
+	for range 3 {
+		if shs, diverged = c.StateHashes(t, h); len(diverged) == 0 {
+			return
+		}
+		c.WaitForNewHeight(t)
+	}
+	assert.Failf(t, "Not equal state hashes", "Not equal state hashes of nodes %s at height %d:\n%s",
+		strings.Join(diverged, ", "), h, c.formatStateHashes(shs))
+	c.reportFirstDivergedHeight(t, h)
+}
+
+func (c *ClusterClients) reportFirstDivergedHeight(t *testing.T, height uint64) {
+	var first uint64
+	for h := height; h > 0; h-- {
+		if _, diverged := c.StateHashes(t, h); len(diverged) == 0 {
+			break
+		}
+		first = h
+	}
+	if first == 0 {
+		t.Error("couldn't find the height when state hashes diverged. should not happen")
+		return
+	}
+	shs, _ := c.StateHashes(t, first)
+	t.Logf("First height when state hashes diverged: %d:\n%s", first, c.formatStateHashes(shs))
+}
+
+func (c *ClusterClients) formatStateHashes(shs []*proto.StateHash) string {
+	sb := new(strings.Builder)
+	for i, sh := range shs {
+		_, _ = fmt.Fprintf(sb, "%s:\tBlockID=%s\tStateHash=%s\tFieldHashes=%s\n", c.Nodes[i].Name,
+			sh.BlockID.String(), sh.SumHash.String(), mustFieldsHashesToString(sh.FieldsHashes))
+	}
+	return sb.String()
+}
@@ -8,6 +8,8 @@ import (
 	"html/template"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/ory/dockertest/v3"
 	"github.com/pkg/errors"
@@ -28,6 +30,7 @@ const (
 
 	scalaContainerName = "scala-node"
 	goContainerName    = "go-node"
+	goImageRepository  = "go-node"
 )
 
 const (
@@ -183,10 +186,15 @@ type GoConfigurator struct {
 	cfg          *BlockchainConfig
 	configFolder string
 	walletFolder string
+	name         string
+	repository   string
+	tag          string
+	knownPeers   []string
+	noMining     bool
 }
 
 func NewGoConfigurator(suite string, cfg *BlockchainConfig) (*GoConfigurator, error) {
-	c := &GoConfigurator{suite: suite, cfg: cfg}
+	c := &GoConfigurator{suite: suite, cfg: cfg, name: goContainerName, repository: goImageRepository}
 	if err := c.createNodeConfig(); err != nil {
 		return nil, errors.Wrap(err, "failed to create go node configuration")
 	}
@@ -196,22 +204,62 @@ func NewGoConfigurator(suite string, cfg *BlockchainConfig) (*GoConfigurator, er
 	return c, nil
 }
 
+// WithName sets the name of the node, which is used as container's host name. It's necessary to run several
+// Go nodes in one suite.
+func (c *GoConfigurator) WithName(name string) *GoConfigurator {
+	c.name = name
+	return c
+}
+
+// WithImage sets the Docker image of the node in form "repository[:tag]", by default the image built from the
+// current sources is used.
+func (c *GoConfigurator) WithImage(image string) *GoConfigurator {
+	c.repository, c.tag = image, ""
+	// Tag separator must be after the last slash, otherwise it's a port of registry host.
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		c.repository, c.tag = image[:i], image[i+1:]
+	}
+	return c
+}
+
+// WithPeers adds the host names of other nodes to connect to.
+func (c *GoConfigurator) WithPeers(names ...string) *GoConfigurator {
+	c.knownPeers = append(c.knownPeers, names...)
+	return c
+}
+
+// WithoutMining disables mining on the node regardless of blockchain configuration. Nodes share the same wallet,
+// so only one Go node in the suite should mine.
+func (c *GoConfigurator) WithoutMining() *GoConfigurator {
+	c.noMining = true
+	return c
+}
+
 func (c *GoConfigurator) DockerRunOptions() *dockertest.RunOptions {
+	disableMiner := c.cfg.DisableGoMiningString()
+	if c.noMining {
+		disableMiner = strconv.FormatBool(true)
+	}
+	peers := make([]string, len(c.knownPeers))
+	for i, kp := range c.knownPeers {
+		peers[i] = kp + ":" + BindPort
+	}
 	opt := &dockertest.RunOptions{
-		Repository: "go-node",
-		Name:       c.suite + "-" + goContainerName,
+		Repository: c.repository,
+		Tag:        c.tag,
+		Name:       c.suite + "-" + c.name,
 		User:       "gowaves",
-		Hostname:   "go-node",
+		Hostname:   c.name,
 		Env: []string{
 			"GRPC_ADDR=" + DefaultIP + ":" + GRPCAPIPort,
 			"API_ADDR=" + DefaultIP + ":" + RESTAPIPort,
 			"BIND_ADDR=" + DefaultIP + ":" + BindPort,
-			"DECLARED_ADDR=" + "go-node:" + BindPort,
-			"PEERS=",
+			"DECLARED_ADDR=" + c.name + ":" + BindPort,
+			"PEERS=" + strings.Join(peers, ","),
 			"WALLET_PASSWORD=itest",
 			"DESIRED_REWARD=" + c.cfg.DesiredBlockRewardString(),
 			"SUPPORTED_FEATURES=" + c.cfg.SupportedFeaturesString(),
-			"DISABLE_MINER=" + c.cfg.DisableGoMiningString(),
+			"DISABLE_MINER=" + disableMiner,
 		},
 		ExposedPorts: []string{
 			GRPCAPIPort + NetTCP,
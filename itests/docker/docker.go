@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/ory/dockertest/v3"
@@ -102,6 +103,9 @@ type Docker struct {
 	goNode    *NodeContainer
 	scalaNode *NodeContainer
 
+	mu         sync.Mutex
+	extraNodes map[string]*NodeContainer
+
 	logs string
 }
 
@@ -113,7 +117,7 @@ func NewDocker(suiteName string) (*Docker, error) {
 		return nil, err
 	}
 	pool.MaxWait = PoolRetryTimeout
-	docker := &Docker{suite: suiteName, pool: pool}
+	docker := &Docker{suite: suiteName, pool: pool, extraNodes: make(map[string]*NodeContainer)}
 	if rmErr := docker.removeContainers(); rmErr != nil {
 		return nil, rmErr
 	}
@@ -174,8 +178,43 @@ func (d *Docker) StartScalaNode(ctx context.Context, cfg config.DockerConfigurat
 	return nil
 }
 
+// StartExtraGoNode starts one more Go node container, for example, of another version. The name of the node must
+// be unique in the suite and equal to the name set in configuration.
+func (d *Docker) StartExtraGoNode(ctx context.Context, name string, cfg config.DockerConfigurator) error {
+	d.mu.Lock()
+	_, ok := d.extraNodes[name]
+	d.mu.Unlock()
+	if ok {
+		return errors.Errorf("node %q is already started", name)
+	}
+	nc, err := d.startNode(ctx, cfg, name+".log", name+".err")
+	if err != nil {
+		return errors.Wrapf(err, "failed to start Go node %q", name)
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.extraNodes[name] = nc
+	return nil
+}
+
+// ExtraNode returns the container of additional node started by StartExtraGoNode or nil if there is no such node.
+func (d *Docker) ExtraNode(name string) *NodeContainer {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.extraNodes[name]
+}
+
 func (d *Docker) Finish(cancel context.CancelFunc) {
 	eg := errgroup.Group{}
+	d.mu.Lock()
+	for _, n := range d.extraNodes {
+		eg.Go(func() error {
+			stErr := d.stopContainer(n.container.Container.ID)
+			clErr := n.Close()
+			return stderrs.Join(stErr, clErr)
+		})
+	}
+	d.mu.Unlock()
 	if d.scalaNode != nil {
 		eg.Go(func() error {
 			stErr := d.stopContainer(d.scalaNode.container.Container.ID)
@@ -0,0 +1,107 @@
+package fixtures
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/stoewer/go-strcase"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/wavesplatform/gowaves/itests/clients"
+	"github.com/wavesplatform/gowaves/itests/config"
+	d "github.com/wavesplatform/gowaves/itests/docker"
+)
+
+const (
+	// PreviousGoNodeImageEnvKey is the name of environment variable with Docker image of the previous release of
+	// Go node, for example "go-node-previous" or "wavesplatform/gowaves:v0.10.5".
+	PreviousGoNodeImageEnvKey = "ITESTS_PREVIOUS_GO_NODE_IMAGE"
+
+	goNodeName         = "go-node"
+	previousGoNodeName = "go-node-previous"
+	scalaNodeName      = "scala-node"
+)
+
+// MixedClusterSuite runs the cluster of the current Go node, the previous release of Go node and Scala node.
+// Go node of the current version is the reference node of the cluster. Suite is skipped if the image of the
+// previous release is not set by ITESTS_PREVIOUS_GO_NODE_IMAGE environment variable.
+type MixedClusterSuite struct {
+	suite.Suite
+
+	MainCtx context.Context
+	Cancel  context.CancelFunc
+	Cfg     config.TestConfig
+	Docker  *d.Docker
+	Clients *clients.ClusterClients
+}
+
+func (suite *MixedClusterSuite) BaseSetup(options ...config.BlockchainOption) {
+	previousImage := os.Getenv(PreviousGoNodeImageEnvKey)
+	if previousImage == "" {
+		suite.T().Skipf("Image of the previous Go node release is not set by %s", PreviousGoNodeImageEnvKey)
+	}
+	suite.MainCtx, suite.Cancel = context.WithCancel(context.Background())
+	suiteName := strcase.KebabCase(suite.T().Name())
+	cfg, err := config.NewBlockchainConfig(options...)
+	suite.Require().NoError(err, "couldn't create blockchain config")
+	suite.Cfg = cfg.TestConfig()
+
+	goConfigurator, err := config.NewGoConfigurator(suiteName, cfg)
+	suite.Require().NoError(err, "couldn't create Go configurator")
+	previousConfigurator, err := config.NewGoConfigurator(suiteName, cfg)
+	suite.Require().NoError(err, "couldn't create configurator of previous Go node")
+	previousConfigurator.WithName(previousGoNodeName).WithImage(previousImage).WithPeers(goNodeName).
+		WithoutMining()
+	scalaConfigurator, err := config.NewScalaConfigurator(suiteName, cfg)
+	suite.Require().NoError(err, "couldn't create Scala configurator")
+	scalaConfigurator.WithGoNode(goNodeName).WithGoNode(previousGoNodeName)
+	docker, err := d.NewDocker(suiteName)
+	suite.Require().NoError(err, "couldn't create Docker pool")
+	suite.Docker = docker
+
+	if sErr := docker.StartNodes(suite.MainCtx, goConfigurator, scalaConfigurator); sErr != nil {
+		docker.Finish(suite.Cancel)
+		suite.Require().NoError(sErr, "couldn't start nodes")
+	}
+	if sErr := docker.StartExtraGoNode(suite.MainCtx, previousGoNodeName, previousConfigurator); sErr != nil {
+		docker.Finish(suite.Cancel)
+		suite.Require().NoError(sErr, "couldn't start previous Go node")
+	}
+
+	suite.Clients = clients.NewClusterClients(suite.MainCtx, suite.T(),
+		clients.ClusterNode{Name: goNodeName, Implementation: clients.NodeGo, Ports: docker.GoNode().Ports()},
+		clients.ClusterNode{
+			Name:           previousGoNodeName,
+			Implementation: clients.NodeGo,
+			Ports:          docker.ExtraNode(previousGoNodeName).Ports(),
+		},
+		clients.ClusterNode{Name: scalaNodeName, Implementation: clients.NodeScala, Ports: docker.ScalaNode().Ports()},
+	)
+	suite.Clients.Handshake()
+}
+
+func (suite *MixedClusterSuite) SetupSuite() {
+	suite.BaseSetup()
+}
+
+func (suite *MixedClusterSuite) TearDownSuite() {
+	if suite.Clients == nil { // Suite was skipped.
+		return
+	}
+	suite.Clients.WaitForStateHashEquality(suite.T())
+	suite.Clients.Close(suite.T())
+	suite.Docker.Finish(suite.Cancel)
+}
+
+func (suite *MixedClusterSuite) SetupTest() {
+	const waitForConnectedPeersTimeout = 30 * time.Second
+	err := suite.Clients.WaitForConnectedPeers(suite.MainCtx, waitForConnectedPeersTimeout)
+	suite.Require().NoError(err, "not all peers are connected or an unexpected error occurred")
+	suite.Clients.WaitForHeight(suite.T(), 2) // Wait for nodes to start mining
+	suite.Clients.SendStartMessage(suite.T())
+}
+
+func (suite *MixedClusterSuite) TearDownTest() {
+	suite.Clients.SendEndMessage(suite.T())
+}
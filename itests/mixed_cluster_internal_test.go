@@ -0,0 +1,68 @@
+package itests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/wavesplatform/gowaves/itests/fixtures"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+const (
+	mixedClusterTxTimeout = time.Minute
+	mixedClusterFee       = 100000
+)
+
+type MixedClusterSuite struct {
+	fixtures.MixedClusterSuite
+}
+
+// broadcast signs the transaction and sends it to the node with the given index, then waits for it on all nodes.
+func (s *MixedClusterSuite) broadcast(node int, tx proto.Transaction, sk crypto.SecretKey) crypto.Digest {
+	scheme := s.Cfg.BlockchainSettings.AddressSchemeCharacter
+	s.Require().NoError(tx.Sign(scheme, sk), "failed to sign transaction")
+	id, err := tx.GetID(scheme)
+	s.Require().NoError(err, "failed to get transaction ID")
+	txID, err := crypto.NewDigestFromBytes(id)
+	s.Require().NoError(err, "invalid transaction ID")
+	_, err = s.Clients.Clients[node].HTTPClient.TransactionBroadcast(tx)
+	s.Require().NoErrorf(err, "failed to broadcast transaction to node %q", s.Clients.Nodes[node].Name)
+	s.Require().NoError(s.Clients.WaitForTransaction(txID, mixedClusterTxTimeout), "transaction is not applied")
+	return txID
+}
+
+// TestTransfersThroughAllNodes sends transactions to each node of the cluster, so blocks with them are generated and
+// applied by nodes of all versions. State hashes equality is checked on suite tear down.
+func (s *MixedClusterSuite) TestTransfersThroughAllNodes() {
+	from, to := s.Cfg.Accounts[0], s.Cfg.Accounts[1]
+	for i := range s.Clients.Clients {
+		tx := proto.NewUnsignedTransferWithProofs(3, from.PublicKey, proto.NewOptionalAssetWaves(),
+			proto.NewOptionalAssetWaves(), uint64(time.Now().UnixMilli()), 1000000+uint64(i), mixedClusterFee,
+			proto.NewRecipientFromAddress(to.Address), nil)
+		s.broadcast(i, tx, from.SecretKey)
+	}
+	s.Clients.WaitForStateHashEquality(s.T())
+}
+
+func (s *MixedClusterSuite) TestIssueAndTransferAsset() {
+	const issueFee = 100000000
+	from, to := s.Cfg.Accounts[0], s.Cfg.Accounts[2]
+	issue := proto.NewUnsignedIssueWithProofs(3, from.PublicKey, "Mixed", "Asset issued in mixed cluster",
+		1000000, 2, true, nil, uint64(time.Now().UnixMilli()), issueFee)
+	assetID := s.broadcast(0, issue, from.SecretKey)
+
+	last := len(s.Clients.Clients) - 1
+	transfer := proto.NewUnsignedTransferWithProofs(3, from.PublicKey, *proto.NewOptionalAssetFromDigest(assetID),
+		proto.NewOptionalAssetWaves(), uint64(time.Now().UnixMilli()), 1000, mixedClusterFee,
+		proto.NewRecipientFromAddress(to.Address), nil)
+	s.broadcast(last, transfer, from.SecretKey)
+	s.Clients.WaitForStateHashEquality(s.T())
+}
+
+func TestMixedClusterSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(MixedClusterSuite))
+}
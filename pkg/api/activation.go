@@ -0,0 +1,148 @@
+package api
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+)
+
+const (
+	blockchainStatusVoting    = "VOTING"
+	blockchainStatusApproved  = "APPROVED"
+	blockchainStatusActivated = "ACTIVATED"
+
+	nodeStatusImplemented    = "IMPLEMENTED"
+	nodeStatusNotImplemented = "NOT_IMPLEMENTED"
+)
+
+type featureActivationStatus struct {
+	ID                        int16         `json:"id"`
+	Description               string        `json:"description"`
+	BlockchainStatus          string        `json:"blockchainStatus"`
+	NodeStatus                string        `json:"nodeStatus"`
+	ActivationHeight          *proto.Height `json:"activationHeight,omitempty"`
+	SupportingBlocks          uint64        `json:"supportingBlocks"`
+	VotesRequired             uint64        `json:"votesRequired"`
+	BlocksRemaining           uint64        `json:"blocksRemaining"`
+	ProjectedActivationHeight *proto.Height `json:"projectedActivationHeight,omitempty"`
+}
+
+type activationStatusResponse struct {
+	Height          proto.Height              `json:"height"`
+	VotingInterval  uint64                    `json:"votingInterval"`
+	VotingThreshold uint64                    `json:"votingThreshold"`
+	NextCheck       proto.Height              `json:"nextCheck"`
+	Features        []featureActivationStatus `json:"features"`
+}
+
+// ActivationStatus returns the status of all known features with voting statistics of the current voting period.
+// Projected activation height is reported for approved features and for features that are going to collect enough
+// votes by the end of the period at the current rate of voting.
+func (a *NodeApi) ActivationStatus(w http.ResponseWriter, _ *http.Request) error {
+	height, err := a.state.Height()
+	if err != nil {
+		return errors.Wrap(err, "failed to get height")
+	}
+	sets, err := a.state.BlockchainSettings()
+	if err != nil {
+		return errors.Wrap(err, "failed to get blockchain settings")
+	}
+	interval := sets.ActivationWindowSize(height)
+	res := activationStatusResponse{
+		Height:          height,
+		VotingInterval:  interval,
+		VotingThreshold: sets.VotesForFeatureElection(height),
+		NextCheck:       height - height%interval + interval,
+	}
+	ids, err := a.allFeatures()
+	if err != nil {
+		return err
+	}
+	res.Features = make([]featureActivationStatus, len(ids))
+	for i, id := range ids {
+		res.Features[i], err = a.featureActivationStatus(id, res)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get status of feature %d", id)
+		}
+	}
+	if err = trySendJson(w, res); err != nil {
+		return errors.Wrap(err, "ActivationStatus")
+	}
+	return nil
+}
+
+// allFeatures returns sorted IDs of features known by the node or voted in the blockchain.
+func (a *NodeApi) allFeatures() ([]int16, error) {
+	ids, err := a.state.AllFeatures()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get blockchain features")
+	}
+	for f := range settings.FeaturesInfo {
+		if !slices.Contains(ids, int16(f)) {
+			ids = append(ids, int16(f))
+		}
+	}
+	slices.Sort(ids)
+	return ids, nil
+}
+
+func (a *NodeApi) featureActivationStatus(id int16, r activationStatusResponse) (featureActivationStatus, error) {
+	res := featureActivationStatus{
+		ID:               id,
+		NodeStatus:       nodeStatusNotImplemented,
+		BlockchainStatus: blockchainStatusVoting,
+	}
+	if info, ok := settings.FeaturesInfo[settings.Feature(id)]; ok {
+		res.Description = info.Description
+		if info.Implemented {
+			res.NodeStatus = nodeStatusImplemented
+		}
+	}
+	activated, err := a.state.IsActiveAtHeight(id, r.Height)
+	if err != nil {
+		return res, err
+	}
+	if activated {
+		h, ahErr := a.state.ActivationHeight(id)
+		if ahErr != nil {
+			return res, ahErr
+		}
+		res.BlockchainStatus = blockchainStatusActivated
+		res.ActivationHeight = &h
+		return res, nil
+	}
+	approved, err := a.state.IsApprovedAtHeight(id, r.Height)
+	if err != nil {
+		return res, err
+	}
+	if approved {
+		ah, ahErr := a.state.ApprovalHeight(id)
+		if ahErr != nil {
+			return res, ahErr
+		}
+		projected := ah + r.VotingInterval
+		res.BlockchainStatus = blockchainStatusApproved
+		res.ProjectedActivationHeight = &projected
+		return res, nil
+	}
+	votes, err := a.state.VotesNumAtHeight(id, r.Height)
+	if err != nil {
+		return res, err
+	}
+	res.SupportingBlocks = votes
+	res.BlocksRemaining = r.NextCheck - r.Height
+	if votes < r.VotingThreshold {
+		res.VotesRequired = r.VotingThreshold - votes
+	}
+	elapsed := r.VotingInterval - res.BlocksRemaining
+	onTrack := elapsed > 0 && votes+votes*res.BlocksRemaining/elapsed >= r.VotingThreshold
+	if votes >= r.VotingThreshold || onTrack {
+		projected := r.NextCheck + r.VotingInterval
+		res.ProjectedActivationHeight = &projected
+	}
+	return res, nil
+}
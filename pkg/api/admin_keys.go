@@ -0,0 +1,379 @@
+package api
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/mr-tron/base58"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apiErrs "github.com/wavesplatform/gowaves/pkg/api/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/wallet"
+)
+
+const (
+	// configuredAPIKeyName is the name of the API key set by node's configuration. Revocation of the key is saved
+	// to the storage, so the key stays revoked after restart until it's changed in the configuration.
+	configuredAPIKeyName = "default"
+	generatedAPIKeySize  = 32
+)
+
+var (
+	errAPIKeyNotFound       = errors.New("API key not found")
+	errAPIKeysNotPersistent = apiErrs.NewCustomValidationError("API keys storage is not configured")
+)
+
+type keysCipher interface {
+	Encrypt(plaintext []byte) ([]byte, error)
+	Decrypt(ciphertext []byte) ([]byte, error)
+}
+
+// adminKey is the stored API key, only the hash of the key is kept.
+type adminKey struct {
+	Name    string        `json:"name"`
+	Hash    crypto.Digest `json:"hash"`
+	Created time.Time     `json:"created"`
+	// Revoked is set only for the record of the revoked configured key.
+	Revoked bool `json:"revoked,omitempty"`
+	// ephemeral keys live only in memory for the lifetime of the node, they are never saved and can't be revoked.
+	ephemeral bool
+}
+
+// adminKeys is the set of API keys giving access to protected methods. The set starts with the key from node's
+// configuration, other keys are added and revoked at runtime and saved to the encrypted file if the storage is set.
+type adminKeys struct {
+	mu     sync.RWMutex
+	keys   []adminKey
+	path   string
+	cipher keysCipher
+	// revokedConfigured is the hash of the configured key revoked at runtime.
+	revokedConfigured *crypto.Digest
+}
+
+func newAdminKeys(apiKey string) (*adminKeys, error) {
+	ak := &adminKeys{}
+	if len(apiKey) == 0 {
+		return ak, nil
+	}
+	d, err := crypto.SecureHash([]byte(apiKey))
+	if err != nil {
+		return nil, err
+	}
+	ak.keys = append(ak.keys, adminKey{Name: configuredAPIKeyName, Hash: d})
+	return ak, nil
+}
+
+// setStorage loads the keys from the file encrypted with the password and saves further changes to it.
+// Missing file is created on the first change.
+func (k *adminKeys) setStorage(path string, password []byte) error {
+	if len(password) == 0 {
+		return errors.New("empty password")
+	}
+	c := wallet.NewCrypt(password)
+	data, err := os.ReadFile(filepath.Clean(path))
+	var stored []adminKey
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return err
+	default:
+		plain, dErr := c.Decrypt(data)
+		if dErr != nil {
+			return dErr
+		}
+		if uErr := json.Unmarshal(plain, &stored); uErr != nil {
+			return errors.New("invalid password or corrupted file")
+		}
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	for _, sk := range stored {
+		if sk.Revoked {
+			k.dropConfigured(sk.Hash)
+			continue
+		}
+		if vErr := k.validate(sk); vErr != nil {
+			return errors.Wrapf(vErr, "invalid stored API key '%s'", sk.Name)
+		}
+		k.keys = append(k.keys, sk)
+	}
+	k.path = path
+	k.cipher = c
+	return nil
+}
+
+// save writes runtime keys to the file, must be called under the lock.
+func (k *adminKeys) save() error {
+	stored := make([]adminKey, 0, len(k.keys))
+	for _, ak := range k.keys {
+		if ak.Name != configuredAPIKeyName && !ak.ephemeral {
+			stored = append(stored, ak)
+		}
+	}
+	if k.revokedConfigured != nil {
+		stored = append(stored, adminKey{Name: configuredAPIKeyName, Hash: *k.revokedConfigured, Revoked: true})
+	}
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return err
+	}
+	enc, err := k.cipher.Encrypt(data)
+	if err != nil {
+		return err
+	}
+	tmp := k.path + ".tmp"
+	if wErr := os.WriteFile(tmp, enc, 0600); wErr != nil {
+		return wErr
+	}
+	return os.Rename(tmp, k.path)
+}
+
+// validate checks that the key could be added to the set, must be called under the lock.
+func (k *adminKeys) validate(ak adminKey) error {
+	if ak.Name == "" {
+		return errors.New("empty API key name")
+	}
+	if !ak.ephemeral && (ak.Name == configuredAPIKeyName || ak.Name == ipcAPIKeyName) {
+		return errors.Errorf("API key name '%s' is reserved", ak.Name)
+	}
+	for _, e := range k.keys {
+		if e.Name == ak.Name {
+			return errors.Errorf("API key '%s' already exists", ak.Name)
+		}
+		if e.Hash == ak.Hash {
+			return errors.Errorf("API key is already added as '%s'", e.Name)
+		}
+	}
+	return nil
+}
+
+func (k *adminKeys) check(key string) error {
+	d, err := crypto.SecureHash([]byte(key))
+	if err != nil {
+		return errors.Wrap(err, "failed to calculate secure hash for API key")
+	}
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	for _, ak := range k.keys {
+		if ak.Hash == d {
+			return nil
+		}
+	}
+	return apiErrs.ApiKeyNotValid
+}
+
+// nameOf returns the name of the key with the hash or an empty string if there is no such key.
+func (k *adminKeys) nameOf(hash crypto.Digest) string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	for _, ak := range k.keys {
+		if ak.Hash == hash {
+			return ak.Name
+		}
+	}
+	return ""
+}
+
+func generateAPIKey() (string, error) {
+	b := make([]byte, generatedAPIKeySize)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "failed to generate API key")
+	}
+	return base58.Encode(b), nil
+}
+
+// add adds the key with the name to the set. If the key is empty, the random key is generated and returned.
+func (k *adminKeys) add(name, key string) (string, error) {
+	if key == "" {
+		var err error
+		if key, err = generateAPIKey(); err != nil {
+			return "", err
+		}
+	}
+	d, err := crypto.SecureHash([]byte(key))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to calculate secure hash for API key")
+	}
+	ak := adminKey{Name: name, Hash: d, Created: time.Now().UTC()}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.cipher == nil {
+		return "", errAPIKeysNotPersistent
+	}
+	if vErr := k.validate(ak); vErr != nil {
+		return "", apiErrs.NewCustomValidationError(vErr.Error())
+	}
+	k.keys = append(k.keys, ak)
+	if sErr := k.save(); sErr != nil {
+		k.keys = k.keys[:len(k.keys)-1]
+		return "", errors.Wrap(sErr, "failed to save API keys")
+	}
+	zap.S().Infof("API key '%s' is added", name)
+	return key, nil
+}
+
+// addEphemeral adds the random key with the name to the set without saving it to the storage.
+// The key is returned and stays valid until it is removed.
+func (k *adminKeys) addEphemeral(name string) (string, error) {
+	key, err := generateAPIKey()
+	if err != nil {
+		return "", err
+	}
+	d, err := crypto.SecureHash([]byte(key))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to calculate secure hash for API key")
+	}
+	ak := adminKey{Name: name, Hash: d, Created: time.Now().UTC(), ephemeral: true}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if vErr := k.validate(ak); vErr != nil {
+		return "", vErr
+	}
+	k.keys = append(k.keys, ak)
+	return key, nil
+}
+
+// removeEphemeral removes the ephemeral key with the name from the set.
+func (k *adminKeys) removeEphemeral(name string) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.keys = slices.DeleteFunc(k.keys, func(ak adminKey) bool { return ak.ephemeral && ak.Name == name })
+}
+
+// dropConfigured removes the configured key if it has the hash, must be called under the lock.
+// Revocation of the previous configured key is forgotten if the key has been changed.
+func (k *adminKeys) dropConfigured(hash crypto.Digest) {
+	for i, ak := range k.keys {
+		if ak.Name == configuredAPIKeyName && ak.Hash == hash {
+			k.keys = slices.Delete(k.keys, i, i+1)
+			k.revokedConfigured = &hash
+			return
+		}
+	}
+}
+
+// revoke removes the key with the name from the set. The last persistent key can't be revoked.
+func (k *adminKeys) revoke(name string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.cipher == nil {
+		return errAPIKeysNotPersistent
+	}
+	i := -1
+	persistent := 0
+	for j, ak := range k.keys {
+		if ak.Name == name {
+			i = j
+		}
+		if !ak.ephemeral {
+			persistent++
+		}
+	}
+	if i < 0 {
+		return errAPIKeyNotFound
+	}
+	if k.keys[i].ephemeral {
+		return apiErrs.NewCustomValidationError("ephemeral API key can't be revoked")
+	}
+	if persistent == 1 {
+		return apiErrs.NewCustomValidationError("the last API key can't be revoked")
+	}
+	prev, prevRevoked := k.keys, k.revokedConfigured
+	if name == configuredAPIKeyName {
+		h := prev[i].Hash
+		k.revokedConfigured = &h
+	}
+	k.keys = make([]adminKey, 0, len(prev)-1)
+	k.keys = append(append(k.keys, prev[:i]...), prev[i+1:]...)
+	if err := k.save(); err != nil {
+		k.keys, k.revokedConfigured = prev, prevRevoked
+		return errors.Wrap(err, "failed to save API keys")
+	}
+	zap.S().Infof("API key '%s' is revoked", name)
+	return nil
+}
+
+type adminKeyInfo struct {
+	Name    string     `json:"name"`
+	Created *time.Time `json:"created,omitempty"`
+}
+
+func (k *adminKeys) list() []adminKeyInfo {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	res := make([]adminKeyInfo, len(k.keys))
+	for i, ak := range k.keys {
+		res[i] = adminKeyInfo{Name: ak.Name}
+		if !ak.Created.IsZero() {
+			created := ak.Created
+			res[i].Created = &created
+		}
+	}
+	return res
+}
+
+// SetAPIKeysStorage loads API keys added at runtime from the file encrypted with the password, the password must not
+// be empty. Without the storage API keys can't be added or revoked.
+func (a *App) SetAPIKeysStorage(path string, password []byte) error {
+	if err := a.keys.setStorage(path, password); err != nil {
+		return errors.Wrapf(err, "failed to load API keys from '%s'", path)
+	}
+	return nil
+}
+
+// apiKeys returns names of API keys.
+func (a *NodeApi) apiKeys(w http.ResponseWriter, _ *http.Request) error {
+	if err := trySendJson(w, a.app.keys.list()); err != nil {
+		return errors.Wrap(err, "apiKeys")
+	}
+	return nil
+}
+
+type addAPIKeyRequest struct {
+	Name string `json:"name"`
+	Key  string `json:"key,omitempty"`
+}
+
+// addAPIKey adds the API key, the key is generated if it's not set in the request.
+func (a *NodeApi) addAPIKey(w http.ResponseWriter, r *http.Request) error {
+	var req addAPIKeyRequest
+	if err := tryParseJson(r.Body, &req); err != nil {
+		return apiErrs.NewCustomValidationError(err.Error())
+	}
+	key, err := a.app.keys.add(req.Name, req.Key)
+	if err != nil {
+		return err
+	}
+	res := addAPIKeyRequest{Name: req.Name}
+	if req.Key == "" {
+		res.Key = key
+	}
+	if sErr := trySendJson(w, res); sErr != nil {
+		return errors.Wrap(sErr, "addAPIKey")
+	}
+	return nil
+}
+
+// revokeAPIKey revokes the API key by its name.
+func (a *NodeApi) revokeAPIKey(w http.ResponseWriter, r *http.Request) error {
+	name := chi.URLParam(r, "name")
+	if err := a.app.keys.revoke(name); err != nil {
+		if errors.Is(err, errAPIKeyNotFound) {
+			return apiErrs.NewCustomValidationError(err.Error())
+		}
+		return err
+	}
+	if err := trySendJson(w, adminKeyInfo{Name: name}); err != nil {
+		return errors.Wrap(err, "revokeAPIKey")
+	}
+	return nil
+}
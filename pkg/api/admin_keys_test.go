@@ -0,0 +1,105 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apiErrs "github.com/wavesplatform/gowaves/pkg/api/errors"
+	"github.com/wavesplatform/gowaves/pkg/services"
+)
+
+func TestNodeApi_APIKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api-keys")
+	app, err := NewApp("api-key", nil, services.Services{})
+	require.NoError(t, err)
+	a := NewNodeAPI(app, nil)
+
+	add := func(body string) (addAPIKeyRequest, error) {
+		resp := httptest.NewRecorder()
+		aErr := a.addAPIKey(resp, httptest.NewRequest(http.MethodPost, "/go/api-keys", strings.NewReader(body)))
+		var res addAPIKeyRequest
+		if aErr == nil {
+			require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &res))
+		}
+		return res, aErr
+	}
+	revoke := func(name string) error {
+		req := httptest.NewRequest(http.MethodDelete, "/go/api-keys/"+name, nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("name", name)
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		return a.revokeAPIKey(httptest.NewRecorder(), req)
+	}
+
+	_, err = add(`{"name":"alice","key":"alice-key"}`)
+	assert.ErrorIs(t, err, errAPIKeysNotPersistent)
+
+	assert.Error(t, app.SetAPIKeysStorage(path, nil))
+	require.NoError(t, app.SetAPIKeysStorage(path, []byte("password")))
+	_, err = add(`{"name":"alice","key":"alice-key"}`)
+	require.NoError(t, err)
+	generated, err := add(`{"name":"bob"}`)
+	require.NoError(t, err)
+	assert.NotEmpty(t, generated.Key)
+	_, err = add(`{"name":"alice","key":"other-key"}`)
+	assert.ErrorAs(t, err, new(*apiErrs.CustomValidationError))
+	_, err = add(`{"name":"carol","key":"api-key"}`)
+	assert.ErrorAs(t, err, new(*apiErrs.CustomValidationError))
+	_, err = add(`{"name":"ipc","key":"ipc-key"}`)
+	assert.ErrorAs(t, err, new(*apiErrs.CustomValidationError))
+	_, err = add(`{"name":"default","key":"default-key"}`)
+	assert.ErrorAs(t, err, new(*apiErrs.CustomValidationError))
+
+	assert.NoError(t, app.checkAuth("api-key"))
+	assert.NoError(t, app.checkAuth("alice-key"))
+	assert.NoError(t, app.checkAuth(generated.Key))
+	assert.ErrorIs(t, app.checkAuth("unknown"), apiErrs.ApiKeyNotValid)
+
+	resp := httptest.NewRecorder()
+	require.NoError(t, a.apiKeys(resp, httptest.NewRequest(http.MethodGet, "/go/api-keys", nil)))
+	var list []adminKeyInfo
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &list))
+	require.Len(t, list, 3)
+	assert.Equal(t, configuredAPIKeyName, list[0].Name)
+	assert.Nil(t, list[0].Created)
+	assert.Equal(t, "alice", list[1].Name)
+	assert.NotNil(t, list[1].Created)
+	assert.NotContains(t, resp.Body.String(), "alice-key")
+
+	require.NoError(t, revoke("alice"))
+	assert.ErrorIs(t, app.checkAuth("alice-key"), apiErrs.ApiKeyNotValid)
+	assert.ErrorAs(t, revoke("alice"), new(*apiErrs.CustomValidationError))
+	require.NoError(t, revoke(configuredAPIKeyName))
+	assert.ErrorIs(t, app.checkAuth("api-key"), apiErrs.ApiKeyNotValid)
+
+	// Keys are restored from the file by another node without the configured key.
+	restarted, err := NewApp("", nil, services.Services{})
+	require.NoError(t, err)
+	assert.Error(t, restarted.SetAPIKeysStorage(path, []byte("wrong")))
+	require.NoError(t, restarted.SetAPIKeysStorage(path, []byte("password")))
+	assert.NoError(t, restarted.checkAuth(generated.Key))
+	assert.ErrorIs(t, restarted.checkAuth("alice-key"), apiErrs.ApiKeyNotValid)
+	assert.ErrorIs(t, restarted.checkAuth("api-key"), apiErrs.ApiKeyNotValid)
+	assert.ErrorAs(t, restarted.keys.revoke("bob"), new(*apiErrs.CustomValidationError))
+
+	// Revoked configured key stays revoked after restart until it's changed.
+	same, err := NewApp("api-key", nil, services.Services{})
+	require.NoError(t, err)
+	require.NoError(t, same.SetAPIKeysStorage(path, []byte("password")))
+	assert.ErrorIs(t, same.checkAuth("api-key"), apiErrs.ApiKeyNotValid)
+	assert.NoError(t, same.checkAuth(generated.Key))
+	changed, err := NewApp("new-api-key", nil, services.Services{})
+	require.NoError(t, err)
+	require.NoError(t, changed.SetAPIKeysStorage(path, []byte("password")))
+	assert.NoError(t, changed.checkAuth("new-api-key"))
+	assert.NoError(t, changed.checkAuth(generated.Key))
+}
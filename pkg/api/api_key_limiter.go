@@ -0,0 +1,165 @@
+package api
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/throttled/throttled/v2"
+	"github.com/throttled/throttled/v2/store/memstore"
+	"go.uber.org/zap"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+)
+
+const apiKeyUsageRetentionHours = 24
+
+type apiKeyHourUsage struct {
+	Hour     time.Time `json:"hour"`
+	Calls    uint64    `json:"calls"`
+	Rejected uint64    `json:"rejected"`
+}
+
+type apiKeyUsage struct {
+	Name  string            `json:"name"`
+	Hours []apiKeyHourUsage `json:"hours"`
+}
+
+type apiKeyEntry struct {
+	name    string
+	limiter *throttled.GCRARateLimiterCtx
+	hours   []apiKeyHourUsage // ordered by hour, the last one is the current hour
+}
+
+// apiKeyLimiter limits requests made with partners' API keys by the quotas of the keys and accounts the number of
+// calls per key per hour. Requests without known API key are passed to the common rate limiter.
+type apiKeyLimiter struct {
+	mu    sync.Mutex
+	keys  map[crypto.Digest]*apiKeyEntry
+	order []*apiKeyEntry
+	now   func() time.Time
+}
+
+func newAPIKeyLimiter(quotas []APIKeyQuota) (*apiKeyLimiter, error) {
+	l := &apiKeyLimiter{
+		keys:  make(map[crypto.Digest]*apiKeyEntry, len(quotas)),
+		order: make([]*apiKeyEntry, 0, len(quotas)),
+		now:   time.Now,
+	}
+	for _, q := range quotas {
+		if q.MaxRequestsPerSecond <= 0 {
+			return nil, errors.Errorf("invalid requests per second value %d of API key '%s'",
+				q.MaxRequestsPerSecond, q.Name)
+		}
+		d, err := crypto.SecureHash([]byte(q.Key))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to hash API key '%s'", q.Name)
+		}
+		if _, ok := l.keys[d]; ok {
+			return nil, errors.Errorf("API key '%s' is duplicated", q.Name)
+		}
+		store, err := memstore.New(1) // Only one key is used by each limiter.
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create memstore for API key '%s'", q.Name)
+		}
+		quota := throttled.RateQuota{MaxRate: throttled.PerSec(q.MaxRequestsPerSecond), MaxBurst: q.MaxBurst}
+		rl, err := throttled.NewGCRARateLimiterCtx(throttled.WrapStoreWithContext(store), quota)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create rate limiter for API key '%s'", q.Name)
+		}
+		e := &apiKeyEntry{name: q.Name, limiter: rl}
+		l.keys[d] = e
+		l.order = append(l.order, e)
+	}
+	return l, nil
+}
+
+// middleware returns the middleware that applies the quota of the API key if the key is known,
+// otherwise the request is passed through the fallback middleware. Fallback could be nil.
+func (l *apiKeyLimiter) middleware(fallback func(next http.Handler) http.Handler) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		unknownKey := next
+		if fallback != nil {
+			unknownKey = fallback(next)
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			e := l.entry(r.Header.Get("X-API-Key"))
+			if e == nil {
+				unknownKey.ServeHTTP(w, r)
+				return
+			}
+			limited, res, err := e.limiter.RateLimitCtx(r.Context(), e.name, 1)
+			if err != nil {
+				zap.S().Errorf("Failed to apply rate limit of API key '%s': %v", e.name, err)
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+			l.account(e, limited)
+			w.Header().Set("X-RateLimit-Limit", strconv.Itoa(res.Limit))
+			w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(res.Remaining))
+			if limited {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(res.RetryAfter.Seconds()))))
+				http.Error(w, "limit exceeded", http.StatusTooManyRequests)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func (l *apiKeyLimiter) entry(key string) *apiKeyEntry {
+	if key == "" {
+		return nil
+	}
+	d, err := crypto.SecureHash([]byte(key))
+	if err != nil {
+		return nil
+	}
+	return l.keys[d]
+}
+
+func (l *apiKeyLimiter) account(e *apiKeyEntry, rejected bool) {
+	hour := l.now().UTC().Truncate(time.Hour)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if n := len(e.hours); n == 0 || !e.hours[n-1].Hour.Equal(hour) {
+		e.hours = append(e.hours, apiKeyHourUsage{Hour: hour})
+	}
+	if n := len(e.hours); n > apiKeyUsageRetentionHours {
+		e.hours = append(e.hours[:0], e.hours[n-apiKeyUsageRetentionHours:]...)
+	}
+	cur := &e.hours[len(e.hours)-1]
+	if rejected {
+		cur.Rejected++
+	} else {
+		cur.Calls++
+	}
+}
+
+// usage returns calls per key per hour for the last day.
+func (l *apiKeyLimiter) usage() []apiKeyUsage {
+	oldest := l.now().UTC().Truncate(time.Hour).Add(-(apiKeyUsageRetentionHours - 1) * time.Hour)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	r := make([]apiKeyUsage, len(l.order))
+	for i, e := range l.order {
+		hours := make([]apiKeyHourUsage, 0, len(e.hours))
+		for _, h := range e.hours {
+			if !h.Hour.Before(oldest) {
+				hours = append(hours, h)
+			}
+		}
+		r[i] = apiKeyUsage{Name: e.name, Hours: hours}
+	}
+	return r
+}
+
+func (l *apiKeyLimiter) usageHandler(w http.ResponseWriter, _ *http.Request) error {
+	if err := trySendJson(w, l.usage()); err != nil {
+		return errors.Wrap(err, "apiKeysUsage")
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyLimiter(t *testing.T) {
+	l, err := newAPIKeyLimiter([]APIKeyQuota{
+		{Name: "a", Key: "key-a", MaxRequestsPerSecond: 1, MaxBurst: 0},
+		{Name: "b", Key: "key-b", MaxRequestsPerSecond: 1, MaxBurst: 2},
+	})
+	require.NoError(t, err)
+	now := time.Date(2024, 5, 1, 10, 15, 0, 0, time.UTC)
+	l.now = func() time.Time { return now }
+
+	fallbackCalls := 0
+	fallback := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fallbackCalls++
+			next.ServeHTTP(w, r)
+		})
+	}
+	h := l.middleware(fallback)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	do := func(key string) int {
+		r := httptest.NewRequest(http.MethodGet, "/blocks/height", nil)
+		if key != "" {
+			r.Header.Set("X-API-Key", key)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusOK, do("key-a"))
+	assert.Equal(t, http.StatusTooManyRequests, do("key-a"))
+	for range 3 {
+		assert.Equal(t, http.StatusOK, do("key-b"))
+	}
+	assert.Equal(t, http.StatusOK, do(""))
+	assert.Equal(t, http.StatusOK, do("unknown"))
+	assert.Equal(t, 2, fallbackCalls)
+
+	hour := now.Truncate(time.Hour)
+	assert.Equal(t, []apiKeyUsage{
+		{Name: "a", Hours: []apiKeyHourUsage{{Hour: hour, Calls: 1, Rejected: 1}}},
+		{Name: "b", Hours: []apiKeyHourUsage{{Hour: hour, Calls: 3}}},
+	}, l.usage())
+
+	now = now.Add(25 * time.Hour)
+	assert.Equal(t, []apiKeyUsage{{Name: "a", Hours: []apiKeyHourUsage{}}, {Name: "b", Hours: []apiKeyHourUsage{}}},
+		l.usage())
+}
+
+func TestAPIKeyLimiterInvalidQuota(t *testing.T) {
+	_, err := newAPIKeyLimiter([]APIKeyQuota{{Name: "a", Key: "k", MaxRequestsPerSecond: 0}})
+	assert.EqualError(t, err, "invalid requests per second value 0 of API key 'a'")
+	_, err = newAPIKeyLimiter([]APIKeyQuota{
+		{Name: "a", Key: "k", MaxRequestsPerSecond: 1},
+		{Name: "b", Key: "k", MaxRequestsPerSecond: 1},
+	})
+	assert.EqualError(t, err, "API key 'b' is duplicated")
+}
@@ -3,17 +3,23 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/pkg/errors"
 
+	apiErrs "github.com/wavesplatform/gowaves/pkg/api/errors"
 	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/errs"
 	"github.com/wavesplatform/gowaves/pkg/miner/scheduler"
 	"github.com/wavesplatform/gowaves/pkg/node/messages"
 	"github.com/wavesplatform/gowaves/pkg/node/peers"
 	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/ride"
 	"github.com/wavesplatform/gowaves/pkg/services"
+	"github.com/wavesplatform/gowaves/pkg/settings"
 	"github.com/wavesplatform/gowaves/pkg/state"
+	"github.com/wavesplatform/gowaves/pkg/state/stateerr"
 	"github.com/wavesplatform/gowaves/pkg/types"
 )
 
@@ -45,15 +51,17 @@ func defaultAppSettings() *appSettings {
 }
 
 type App struct {
-	hashedApiKey  crypto.Digest
-	apiKeyEnabled bool
-	scheduler     SchedulerEmits
-	utx           types.UtxPool
-	state         state.State
-	peers         peers.PeerManager
-	sync          types.StateSync
-	services      services.Services
-	settings      *appSettings
+	keys      *adminKeys
+	scheduler SchedulerEmits
+	utx       types.UtxPool
+	state     state.State
+	peers     peers.PeerManager
+	sync      types.StateSync
+	services  services.Services
+	settings  *appSettings
+	progress  *syncProgress
+	// maxClockDrift is reported as the clock warning in the node status, zero disables the check.
+	maxClockDrift time.Duration
 }
 
 func NewApp(apiKey string, scheduler SchedulerEmits, services services.Services) (*App, error) {
@@ -64,47 +72,139 @@ func newApp(apiKey string, scheduler SchedulerEmits, services services.Services,
 	if settings == nil {
 		settings = defaultAppSettings()
 	}
-	digest, err := crypto.SecureHash([]byte(apiKey))
+	keys, err := newAdminKeys(apiKey)
 	if err != nil {
 		return nil, err
 	}
 
 	return &App{
-		hashedApiKey:  digest,
-		apiKeyEnabled: len(apiKey) > 0,
-		state:         services.State,
-		scheduler:     scheduler,
-		utx:           services.UtxPool,
-		peers:         services.Peers,
-		services:      services,
-		settings:      settings,
+		keys:      keys,
+		state:     services.State,
+		scheduler: scheduler,
+		utx:       services.UtxPool,
+		peers:     services.Peers,
+		services:  services,
+		settings:  settings,
+		progress:  newSyncProgress(),
 	}, nil
 }
 
-func (a *App) TransactionsBroadcast(ctx context.Context, b []byte) (proto.Transaction, error) {
+// TransactionDuplicate is the status of broadcasted transaction which is already known by the node.
+type TransactionDuplicate struct {
+	Duplicate         bool                     `json:"duplicate"`
+	Status            string                   `json:"status"`
+	Height            proto.Height             `json:"height,omitempty"`
+	ApplicationStatus *proto.TransactionStatus `json:"applicationStatus,omitempty"`
+}
+
+// TransactionsBroadcast validates the transaction and puts it into UTX pool. If the transaction is already in UTX
+// pool or in the blockchain, it's not broadcasted again and its current status is returned instead of an error.
+// In async mode only stateless validation is performed before the transaction is enqueued, the result of its
+// insertion into UTX pool is not awaited.
+func (a *App) TransactionsBroadcast(
+	ctx context.Context, b []byte, async bool,
+) (proto.Transaction, *TransactionDuplicate, error) {
 	tt := proto.TransactionTypeVersion{}
 	err := json.Unmarshal(b, &tt)
 	if err != nil {
-		return nil, wrapToBadRequestError(err)
+		return nil, nil, apiErrs.NewWrongJsonError(err.Error(), nil)
 	}
 
 	realType, err := proto.GuessTransactionType(&tt)
 	if err != nil {
-		return nil, wrapToBadRequestError(err)
+		return nil, nil, apiErrs.UnsupportedTransactionType
 	}
 
 	err = proto.UnmarshalTransactionFromJSON(b, a.services.Scheme, realType)
 	if err != nil {
-		return nil, wrapToBadRequestError(err)
+		return nil, nil, apiErrs.NewWrongJsonError(err.Error(), nil)
+	}
+	dup, err := a.transactionDuplicate(realType)
+	if err != nil {
+		return nil, nil, err
+	}
+	if dup != nil {
+		return realType, dup, nil
 	}
+	if async {
+		if eErr := a.enqueueTransaction(ctx, realType); eErr != nil {
+			return nil, nil, eErr
+		}
+		return realType, nil, nil
+	}
+	if bErr := a.broadcastTransaction(ctx, realType); bErr != nil {
+		return nil, nil, bErr
+	}
+	return realType, nil, nil
+}
 
-	respCh := make(chan error, 1)
+// transactionDuplicate returns the status of the transaction if it's already in UTX pool or in the blockchain,
+// nil is returned for unknown transaction.
+func (a *App) transactionDuplicate(tx proto.Transaction) (*TransactionDuplicate, error) {
+	id, err := tx.GetID(a.services.Scheme)
+	if err != nil {
+		return nil, apiErrs.NewCustomValidationError(err.Error())
+	}
+	if a.utx.ExistsByID(id) {
+		return &TransactionDuplicate{Duplicate: true, Status: "unconfirmed"}, nil
+	}
+	_, status, err := a.state.TransactionByIDWithStatus(id)
+	if err != nil {
+		if stateerr.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to check transaction in state")
+	}
+	height, err := a.state.TransactionHeightByID(id)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get height of transaction")
+	}
+	return &TransactionDuplicate{
+		Duplicate:         true,
+		Status:            "confirmed",
+		Height:            height,
+		ApplicationStatus: &status,
+	}, nil
+}
 
+// sendTransaction sends the transaction to the node's internal channel. The result of validation and insertion into
+// UTX pool is written to the returned channel, the channel is buffered, so it's safe to not read the result.
+func (a *App) sendTransaction(ctx context.Context, tx proto.Transaction) (<-chan error, error) {
+	respCh := make(chan error, 1)
 	select {
-	case a.services.InternalChannel <- messages.NewBroadcastTransaction(respCh, realType):
+	case a.services.InternalChannel <- messages.NewBroadcastTransaction(respCh, tx):
+		return respCh, nil
 	case <-ctx.Done():
 		return nil, errors.Wrap(ctx.Err(), "failed to send internal")
 	}
+}
+
+// enqueueTransaction performs stateless validation of the transaction and sends it to the node's internal channel
+// without waiting for the result of its insertion into UTX pool.
+func (a *App) enqueueTransaction(ctx context.Context, tx proto.Transaction) error {
+	lightNodeActivated, err := a.state.IsActivated(int16(settings.LightNode))
+	if err != nil {
+		return errors.Wrap(err, "failed to check if LightNode feature is activated")
+	}
+	params := proto.TransactionValidationParams{Scheme: a.services.Scheme, CheckVersion: lightNodeActivated}
+	if _, vErr := tx.Validate(params); vErr != nil {
+		return broadcastRejectionError(vErr, tx)
+	}
+	_, err = a.sendTransaction(ctx, tx)
+	return err
+}
+
+// errBroadcastUnconfirmed is returned when the transaction was sent to the node's internal channel, but the result
+// of its validation wasn't received. Such transaction still may be accepted.
+var errBroadcastUnconfirmed = errors.New("result of transaction validation is unknown")
+
+// broadcastTransaction sends the transaction to the node's internal channel and waits for the result of
+// its validation and insertion into UTX pool.
+func (a *App) broadcastTransaction(ctx context.Context, tx proto.Transaction) error {
+	respCh, err := a.sendTransaction(ctx, tx)
+	if err != nil {
+		return err
+	}
 	var (
 		delay = time.NewTimer(5 * time.Second)
 		fired bool
@@ -119,15 +219,40 @@ func (a *App) TransactionsBroadcast(ctx context.Context, b []byte) (proto.Transa
 	}()
 	select {
 	case <-ctx.Done():
-		return nil, errors.Wrap(ctx.Err(), "ctx cancelled from client")
+		return fmt.Errorf("ctx cancelled from client: %w: %w", ctx.Err(), errBroadcastUnconfirmed)
 	case <-delay.C:
 		fired = true
-		return nil, errors.New("timeout waiting response from internal")
+		return errors.Wrap(errBroadcastUnconfirmed, "timeout waiting response from internal")
 	case err := <-respCh:
 		if err != nil {
-			return nil, err
+			return broadcastRejectionError(err, tx)
+		}
+		return nil
+	}
+}
+
+// broadcastRejectionError converts the reason of transaction rejection to API error the same way as Scala node does.
+func broadcastRejectionError(err error, tx proto.Transaction) error {
+	var (
+		mistiming  *errs.Mistiming
+		fee        *errs.FeeValidation
+		notAllowed *errs.TransactionNotAllowedByScript
+	)
+	switch {
+	case errors.As(err, &mistiming):
+		return apiErrs.NewMistimingError(mistiming.Error())
+	case errors.As(err, &fee):
+		return apiErrs.NewInsufficientFeeError(fee.Error())
+	case errors.As(err, &notAllowed):
+		if notAllowed.IsAssetScript() {
+			return apiErrs.NewTransactionNotAllowedByAssetScriptError(tx)
 		}
-		return realType, nil
+		return apiErrs.NewTransactionNotAllowedByAccountScriptError(tx)
+	case ride.GetEvaluationErrorType(err) != ride.Undefined:
+		// Account script failed with an error, asset script errors are reported as TransactionNotAllowedByScript.
+		return apiErrs.NewScriptExecutionError(err.Error(), false, tx)
+	default:
+		return apiErrs.NewStateCheckFailedError(err.Error(), tx)
 	}
 }
 
@@ -157,18 +282,11 @@ func (a *App) Accounts() ([]account, error) {
 	return accounts, nil
 }
 
+// CheckAPIKey checks the key the same way as API key protected routes do, it's used to protect other API surfaces.
+func (a *App) CheckAPIKey(key string) error {
+	return a.checkAuth(key)
+}
+
 func (a *App) checkAuth(key string) error {
-	if !a.apiKeyEnabled {
-		// TODO(nickeskov): use new types of errors
-		return wrapToAuthError(errors.New("api key disabled"))
-	}
-	d, err := crypto.SecureHash([]byte(key))
-	if err != nil {
-		return errors.Wrap(err, "failed to calculate secure hash for API key")
-	}
-	if d != a.hashedApiKey {
-		// TODO(nickeskov): use new types of errors
-		return wrapToAuthError(errors.New("invalid api key"))
-	}
-	return nil
+	return a.keys.check(key)
 }
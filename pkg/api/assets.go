@@ -6,6 +6,7 @@ import (
 	"github.com/wavesplatform/gowaves/pkg/crypto"
 	"github.com/wavesplatform/gowaves/pkg/errs"
 	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/state"
 )
 
 type ScriptDetails struct {
@@ -110,6 +111,20 @@ func (a *App) AssetsDetails(fullAssetsIDs []crypto.Digest, full bool) ([]AssetDe
 	return assetDetails, nil
 }
 
+// AssetsSearch returns details of assets which names start with the query ignoring case, ordered by names.
+func (a *App) AssetsSearch(query string, limit uint64, after *crypto.Digest) ([]AssetDetails, error) {
+	var afterID *proto.AssetID
+	if after != nil {
+		id := proto.AssetIDFromDigest(*after)
+		afterID = &id
+	}
+	ids, err := a.state.SearchAssets(query, limit, afterID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to search assets by query %q", query)
+	}
+	return a.AssetsDetails(ids, false)
+}
+
 func (a *App) generateAssetsDoesNotExistError(fullAssetsIDs []crypto.Digest) error {
 	var notFoundAssets []string
 	for _, fullAssetsID := range fullAssetsIDs {
@@ -126,3 +141,32 @@ func (a *App) generateAssetsDoesNotExistError(fullAssetsIDs []crypto.Digest) err
 	}
 	return nil
 }
+
+type SponsorshipDetails struct {
+	AssetId              crypto.Digest      `json:"assetId"`
+	IsSponsored          bool               `json:"isSponsored"`
+	MinSponsoredAssetFee *uint64            `json:"minSponsoredAssetFee"`
+	Sponsor              proto.WavesAddress `json:"sponsor"`
+	SponsorBalance       *uint64            `json:"sponsorBalance"`
+	// SponsoredTransactions is the number of transactions with minimal fee the sponsor is able to pay for.
+	SponsoredTransactions *uint64 `json:"sponsoredTransactions"`
+}
+
+func (a *App) AssetSponsorship(fullAssetID crypto.Digest) (*SponsorshipDetails, error) {
+	status, err := a.state.SponsorshipStatus(proto.AssetIDFromDigest(fullAssetID))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get sponsorship status of asset")
+	}
+	details := &SponsorshipDetails{
+		AssetId:     status.AssetID,
+		IsSponsored: status.IsSponsored,
+		Sponsor:     status.Sponsor,
+	}
+	if status.IsSponsored {
+		fee, balance, txs := status.MinSponsoredFee, status.SponsorBalance, status.SponsorBalance/state.FeeUnit
+		details.MinSponsoredAssetFee = &fee
+		details.SponsorBalance = &balance
+		details.SponsoredTransactions = &txs
+	}
+	return details, nil
+}
@@ -0,0 +1,230 @@
+package api
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+)
+
+const (
+	auditLogSyslog       = "syslog"
+	auditFingerprintSize = 8
+
+	auditAuthAPIKey = "api-key"
+	auditAuthBearer = "bearer"
+	auditAuthNone   = "none"
+
+	auditOutcomeSucceeded = "succeeded"
+	auditOutcomeFailed    = "failed"
+	auditOutcomeDenied    = "denied"
+)
+
+// auditEntry is the record of the audit log. The key itself is never logged, the fingerprint is the hex encoded
+// prefix of the secure hash of the key, the same hash is stored in the API keys file.
+type auditEntry struct {
+	Time        time.Time `json:"time"`
+	Method      string    `json:"method"`
+	Path        string    `json:"path"`
+	SourceIP    string    `json:"sourceIp"`
+	Auth        string    `json:"auth"`
+	KeyName     string    `json:"keyName,omitempty"`
+	Fingerprint string    `json:"fingerprint,omitempty"`
+	Status      int       `json:"status"`
+	Outcome     string    `json:"outcome"`
+	DurationMS  float64   `json:"durationMs"`
+}
+
+// AuditLog records requests to the API methods protected by API key, one JSON object per line.
+type AuditLog struct {
+	mu sync.Mutex
+	w  io.WriteCloser
+}
+
+// NewAuditLog opens the audit log with the options.
+func NewAuditLog(opts AuditLogOptions) (*AuditLog, error) {
+	if opts.Path == auditLogSyslog {
+		w, err := newSyslogWriter()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to connect to syslog")
+		}
+		return &AuditLog{w: w}, nil
+	}
+	f, err := newRotatingFile(opts.Path, opts.MaxSize, opts.MaxBackups)
+	if err != nil {
+		return nil, err
+	}
+	return &AuditLog{w: f}, nil
+}
+
+func (l *AuditLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Close()
+}
+
+func (l *AuditLog) write(e auditEntry) {
+	b, err := json.Marshal(e)
+	if err != nil {
+		zap.S().Errorf("Failed to marshal API audit log entry: %v", err)
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, wErr := l.w.Write(append(b, '\n')); wErr != nil {
+		zap.S().Errorf("Failed to write API audit log entry: %v", wErr)
+	}
+}
+
+// middleware wraps the authentication middleware to record every request passing through it, including the rejected
+// ones.
+func (l *AuditLog) middleware(
+	keys *adminKeys, auth func(next http.Handler) http.Handler,
+) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			begin := time.Now()
+			ww, ok := w.(middleware.WrapResponseWriter)
+			if !ok {
+				ww = middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			}
+			authorized := false
+			auth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				authorized = true
+				next.ServeHTTP(w, r)
+			})).ServeHTTP(ww, r)
+			l.write(newAuditEntry(r, keys, ww.Status(), authorized, begin))
+		})
+	}
+}
+
+func newAuditEntry(r *http.Request, keys *adminKeys, status int, authorized bool, begin time.Time) auditEntry {
+	if status == 0 {
+		status = http.StatusOK
+	}
+	e := auditEntry{
+		Time:       begin.UTC(),
+		Method:     r.Method,
+		Path:       r.URL.Path,
+		SourceIP:   sourceIP(r.RemoteAddr),
+		Auth:       auditAuthNone,
+		Status:     status,
+		DurationMS: float64(time.Since(begin)) / float64(time.Millisecond),
+	}
+	switch {
+	case !authorized:
+		e.Outcome = auditOutcomeDenied
+	case status >= http.StatusBadRequest:
+		e.Outcome = auditOutcomeFailed
+	default:
+		e.Outcome = auditOutcomeSucceeded
+	}
+	var secret string
+	if token, ok := bearerToken(r); ok {
+		e.Auth, secret = auditAuthBearer, token
+	} else if key := r.Header.Get("X-API-Key"); key != "" {
+		e.Auth, secret = auditAuthAPIKey, key
+	}
+	if secret == "" {
+		return e
+	}
+	d, err := crypto.SecureHash([]byte(secret))
+	if err != nil {
+		zap.S().Errorf("Failed to calculate fingerprint of API key: %v", err)
+		return e
+	}
+	e.Fingerprint = hex.EncodeToString(d[:auditFingerprintSize])
+	if e.Auth == auditAuthAPIKey {
+		e.KeyName = keys.nameOf(d)
+	}
+	return e
+}
+
+func sourceIP(remoteAddr string) string {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return remoteAddr
+	}
+	return host
+}
+
+// rotatingFile is the file which is renamed to path.1 when its size exceeds the limit, previously rotated files are
+// shifted to path.2 and so on, up to the number of backups.
+type rotatingFile struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+	f          *os.File
+	size       int64
+}
+
+func newRotatingFile(path string, maxSize int64, maxBackups int) (*rotatingFile, error) {
+	if maxSize <= 0 {
+		return nil, errors.Errorf("invalid max size %d of file '%s'", maxSize, path)
+	}
+	rf := &rotatingFile{path: path, maxSize: maxSize, maxBackups: maxBackups}
+	if err := rf.open(); err != nil {
+		return nil, err
+	}
+	return rf, nil
+}
+
+func (rf *rotatingFile) open() error {
+	f, err := os.OpenFile(rf.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open file '%s'", rf.path)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return errors.Wrapf(err, "failed to get size of file '%s'", rf.path)
+	}
+	rf.f, rf.size = f, info.Size()
+	return nil
+}
+
+func (rf *rotatingFile) Write(p []byte) (int, error) {
+	if rf.size > 0 && rf.size+int64(len(p)) > rf.maxSize {
+		if err := rf.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := rf.f.Write(p)
+	rf.size += int64(n)
+	return n, err
+}
+
+func (rf *rotatingFile) rotate() error {
+	if err := rf.f.Close(); err != nil {
+		return errors.Wrapf(err, "failed to close file '%s'", rf.path)
+	}
+	backup := func(i int) string { return rf.path + "." + strconv.Itoa(i) }
+	if rf.maxBackups > 0 {
+		for i := rf.maxBackups - 1; i > 0; i-- {
+			if err := os.Rename(backup(i), backup(i+1)); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return errors.Wrap(err, "failed to shift rotated file")
+			}
+		}
+		if err := os.Rename(rf.path, backup(1)); err != nil {
+			return errors.Wrapf(err, "failed to rotate file '%s'", rf.path)
+		}
+	} else if err := os.Remove(rf.path); err != nil {
+		return errors.Wrapf(err, "failed to remove file '%s'", rf.path)
+	}
+	return rf.open()
+}
+
+func (rf *rotatingFile) Close() error {
+	return rf.f.Close()
+}
@@ -0,0 +1,13 @@
+//go:build windows || plan9
+
+package api
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+func newSyslogWriter() (io.WriteCloser, error) {
+	return nil, errors.New("syslog is not supported on this platform")
+}
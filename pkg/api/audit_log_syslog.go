@@ -0,0 +1,12 @@
+//go:build !windows && !plan9
+
+package api
+
+import (
+	"io"
+	"log/syslog"
+)
+
+func newSyslogWriter() (io.WriteCloser, error) {
+	return syslog.New(syslog.LOG_INFO|syslog.LOG_AUTH, "gowaves")
+}
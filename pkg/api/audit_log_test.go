@@ -0,0 +1,126 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+)
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+func TestAuditLogMiddleware(t *testing.T) {
+	const key = "secret"
+	keys, err := newAdminKeys(key)
+	require.NoError(t, err)
+	buf := new(bytes.Buffer)
+	l := &AuditLog{w: nopWriteCloser{buf}}
+	auth := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if token, ok := bearerToken(r); (ok && token == "token") || r.Header.Get("X-API-Key") == key {
+				next.ServeHTTP(w, r)
+				return
+			}
+			w.WriteHeader(http.StatusForbidden)
+		})
+	}
+	h := l.middleware(keys, auth)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	request := func(path string, header, value string) {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		req.RemoteAddr = "10.0.0.1:12345"
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+		h.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	request("/ok", "X-API-Key", key)
+	request("/fail", "X-API-Key", key)
+	request("/ok", "X-API-Key", "wrong")
+	request("/ok", "", "")
+	request("/ok", "Authorization", "Bearer token")
+
+	assert.NotContains(t, buf.String(), key)
+	var entries []auditEntry
+	sc := bufio.NewScanner(buf)
+	for sc.Scan() {
+		var e auditEntry
+		require.NoError(t, json.Unmarshal(sc.Bytes(), &e))
+		entries = append(entries, e)
+	}
+	require.Len(t, entries, 5)
+	fingerprint := func(s string) string {
+		d, hErr := crypto.SecureHash([]byte(s))
+		require.NoError(t, hErr)
+		return hex.EncodeToString(d[:auditFingerprintSize])
+	}
+	for i, test := range []struct {
+		path        string
+		auth        string
+		name        string
+		fingerprint string
+		status      int
+		outcome     string
+	}{
+		{"/ok", auditAuthAPIKey, configuredAPIKeyName, fingerprint(key), http.StatusOK, auditOutcomeSucceeded},
+		{"/fail", auditAuthAPIKey, configuredAPIKeyName, fingerprint(key), http.StatusBadRequest, auditOutcomeFailed},
+		{"/ok", auditAuthAPIKey, "", fingerprint("wrong"), http.StatusForbidden, auditOutcomeDenied},
+		{"/ok", auditAuthNone, "", "", http.StatusForbidden, auditOutcomeDenied},
+		{"/ok", auditAuthBearer, "", fingerprint("token"), http.StatusOK, auditOutcomeSucceeded},
+	} {
+		e := entries[i]
+		assert.Equal(t, http.MethodPost, e.Method)
+		assert.Equal(t, test.path, e.Path)
+		assert.Equal(t, "10.0.0.1", e.SourceIP)
+		assert.Equal(t, test.auth, e.Auth)
+		assert.Equal(t, test.name, e.KeyName)
+		assert.Equal(t, test.fingerprint, e.Fingerprint)
+		assert.Equal(t, test.status, e.Status)
+		assert.Equal(t, test.outcome, e.Outcome)
+	}
+}
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	rf, err := newRotatingFile(path, 10, 2)
+	require.NoError(t, err)
+	for _, s := range []string{"aaaaaa", "bbbbbb", "cccccc", "dddd", "eeeeee"} {
+		_, wErr := rf.Write([]byte(s))
+		require.NoError(t, wErr)
+	}
+	require.NoError(t, rf.Close())
+	for name, content := range map[string]string{path: "eeeeee", path + ".1": "ccccccdddd", path + ".2": "bbbbbb"} {
+		b, rErr := os.ReadFile(name)
+		require.NoError(t, rErr)
+		assert.Equal(t, content, string(b), name)
+	}
+	_, err = os.Stat(path + ".3")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	rf, err = newRotatingFile(path, 10, 0)
+	require.NoError(t, err)
+	_, err = rf.Write([]byte("ffffff"))
+	require.NoError(t, err)
+	require.NoError(t, rf.Close())
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "ffffff", string(b), "the file is truncated without backups")
+}
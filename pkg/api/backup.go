@@ -0,0 +1,63 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+type backupResponse struct {
+	Path string `json:"path"`
+}
+
+// Backup writes a consistent copy of the node's state to the directory on the node's host.
+func (a *App) Backup(dir string) error {
+	return a.state.Backup(dir)
+}
+
+// BackupTar streams a consistent copy of the node's state to w as a tar archive.
+func (a *App) BackupTar(w io.Writer) error {
+	return a.state.BackupTar(w)
+}
+
+// Backup creates a backup of the state. If the 'path' query parameter is set, backup is written to the given
+// directory on the node's host, otherwise backup is streamed in the response as a tar archive.
+func (a *NodeApi) Backup(w http.ResponseWriter, r *http.Request) error {
+	if dir := r.URL.Query().Get("path"); dir != "" {
+		if err := a.app.Backup(filepath.Clean(dir)); err != nil {
+			return errors.Wrap(err, "Backup")
+		}
+		if err := trySendJson(w, backupResponse{Path: dir}); err != nil {
+			return errors.Wrap(err, "Backup")
+		}
+		return nil
+	}
+	tw := &tarResponseWriter{w: w}
+	if err := a.app.BackupTar(tw); err != nil {
+		if !tw.started {
+			return errors.Wrap(err, "Backup")
+		}
+		// Headers are already sent, so it's only possible to log the error and break the archive.
+		zap.S().Errorf("Failed to stream state backup: %v", err)
+	}
+	return nil
+}
+
+// tarResponseWriter sets the headers of the tar archive on the first write, so the response is still available to
+// report an error if the backup fails before streaming.
+type tarResponseWriter struct {
+	w       http.ResponseWriter
+	started bool
+}
+
+func (t *tarResponseWriter) Write(p []byte) (int, error) {
+	if !t.started {
+		t.started = true
+		t.w.Header().Set("Content-Type", "application/x-tar")
+		t.w.Header().Set("Content-Disposition", `attachment; filename="state-backup.tar"`)
+	}
+	return t.w.Write(p)
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"archive/tar"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/mock"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/services"
+)
+
+func TestNodeApi_Backup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	st := mock.NewMockState(ctrl)
+	app, err := NewApp("api-key", nil, services.Services{State: st, Scheme: proto.TestNetScheme})
+	require.NoError(t, err)
+	api := NewNodeAPI(app, st)
+
+	t.Run("to path", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "backup")
+		st.EXPECT().Backup(dir).Return(nil)
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/node/backup?path="+dir, nil)
+		require.NoError(t, api.Backup(resp, req))
+		assert.JSONEq(t, `{"path":"`+dir+`"}`, resp.Body.String())
+	})
+	t.Run("tar stream", func(t *testing.T) {
+		st.EXPECT().BackupTar(gomock.Any()).DoAndReturn(func(w io.Writer) error {
+			tw := tar.NewWriter(w)
+			if err := tw.WriteHeader(&tar.Header{Name: "key_value/CURRENT", Mode: 0600, Size: 4}); err != nil {
+				return err
+			}
+			if _, err := tw.Write([]byte("data")); err != nil {
+				return err
+			}
+			return tw.Close()
+		})
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/node/backup", nil)
+		require.NoError(t, api.Backup(resp, req))
+		assert.Equal(t, "application/x-tar", resp.Header().Get("Content-Type"))
+
+		tr := tar.NewReader(resp.Body)
+		hdr, err := tr.Next()
+		require.NoError(t, err)
+		assert.Equal(t, "key_value/CURRENT", hdr.Name)
+		data, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		assert.Equal(t, "data", string(data))
+		_, err = tr.Next()
+		assert.ErrorIs(t, err, io.EOF)
+	})
+	t.Run("tar stream failure", func(t *testing.T) {
+		st.EXPECT().BackupTar(gomock.Any()).Return(errors.New("another backup is in progress"))
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/node/backup", nil)
+		assert.Error(t, api.Backup(resp, req))
+		assert.Empty(t, resp.Header().Get("Content-Type"))
+	})
+}
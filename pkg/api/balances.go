@@ -0,0 +1,93 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	apiErrs "github.com/wavesplatform/gowaves/pkg/api/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/state/stateerr"
+)
+
+const maxBalancesAddresses = 1000
+
+type addressesBalanceRequest struct {
+	Addresses []string     `json:"addresses"`
+	Height    proto.Height `json:"height,omitempty"`
+	Asset     string       `json:"asset,omitempty"`
+}
+
+type addressBalance struct {
+	ID      proto.WavesAddress `json:"id"`
+	Balance uint64             `json:"balance"`
+}
+
+// AddressesBalance returns regular balances of the list of addresses in Waves or in the asset, if it's specified.
+// If the height is specified, the balances at the height are returned, the height must be in the retained history.
+func (a *NodeApi) AddressesBalance(w http.ResponseWriter, r *http.Request) error {
+	var req addressesBalanceRequest
+	if err := tryParseJson(r.Body, &req); err != nil {
+		return err
+	}
+	if len(req.Addresses) == 0 {
+		return apiErrs.NewCustomValidationError("addresses are not specified")
+	}
+	if len(req.Addresses) > maxBalancesAddresses {
+		return apiErrs.NewTooBigArrayAllocationError(maxBalancesAddresses)
+	}
+	addresses := make([]proto.WavesAddress, len(req.Addresses))
+	for i, s := range req.Addresses {
+		addr, err := proto.NewAddressFromString(s)
+		if err != nil {
+			return apiErrs.InvalidAddress
+		}
+		if ok, vErr := addr.Valid(a.app.scheme()); !ok {
+			return apiErrs.NewCustomValidationError(vErr.Error())
+		}
+		addresses[i] = addr
+	}
+	asset := proto.NewOptionalAssetWaves()
+	if req.Asset != "" {
+		id, err := crypto.NewDigestFromBase58(req.Asset)
+		if err != nil {
+			return apiErrs.InvalidAssetId
+		}
+		exists, err := a.state.IsAssetExist(proto.AssetIDFromDigest(id))
+		if err != nil {
+			return errors.Wrapf(err, "failed to check existence of asset %q", id)
+		}
+		if !exists {
+			return apiErrs.NewAssetDoesNotExistError(id)
+		}
+		asset = *proto.NewOptionalAssetFromDigest(id)
+	}
+	res := make([]addressBalance, len(addresses))
+	for i, addr := range addresses {
+		balance, err := a.balance(proto.NewRecipientFromAddress(addr), asset, req.Height)
+		if err != nil {
+			if stateerr.IsInvalidInput(err) {
+				return apiErrs.NewCustomValidationError(err.Error())
+			}
+			return errors.Wrapf(err, "failed to get balance of address %q", addr.String())
+		}
+		res[i] = addressBalance{ID: addr, Balance: balance}
+	}
+	if err := trySendJson(w, res); err != nil {
+		return errors.Wrap(err, "AddressesBalance")
+	}
+	return nil
+}
+
+// balance returns the current balance of the account if the height is zero, or the balance at the height otherwise.
+func (a *NodeApi) balance(rcp proto.Recipient, asset proto.OptionalAsset, height proto.Height) (uint64, error) {
+	switch {
+	case height != 0:
+		return a.state.BalanceAtHeight(rcp, asset, height)
+	case asset.Present:
+		return a.state.AssetBalance(rcp, proto.AssetIDFromDigest(asset.ID))
+	default:
+		return a.state.WavesBalance(rcp)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/go-chi/chi"
 	"github.com/pkg/errors"
 
+	apiErrs "github.com/wavesplatform/gowaves/pkg/api/errors"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 	"github.com/wavesplatform/gowaves/pkg/settings"
 	"github.com/wavesplatform/gowaves/pkg/state"
@@ -33,12 +34,12 @@ func (a *NodeApi) rewardAtHeight(height proto.Height) (rewardInfoResponse, error
 		return rewardInfoResponse{}, err
 	}
 	if !blockRewardsActivated || height == 1 {
-		return rewardInfoResponse{}, errors.Wrap(err, "Block reward feature is not activated yet")
+		return rewardInfoResponse{}, apiErrs.NewCustomValidationError("Block reward feature is not activated yet")
 	}
 
 	cappedRewardsActivated, err := a.state.IsActiveAtHeight(int16(settings.CappedRewards), height)
 	if err != nil {
-		return rewardInfoResponse{}, errors.Wrap(err, "Capped rewards feature is not activated yet")
+		return rewardInfoResponse{}, errors.Wrap(err, "failed to check activation of capped rewards feature")
 	}
 	set, err := a.state.BlockchainSettings()
 	if err != nil {
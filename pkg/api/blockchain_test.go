@@ -0,0 +1,73 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apiErrs "github.com/wavesplatform/gowaves/pkg/api/errors"
+	"github.com/wavesplatform/gowaves/pkg/mock"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+)
+
+func TestNodeApi_RewardAtHeightNotActivated(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		height    proto.Height
+		activated bool
+	}{
+		{"not activated", 100, false},
+		{"genesis", 1, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			st := mock.NewMockState(ctrl)
+			st.EXPECT().IsActiveAtHeight(int16(settings.BlockReward), tc.height).Return(tc.activated, nil)
+			a := NewNodeAPI(&App{}, st)
+			_, err := a.rewardAtHeight(tc.height)
+			assert.ErrorAs(t, err, new(*apiErrs.CustomValidationError))
+		})
+	}
+}
+
+func TestNodeApi_RewardAtHeightDistribution(t *testing.T) {
+	const height = proto.Height(1000)
+	dao := proto.MustAddressFromString("3PEgG7eZHLFhcfsTSaYxgRhZsh4AxMvA4Ms")
+	xtn := proto.MustAddressFromString("3PFjHWuH6WXNJbwnfLHqNFBpwBS5dkYjTfv")
+	set := settings.MustMainNetSettings()
+	set.RewardAddresses = []proto.WavesAddress{dao, xtn}
+	set.RewardAddressesAfter21 = []proto.WavesAddress{dao}
+
+	for _, tc := range []struct {
+		name      string
+		cessation bool
+		xtn       *proto.WavesAddress
+	}{
+		{"before XTN buy-back cessation", false, &xtn},
+		{"after XTN buy-back cessation", true, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			st := mock.NewMockState(ctrl)
+			st.EXPECT().IsActiveAtHeight(int16(settings.BlockReward), height).Return(true, nil)
+			st.EXPECT().IsActiveAtHeight(int16(settings.CappedRewards), height).Return(false, nil)
+			st.EXPECT().BlockchainSettings().Return(set, nil)
+			st.EXPECT().ActivationHeight(int16(settings.BlockReward)).Return(proto.Height(2), nil)
+			st.EXPECT().RewardAtHeight(height).Return(uint64(600000000), nil)
+			st.EXPECT().IsActiveAtHeight(int16(settings.BlockRewardDistribution), height).Return(true, nil)
+			st.EXPECT().IsActiveAtHeight(int16(settings.XTNBuyBackCessation), height).Return(tc.cessation, nil)
+			st.EXPECT().RewardVotes(height).Return(proto.RewardVotes{}, nil)
+			st.EXPECT().TotalWavesAmount(height).Return(uint64(10000000000000000), nil)
+			a := NewNodeAPI(&App{}, st)
+			res, err := a.rewardAtHeight(height)
+			require.NoError(t, err)
+			assert.Equal(t, uint64(600000000), res.CurrentReward)
+			require.NotNil(t, res.DAOAddress)
+			assert.Equal(t, dao, *res.DAOAddress)
+			assert.Equal(t, tc.xtn, res.XTNBuybackAddress)
+		})
+	}
+}
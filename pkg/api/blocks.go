@@ -1,6 +1,8 @@
 package api
 
 import (
+	"context"
+
 	"github.com/pkg/errors"
 
 	apiErrs "github.com/wavesplatform/gowaves/pkg/api/errors"
@@ -83,7 +85,7 @@ func (a *App) BlocksHeadersByID(id proto.BlockID) (*Block, error) {
 	return a.BlocksHeadersAt(height)
 }
 
-func (a *App) BlocksHeadersFromTo(from, to proto.Height) ([]*Block, error) {
+func (a *App) BlocksHeadersFromTo(ctx context.Context, from, to proto.Height) ([]*Block, error) {
 	if from > to || to-from >= a.settings.BlockRequestLimit {
 		return nil, apiErrs.TooBigArrayAllocation
 	}
@@ -103,6 +105,9 @@ func (a *App) BlocksHeadersFromTo(from, to proto.Height) ([]*Block, error) {
 	}
 	seq := make([]*Block, 0, to-from+1)
 	for h := from; h <= to && h <= currHeight; h++ {
+		if ctx.Err() != nil {
+			return nil, errors.Wrapf(ctx.Err(), "failed to get block header at height %d", h)
+		}
 		header, err := a.BlocksHeadersAt(h)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to get block header at height %d", h)
@@ -127,7 +132,7 @@ type Generator struct {
 	PubKey crypto.PublicKey `json:"pub_key"`
 }
 
-func (a *App) BlocksGenerators() (Generators, error) {
+func (a *App) BlocksGenerators(ctx context.Context) (Generators, error) {
 	curHeight, err := a.state.Height()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get state height")
@@ -141,6 +146,9 @@ func (a *App) BlocksGenerators() (Generators, error) {
 
 	out := Generators{}
 	for i := initialHeight; i < curHeight; i++ {
+		if ctx.Err() != nil {
+			return nil, errors.Wrapf(ctx.Err(), "failed to get from state block by height %d", i)
+		}
 		block, err := a.state.BlockByHeight(i)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to get from state block by height %d", i)
@@ -1,5 +1,16 @@
 package api
 
+import (
+	"github.com/wavesplatform/gowaves/pkg/miner"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
 func (a *App) DebugSyncEnabled(enabled bool) {
 	a.sync.SetEnabled(enabled)
 }
+
+// BlockDryRun simulates generation of the next block from transactions of UTX pool without broadcasting it.
+func (a *App) BlockDryRun() (*miner.DryRunResult, error) {
+	ts := proto.NewTimestampFromTime(a.services.Time.Now())
+	return miner.DryRun(a.state, a.utx, a.services.Scheme, miner.DefaultConstraints(), a.services.MinerFilter, ts)
+}
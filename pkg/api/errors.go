@@ -1,8 +1,8 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 	"net/http"
 
 	"github.com/go-chi/chi/middleware"
@@ -31,20 +31,6 @@ func (e *BadRequestError) Error() string {
 	return e.inner.Error()
 }
 
-// AuthError represents an authentication error or problem.
-// Deprecated: don't use this error type in new code. Create a new error type or value in 'pkg/api/errors' package.
-type AuthError struct {
-	inner error
-}
-
-func wrapToAuthError(err error) *AuthError {
-	return &AuthError{inner: err}
-}
-
-func (e *AuthError) Error() string {
-	return e.inner.Error()
-}
-
 type ErrorHandler struct {
 	logger *zap.Logger
 }
@@ -62,19 +48,22 @@ func (eh *ErrorHandler) Handle(w http.ResponseWriter, r *http.Request, err error
 	// target errors
 	var (
 		badRequestError = &BadRequestError{}
-		authError       = &AuthError{}
 		unknownError    = &apiErrs.UnknownError{}
 		apiError        = apiErrs.ApiError(nil)
 		// check that all targets implement the error interface
-		_, _, _, _ = error(badRequestError), error(authError), error(unknownError), error(apiError)
+		_, _, _ = error(badRequestError), error(unknownError), error(apiError)
 	)
 	switch {
+	case errors.Is(err, errBroadcastUnconfirmed):
+		// Checked before the timeout of the route, the error could be caused by it.
+		eh.sendApiErrJSON(w, r, apiErrs.BroadcastTimeout)
+	case errors.Is(err, context.DeadlineExceeded):
+		// Handler was stopped by the timeout of the route.
+		http.Error(w, http.StatusText(http.StatusGatewayTimeout), http.StatusGatewayTimeout)
 	case errors.As(err, &badRequestError):
 		// nickeskov: this error type will be removed in future
-		http.Error(w, fmt.Sprintf("Failed to complete request: %s", badRequestError.Error()), http.StatusBadRequest)
-	case errors.As(err, &authError):
-		// nickeskov: this error type will be removed in future
-		http.Error(w, fmt.Sprintf("Failed to complete request: %s", authError.Error()), http.StatusForbidden)
+		// Scala node reports all generic request validation failures as CustomValidationError.
+		eh.sendApiErrJSON(w, r, apiErrs.NewCustomValidationError(badRequestError.Error()))
 	case errors.As(err, &unknownError):
 		eh.logger.Error("UnknownError",
 			zap.String("proto", r.Proto),
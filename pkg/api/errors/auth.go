@@ -19,7 +19,7 @@ var (
 	ApiKeyNotValid = &ApiKeyNotValidError{
 		genericError: genericError{
 			ID:       ApiKeyNotValidErrorID,
-			HttpCode: http.StatusBadRequest,
+			HttpCode: http.StatusForbidden,
 			Message:  "Provided API key is not correct",
 		},
 	}
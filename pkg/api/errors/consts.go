@@ -53,6 +53,8 @@ const (
 	InvalidBlockIdErrorID             TransactionErrorID = 4002
 	InvalidAssetIdErrorID             TransactionErrorID = 4007
 	AssetIdNotSpecifiedErrorID        TransactionErrorID = 4009
+	// BroadcastTimeoutErrorID is specific to Go node, Scala node doesn't report such errors.
+	BroadcastTimeoutErrorID TransactionErrorID = 4100
 )
 
 var errorNames = map[Identifier]string{
@@ -98,4 +100,5 @@ var errorNames = map[Identifier]string{
 	InvalidBlockIdErrorID:             "InvalidBlockIdError",
 	InvalidAssetIdErrorID:             "InvalidAssetIdError",
 	AssetIdNotSpecifiedErrorID:        "AssetIdNotSpecifiedError",
+	BroadcastTimeoutErrorID:           "BroadcastTimeoutError",
 }
@@ -44,6 +44,7 @@ type (
 	InvalidBlockIdError       transactionError
 	InvalidAssetIdError       transactionError
 	AssetIdNotSpecifiedError  transactionError
+	BroadcastTimeoutError     transactionError
 )
 
 var (
@@ -75,8 +76,25 @@ var (
 			Message:  "Asset ID was not specified",
 		},
 	}
+	BroadcastTimeout = &BroadcastTimeoutError{
+		genericError: genericError{
+			ID:       BroadcastTimeoutErrorID,
+			HttpCode: http.StatusGatewayTimeout,
+			Message:  "Transaction was sent to the node, but the result of its validation is unknown",
+		},
+	}
 )
 
+func NewInsufficientFeeError(message string) *InsufficientFeeError {
+	return &InsufficientFeeError{
+		genericError: genericError{
+			ID:       InsufficientFeeErrorID,
+			HttpCode: http.StatusBadRequest,
+			Message:  message,
+		},
+	}
+}
+
 func NewInvalidBlockIDError(message string) *InvalidBlockIdError {
 	return &InvalidBlockIdError{
 		genericError: genericError{
@@ -119,3 +137,13 @@ func NewAssetsDoesNotExistError(ids []string) *AssetsDoesNotExistError {
 		IDs: ids,
 	}
 }
+
+func NewAlreadyInStateError(txID crypto.Digest, height proto.Height) *AlreadyInStateError {
+	return &AlreadyInStateError{
+		genericError: genericError{
+			ID:       AlreadyInStateErrorID,
+			HttpCode: http.StatusBadRequest,
+			Message:  fmt.Sprintf("Transaction %s is already in the state on a height of %d", txID.String(), height),
+		},
+	}
+}
@@ -49,6 +49,7 @@ type (
 	ScriptCompilerError                       validationError
 	ScriptExecutionError                      validationErrorWithTransaction
 	TransactionNotAllowedByAccountScriptError validationErrorWithTransaction
+	TransactionNotAllowedByAssetScriptError   validationErrorWithTransaction
 )
 
 func (e StateCheckFailedError) MarshalJSON() ([]byte, error) {
@@ -193,3 +194,72 @@ func NewInvalidIDsError(ids []string) *InvalidIdsError {
 		IDs: ids,
 	}
 }
+
+// NewStateCheckFailedError creates an error for a transaction rejected by state validation.
+func NewStateCheckFailedError(reason string, tx transaction) *StateCheckFailedError {
+	return &StateCheckFailedError{
+		validationErrorWithTransaction: validationErrorWithTransaction{
+			validationError: validationError{
+				genericError: genericError{
+					ID:       StateCheckFailedErrorID,
+					HttpCode: http.StatusBadRequest,
+					Message:  fmt.Sprintf("State check failed. Reason: %s", reason),
+				},
+			},
+			Transaction: tx,
+		},
+	}
+}
+
+func NewMistimingError(message string) *MistimingError {
+	return &MistimingError{
+		genericError: genericError{
+			ID:       MistimingErrorID,
+			HttpCode: http.StatusBadRequest,
+			Message:  message,
+		},
+	}
+}
+
+func NewScriptExecutionError(message string, isAssetScript bool, tx transaction) *ScriptExecutionError {
+	kind := "account"
+	if isAssetScript {
+		kind = "token"
+	}
+	return &ScriptExecutionError{
+		validationError: validationError{
+			genericError: genericError{
+				ID:       ScriptExecutionErrorErrorID,
+				HttpCode: http.StatusBadRequest,
+				Message:  fmt.Sprintf("Error while executing %s-script: %s", kind, message),
+			},
+		},
+		Transaction: tx,
+	}
+}
+
+func NewTransactionNotAllowedByAccountScriptError(tx transaction) *TransactionNotAllowedByAccountScriptError {
+	return &TransactionNotAllowedByAccountScriptError{
+		validationError: validationError{
+			genericError: genericError{
+				ID:       TransactionNotAllowedByAccountScriptErrorID,
+				HttpCode: http.StatusBadRequest,
+				Message:  "Transaction is not allowed by account-script",
+			},
+		},
+		Transaction: tx,
+	}
+}
+
+func NewTransactionNotAllowedByAssetScriptError(tx transaction) *TransactionNotAllowedByAssetScriptError {
+	return &TransactionNotAllowedByAssetScriptError{
+		validationError: validationError{
+			genericError: genericError{
+				ID:       TransactionNotAllowedByAssetScriptErrorID,
+				HttpCode: http.StatusBadRequest,
+				Message:  "Transaction is not allowed by token-script",
+			},
+		},
+		Transaction: tx,
+	}
+}
@@ -37,3 +37,17 @@ func TestStateCheckFailedError_MarshalJSON(t *testing.T) {
 	assert.Equal(t, "value", unmarshaled["extra_field"])
 	assert.Equal(t, float64(1), unmarshaled["extra_int"].(float64))
 }
+
+func TestNewStateCheckFailedError(t *testing.T) {
+	tx := map[string]interface{}{"type": float64(4)}
+	marshaled, err := json.Marshal(NewStateCheckFailedError("negative waves balance", tx))
+	assert.NoError(t, err)
+
+	var unmarshaled map[string]interface{}
+	err = json.Unmarshal(marshaled, &unmarshaled)
+	assert.NoError(t, err)
+
+	assert.Equal(t, float64(StateCheckFailedErrorID), unmarshaled["error"])
+	assert.Equal(t, "State check failed. Reason: negative waves balance", unmarshaled["message"])
+	assert.Equal(t, tx, unmarshaled["transaction"])
+}
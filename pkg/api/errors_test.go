@@ -34,19 +34,19 @@ func TestErrorHandler_Handle(t *testing.T) {
 			name:         "BadRequestErrorCase",
 			err:          errors.WithStack(errors.WithStack(badReqErr)),
 			expectedCode: http.StatusBadRequest,
-			expectedBody: "Failed to complete request: bad-request\n",
+			expectedBody: mustJSON(apiErrs.NewCustomValidationError("bad-request")) + "\n",
 		},
 		{
 			name:         "ErrorWithMultipleWraps",
 			err:          errors.Wrap(errors.Wrap(badReqErr, "wrap1"), "wrap2"),
 			expectedCode: http.StatusBadRequest,
-			expectedBody: "Failed to complete request: bad-request\n",
+			expectedBody: mustJSON(apiErrs.NewCustomValidationError("bad-request")) + "\n",
 		},
 		{
 			name:         "AuthErrorCase",
-			err:          &AuthError{errors.New("auth")},
+			err:          errors.Wrap(apiErrs.ApiKeyNotValid, "auth"),
 			expectedCode: http.StatusForbidden,
-			expectedBody: "Failed to complete request: auth\n",
+			expectedBody: mustJSON(apiErrs.ApiKeyNotValid) + "\n",
 		},
 		{
 			name:         "ApiErrorCase",
@@ -60,6 +60,12 @@ func TestErrorHandler_Handle(t *testing.T) {
 			expectedCode: unknownErr.GetHttpCode(),
 			expectedBody: mustJSON(unknownErr) + "\n",
 		},
+		{
+			name:         "BroadcastTimeoutCase",
+			err:          errors.Wrap(errBroadcastUnconfirmed, "timeout"),
+			expectedCode: http.StatusGatewayTimeout,
+			expectedBody: mustJSON(apiErrs.BroadcastTimeout) + "\n",
+		},
 		{
 			name:         "DefaultCase",
 			err:          defaultErr,
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/node/blocks_applier"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/state/stateerr"
+)
+
+// immutableCacheControl is sent along with the resources that can't be changed by a rollback anymore.
+const immutableCacheControl = "public, max-age=31536000, immutable"
+
+// Kinds of resources distinguished by ETag.
+const (
+	etagBlock       = "block"
+	etagHeader      = "header"
+	etagTransaction = "tx"
+)
+
+// isImmutableHeight checks that the block at the given height is deeper than the maximal rollback depth,
+// so the block and its transactions can't be changed anymore.
+func (a *NodeApi) isImmutableHeight(height proto.Height) (bool, error) {
+	current, err := a.state.Height()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get current height")
+	}
+	return height > 0 && height+blocks_applier.MaxRollbackDeltaHeight <= current, nil
+}
+
+// notModified handles the conditional request for the resource with the given ID at the given height.
+// ETag and Cache-Control headers are set only for immutable resources. Function returns true if the client already
+// has the actual version of the resource and the response with status 304 is written.
+func (a *NodeApi) notModified(
+	w http.ResponseWriter, r *http.Request, kind, id string, height proto.Height,
+) (bool, error) {
+	immutable, err := a.isImmutableHeight(height)
+	if err != nil || !immutable {
+		return false, err
+	}
+	if wantsProtobuf(r) {
+		kind += "-" + protobufFormat
+	}
+	etag := fmt.Sprintf("%q", kind+"-"+id)
+	h := w.Header()
+	h.Set("ETag", etag)
+	h.Set("Cache-Control", immutableCacheControl)
+	h.Add("Vary", "Accept")
+	if !etagMatches(r.Header.Get("If-None-Match"), etag) {
+		return false, nil
+	}
+	w.WriteHeader(http.StatusNotModified)
+	return true, nil
+}
+
+// notModifiedBlockAt handles the conditional request for the block or its part at the given height.
+// Absent block is not reported here, it's left for the handler.
+func (a *NodeApi) notModifiedBlockAt(
+	w http.ResponseWriter, r *http.Request, kind string, height proto.Height,
+) (bool, error) {
+	id, err := a.state.HeightToBlockID(height)
+	if err != nil {
+		if stateerr.IsNotFound(err) || stateerr.IsInvalidInput(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "failed to get ID of block at height %d", height)
+	}
+	return a.notModified(w, r, kind, id.String(), height)
+}
+
+// notModifiedBlockID handles the conditional request for the block or its part with the given ID.
+func (a *NodeApi) notModifiedBlockID(
+	w http.ResponseWriter, r *http.Request, kind string, id proto.BlockID,
+) (bool, error) {
+	height, err := a.state.BlockIDToHeight(id)
+	if err != nil {
+		if stateerr.IsNotFound(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "failed to get height of block %s", id.String())
+	}
+	return a.notModified(w, r, kind, id.String(), height)
+}
+
+// etagMatches checks the value of If-None-Match header against the ETag using the weak comparison.
+func etagMatches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	for _, part := range strings.Split(header, ",") {
+		part = strings.TrimSpace(part)
+		if part == "*" || strings.TrimPrefix(part, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/mock"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/services"
+)
+
+func TestEtagMatches(t *testing.T) {
+	for _, test := range []struct {
+		header string
+		want   bool
+	}{
+		{"", false},
+		{`"block-abc"`, true},
+		{`W/"block-abc"`, true},
+		{`"block-xyz", "block-abc"`, true},
+		{`"block-xyz"`, false},
+		{"*", true},
+	} {
+		assert.Equal(t, test.want, etagMatches(test.header, `"block-abc"`), "header %q", test.header)
+	}
+}
+
+func newRequestWithParam(target, key, value string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add(key, value)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestNodeApi_BlockAtNotModified(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	id := proto.NewBlockIDFromDigest(crypto.MustDigestFromBase58("8vgMmBp3KS3VKXJmz3LNQvgQ4ELRRtvXPbXTbEd3iJyo"))
+	etag := `"block-` + id.String() + `"`
+
+	s := mock.NewMockState(ctrl)
+	s.EXPECT().HeightToBlockID(uint64(10)).Return(id, nil)
+	s.EXPECT().Height().Return(uint64(500), nil)
+	app, err := NewApp("api-key", nil, services.Services{State: s, Scheme: proto.TestNetScheme})
+	require.NoError(t, err)
+	a := NewNodeAPI(app, s)
+
+	req := newRequestWithParam("/blocks/at/10", "height", "10")
+	req.Header.Set("If-None-Match", etag)
+	resp := httptest.NewRecorder()
+	require.NoError(t, a.BlockAt(resp, req))
+	assert.Equal(t, http.StatusNotModified, resp.Code)
+	assert.Equal(t, etag, resp.Header().Get("ETag"))
+	assert.Equal(t, immutableCacheControl, resp.Header().Get("Cache-Control"))
+	assert.Empty(t, resp.Body.Bytes())
+}
+
+func TestNodeApi_TransactionInfoNotModified(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	id := crypto.MustDigestFromBase58("8vgMmBp3KS3VKXJmz3LNQvgQ4ELRRtvXPbXTbEd3iJyo")
+
+	s := mock.NewMockState(ctrl)
+	s.EXPECT().TransactionHeightByID(id.Bytes()).Return(uint64(10), nil)
+	s.EXPECT().Height().Return(uint64(500), nil)
+	app, err := NewApp("api-key", nil, services.Services{State: s, Scheme: proto.TestNetScheme})
+	require.NoError(t, err)
+	a := NewNodeAPI(app, s)
+
+	req := newRequestWithParam("/transactions/info/"+id.String(), "id", id.String())
+	req.Header.Set("Accept", protobufContentType)
+	req.Header.Set("If-None-Match", `"tx-protobuf-`+id.String()+`"`)
+	resp := httptest.NewRecorder()
+	require.NoError(t, a.TransactionInfo(resp, req))
+	assert.Equal(t, http.StatusNotModified, resp.Code)
+	assert.Equal(t, "Accept", resp.Header().Get("Vary"))
+}
@@ -0,0 +1,22 @@
+package api
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+const explorerPath = "/explorer"
+
+//go:embed explorer
+var explorerFiles embed.FS
+
+// explorerHandler serves the single page block explorer at explorerPath. The explorer is a static site, it shows
+// blocks, transactions and addresses requesting them from the REST API of the node.
+func explorerHandler() http.Handler {
+	files, err := fs.Sub(explorerFiles, "explorer")
+	if err != nil {
+		panic(err) // the directory is embedded, it can't be missing
+	}
+	return http.StripPrefix(explorerPath, http.FileServer(http.FS(files)))
+}
@@ -0,0 +1,196 @@
+'use strict';
+
+// The explorer is served at '<api>/explorer/', so the REST API of the node is one level up.
+const apiRoot = new URL('../', window.location.href);
+const lastBlocksCount = 20;
+const content = document.getElementById('content');
+
+async function get(path, options) {
+  const resp = await fetch(new URL(path, apiRoot), options);
+  const body = await resp.json().catch(() => null);
+  if (!resp.ok) {
+    throw new Error((body && body.message) || resp.statusText);
+  }
+  return body;
+}
+
+function post(path, data) {
+  return get(path, {method: 'POST', headers: {'Content-Type': 'application/json'}, body: JSON.stringify(data)});
+}
+
+function el(tag, attrs, ...children) {
+  const e = document.createElement(tag);
+  Object.entries(attrs || {}).forEach(([k, v]) => e.setAttribute(k, v));
+  children.forEach((c) => e.append(c instanceof Node ? c : String(c ?? '')));
+  return e;
+}
+
+function link(hash, text) {
+  return el('a', {href: '#/' + hash}, text ?? '');
+}
+
+function time(ts) {
+  return new Date(ts).toISOString().replace('T', ' ').replace('.000Z', ' UTC');
+}
+
+function waves(amount) {
+  return (amount / 1e8).toFixed(8) + ' WAVES';
+}
+
+// details renders the table of [name, value] rows.
+function details(rows) {
+  return el('table', {}, ...rows.map(([k, v]) => el('tr', {}, el('th', {}, k), el('td', {}, v))));
+}
+
+function list(headers, rows) {
+  return el('table', {},
+    el('thead', {}, el('tr', {}, ...headers.map((h) => el('th', {}, h)))),
+    el('tbody', {}, ...rows.map((r) => el('tr', {}, ...r.map((v) => el('td', {}, v))))));
+}
+
+function render(...nodes) {
+  content.replaceChildren(...nodes);
+}
+
+async function showLastBlocks() {
+  const {height} = await get('blocks/height');
+  const from = Math.max(1, height - lastBlocksCount + 1);
+  const headers = await get(`blocks/headers/seq/${from}/${height}`);
+  headers.reverse();
+  render(el('h2', {}, 'Last blocks'), list(['Height', 'ID', 'Time', 'Transactions', 'Generator'],
+    headers.map((h) => [
+      link('block/' + h.height, h.height),
+      link('block/' + h.id, h.id),
+      time(h.timestamp),
+      h.transactionCount,
+      link('address/' + h.generator, h.generator),
+    ])));
+}
+
+function transactionsList(txs) {
+  return list(['ID', 'Type', 'Sender', 'Fee'], txs.map((tx) => [
+    link('tx/' + tx.id, tx.id),
+    tx.type,
+    tx.sender ? link('address/' + tx.sender, tx.sender) : '',
+    tx.fee,
+  ]));
+}
+
+async function showBlock(ref) {
+  const b = await get(/^\d+$/.test(ref) ? 'blocks/at/' + ref : 'blocks/' + ref);
+  const rows = [
+    ['Height', b.height],
+    ['ID', b.id],
+    ['Parent', link('block/' + b.reference, b.reference)],
+    ['Time', time(b.timestamp)],
+    ['Version', b.version],
+    ['Generator', link('address/' + b.generator, b.generator)],
+    ['Base target', b['nxt-consensus']['base-target']],
+    ['Transactions', b.transactionCount],
+  ];
+  if (b.height > 1) {
+    rows.push(['Previous', link('block/' + (b.height - 1), b.height - 1)]);
+  }
+  rows.push(['Next', link('block/' + (b.height + 1), b.height + 1)]);
+  render(el('h2', {}, 'Block ' + b.height), details(rows),
+    el('h2', {}, 'Transactions'), transactionsList(b.transactions || []));
+}
+
+async function showTransaction(id) {
+  const tx = await get('transactions/info/' + id);
+  const rows = [
+    ['ID', tx.id],
+    ['Type', tx.type],
+    ['Time', time(tx.timestamp)],
+  ];
+  if (tx.height) {
+    rows.push(['Height', link('block/' + tx.height, tx.height)]);
+  }
+  if (tx.sender) {
+    rows.push(['Sender', link('address/' + tx.sender, tx.sender)]);
+  }
+  if (tx.recipient) {
+    rows.push(['Recipient', /^alias:/.test(tx.recipient) ? tx.recipient : link('address/' + tx.recipient, tx.recipient)]);
+  }
+  rows.push(['Fee', tx.fee], ['JSON', el('pre', {}, JSON.stringify(tx, null, 2))]);
+  render(el('h2', {}, 'Transaction'), details(rows));
+}
+
+async function showAddress(addr) {
+  if (!/^[1-9A-HJ-NP-Za-km-z]{35}$/.test(addr)) {
+    const alias = addr.replace(/^alias:.:/, '');
+    addr = (await get('alias/by-alias/' + encodeURIComponent(alias))).address;
+  }
+  const [balances, aliases] = await Promise.all([
+    post('addresses/balance', {addresses: [addr]}),
+    get('alias/by-address/' + addr).catch(() => []),
+  ]);
+  render(el('h2', {}, 'Address'), details([
+    ['Address', addr],
+    ['Balance', waves(balances[0].balance)],
+    ['Aliases', aliases.join(', ')],
+  ]));
+}
+
+// search guesses the kind of the query: digits are the height, 35 characters is an address, IDs are tried as
+// transaction IDs first and as block IDs then, anything else is looked up as an alias.
+async function search(q) {
+  if (/^\d+$/.test(q)) {
+    return 'block/' + q;
+  }
+  if (/^[1-9A-HJ-NP-Za-km-z]{35}$/.test(q)) {
+    return 'address/' + q;
+  }
+  if (/^[1-9A-HJ-NP-Za-km-z]{43,88}$/.test(q)) {
+    try {
+      await get('transactions/info/' + q);
+      return 'tx/' + q;
+    } catch (e) {
+      return 'block/' + q;
+    }
+  }
+  return 'address/' + q;
+}
+
+async function route() {
+  const [kind, ...rest] = window.location.hash.replace(/^#\/?/, '').split('/');
+  const arg = decodeURIComponent(rest.join('/'));
+  try {
+    switch (kind) {
+      case 'block':
+        await showBlock(arg);
+        break;
+      case 'tx':
+        await showTransaction(arg);
+        break;
+      case 'address':
+        await showAddress(arg);
+        break;
+      default:
+        await showLastBlocks();
+    }
+  } catch (e) {
+    render(el('div', {class: 'error'}, e.message));
+  }
+}
+
+async function showStatus() {
+  try {
+    const {height} = await get('blocks/height');
+    document.getElementById('status').textContent = 'Height ' + height;
+  } catch (e) {
+    document.getElementById('status').textContent = 'Node is unavailable';
+  }
+}
+
+document.getElementById('search').addEventListener('submit', async (e) => {
+  e.preventDefault();
+  const q = document.getElementById('query').value.trim();
+  if (q) {
+    window.location.hash = '#/' + await search(q);
+  }
+});
+window.addEventListener('hashchange', route);
+route();
+showStatus();
+setInterval(showStatus, 10000);
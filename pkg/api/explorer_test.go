@@ -0,0 +1,32 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExplorerHandler(t *testing.T) {
+	h := explorerHandler()
+	for _, test := range []struct {
+		path        string
+		status      int
+		contentType string
+		contains    string
+	}{
+		{"/explorer/", http.StatusOK, "text/html; charset=utf-8", "<title>Waves Explorer</title>"},
+		{"/explorer/explorer.js", http.StatusOK, "text/javascript; charset=utf-8", "apiRoot"},
+		{"/explorer/explorer.css", http.StatusOK, "text/css; charset=utf-8", "#search"},
+		{"/explorer/missing.js", http.StatusNotFound, "", ""},
+	} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, test.path, nil))
+		assert.Equal(t, test.status, rec.Code, test.path)
+		if test.status == http.StatusOK {
+			assert.Equal(t, test.contentType, rec.Header().Get("Content-Type"), test.path)
+			assert.Contains(t, rec.Body.String(), test.contains, test.path)
+		}
+	}
+}
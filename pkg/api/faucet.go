@@ -0,0 +1,328 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	apiErrs "github.com/wavesplatform/gowaves/pkg/api/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+const (
+	DefaultFaucetAmount       = 10 * proto.PriceConstant // 10 Waves
+	DefaultFaucetFee          = 100_000
+	DefaultFaucetAddressQuota = 1
+	DefaultFaucetIPQuota      = 5
+	DefaultFaucetQuotaPeriod  = 24 * time.Hour
+)
+
+// CaptchaVerifier is a hook which allows to protect the faucet with a captcha service.
+// Verify must return an error if the token provided by the user is not accepted.
+type CaptchaVerifier interface {
+	Verify(ctx context.Context, token, remoteIP string) error
+}
+
+// FaucetOptions configures the faucet which dispenses Waves from one of the wallet accounts.
+// Quotas limit the number of dispenses per recipient address and per source IP during the QuotaPeriod,
+// zero quota value disables the corresponding limit. Source IP is taken from the X-Forwarded-For and X-Real-IP
+// headers only if the request comes from one of the TrustedProxies, otherwise the address of the connection is used.
+type FaucetOptions struct {
+	Account        proto.WavesAddress
+	Amount         uint64
+	Fee            uint64
+	AddressQuota   int
+	IPQuota        int
+	QuotaPeriod    time.Duration
+	TrustedProxies []netip.Prefix
+	Captcha        CaptchaVerifier
+}
+
+// ParseTrustedProxies parses the comma separated list of IP addresses and CIDR subnets.
+func ParseTrustedProxies(s string) ([]netip.Prefix, error) {
+	var res []netip.Prefix
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if addr, err := netip.ParseAddr(part); err == nil {
+			res = append(res, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(part)
+		if err != nil {
+			return nil, errors.Errorf("invalid trusted proxy %q", part)
+		}
+		res = append(res, prefix.Masked())
+	}
+	return res, nil
+}
+
+func DefaultFaucetOptions(account proto.WavesAddress) *FaucetOptions {
+	return &FaucetOptions{
+		Account:      account,
+		Amount:       DefaultFaucetAmount,
+		Fee:          DefaultFaucetFee,
+		AddressQuota: DefaultFaucetAddressQuota,
+		IPQuota:      DefaultFaucetIPQuota,
+		QuotaPeriod:  DefaultFaucetQuotaPeriod,
+	}
+}
+
+// faucetHit is the dispense registered by the quota.
+type faucetHit struct {
+	key string
+	at  time.Time
+}
+
+// faucetQuota counts hits by key in the sliding time window.
+type faucetQuota struct {
+	mu     sync.Mutex
+	limit  int
+	period time.Duration
+	hits   map[string][]time.Time
+	order  []faucetHit // hits of all keys in the order of registration, outdated hits are evicted from the front
+}
+
+func newFaucetQuota(limit int, period time.Duration) *faucetQuota {
+	return &faucetQuota{limit: limit, period: period, hits: make(map[string][]time.Time)}
+}
+
+// take registers a hit for the key and returns false if the quota for the key is exhausted.
+func (q *faucetQuota) take(key string, now time.Time) bool {
+	if q.limit <= 0 {
+		return true
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.evict(now)
+	if len(q.hits[key]) >= q.limit {
+		return false
+	}
+	q.hits[key] = append(q.hits[key], now)
+	q.order = append(q.order, faucetHit{key: key, at: now})
+	return true
+}
+
+// refund cancels the hit registered for the key at the given time by take.
+func (q *faucetQuota) refund(key string, at time.Time) {
+	if q.limit <= 0 {
+		return
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	ts := q.hits[key]
+	for i := len(ts) - 1; i >= 0; i-- {
+		if ts[i].Equal(at) {
+			ts = append(ts[:i], ts[i+1:]...)
+			break
+		}
+	}
+	if len(ts) == 0 {
+		delete(q.hits, key)
+	} else {
+		q.hits[key] = ts
+	}
+	// The hit is left in the order, it's dropped on eviction.
+}
+
+// evict drops the hits which left the time window. Only the keys of the outdated hits are visited.
+func (q *faucetQuota) evict(now time.Time) {
+	edge := now.Add(-q.period)
+	n := 0
+	for n < len(q.order) && !q.order[n].at.After(edge) {
+		key := q.order[n].key
+		ts := q.hits[key]
+		i := 0
+		for i < len(ts) && !ts[i].After(edge) {
+			i++
+		}
+		if i == len(ts) {
+			delete(q.hits, key)
+		} else if i > 0 {
+			q.hits[key] = ts[i:]
+		}
+		n++
+	}
+	q.order = q.order[n:]
+}
+
+type faucet struct {
+	app          *App
+	opts         FaucetOptions
+	addressQuota *faucetQuota
+	ipQuota      *faucetQuota
+}
+
+// Validate checks that the faucet could be run on the network with the given scheme.
+func (o *FaucetOptions) Validate(scheme proto.Scheme) error {
+	if scheme == proto.MainNetScheme {
+		return errors.New("faucet is not allowed on MainNet")
+	}
+	if o.Account.Scheme() != scheme {
+		return errors.Errorf("faucet account %q belongs to other network", o.Account.String())
+	}
+	if o.Amount == 0 {
+		return errors.New("faucet amount must be positive")
+	}
+	return nil
+}
+
+func newFaucet(app *App, opts FaucetOptions) (*faucet, error) {
+	if err := opts.Validate(app.scheme()); err != nil {
+		return nil, err
+	}
+	return &faucet{
+		app:          app,
+		opts:         opts,
+		addressQuota: newFaucetQuota(opts.AddressQuota, opts.QuotaPeriod),
+		ipQuota:      newFaucetQuota(opts.IPQuota, opts.QuotaPeriod),
+	}, nil
+}
+
+func (f *faucet) accountPublicKey() (crypto.PublicKey, error) {
+	accounts, err := f.app.Accounts()
+	if err != nil {
+		return crypto.PublicKey{}, errors.Wrap(err, "failed to get wallet accounts")
+	}
+	for _, acc := range accounts {
+		if acc.Address == f.opts.Account {
+			return acc.PublicKey, nil
+		}
+	}
+	return crypto.PublicKey{}, errors.Errorf("faucet account %q is not found in wallet", f.opts.Account.String())
+}
+
+// dispense sends the transfer to the recipient. Quotas are taken before the transfer and are refunded if it fails,
+// so the concurrent requests can't exceed them. Quotas are not refunded if the transfer was sent, but its result is
+// unknown, because it still may be accepted.
+func (f *faucet) dispense(
+	ctx context.Context, recipient proto.WavesAddress, remoteIP, captcha string,
+) (_ proto.Transaction, err error) {
+	if f.opts.Captcha != nil {
+		if err := f.opts.Captcha.Verify(ctx, captcha, remoteIP); err != nil {
+			return nil, apiErrs.NewCustomValidationError(fmt.Sprintf("captcha verification failed: %v", err))
+		}
+	}
+	now := f.app.services.Time.Now()
+	if !f.ipQuota.take(remoteIP, now) {
+		return nil, apiErrs.NewCustomValidationError("faucet quota for IP address is exhausted")
+	}
+	if !f.addressQuota.take(recipient.String(), now) {
+		f.ipQuota.refund(remoteIP, now)
+		return nil, apiErrs.NewCustomValidationError(
+			fmt.Sprintf("faucet quota for address %q is exhausted", recipient.String()),
+		)
+	}
+	defer func() {
+		if err != nil && !errors.Is(err, errBroadcastUnconfirmed) {
+			f.ipQuota.refund(remoteIP, now)
+			f.addressQuota.refund(recipient.String(), now)
+		}
+	}()
+	pk, err := f.accountPublicKey()
+	if err != nil {
+		return nil, err
+	}
+	waves := proto.NewOptionalAssetWaves()
+	tx := proto.NewUnsignedTransferWithProofs(3, pk, waves, waves, proto.NewTimestampFromTime(now),
+		f.opts.Amount, f.opts.Fee, proto.NewRecipientFromAddress(recipient), nil,
+	)
+	if sErr := f.app.services.Wallet.SignTransactionWith(pk, tx); sErr != nil {
+		return nil, errors.Wrap(sErr, "failed to sign faucet transaction")
+	}
+	if bErr := f.app.broadcastTransaction(ctx, tx); bErr != nil {
+		return nil, errors.Wrap(bErr, "failed to broadcast faucet transaction")
+	}
+	return tx, nil
+}
+
+type connAddrKey struct{}
+
+// keepConnAddr saves the address of the connection in the request context before it's replaced by
+// the RealIP middleware.
+func keepConnAddr(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), connAddrKey{}, r.RemoteAddr)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func (f *faucet) trusted(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, p := range f.opts.TrustedProxies {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// remoteIP returns the IP address of the client. Forwarding headers are used only if the connection is made by
+// a trusted proxy, the rightmost address of X-Forwarded-For which doesn't belong to a trusted proxy is the client.
+func (f *faucet) remoteIP(r *http.Request) string {
+	connAddr, ok := r.Context().Value(connAddrKey{}).(string)
+	if !ok {
+		connAddr = r.RemoteAddr
+	}
+	ap, err := netip.ParseAddrPort(connAddr)
+	if err != nil {
+		return connAddr
+	}
+	client := ap.Addr().Unmap()
+	if !f.trusted(client) {
+		return client.String()
+	}
+	if xff := r.Header.Values("X-Forwarded-For"); len(xff) > 0 {
+		hops := strings.Split(strings.Join(xff, ","), ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop, pErr := netip.ParseAddr(strings.TrimSpace(hops[i]))
+			if pErr != nil {
+				break
+			}
+			client = hop.Unmap()
+			if !f.trusted(client) {
+				break
+			}
+		}
+		return client.String()
+	}
+	if xrip, pErr := netip.ParseAddr(strings.TrimSpace(r.Header.Get("X-Real-IP"))); pErr == nil {
+		return xrip.Unmap().String()
+	}
+	return client.String()
+}
+
+func (f *faucet) handle(w http.ResponseWriter, r *http.Request) error {
+	type faucetRequest struct {
+		Address string `json:"address"`
+		Captcha string `json:"captcha,omitempty"`
+	}
+	req := &faucetRequest{}
+	if err := tryParseJson(r.Body, req); err != nil {
+		return errors.Wrap(err, "failed to parse faucet request body as JSON")
+	}
+	recipient, err := proto.NewAddressFromString(req.Address)
+	if err != nil {
+		return apiErrs.InvalidAddress
+	}
+	if ok, vErr := recipient.Valid(f.app.scheme()); !ok {
+		return apiErrs.NewCustomValidationError(vErr.Error())
+	}
+	tx, err := f.dispense(r.Context(), recipient, f.remoteIP(r), req.Captcha)
+	if err != nil {
+		return errors.Wrap(err, "faucet")
+	}
+	if err := trySendJson(w, tx); err != nil {
+		return errors.Wrap(err, "faucet")
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apiErrs "github.com/wavesplatform/gowaves/pkg/api/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/node/messages"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/services"
+)
+
+func TestFaucetQuota(t *testing.T) {
+	now := time.Now()
+	q := newFaucetQuota(2, time.Hour)
+	assert.True(t, q.take("a", now))
+	assert.True(t, q.take("a", now.Add(time.Minute)))
+	assert.False(t, q.take("a", now.Add(2*time.Minute)))
+	assert.True(t, q.take("b", now.Add(2*time.Minute)))
+	// first hit leaves the window
+	assert.True(t, q.take("a", now.Add(time.Hour+time.Second)))
+	assert.False(t, q.take("a", now.Add(time.Hour+2*time.Second)))
+	// refunded hit doesn't count
+	q.refund("a", now.Add(time.Hour+time.Second))
+	assert.True(t, q.take("a", now.Add(time.Hour+3*time.Second)))
+	assert.False(t, q.take("a", now.Add(time.Hour+4*time.Second)))
+	// outdated hits of all keys are evicted
+	assert.True(t, q.take("c", now.Add(3*time.Hour)))
+	assert.Len(t, q.hits, 1)
+	assert.Len(t, q.order, 1)
+
+	unlimited := newFaucetQuota(0, time.Hour)
+	for i := 0; i < 10; i++ {
+		assert.True(t, unlimited.take("a", now))
+	}
+}
+
+func TestNewFaucet(t *testing.T) {
+	_, pk, err := crypto.GenerateKeyPair([]byte("faucet"))
+	require.NoError(t, err)
+	addr, err := proto.NewAddressFromPublicKey(proto.TestNetScheme, pk)
+	require.NoError(t, err)
+
+	app, err := NewApp("", nil, services.Services{Scheme: proto.TestNetScheme})
+	require.NoError(t, err)
+	_, err = newFaucet(app, *DefaultFaucetOptions(addr))
+	assert.NoError(t, err)
+
+	_, err = newFaucet(app, FaucetOptions{Account: addr})
+	assert.EqualError(t, err, "faucet amount must be positive")
+
+	mainNetApp, err := NewApp("", nil, services.Services{Scheme: proto.MainNetScheme})
+	require.NoError(t, err)
+	_, err = newFaucet(mainNetApp, *DefaultFaucetOptions(addr))
+	assert.EqualError(t, err, "faucet is not allowed on MainNet")
+
+	stageNetApp, err := NewApp("", nil, services.Services{Scheme: proto.StageNetScheme})
+	require.NoError(t, err)
+	_, err = newFaucet(stageNetApp, *DefaultFaucetOptions(addr))
+	assert.EqualError(t, err, fmt.Sprintf("faucet account %q belongs to other network", addr.String()))
+}
+
+type faucetTestWallet struct {
+	seed []byte
+}
+
+func (w faucetTestWallet) SignTransactionWith(_ crypto.PublicKey, tx proto.Transaction) error {
+	sk, _, err := crypto.GenerateKeyPair(w.seed)
+	if err != nil {
+		return err
+	}
+	return tx.Sign(proto.TestNetScheme, sk)
+}
+
+func (w faucetTestWallet) Load([]byte) error { return nil }
+
+func (w faucetTestWallet) AccountSeeds() [][]byte { return [][]byte{w.seed} }
+
+type faucetTestTime struct{}
+
+func (faucetTestTime) Now() time.Time { return time.Now() }
+
+func TestFaucetDispenseRefund(t *testing.T) {
+	seed := []byte("faucet")
+	_, pk, err := crypto.GenerateKeyPair(seed)
+	require.NoError(t, err)
+	account, err := proto.NewAddressFromPublicKey(proto.TestNetScheme, pk)
+	require.NoError(t, err)
+	internal := make(chan messages.InternalMessage)
+	app, err := NewApp("", nil, services.Services{
+		Scheme:          proto.TestNetScheme,
+		Wallet:          faucetTestWallet{seed: seed},
+		Time:            faucetTestTime{},
+		InternalChannel: internal,
+	})
+	require.NoError(t, err)
+	opts := DefaultFaucetOptions(account)
+	opts.AddressQuota = 0
+	opts.IPQuota = 1
+	f, err := newFaucet(app, *opts)
+	require.NoError(t, err)
+	const ip = "192.0.2.1"
+
+	// Rejected transfer doesn't count.
+	go func() {
+		msg := <-internal
+		msg.(*messages.BroadcastTransaction).Response <- errors.New("rejected")
+	}()
+	_, err = f.dispense(context.Background(), account, ip, "")
+	var stateCheckErr *apiErrs.StateCheckFailedError
+	assert.ErrorAs(t, err, &stateCheckErr)
+
+	// Transfer with unknown result counts, it may be accepted later.
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-internal
+		cancel()
+	}()
+	_, err = f.dispense(ctx, account, ip, "")
+	assert.ErrorIs(t, err, errBroadcastUnconfirmed)
+
+	_, err = f.dispense(context.Background(), account, ip, "")
+	assert.EqualError(t, err, apiErrs.NewCustomValidationError("faucet quota for IP address is exhausted").Error())
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	proxies, err := ParseTrustedProxies(" 10.0.0.0/8, 192.0.2.1,,::1 ")
+	require.NoError(t, err)
+	assert.Equal(t, []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("192.0.2.1/32"),
+		netip.MustParsePrefix("::1/128"),
+	}, proxies)
+	_, err = ParseTrustedProxies("proxy")
+	assert.Error(t, err)
+}
+
+func TestFaucetRemoteIP(t *testing.T) {
+	f := &faucet{opts: FaucetOptions{TrustedProxies: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}}}
+	request := func(connAddr string, headers map[string]string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/faucet", nil)
+		r.RemoteAddr = "203.0.113.99:1234" // rewritten by RealIP middleware
+		for k, v := range headers {
+			r.Header.Set(k, v)
+		}
+		return r.WithContext(context.WithValue(r.Context(), connAddrKey{}, connAddr))
+	}
+	xff := map[string]string{"X-Forwarded-For": "198.51.100.1, 192.0.2.1, 10.0.0.2"}
+
+	assert.Equal(t, "192.0.2.7", f.remoteIP(request("192.0.2.7:1000", xff)), "headers of untrusted client")
+	assert.Equal(t, "192.0.2.1", f.remoteIP(request("10.0.0.1:1000", xff)), "rightmost untrusted hop")
+	assert.Equal(t, "192.0.2.2",
+		f.remoteIP(request("10.0.0.1:1000", map[string]string{"X-Real-IP": "192.0.2.2"})))
+	assert.Equal(t, "10.0.0.1", f.remoteIP(request("10.0.0.1:1000", nil)))
+}
@@ -0,0 +1,173 @@
+package api
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/miner"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/state"
+)
+
+const (
+	feeEstimateBlocks        = 10      // number of recent blocks to check
+	feeEstimateFullBlock     = 0.9     // fullness starting from which a block is considered full
+	defaultFeeEstimateTxSize = 300     // typical size of a transaction in bytes
+	maxFeeEstimateTxSize     = 1 << 20 // upper bound for the requested transaction size
+)
+
+type feeEstimate struct {
+	Type             proto.TransactionType `json:"type"`
+	Size             int                   `json:"size"`
+	MinFee           uint64                `json:"minFee"`
+	NextBlock        uint64                `json:"nextBlock"`
+	Within3Blocks    uint64                `json:"within3Blocks"`
+	BlockFullness    float64               `json:"blockFullness"`
+	UnconfirmedCount int                   `json:"unconfirmedCount"`
+	UnconfirmedBytes int                   `json:"unconfirmedBytes"`
+}
+
+// FeeEstimate suggests fees in Waves for the transaction of the given type and size to be included into the next
+// block or within 3 blocks. The UTX pool is ordered by fee per byte, so the fee of a new transaction has to outbid
+// transactions that don't fit into the target number of blocks. If recent blocks were full, the fee also has to be
+// not less than the lowest fee per byte accepted in them.
+func (a *App) FeeEstimate(txType proto.TransactionType, size int) (feeEstimate, error) {
+	minFee, ok := state.BaseFee(txType)
+	if !ok {
+		return feeEstimate{}, errors.Errorf("unsupported transaction type %d", txType)
+	}
+	constraints := miner.DefaultConstraints()
+	fullness, minRates, err := a.recentBlocksFeeRates(constraints)
+	if err != nil {
+		return feeEstimate{}, err
+	}
+	utxRates, utxBytes := a.utxFeeRates()
+	res := feeEstimate{
+		Type:             txType,
+		Size:             size,
+		MinFee:           minFee,
+		BlockFullness:    fullness,
+		UnconfirmedCount: len(utxRates),
+		UnconfirmedBytes: utxBytes,
+	}
+	var nextRate, within3Rate uint64
+	if len(minRates) > 0 {
+		nextRate, within3Rate = slices.Max(minRates), slices.Min(minRates)
+	}
+	nextRate = max(nextRate, outbidRate(utxRates, constraints.MaxTxsSizeInBytes-size))
+	within3Rate = max(within3Rate, outbidRate(utxRates, 3*constraints.MaxTxsSizeInBytes-size))
+	res.NextBlock = max(minFee, nextRate*uint64(size))
+	res.Within3Blocks = max(minFee, within3Rate*uint64(size))
+	return res, nil
+}
+
+type feeRate struct {
+	rate uint64 // fee per byte as ordered in UTX pool
+	size int
+}
+
+// utxFeeRates returns fee rates of UTX transactions with fees in Waves ordered from the highest rate to the lowest
+// and the total size of the transactions.
+func (a *App) utxFeeRates() ([]feeRate, int) {
+	txs := a.utx.AllTransactions()
+	rates := make([]feeRate, 0, len(txs))
+	total := 0
+	for _, tx := range txs {
+		if len(tx.B) == 0 {
+			continue
+		}
+		total += len(tx.B)
+		if tx.T.GetFeeAsset().Present {
+			continue // sponsored fees can't be compared with Waves fees directly
+		}
+		rates = append(rates, feeRate{rate: tx.T.GetFee() / uint64(len(tx.B)), size: len(tx.B)})
+	}
+	slices.SortFunc(rates, func(a, b feeRate) int { return cmp.Compare(b.rate, a.rate) })
+	return rates, total
+}
+
+// outbidRate returns the fee rate required to get ahead of transactions that don't fit into the capacity.
+func outbidRate(rates []feeRate, capacity int) uint64 {
+	used := 0
+	for _, r := range rates {
+		used += r.size
+		if used > capacity {
+			return r.rate + 1
+		}
+	}
+	return 0
+}
+
+// recentBlocksFeeRates returns the average fullness of recent blocks and the lowest fee rates of full blocks.
+func (a *App) recentBlocksFeeRates(constraints miner.Constraints) (float64, []uint64, error) {
+	height, err := a.state.Height()
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "failed to get height")
+	}
+	var (
+		total float64
+		n     int
+		rates []uint64
+	)
+	for h := height; h > 0 && n < feeEstimateBlocks; h-- {
+		header, hErr := a.state.HeaderByHeight(h)
+		if hErr != nil {
+			return 0, nil, errors.Wrapf(hErr, "failed to get block header at height %d", h)
+		}
+		maxCount := proto.MaxTransactionsPerBlock
+		if header.Version < proto.NgBlockVersion {
+			maxCount = constraints.ClassicAmountOfTxsInBlock
+		}
+		fullness := math.Max(
+			float64(header.TransactionBlockLength)/float64(constraints.MaxTxsSizeInBytes),
+			float64(header.TransactionCount)/float64(maxCount),
+		)
+		fullness = math.Min(fullness, 1)
+		total += fullness
+		n++
+		if fullness < feeEstimateFullBlock {
+			continue
+		}
+		rate, ok, rErr := a.lowestFeeRate(h)
+		if rErr != nil {
+			return 0, nil, rErr
+		}
+		if ok {
+			rates = append(rates, rate)
+		}
+	}
+	if n == 0 {
+		return 0, nil, nil
+	}
+	return total / float64(n), rates, nil
+}
+
+// lowestFeeRate returns the lowest fee rate of transactions with fees in Waves in the block at the given height.
+func (a *App) lowestFeeRate(height proto.Height) (uint64, bool, error) {
+	block, err := a.state.BlockByHeight(height)
+	if err != nil {
+		return 0, false, errors.Wrapf(err, "failed to get block at height %d", height)
+	}
+	var (
+		lowest uint64 = math.MaxUint64
+		found  bool
+	)
+	for _, tx := range block.Transactions {
+		if tx.GetFeeAsset().Present {
+			continue
+		}
+		b, mErr := proto.MarshalTx(a.services.Scheme, tx)
+		if mErr != nil {
+			return 0, false, errors.Wrap(mErr, "failed to marshal transaction")
+		}
+		if len(b) == 0 {
+			continue
+		}
+		lowest = min(lowest, tx.GetFee()/uint64(len(b)))
+		found = true
+	}
+	return lowest, found, nil
+}
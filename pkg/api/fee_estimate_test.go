@@ -0,0 +1,62 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/mock"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/services"
+	"github.com/wavesplatform/gowaves/pkg/types"
+)
+
+type utxWithTransactions struct {
+	types.UtxPool
+	txs []*types.TransactionWithBytes
+}
+
+func (u *utxWithTransactions) AllTransactions() []*types.TransactionWithBytes {
+	return u.txs
+}
+
+func TestOutbidRate(t *testing.T) {
+	rates := []feeRate{{rate: 100, size: 400}, {rate: 50, size: 400}, {rate: 10, size: 400}}
+	assert.Equal(t, uint64(0), outbidRate(rates, 1200))
+	assert.Equal(t, uint64(11), outbidRate(rates, 1000))
+	assert.Equal(t, uint64(51), outbidRate(rates, 500))
+	assert.Equal(t, uint64(101), outbidRate(rates, 300))
+	assert.Equal(t, uint64(0), outbidRate(nil, 0))
+}
+
+func TestApp_FeeEstimate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	st := mock.NewMockState(ctrl)
+	utx := &utxWithTransactions{}
+	for range 3 {
+		tx := &proto.TransferWithProofs{Transfer: proto.Transfer{Fee: 1000 * 400 * 1024}}
+		utx.txs = append(utx.txs, &types.TransactionWithBytes{T: tx, B: make([]byte, 400*1024)})
+	}
+	app, err := NewApp("api-key", nil, services.Services{State: st, UtxPool: utx, Scheme: proto.TestNetScheme})
+	require.NoError(t, err)
+
+	st.EXPECT().Height().Return(proto.Height(2), nil)
+	st.EXPECT().HeaderByHeight(proto.Height(2)).Return(&proto.BlockHeader{Version: proto.ProtobufBlockVersion}, nil)
+	st.EXPECT().HeaderByHeight(proto.Height(1)).Return(&proto.BlockHeader{Version: proto.ProtobufBlockVersion}, nil)
+
+	res, err := app.FeeEstimate(proto.TransferTransaction, 300)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100_000), res.MinFee)
+	assert.Equal(t, 3, res.UnconfirmedCount)
+	assert.Equal(t, 3*400*1024, res.UnconfirmedBytes)
+	assert.Zero(t, res.BlockFullness)
+	// Only two transactions fit into the next block, the third one has to be outbid.
+	assert.Equal(t, uint64(1001*300), res.NextBlock)
+	// All transactions fit into 3 blocks.
+	assert.Equal(t, uint64(100_000), res.Within3Blocks)
+
+	_, err = app.FeeEstimate(proto.TransactionType(255), 300)
+	assert.Error(t, err)
+}
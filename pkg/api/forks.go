@@ -0,0 +1,61 @@
+package api
+
+import (
+	"math/big"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/node/forks"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+type forkResponse struct {
+	Tip          proto.BlockID `json:"tip"`
+	CommonHeight proto.Height  `json:"commonHeight"`
+	Length       uint64        `json:"length"`
+	Score        *big.Int      `json:"score"`
+	LastSeen     int64         `json:"lastSeen"`
+}
+
+// Forks returns the competing chains seen by the node which can still replace the current one.
+func (a *App) Forks() ([]forkResponse, error) {
+	height, err := a.state.Height()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get height")
+	}
+	inMainChain := func(id proto.BlockID) bool {
+		_, hErr := a.state.BlockIDToHeight(id)
+		return hErr == nil
+	}
+	res := make([]forkResponse, 0)
+	for _, f := range a.services.Forks.List(height, inMainChain) {
+		res = append(res, forkResponse{
+			Tip:          f.Tip,
+			CommonHeight: f.CommonHeight,
+			Length:       f.Length,
+			Score:        f.Score,
+			LastSeen:     f.LastSeen.UnixMilli(),
+		})
+	}
+	return res, nil
+}
+
+func (a *NodeApi) debugForks(w http.ResponseWriter, _ *http.Request) error {
+	list, err := a.app.Forks()
+	if err != nil {
+		return errors.Wrap(err, "debugForks")
+	}
+	if err := trySendJson(w, list); err != nil {
+		return errors.Wrap(err, "debugForks")
+	}
+	return nil
+}
+
+// debugForksStats returns the counters of micro-fork rollbacks, key-block switches and discarded micro-blocks.
+func (a *NodeApi) debugForksStats(w http.ResponseWriter, _ *http.Request) error {
+	if err := trySendJson(w, forks.CurrentStats()); err != nil {
+		return errors.Wrap(err, "debugForksStats")
+	}
+	return nil
+}
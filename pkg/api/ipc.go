@@ -0,0 +1,107 @@
+package api
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	// ipcAPIKeyName is the name of the ephemeral API key authorizing requests received over the IPC socket.
+	ipcAPIKeyName   = "ipc"
+	ipcSocketMode   = 0600
+	ipcProbeTimeout = time.Second
+)
+
+// RunIPC serves the REST API on the unix socket at the path for local tools like the 'attach' console.
+// Access to the socket is restricted by its permissions to the user running the node, so requests received over it
+// are authorized with the ephemeral API key existing only while the server is running. Rate limits, quotas and
+// the other options for the public API are not applied.
+func RunIPC(ctx context.Context, path string, n *NodeApi, opts *RunOptions) error {
+	ipcOpts := DefaultRunOptions()
+	if opts != nil {
+		o := *opts
+		ipcOpts = &o
+	}
+	ipcOpts.RateLimiterOpts = nil
+	ipcOpts.APIKeyQuotas = nil
+	ipcOpts.UseRealIPMiddleware = false
+	ipcOpts.FaucetOpts = nil
+	ipcOpts.EnableExplorer = false
+	ipcOpts.GRPCWeb = nil
+	routes, err := n.routes(ipcOpts)
+	if err != nil {
+		return errors.Wrap(err, "RunIPC")
+	}
+	ln, err := listenIPC(path)
+	if err != nil {
+		return err
+	}
+	key, err := n.app.keys.addEphemeral(ipcAPIKeyName)
+	if err != nil {
+		_ = ln.Close()
+		return errors.Wrap(err, "failed to add IPC API key")
+	}
+	defer n.app.keys.removeEphemeral(ipcAPIKeyName)
+
+	srv := &http.Server{
+		Handler:           ipcAuthHandler(key, routes),
+		ReadHeaderTimeout: DefaultServerOptions().ReadHeaderTimeout,
+	}
+	done := make(chan struct{})
+	defer func() { <-done }() // wait for server shutdown
+	go func() {
+		defer close(done)
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), ipcOpts.ShutdownTimeout)
+		defer cancel()
+		if sErr := srv.Shutdown(shutdownCtx); sErr != nil {
+			zap.S().Errorf("Failed to shutdown IPC server gracefully: %v", sErr)
+			if clErr := srv.Close(); clErr != nil {
+				zap.S().Errorf("Failed to close IPC server: %v", clErr)
+			}
+		}
+	}()
+	if sErr := srv.Serve(ln); sErr != nil && !errors.Is(sErr, http.ErrServerClosed) {
+		return sErr
+	}
+	return nil
+}
+
+// listenIPC creates the socket accessible only by the current user. The socket file left by the node which was not
+// stopped gracefully is removed, but the socket of the running node is not.
+func listenIPC(path string) (net.Listener, error) {
+	if _, err := os.Stat(path); err == nil {
+		conn, dErr := net.DialTimeout("unix", path, ipcProbeTimeout)
+		if dErr == nil {
+			_ = conn.Close()
+			return nil, errors.Errorf("IPC socket '%s' is in use", path)
+		}
+		if rErr := os.Remove(path); rErr != nil {
+			return nil, errors.Wrapf(rErr, "failed to remove stale IPC socket '%s'", path)
+		}
+	}
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to listen on IPC socket '%s'", path)
+	}
+	if chErr := os.Chmod(path, ipcSocketMode); chErr != nil {
+		_ = ln.Close()
+		return nil, errors.Wrapf(chErr, "failed to set permissions of IPC socket '%s'", path)
+	}
+	return ln, nil
+}
+
+// ipcAuthHandler replaces the credentials of the request with the IPC API key.
+func ipcAuthHandler(key string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Header.Del("Authorization")
+		r.Header.Set("X-API-Key", key)
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/services"
+)
+
+func TestRunIPC(t *testing.T) {
+	// Paths of unix sockets are limited to about a hundred bytes, the test temporary directory could be longer.
+	dir, err := os.MkdirTemp("", "ipc")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+	path := filepath.Join(dir, "node.ipc")
+	stale, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+
+	app, err := NewApp("api-key", nil, services.Services{})
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() { errCh <- RunIPC(ctx, path, NewNodeAPI(app, nil), nil) }()
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return new(net.Dialer).DialContext(ctx, "unix", path)
+		},
+	}}
+	var resp *http.Response
+	require.Eventually(t, func() bool {
+		// The protected route is requested without the API key.
+		resp, err = client.Get("http://ipc/go/api-keys/")
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+	defer func() { _ = resp.Body.Close() }()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var keys []adminKeyInfo
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&keys))
+	require.Len(t, keys, 2)
+	assert.Equal(t, configuredAPIKeyName, keys[0].Name)
+	assert.Equal(t, ipcAPIKeyName, keys[1].Name)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(ipcSocketMode), info.Mode().Perm())
+	_, err = listenIPC(path)
+	assert.Error(t, err, "socket of the running server must not be replaced")
+
+	cancel()
+	require.NoError(t, <-errCh)
+	assert.Len(t, app.keys.list(), 1, "IPC API key must be removed")
+	_, err = os.Stat(path)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
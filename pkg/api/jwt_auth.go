@@ -0,0 +1,390 @@
+package api
+
+import (
+	"context"
+	stdcrypto "crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	_ "crypto/sha256" // SHA-256 is used by RS256 and ES256 algorithms
+	_ "crypto/sha512" // SHA-384 and SHA-512 are used by RS384, RS512, ES384 and ES512 algorithms
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	DefaultJWKSRefreshInterval = time.Hour
+	DefaultJWTLeeway           = time.Minute
+
+	jwksFetchTimeout     = 10 * time.Second
+	jwksMinFetchInterval = time.Minute
+	jwksMaxSize          = 1024 * 1024
+)
+
+// JWTAuthOptions enables authentication of requests to protected methods with bearer JWTs issued by the identity
+// provider. Tokens are accepted in addition to API keys. Signatures are verified with the keys published by the
+// provider at JWKSURL, which must be an https URL. Only RSA and ECDSA signatures are supported.
+type JWTAuthOptions struct {
+	Issuer          string
+	JWKSURL         string
+	Audience        string // if set, the token must be issued for the audience
+	Scope           string // if set, the scope must be granted to the token
+	RefreshInterval time.Duration
+	Leeway          time.Duration // allowed clock skew for expiration checks
+}
+
+var jwtAlgorithms = map[string]stdcrypto.Hash{
+	"RS256": stdcrypto.SHA256,
+	"RS384": stdcrypto.SHA384,
+	"RS512": stdcrypto.SHA512,
+	"ES256": stdcrypto.SHA256,
+	"ES384": stdcrypto.SHA384,
+	"ES512": stdcrypto.SHA512,
+}
+
+var jwtCurves = map[string]elliptic.Curve{
+	"ES256": elliptic.P256(),
+	"ES384": elliptic.P384(),
+	"ES512": elliptic.P521(),
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// jwtAudience is the value of "aud" claim, which could be either a string or an array of strings.
+type jwtAudience []string
+
+func (a *jwtAudience) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*a = jwtAudience{s}
+		return nil
+	}
+	var ss []string
+	if err := json.Unmarshal(data, &ss); err != nil {
+		return errors.New("invalid audience")
+	}
+	*a = ss
+	return nil
+}
+
+type jwtClaims struct {
+	Issuer    string      `json:"iss"`
+	Subject   string      `json:"sub"`
+	Audience  jwtAudience `json:"aud"`
+	ExpiresAt *int64      `json:"exp"`
+	NotBefore *int64      `json:"nbf"`
+	Scope     string      `json:"scope"`
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type verificationKey struct {
+	alg string // algorithm the key is restricted to, empty if any algorithm of the key type is allowed
+	key stdcrypto.PublicKey
+}
+
+// jwtValidator validates bearer tokens, keys of the provider are fetched on the first use, on the expiration of
+// refresh interval and when the token is signed with unknown key.
+type jwtValidator struct {
+	opts   JWTAuthOptions
+	client *http.Client
+	now    func() time.Time
+
+	mu      sync.Mutex
+	keys    map[string]verificationKey
+	fetched time.Time
+}
+
+func newJWTValidator(opts JWTAuthOptions) (*jwtValidator, error) {
+	if opts.Issuer == "" {
+		return nil, errors.New("empty JWT issuer")
+	}
+	if opts.JWKSURL == "" {
+		return nil, errors.New("empty JWKS URL")
+	}
+	if u, err := url.Parse(opts.JWKSURL); err != nil || u.Scheme != "https" || u.Host == "" {
+		return nil, errors.Errorf("JWKS URL '%s' is not an https URL", opts.JWKSURL)
+	}
+	if opts.RefreshInterval <= 0 {
+		opts.RefreshInterval = DefaultJWKSRefreshInterval
+	}
+	if opts.Leeway < 0 {
+		opts.Leeway = 0
+	}
+	return &jwtValidator{
+		opts:   opts,
+		client: &http.Client{Timeout: jwksFetchTimeout},
+		now:    time.Now,
+	}, nil
+}
+
+// NewBearerTokenChecker creates the function validating bearer tokens the same way as protected REST API methods do,
+// it's used to protect other API surfaces.
+func NewBearerTokenChecker(opts JWTAuthOptions) (func(ctx context.Context, token string) error, error) {
+	v, err := newJWTValidator(opts)
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, token string) error {
+		_, vErr := v.validate(ctx, token)
+		return vErr
+	}, nil
+}
+
+// validate checks the signature and the claims of the token and returns the subject of the token.
+func (v *jwtValidator) validate(ctx context.Context, token string) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", errors.New("malformed token")
+	}
+	var h jwtHeader
+	if err := decodeJWTPart(parts[0], &h); err != nil {
+		return "", errors.Wrap(err, "invalid token header")
+	}
+	key, err := v.key(ctx, h.Kid)
+	if err != nil {
+		return "", err
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", errors.Wrap(err, "invalid token signature")
+	}
+	if vErr := verifyJWTSignature(h.Alg, key, parts[0]+"."+parts[1], sig); vErr != nil {
+		return "", vErr
+	}
+	var c jwtClaims
+	if dErr := decodeJWTPart(parts[1], &c); dErr != nil {
+		return "", errors.Wrap(dErr, "invalid token claims")
+	}
+	if vErr := v.validateClaims(&c); vErr != nil {
+		return "", vErr
+	}
+	return c.Subject, nil
+}
+
+func (v *jwtValidator) validateClaims(c *jwtClaims) error {
+	now := v.now()
+	if c.Issuer != v.opts.Issuer {
+		return errors.Errorf("unexpected issuer '%s'", c.Issuer)
+	}
+	if v.opts.Audience != "" && !slices.Contains(c.Audience, v.opts.Audience) {
+		return errors.New("token is not issued for the node")
+	}
+	if c.ExpiresAt == nil {
+		return errors.New("token without expiration time")
+	}
+	if now.After(time.Unix(*c.ExpiresAt, 0).Add(v.opts.Leeway)) {
+		return errors.New("token is expired")
+	}
+	if c.NotBefore != nil && now.Add(v.opts.Leeway).Before(time.Unix(*c.NotBefore, 0)) {
+		return errors.New("token is not valid yet")
+	}
+	if v.opts.Scope != "" && !slices.Contains(strings.Fields(c.Scope), v.opts.Scope) {
+		return errors.Errorf("scope '%s' is not granted", v.opts.Scope)
+	}
+	return nil
+}
+
+// key returns the verification key by ID, the key set is fetched again if it's outdated or the key is unknown.
+func (v *jwtValidator) key(ctx context.Context, kid string) (verificationKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	since := v.now().Sub(v.fetched)
+	k, ok := v.lookup(kid)
+	if (!ok && since >= jwksMinFetchInterval) || since >= v.opts.RefreshInterval {
+		keys, err := v.fetch(ctx)
+		if err != nil {
+			return verificationKey{}, errors.Wrap(err, "failed to fetch JWKS")
+		}
+		v.keys = keys
+		v.fetched = v.now()
+		k, ok = v.lookup(kid)
+	}
+	if !ok {
+		return verificationKey{}, errors.Errorf("unknown key '%s'", kid)
+	}
+	return k, nil
+}
+
+// lookup returns the key by ID, token without key ID could be verified only if there is the single key.
+// Must be called under the lock.
+func (v *jwtValidator) lookup(kid string) (verificationKey, bool) {
+	if kid == "" && len(v.keys) == 1 {
+		for _, k := range v.keys {
+			return k, true
+		}
+	}
+	k, ok := v.keys[kid]
+	return k, ok
+}
+
+func (v *jwtValidator) fetch(ctx context.Context) (map[string]verificationKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.opts.JWKSURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return parseJWKS(io.LimitReader(resp.Body, jwksMaxSize))
+}
+
+func parseJWKS(r io.Reader) (map[string]verificationKey, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.NewDecoder(r).Decode(&set); err != nil {
+		return nil, err
+	}
+	keys := make(map[string]verificationKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		pk, err := k.publicKey()
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid key '%s'", k.Kid)
+		}
+		if pk == nil {
+			continue // Unsupported key types are skipped.
+		}
+		keys[k.Kid] = verificationKey{alg: k.Alg, key: pk}
+	}
+	return keys, nil
+}
+
+func (k *jwk) publicKey() (stdcrypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeJWKInt(k.N)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid modulus")
+		}
+		e, err := decodeJWKInt(k.E)
+		if err != nil || !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, errors.New("invalid exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, errors.Errorf("unsupported curve '%s'", k.Crv)
+		}
+		x, err := decodeJWKInt(k.X)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid X coordinate")
+		}
+		y, err := decodeJWKInt(k.Y)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid Y coordinate")
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("point is not on curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, nil
+	}
+}
+
+func decodeJWKInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, errors.New("empty value")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+func decodeJWTPart(s string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+func verifyJWTSignature(alg string, k verificationKey, signed string, sig []byte) error {
+	if k.alg != "" && k.alg != alg {
+		return errors.Errorf("algorithm '%s' is not allowed for the key", alg)
+	}
+	hash, ok := jwtAlgorithms[alg]
+	if !ok {
+		return errors.Errorf("unsupported algorithm '%s'", alg)
+	}
+	h := hash.New()
+	h.Write([]byte(signed))
+	digest := h.Sum(nil)
+	switch pk := k.key.(type) {
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(alg, "RS") {
+			return errors.Errorf("algorithm '%s' is not allowed for RSA key", alg)
+		}
+		if err := rsa.VerifyPKCS1v15(pk, hash, digest, sig); err != nil {
+			return errors.New("invalid token signature")
+		}
+	case *ecdsa.PublicKey:
+		if c, isEC := jwtCurves[alg]; !isEC || c != pk.Curve {
+			return errors.Errorf("algorithm '%s' is not allowed for the EC key", alg)
+		}
+		size := (pk.Curve.Params().BitSize + 7) / 8
+		if len(sig) != 2*size {
+			return errors.New("invalid token signature")
+		}
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		if !ecdsa.Verify(pk, digest, r, s) {
+			return errors.New("invalid token signature")
+		}
+	default:
+		return errors.New("unsupported key type")
+	}
+	return nil
+}
+
+// bearerToken returns the token from Authorization header of the request.
+func bearerToken(r *http.Request) (string, bool) {
+	const prefix = "Bearer "
+	h := r.Header.Get("Authorization")
+	if len(h) <= len(prefix) || !strings.EqualFold(h[:len(prefix)], prefix) {
+		return "", false
+	}
+	return h[len(prefix):], true
+}
@@ -0,0 +1,201 @@
+package api
+
+import (
+	"context"
+	stdcrypto "crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apiErrs "github.com/wavesplatform/gowaves/pkg/api/errors"
+	"github.com/wavesplatform/gowaves/pkg/services"
+)
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func signJWT(t *testing.T, alg, kid string, key stdcrypto.Signer, claims map[string]interface{}) string {
+	h, err := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	require.NoError(t, err)
+	c, err := json.Marshal(claims)
+	require.NoError(t, err)
+	signed := b64(h) + "." + b64(c)
+	hash := jwtAlgorithms[alg]
+	hh := hash.New()
+	hh.Write([]byte(signed))
+	digest := hh.Sum(nil)
+	var sig []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		sig, err = rsa.SignPKCS1v15(rand.Reader, k, hash, digest)
+		require.NoError(t, err)
+	case *ecdsa.PrivateKey:
+		r, s, sErr := ecdsa.Sign(rand.Reader, k, digest)
+		require.NoError(t, sErr)
+		size := (k.Curve.Params().BitSize + 7) / 8
+		sig = make([]byte, 2*size)
+		r.FillBytes(sig[:size])
+		s.FillBytes(sig[size:])
+	}
+	return signed + "." + b64(sig)
+}
+
+func TestJWTValidator(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	var fetches atomic.Int32
+	jwks := map[string]interface{}{"keys": []map[string]string{
+		{
+			"kty": "RSA", "kid": "rsa", "use": "sig", "alg": "RS256",
+			"n": b64(rsaKey.N.Bytes()), "e": b64(big.NewInt(int64(rsaKey.E)).Bytes()),
+		},
+		{
+			"kty": "EC", "kid": "ec", "crv": "P-256",
+			"x": b64(ecKey.X.FillBytes(make([]byte, 32))), "y": b64(ecKey.Y.FillBytes(make([]byte, 32))),
+		},
+		{"kty": "oct", "kid": "hmac", "k": "c2VjcmV0"},
+	}}
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fetches.Add(1)
+		assert.NoError(t, json.NewEncoder(w).Encode(jwks))
+	}))
+	defer srv.Close()
+
+	v, err := newJWTValidator(JWTAuthOptions{
+		Issuer: "https://sso.example.com", JWKSURL: srv.URL, Audience: "node", Scope: "node:admin",
+	})
+	require.NoError(t, err)
+	v.client = srv.Client()
+	now := time.Now()
+	v.now = func() time.Time { return now }
+
+	claims := func(modify func(c map[string]interface{})) map[string]interface{} {
+		c := map[string]interface{}{
+			"iss": "https://sso.example.com", "sub": "alice", "aud": []string{"node", "explorer"},
+			"exp": now.Add(time.Hour).Unix(), "scope": "openid node:admin",
+		}
+		if modify != nil {
+			modify(c)
+		}
+		return c
+	}
+	ctx := context.Background()
+
+	sub, err := v.validate(ctx, signJWT(t, "RS256", "rsa", rsaKey, claims(nil)))
+	require.NoError(t, err)
+	assert.Equal(t, "alice", sub)
+	_, err = v.validate(ctx, signJWT(t, "ES256", "ec", ecKey, claims(func(c map[string]interface{}) {
+		c["aud"] = "node"
+	})))
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, fetches.Load())
+
+	for _, test := range []struct {
+		name  string
+		token string
+	}{
+		{"malformed", "token"},
+		{"wrong key", signJWT(t, "ES256", "ec", otherKey, claims(nil))},
+		{"algorithm not allowed for key", signJWT(t, "RS512", "rsa", rsaKey, claims(nil))},
+		{"algorithm of other key type", signJWT(t, "ES256", "rsa", ecKey, claims(nil))},
+		{"unsupported key", signJWT(t, "ES256", "hmac", ecKey, claims(nil))},
+		{"unexpected issuer", signJWT(t, "ES256", "ec", ecKey, claims(func(c map[string]interface{}) {
+			c["iss"] = "https://evil.example.com"
+		}))},
+		{"other audience", signJWT(t, "ES256", "ec", ecKey, claims(func(c map[string]interface{}) {
+			c["aud"] = "explorer"
+		}))},
+		{"no expiration", signJWT(t, "ES256", "ec", ecKey, claims(func(c map[string]interface{}) {
+			delete(c, "exp")
+		}))},
+		{"expired", signJWT(t, "ES256", "ec", ecKey, claims(func(c map[string]interface{}) {
+			c["exp"] = now.Add(-time.Minute).Unix()
+		}))},
+		{"not valid yet", signJWT(t, "ES256", "ec", ecKey, claims(func(c map[string]interface{}) {
+			c["nbf"] = now.Add(time.Minute).Unix()
+		}))},
+		{"no scope", signJWT(t, "ES256", "ec", ecKey, claims(func(c map[string]interface{}) {
+			c["scope"] = "openid"
+		}))},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, vErr := v.validate(ctx, test.token)
+			assert.Error(t, vErr)
+		})
+	}
+	// Unknown keys don't cause fetching of JWKS more often than once in a while.
+	assert.EqualValues(t, 1, fetches.Load())
+	now = now.Add(jwksMinFetchInterval)
+	_, err = v.validate(ctx, signJWT(t, "ES256", "unknown", ecKey, claims(nil)))
+	assert.Error(t, err)
+	assert.EqualValues(t, 2, fetches.Load())
+}
+
+func TestCheckAuthMiddlewareBearerToken(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		assert.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{{
+			"kty": "EC", "kid": "ec", "crv": "P-256",
+			"x": b64(key.X.FillBytes(make([]byte, 32))), "y": b64(key.Y.FillBytes(make([]byte, 32))),
+		}}}))
+	}))
+	defer srv.Close()
+	v, err := newJWTValidator(JWTAuthOptions{Issuer: "sso", JWKSURL: srv.URL})
+	require.NoError(t, err)
+	v.client = srv.Client()
+	app, err := NewApp("api-key", nil, services.Services{})
+	require.NoError(t, err)
+
+	var handledErr error
+	mw := createCheckAuthMiddleware(app, v, func(w http.ResponseWriter, _ *http.Request, err error) {
+		handledErr = err
+		w.WriteHeader(http.StatusForbidden)
+	})
+	h := mw(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) }))
+	serve := func(header, value string) int {
+		handledErr = nil
+		req := httptest.NewRequest(http.MethodGet, "/go/api-keys", nil)
+		req.Header.Set(header, value)
+		resp := httptest.NewRecorder()
+		h.ServeHTTP(resp, req)
+		return resp.Code
+	}
+
+	token := signJWT(t, "ES256", "ec", key, map[string]interface{}{
+		"iss": "sso", "sub": "bob", "exp": time.Now().Add(time.Hour).Unix(),
+	})
+	assert.Equal(t, http.StatusOK, serve("Authorization", "Bearer "+token))
+	assert.Equal(t, http.StatusOK, serve("X-API-Key", "api-key"))
+	assert.Equal(t, http.StatusForbidden, serve("Authorization", "Bearer "+token[:len(token)-2]))
+	assert.ErrorIs(t, handledErr, apiErrs.ApiKeyNotValid)
+	assert.Equal(t, http.StatusForbidden, serve("Authorization", "Basic "+token))
+	assert.ErrorIs(t, handledErr, apiErrs.ApiKeyNotValid)
+}
+
+func TestNewJWTValidatorJWKSURL(t *testing.T) {
+	for _, u := range []string{"", "http://sso.example.com/jwks", "sso.example.com/jwks", "https:///jwks"} {
+		_, err := newJWTValidator(JWTAuthOptions{Issuer: "sso", JWKSURL: u})
+		assert.Error(t, err, u)
+	}
+	_, err := newJWTValidator(JWTAuthOptions{Issuer: "sso", JWKSURL: "https://sso.example.com/jwks"})
+	assert.NoError(t, err)
+}
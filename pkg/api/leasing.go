@@ -0,0 +1,52 @@
+package api
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+const (
+	leaseStatusActive   = "active"
+	leaseStatusCanceled = "canceled"
+)
+
+type leaseInfo struct {
+	ID                  crypto.Digest      `json:"id"`
+	OriginTransactionID crypto.Digest      `json:"originTransactionId"`
+	Sender              proto.WavesAddress `json:"sender"`
+	Recipient           proto.WavesAddress `json:"recipient"`
+	Amount              uint64             `json:"amount"`
+	Height              proto.Height       `json:"height"`
+	Status              string             `json:"status"`
+	CancelHeight        *proto.Height      `json:"cancelHeight,omitempty"`
+	CancelTransactionID *crypto.Digest     `json:"cancelTransactionId,omitempty"`
+}
+
+func newLeaseInfo(l *proto.LeaseDetails) leaseInfo {
+	info := leaseInfo{
+		ID:                  l.ID,
+		OriginTransactionID: l.OriginTransactionID,
+		Sender:              l.Sender,
+		Recipient:           l.Recipient,
+		Amount:              l.Amount,
+		Height:              l.Height,
+		Status:              leaseStatusActive,
+	}
+	if !l.IsActive {
+		info.Status = leaseStatusCanceled
+		h := l.CancelHeight
+		info.CancelHeight = &h
+		info.CancelTransactionID = l.CancelTransactionID
+	}
+	return info
+}
+
+func (a *App) LeaseInfo(id crypto.Digest) (*proto.LeaseDetails, error) {
+	l, err := a.state.LeaseInfo(id)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get lease info by id %q", id.String())
+	}
+	return l, nil
+}
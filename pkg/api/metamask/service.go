@@ -245,7 +245,7 @@ func ethCall(state state.State, scheme proto.Scheme, params ethCallParams) ([]by
 	}
 
 	var (
-		shortAssetID = proto.AssetID(params.To)
+		shortAssetID = params.To.AssetID()
 	)
 	switch selector {
 	case erc20SymbolSelector:
@@ -312,7 +312,7 @@ func (s RPCService) Eth_GetCode(ethAddr proto.EthereumAddress, blockOrTag string
 	switch {
 	case stateerr.IsNotFound(err):
 		// account has no script, trying fetch data as asset
-		assetID := proto.AssetID(ethAddr)
+		assetID := ethAddr.AssetID()
 		_, err := s.nodeRPCApp.State.AssetInfo(assetID)
 		switch {
 		case errors.Is(err, errs.UnknownAsset{}):
@@ -1,13 +1,20 @@
 package api
 
 import (
+	"context"
 	"net/http"
 	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
+
+	apiErrs "github.com/wavesplatform/gowaves/pkg/api/errors"
+	"github.com/wavesplatform/gowaves/pkg/metrics"
 )
 
 // createLoggerMiddleware creates a middleware that logs the start and end of each request, along
@@ -62,8 +69,10 @@ func chiHttpApiGeneralMetricsMiddleware(next http.Handler) http.Handler {
 			statusCode := ww.Status()
 			metricApiHits.WithLabelValues(strconv.Itoa(statusCode), routePath).Inc()
 
+			duration := time.Since(begin)
 			observer := metricApiRequestDuration.WithLabelValues(r.Method, routePath)
-			observer.Observe(time.Since(begin).Seconds())
+			observer.Observe(duration.Seconds())
+			metrics.APIRequest(duration)
 		}()
 
 		next.ServeHTTP(ww, r)
@@ -87,9 +96,24 @@ func JsonContentTypeMiddleware(next http.Handler) http.Handler {
 	})(next)
 }
 
-func createCheckAuthMiddleware(app *App, errorHandler HandleErrorFunc) func(next http.Handler) http.Handler {
+// createCheckAuthMiddleware creates the middleware that passes requests with valid API key in X-API-Key header.
+// If JWT validator is set, requests with valid bearer token in Authorization header are passed too.
+func createCheckAuthMiddleware(
+	app *App, jwt *jwtValidator, errorHandler HandleErrorFunc,
+) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if token, ok := bearerToken(r); ok && jwt != nil {
+				sub, err := jwt.validate(r.Context(), token)
+				if err != nil {
+					zap.S().Debugf("Bearer token of request to '%s' is rejected: %v", r.URL.Path, err)
+					errorHandler(w, r, apiErrs.ApiKeyNotValid)
+					return
+				}
+				zap.S().Debugf("Request to '%s' is authorized by bearer token of '%s'", r.URL.Path, sub)
+				next.ServeHTTP(w, r)
+				return
+			}
 			apiKey := r.Header.Get("X-API-Key")
 			err := app.checkAuth(apiKey)
 			if err != nil {
@@ -100,3 +124,52 @@ func createCheckAuthMiddleware(app *App, errorHandler HandleErrorFunc) func(next
 		})
 	}
 }
+
+// createHandlerTimeoutMiddleware creates the middleware that sets the deadline of request's context by the timeout
+// of the route. If the handler stops on the deadline without writing a response, 504 status is returned.
+func createHandlerTimeoutMiddleware(dft time.Duration, routes []RouteTimeout) func(next http.Handler) http.Handler {
+	timeout := func(path string) time.Duration {
+		t, l := dft, -1
+		for _, rt := range routes {
+			if len(rt.Prefix) > l && strings.HasPrefix(path, rt.Prefix) {
+				t, l = rt.Timeout, len(rt.Prefix)
+			}
+		}
+		return t
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t := timeout(r.URL.Path)
+			if t <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+			ctx, cancel := context.WithTimeout(r.Context(), t)
+			defer cancel()
+			ww, ok := w.(middleware.WrapResponseWriter)
+			if !ok {
+				ww = middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			}
+			next.ServeHTTP(ww, r.WithContext(ctx))
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) && ww.Status() == 0 {
+				http.Error(ww, http.StatusText(http.StatusGatewayTimeout), http.StatusGatewayTimeout)
+			}
+		})
+	}
+}
+
+// createDrainingMiddleware creates the middleware that rejects requests with 503 status while the server is being
+// shut down. Connection: close header makes keep-alive clients reconnect, presumably to another node.
+func createDrainingMiddleware(draining *atomic.Bool) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if draining.Load() {
+				w.Header().Set("Connection", "close")
+				w.Header().Set("Retry-After", "1")
+				http.Error(w, "node is shutting down", http.StatusServiceUnavailable)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
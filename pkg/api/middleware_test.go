@@ -0,0 +1,58 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandlerTimeoutMiddleware(t *testing.T) {
+	mw := createHandlerTimeoutMiddleware(time.Hour, []RouteTimeout{
+		{"/blocks", time.Millisecond},
+		{"/blocks/last", 0},
+	})
+	h := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deadline, ok := r.Context().Deadline()
+		if !ok {
+			_, _ = w.Write([]byte("no deadline"))
+			return
+		}
+		if time.Until(deadline) > time.Minute {
+			_, _ = w.Write([]byte("default"))
+			return
+		}
+		<-r.Context().Done() // slow handler stops on the deadline without response
+	}))
+	serve := func(path string) *httptest.ResponseRecorder {
+		resp := httptest.NewRecorder()
+		h.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, path, nil))
+		return resp
+	}
+
+	assert.Equal(t, "default", serve("/addresses").Body.String())
+	assert.Equal(t, http.StatusGatewayTimeout, serve("/blocks/headers/seq/1/10").Code)
+	assert.Equal(t, "no deadline", serve("/blocks/last").Body.String())
+}
+
+func TestDrainingMiddleware(t *testing.T) {
+	var draining atomic.Bool
+	h := createDrainingMiddleware(&draining)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	resp := httptest.NewRecorder()
+	h.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/blocks/height", nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Empty(t, resp.Header().Get("Connection"))
+
+	draining.Store(true)
+	resp = httptest.NewRecorder()
+	h.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/blocks/height", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+	assert.Equal(t, "close", resp.Header().Get("Connection"))
+	assert.Equal(t, "1", resp.Header().Get("Retry-After"))
+}
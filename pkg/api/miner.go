@@ -1,11 +1,25 @@
 package api
 
 import (
+	"slices"
 	"time"
 
+	"github.com/pkg/errors"
+
+	apiErrs "github.com/wavesplatform/gowaves/pkg/api/errors"
 	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
 )
 
+// MinerControl pauses and resumes block generation without stopping the node.
+type MinerControl interface {
+	Pause()
+	Resume()
+	Paused() bool
+}
+
+var errMiningDisabled = apiErrs.NewCustomValidationError("mining is disabled on the node")
+
 type Scheduler struct {
 	TimeNow time.Time `json:"time_now"`
 	Next    []Next    `json:"next"`
@@ -17,6 +31,7 @@ type Next struct {
 
 type MinerInfo struct {
 	Scheduler Scheduler
+	Paused    bool
 }
 
 func (a *App) Miner() MinerInfo {
@@ -30,10 +45,81 @@ func (a *App) Miner() MinerInfo {
 		})
 	}
 
+	ctl, ok := a.scheduler.(MinerControl)
 	return MinerInfo{
 		Scheduler: Scheduler{
 			TimeNow: time.Now(),
 			Next:    next,
 		},
+		Paused: ok && ctl.Paused(),
+	}
+}
+
+// PauseMining stops block generation, the node continues to sync blockchain and serve APIs.
+func (a *App) PauseMining() error {
+	ctl, ok := a.scheduler.(MinerControl)
+	if !ok {
+		return errMiningDisabled
+	}
+	ctl.Pause()
+	return nil
+}
+
+// ResumeMining restarts block generation stopped by PauseMining.
+func (a *App) ResumeMining() error {
+	ctl, ok := a.scheduler.(MinerControl)
+	if !ok {
+		return errMiningDisabled
+	}
+	ctl.Resume()
+	return nil
+}
+
+// NextBlockEstimate is the scheduled generation of the next block by one of the node's generators.
+type NextBlockEstimate struct {
+	Address    proto.WavesAddress `json:"address"`
+	PublicKey  crypto.PublicKey   `json:"publicKey"`
+	Timestamp  uint64             `json:"timestamp"`
+	Delay      int64              `json:"delay"` // milliseconds left to the timestamp, negative if the slot is missed
+	BaseTarget uint64             `json:"baseTarget"`
+	Parent     proto.BlockID      `json:"parent"`
+}
+
+type NextBlockEstimates struct {
+	Time   uint64              `json:"time"`
+	Paused bool                `json:"paused"`
+	Next   []NextBlockEstimate `json:"next"`
+}
+
+// NextBlockEstimates returns the scheduled generations of the next block ordered by timestamp.
+func (a *App) NextBlockEstimates() (NextBlockEstimates, error) {
+	now := proto.NewTimestampFromTime(time.Now())
+	emits := a.scheduler.Emits()
+	next := make([]NextBlockEstimate, 0, len(emits))
+	for _, e := range emits {
+		addr, err := proto.NewAddressFromPublicKey(a.services.Scheme, e.KeyPair.Public)
+		if err != nil {
+			return NextBlockEstimates{}, errors.Wrap(err, "failed to get generator address")
+		}
+		next = append(next, NextBlockEstimate{
+			Address:    addr,
+			PublicKey:  e.KeyPair.Public,
+			Timestamp:  e.Timestamp,
+			Delay:      int64(e.Timestamp) - int64(now),
+			BaseTarget: e.BaseTarget,
+			Parent:     e.Parent,
+		})
 	}
+	slices.SortFunc(next, func(a, b NextBlockEstimate) int {
+		switch {
+		case a.Timestamp < b.Timestamp:
+			return -1
+		case a.Timestamp > b.Timestamp:
+			return 1
+		default:
+			return 0
+		}
+	})
+	ctl, ok := a.scheduler.(MinerControl)
+	return NextBlockEstimates{Time: now, Paused: ok && ctl.Paused(), Next: next}, nil
 }
@@ -2,9 +2,18 @@ package api
 
 import (
 	"encoding/json"
-	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apiErrs "github.com/wavesplatform/gowaves/pkg/api/errors"
+	"github.com/wavesplatform/gowaves/pkg/miner/scheduler"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/services"
 )
 
 func TestApp_Miner(t *testing.T) {
@@ -17,3 +26,76 @@ func TestApp_Miner(t *testing.T) {
 
 	require.Contains(t, string(bts), "2019-06-03T")
 }
+
+type testMinerControl struct {
+	scheduler.DisabledScheduler
+	paused bool
+}
+
+func (c *testMinerControl) Pause() {
+	c.paused = true
+}
+
+func (c *testMinerControl) Resume() {
+	c.paused = false
+}
+
+func (c *testMinerControl) Paused() bool {
+	return c.paused
+}
+
+func TestNodeApi_MinerPauseResume(t *testing.T) {
+	ctl := new(testMinerControl)
+	app, err := NewApp("", ctl, services.Services{})
+	require.NoError(t, err)
+	a := NewNodeAPI(app, nil)
+
+	resp := httptest.NewRecorder()
+	require.NoError(t, a.debugMinerPause(resp, httptest.NewRequest(http.MethodPost, "/debug/miner/pause", nil)))
+	assert.JSONEq(t, `{"paused":true}`, resp.Body.String())
+	assert.True(t, app.Miner().Paused)
+
+	resp = httptest.NewRecorder()
+	require.NoError(t, a.debugMinerResume(resp, httptest.NewRequest(http.MethodPost, "/debug/miner/resume", nil)))
+	assert.JSONEq(t, `{"paused":false}`, resp.Body.String())
+	assert.False(t, app.Miner().Paused)
+
+	disabled, err := NewApp("", scheduler.DisabledScheduler{}, services.Services{})
+	require.NoError(t, err)
+	err = NewNodeAPI(disabled, nil).debugMinerPause(httptest.NewRecorder(),
+		httptest.NewRequest(http.MethodPost, "/debug/miner/pause", nil))
+	assert.ErrorAs(t, err, new(*apiErrs.CustomValidationError))
+}
+
+type testEmits []scheduler.Emit
+
+func (e testEmits) Emits() []scheduler.Emit {
+	return e
+}
+
+func TestApp_NextBlockEstimates(t *testing.T) {
+	kp1, err := proto.NewKeyPair([]byte("generator1"))
+	require.NoError(t, err)
+	kp2, err := proto.NewKeyPair([]byte("generator2"))
+	require.NoError(t, err)
+	now := proto.NewTimestampFromTime(time.Now())
+	emits := testEmits{
+		{Timestamp: now + 60_000, KeyPair: kp1, BaseTarget: 100},
+		{Timestamp: now - 1_000, KeyPair: kp2, BaseTarget: 100},
+	}
+	app, err := NewApp("", emits, services.Services{Scheme: proto.TestNetScheme})
+	require.NoError(t, err)
+
+	rs, err := app.NextBlockEstimates()
+	require.NoError(t, err)
+	assert.False(t, rs.Paused)
+	require.Len(t, rs.Next, 2)
+	addr2, err := proto.NewAddressFromPublicKey(proto.TestNetScheme, kp2.Public)
+	require.NoError(t, err)
+	assert.Equal(t, addr2, rs.Next[0].Address)
+	assert.Equal(t, now-1_000, rs.Next[0].Timestamp)
+	assert.Negative(t, rs.Next[0].Delay)
+	assert.Equal(t, kp1.Public, rs.Next[1].PublicKey)
+	assert.Positive(t, rs.Next[1].Delay)
+	assert.EqualValues(t, 100, rs.Next[1].BaseTarget)
+}
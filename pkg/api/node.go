@@ -2,14 +2,142 @@ package api
 
 import (
 	"fmt"
+	"math"
+	"math/big"
+	"time"
 
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/p2p/peer"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/types"
 	"github.com/wavesplatform/gowaves/pkg/versioning"
 )
 
+// clockOffsetObsolescence is the age of clock offset measurement after which it's reported as outdated.
+const clockOffsetObsolescence = 15 * time.Minute
+
 type nodeVersion struct {
-	Version string `json:"version"`
+	Version string               `json:"version"`
+	Build   versioning.BuildInfo `json:"build"`
 }
 
 func (a *App) version() nodeVersion {
-	return nodeVersion{Version: fmt.Sprintf("Gowaves %s", versioning.Version)}
+	return nodeVersion{Version: fmt.Sprintf("Gowaves %s", versioning.Version), Build: versioning.Info()}
+}
+
+type generatorStatus struct {
+	Enabled                 bool   `json:"enabled"`
+	NextGenerationTimestamp uint64 `json:"nextGenerationTimestamp,omitempty"`
+}
+
+type syncStatus struct {
+	LocalScore                 *big.Int        `json:"localScore"`
+	NetworkScore               *big.Int        `json:"networkScore"`
+	BlocksBehind               uint64          `json:"blocksBehind"`
+	Synced                     bool            `json:"synced"`
+	SyncRate                   float64         `json:"syncRate"` // blocks per second
+	EstimatedSyncSeconds       *uint64         `json:"estimatedSyncSeconds,omitempty"`
+	LastBlockReceivedTimestamp int64           `json:"lastBlockReceivedTimestamp,omitempty"`
+	Generator                  generatorStatus `json:"generator"`
+}
+
+// syncStatus estimates how far the node is behind the network. The network tip is estimated by the maximal score of
+// connected peers, the score difference is converted to the number of blocks using the score of the last block.
+func (a *App) syncStatus(height proto.Height) (syncStatus, error) {
+	localScore, err := a.state.CurrentScore()
+	if err != nil {
+		return syncStatus{}, errors.Wrap(err, "failed to get current score")
+	}
+	networkScore := new(big.Int).Set(localScore)
+	if a.peers != nil {
+		a.peers.EachConnected(func(_ peer.Peer, score *proto.Score) {
+			if score != nil && score.Cmp(networkScore) > 0 {
+				networkScore.Set(score)
+			}
+		})
+	}
+	res := syncStatus{LocalScore: localScore, NetworkScore: networkScore, SyncRate: a.progress.rate()}
+	if diff := new(big.Int).Sub(networkScore, localScore); diff.Sign() > 0 {
+		res.BlocksBehind, err = a.blocksForScore(height, diff)
+		if err != nil {
+			return syncStatus{}, err
+		}
+	}
+	res.Synced = res.BlocksBehind == 0
+	if res.BlocksBehind > 0 && res.SyncRate > 0 {
+		seconds := uint64(math.Ceil(float64(res.BlocksBehind) / res.SyncRate))
+		res.EstimatedSyncSeconds = &seconds
+	}
+	if t := a.progress.lastBlockTime(); !t.IsZero() {
+		res.LastBlockReceivedTimestamp = t.UnixMilli()
+	}
+	if a.scheduler != nil {
+		if emits := a.scheduler.Emits(); len(emits) > 0 {
+			res.Generator.Enabled = true
+			res.Generator.NextGenerationTimestamp = emits[0].Timestamp
+			for _, e := range emits[1:] {
+				res.Generator.NextGenerationTimestamp = min(res.Generator.NextGenerationTimestamp, e.Timestamp)
+			}
+		}
+	}
+	return res, nil
+}
+
+type clockStatus struct {
+	Offset            int64  `json:"offset"` // milliseconds
+	MeasuredTimestamp int64  `json:"measuredTimestamp,omitempty"`
+	Warning           string `json:"warning,omitempty"`
+}
+
+// SetMaxClockDrift sets the offset of the local clock, exceeding which is reported by the node status.
+func (a *App) SetMaxClockDrift(d time.Duration) {
+	a.maxClockDrift = d
+}
+
+// clockStatus returns the status of the local clock if it's measured by the node's time source.
+func (a *App) clockStatus() *clockStatus {
+	clock, ok := a.services.Time.(types.ClockDrift)
+	if !ok {
+		return nil
+	}
+	offset, measured := clock.ClockOffset()
+	if measured.IsZero() {
+		return nil
+	}
+	res := &clockStatus{Offset: offset.Milliseconds(), MeasuredTimestamp: measured.UnixMilli()}
+	switch {
+	case a.maxClockDrift > 0 && offset.Abs() > a.maxClockDrift:
+		res.Warning = fmt.Sprintf("local clock offset %s exceeds %s, mining is not allowed", offset, a.maxClockDrift)
+	case time.Since(measured) > clockOffsetObsolescence:
+		res.Warning = fmt.Sprintf("clock offset is not measured since %s", measured.UTC().Format(time.RFC3339))
+	}
+	return res
+}
+
+// blocksForScore returns the number of blocks with the score of the last block needed to gain the given score.
+func (a *App) blocksForScore(height proto.Height, score *big.Int) (uint64, error) {
+	if height < 2 {
+		return 1, nil
+	}
+	last, err := a.state.ScoreAtHeight(height)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to get score at height %d", height)
+	}
+	prev, err := a.state.ScoreAtHeight(height - 1)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to get score at height %d", height-1)
+	}
+	blockScore := new(big.Int).Sub(last, prev)
+	if blockScore.Sign() <= 0 {
+		return 1, nil
+	}
+	blocks, rem := new(big.Int).QuoRem(score, blockScore, new(big.Int))
+	if rem.Sign() > 0 {
+		blocks.Add(blocks, big.NewInt(1))
+	}
+	if !blocks.IsUint64() {
+		return math.MaxUint64, nil
+	}
+	return blocks.Uint64(), nil
 }
@@ -7,8 +7,11 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-chi/chi"
@@ -25,10 +28,8 @@ import (
 )
 
 const (
-	defaultTimeout               = 30 * time.Second
-	defaultShutdownTimeout       = 5 * time.Second
-	postMessageSizeLimit   int64 = 1 << 20 // 1 MB
-	maxDebugMessageLength        = 100
+	postMessageSizeLimit  int64 = 1 << 20 // 1 MB
+	maxDebugMessageLength       = 100
 )
 
 type NodeApi struct {
@@ -48,17 +49,53 @@ func (a *NodeApi) TransactionsBroadcast(w http.ResponseWriter, r *http.Request)
 	if err != nil {
 		return errors.Wrap(err, "TransactionsBroadcast: failed to read request body")
 	}
-	tx, err := a.app.TransactionsBroadcast(r.Context(), b)
+	async := false
+	if v := r.URL.Query().Get("async"); v != "" {
+		async, err = strconv.ParseBool(v)
+		if err != nil {
+			return apiErrs.NewCustomValidationError(fmt.Sprintf("invalid 'async' parameter value '%s'", v))
+		}
+	}
+	tx, dup, err := a.app.TransactionsBroadcast(r.Context(), b, async)
 	if err != nil {
 		return errors.Wrap(err, "TransactionsBroadcast")
 	}
-	err = trySendJson(w, tx)
+	if dup != nil {
+		err = trySendJson(w, duplicateTransaction{tx: tx, dup: dup})
+	} else {
+		err = trySendJson(w, tx)
+	}
 	if err != nil {
 		return errors.Wrap(err, "TransactionsBroadcast")
 	}
 	return nil
 }
 
+// duplicateTransaction is marshaled as the transaction JSON object extended with the fields of TransactionDuplicate.
+type duplicateTransaction struct {
+	tx  proto.Transaction
+	dup *TransactionDuplicate
+}
+
+func (d duplicateTransaction) MarshalJSON() ([]byte, error) {
+	txJS, err := json.Marshal(d.tx)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]json.RawMessage)
+	if err = json.Unmarshal(txJS, &fields); err != nil {
+		return nil, err
+	}
+	dupJS, err := json.Marshal(d.dup)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(dupJS, &fields); err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
 func transactionIDAtInvalidLenErr(key string) *apiErrs.InvalidTransactionIdError {
 	return apiErrs.NewInvalidTransactionIDError(
 		fmt.Sprintf("%s has invalid length %d. Length can either be %d or %d",
@@ -90,6 +127,11 @@ func (a *NodeApi) TransactionInfo(w http.ResponseWriter, r *http.Request) error
 		}
 		return transactionIDAtInvalidLenErr(s)
 	}
+	if txHeight, hErr := a.state.TransactionHeightByID(id.Bytes()); hErr == nil {
+		if done, nmErr := a.notModified(w, r, etagTransaction, s, txHeight); nmErr != nil || done {
+			return nmErr
+		}
+	}
 	tx, err := a.state.TransactionByID(id.Bytes())
 	if err != nil {
 		origErr := errors.Cause(err)
@@ -100,6 +142,13 @@ func (a *NodeApi) TransactionInfo(w http.ResponseWriter, r *http.Request) error
 			"TransactionsInfo: expected NotFound in state error, but received other error = %s", s,
 		)
 	}
+	if wantsProtobuf(r) {
+		pbTx, pErr := tx.ToProtobufSigned(a.app.services.Scheme)
+		if pErr != nil {
+			return errors.Wrap(pErr, "TransactionsInfo: failed to convert transaction to protobuf")
+		}
+		return trySendProtobuf(w, pbTx)
+	}
 	err = trySendJson(w, tx)
 	if err != nil {
 		return errors.Wrap(err, "TransactionsInfo")
@@ -107,7 +156,86 @@ func (a *NodeApi) TransactionInfo(w http.ResponseWriter, r *http.Request) error
 	return nil
 }
 
-func (a *NodeApi) BlocksLast(w http.ResponseWriter, _ *http.Request) error {
+func (a *NodeApi) LeasingInfo(w http.ResponseWriter, r *http.Request) error {
+	s := chi.URLParam(r, "id")
+	id, err := crypto.NewDigestFromBase58(s)
+	if err != nil {
+		if invalidRune, isInvalid := findFirstInvalidRuneInBase58String(s); isInvalid {
+			return transactionIDAtInvalidCharErr(invalidRune, s)
+		}
+		return transactionIDAtInvalidLenErr(s)
+	}
+	l, err := a.app.LeaseInfo(id)
+	if err != nil {
+		if stateerr.IsNotFound(errors.Cause(err)) {
+			return apiErrs.TransactionDoesNotExist
+		}
+		return errors.Wrap(err, "LeasingInfo")
+	}
+	if err := trySendJson(w, newLeaseInfo(l)); err != nil {
+		return errors.Wrap(err, "LeasingInfo")
+	}
+	return nil
+}
+
+func (a *NodeApi) OrderFilled(w http.ResponseWriter, r *http.Request) error {
+	s := chi.URLParam(r, "orderId")
+	id, err := crypto.NewDigestFromBase58(s)
+	if err != nil {
+		if invalidRune, isInvalid := findFirstInvalidRuneInBase58String(s); isInvalid {
+			return transactionIDAtInvalidCharErr(invalidRune, s)
+		}
+		return transactionIDAtInvalidLenErr(s)
+	}
+	filled, err := a.app.OrderFilled(id)
+	if err != nil {
+		return errors.Wrap(err, "OrderFilled")
+	}
+	if err := trySendJson(w, filled); err != nil {
+		return errors.Wrap(err, "OrderFilled")
+	}
+	return nil
+}
+
+// TransactionSPVProof returns the SPV proof of the transaction. The optional query parameter 'to' is the height of
+// the last block header included into the proof, the current height by default.
+func (a *NodeApi) TransactionSPVProof(w http.ResponseWriter, r *http.Request) error {
+	s := chi.URLParam(r, "id")
+	id, err := crypto.NewDigestFromBase58(s)
+	if err != nil {
+		if invalidRune, isInvalid := findFirstInvalidRuneInBase58String(s); isInvalid {
+			return transactionIDAtInvalidCharErr(invalidRune, s)
+		}
+		return transactionIDAtInvalidLenErr(s)
+	}
+	var to proto.Height
+	if v := r.URL.Query().Get("to"); v != "" {
+		to, err = strconv.ParseUint(v, 10, 64)
+		if err != nil || to == 0 {
+			return apiErrs.NewCustomValidationError("invalid 'to' parameter")
+		}
+	}
+	proof, err := a.app.TransactionSPVProof(id, to)
+	if err != nil {
+		if stateerr.IsNotFound(errors.Cause(err)) {
+			return apiErrs.TransactionDoesNotExist
+		}
+		return errors.Wrap(err, "TransactionSPVProof")
+	}
+	if err := trySendJson(w, proof); err != nil {
+		return errors.Wrap(err, "TransactionSPVProof")
+	}
+	return nil
+}
+
+func (a *NodeApi) BlocksLast(w http.ResponseWriter, r *http.Request) error {
+	if wantsProtobuf(r) {
+		h, err := a.state.Height()
+		if err != nil {
+			return errors.Wrap(err, "BlocksLast: failed to get height")
+		}
+		return a.sendProtobufBlock(w, h, true)
+	}
 	apiBlock, err := a.app.BlocksLast()
 	if err != nil {
 		return errors.Wrap(err, "BlocksLast: failed to get last block")
@@ -131,7 +259,14 @@ func (a *NodeApi) BlocksFirst(w http.ResponseWriter, _ *http.Request) error {
 	return nil
 }
 
-func (a *NodeApi) BlocksHeadersLast(w http.ResponseWriter, _ *http.Request) error {
+func (a *NodeApi) BlocksHeadersLast(w http.ResponseWriter, r *http.Request) error {
+	if wantsProtobuf(r) {
+		h, err := a.state.Height()
+		if err != nil {
+			return errors.Wrap(err, "BlocksHeadersLast: failed to get height")
+		}
+		return a.sendProtobufBlock(w, h, false)
+	}
 	lastBlockHeader, err := a.app.BlocksHeadersLast()
 	if err != nil {
 		return errors.Wrap(err, "BlocksHeadersLast: failed to get last block header")
@@ -149,6 +284,12 @@ func (a *NodeApi) BlocksHeadersAt(w http.ResponseWriter, r *http.Request) error
 	if err != nil {
 		return errors.Wrap(err, "failed to parse 'height' url param")
 	}
+	if done, nmErr := a.notModifiedBlockAt(w, r, etagHeader, h); nmErr != nil || done {
+		return nmErr
+	}
+	if wantsProtobuf(r) {
+		return a.sendProtobufBlock(w, h, false)
+	}
 	header, err := a.app.BlocksHeadersAt(h)
 	if err != nil {
 		if stateerr.IsInvalidInput(err) || stateerr.IsNotFound(err) {
@@ -173,6 +314,19 @@ func (a *NodeApi) BlockHeadersID(w http.ResponseWriter, r *http.Request) error {
 		}
 		return blockIDAtInvalidLenErr(s, err)
 	}
+	if done, nmErr := a.notModifiedBlockID(w, r, etagHeader, id); nmErr != nil || done {
+		return nmErr
+	}
+	if wantsProtobuf(r) {
+		height, hErr := a.state.BlockIDToHeight(id)
+		if hErr != nil {
+			if stateerr.IsNotFound(hErr) {
+				return apiErrs.BlockDoesNotExist
+			}
+			return errors.Wrapf(hErr, "BlockHeadersID: failed to get height of block ID=%q", s)
+		}
+		return a.sendProtobufBlock(w, height, false)
+	}
 	header, err := a.app.BlocksHeadersByID(id)
 	if err != nil {
 		if stateerr.IsNotFound(err) {
@@ -200,7 +354,7 @@ func (a *NodeApi) BlocksHeadersSeqFromTo(w http.ResponseWriter, r *http.Request)
 	if err != nil {
 		return errors.Wrap(err, "failed to parse 'to' url param")
 	}
-	seq, err := a.app.BlocksHeadersFromTo(from, to)
+	seq, err := a.app.BlocksHeadersFromTo(r.Context(), from, to)
 	if err != nil {
 		return errors.Wrapf(err, "BlocksHeadersSeqFromTo: failed to get block sequence from %d to %d", from, to)
 	}
@@ -246,6 +400,12 @@ func (a *NodeApi) BlockAt(w http.ResponseWriter, r *http.Request) error {
 		// 	try execute `curl -X GET "https://nodes-testnet.wavesnodes.com/blocks/at/fdsfasdff" -H  "accept: application/json"`
 		return blockIDAtInvalidLenErr("at", err)
 	}
+	if done, nmErr := a.notModifiedBlockAt(w, r, etagBlock, height); nmErr != nil || done {
+		return nmErr
+	}
+	if wantsProtobuf(r) {
+		return a.sendProtobufBlock(w, height, true)
+	}
 
 	block, err := a.app.BlockByHeight(height)
 	if err != nil {
@@ -285,6 +445,9 @@ func (a *NodeApi) BlockIDAt(w http.ResponseWriter, r *http.Request) error {
 		}
 		return blockIDAtInvalidLenErr(s, err)
 	}
+	if done, nmErr := a.notModifiedBlockID(w, r, etagBlock, id); nmErr != nil || done {
+		return nmErr
+	}
 	block, err := a.app.Block(id)
 	if err != nil {
 		if errors.Is(err, notFound) {
@@ -301,6 +464,9 @@ func (a *NodeApi) BlockIDAt(w http.ResponseWriter, r *http.Request) error {
 		return errors.Wrapf(err,
 			"BlockIDAt: failed to execute state.BlockIDToHeight for blockID=%s", s)
 	}
+	if wantsProtobuf(r) {
+		return a.sendProtobufBlock(w, height, true)
+	}
 	apiBlock, err := newAPIBlock(block, a.app.services.Scheme, height)
 	if err != nil {
 		return errors.Wrap(err, "failed to create API block")
@@ -413,47 +579,83 @@ func Run(ctx context.Context, address string, n *NodeApi, opts *RunOptions) erro
 		return errors.Wrap(err, "RunWithOpts")
 	}
 
-	apiServer := &http.Server{Addr: address, Handler: routes, ReadHeaderTimeout: defaultTimeout, ReadTimeout: defaultTimeout}
+	so := opts.ServerOpts
+	if so == nil {
+		so = DefaultServerOptions()
+	}
+	var draining atomic.Bool
+	apiServer := &http.Server{
+		Addr:              address,
+		Handler:           createDrainingMiddleware(&draining)(routes),
+		ReadHeaderTimeout: so.ReadHeaderTimeout,
+		ReadTimeout:       so.ReadTimeout,
+		WriteTimeout:      so.WriteTimeout,
+		IdleTimeout:       so.IdleTimeout,
+		MaxHeaderBytes:    so.MaxHeaderBytes,
+	}
 	apiServer.RegisterOnShutdown(func() {
 		zap.S().Info("Shutting down API server ...")
 	})
+
+	if address == "" {
+		address = ":http"
+	}
+	ln, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	if opts.MaxConnections > 0 {
+		ln = limit_listener.LimitListener(ln, opts.MaxConnections)
+		zap.S().Debugf("Set limit for number of simultaneous connections for REST API to %d", opts.MaxConnections)
+	}
+	ln = &onceCloseListener{Listener: ln}
+
+	go n.app.progress.run(ctx, n.state.Height)
+
 	done := make(chan struct{})
 	defer func() { <-done }() // wait for server shutdown
 	go func() {
 		defer close(done)
 		<-ctx.Done()
-		shutdownCtx, cancel := context.WithTimeout(context.Background(), defaultShutdownTimeout)
-		defer cancel()
-		sErr := apiServer.Shutdown(shutdownCtx)
-		if sErr != nil {
-			zap.S().Errorf("Failed to shutdown API server: %v", sErr)
+		// Stop accepting new connections, requests on open connections are rejected during the drain period
+		// and the connections are closed, in-flight requests are given time to complete.
+		draining.Store(true)
+		if clErr := ln.Close(); clErr != nil {
+			zap.S().Errorf("Failed to close API server listener: %v", clErr)
 		}
-	}()
-
-	if opts.MaxConnections > 0 {
-		if address == "" {
-			address = ":http"
+		if opts.DrainPeriod > 0 {
+			time.Sleep(opts.DrainPeriod)
 		}
-
-		ln, lErr := net.Listen("tcp", address)
-		if lErr != nil {
-			return lErr
+		apiServer.SetKeepAlivesEnabled(false)
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), opts.ShutdownTimeout)
+		defer cancel()
+		if sErr := apiServer.Shutdown(shutdownCtx); sErr != nil {
+			zap.S().Errorf("Failed to shutdown API server gracefully: %v", sErr)
+			if clErr := apiServer.Close(); clErr != nil {
+				zap.S().Errorf("Failed to close API server: %v", clErr)
+			}
 		}
+	}()
 
-		ln = limit_listener.LimitListener(ln, opts.MaxConnections)
-		zap.S().Debugf("Set limit for number of simultaneous connections for REST API to %d", opts.MaxConnections)
-
-		err = apiServer.Serve(ln)
-	} else {
-		err = apiServer.ListenAndServe()
-	}
-
-	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+	err = apiServer.Serve(ln)
+	if err != nil && !errors.Is(err, http.ErrServerClosed) && !(draining.Load() && errors.Is(err, net.ErrClosed)) {
 		return err
 	}
 	return nil
 }
 
+// onceCloseListener closes the listener only once, so it could be closed before the shutdown of the server.
+type onceCloseListener struct {
+	net.Listener
+	once sync.Once
+	err  error
+}
+
+func (l *onceCloseListener) Close() error {
+	l.once.Do(func() { l.err = l.Listener.Close() })
+	return l.err
+}
+
 func (a *NodeApi) PeersAll(w http.ResponseWriter, _ *http.Request) error {
 	rs, err := a.app.PeersAll()
 	if err != nil {
@@ -508,6 +710,46 @@ func (a *NodeApi) PeersConnect(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
+func (a *NodeApi) PeersPinned(w http.ResponseWriter, _ *http.Request) error {
+	rs := a.app.PeersPinned()
+	if err := trySendJson(w, rs); err != nil {
+		return errors.Wrap(err, "PeersPinned")
+	}
+	return nil
+}
+
+func (a *NodeApi) PeersPin(w http.ResponseWriter, r *http.Request) error {
+	req := &PeersConnectRequest{}
+	if err := tryParseJson(r.Body, req); err != nil {
+		return errors.Wrap(err, "failed to parse PeersPin request body as JSON")
+	}
+	addr := net.JoinHostPort(req.Host, strconv.FormatUint(uint64(req.Port), 10))
+	rs, err := a.app.PeersPin(addr)
+	if err != nil {
+		return errors.Wrapf(err, "failed to pin peer, addr %s", addr)
+	}
+	if err := trySendJson(w, rs); err != nil {
+		return errors.Wrap(err, "PeersPin")
+	}
+	return nil
+}
+
+func (a *NodeApi) PeersUnpin(w http.ResponseWriter, r *http.Request) error {
+	req := &PeersConnectRequest{}
+	if err := tryParseJson(r.Body, req); err != nil {
+		return errors.Wrap(err, "failed to parse PeersUnpin request body as JSON")
+	}
+	addr := net.JoinHostPort(req.Host, strconv.FormatUint(uint64(req.Port), 10))
+	rs, err := a.app.PeersUnpin(addr)
+	if err != nil {
+		return errors.Wrapf(err, "failed to unpin peer, addr %s", addr)
+	}
+	if err := trySendJson(w, rs); err != nil {
+		return errors.Wrap(err, "PeersUnpin")
+	}
+	return nil
+}
+
 func (a *NodeApi) AddrByAlias(w http.ResponseWriter, r *http.Request) error {
 	type addrResponse struct {
 		Address string `json:"address"`
@@ -516,6 +758,13 @@ func (a *NodeApi) AddrByAlias(w http.ResponseWriter, r *http.Request) error {
 	aliasShort := chi.URLParam(r, "alias")
 
 	alias := proto.NewAlias(a.app.scheme(), aliasShort)
+	if strings.HasPrefix(aliasShort, proto.AliasPrefix+":") { // full alias form 'alias:<scheme>:<alias>'
+		full, err := proto.NewAliasFromString(aliasShort)
+		if err != nil {
+			return apiErrs.NewCustomValidationError(err.Error())
+		}
+		alias = full
+	}
 	if _, err := alias.Valid(a.app.scheme()); err != nil {
 		msg := err.Error()
 		return apiErrs.NewCustomValidationError(msg)
@@ -565,26 +814,34 @@ func (a *NodeApi) AliasesByAddr(w http.ResponseWriter, r *http.Request) error {
 
 func (a *NodeApi) NodeStatus(w http.ResponseWriter, r *http.Request) error {
 	type resp struct {
-		BlockchainHeight uint64 `json:"blockchainHeight"`
-		StateHeight      uint64 `json:"stateHeight"`
-		UpdatedTimestamp int64  `json:"updatedTimestamp"`
-		UpdatedDate      string `json:"updatedDate"`
+		BlockchainHeight uint64       `json:"blockchainHeight"`
+		StateHeight      uint64       `json:"stateHeight"`
+		UpdatedTimestamp int64        `json:"updatedTimestamp"`
+		UpdatedDate      string       `json:"updatedDate"`
+		Clock            *clockStatus `json:"clock,omitempty"`
+		syncStatus
 	}
 
 	stateHeight, err := a.app.state.Height()
 	if err != nil {
 		return errors.Wrap(err, "failed to get state height in NodeStatus HTTP endpoint")
 	}
+	ss, err := a.app.syncStatus(stateHeight)
+	if err != nil {
+		return errors.Wrap(err, "failed to get sync status in NodeStatus HTTP endpoint")
+	}
 
 	blockHeader := a.state.TopBlock()
 	updatedTimestampMillis := int64(blockHeader.Timestamp)
 
 	// TODO: meaning of 'UpdatedDate' in scala node  differs from ours
 	out := resp{
-		BlockchainHeight: stateHeight,
+		BlockchainHeight: stateHeight + ss.BlocksBehind, // estimated height of the network tip
 		StateHeight:      stateHeight,
 		UpdatedTimestamp: updatedTimestampMillis,
 		UpdatedDate:      time.UnixMilli(updatedTimestampMillis).UTC().Format(time.RFC3339Nano),
+		Clock:            a.app.clockStatus(),
+		syncStatus:       ss,
 	}
 	if err := trySendJson(w, out); err != nil {
 		return errors.Wrap(err, "NodeStatus")
@@ -641,8 +898,8 @@ func (a *NodeApi) PeersClearBlackList(w http.ResponseWriter, _ *http.Request) er
 	return nil
 }
 
-func (a *NodeApi) BlocksGenerators(w http.ResponseWriter, _ *http.Request) error {
-	rs, err := a.app.BlocksGenerators()
+func (a *NodeApi) BlocksGenerators(w http.ResponseWriter, r *http.Request) error {
+	rs, err := a.app.BlocksGenerators(r.Context())
 	if err != nil {
 		return errors.Wrap(err, "failed to get BlocksGenerators")
 	}
@@ -680,6 +937,38 @@ func (a *NodeApi) unconfirmedSize(w http.ResponseWriter, _ *http.Request) error
 	return nil
 }
 
+// FeeEstimate suggests fees for a transaction. Optional query parameters are 'type' of the transaction, transfer
+// by default, and its 'size' in bytes.
+func (a *NodeApi) FeeEstimate(w http.ResponseWriter, r *http.Request) error {
+	txType := proto.TransferTransaction
+	if s := r.URL.Query().Get("type"); s != "" {
+		t, err := strconv.ParseUint(s, 10, 8)
+		if err != nil {
+			return apiErrs.NewCustomValidationError("invalid 'type' parameter")
+		}
+		txType = proto.TransactionType(t)
+	}
+	size := defaultFeeEstimateTxSize
+	if s := r.URL.Query().Get("size"); s != "" {
+		v, err := strconv.Atoi(s)
+		if err != nil || v <= 0 || v > maxFeeEstimateTxSize {
+			return apiErrs.NewCustomValidationError("invalid 'size' parameter")
+		}
+		size = v
+	}
+	if _, ok := state.BaseFee(txType); !ok {
+		return apiErrs.NewCustomValidationError(fmt.Sprintf("unsupported transaction type %d", txType))
+	}
+	rs, err := a.app.FeeEstimate(txType, size)
+	if err != nil {
+		return errors.Wrap(err, "FeeEstimate")
+	}
+	if err = trySendJson(w, rs); err != nil {
+		return errors.Wrap(err, "FeeEstimate")
+	}
+	return nil
+}
+
 type rollbackResponse struct {
 	BlockID proto.BlockID `json:"blockId"`
 }
@@ -775,6 +1064,54 @@ func (a *NodeApi) GoMinerInfo(w http.ResponseWriter, _ *http.Request) error {
 	return nil
 }
 
+func (a *NodeApi) minerNextBlock(w http.ResponseWriter, _ *http.Request) error {
+	rs, err := a.app.NextBlockEstimates()
+	if err != nil {
+		return errors.Wrap(err, "minerNextBlock")
+	}
+	if err = trySendJson(w, rs); err != nil {
+		return errors.Wrap(err, "minerNextBlock")
+	}
+	return nil
+}
+
+type minerStateResponse struct {
+	Paused bool `json:"paused"`
+}
+
+func (a *NodeApi) debugMinerPause(w http.ResponseWriter, _ *http.Request) error {
+	if err := a.app.PauseMining(); err != nil {
+		return err
+	}
+	zap.S().Info("Mining is paused via API")
+	if err := trySendJson(w, minerStateResponse{Paused: true}); err != nil {
+		return errors.Wrap(err, "debugMinerPause")
+	}
+	return nil
+}
+
+func (a *NodeApi) debugMinerResume(w http.ResponseWriter, _ *http.Request) error {
+	if err := a.app.ResumeMining(); err != nil {
+		return err
+	}
+	zap.S().Info("Mining is resumed via API")
+	if err := trySendJson(w, minerStateResponse{Paused: false}); err != nil {
+		return errors.Wrap(err, "debugMinerResume")
+	}
+	return nil
+}
+
+func (a *NodeApi) debugBlockDryRun(w http.ResponseWriter, _ *http.Request) error {
+	rs, err := a.app.BlockDryRun()
+	if err != nil {
+		return errors.Wrap(err, "failed to simulate block generation")
+	}
+	if err = trySendJson(w, rs); err != nil {
+		return errors.Wrap(err, "debugBlockDryRun")
+	}
+	return nil
+}
+
 func (a *NodeApi) Addresses(w http.ResponseWriter, _ *http.Request) error {
 	addresses, err := a.app.Addresses()
 	if err != nil {
@@ -846,6 +1183,114 @@ func (a *NodeApi) stateHashLast(w http.ResponseWriter, _ *http.Request) error {
 	return nil
 }
 
+// balancesHistory returns regular Waves balances of the address by heights they were changed at, newest first.
+func (a *NodeApi) balancesHistory(w http.ResponseWriter, r *http.Request) error {
+	addr, err := proto.NewAddressFromString(chi.URLParam(r, "address"))
+	if err != nil {
+		return apiErrs.InvalidAddress
+	}
+	if ok, vErr := addr.Valid(a.app.scheme()); !ok {
+		return apiErrs.NewCustomValidationError(vErr.Error())
+	}
+	history, err := a.state.WavesBalanceHistory(proto.NewRecipientFromAddress(addr))
+	if err != nil {
+		return errors.Wrapf(err, "failed to get balance history of address %q", addr.String())
+	}
+	if history == nil {
+		history = []proto.BalanceAtHeight{} // ensure that empty array will be return instead of nil
+	}
+	if err := trySendJson(w, history); err != nil {
+		return errors.Wrap(err, "balancesHistory")
+	}
+	return nil
+}
+
+// effectiveBalances returns effective and generating balances of the address at the given height.
+func (a *NodeApi) effectiveBalances(w http.ResponseWriter, r *http.Request) error {
+	addr, err := proto.NewAddressFromString(chi.URLParam(r, "address"))
+	if err != nil {
+		return apiErrs.InvalidAddress
+	}
+	if ok, vErr := addr.Valid(a.app.scheme()); !ok {
+		return apiErrs.NewCustomValidationError(vErr.Error())
+	}
+	height, err := strconv.ParseUint(chi.URLParam(r, "height"), 10, 64)
+	if err != nil {
+		return apiErrs.NewCustomValidationError("invalid height")
+	}
+	balances, err := a.state.EffectiveBalancesAtHeight(proto.NewRecipientFromAddress(addr), height)
+	if err != nil {
+		if stateerr.IsInvalidInput(err) {
+			return apiErrs.NewCustomValidationError(err.Error())
+		}
+		return errors.Wrapf(err, "failed to get effective balances of address %q at height %d", addr.String(), height)
+	}
+	if err := trySendJson(w, balances); err != nil {
+		return errors.Wrap(err, "effectiveBalances")
+	}
+	return nil
+}
+
+// AddressDataByKey returns the data entry of the account. If the "height" query parameter is set, the value of
+// the entry at the height is returned.
+func (a *NodeApi) AddressDataByKey(w http.ResponseWriter, r *http.Request) error {
+	addr, err := proto.NewAddressFromString(chi.URLParam(r, "address"))
+	if err != nil {
+		return apiErrs.InvalidAddress
+	}
+	if ok, vErr := addr.Valid(a.app.scheme()); !ok {
+		return apiErrs.NewCustomValidationError(vErr.Error())
+	}
+	key, err := url.PathUnescape(chi.URLParam(r, "key"))
+	if err != nil {
+		return apiErrs.NewCustomValidationError("invalid data key")
+	}
+	rcp := proto.NewRecipientFromAddress(addr)
+	var entry proto.DataEntry
+	if h := r.URL.Query().Get("height"); h != "" {
+		height, pErr := strconv.ParseUint(h, 10, 64)
+		if pErr != nil {
+			return apiErrs.NewCustomValidationError("invalid height")
+		}
+		entry, err = a.state.RetrieveEntryAtHeight(rcp, key, height)
+	} else {
+		entry, err = a.state.RetrieveEntry(rcp, key)
+	}
+	if err != nil {
+		switch {
+		case stateerr.IsInvalidInput(err):
+			return apiErrs.NewCustomValidationError(err.Error())
+		case stateerr.IsNotFound(err):
+			return apiErrs.DataKeyDoesNotExist
+		default:
+			return errors.Wrapf(err, "failed to get data entry %q of address %q", key, addr.String())
+		}
+	}
+	if err := trySendJson(w, entry); err != nil {
+		return errors.Wrap(err, "AddressDataByKey")
+	}
+	return nil
+}
+
+// AddressScriptHistory returns the changes of account script from the most recent to the oldest.
+func (a *NodeApi) AddressScriptHistory(w http.ResponseWriter, r *http.Request) error {
+	addr, err := proto.NewAddressFromString(chi.URLParam(r, "address"))
+	if err != nil {
+		return apiErrs.InvalidAddress
+	}
+	if ok, vErr := addr.Valid(a.app.scheme()); !ok {
+		return apiErrs.NewCustomValidationError(vErr.Error())
+	}
+	changes, err := a.state.ScriptHistoryByAccount(proto.NewRecipientFromAddress(addr))
+	if err != nil {
+		return errors.Wrapf(err, "failed to get script history of address %q", addr.String())
+	}
+	if err := trySendJson(w, changes); err != nil {
+		return errors.Wrap(err, "AddressScriptHistory")
+	}
+	return nil
+}
+
 func (a *NodeApi) snapshotStateHash(w http.ResponseWriter, r *http.Request) error {
 	s := chi.URLParam(r, "height")
 	height, err := strconv.ParseUint(s, 10, 64)
@@ -931,6 +1376,146 @@ func (a *NodeApi) AssetsDetailsByID(w http.ResponseWriter, r *http.Request) erro
 	return nil
 }
 
+func (a *NodeApi) AssetsSponsorship(w http.ResponseWriter, r *http.Request) error {
+	fullAssetID, err := crypto.NewDigestFromBase58(chi.URLParam(r, "id"))
+	if err != nil {
+		return apiErrs.InvalidAssetId
+	}
+	details, err := a.app.AssetSponsorship(fullAssetID)
+	if err != nil {
+		if errors.Is(err, errs.UnknownAsset{}) {
+			return apiErrs.NewAssetDoesNotExistError(fullAssetID)
+		}
+		return errors.Wrapf(err, "failed to get sponsorship of asset %q", fullAssetID)
+	}
+	if err := trySendJson(w, details); err != nil {
+		return errors.Wrap(err, "AssetsSponsorship")
+	}
+	return nil
+}
+
+const maxRichlistLimit = 1000
+
+type richlistEntry struct {
+	Address proto.WavesAddress `json:"address"`
+	Balance uint64             `json:"balance"`
+}
+
+func richlistLimit(r *http.Request) (uint64, error) {
+	limit, err := strconv.ParseUint(chi.URLParam(r, "limit"), 10, 64)
+	if err != nil {
+		return 0, apiErrs.NewCustomValidationError("invalid limit")
+	}
+	if limit > maxRichlistLimit {
+		return 0, apiErrs.NewTooBigArrayAllocationError(maxRichlistLimit)
+	}
+	return limit, nil
+}
+
+func (a *NodeApi) sendRichlist(w http.ResponseWriter, asset proto.OptionalAsset, limit uint64) error {
+	records, err := a.state.Richlist(asset, limit)
+	if errors.Is(err, state.ErrIndexBuildLimited) {
+		sendIndexBuildLimited(w)
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to get richlist of asset %q", asset.String())
+	}
+	res := make([]richlistEntry, len(records))
+	for i, rec := range records {
+		res[i] = richlistEntry{Address: rec.Address, Balance: rec.Balance}
+	}
+	if err = trySendJson(w, res); err != nil {
+		return errors.Wrap(err, "Richlist")
+	}
+	return nil
+}
+
+// sendIndexBuildLimited responds to the request which requires a full scan of the state to build an in-memory index
+// when such scans are made too often.
+func sendIndexBuildLimited(w http.ResponseWriter) {
+	w.Header().Set("Retry-After", "10")
+	http.Error(w, state.ErrIndexBuildLimited.Error(), http.StatusServiceUnavailable)
+}
+
+// AddressesRichlist returns addresses with the largest Waves balances.
+func (a *NodeApi) AddressesRichlist(w http.ResponseWriter, r *http.Request) error {
+	limit, err := richlistLimit(r)
+	if err != nil {
+		return err
+	}
+	return a.sendRichlist(w, proto.NewOptionalAssetWaves(), limit)
+}
+
+// AssetsRichlist returns addresses with the largest balances of the asset.
+func (a *NodeApi) AssetsRichlist(w http.ResponseWriter, r *http.Request) error {
+	fullAssetID, err := crypto.NewDigestFromBase58(chi.URLParam(r, "id"))
+	if err != nil {
+		return apiErrs.InvalidAssetId
+	}
+	limit, err := richlistLimit(r)
+	if err != nil {
+		return err
+	}
+	exists, err := a.state.IsAssetExist(proto.AssetIDFromDigest(fullAssetID))
+	if err != nil {
+		return errors.Wrapf(err, "failed to check existence of asset %q", fullAssetID)
+	}
+	if !exists {
+		return apiErrs.NewAssetDoesNotExistError(fullAssetID)
+	}
+	return a.sendRichlist(w, *proto.NewOptionalAssetFromDigest(fullAssetID), limit)
+}
+
+// AssetsSearch returns details of assets which names start with the query ignoring case.
+// Results are paginated with limit and after (ID of the last asset on the previous page) query parameters.
+func (a *NodeApi) AssetsSearch(w http.ResponseWriter, r *http.Request) error {
+	q := r.URL.Query()
+	query := q.Get("query")
+	if query == "" {
+		return apiErrs.NewCustomValidationError("query is not specified")
+	}
+	maxLimit := a.app.settings.AssetDetailsLimit
+	limit := uint64(maxLimit)
+	if v := q.Get("limit"); v != "" {
+		l, err := strconv.ParseUint(v, 10, 64)
+		if err != nil || l == 0 {
+			return apiErrs.NewCustomValidationError("invalid limit")
+		}
+		if l > limit {
+			return apiErrs.NewTooBigArrayAllocationError(maxLimit)
+		}
+		limit = l
+	}
+	var after *crypto.Digest
+	if v := q.Get("after"); v != "" {
+		d, err := crypto.NewDigestFromBase58(v)
+		if err != nil {
+			return apiErrs.InvalidAssetId
+		}
+		exists, err := a.state.IsAssetExist(proto.AssetIDFromDigest(d))
+		if err != nil {
+			return errors.Wrapf(err, "failed to check existence of asset %q", d)
+		}
+		if !exists {
+			return apiErrs.NewCustomValidationError(fmt.Sprintf("unknown asset %q in 'after'", d))
+		}
+		after = &d
+	}
+	assets, err := a.app.AssetsSearch(query, limit, after)
+	if errors.Is(err, state.ErrIndexBuildLimited) {
+		sendIndexBuildLimited(w)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err = trySendJson(w, assets); err != nil {
+		return errors.Wrap(err, "AssetsSearch")
+	}
+	return nil
+}
+
 func (a *NodeApi) AssetsDetailsByIDsGet(w http.ResponseWriter, r *http.Request) error {
 	query := r.URL.Query()
 	return a.assetsDetailsByIDs(w, query.Get("full"), query["id"])
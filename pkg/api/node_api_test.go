@@ -1,11 +1,33 @@
 package api
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 
+	"github.com/go-chi/chi"
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apiErrs "github.com/wavesplatform/gowaves/pkg/api/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/errs"
+	"github.com/wavesplatform/gowaves/pkg/mock"
+	"github.com/wavesplatform/gowaves/pkg/node/messages"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/ride"
+	"github.com/wavesplatform/gowaves/pkg/services"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+	"github.com/wavesplatform/gowaves/pkg/spv"
+	"github.com/wavesplatform/gowaves/pkg/state"
+	"github.com/wavesplatform/gowaves/pkg/state/stateerr"
+	"github.com/wavesplatform/gowaves/pkg/types"
 )
 
 const apiKey = "X-API-Key"
@@ -53,3 +75,775 @@ func TestNodeApi_FindFirstInvalidRuneInBase58String(t *testing.T) {
 		assert.Equal(t, testCase.expected, actual)
 	}
 }
+
+func TestNodeApi_BalancesHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	_, pk, err := crypto.GenerateKeyPair([]byte("history"))
+	require.NoError(t, err)
+	addr, err := proto.NewAddressFromPublicKey(proto.TestNetScheme, pk)
+	require.NoError(t, err)
+
+	s := mock.NewMockState(ctrl)
+	s.EXPECT().WavesBalanceHistory(proto.NewRecipientFromAddress(addr)).Return([]proto.BalanceAtHeight{
+		{Height: 12, Balance: 300},
+		{Height: 10, Balance: 100},
+	}, nil)
+	app, err := NewApp("api-key", nil, services.Services{State: s, Scheme: proto.TestNetScheme})
+	require.NoError(t, err)
+	a := NewNodeAPI(app, s)
+
+	newRequest := func(address string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/debug/balances/history/"+address, nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("address", address)
+		return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	}
+
+	resp := httptest.NewRecorder()
+	require.NoError(t, a.balancesHistory(resp, newRequest(addr.String())))
+	assert.JSONEq(t, `[{"height":12,"balance":300},{"height":10,"balance":100}]`, resp.Body.String())
+
+	err = a.balancesHistory(httptest.NewRecorder(), newRequest("invalid"))
+	assert.ErrorIs(t, err, apiErrs.InvalidAddress)
+}
+
+func TestNodeApi_LeasingInfo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	_, pk, err := crypto.GenerateKeyPair([]byte("lessor"))
+	require.NoError(t, err)
+	sender, err := proto.NewAddressFromPublicKey(proto.TestNetScheme, pk)
+	require.NoError(t, err)
+	_, rpk, err := crypto.GenerateKeyPair([]byte("lessee"))
+	require.NoError(t, err)
+	recipient, err := proto.NewAddressFromPublicKey(proto.TestNetScheme, rpk)
+	require.NoError(t, err)
+	id := crypto.MustDigestFromBase58("CMBHKDtyE8GMbZAZANNeE5n2HU4VDpsQaBLmfCw9ASbf")
+	cancelID := crypto.MustDigestFromBase58("8xM5qZH1DL1oUMqvRqpnFm4vtYMj2YzsafQzDchTMpLp")
+	unknownID := crypto.MustDigestFromBase58("BJ3Q8kNPByCWHwJ3RLn55UPzUDVgnh64EwYAU5iCj6z6")
+
+	s := mock.NewMockState(ctrl)
+	s.EXPECT().LeaseInfo(id).Return(&proto.LeaseDetails{
+		ID:                  id,
+		OriginTransactionID: id,
+		Sender:              sender,
+		SenderPK:            pk,
+		Recipient:           recipient,
+		Amount:              100500,
+		Height:              10,
+		CancelHeight:        12,
+		CancelTransactionID: &cancelID,
+	}, nil)
+	s.EXPECT().LeaseInfo(unknownID).Return(nil, stateerr.NewStateError(stateerr.NotFoundError, proto.ErrNotFound))
+	app, err := NewApp("api-key", nil, services.Services{State: s, Scheme: proto.TestNetScheme})
+	require.NoError(t, err)
+	a := NewNodeAPI(app, s)
+
+	newRequest := func(id string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/leasing/info/"+id, nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", id)
+		return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	}
+
+	resp := httptest.NewRecorder()
+	require.NoError(t, a.LeasingInfo(resp, newRequest(id.String())))
+	expected := fmt.Sprintf(`{"id":"%[1]s","originTransactionId":"%[1]s","sender":"%[2]s","recipient":"%[3]s",`+
+		`"amount":100500,"height":10,"status":"canceled","cancelHeight":12,"cancelTransactionId":"%[4]s"}`,
+		id.String(), sender.String(), recipient.String(), cancelID.String())
+	assert.JSONEq(t, expected, resp.Body.String())
+
+	err = a.LeasingInfo(httptest.NewRecorder(), newRequest(unknownID.String()))
+	assert.ErrorIs(t, err, apiErrs.TransactionDoesNotExist)
+	err = a.LeasingInfo(httptest.NewRecorder(), newRequest("invalid"))
+	assert.Error(t, err)
+}
+
+func TestNodeApi_OrderFilled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	filledID := crypto.MustDigestFromBase58("CMBHKDtyE8GMbZAZANNeE5n2HU4VDpsQaBLmfCw9ASbf")
+	unknownID := crypto.MustDigestFromBase58("BJ3Q8kNPByCWHwJ3RLn55UPzUDVgnh64EwYAU5iCj6z6")
+
+	s := mock.NewMockState(ctrl)
+	s.EXPECT().OrderFilled(filledID.Bytes()).Return(uint64(100500), uint64(300000), nil)
+	s.EXPECT().OrderFilled(unknownID.Bytes()).Return(uint64(0), uint64(0), nil)
+	app, err := NewApp("api-key", nil, services.Services{State: s, Scheme: proto.TestNetScheme})
+	require.NoError(t, err)
+	a := NewNodeAPI(app, s)
+
+	newRequest := func(id string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/transactions/exchange/filled/"+id, nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("orderId", id)
+		return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	}
+
+	resp := httptest.NewRecorder()
+	require.NoError(t, a.OrderFilled(resp, newRequest(filledID.String())))
+	expected := fmt.Sprintf(`{"orderId":"%s","filledAmount":100500,"filledFee":300000}`, filledID.String())
+	assert.JSONEq(t, expected, resp.Body.String())
+
+	resp = httptest.NewRecorder()
+	require.NoError(t, a.OrderFilled(resp, newRequest(unknownID.String())))
+	expected = fmt.Sprintf(`{"orderId":"%s","filledAmount":0,"filledFee":0}`, unknownID.String())
+	assert.JSONEq(t, expected, resp.Body.String())
+
+	err = a.OrderFilled(httptest.NewRecorder(), newRequest("invalid"))
+	assert.ErrorAs(t, err, new(*apiErrs.InvalidTransactionIdError))
+}
+
+func TestNodeApi_TransactionSPVProof(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sk, pk, err := crypto.GenerateKeyPair([]byte("spv"))
+	require.NoError(t, err)
+	addr, err := proto.NewAddressFromPublicKey(proto.TestNetScheme, pk)
+	require.NoError(t, err)
+	tx := proto.NewUnsignedTransferWithProofs(3, pk, proto.NewOptionalAssetWaves(), proto.NewOptionalAssetWaves(),
+		1000, 100, 100000, proto.NewRecipientFromAddress(addr), nil)
+	require.NoError(t, tx.Sign(proto.TestNetScheme, sk))
+	newBlock := func(parent proto.BlockID, txs proto.Transactions) *proto.Block {
+		b, bErr := proto.CreateBlock(txs, 1000, parent, pk,
+			proto.NxtConsensus{BaseTarget: 65, GenSignature: make([]byte, crypto.KeySize)},
+			proto.ProtobufBlockVersion, nil, -1, proto.TestNetScheme, nil)
+		require.NoError(t, bErr)
+		require.NoError(t, b.Sign(proto.TestNetScheme, sk))
+		return b
+	}
+	block := newBlock(proto.NewBlockIDFromDigest(crypto.Digest{}), proto.Transactions{tx})
+	next := newBlock(block.BlockID(), nil)
+
+	s := mock.NewMockState(ctrl)
+	s.EXPECT().TransactionHeightByID(tx.ID.Bytes()).Return(uint64(10), nil).Times(3)
+	s.EXPECT().Height().Return(uint64(11), nil)
+	s.EXPECT().BlockByHeight(uint64(10)).Return(block, nil)
+	s.EXPECT().HeaderByHeight(uint64(11)).Return(&next.BlockHeader, nil)
+	app, err := NewApp("api-key", nil, services.Services{State: s, Scheme: proto.TestNetScheme})
+	require.NoError(t, err)
+	a := NewNodeAPI(app, s)
+
+	newRequest := func(id, query string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/transactions/spv/"+id+query, nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", id)
+		return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	}
+
+	resp := httptest.NewRecorder()
+	require.NoError(t, a.TransactionSPVProof(resp, newRequest(tx.ID.String(), "")))
+	var proof spv.Proof
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &proof))
+	assert.EqualValues(t, 10, proof.Height)
+	assert.Len(t, proof.Headers, 2)
+	_, err = proof.Verify(proto.TestNetScheme, next.BlockID())
+	require.NoError(t, err)
+
+	err = a.TransactionSPVProof(httptest.NewRecorder(), newRequest(tx.ID.String(), "?to=9"))
+	assert.ErrorAs(t, err, new(*apiErrs.CustomValidationError))
+	err = a.TransactionSPVProof(httptest.NewRecorder(), newRequest(tx.ID.String(), "?to=1010"))
+	assert.ErrorAs(t, err, new(*apiErrs.CustomValidationError))
+	err = a.TransactionSPVProof(httptest.NewRecorder(), newRequest(tx.ID.String(), "?to=x"))
+	assert.ErrorAs(t, err, new(*apiErrs.CustomValidationError))
+}
+
+func TestNodeApi_AddressDataByKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	_, pk, err := crypto.GenerateKeyPair([]byte("data"))
+	require.NoError(t, err)
+	addr, err := proto.NewAddressFromPublicKey(proto.TestNetScheme, pk)
+	require.NoError(t, err)
+	rcp := proto.NewRecipientFromAddress(addr)
+
+	s := mock.NewMockState(ctrl)
+	s.EXPECT().RetrieveEntry(rcp, "some key").Return(&proto.IntegerDataEntry{Key: "some key", Value: 2}, nil)
+	s.EXPECT().RetrieveEntryAtHeight(rcp, "some key", uint64(10)).
+		Return(&proto.IntegerDataEntry{Key: "some key", Value: 1}, nil)
+	s.EXPECT().RetrieveEntryAtHeight(rcp, "some key", uint64(1)).
+		Return(nil, stateerr.NewStateError(stateerr.InvalidInputError, errors.New("out of range")))
+	s.EXPECT().RetrieveEntry(rcp, "unknown").Return(nil, stateerr.NewStateError(stateerr.NotFoundError,
+		proto.ErrNotFound))
+	app, err := NewApp("api-key", nil, services.Services{State: s, Scheme: proto.TestNetScheme})
+	require.NoError(t, err)
+	a := NewNodeAPI(app, s)
+
+	newRequest := func(address, key, query string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/addresses/data/"+address+"/"+key+query, nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("address", address)
+		rctx.URLParams.Add("key", key)
+		return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	}
+
+	resp := httptest.NewRecorder()
+	require.NoError(t, a.AddressDataByKey(resp, newRequest(addr.String(), "some%20key", "")))
+	assert.JSONEq(t, `{"key":"some key","type":"integer","value":2}`, resp.Body.String())
+	resp = httptest.NewRecorder()
+	require.NoError(t, a.AddressDataByKey(resp, newRequest(addr.String(), "some%20key", "?height=10")))
+	assert.JSONEq(t, `{"key":"some key","type":"integer","value":1}`, resp.Body.String())
+
+	err = a.AddressDataByKey(httptest.NewRecorder(), newRequest(addr.String(), "some%20key", "?height=1"))
+	assert.ErrorAs(t, err, new(*apiErrs.CustomValidationError))
+	err = a.AddressDataByKey(httptest.NewRecorder(), newRequest(addr.String(), "some%20key", "?height=x"))
+	assert.ErrorAs(t, err, new(*apiErrs.CustomValidationError))
+	err = a.AddressDataByKey(httptest.NewRecorder(), newRequest(addr.String(), "unknown", ""))
+	assert.ErrorIs(t, err, apiErrs.DataKeyDoesNotExist)
+	err = a.AddressDataByKey(httptest.NewRecorder(), newRequest("invalid", "unknown", ""))
+	assert.ErrorIs(t, err, apiErrs.InvalidAddress)
+}
+
+func TestNodeApi_AddressScriptHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	_, pk, err := crypto.GenerateKeyPair([]byte("script"))
+	require.NoError(t, err)
+	addr, err := proto.NewAddressFromPublicKey(proto.TestNetScheme, pk)
+	require.NoError(t, err)
+	setID := crypto.MustDigestFromBase58("ADXuoPsKMJ59HyLMGzLBbNQD8p2eJ93dciuBPJp3Qhx")
+	clearID := crypto.MustDigestFromBase58("6rSqVHYmWX4rgMqUTMz4WpPjuE27vY2p5HnJLPFmTFAD")
+	scriptHash := crypto.MustDigestFromBase58("BJ3Q8ZsNYEMy6o7RMDD7bqJkJUBELuq9jkShcZpsVuNx")
+
+	s := mock.NewMockState(ctrl)
+	s.EXPECT().ScriptHistoryByAccount(proto.NewRecipientFromAddress(addr)).Return([]proto.ScriptChange{
+		{Height: 20, TransactionID: clearID},
+		{Height: 10, TransactionID: setID, ScriptHash: &scriptHash},
+	}, nil)
+	app, err := NewApp("api-key", nil, services.Services{State: s, Scheme: proto.TestNetScheme})
+	require.NoError(t, err)
+	a := NewNodeAPI(app, s)
+
+	newRequest := func(address string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/addresses/scriptInfo/"+address+"/history", nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("address", address)
+		return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	}
+
+	resp := httptest.NewRecorder()
+	require.NoError(t, a.AddressScriptHistory(resp, newRequest(addr.String())))
+	expected := `[
+		{"height":20,"transactionId":"` + clearID.String() + `","scriptHash":null},
+		{"height":10,"transactionId":"` + setID.String() + `","scriptHash":"` + scriptHash.String() + `"}
+	]`
+	assert.JSONEq(t, expected, resp.Body.String())
+
+	err = a.AddressScriptHistory(httptest.NewRecorder(), newRequest("invalid"))
+	assert.ErrorIs(t, err, apiErrs.InvalidAddress)
+}
+
+func TestNodeApi_AssetsSponsorship(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	_, pk, err := crypto.GenerateKeyPair([]byte("sponsor"))
+	require.NoError(t, err)
+	sponsor, err := proto.NewAddressFromPublicKey(proto.TestNetScheme, pk)
+	require.NoError(t, err)
+	sponsored := crypto.MustDigestFromBase58("ADXuoPsKMJ59HyLMGzLBbNQD8p2eJ93dciuBPJp3Qhx")
+	notSponsored := crypto.MustDigestFromBase58("6rSqVHYmWX4rgMqUTMz4WpPjuE27vY2p5HnJLPFmTFAD")
+	unknown := crypto.MustDigestFromBase58("BJ3Q8ZsNYEMy6o7RMDD7bqJkJUBELuq9jkShcZpsVuNx")
+
+	s := mock.NewMockState(ctrl)
+	s.EXPECT().SponsorshipStatus(proto.AssetIDFromDigest(sponsored)).Return(&proto.SponsorshipStatus{
+		AssetID:         sponsored,
+		IsSponsored:     true,
+		MinSponsoredFee: 10,
+		Sponsor:         sponsor,
+		SponsorBalance:  250000,
+	}, nil)
+	s.EXPECT().SponsorshipStatus(proto.AssetIDFromDigest(notSponsored)).Return(&proto.SponsorshipStatus{
+		AssetID: notSponsored,
+		Sponsor: sponsor,
+	}, nil)
+	s.EXPECT().SponsorshipStatus(proto.AssetIDFromDigest(unknown)).Return(nil, errs.NewUnknownAsset("unknown"))
+	app, err := NewApp("api-key", nil, services.Services{State: s, Scheme: proto.TestNetScheme})
+	require.NoError(t, err)
+	a := NewNodeAPI(app, s)
+
+	newRequest := func(id string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/assets/sponsorship/"+id, nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", id)
+		return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	}
+
+	resp := httptest.NewRecorder()
+	require.NoError(t, a.AssetsSponsorship(resp, newRequest(sponsored.String())))
+	expected := fmt.Sprintf(`{"assetId":"%s","isSponsored":true,"minSponsoredAssetFee":10,"sponsor":"%s",
+		"sponsorBalance":250000,"sponsoredTransactions":2}`, sponsored.String(), sponsor.String())
+	assert.JSONEq(t, expected, resp.Body.String())
+
+	resp = httptest.NewRecorder()
+	require.NoError(t, a.AssetsSponsorship(resp, newRequest(notSponsored.String())))
+	expected = fmt.Sprintf(`{"assetId":"%s","isSponsored":false,"minSponsoredAssetFee":null,"sponsor":"%s",
+		"sponsorBalance":null,"sponsoredTransactions":null}`, notSponsored.String(), sponsor.String())
+	assert.JSONEq(t, expected, resp.Body.String())
+
+	err = a.AssetsSponsorship(httptest.NewRecorder(), newRequest(unknown.String()))
+	assert.ErrorAs(t, err, new(*apiErrs.AssetDoesNotExistError))
+	err = a.AssetsSponsorship(httptest.NewRecorder(), newRequest("invalid"))
+	assert.ErrorIs(t, err, apiErrs.InvalidAssetId)
+}
+
+func TestNodeApi_EffectiveBalances(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	_, pk, err := crypto.GenerateKeyPair([]byte("balances"))
+	require.NoError(t, err)
+	addr, err := proto.NewAddressFromPublicKey(proto.TestNetScheme, pk)
+	require.NoError(t, err)
+	rcp := proto.NewRecipientFromAddress(addr)
+
+	s := mock.NewMockState(ctrl)
+	s.EXPECT().EffectiveBalancesAtHeight(rcp, uint64(1500)).
+		Return(&proto.EffectiveBalances{Height: 1500, Effective: 300, Generating: 200}, nil)
+	s.EXPECT().EffectiveBalancesAtHeight(rcp, uint64(10)).
+		Return(nil, stateerr.NewStateError(stateerr.InvalidInputError, errors.New("out of range")))
+	app, err := NewApp("api-key", nil, services.Services{State: s, Scheme: proto.TestNetScheme})
+	require.NoError(t, err)
+	a := NewNodeAPI(app, s)
+
+	newRequest := func(address, height string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/debug/balances/effective/"+address+"/"+height, nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("address", address)
+		rctx.URLParams.Add("height", height)
+		return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	}
+
+	resp := httptest.NewRecorder()
+	require.NoError(t, a.effectiveBalances(resp, newRequest(addr.String(), "1500")))
+	assert.JSONEq(t, `{"height":1500,"effective":300,"generating":200}`, resp.Body.String())
+
+	err = a.effectiveBalances(httptest.NewRecorder(), newRequest(addr.String(), "10"))
+	assert.ErrorAs(t, err, new(*apiErrs.CustomValidationError))
+	err = a.effectiveBalances(httptest.NewRecorder(), newRequest("invalid", "10"))
+	assert.ErrorIs(t, err, apiErrs.InvalidAddress)
+}
+
+type utxWithIDs struct {
+	types.UtxPool
+	ids map[crypto.Digest]struct{}
+}
+
+func (u *utxWithIDs) ExistsByID(id []byte) bool {
+	d, err := crypto.NewDigestFromBytes(id)
+	if err != nil {
+		return false
+	}
+	_, ok := u.ids[d]
+	return ok
+}
+
+func TestNodeApi_TransactionsBroadcastDuplicate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sk, pk, err := crypto.GenerateKeyPair([]byte("sender"))
+	require.NoError(t, err)
+	recipient, err := proto.NewAddressFromPublicKey(proto.TestNetScheme, pk)
+	require.NoError(t, err)
+	newTransfer := func(amount uint64) (*proto.TransferWithProofs, crypto.Digest, []byte) {
+		tx := proto.NewUnsignedTransferWithProofs(3, pk, proto.NewOptionalAssetWaves(), proto.NewOptionalAssetWaves(),
+			1700000000000, amount, 100000, proto.NewRecipientFromAddress(recipient), nil)
+		require.NoError(t, tx.Sign(proto.TestNetScheme, sk))
+		js, mErr := json.Marshal(tx)
+		require.NoError(t, mErr)
+		return tx, *tx.ID, js
+	}
+	unconfirmed, unconfirmedID, unconfirmedJS := newTransfer(1)
+	confirmed, confirmedID, confirmedJS := newTransfer(2)
+
+	s := mock.NewMockState(ctrl)
+	s.EXPECT().TransactionByIDWithStatus(confirmedID.Bytes()).Return(confirmed, proto.TransactionSucceeded, nil)
+	s.EXPECT().TransactionHeightByID(confirmedID.Bytes()).Return(uint64(100), nil)
+	utx := &utxWithIDs{ids: map[crypto.Digest]struct{}{unconfirmedID: {}}}
+	app, err := NewApp("api-key", nil, services.Services{State: s, UtxPool: utx, Scheme: proto.TestNetScheme})
+	require.NoError(t, err)
+	a := NewNodeAPI(app, s)
+
+	broadcast := func(body []byte) map[string]interface{} {
+		req := httptest.NewRequest(http.MethodPost, "/transactions/broadcast", strings.NewReader(string(body)))
+		resp := httptest.NewRecorder()
+		require.NoError(t, a.TransactionsBroadcast(resp, req))
+		var r map[string]interface{}
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &r))
+		return r
+	}
+
+	r := broadcast(unconfirmedJS)
+	assert.Equal(t, unconfirmed.ID.String(), r["id"])
+	assert.Equal(t, true, r["duplicate"])
+	assert.Equal(t, "unconfirmed", r["status"])
+	assert.NotContains(t, r, "height")
+
+	r = broadcast(confirmedJS)
+	assert.Equal(t, confirmed.ID.String(), r["id"])
+	assert.Equal(t, true, r["duplicate"])
+	assert.Equal(t, "confirmed", r["status"])
+	assert.Equal(t, float64(100), r["height"])
+	assert.Equal(t, "succeeded", r["applicationStatus"])
+}
+
+func TestNodeApi_TransactionsBroadcastAsync(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sk, pk, err := crypto.GenerateKeyPair([]byte("sender"))
+	require.NoError(t, err)
+	recipient, err := proto.NewAddressFromPublicKey(proto.TestNetScheme, pk)
+	require.NoError(t, err)
+	tx := proto.NewUnsignedTransferWithProofs(3, pk, proto.NewOptionalAssetWaves(), proto.NewOptionalAssetWaves(),
+		1700000000000, 1, 100000, proto.NewRecipientFromAddress(recipient), nil)
+	require.NoError(t, tx.Sign(proto.TestNetScheme, sk))
+	js, err := json.Marshal(tx)
+	require.NoError(t, err)
+
+	s := mock.NewMockState(ctrl)
+	s.EXPECT().TransactionByIDWithStatus(tx.ID.Bytes()).
+		Return(nil, proto.TransactionStatus(0), stateerr.NewStateError(stateerr.NotFoundError, proto.ErrNotFound))
+	s.EXPECT().IsActivated(gomock.Any()).Return(true, nil)
+	internal := make(chan messages.InternalMessage, 1)
+	utx := &utxWithIDs{}
+	app, err := NewApp("api-key", nil,
+		services.Services{State: s, UtxPool: utx, Scheme: proto.TestNetScheme, InternalChannel: internal})
+	require.NoError(t, err)
+	a := NewNodeAPI(app, s)
+
+	req := httptest.NewRequest(http.MethodPost, "/transactions/broadcast?async=true", strings.NewReader(string(js)))
+	resp := httptest.NewRecorder()
+	require.NoError(t, a.TransactionsBroadcast(resp, req)) // returns without waiting for the response from node
+	assert.JSONEq(t, string(js), resp.Body.String())
+	msg := <-internal
+	bt, ok := msg.(*messages.BroadcastTransaction)
+	require.True(t, ok)
+	assert.Equal(t, tx.ID, bt.Transaction.(*proto.TransferWithProofs).ID)
+
+	req = httptest.NewRequest(http.MethodPost, "/transactions/broadcast?async=maybe", strings.NewReader(string(js)))
+	err = a.TransactionsBroadcast(httptest.NewRecorder(), req)
+	assert.EqualError(t, err, apiErrs.NewCustomValidationError("invalid 'async' parameter value 'maybe'").Error())
+}
+
+func TestNodeApi_TransactionsBroadcastRejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sk, pk, err := crypto.GenerateKeyPair([]byte("sender"))
+	require.NoError(t, err)
+	recipient, err := proto.NewAddressFromPublicKey(proto.TestNetScheme, pk)
+	require.NoError(t, err)
+	tx := proto.NewUnsignedTransferWithProofs(3, pk, proto.NewOptionalAssetWaves(), proto.NewOptionalAssetWaves(),
+		1700000000000, 1, 100000, proto.NewRecipientFromAddress(recipient), nil)
+	require.NoError(t, tx.Sign(proto.TestNetScheme, sk))
+	js, err := json.Marshal(tx)
+	require.NoError(t, err)
+
+	s := mock.NewMockState(ctrl)
+	s.EXPECT().TransactionByIDWithStatus(tx.ID.Bytes()).
+		Return(nil, proto.TransactionStatus(0), stateerr.NewStateError(stateerr.NotFoundError, proto.ErrNotFound)).
+		AnyTimes()
+	internal := make(chan messages.InternalMessage, 1)
+	app, err := NewApp("api-key", nil,
+		services.Services{State: s, UtxPool: &utxWithIDs{}, Scheme: proto.TestNetScheme, InternalChannel: internal})
+	require.NoError(t, err)
+	a := NewNodeAPI(app, s)
+
+	for _, test := range []struct {
+		name   string
+		reason error
+		code   int
+	}{
+		{"mistiming", errs.NewMistiming("transaction timestamp is too old"), int(apiErrs.MistimingErrorID)},
+		{"fee", errs.NewFeeValidation("fee does not exceed minimal value"), int(apiErrs.InsufficientFeeErrorID)},
+		{"account-script", errs.NewTransactionNotAllowedByScript("script failed", nil),
+			int(apiErrs.TransactionNotAllowedByAccountScriptErrorID)},
+		{"asset-script", errs.NewTransactionNotAllowedByScript("", crypto.Digest{1}.Bytes()),
+			int(apiErrs.TransactionNotAllowedByAssetScriptErrorID)},
+		{"account-script-error", fmt.Errorf("account script failed with error: %w", ride.UserError.New("boom")),
+			int(apiErrs.ScriptExecutionErrorErrorID)},
+		{"other", errors.New("negative waves balance"), int(apiErrs.StateCheckFailedErrorID)},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			go func() {
+				msg := (<-internal).(*messages.BroadcastTransaction)
+				// Reason of rejection is wrapped the same way as by the node's FSM.
+				stErr := stateerr.NewStateError(stateerr.TxValidationError, test.reason)
+				msg.Response <- fmt.Errorf("[NG] %w", fmt.Errorf("failed to add transaction to utx: %w", stErr))
+			}()
+			req := httptest.NewRequest(http.MethodPost, "/transactions/broadcast", strings.NewReader(string(js)))
+			bErr := a.TransactionsBroadcast(httptest.NewRecorder(), req)
+			var apiErr apiErrs.ApiError
+			require.ErrorAs(t, bErr, &apiErr)
+			assert.Equal(t, test.code, apiErr.GetID().IntCode())
+		})
+	}
+}
+
+func TestNodeApi_ActivationStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const (
+		height     = 25
+		activated  = 1
+		approved   = 2
+		onTrack    = 3
+		notVoted   = 4
+		unknownID  = 1000
+		nextCheck  = 30
+		interval   = 10
+		threshold  = 8
+		activation = 5
+		approval   = 20
+	)
+	s := mock.NewMockState(ctrl)
+	s.EXPECT().Height().Return(proto.Height(height), nil)
+	s.EXPECT().BlockchainSettings().Return(&settings.BlockchainSettings{
+		FunctionalitySettings: settings.FunctionalitySettings{
+			FeaturesVotingPeriod:             interval,
+			VotesForFeatureActivation:        threshold,
+			DoubleFeaturesPeriodsAfterHeight: 1000,
+		},
+	}, nil)
+	s.EXPECT().AllFeatures().Return([]int16{unknownID}, nil)
+	s.EXPECT().IsActiveAtHeight(gomock.Any(), proto.Height(height)).AnyTimes().
+		DoAndReturn(func(id int16, _ proto.Height) (bool, error) { return id == activated, nil })
+	s.EXPECT().ActivationHeight(int16(activated)).Return(proto.Height(activation), nil)
+	s.EXPECT().IsApprovedAtHeight(gomock.Any(), proto.Height(height)).AnyTimes().
+		DoAndReturn(func(id int16, _ proto.Height) (bool, error) { return id == approved, nil })
+	s.EXPECT().ApprovalHeight(int16(approved)).Return(proto.Height(approval), nil)
+	s.EXPECT().VotesNumAtHeight(gomock.Any(), proto.Height(height)).AnyTimes().
+		DoAndReturn(func(id int16, _ proto.Height) (uint64, error) {
+			switch id {
+			case onTrack:
+				return 4, nil
+			case unknownID:
+				return 1, nil
+			default:
+				return 0, nil
+			}
+		})
+	app, err := NewApp("api-key", nil, services.Services{State: s, Scheme: proto.TestNetScheme})
+	require.NoError(t, err)
+	a := NewNodeAPI(app, s)
+
+	resp := httptest.NewRecorder()
+	require.NoError(t, a.ActivationStatus(resp, httptest.NewRequest(http.MethodGet, "/activation/status", nil)))
+	var res activationStatusResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &res))
+	assert.Equal(t, proto.Height(height), res.Height)
+	assert.Equal(t, uint64(interval), res.VotingInterval)
+	assert.Equal(t, uint64(threshold), res.VotingThreshold)
+	assert.Equal(t, proto.Height(nextCheck), res.NextCheck)
+	assert.Len(t, res.Features, len(settings.FeaturesInfo)+1)
+
+	byID := make(map[int16]featureActivationStatus, len(res.Features))
+	for _, f := range res.Features {
+		byID[f.ID] = f
+	}
+	h := func(v proto.Height) *proto.Height { return &v }
+	assert.Equal(t, blockchainStatusActivated, byID[activated].BlockchainStatus)
+	assert.Equal(t, h(activation), byID[activated].ActivationHeight)
+	assert.Equal(t, blockchainStatusApproved, byID[approved].BlockchainStatus)
+	assert.Equal(t, h(approval+interval), byID[approved].ProjectedActivationHeight)
+	assert.Equal(t, featureActivationStatus{
+		ID:                        onTrack,
+		Description:               settings.FeaturesInfo[onTrack].Description,
+		BlockchainStatus:          blockchainStatusVoting,
+		NodeStatus:                nodeStatusImplemented,
+		SupportingBlocks:          4,
+		VotesRequired:             4,
+		BlocksRemaining:           5,
+		ProjectedActivationHeight: h(nextCheck + interval),
+	}, byID[onTrack])
+	assert.Nil(t, byID[notVoted].ProjectedActivationHeight)
+	assert.Equal(t, featureActivationStatus{
+		ID:               unknownID,
+		BlockchainStatus: blockchainStatusVoting,
+		NodeStatus:       nodeStatusNotImplemented,
+		SupportingBlocks: 1,
+		VotesRequired:    7,
+		BlocksRemaining:  5,
+	}, byID[unknownID])
+}
+
+func TestNodeApi_Richlist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	_, pk, err := crypto.GenerateKeyPair([]byte("richlist"))
+	require.NoError(t, err)
+	addr, err := proto.NewAddressFromPublicKey(proto.TestNetScheme, pk)
+	require.NoError(t, err)
+	assetID := crypto.MustDigestFromBase58("ADXuoPsKMJ59HyLMGzLBbNQD8p2eJ93dciuBPJp3Qhx")
+	unknownID := crypto.MustDigestFromBase58("6rSqVHYmWX4rgMqUTMz4WpPjuE27vY2p5HnJLPFmTFAD")
+	asset := *proto.NewOptionalAssetFromDigest(assetID)
+
+	s := mock.NewMockState(ctrl)
+	s.EXPECT().Richlist(proto.NewOptionalAssetWaves(), uint64(2)).
+		Return([]state.BalanceRecord{{Address: addr, Asset: proto.NewOptionalAssetWaves(), Balance: 100}}, nil)
+	s.EXPECT().IsAssetExist(proto.AssetIDFromDigest(assetID)).Return(true, nil)
+	s.EXPECT().IsAssetExist(proto.AssetIDFromDigest(unknownID)).Return(false, nil)
+	s.EXPECT().Richlist(asset, uint64(1)).
+		Return([]state.BalanceRecord{{Address: addr, Asset: asset, Balance: 5}}, nil)
+	s.EXPECT().Richlist(proto.NewOptionalAssetWaves(), uint64(3)).Return(nil, state.ErrIndexBuildLimited)
+	app, err := NewApp("api-key", nil, services.Services{State: s, Scheme: proto.TestNetScheme})
+	require.NoError(t, err)
+	a := NewNodeAPI(app, s)
+
+	newRequest := func(id, limit string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/richlist/"+limit, nil)
+		rctx := chi.NewRouteContext()
+		if id != "" {
+			rctx.URLParams.Add("id", id)
+		}
+		rctx.URLParams.Add("limit", limit)
+		return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	}
+
+	resp := httptest.NewRecorder()
+	require.NoError(t, a.AddressesRichlist(resp, newRequest("", "2")))
+	assert.JSONEq(t, fmt.Sprintf(`[{"address":"%s","balance":100}]`, addr.String()), resp.Body.String())
+	resp = httptest.NewRecorder()
+	require.NoError(t, a.AssetsRichlist(resp, newRequest(assetID.String(), "1")))
+	assert.JSONEq(t, fmt.Sprintf(`[{"address":"%s","balance":5}]`, addr.String()), resp.Body.String())
+
+	resp = httptest.NewRecorder()
+	require.NoError(t, a.AddressesRichlist(resp, newRequest("", "3")))
+	assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+	assert.NotEmpty(t, resp.Header().Get("Retry-After"))
+
+	err = a.AddressesRichlist(httptest.NewRecorder(), newRequest("", "1001"))
+	assert.ErrorAs(t, err, new(*apiErrs.TooBigArrayAllocationError))
+	err = a.AssetsRichlist(httptest.NewRecorder(), newRequest(unknownID.String(), "1"))
+	assert.ErrorAs(t, err, new(*apiErrs.AssetDoesNotExistError))
+	err = a.AssetsRichlist(httptest.NewRecorder(), newRequest("invalid", "1"))
+	assert.ErrorIs(t, err, apiErrs.InvalidAssetId)
+}
+
+func TestNodeApi_AddressesBalance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	_, pk1, err := crypto.GenerateKeyPair([]byte("balance1"))
+	require.NoError(t, err)
+	addr1, err := proto.NewAddressFromPublicKey(proto.TestNetScheme, pk1)
+	require.NoError(t, err)
+	_, pk2, err := crypto.GenerateKeyPair([]byte("balance2"))
+	require.NoError(t, err)
+	addr2, err := proto.NewAddressFromPublicKey(proto.TestNetScheme, pk2)
+	require.NoError(t, err)
+	rcp1, rcp2 := proto.NewRecipientFromAddress(addr1), proto.NewRecipientFromAddress(addr2)
+	assetID := crypto.MustDigestFromBase58("ADXuoPsKMJ59HyLMGzLBbNQD8p2eJ93dciuBPJp3Qhx")
+	asset := *proto.NewOptionalAssetFromDigest(assetID)
+
+	s := mock.NewMockState(ctrl)
+	s.EXPECT().WavesBalance(rcp1).Return(uint64(100), nil)
+	s.EXPECT().WavesBalance(rcp2).Return(uint64(0), nil)
+	s.EXPECT().IsAssetExist(proto.AssetIDFromDigest(assetID)).Return(true, nil)
+	s.EXPECT().BalanceAtHeight(rcp1, asset, uint64(1500)).Return(uint64(7), nil)
+	s.EXPECT().BalanceAtHeight(rcp1, proto.NewOptionalAssetWaves(), uint64(10)).
+		Return(uint64(0), stateerr.NewStateError(stateerr.InvalidInputError, errors.New("out of range")))
+	app, err := NewApp("api-key", nil, services.Services{State: s, Scheme: proto.TestNetScheme})
+	require.NoError(t, err)
+	a := NewNodeAPI(app, s)
+
+	newRequest := func(body string) *http.Request {
+		return httptest.NewRequest(http.MethodPost, "/addresses/balance", strings.NewReader(body))
+	}
+
+	resp := httptest.NewRecorder()
+	body := fmt.Sprintf(`{"addresses":["%s","%s"]}`, addr1.String(), addr2.String())
+	require.NoError(t, a.AddressesBalance(resp, newRequest(body)))
+	assert.JSONEq(t, fmt.Sprintf(`[{"id":"%s","balance":100},{"id":"%s","balance":0}]`, addr1.String(), addr2.String()),
+		resp.Body.String())
+
+	resp = httptest.NewRecorder()
+	body = fmt.Sprintf(`{"addresses":["%s"],"asset":"%s","height":1500}`, addr1.String(), assetID.String())
+	require.NoError(t, a.AddressesBalance(resp, newRequest(body)))
+	assert.JSONEq(t, fmt.Sprintf(`[{"id":"%s","balance":7}]`, addr1.String()), resp.Body.String())
+
+	body = fmt.Sprintf(`{"addresses":["%s"],"height":10}`, addr1.String())
+	err = a.AddressesBalance(httptest.NewRecorder(), newRequest(body))
+	assert.ErrorAs(t, err, new(*apiErrs.CustomValidationError))
+	err = a.AddressesBalance(httptest.NewRecorder(), newRequest(`{"addresses":["invalid"]}`))
+	assert.ErrorIs(t, err, apiErrs.InvalidAddress)
+	err = a.AddressesBalance(httptest.NewRecorder(), newRequest(`{"addresses":[]}`))
+	assert.ErrorAs(t, err, new(*apiErrs.CustomValidationError))
+}
+
+func TestNodeApi_Aliases(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	_, pk, err := crypto.GenerateKeyPair([]byte("aliases"))
+	require.NoError(t, err)
+	addr, err := proto.NewAddressFromPublicKey(proto.TestNetScheme, pk)
+	require.NoError(t, err)
+	alias := *proto.NewAlias(proto.TestNetScheme, "alice")
+
+	s := mock.NewMockState(ctrl)
+	s.EXPECT().AddrByAlias(alias).Return(addr, nil).Times(2)
+	s.EXPECT().AddrByAlias(*proto.NewAlias(proto.TestNetScheme, "carol")).
+		Return(proto.WavesAddress{}, stateerr.NewStateError(stateerr.RetrievalError, proto.ErrNotFound))
+	s.EXPECT().AliasesByAddr(addr).Return([]string{"alice"}, nil)
+	app, err := NewApp("api-key", nil, services.Services{State: s, Scheme: proto.TestNetScheme})
+	require.NoError(t, err)
+	a := NewNodeAPI(app, s)
+
+	newRequest := func(param, value string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/alias/"+value, nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add(param, value)
+		return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	}
+
+	expected := fmt.Sprintf(`{"address":"%s"}`, addr.String())
+	resp := httptest.NewRecorder()
+	require.NoError(t, a.AddrByAlias(resp, newRequest("alias", "alice")))
+	assert.JSONEq(t, expected, resp.Body.String())
+	resp = httptest.NewRecorder()
+	require.NoError(t, a.AddrByAlias(resp, newRequest("alias", alias.String())))
+	assert.JSONEq(t, expected, resp.Body.String())
+	err = a.AddrByAlias(httptest.NewRecorder(), newRequest("alias", "carol"))
+	assert.ErrorAs(t, err, new(*apiErrs.AliasDoesNotExistError))
+	err = a.AddrByAlias(httptest.NewRecorder(), newRequest("alias", "alias:W:alice"))
+	assert.ErrorAs(t, err, new(*apiErrs.CustomValidationError))
+
+	resp = httptest.NewRecorder()
+	require.NoError(t, a.AliasesByAddr(resp, newRequest("address", addr.String())))
+	assert.JSONEq(t, `["alias:T:alice"]`, resp.Body.String())
+}
+
+func TestNodeApi_AssetsSearch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	unknownID := crypto.MustDigestFromBase58("6rSqVHYmWX4rgMqUTMz4WpPjuE27vY2p5HnJLPFmTFAD")
+	s := mock.NewMockState(ctrl)
+	s.EXPECT().IsAssetExist(proto.AssetIDFromDigest(unknownID)).Return(false, nil)
+	s.EXPECT().SearchAssets("usd", gomock.Any(), nil).Return(nil, state.ErrIndexBuildLimited)
+	app, err := NewApp("api-key", nil, services.Services{State: s, Scheme: proto.TestNetScheme})
+	require.NoError(t, err)
+	a := NewNodeAPI(app, s)
+
+	req := httptest.NewRequest(http.MethodGet, "/assets/search?query=usd&after="+unknownID.String(), nil)
+	err = a.AssetsSearch(httptest.NewRecorder(), req)
+	var validationErr *apiErrs.CustomValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, http.StatusBadRequest, validationErr.GetHttpCode())
+
+	resp := httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/assets/search?query=usd", nil)
+	require.NoError(t, a.AssetsSearch(resp, req))
+	assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+}
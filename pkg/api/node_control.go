@@ -0,0 +1,74 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apiErrs "github.com/wavesplatform/gowaves/pkg/api/errors"
+)
+
+const maxNodeControlDelay = 24 * time.Hour
+
+// NodeControl triggers the graceful shutdown of the node.
+type NodeControl interface {
+	// Stop gracefully shuts the node down after the delay.
+	Stop(delay time.Duration)
+	// Restart gracefully shuts the node down after the delay and starts it again.
+	Restart(delay time.Duration)
+}
+
+type nodeControlResponse struct {
+	Stopped   bool  `json:"stopped,omitempty"`
+	Restarted bool  `json:"restarted,omitempty"`
+	Delay     int64 `json:"delay"` // in seconds
+}
+
+// parseNodeControlDelay parses optional 'delay' query parameter, the delay is given in seconds.
+func parseNodeControlDelay(r *http.Request) (time.Duration, error) {
+	s := r.URL.Query().Get("delay")
+	if s == "" {
+		return 0, nil
+	}
+	seconds, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, apiErrs.NewCustomValidationError("invalid 'delay' parameter, expected number of seconds")
+	}
+	if seconds > uint64(maxNodeControlDelay/time.Second) {
+		return 0, apiErrs.NewCustomValidationError("'delay' parameter exceeds " + maxNodeControlDelay.String())
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+func stopNode(ctl NodeControl) HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		delay, err := parseNodeControlDelay(r)
+		if err != nil {
+			return err
+		}
+		zap.S().Infof("Node stop is requested via API with delay %s", delay)
+		ctl.Stop(delay)
+		if sErr := trySendJson(w, nodeControlResponse{Stopped: true, Delay: int64(delay / time.Second)}); sErr != nil {
+			return errors.Wrap(sErr, "StopNode")
+		}
+		return nil
+	}
+}
+
+func restartNode(ctl NodeControl) HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		delay, err := parseNodeControlDelay(r)
+		if err != nil {
+			return err
+		}
+		zap.S().Infof("Node restart is requested via API with delay %s", delay)
+		ctl.Restart(delay)
+		if sErr := trySendJson(w, nodeControlResponse{Restarted: true, Delay: int64(delay / time.Second)}); sErr != nil {
+			return errors.Wrap(sErr, "RestartNode")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testNodeControl struct {
+	stopped   []time.Duration
+	restarted []time.Duration
+}
+
+func (c *testNodeControl) Stop(delay time.Duration) {
+	c.stopped = append(c.stopped, delay)
+}
+
+func (c *testNodeControl) Restart(delay time.Duration) {
+	c.restarted = append(c.restarted, delay)
+}
+
+func TestNodeControlHandlers(t *testing.T) {
+	ctl := new(testNodeControl)
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/node/stop", nil)
+	require.NoError(t, stopNode(ctl)(resp, req))
+	assert.JSONEq(t, `{"stopped":true,"delay":0}`, resp.Body.String())
+	assert.Equal(t, []time.Duration{0}, ctl.stopped)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/node/restart?delay=30", nil)
+	require.NoError(t, restartNode(ctl)(resp, req))
+	assert.JSONEq(t, `{"restarted":true,"delay":30}`, resp.Body.String())
+	assert.Equal(t, []time.Duration{30 * time.Second}, ctl.restarted)
+
+	for _, delay := range []string{"-1", "abc", "86401"} {
+		req = httptest.NewRequest(http.MethodPost, "/node/stop?delay="+delay, nil)
+		assert.Error(t, stopNode(ctl)(httptest.NewRecorder(), req), delay)
+	}
+	assert.Len(t, ctl.stopped, 1)
+}
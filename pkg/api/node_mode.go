@@ -0,0 +1,70 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/wavesplatform/gowaves/pkg/settings"
+)
+
+// routeCapability is the kind of node functionality exposed by the route. Each route is tagged with capabilities
+// in routes.go and is served only if all of them are enabled in the node mode.
+type routeCapability byte
+
+const (
+	// statusCapability routes report node's status, they are served in all modes.
+	statusCapability routeCapability = iota + 1
+	// dataCapability routes serve blockchain data and accept transactions from the public.
+	dataCapability
+	// adminCapability routes are API key protected routes managing the node.
+	adminCapability
+	// miningCapability routes show or control block generation.
+	miningCapability
+	// walletCapability routes expose or use node's wallet.
+	walletCapability
+)
+
+// modeAllows reports whether the capability is enabled in the node mode.
+func modeAllows(mode settings.NodeMode, c routeCapability) bool {
+	switch mode {
+	case settings.APIOnlyNodeMode:
+		return c != miningCapability && c != walletCapability
+	case settings.ValidatorOnlyNodeMode:
+		return c != dataCapability
+	default:
+		return true
+	}
+}
+
+// capabilityFilter creates middlewares tagging routes with capabilities. Requests to the routes with capabilities
+// disabled in the node mode are answered with notFound handler.
+type capabilityFilter struct {
+	mode     settings.NodeMode
+	notFound http.HandlerFunc
+}
+
+func newCapabilityFilter(mode settings.NodeMode, notFound http.HandlerFunc) *capabilityFilter {
+	if notFound == nil {
+		notFound = http.NotFound
+	}
+	return &capabilityFilter{mode: mode, notFound: notFound}
+}
+
+func (f *capabilityFilter) allowed(capabilities []routeCapability) bool {
+	for _, c := range capabilities {
+		if !modeAllows(f.mode, c) {
+			return false
+		}
+	}
+	return true
+}
+
+// require returns the middleware which serves the route only if all the capabilities are enabled.
+func (f *capabilityFilter) require(capabilities ...routeCapability) func(next http.Handler) http.Handler {
+	allowed := f.allowed(capabilities)
+	return func(next http.Handler) http.Handler {
+		if allowed {
+			return next
+		}
+		return f.notFound
+	}
+}
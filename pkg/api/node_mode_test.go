@@ -0,0 +1,60 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/services"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+)
+
+func TestNodeModeRoutes(t *testing.T) {
+	app, err := NewApp("api-key", nil, services.Services{})
+	require.NoError(t, err)
+	a := NewNodeAPI(app, nil)
+	// Allowed routes are checked with requests stopped by authorization or with handlers not using the state.
+	for _, tc := range []struct {
+		mode    settings.NodeMode
+		method  string
+		path    string
+		allowed bool
+	}{
+		{settings.FullNodeMode, http.MethodPost, "/debug/miner/pause", true},
+		{settings.FullNodeMode, http.MethodGet, "/wallet/seed", true},
+		{settings.FullNodeMode, http.MethodGet, "/go/webhooks/", true},
+		{settings.APIOnlyNodeMode, http.MethodGet, "/go/node/healthz", true},
+		{settings.APIOnlyNodeMode, http.MethodGet, "/go/webhooks/", true},
+		{settings.APIOnlyNodeMode, http.MethodPost, "/debug/rollback", true},
+		{settings.APIOnlyNodeMode, http.MethodPost, "/debug/miner/pause", false},
+		{settings.APIOnlyNodeMode, http.MethodPost, "/debug/miner/resume", false},
+		{settings.APIOnlyNodeMode, http.MethodGet, "/go/debug/blockDryRun", false},
+		{settings.APIOnlyNodeMode, http.MethodGet, "/go/miner/info", false},
+		{settings.APIOnlyNodeMode, http.MethodGet, "/go/miner/next", false},
+		{settings.APIOnlyNodeMode, http.MethodGet, "/wallet/seed", false},
+		{settings.APIOnlyNodeMode, http.MethodGet, "/go/wallet/accounts", false},
+		{settings.APIOnlyNodeMode, http.MethodPost, "/go/wallet/load", false},
+		{settings.APIOnlyNodeMode, http.MethodGet, "/addresses", false},
+		{settings.ValidatorOnlyNodeMode, http.MethodGet, "/go/node/healthz", true},
+		{settings.ValidatorOnlyNodeMode, http.MethodPost, "/debug/miner/pause", true},
+		{settings.ValidatorOnlyNodeMode, http.MethodGet, "/wallet/seed", true},
+		{settings.ValidatorOnlyNodeMode, http.MethodPost, "/peers/connect", true},
+		{settings.ValidatorOnlyNodeMode, http.MethodGet, "/go/webhooks/", false},
+		{settings.ValidatorOnlyNodeMode, http.MethodGet, "/blocks/at/10", false},
+		{settings.ValidatorOnlyNodeMode, http.MethodPost, "/transactions/broadcast", false},
+		{settings.ValidatorOnlyNodeMode, http.MethodGet, "/assets/details/abc", false},
+	} {
+		opts := DefaultRunOptions()
+		opts.Mode = tc.mode
+		opts.EnableHeartbeatRoute = true
+		routes, rErr := a.routes(opts)
+		require.NoError(t, rErr)
+		rec := httptest.NewRecorder()
+		routes.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.path, nil))
+		assert.Equal(t, tc.allowed, rec.Code != http.StatusNotFound, "mode %q, %s %s: status %d",
+			tc.mode, tc.method, tc.path, rec.Code)
+	}
+}
@@ -0,0 +1,22 @@
+package api
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+)
+
+type orderFilled struct {
+	OrderID      crypto.Digest `json:"orderId"`
+	FilledAmount uint64        `json:"filledAmount"`
+	FilledFee    uint64        `json:"filledFee"`
+}
+
+// OrderFilled returns the amount and the matcher fee filled by exchange transactions of the order.
+func (a *App) OrderFilled(orderID crypto.Digest) (*orderFilled, error) {
+	amount, fee, err := a.state.OrderFilled(orderID.Bytes())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get filled volume of order %q", orderID.String())
+	}
+	return &orderFilled{OrderID: orderID, FilledAmount: amount, FilledFee: fee}, nil
+}
@@ -54,16 +54,42 @@ func (a *App) PeersAll() (PeersKnown, error) {
 	return PeersKnown{Peers: out}, nil
 }
 
-func (a *App) PeersKnown() (PeersKnown, error) {
-	knownPeers := a.peers.KnownPeers()
+// KnownPeer is the known peer with its connection history, timestamps are in milliseconds.
+type KnownPeer struct {
+	Address            string `json:"address"`
+	FirstSeen          int64  `json:"firstSeen,omitempty"`
+	LastSeen           int64  `json:"lastSeen,omitempty"` // time of the last successful handshake
+	LastAttempt        int64  `json:"lastAttempt,omitempty"`
+	Failures           uint32 `json:"failures"`
+	ApplicationName    string `json:"applicationName,omitempty"`
+	ApplicationVersion string `json:"applicationVersion,omitempty"`
+}
 
-	out := make([]Peer, 0, len(knownPeers))
-	for _, knownPeer := range knownPeers {
-		// nickeksov: knownPeers without lastSeen field
-		out = append(out, Peer{Address: knownPeer.String()})
+type PeersKnownRecords struct {
+	Peers []KnownPeer `json:"peers"`
+}
+
+// PeersKnown returns all known peers in the order the node tries to connect to them.
+func (a *App) PeersKnown() (PeersKnownRecords, error) {
+	records := a.peers.KnownPeersRecords()
+
+	out := make([]KnownPeer, 0, len(records))
+	for _, r := range records {
+		p := KnownPeer{
+			Address:            r.Peer.String(),
+			FirstSeen:          r.FirstSeenMillis,
+			LastSeen:           r.LastHandshakeMillis,
+			Failures:           r.Failures,
+			ApplicationName:    r.AppName,
+			ApplicationVersion: r.Version,
+		}
+		if r.LastAttemptNanos != 0 {
+			p.LastAttempt = r.LastAttempt().UnixMilli()
+		}
+		out = append(out, p)
 	}
 
-	return PeersKnown{Peers: out}, nil
+	return PeersKnownRecords{Peers: out}, nil
 }
 
 type PeersConnectResponse struct {
@@ -194,3 +220,45 @@ func (a *App) PeersSpawned() PeersSpawnedResponse {
 	rs := a.peers.Spawned()
 	return PeersSpawnedResponse{Peers: rs}
 }
+
+type PeersPinnedResponse struct {
+	Peers []proto.IpPort `json:"peers"`
+}
+
+// PeersPinned returns addresses of peers which connections are always maintained.
+func (a *App) PeersPinned() PeersPinnedResponse {
+	return PeersPinnedResponse{Peers: a.peers.Pinned()}
+}
+
+type PeersPinResponse struct {
+	Hostname string `json:"hostname"`
+	Status   string `json:"status"`
+}
+
+// PeersPin pins the peer with the given address, the connection to the peer is established if needed.
+func (a *App) PeersPin(addr string) (*PeersPinResponse, error) {
+	d := proto.NewTCPAddrFromString(addr)
+	if d.Empty() {
+		return nil, wrapToBadRequestError(errors.New("invalid address"))
+	}
+	if err := a.peers.Pin(d); err != nil {
+		return nil, errors.Wrap(err, "failed to pin peer")
+	}
+	return &PeersPinResponse{Hostname: d.String(), Status: "Pinned"}, nil
+}
+
+// PeersUnpin makes the connection to the peer with the given address ordinary.
+func (a *App) PeersUnpin(addr string) (*PeersPinResponse, error) {
+	d := proto.NewTCPAddrFromString(addr)
+	if d.Empty() {
+		return nil, wrapToBadRequestError(errors.New("invalid address"))
+	}
+	unpinned, err := a.peers.Unpin(d)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unpin peer")
+	}
+	if !unpinned {
+		return nil, wrapToBadRequestError(errors.Errorf("peer '%s' is not pinned", d.String()))
+	}
+	return &PeersPinResponse{Hostname: d.String(), Status: "Unpinned"}, nil
+}
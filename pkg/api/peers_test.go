@@ -23,7 +23,18 @@ func TestApp_PeersKnown(t *testing.T) {
 
 	peerManager := mock.NewMockPeerManager(ctrl)
 	addr := proto.NewTCPAddr(net.ParseIP("127.0.0.1"), 6868).ToIpPort()
-	peerManager.EXPECT().KnownPeers().Return([]storage.KnownPeer{storage.KnownPeer(addr)})
+	now := time.Now()
+	peerManager.EXPECT().KnownPeersRecords().Return([]storage.KnownPeerRecord{{
+		Peer: storage.KnownPeer(addr),
+		KnownPeerInfo: storage.KnownPeerInfo{
+			LastAttemptNanos:    now.UnixNano(),
+			FirstSeenMillis:     now.Add(-time.Hour).UnixMilli(),
+			LastHandshakeMillis: now.UnixMilli(),
+			Failures:            1,
+			Version:             "1.5.0",
+			AppName:             "wavesW",
+		},
+	}})
 
 	app, err := NewApp("key", nil, services.Services{Peers: peerManager})
 	require.NoError(t, err)
@@ -31,6 +42,15 @@ func TestApp_PeersKnown(t *testing.T) {
 	rs2, err := app.PeersKnown()
 	require.NoError(t, err)
 	require.Len(t, rs2.Peers, 1)
+	assert.Equal(t, KnownPeer{
+		Address:            "127.0.0.1:6868",
+		FirstSeen:          now.Add(-time.Hour).UnixMilli(),
+		LastSeen:           now.UnixMilli(),
+		LastAttempt:        now.UnixMilli(),
+		Failures:           1,
+		ApplicationName:    "wavesW",
+		ApplicationVersion: "1.5.0",
+	}, rs2.Peers[0])
 }
 
 func TestApp_PeersSuspended(t *testing.T) {
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	protobuf "google.golang.org/protobuf/proto"
+
+	apiErrs "github.com/wavesplatform/gowaves/pkg/api/errors"
+	pb "github.com/wavesplatform/gowaves/pkg/grpc/generated/waves/node/grpc"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/state/stateerr"
+)
+
+const (
+	protobufContentType = "application/vnd.waves.protobuf"
+	protobufFormat      = "protobuf"
+)
+
+// wantsProtobuf checks whether the client asked for the protobuf encoded response with the Accept header or
+// the 'format' query parameter.
+func wantsProtobuf(r *http.Request) bool {
+	if r.URL.Query().Get("format") == protobufFormat {
+		return true
+	}
+	for _, v := range r.Header.Values("Accept") {
+		for _, part := range strings.Split(v, ",") {
+			mt, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+			if err == nil && mt == protobufContentType {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func trySendProtobuf(w http.ResponseWriter, m protobuf.Message) error {
+	data, err := protobuf.Marshal(m)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal %T to protobuf", m)
+	}
+	w.Header().Set("Content-Type", protobufContentType)
+	if _, err := w.Write(data); err != nil {
+		return errors.Wrapf(err, "failed to write protobuf to %T", w)
+	}
+	return nil
+}
+
+// ProtobufBlockAt returns the block or only its header at the given height in the same form as gRPC BlocksApi.
+func (a *App) ProtobufBlockAt(height proto.Height, includeTransactions bool) (*pb.BlockWithHeight, error) {
+	header, err := a.state.HeaderByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	vrf, err := a.state.BlockVRF(header, height)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to calculate VRF of block at height %d", height)
+	}
+	generator, err := proto.NewAddressFromPublicKey(a.services.Scheme, header.GeneratorPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	rewards, err := a.state.BlockRewards(generator, height)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to calculate rewards of block at height %d", height)
+	}
+	if !includeTransactions {
+		return header.HeaderToProtobufWithHeight(a.services.Scheme, height, vrf, rewards)
+	}
+	block, err := a.state.BlockByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	return block.ToProtobufWithHeight(a.services.Scheme, height, vrf, rewards)
+}
+
+func (a *NodeApi) sendProtobufBlock(w http.ResponseWriter, height proto.Height, includeTransactions bool) error {
+	block, err := a.app.ProtobufBlockAt(height, includeTransactions)
+	if err != nil {
+		if stateerr.IsNotFound(err) || stateerr.IsInvalidInput(err) {
+			return apiErrs.BlockDoesNotExist
+		}
+		return errors.Wrapf(err, "failed to get protobuf block at height %d", height)
+	}
+	return trySendProtobuf(w, block)
+}
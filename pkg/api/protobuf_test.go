@@ -0,0 +1,76 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	g "github.com/wavesplatform/gowaves/pkg/grpc/generated/waves"
+	"github.com/wavesplatform/gowaves/pkg/mock"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/services"
+)
+
+func TestWantsProtobuf(t *testing.T) {
+	for _, test := range []struct {
+		url    string
+		accept string
+		want   bool
+	}{
+		{"/blocks/last", "", false},
+		{"/blocks/last", "application/json", false},
+		{"/blocks/last?format=protobuf", "", true},
+		{"/blocks/last?format=json", "", false},
+		{"/blocks/last", "application/vnd.waves.protobuf", true},
+		{"/blocks/last", "application/json;q=0.5, application/vnd.waves.protobuf", true},
+	} {
+		req := httptest.NewRequest(http.MethodGet, test.url, nil)
+		if test.accept != "" {
+			req.Header.Set("Accept", test.accept)
+		}
+		assert.Equal(t, test.want, wantsProtobuf(req), "url %q, accept %q", test.url, test.accept)
+	}
+}
+
+func TestNodeApi_TransactionInfoProtobuf(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sk, pk, err := crypto.GenerateKeyPair([]byte("sender"))
+	require.NoError(t, err)
+	addr, err := proto.NewAddressFromPublicKey(proto.TestNetScheme, pk)
+	require.NoError(t, err)
+	tx := proto.NewUnsignedTransferWithProofs(3, pk, proto.NewOptionalAssetWaves(), proto.NewOptionalAssetWaves(),
+		1700000000000, 100, 100000, proto.NewRecipientFromAddress(addr), nil)
+	require.NoError(t, tx.Sign(proto.TestNetScheme, sk))
+
+	s := mock.NewMockState(ctrl)
+	s.EXPECT().TransactionHeightByID(tx.ID.Bytes()).Return(uint64(10), nil)
+	s.EXPECT().Height().Return(uint64(20), nil)
+	s.EXPECT().TransactionByID(tx.ID.Bytes()).Return(tx, nil)
+	app, err := NewApp("api-key", nil, services.Services{State: s, Scheme: proto.TestNetScheme})
+	require.NoError(t, err)
+	a := NewNodeAPI(app, s)
+
+	req := httptest.NewRequest(http.MethodGet, "/transactions/info/"+tx.ID.String(), nil)
+	req.Header.Set("Accept", protobufContentType)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", tx.ID.String())
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	resp := httptest.NewRecorder()
+	require.NoError(t, a.TransactionInfo(resp, req))
+	assert.Equal(t, protobufContentType, resp.Header().Get("Content-Type"))
+	assert.Empty(t, resp.Header().Get("ETag"), "transaction can still be rolled back")
+
+	expected, err := tx.ToProtobufSigned(proto.TestNetScheme)
+	require.NoError(t, err)
+	actual := new(g.SignedTransaction)
+	require.NoError(t, protobuf.Unmarshal(resp.Body.Bytes(), actual))
+	assert.True(t, protobuf.Equal(expected, actual))
+}
@@ -27,6 +27,7 @@ func toHTTPHandlerFunc(handler HandlerFunc, errorHandler HandleErrorFunc) http.H
 func (a *NodeApi) routes(opts *RunOptions) (chi.Router, error) {
 	r := chi.NewRouter()
 
+	r.Use(keepConnAddr)
 	if opts.UseRealIPMiddleware {
 		// nickeskov: for nginx/haproxy specific headers
 		r.Use(middleware.RealIP)
@@ -34,12 +35,25 @@ func (a *NodeApi) routes(opts *RunOptions) (chi.Router, error) {
 	if opts.CollectMetrics {
 		r.Use(chiHttpApiGeneralMetricsMiddleware)
 	}
+	var rateLimit func(next http.Handler) http.Handler
 	if opts.RateLimiterOpts != nil {
 		rateLimiter, err := createRateLimiter(opts.RateLimiterOpts)
 		if err != nil {
 			return nil, errors.WithStack(err)
 		}
-		r.Use(rateLimiter.RateLimit)
+		rateLimit = rateLimiter.RateLimit
+	}
+	var keyLimiter *apiKeyLimiter
+	if len(opts.APIKeyQuotas) > 0 {
+		kl, err := newAPIKeyLimiter(opts.APIKeyQuotas)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create API keys rate limiter")
+		}
+		keyLimiter = kl
+		rateLimit = kl.middleware(rateLimit)
+	}
+	if rateLimit != nil {
+		r.Use(rateLimit)
 	}
 	if opts.RequestIDMiddleware {
 		r.Use(middleware.RequestID)
@@ -47,20 +61,49 @@ func (a *NodeApi) routes(opts *RunOptions) (chi.Router, error) {
 	if opts.LogHttpRequestOpts {
 		r.Use(createLoggerMiddleware(zap.L()))
 	}
+	if opts.GRPCWeb != nil {
+		r.Use(opts.GRPCWeb)
+	}
+	if so := opts.ServerOpts; so != nil && (so.HandlerTimeout > 0 || len(so.RouteTimeouts) > 0) {
+		r.Use(createHandlerTimeoutMiddleware(so.HandlerTimeout, so.RouteTimeouts))
+	}
 	if opts.RouteNotFoundHandler != nil {
 		r.NotFound(opts.RouteNotFoundHandler)
 	}
+	// Routes are tagged with capabilities, the node mode decides which of them are served.
+	caps := newCapabilityFilter(opts.Mode, opts.RouteNotFoundHandler)
+	status, data, admin := caps.require(statusCapability), caps.require(dataCapability), caps.require(adminCapability)
+	mining, wallet := caps.require(miningCapability), caps.require(walletCapability)
 
 	// nickeskov: middlewares and custom handlers
 	errHandler := NewErrorHandler(zap.L())
-	checkAuthMiddleware := createCheckAuthMiddleware(a.app, errHandler.Handle)
+	var jwt *jwtValidator
+	if opts.JWTAuthOpts != nil {
+		v, err := newJWTValidator(*opts.JWTAuthOpts)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create JWT validator")
+		}
+		jwt = v
+	}
+	checkAuthMiddleware := createCheckAuthMiddleware(a.app, jwt, errHandler.Handle)
+	if opts.AuditLog != nil {
+		checkAuthMiddleware = opts.AuditLog.middleware(a.app.keys, checkAuthMiddleware)
+	}
 
 	wrapper := func(handlerFunc HandlerFunc) http.HandlerFunc {
 		return toHTTPHandlerFunc(handlerFunc, errHandler.Handle)
 	}
 
+	if opts.FaucetOpts != nil {
+		f, err := newFaucet(a.app, *opts.FaucetOpts)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create faucet")
+		}
+		r.With(data, wallet).Post("/faucet", wrapper(f.handle))
+	}
+
 	if opts.EnableHeartbeatRoute {
-		r.Get("/go/node/healthz", func(w http.ResponseWriter, r *http.Request) {
+		r.With(status).Get("/go/node/healthz", func(w http.ResponseWriter, r *http.Request) {
 			if _, err := w.Write([]byte("OK")); err != nil {
 				zap.S().Errorf("Can't write 'OK' to ResponseWriter: %+v", err)
 				w.WriteHeader(http.StatusInternalServerError)
@@ -71,6 +114,7 @@ func (a *NodeApi) routes(opts *RunOptions) (chi.Router, error) {
 	// nickeskov: go node routes
 	r.Route("/go", func(r chi.Router) {
 		r.Route("/blocks", func(r chi.Router) {
+			r.Use(data)
 			r.Get("/score/at/{id:\\d+}", wrapper(a.BlockScoreAt))
 			r.Get("/id/{id}", wrapper(a.BlockIDAt))
 			r.Get("/generators", wrapper(a.BlocksGenerators))
@@ -79,11 +123,18 @@ func (a *NodeApi) routes(opts *RunOptions) (chi.Router, error) {
 		})
 
 		r.Route("/peers", func(r chi.Router) {
-			r.Get("/known", wrapper(a.PeersKnown))
-			r.Get("/spawned", wrapper(a.PeersSpawned))
+			r.With(status).Get("/known", wrapper(a.PeersKnown))
+			r.With(status).Get("/spawned", wrapper(a.PeersSpawned))
+			r.With(status).Get("/pinned", wrapper(a.PeersPinned))
+
+			rAuth := r.With(admin, checkAuthMiddleware)
+
+			rAuth.Post("/pin", wrapper(a.PeersPin))
+			rAuth.Post("/unpin", wrapper(a.PeersUnpin))
 		})
 
 		r.Route("/wallet", func(r chi.Router) {
+			r.Use(wallet)
 			r.Get("/accounts", wrapper(a.WalletAccounts))
 
 			rAuth := r.With(checkAuthMiddleware)
@@ -91,23 +142,54 @@ func (a *NodeApi) routes(opts *RunOptions) (chi.Router, error) {
 			rAuth.Post("/load", wrapper(WalletLoadKeys(a.app)))
 		})
 		r.Route("/debug", func(r chi.Router) {
-			r.Get("/snapshotStateHash/{height:\\d+}", wrapper(a.snapshotStateHash))
+			r.With(status).Get("/snapshotStateHash/{height:\\d+}", wrapper(a.snapshotStateHash))
+			r.With(mining, checkAuthMiddleware).Get("/blockDryRun", wrapper(a.debugBlockDryRun))
+		})
+
+		r.Route("/api-keys", func(r chi.Router) {
+			rAuth := r.With(admin, checkAuthMiddleware)
+
+			rAuth.Get("/", wrapper(a.apiKeys))
+			rAuth.Post("/", wrapper(a.addAPIKey))
+			rAuth.Delete("/{name}", wrapper(a.revokeAPIKey))
+			if keyLimiter != nil {
+				rAuth.Get("/usage", wrapper(keyLimiter.usageHandler))
+			}
 		})
 
-		r.Get("/miner/info", wrapper(a.GoMinerInfo))
-		r.Get("/pool/transactions", wrapper(a.poolTransactions))
+		r.Route("/webhooks", func(r chi.Router) {
+			rAuth := r.With(data, admin, checkAuthMiddleware)
+
+			rAuth.Get("/", wrapper(a.webhooks))
+			rAuth.Post("/", wrapper(a.addWebhook))
+			rAuth.Delete("/{id}", wrapper(a.removeWebhook))
+		})
+
+		r.Route("/watchlist", func(r chi.Router) {
+			rAuth := r.With(data, admin, checkAuthMiddleware)
+
+			rAuth.Get("/", wrapper(a.watchlistAddresses))
+			rAuth.Post("/", wrapper(a.watchlistAdd))
+			rAuth.Delete("/{address}", wrapper(a.watchlistRemove))
+			rAuth.Get("/{address}/transactions", wrapper(a.watchlistTransactions))
+		})
+
+		r.With(mining).Get("/miner/info", wrapper(a.GoMinerInfo))
+		r.With(mining).Get("/miner/next", wrapper(a.minerNextBlock))
+		r.With(data).Get("/pool/transactions", wrapper(a.poolTransactions))
 	})
 
 	// nickeskov: json api
 	r.Group(func(r chi.Router) {
 		r.Route("/blocks", func(r chi.Router) {
-			r.Get("/last", wrapper(a.BlocksLast))
-			r.Get("/height", wrapper(a.BlockHeight))
-			r.Get("/height/{id}", wrapper(a.BlockHeightByID))
-			r.Get("/at/{height}", wrapper(a.BlockAt))
-			r.Get("/{id}", wrapper(a.BlockIDAt))
+			r.With(status).Get("/last", wrapper(a.BlocksLast))
+			r.With(status).Get("/height", wrapper(a.BlockHeight))
+			r.With(data).Get("/height/{id}", wrapper(a.BlockHeightByID))
+			r.With(data).Get("/at/{height}", wrapper(a.BlockAt))
+			r.With(data).Get("/{id}", wrapper(a.BlockIDAt))
 
 			r.Route("/headers", func(r chi.Router) {
+				r.Use(data)
 				r.Get("/last", wrapper(a.BlocksHeadersLast))
 				r.Get("/at/{height:\\d+}", wrapper(a.BlocksHeadersAt))
 				r.Get("/{id}", wrapper(a.BlockHeadersID))
@@ -116,60 +198,97 @@ func (a *NodeApi) routes(opts *RunOptions) (chi.Router, error) {
 		})
 
 		r.Route("/assets", func(r chi.Router) {
+			r.Use(data)
 			r.Get("/details/{id}", wrapper(a.AssetsDetailsByID))
 			r.Get("/details", wrapper(a.AssetsDetailsByIDsGet))
 			r.Post("/details", wrapper(a.AssetsDetailsByIDsPost))
+			r.Get("/sponsorship/{id}", wrapper(a.AssetsSponsorship))
+			r.Get("/search", wrapper(a.AssetsSearch))
+			r.Get("/{id}/richlist/{limit:\\d+}", wrapper(a.AssetsRichlist))
 		})
 
 		r.Route("/addresses", func(r chi.Router) {
-			r.Get("/", wrapper(a.Addresses))
+			r.Use(data)
+			r.With(wallet).Get("/", wrapper(a.Addresses))
+			r.Post("/balance", wrapper(a.AddressesBalance))
+			r.Get("/data/{address}/{key}", wrapper(a.AddressDataByKey))
+			r.Get("/scriptInfo/{address}/history", wrapper(a.AddressScriptHistory))
+			r.Get("/richlist/{limit:\\d+}", wrapper(a.AddressesRichlist))
 		})
 
 		r.Route("/alias", func(r chi.Router) {
+			r.Use(data)
 			r.Get("/by-alias/{alias}", wrapper(a.AddrByAlias))
 			r.Get("/by-address/{address}", wrapper(a.AliasesByAddr))
 		})
 
 		r.Route("/transactions", func(r chi.Router) {
+			r.Use(data)
 			r.Get("/unconfirmed/size", wrapper(a.unconfirmedSize))
+			r.Get("/fee/estimate", wrapper(a.FeeEstimate))
 			r.Get("/info/{id}", wrapper(a.TransactionInfo))
+			r.Get("/exchange/filled/{orderId}", wrapper(a.OrderFilled))
+			r.Get("/spv/{id}", wrapper(a.TransactionSPVProof))
 			r.Post("/broadcast", wrapper(a.TransactionsBroadcast))
 		})
 
+		r.Route("/leasing", func(r chi.Router) {
+			r.Use(data)
+			r.Get("/info/{id}", wrapper(a.LeasingInfo))
+		})
+
 		r.Route("/peers", func(r chi.Router) {
-			r.Get("/all", wrapper(a.PeersAll))
-			r.Get("/connected", wrapper(a.PeersConnected))
-			r.Get("/suspended", wrapper(a.PeersSuspended))
-			r.Get("/blacklisted", wrapper(a.PeersBlackListed))
+			r.With(status).Get("/all", wrapper(a.PeersAll))
+			r.With(status).Get("/connected", wrapper(a.PeersConnected))
+			r.With(status).Get("/suspended", wrapper(a.PeersSuspended))
+			r.With(status).Get("/blacklisted", wrapper(a.PeersBlackListed))
 
-			rAuth := r.With(checkAuthMiddleware)
+			rAuth := r.With(admin, checkAuthMiddleware)
 
 			rAuth.Post("/connect", wrapper(a.PeersConnect))
 			rAuth.Post("/clearblacklist", wrapper(a.PeersClearBlackList))
 		})
 
 		r.Route("/debug", func(r chi.Router) {
-			r.Get("/stateHash/{height:\\d+}", wrapper(a.stateHash))
-			r.Get("/stateHash/last", wrapper(a.stateHashLast))
+			r.With(status).Get("/stateHash/{height:\\d+}", wrapper(a.stateHash))
+			r.With(status).Get("/stateHash/last", wrapper(a.stateHashLast))
+			r.With(data).Get("/balances/history/{address}", wrapper(a.balancesHistory))
+			r.With(data).Get("/balances/effective/{address}/{height:\\d+}", wrapper(a.effectiveBalances))
+			r.With(status).Get("/forks", wrapper(a.debugForks))
+			r.With(status).Get("/forks/stats", wrapper(a.debugForksStats))
+			r.With(status).Get("/storage", wrapper(a.debugStorage))
 
-			rAuth := r.With(checkAuthMiddleware)
+			rAuth := r.With(admin, checkAuthMiddleware)
 
 			rAuth.Post("/print", wrapper(a.debugPrint))
 			rAuth.Post("/rollback", wrapper(a.RollbackToHeight))
 			rAuth.Post("/rollback-to/{id}", wrapper(a.RollbackTo))
+
+			rMining := r.With(admin, mining, checkAuthMiddleware)
+
+			rMining.Post("/miner/pause", wrapper(a.debugMinerPause))
+			rMining.Post("/miner/resume", wrapper(a.debugMinerResume))
 		})
 		r.Route("/node", func(r chi.Router) {
-			r.Get("/version", wrapper(a.version))
-			r.Get("/status", wrapper(a.NodeStatus))
+			r.With(status).Get("/version", wrapper(a.version))
+			r.With(status).Get("/status", wrapper(a.NodeStatus))
+			r.With(admin, checkAuthMiddleware).Post("/backup", wrapper(a.Backup))
+			if opts.NodeControl != nil {
+				rAuth := r.With(admin, checkAuthMiddleware)
+
+				rAuth.Post("/stop", wrapper(stopNode(opts.NodeControl)))
+				rAuth.Post("/restart", wrapper(restartNode(opts.NodeControl)))
+			}
 		})
 
 		r.Route("/wallet", func(r chi.Router) {
-			rAuth := r.With(checkAuthMiddleware)
+			rAuth := r.With(wallet, checkAuthMiddleware)
 
 			rAuth.Get("/seed", wrapper(a.walletSeed))
 		})
 
 		r.Route("/eth", func(r chi.Router) {
+			r.Use(data)
 			r.Get("/abi/{address}", wrapper(a.EthereumDAppABI))
 			if opts.EnableMetaMaskAPI {
 				service := metamask.NewRPCService(&a.app.services)
@@ -182,7 +301,10 @@ func (a *NodeApi) routes(opts *RunOptions) (chi.Router, error) {
 			}
 		})
 
+		r.With(status).Get("/activation/status", wrapper(a.ActivationStatus))
+
 		r.Route("/blockchain", func(r chi.Router) {
+			r.Use(data)
 			r.Get("/rewards", wrapper(a.blockchainRewards))
 			r.Get("/rewards/{height}", wrapper(a.blockchainRewardsAtHeight))
 		})
@@ -191,5 +313,19 @@ func (a *NodeApi) routes(opts *RunOptions) (chi.Router, error) {
 		//r.Get("/debug/sync/{enabled:\\d+}", a.DebugSyncEnabled)
 	})
 
+	if opts.EnableExplorer {
+		redirect := http.RedirectHandler(explorerPath+"/", http.StatusMovedPermanently)
+		r.With(data).Get(explorerPath, redirect.ServeHTTP)
+		r.With(data).Handle(explorerPath+"/*", explorerHandler())
+	}
+
+	if opts.RegisterExtensionRoutes != nil {
+		// Extensions serve their own data, so they are disabled where public data routes are.
+		r.Group(func(r chi.Router) {
+			r.Use(data)
+			opts.RegisterExtensionRoutes(r, checkAuthMiddleware)
+		})
+	}
+
 	return r, nil
 }
@@ -5,22 +5,48 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/go-chi/chi"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
+
+	"github.com/wavesplatform/gowaves/pkg/settings"
 )
 
 const (
 	DefaultMaxConnections       = 128
+	DefaultShutdownTimeout      = 5 * time.Second
+	DefaultDrainPeriod          = time.Second
 	DefaultRateLimiterCacheSize = 64 * 1024 // 64 KB
 	DefaultRateLimiterRPS       = 1
 	DefaultRateLimiterBurst     = 1
 )
 
+const (
+	DefaultReadHeaderTimeout = 30 * time.Second
+	DefaultReadTimeout       = 30 * time.Second
+)
+
+const (
+	DefaultAuditLogMaxSizeMB  = 100
+	DefaultAuditLogMaxBackups = 5
+)
+
 const (
 	cacheSizeKey = "cache"
 	rpsKey       = "rps"
 	burstKey     = "burst"
+
+	readHeaderTimeoutKey = "read-header"
+	readTimeoutKey       = "read"
+	writeTimeoutKey      = "write"
+	idleTimeoutKey       = "idle"
+	maxHeaderBytesKey    = "max-header-bytes"
+	handlerTimeoutKey    = "handler"
+
+	maxSizeKey    = "max-size"
+	maxBackupsKey = "backups"
 )
 
 type RunOptions struct {
@@ -32,8 +58,51 @@ type RunOptions struct {
 	EnableHeartbeatRoute bool
 	RouteNotFoundHandler func(w http.ResponseWriter, r *http.Request)
 	MaxConnections       int
+	ShutdownTimeout      time.Duration // time given to in-flight requests to complete on shutdown
+	DrainPeriod          time.Duration // time of rejecting requests on open connections with 503 before shutdown
 	EnableMetaMaskAPI    bool
 	EnableMetaMaskAPILog bool
+	EnableExplorer       bool // serves the block explorer UI at /explorer
+	FaucetOpts           *FaucetOptions
+	Mode                 settings.NodeMode
+	APIKeyQuotas         []APIKeyQuota
+	ServerOpts           *ServerOptions
+	JWTAuthOpts          *JWTAuthOptions // enables bearer tokens authentication if set
+	AuditLog             *AuditLog       // records requests to protected routes if set
+	NodeControl          NodeControl     // enables /node/stop and /node/restart routes if set
+	// GRPCWeb serves gRPC-Web requests on the API port if set, other requests are passed to the next handler.
+	GRPCWeb func(next http.Handler) http.Handler
+	// RegisterExtensionRoutes adds routes of node extensions, auth is the API key check middleware.
+	RegisterExtensionRoutes func(r chi.Router, auth func(http.Handler) http.Handler)
+}
+
+// ServerOptions are the limits of HTTP server. Zero timeout means no timeout, except IdleTimeout, which
+// defaults to ReadTimeout if zero. Handler timeouts set the deadline of request's context, long-running handlers
+// stop on its expiration and the request is answered with 504 status.
+type ServerOptions struct {
+	ReadHeaderTimeout time.Duration
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration // should be zero or large enough for streaming responses
+	IdleTimeout       time.Duration
+	MaxHeaderBytes    int
+	HandlerTimeout    time.Duration  // default timeout of all routes
+	RouteTimeouts     []RouteTimeout // overrides HandlerTimeout for matching routes
+}
+
+// AuditLogOptions are the options of the audit log of requests to protected API methods. The log is written to
+// the file at the path, which is rotated when its size exceeds MaxSize bytes, or to the local syslog daemon if the
+// path is "syslog".
+type AuditLogOptions struct {
+	Path       string
+	MaxSize    int64
+	MaxBackups int
+}
+
+// RouteTimeout is the timeout of handlers of requests which paths start with the prefix. If several prefixes match
+// the path, the longest one is used. Zero timeout disables the timeout of matching routes.
+type RouteTimeout struct {
+	Prefix  string
+	Timeout time.Duration
 }
 
 type RateLimiterOptions struct {
@@ -42,6 +111,15 @@ type RateLimiterOptions struct {
 	MaxBurst             int
 }
 
+// APIKeyQuota is the rate limit quota of a partner's API key. Requests with the key in X-API-Key header are limited
+// by the quota of the key instead of the common rate limiter. Partner's keys don't give access to protected methods.
+type APIKeyQuota struct {
+	Name                 string
+	Key                  string
+	MaxRequestsPerSecond int
+	MaxBurst             int
+}
+
 func DefaultRunOptions() *RunOptions {
 	return &RunOptions{
 		RateLimiterOpts:      DefaultRateLimiterOptions(),
@@ -55,8 +133,20 @@ func DefaultRunOptions() *RunOptions {
 			w.WriteHeader(http.StatusNotFound)
 		},
 		MaxConnections:       DefaultMaxConnections,
+		ShutdownTimeout:      DefaultShutdownTimeout,
+		DrainPeriod:          DefaultDrainPeriod,
+		ServerOpts:           DefaultServerOptions(),
 		EnableMetaMaskAPI:    false,
 		EnableMetaMaskAPILog: false,
+		Mode:                 settings.FullNodeMode,
+	}
+}
+
+func DefaultServerOptions() *ServerOptions {
+	return &ServerOptions{
+		ReadHeaderTimeout: DefaultReadHeaderTimeout,
+		ReadTimeout:       DefaultReadTimeout,
+		MaxHeaderBytes:    http.DefaultMaxHeaderBytes,
 	}
 }
 
@@ -92,6 +182,82 @@ func NewRateLimiterOptionsFromString(s string) (*RateLimiterOptions, error) {
 	return opt, nil
 }
 
+// NewServerOptionsFromString parses HTTP server options in form of URL query options, e.g.
+// "read=30s&write=1m&idle=2m&max-header-bytes=65536&handler=20s". Omitted options have default values.
+func NewServerOptionsFromString(s string) (*ServerOptions, error) {
+	opts := DefaultServerOptions()
+	query, err := url.ParseQuery(strings.TrimSpace(s))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid server options")
+	}
+	for _, d := range []struct {
+		key string
+		v   *time.Duration
+	}{
+		{readHeaderTimeoutKey, &opts.ReadHeaderTimeout},
+		{readTimeoutKey, &opts.ReadTimeout},
+		{writeTimeoutKey, &opts.WriteTimeout},
+		{idleTimeoutKey, &opts.IdleTimeout},
+		{handlerTimeoutKey, &opts.HandlerTimeout},
+	} {
+		v, dErr := extractFirstDurationValue(query, d.key, *d.v)
+		if dErr != nil {
+			return nil, errors.Wrap(dErr, "invalid server options")
+		}
+		*d.v = v
+	}
+	mhb, err := extractFirstIntValue(query, maxHeaderBytesKey, opts.MaxHeaderBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid server options")
+	}
+	if mhb <= 0 {
+		return nil, errors.Errorf("invalid server options: non-positive value for key '%s'", maxHeaderBytesKey)
+	}
+	opts.MaxHeaderBytes = mhb
+	return opts, nil
+}
+
+// NewRouteTimeoutsFromString parses timeouts of routes in form of semicolon separated list of "prefix=duration"
+// entries, e.g. "/blocks/headers/seq=10s;/go/debug=0".
+func NewRouteTimeoutsFromString(s string) ([]RouteTimeout, error) {
+	var r []RouteTimeout
+	for _, entry := range strings.Split(s, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		prefix, value, ok := strings.Cut(entry, "=")
+		prefix = strings.TrimSpace(prefix)
+		if !ok || !strings.HasPrefix(prefix, "/") {
+			return nil, errors.Errorf("invalid route timeout '%s': expected 'prefix=duration' pair", entry)
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || d < 0 {
+			return nil, errors.Errorf("invalid timeout of route '%s'", prefix)
+		}
+		r = append(r, RouteTimeout{Prefix: prefix, Timeout: d})
+	}
+	return r, nil
+}
+
+func extractFirstDurationValue(query url.Values, key string, dft time.Duration) (time.Duration, error) {
+	values, ok := query[key]
+	if !ok {
+		return dft, nil
+	}
+	if len(values) < 1 {
+		return 0, errors.Errorf("no value for key '%s'", key)
+	}
+	v, err := time.ParseDuration(strings.TrimSpace(values[0]))
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid value for key '%s'", key)
+	}
+	if v < 0 {
+		return 0, errors.Errorf("negative value for key '%s'", key)
+	}
+	return v, nil
+}
+
 func extractFirstIntValue(query url.Values, key string, dft int) (int, error) {
 	values, ok := query[key]
 	if !ok {
@@ -106,3 +272,75 @@ func extractFirstIntValue(query url.Values, key string, dft int) (int, error) {
 	}
 	return int(v), nil
 }
+
+// NewAuditLogOptionsFromString parses options of the audit log in form of "path?max-size=100&backups=5", where
+// 'max-size' is the size of the file in megabytes and 'backups' is the number of rotated files to keep.
+// The "syslog" string sends the log to the local syslog daemon.
+func NewAuditLogOptionsFromString(s string) (*AuditLogOptions, error) {
+	path, options, _ := strings.Cut(strings.TrimSpace(s), "?")
+	if path == "" {
+		return nil, errors.New("invalid audit log options: empty path")
+	}
+	if path == auditLogSyslog {
+		if options != "" {
+			return nil, errors.New("invalid audit log options: syslog doesn't have options")
+		}
+		return &AuditLogOptions{Path: path}, nil
+	}
+	query, err := url.ParseQuery(options)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid audit log options")
+	}
+	size, err := extractFirstIntValue(query, maxSizeKey, DefaultAuditLogMaxSizeMB)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid audit log options")
+	}
+	if size <= 0 {
+		return nil, errors.Errorf("invalid audit log options: non-positive value for key '%s'", maxSizeKey)
+	}
+	backups, err := extractFirstIntValue(query, maxBackupsKey, DefaultAuditLogMaxBackups)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid audit log options")
+	}
+	if backups < 0 {
+		return nil, errors.Errorf("invalid audit log options: negative value for key '%s'", maxBackupsKey)
+	}
+	return &AuditLogOptions{Path: path, MaxSize: int64(size) << 20, MaxBackups: backups}, nil
+}
+
+// NewAPIKeyQuotasFromString parses quotas of API keys in form of semicolon separated list of
+// "name:key?rps=10&burst=20" entries, where 'rps' and 'burst' are optional URL query options.
+func NewAPIKeyQuotasFromString(s string) ([]APIKeyQuota, error) {
+	var r []APIKeyQuota
+	names := make(map[string]struct{})
+	for _, entry := range strings.Split(s, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		nameAndKey, options, _ := strings.Cut(entry, "?")
+		name, key, ok := strings.Cut(nameAndKey, ":")
+		name, key = strings.TrimSpace(name), strings.TrimSpace(key)
+		if !ok || name == "" || key == "" {
+			return nil, errors.Errorf("invalid API key quota '%s': expected 'name:key' pair", entry)
+		}
+		if _, ok := names[name]; ok {
+			return nil, errors.Errorf("duplicate API key name '%s'", name)
+		}
+		names[name] = struct{}{}
+		query, err := url.ParseQuery(options)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid options of API key '%s'", name)
+		}
+		rps, err := extractFirstIntValue(query, rpsKey, DefaultRateLimiterRPS)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid options of API key '%s'", name)
+		}
+		burst, err := extractFirstIntValue(query, burstKey, DefaultRateLimiterBurst)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid options of API key '%s'", name)
+		}
+		r = append(r, APIKeyQuota{Name: name, Key: key, MaxRequestsPerSecond: rps, MaxBurst: burst})
+	}
+	return r, nil
+}
@@ -1,7 +1,9 @@
 package api
 
 import (
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -36,3 +38,113 @@ func TestRateLimiterOptions(t *testing.T) {
 		}
 	}
 }
+
+func TestAPIKeyQuotas(t *testing.T) {
+	for _, test := range []struct {
+		s      string
+		quotas []APIKeyQuota
+		err    string
+	}{
+		{"", nil, ""},
+		{"partner:secret", []APIKeyQuota{{"partner", "secret", DefaultRateLimiterRPS, DefaultRateLimiterBurst}}, ""},
+		{" a:k1?rps=10&burst=20 ; b:k2?rps=5 ;", []APIKeyQuota{
+			{"a", "k1", 10, 20},
+			{"b", "k2", 5, DefaultRateLimiterBurst},
+		}, ""},
+		{"partner", nil, "invalid API key quota 'partner': expected 'name:key' pair"},
+		{":secret", nil, "invalid API key quota ':secret': expected 'name:key' pair"},
+		{"a:k1;a:k2", nil, "duplicate API key name 'a'"},
+		{"a:k1?rps=x", nil, "invalid options of API key 'a': invalid value for key 'rps': strconv.ParseInt: parsing \"x\": invalid syntax"},
+	} {
+		quotas, err := NewAPIKeyQuotasFromString(test.s)
+		if test.err != "" {
+			assert.EqualError(t, err, test.err)
+			continue
+		}
+		require.NoError(t, err)
+		assert.Equal(t, test.quotas, quotas)
+	}
+}
+
+func TestServerOptions(t *testing.T) {
+	for _, test := range []struct {
+		s    string
+		opts *ServerOptions
+		err  string
+	}{
+		{"", DefaultServerOptions(), ""},
+		{"read=10s&write=1m&idle=2m&max-header-bytes=4096&handler=20s", &ServerOptions{
+			ReadHeaderTimeout: DefaultReadHeaderTimeout,
+			ReadTimeout:       10 * time.Second,
+			WriteTimeout:      time.Minute,
+			IdleTimeout:       2 * time.Minute,
+			MaxHeaderBytes:    4096,
+			HandlerTimeout:    20 * time.Second,
+		}, ""},
+		{"read-header=0&read=0", &ServerOptions{MaxHeaderBytes: http.DefaultMaxHeaderBytes}, ""},
+		{"write=x", nil, "invalid server options: invalid value for key 'write': time: invalid duration \"x\""},
+		{"idle=-1s", nil, "invalid server options: negative value for key 'idle'"},
+		{"max-header-bytes=0", nil, "invalid server options: non-positive value for key 'max-header-bytes'"},
+	} {
+		opts, err := NewServerOptionsFromString(test.s)
+		if test.err != "" {
+			assert.EqualError(t, err, test.err)
+			continue
+		}
+		require.NoError(t, err)
+		assert.Equal(t, test.opts, opts)
+	}
+}
+
+func TestRouteTimeouts(t *testing.T) {
+	for _, test := range []struct {
+		s        string
+		timeouts []RouteTimeout
+		err      string
+	}{
+		{"", nil, ""},
+		{" /blocks/headers/seq=10s ; /node/backup=0 ;", []RouteTimeout{
+			{"/blocks/headers/seq", 10 * time.Second},
+			{"/node/backup", 0},
+		}, ""},
+		{"blocks=1s", nil, "invalid route timeout 'blocks=1s': expected 'prefix=duration' pair"},
+		{"/blocks", nil, "invalid route timeout '/blocks': expected 'prefix=duration' pair"},
+		{"/blocks=-1s", nil, "invalid timeout of route '/blocks'"},
+	} {
+		timeouts, err := NewRouteTimeoutsFromString(test.s)
+		if test.err != "" {
+			assert.EqualError(t, err, test.err)
+			continue
+		}
+		require.NoError(t, err)
+		assert.Equal(t, test.timeouts, timeouts)
+	}
+}
+
+func TestAuditLogOptions(t *testing.T) {
+	for _, test := range []struct {
+		s    string
+		opts *AuditLogOptions
+		err  string
+	}{
+		{"/var/log/audit.log", &AuditLogOptions{"/var/log/audit.log", DefaultAuditLogMaxSizeMB << 20,
+			DefaultAuditLogMaxBackups}, ""},
+		{" audit.log?max-size=10&backups=0 ", &AuditLogOptions{"audit.log", 10 << 20, 0}, ""},
+		{"syslog", &AuditLogOptions{Path: "syslog"}, ""},
+		{"", nil, "invalid audit log options: empty path"},
+		{"?max-size=10", nil, "invalid audit log options: empty path"},
+		{"syslog?backups=1", nil, "invalid audit log options: syslog doesn't have options"},
+		{"audit.log?max-size=0", nil, "invalid audit log options: non-positive value for key 'max-size'"},
+		{"audit.log?backups=-1", nil, "invalid audit log options: negative value for key 'backups'"},
+		{"audit.log?backups=x", nil, "invalid audit log options: invalid value for key 'backups': " +
+			"strconv.ParseInt: parsing \"x\": invalid syntax"},
+	} {
+		opts, err := NewAuditLogOptionsFromString(test.s)
+		if test.err != "" {
+			assert.EqualError(t, err, test.err)
+		} else {
+			require.NoError(t, err)
+			assert.Equal(t, test.opts, opts)
+		}
+	}
+}
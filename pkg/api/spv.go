@@ -0,0 +1,52 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	apiErrs "github.com/wavesplatform/gowaves/pkg/api/errors"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/spv"
+)
+
+// TransactionSPVProof returns the SPV proof of the transaction with the headers of blocks up to the given height.
+// If the height is zero the headers up to the current height are included.
+func (a *App) TransactionSPVProof(id crypto.Digest, to proto.Height) (*spv.Proof, error) {
+	height, err := a.state.TransactionHeightByID(id.Bytes())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get height of transaction %q", id.String())
+	}
+	if to == 0 {
+		to, err = a.state.Height()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get current height")
+		}
+	}
+	if to < height {
+		return nil, apiErrs.NewCustomValidationError(
+			fmt.Sprintf("height %d is below the height %d of the transaction", to, height))
+	}
+	if to-height+1 > spv.MaxHeaders {
+		return nil, apiErrs.NewCustomValidationError(
+			fmt.Sprintf("too many headers requested, maximum is %d", spv.MaxHeaders))
+	}
+	block, err := a.state.BlockByHeight(height)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get block at height %d", height)
+	}
+	next := make([]*proto.BlockHeader, 0, to-height)
+	for h := height + 1; h <= to; h++ {
+		header, hErr := a.state.HeaderByHeight(h)
+		if hErr != nil {
+			return nil, errors.Wrapf(hErr, "failed to get header at height %d", h)
+		}
+		next = append(next, header)
+	}
+	proof, err := spv.NewProof(a.services.Scheme, block, height, id, next)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create SPV proof of transaction %q", id.String())
+	}
+	return proof, nil
+}
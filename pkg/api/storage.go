@@ -0,0 +1,25 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/keyvalue"
+)
+
+// StorageStats returns the statistics of the state database.
+func (a *App) StorageStats() (*keyvalue.Stats, error) {
+	return a.state.StorageStats()
+}
+
+func (a *NodeApi) debugStorage(w http.ResponseWriter, _ *http.Request) error {
+	st, err := a.app.StorageStats()
+	if err != nil {
+		return errors.Wrap(err, "debugStorage")
+	}
+	if err = trySendJson(w, st); err != nil {
+		return errors.Wrap(err, "debugStorage")
+	}
+	return nil
+}
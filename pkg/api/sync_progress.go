@@ -0,0 +1,88 @@
+package api
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+const (
+	syncProgressInterval = time.Second
+	syncProgressWindow   = time.Minute
+)
+
+type heightSample struct {
+	at     time.Time
+	height proto.Height
+}
+
+// syncProgress tracks the blockchain height to estimate the speed of synchronization and the time of the last
+// block arrival.
+type syncProgress struct {
+	mu          sync.Mutex
+	samples     []heightSample // ordered by time, covers the last syncProgressWindow
+	lastBlockAt time.Time
+}
+
+func newSyncProgress() *syncProgress {
+	return &syncProgress{}
+}
+
+func (p *syncProgress) run(ctx context.Context, height func() (proto.Height, error)) {
+	ticker := time.NewTicker(syncProgressInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			h, err := height()
+			if err != nil {
+				zap.S().Debugf("Failed to get height for sync progress: %v", err)
+				continue
+			}
+			p.observe(now, h)
+		}
+	}
+}
+
+func (p *syncProgress) observe(now time.Time, height proto.Height) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if n := len(p.samples); n == 0 || p.samples[n-1].height != height {
+		p.lastBlockAt = now
+	}
+	p.samples = append(p.samples, heightSample{at: now, height: height})
+	i := 0
+	for i < len(p.samples)-1 && now.Sub(p.samples[i].at) > syncProgressWindow {
+		i++
+	}
+	p.samples = p.samples[i:]
+}
+
+// rate returns the number of blocks applied per second during the last syncProgressWindow.
+func (p *syncProgress) rate() float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	n := len(p.samples)
+	if n < 2 {
+		return 0
+	}
+	first, last := p.samples[0], p.samples[n-1]
+	elapsed := last.at.Sub(first.at).Seconds()
+	if elapsed <= 0 || last.height <= first.height {
+		return 0
+	}
+	return float64(last.height-first.height) / elapsed
+}
+
+// lastBlockTime returns the time the current height was first observed, zero time if nothing was observed yet.
+func (p *syncProgress) lastBlockTime() time.Time {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.lastBlockAt
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/mock"
+	"github.com/wavesplatform/gowaves/pkg/p2p/peer"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/services"
+)
+
+func TestSyncProgress(t *testing.T) {
+	p := newSyncProgress()
+	assert.Zero(t, p.rate())
+	assert.True(t, p.lastBlockTime().IsZero())
+
+	start := time.Unix(1_700_000_000, 0)
+	p.observe(start, 100)
+	assert.Zero(t, p.rate())
+	assert.Equal(t, start, p.lastBlockTime())
+
+	p.observe(start.Add(10*time.Second), 120)
+	assert.InDelta(t, 2.0, p.rate(), 1e-9)
+	assert.Equal(t, start.Add(10*time.Second), p.lastBlockTime())
+
+	// Height didn't change, last block time stays the same.
+	p.observe(start.Add(20*time.Second), 120)
+	assert.InDelta(t, 1.0, p.rate(), 1e-9)
+	assert.Equal(t, start.Add(10*time.Second), p.lastBlockTime())
+
+	// Samples older than the window are dropped.
+	p.observe(start.Add(90*time.Second), 120)
+	assert.Zero(t, p.rate())
+	assert.Equal(t, start.Add(10*time.Second), p.lastBlockTime())
+}
+
+func TestApp_syncStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	st := mock.NewMockState(ctrl)
+	pm := mock.NewMockPeerManager(ctrl)
+	app, err := NewApp("api-key", nil, services.Services{State: st, Peers: pm, Scheme: proto.TestNetScheme})
+	require.NoError(t, err)
+
+	pm.EXPECT().EachConnected(gomock.Any()).Return()
+
+	st.EXPECT().CurrentScore().Return(big.NewInt(1000), nil)
+	ss, err := app.syncStatus(10)
+	require.NoError(t, err)
+	assert.True(t, ss.Synced)
+	assert.Zero(t, ss.BlocksBehind)
+	assert.Nil(t, ss.EstimatedSyncSeconds)
+
+	pm.EXPECT().EachConnected(gomock.Any()).Do(func(fn func(peer.Peer, *proto.Score)) {
+		fn(mock.NewMockPeer(ctrl), big.NewInt(1250))
+		fn(mock.NewMockPeer(ctrl), big.NewInt(1100))
+	})
+	st.EXPECT().CurrentScore().Return(big.NewInt(1000), nil)
+	st.EXPECT().ScoreAtHeight(proto.Height(10)).Return(big.NewInt(1000), nil)
+	st.EXPECT().ScoreAtHeight(proto.Height(9)).Return(big.NewInt(900), nil)
+	start := time.Unix(1_700_000_000, 0)
+	app.progress.observe(start, 5)
+	app.progress.observe(start.Add(5*time.Second), 10)
+	ss, err = app.syncStatus(10)
+	require.NoError(t, err)
+	assert.False(t, ss.Synced)
+	assert.Equal(t, uint64(3), ss.BlocksBehind)
+	assert.Equal(t, big.NewInt(1250), ss.NetworkScore)
+	require.NotNil(t, ss.EstimatedSyncSeconds)
+	assert.Equal(t, uint64(3), *ss.EstimatedSyncSeconds)
+	assert.Equal(t, start.Add(5*time.Second).UnixMilli(), ss.LastBlockReceivedTimestamp)
+}
+
+type testClock struct {
+	offset   time.Duration
+	measured time.Time
+}
+
+func (c testClock) Now() time.Time {
+	return time.Now().Add(c.offset)
+}
+
+func (c testClock) ClockOffset() (time.Duration, time.Time) {
+	return c.offset, c.measured
+}
+
+func TestApp_clockStatus(t *testing.T) {
+	status := func(clock testClock) *clockStatus {
+		app, err := NewApp("", nil, services.Services{Time: clock})
+		require.NoError(t, err)
+		app.SetMaxClockDrift(time.Second)
+		return app.clockStatus()
+	}
+	assert.Nil(t, status(testClock{offset: time.Minute})) // never measured
+
+	now := time.Now()
+	cs := status(testClock{offset: -200 * time.Millisecond, measured: now})
+	require.NotNil(t, cs)
+	assert.EqualValues(t, -200, cs.Offset)
+	assert.Equal(t, now.UnixMilli(), cs.MeasuredTimestamp)
+	assert.Empty(t, cs.Warning)
+
+	cs = status(testClock{offset: -2 * time.Second, measured: now})
+	assert.Contains(t, cs.Warning, "mining is not allowed")
+	cs = status(testClock{measured: now.Add(-time.Hour)})
+	assert.Contains(t, cs.Warning, "not measured since")
+}
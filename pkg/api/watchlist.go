@@ -0,0 +1,100 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/pkg/errors"
+
+	apiErrs "github.com/wavesplatform/gowaves/pkg/api/errors"
+	"github.com/wavesplatform/gowaves/pkg/node/watchlist"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+var errWatchlistDisabled = apiErrs.NewCustomValidationError("watch list is disabled")
+
+// watchlistAddresses returns the watched addresses.
+func (a *NodeApi) watchlistAddresses(w http.ResponseWriter, _ *http.Request) error {
+	wl := a.app.services.Watchlist
+	if wl == nil {
+		return errWatchlistDisabled
+	}
+	if err := trySendJson(w, wl.Addresses()); err != nil {
+		return errors.Wrap(err, "watchlistAddresses")
+	}
+	return nil
+}
+
+// watchlistAdd puts the addresses to the watch list and returns all watched addresses.
+func (a *NodeApi) watchlistAdd(w http.ResponseWriter, r *http.Request) error {
+	wl := a.app.services.Watchlist
+	if wl == nil {
+		return errWatchlistDisabled
+	}
+	var req struct {
+		Addresses []string `json:"addresses"`
+	}
+	if err := tryParseJson(r.Body, &req); err != nil {
+		return apiErrs.NewCustomValidationError(err.Error())
+	}
+	if len(req.Addresses) == 0 {
+		return apiErrs.NewCustomValidationError("addresses are not specified")
+	}
+	addresses := make([]proto.WavesAddress, len(req.Addresses))
+	for i, s := range req.Addresses {
+		addr, err := proto.NewAddressFromString(s)
+		if err != nil {
+			return apiErrs.InvalidAddress
+		}
+		addresses[i] = addr
+	}
+	if err := wl.Add(addresses...); err != nil {
+		return apiErrs.NewCustomValidationError(err.Error())
+	}
+	if err := trySendJson(w, wl.Addresses()); err != nil {
+		return errors.Wrap(err, "watchlistAdd")
+	}
+	return nil
+}
+
+// watchlistRemove deletes the address from the watch list.
+func (a *NodeApi) watchlistRemove(w http.ResponseWriter, r *http.Request) error {
+	wl := a.app.services.Watchlist
+	if wl == nil {
+		return errWatchlistDisabled
+	}
+	addr, err := proto.NewAddressFromString(chi.URLParam(r, "address"))
+	if err != nil {
+		return apiErrs.InvalidAddress
+	}
+	if rErr := wl.Remove(addr); rErr != nil {
+		if errors.Is(rErr, watchlist.ErrNotWatched) {
+			return apiErrs.NewCustomValidationError(rErr.Error())
+		}
+		return errors.Wrapf(rErr, "failed to remove address %q from watch list", addr.String())
+	}
+	if sErr := trySendJson(w, wl.Addresses()); sErr != nil {
+		return errors.Wrap(sErr, "watchlistRemove")
+	}
+	return nil
+}
+
+// watchlistTransactions returns the indexed transactions of the watched address, the most recent go first.
+func (a *NodeApi) watchlistTransactions(w http.ResponseWriter, r *http.Request) error {
+	wl := a.app.services.Watchlist
+	if wl == nil {
+		return errWatchlistDisabled
+	}
+	addr, err := proto.NewAddressFromString(chi.URLParam(r, "address"))
+	if err != nil {
+		return apiErrs.InvalidAddress
+	}
+	matches, err := wl.Transactions(addr)
+	if err != nil {
+		return apiErrs.NewCustomValidationError(err.Error())
+	}
+	if sErr := trySendJson(w, matches); sErr != nil {
+		return errors.Wrap(sErr, "watchlistTransactions")
+	}
+	return nil
+}
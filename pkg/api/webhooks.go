@@ -0,0 +1,67 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/pkg/errors"
+
+	apiErrs "github.com/wavesplatform/gowaves/pkg/api/errors"
+	"github.com/wavesplatform/gowaves/pkg/node/webhooks"
+)
+
+var errWebhooksDisabled = apiErrs.NewCustomValidationError("webhooks are disabled")
+
+// webhooks returns the registered webhooks, secrets are not returned.
+func (a *NodeApi) webhooks(w http.ResponseWriter, _ *http.Request) error {
+	m := a.app.services.Webhooks
+	if m == nil {
+		return errWebhooksDisabled
+	}
+	if err := trySendJson(w, m.List()); err != nil {
+		return errors.Wrap(err, "webhooks")
+	}
+	return nil
+}
+
+// addWebhook registers the webhook and returns it with the assigned ID.
+func (a *NodeApi) addWebhook(w http.ResponseWriter, r *http.Request) error {
+	m := a.app.services.Webhooks
+	if m == nil {
+		return errWebhooksDisabled
+	}
+	var hook webhooks.Webhook
+	if err := tryParseJson(r.Body, &hook); err != nil {
+		return apiErrs.NewCustomValidationError(err.Error())
+	}
+	added, err := m.Add(hook)
+	if err != nil {
+		return apiErrs.NewCustomValidationError(err.Error())
+	}
+	added.Secret = ""
+	if err := trySendJson(w, added); err != nil {
+		return errors.Wrap(err, "addWebhook")
+	}
+	return nil
+}
+
+// removeWebhook unregisters the webhook.
+func (a *NodeApi) removeWebhook(w http.ResponseWriter, r *http.Request) error {
+	m := a.app.services.Webhooks
+	if m == nil {
+		return errWebhooksDisabled
+	}
+	id := chi.URLParam(r, "id")
+	if err := m.Remove(id); err != nil {
+		if errors.Is(err, webhooks.ErrNotFound) {
+			return apiErrs.NewCustomValidationError(err.Error())
+		}
+		return errors.Wrapf(err, "failed to remove webhook %q", id)
+	}
+	if err := trySendJson(w, struct {
+		ID string `json:"id"`
+	}{ID: id}); err != nil {
+		return errors.Wrap(err, "removeWebhook")
+	}
+	return nil
+}
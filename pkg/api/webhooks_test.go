@@ -0,0 +1,57 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apiErrs "github.com/wavesplatform/gowaves/pkg/api/errors"
+	"github.com/wavesplatform/gowaves/pkg/node/webhooks"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/services"
+)
+
+func TestNodeApi_Webhooks(t *testing.T) {
+	m, err := webhooks.NewManager("", nil, proto.TestNetScheme)
+	require.NoError(t, err)
+	app, err := NewApp("api-key", nil, services.Services{Scheme: proto.TestNetScheme, Webhooks: m})
+	require.NoError(t, err)
+	a := NewNodeAPI(app, nil)
+
+	body := `{"url":"https://example.com/hook","events":["block_applied"],"secret":"s"}`
+	resp := httptest.NewRecorder()
+	require.NoError(t, a.addWebhook(resp, httptest.NewRequest(http.MethodPost, "/go/webhooks", strings.NewReader(body))))
+	var added webhooks.Webhook
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &added))
+	assert.NotEmpty(t, added.ID)
+	assert.Empty(t, added.Secret)
+
+	err = a.addWebhook(httptest.NewRecorder(),
+		httptest.NewRequest(http.MethodPost, "/go/webhooks", strings.NewReader(`{"url":"https://example.com"}`)))
+	assert.ErrorAs(t, err, new(*apiErrs.CustomValidationError))
+
+	resp = httptest.NewRecorder()
+	require.NoError(t, a.webhooks(resp, httptest.NewRequest(http.MethodGet, "/go/webhooks", nil)))
+	var list []webhooks.Webhook
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &list))
+	require.Len(t, list, 1)
+	assert.Equal(t, added.ID, list[0].ID)
+
+	newDelete := func(id string) *http.Request {
+		req := httptest.NewRequest(http.MethodDelete, "/go/webhooks/"+id, nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", id)
+		return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	}
+	require.NoError(t, a.removeWebhook(httptest.NewRecorder(), newDelete(added.ID)))
+	assert.Empty(t, m.List())
+	err = a.removeWebhook(httptest.NewRecorder(), newDelete(added.ID))
+	assert.ErrorAs(t, err, new(*apiErrs.CustomValidationError))
+}
@@ -112,6 +112,45 @@ func (a *Addresses) ScriptInfo(ctx context.Context, address proto.WavesAddress)
 	return out, response, nil
 }
 
+// AddressesScriptMeta is the metadata of account's dApp script.
+type AddressesScriptMeta struct {
+	Address proto.WavesAddress `json:"address"`
+	Meta    ScriptMeta         `json:"meta"`
+}
+
+// ScriptMeta describes callable functions of dApp script, the map is empty for accounts without dApp.
+type ScriptMeta struct {
+	Version           string                          `json:"version"`
+	CallableFuncTypes map[string][]ScriptMetaArgument `json:"callableFuncTypes"`
+}
+
+// ScriptMetaArgument is an argument of dApp callable function.
+type ScriptMetaArgument struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// ScriptMeta gets metadata of account's dApp script
+func (a *Addresses) ScriptMeta(ctx context.Context, address proto.WavesAddress) (*AddressesScriptMeta, *Response, error) {
+	u, err := joinUrl(a.options.BaseUrl, fmt.Sprintf("/addresses/scriptInfo/%s/meta", address.String()))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	out := new(AddressesScriptMeta)
+	response, err := doHttp(ctx, a.options, req, out)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return out, response, nil
+}
+
 // Addresses gets wallet accounts addresses
 func (a *Addresses) Addresses(ctx context.Context) ([]proto.WavesAddress, *Response, error) {
 	u, err := joinUrl(a.options.BaseUrl, "/addresses")
@@ -76,6 +76,42 @@ func TestAddresses_ScriptInfo(t *testing.T) {
 		resp.Request.URL.String())
 }
 
+var addressesScriptMetaJson = `
+{
+	"address": "3NBVqYXrapgJP9atQccdBPAgJPwHDKkh6A8",
+	"meta": {
+		"version": "2",
+		"callableFuncTypes": {
+			"deposit": [],
+			"withdraw": [{"name": "amount", "type": "Int"}]
+		}
+	}
+}`
+
+func TestAddresses_ScriptMeta(t *testing.T) {
+	address, _ := proto.NewAddressFromString("3NBVqYXrapgJP9atQccdBPAgJPwHDKkh6A8")
+	client, err := NewClient(Options{
+		BaseUrl: "https://testnode1.wavesnodes.com/",
+		Client:  NewMockHttpRequestFromString(addressesScriptMetaJson, 200),
+	})
+	require.NoError(t, err)
+	body, resp, err := client.Addresses.ScriptMeta(context.Background(), address)
+	require.NoError(t, err)
+	assert.Equal(t, &AddressesScriptMeta{
+		Address: address,
+		Meta: ScriptMeta{
+			Version: "2",
+			CallableFuncTypes: map[string][]ScriptMetaArgument{
+				"deposit":  {},
+				"withdraw": {{Name: "amount", Type: "Int"}},
+			},
+		},
+	}, body)
+	assert.Equal(t,
+		"https://testnode1.wavesnodes.com/addresses/scriptInfo/3NBVqYXrapgJP9atQccdBPAgJPwHDKkh6A8/meta",
+		resp.Request.URL.String())
+}
+
 var addressesAddressesJson = `
 [
   "3MzemqBzJ9h844PparHU1EzGC5SQmtH5pNp"
@@ -3,9 +3,11 @@ package client
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/url"
+
 	"github.com/wavesplatform/gowaves/pkg/crypto"
 	"github.com/wavesplatform/gowaves/pkg/proto"
-	"net/http"
 )
 
 type Assets struct {
@@ -93,6 +95,15 @@ type AssetsDetail struct {
 	Reissuable           bool               `json:"reissuable"`
 	Quantity             uint64             `json:"quantity"`
 	MinSponsoredAssetFee uint64             `json:"minSponsoredAssetFee"`
+	Scripted             bool               `json:"scripted"`
+	// ScriptDetails is returned only for scripted assets and only if full details were requested.
+	ScriptDetails *AssetsScriptDetails `json:"scriptDetails,omitempty"`
+}
+
+type AssetsScriptDetails struct {
+	ScriptComplexity uint64 `json:"scriptComplexity"`
+	Script           string `json:"script"`
+	ScriptText       string `json:"scriptText"`
 }
 
 // Details provides detailed information about given asset.
@@ -116,6 +127,57 @@ func (a *Assets) Details(ctx context.Context, assetId crypto.Digest) (*AssetsDet
 	return out, response, nil
 }
 
+// AssetsDetailsOptions holds query parameters of the assets details request.
+type AssetsDetailsOptions struct {
+	// Full requests additional information about asset scripts.
+	Full bool
+}
+
+// DetailsByIDs provides detailed information about several assets in one request.
+func (a *Assets) DetailsByIDs(ctx context.Context, ids []crypto.Digest, opts AssetsDetailsOptions) ([]*AssetsDetail, *Response, error) {
+	v := url.Values{}
+	for _, id := range ids {
+		v.Add("id", id.String())
+	}
+	if opts.Full {
+		v.Set("full", "true")
+	}
+
+	u, err := joinUrl(a.options.BaseUrl, "/assets/details")
+	if err != nil {
+		return nil, nil, err
+	}
+	u.RawQuery = v.Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var out []*AssetsDetail
+	response, err := doHttp(ctx, a.options, req, &out)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return out, response, nil
+}
+
+// AssetsDistributionOptions holds query parameters of the asset distribution request.
+type AssetsDistributionOptions struct {
+	// Limit is the maximum number of items on the page.
+	Limit uint64
+	// After is the last address of the previous page, nil for the first page.
+	After *proto.WavesAddress
+}
+
+// DistributionAtHeightWithOptions is the same as DistributionAtHeight, but takes query parameters as options.
+func (a *Assets) DistributionAtHeightWithOptions(
+	ctx context.Context, assetId crypto.Digest, height uint64, opts AssetsDistributionOptions,
+) (*AssetsDistributionAtHeight, *Response, error) {
+	return a.DistributionAtHeight(ctx, assetId, height, opts.Limit, opts.After)
+}
+
 type AssetsDistributionAtHeight struct {
 	HasNext  bool                          `json:"hasNext"`
 	LastItem proto.WavesAddress            `json:"lastItem"`
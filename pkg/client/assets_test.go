@@ -122,6 +122,24 @@ func TestAssets_Details(t *testing.T) {
 	assert.Equal(t, "https://testnode1.wavesnodes.com/assets/details/CMBHKDtyE8GMbZAZANNeE5n2HU4VDpsQaBLmfCw9ASbf", resp.Request.URL.String())
 }
 
+func TestAssets_DetailsByIDs(t *testing.T) {
+	assetId, _ := crypto.NewDigestFromBase58("CMBHKDtyE8GMbZAZANNeE5n2HU4VDpsQaBLmfCw9ASbf")
+	client, err := NewClient(Options{
+		Client:  NewMockHttpRequestFromString("["+assertDetailsJson+"]", 200),
+		BaseUrl: "https://testnode1.wavesnodes.com",
+	})
+	require.NoError(t, err)
+	body, resp, err := client.Assets.DetailsByIDs(context.Background(), []crypto.Digest{assetId},
+		AssetsDetailsOptions{Full: true})
+	require.NoError(t, err)
+	require.Len(t, body, 1)
+	assert.Equal(t, assetId, body[0].AssetId)
+	assert.Nil(t, body[0].ScriptDetails)
+	assert.Equal(t,
+		"https://testnode1.wavesnodes.com/assets/details?full=true&id=CMBHKDtyE8GMbZAZANNeE5n2HU4VDpsQaBLmfCw9ASbf",
+		resp.Request.URL.String())
+}
+
 var assetsDistributionJson = `
 {
   "3NBVqYXrapgJP9atQccdBPAgJPwHDKkh6A8": 1906756655
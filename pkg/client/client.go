@@ -26,6 +26,8 @@ type Options struct {
 	ChainID proto.Scheme
 	Client  Doer
 	ApiKey  string
+	// Retry enables retries of failed requests, nil value disables them.
+	Retry *RetryPolicy
 }
 
 var defaultOptions = Options{
@@ -35,7 +37,10 @@ var defaultOptions = Options{
 }
 
 type Client struct {
-	options      Options
+	options Options
+	// chainIDSet is true if ChainID is set in options, otherwise it's the default one and could differ from
+	// the scheme of the node.
+	chainIDSet   bool
 	Addresses    *Addresses
 	Blocks       *Blocks
 	Wallet       *Wallet
@@ -65,6 +70,7 @@ func NewClient(options ...Options) (*Client, error) {
 	}
 
 	opts := defaultOptions
+	chainIDSet := false
 
 	if len(options) == 1 {
 		option := options[0]
@@ -79,11 +85,16 @@ func NewClient(options ...Options) (*Client, error) {
 		}
 		if option.ChainID != 0 {
 			opts.ChainID = option.ChainID
+			chainIDSet = true
+		}
+		if option.Retry != nil {
+			opts.Retry = option.Retry
 		}
 	}
 
 	c := &Client{
 		options:      opts,
+		chainIDSet:   chainIDSet,
 		Addresses:    NewAddresses(opts),
 		Blocks:       NewBlocks(opts),
 		Wallet:       NewWallet(opts),
@@ -126,7 +137,7 @@ func doHttp(ctx context.Context, options Options, req *http.Request, v interface
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := options.Client.Do(req)
+	resp, err := doWithRetries(ctx, options, req)
 	if err != nil {
 		return nil, newRequestError(err, "")
 	}
@@ -138,9 +149,10 @@ func doHttp(ctx context.Context, options Options, req *http.Request, v interface
 
 	if response.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(response.Body)
-		return response, newRequestError(
+		return response, newResponseError(
 			errors.Errorf("Invalid status code: expect 200 got %d", response.StatusCode),
-			string(body),
+			response.StatusCode,
+			body,
 		)
 	}
 
@@ -15,6 +15,13 @@ Client can accept custom node url, http client, and api key:
 	})
 	...
 
+Failed requests could be retried with backoff, see RetryPolicy:
+
+	c, err := client.NewClient(client.Options{
+		Retry: client.DefaultRetryPolicy(),
+	})
+	...
+
 Simple example of client usage:
 
 	c, err := client.NewClient()
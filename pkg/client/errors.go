@@ -1,22 +1,134 @@
 package client
 
-import "github.com/pkg/errors"
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
 
 var NoApiKeyError = errors.New("no api key provided")
 
+// Error codes of node API, the same codes are used by Go and Scala nodes.
+const (
+	UnknownErrorCode                 = 0
+	WrongJSONErrorCode               = 1
+	APIKeyNotValidErrorCode          = 2
+	InvalidSignatureErrorCode        = 101
+	InvalidAddressErrorCode          = 102
+	InvalidPublicKeyErrorCode        = 108
+	InvalidMessageErrorCode          = 110
+	StateCheckFailedErrorCode        = 112
+	InvalidIDsErrorCode              = 116
+	CustomValidationErrorCode        = 199
+	BlockDoesNotExistErrorCode       = 301
+	AliasDoesNotExistErrorCode       = 302
+	MistimingErrorCode               = 303
+	DataKeyDoesNotExistErrorCode     = 304
+	ScriptExecutionErrorCode         = 306
+	TransactionDoesNotExistErrorCode = 311
+	AssetDoesNotExistErrorCode       = 313
+	AlreadyInStateErrorCode          = 400
+	AccountBalanceErrorCode          = 402
+	InvalidChainIDErrorCode          = 404
+	InvalidProofsErrorCode           = 405
+	InvalidTransactionIDErrorCode    = 4001
+	InvalidBlockIDErrorCode          = 4002
+	InvalidAssetIDErrorCode          = 4007
+)
+
+// Sentinel API errors which could be used to check the error returned by client with errors.Is.
+// Only the error code is compared, for example:
+//
+//	if errors.Is(err, client.ErrTransactionDoesNotExist) {
+//		...
+//	}
+var (
+	ErrWrongJSON               = &APIError{Code: WrongJSONErrorCode, Message: "failed to parse json message"}
+	ErrAPIKeyNotValid          = &APIError{Code: APIKeyNotValidErrorCode, Message: "provided API key is not correct"}
+	ErrInvalidSignature        = &APIError{Code: InvalidSignatureErrorCode, Message: "invalid signature"}
+	ErrInvalidAddress          = &APIError{Code: InvalidAddressErrorCode, Message: "invalid address"}
+	ErrInvalidPublicKey        = &APIError{Code: InvalidPublicKeyErrorCode, Message: "invalid public key"}
+	ErrStateCheckFailed        = &APIError{Code: StateCheckFailedErrorCode, Message: "state check failed"}
+	ErrCustomValidation        = &APIError{Code: CustomValidationErrorCode, Message: "validation error"}
+	ErrBlockDoesNotExist       = &APIError{Code: BlockDoesNotExistErrorCode, Message: "block does not exist"}
+	ErrAliasDoesNotExist       = &APIError{Code: AliasDoesNotExistErrorCode, Message: "alias does not exist"}
+	ErrMistiming               = &APIError{Code: MistimingErrorCode, Message: "timestamp is out of range"}
+	ErrDataKeyDoesNotExist     = &APIError{Code: DataKeyDoesNotExistErrorCode, Message: "no data for this key"}
+	ErrScriptExecution         = &APIError{Code: ScriptExecutionErrorCode, Message: "script execution error"}
+	ErrTransactionDoesNotExist = &APIError{Code: TransactionDoesNotExistErrorCode, Message: "transaction does not exist"}
+	ErrAssetDoesNotExist       = &APIError{Code: AssetDoesNotExistErrorCode, Message: "asset does not exist"}
+	ErrAlreadyInState          = &APIError{Code: AlreadyInStateErrorCode, Message: "transaction is already in the state"}
+	ErrAccountBalance          = &APIError{Code: AccountBalanceErrorCode, Message: "accounts balance errors"}
+	ErrInvalidChainID          = &APIError{Code: InvalidChainIDErrorCode, Message: "wrong chain-id"}
+	ErrInvalidProofs           = &APIError{Code: InvalidProofsErrorCode, Message: "invalid proofs"}
+	ErrInvalidTransactionID    = &APIError{Code: InvalidTransactionIDErrorCode, Message: "invalid transaction id"}
+	ErrInvalidBlockID          = &APIError{Code: InvalidBlockIDErrorCode, Message: "invalid block id"}
+	ErrInvalidAssetID          = &APIError{Code: InvalidAssetIDErrorCode, Message: "invalid asset id"}
+)
+
+// APIError is an error reported by node API in the response body as JSON object with error code and message.
+type APIError struct {
+	StatusCode int    `json:"-"`
+	Code       int    `json:"error"`
+	Message    string `json:"message"`
+}
+
+// parseAPIError tries to parse node API error from response body, returns nil if the body is not an API error.
+func parseAPIError(statusCode int, body []byte) *APIError {
+	var raw struct {
+		Code    *int   `json:"error"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil || raw.Code == nil {
+		return nil
+	}
+	return &APIError{StatusCode: statusCode, Code: *raw.Code, Message: raw.Message}
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("node API error %d: %s", e.Code, e.Message)
+}
+
+// Is reports whether the target is an APIError with the same code.
+func (e *APIError) Is(target error) bool {
+	t, ok := target.(*APIError)
+	return ok && t.Code == e.Code
+}
+
 type RequestError struct {
 	Err  error
 	Body string
+	// APIError is set if the response body contains node API error.
+	APIError *APIError
 }
 
 func newRequestError(err error, body string) *RequestError {
 	return &RequestError{Err: err, Body: body}
 }
 
+func newResponseError(err error, statusCode int, body []byte) *RequestError {
+	return &RequestError{Err: err, Body: string(body), APIError: parseAPIError(statusCode, body)}
+}
+
 func (e *RequestError) Unwrap() error {
 	return e.Err
 }
 
+// Is allows to compare the error with sentinel API errors, e.g. errors.Is(err, ErrTransactionDoesNotExist).
+func (e *RequestError) Is(target error) bool {
+	return e.APIError != nil && e.APIError.Is(target)
+}
+
+// As allows to extract APIError from the request error with errors.As.
+func (e *RequestError) As(target any) bool {
+	if t, ok := target.(**APIError); ok && e.APIError != nil {
+		*t = e.APIError
+		return true
+	}
+	return false
+}
+
 func (e *RequestError) Error() string {
 	if e.Body != "" {
 		return errors.Wrap(e.Err, e.Body).Error()
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParseError_Error(t *testing.T) {
@@ -24,3 +25,20 @@ func TestRequestError_Error(t *testing.T) {
 	assert.ErrorIs(t, err, inner)
 	assert.ErrorAs(t, err, new(*RequestError))
 }
+
+func TestRequestError_APIError(t *testing.T) {
+	body := []byte(`{"error": 311, "message": "transactions does not exist"}`)
+	var err error = newResponseError(errors.New("Invalid status code: expect 200 got 404"), 404, body)
+	assert.ErrorIs(t, err, ErrTransactionDoesNotExist)
+	assert.NotErrorIs(t, err, ErrInvalidSignature)
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, 404, apiErr.StatusCode)
+	assert.Equal(t, TransactionDoesNotExistErrorCode, apiErr.Code)
+	assert.Equal(t, "transactions does not exist", apiErr.Message)
+	assert.Contains(t, err.Error(), "Invalid status code")
+
+	err = newResponseError(errors.New("Invalid status code: expect 200 got 502"), 502, []byte("<html>Bad Gateway</html>"))
+	assert.NotErrorIs(t, err, ErrTransactionDoesNotExist)
+	assert.False(t, errors.As(err, &apiErr))
+}
@@ -0,0 +1,261 @@
+package client
+
+import (
+	"context"
+	"io"
+	"math"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	g "github.com/wavesplatform/gowaves/pkg/grpc/generated/waves/node/grpc"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+// GRPCClient is a high-level client of the node's gRPC API. It accepts and returns the same types as
+// the REST client (proto.Transaction, proto.Block, etc.), so it's possible to switch transport without
+// rewriting the code. Connection is created and owned by the caller:
+//
+//	conn, err := grpc.NewClient("localhost:6870", grpc.WithTransportCredentials(insecure.NewCredentials()))
+//	...
+//	c := client.NewGRPCClient(conn, proto.MainNetScheme)
+type GRPCClient struct {
+	scheme       proto.Scheme
+	blocks       g.BlocksApiClient
+	transactions g.TransactionsApiClient
+	accounts     g.AccountsApiClient
+}
+
+// GRPCTransactionInfo is a confirmed transaction with its height and application status.
+type GRPCTransactionInfo struct {
+	Transaction proto.Transaction
+	Height      proto.Height
+	Status      proto.TransactionStatus
+}
+
+// NewGRPCClient creates the client on top of the given connection. Scheme is used to convert
+// transactions and addresses which don't contain chain ID.
+func NewGRPCClient(conn grpc.ClientConnInterface, scheme proto.Scheme) *GRPCClient {
+	return &GRPCClient{
+		scheme:       scheme,
+		blocks:       g.NewBlocksApiClient(conn),
+		transactions: g.NewTransactionsApiClient(conn),
+		accounts:     g.NewAccountsApiClient(conn),
+	}
+}
+
+func (c *GRPCClient) converter() *proto.ProtobufConverter {
+	return &proto.ProtobufConverter{FallbackChainID: c.scheme}
+}
+
+func toInt32Height(height uint64) (int32, error) {
+	if height > math.MaxInt32 {
+		return 0, errors.Errorf("height %d is too big", height)
+	}
+	return int32(height), nil
+}
+
+// Height returns current blockchain height.
+func (c *GRPCClient) Height(ctx context.Context) (uint64, error) {
+	h, err := c.blocks.GetCurrentHeight(ctx, &emptypb.Empty{})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get current height")
+	}
+	return uint64(h.GetValue()), nil
+}
+
+// BlockAt returns block with transactions at the given height.
+func (c *GRPCClient) BlockAt(ctx context.Context, height uint64) (*proto.Block, error) {
+	h, err := toInt32Height(height)
+	if err != nil {
+		return nil, err
+	}
+	b, err := c.blocks.GetBlock(ctx, &g.BlockRequest{
+		Request:             &g.BlockRequest_Height{Height: h},
+		IncludeTransactions: true,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get block at height %d", height)
+	}
+	return c.block(b)
+}
+
+// BlockByID returns block with transactions and its height.
+func (c *GRPCClient) BlockByID(ctx context.Context, id proto.BlockID) (*proto.Block, proto.Height, error) {
+	b, err := c.blocks.GetBlock(ctx, &g.BlockRequest{
+		Request:             &g.BlockRequest_BlockId{BlockId: id.Bytes()},
+		IncludeTransactions: true,
+	})
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "failed to get block '%s'", id.String())
+	}
+	block, err := c.block(b)
+	if err != nil {
+		return nil, 0, err
+	}
+	return block, proto.Height(b.GetHeight()), nil
+}
+
+// BlockRange returns blocks with transactions from the given range of heights, both ends are included.
+func (c *GRPCClient) BlockRange(ctx context.Context, from, to uint64) ([]*proto.Block, error) {
+	if from > to {
+		return nil, errors.Errorf("invalid range of heights [%d, %d]", from, to)
+	}
+	if to > math.MaxUint32 {
+		return nil, errors.Errorf("height %d is too big", to)
+	}
+	stream, err := c.blocks.GetBlockRange(ctx, &g.BlockRangeRequest{
+		FromHeight:          uint32(from),
+		ToHeight:            uint32(to),
+		IncludeTransactions: true,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get blocks range [%d, %d]", from, to)
+	}
+	r := make([]*proto.Block, 0, to-from+1)
+	for {
+		b, rErr := stream.Recv()
+		if errors.Is(rErr, io.EOF) {
+			return r, nil
+		}
+		if rErr != nil {
+			return nil, errors.Wrapf(rErr, "failed to receive blocks range [%d, %d]", from, to)
+		}
+		block, cErr := c.block(b)
+		if cErr != nil {
+			return nil, cErr
+		}
+		r = append(r, block)
+	}
+}
+
+func (c *GRPCClient) block(b *g.BlockWithHeight) (*proto.Block, error) {
+	block, err := c.converter().Block(b.GetBlock())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to convert block at height %d", b.GetHeight())
+	}
+	return &block, nil
+}
+
+// TransactionInfo returns confirmed transaction by its ID.
+func (c *GRPCClient) TransactionInfo(ctx context.Context, id crypto.Digest) (*GRPCTransactionInfo, error) {
+	stream, err := c.transactions.GetTransactions(ctx, &g.TransactionsRequest{TransactionIds: [][]byte{id.Bytes()}})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get transaction '%s'", id.String())
+	}
+	res, err := stream.Recv()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.Errorf("transaction '%s' not found", id.String())
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to receive transaction '%s'", id.String())
+	}
+	tx, err := c.converter().SignedTransaction(res.GetTransaction())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to convert transaction '%s'", id.String())
+	}
+	return &GRPCTransactionInfo{
+		Transaction: tx,
+		Height:      proto.Height(res.GetHeight()),
+		Status:      transactionStatus(res.GetApplicationStatus()),
+	}, nil
+}
+
+func transactionStatus(s g.ApplicationStatus) proto.TransactionStatus {
+	switch s {
+	case g.ApplicationStatus_SUCCEEDED:
+		return proto.TransactionSucceeded
+	case g.ApplicationStatus_SCRIPT_EXECUTION_FAILED:
+		return proto.TransactionFailed
+	case g.ApplicationStatus_ELIDED:
+		return proto.TransactionElided
+	default:
+		return 0
+	}
+}
+
+// Unconfirmed returns all transactions from the node's UTX pool.
+func (c *GRPCClient) Unconfirmed(ctx context.Context) ([]proto.Transaction, error) {
+	stream, err := c.transactions.GetUnconfirmed(ctx, &g.TransactionsRequest{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get unconfirmed transactions")
+	}
+	var r []proto.Transaction
+	for {
+		res, rErr := stream.Recv()
+		if errors.Is(rErr, io.EOF) {
+			return r, nil
+		}
+		if rErr != nil {
+			return nil, errors.Wrap(rErr, "failed to receive unconfirmed transactions")
+		}
+		tx, cErr := c.converter().SignedTransaction(res.GetTransaction())
+		if cErr != nil {
+			return nil, errors.Wrap(cErr, "failed to convert unconfirmed transaction")
+		}
+		r = append(r, tx)
+	}
+}
+
+// Broadcast sends signed transaction to the node.
+func (c *GRPCClient) Broadcast(ctx context.Context, transaction proto.Transaction) error {
+	stx, err := transaction.ToProtobufSigned(c.scheme)
+	if err != nil {
+		return errors.Wrap(err, "failed to convert transaction to protobuf")
+	}
+	if _, err := c.transactions.Broadcast(ctx, stx); err != nil {
+		return errors.Wrap(err, "failed to broadcast transaction")
+	}
+	return nil
+}
+
+// BalanceDetails returns detailed Waves balance of the address.
+func (c *GRPCClient) BalanceDetails(ctx context.Context, address proto.WavesAddress) (*AddressesBalanceDetails, error) {
+	res, err := c.balance(ctx, address, nil)
+	if err != nil {
+		return nil, err
+	}
+	w := res.GetWaves() // nil-safe getters return zeros if there is no balance
+	return &AddressesBalanceDetails{
+		Address:    address,
+		Regular:    uint64(w.GetRegular()),
+		Generating: uint64(w.GetGenerating()),
+		Available:  uint64(w.GetAvailable()),
+		Effective:  uint64(w.GetEffective()),
+	}, nil
+}
+
+// AssetBalance returns balance of the asset on the address.
+func (c *GRPCClient) AssetBalance(
+	ctx context.Context, address proto.WavesAddress, assetID crypto.Digest,
+) (*AssetsBalanceAndAsset, error) {
+	res, err := c.balance(ctx, address, assetID.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return &AssetsBalanceAndAsset{
+		Address: address,
+		AssetId: assetID,
+		Balance: uint64(res.GetAsset().GetAmount()),
+	}, nil
+}
+
+// balance requests the balance of the single asset, nil asset means Waves.
+func (c *GRPCClient) balance(ctx context.Context, address proto.WavesAddress, asset []byte) (*g.BalanceResponse, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel() // closes the stream
+	stream, err := c.accounts.GetBalances(ctx, &g.BalancesRequest{Address: address.Bytes(), Assets: [][]byte{asset}})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get balance of address '%s'", address.String())
+	}
+	res, err := stream.Recv()
+	if errors.Is(err, io.EOF) {
+		return &g.BalanceResponse{}, nil // zero asset balances are not sent by the node
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to receive balance of address '%s'", address.String())
+	}
+	return res, nil
+}
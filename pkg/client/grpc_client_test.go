@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	g "github.com/wavesplatform/gowaves/pkg/grpc/generated/waves/node/grpc"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+)
+
+type fakeBlocksAPI struct {
+	g.BlocksApiClient // not implemented methods panic
+	height            uint32
+	block             *g.BlockWithHeight
+}
+
+func (f *fakeBlocksAPI) GetCurrentHeight(
+	context.Context, *emptypb.Empty, ...grpc.CallOption,
+) (*wrapperspb.UInt32Value, error) {
+	return &wrapperspb.UInt32Value{Value: f.height}, nil
+}
+
+func (f *fakeBlocksAPI) GetBlock(_ context.Context, req *g.BlockRequest, _ ...grpc.CallOption) (*g.BlockWithHeight, error) {
+	if req.GetHeight() != int32(f.block.GetHeight()) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return f.block, nil
+}
+
+func TestGRPCClient_Blocks(t *testing.T) {
+	sets := settings.MustMainNetSettings()
+	pb, err := sets.Genesis.ToProtobuf(sets.AddressSchemeCharacter)
+	require.NoError(t, err)
+	c := &GRPCClient{
+		scheme: sets.AddressSchemeCharacter,
+		blocks: &fakeBlocksAPI{height: 10, block: &g.BlockWithHeight{Block: pb, Height: 1}},
+	}
+
+	h, err := c.Height(context.Background())
+	require.NoError(t, err)
+	assert.EqualValues(t, 10, h)
+
+	b, err := c.BlockAt(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, sets.Genesis.BlockID(), b.BlockID())
+	assert.Len(t, b.Transactions, len(sets.Genesis.Transactions))
+
+	_, err = c.BlockAt(context.Background(), 2)
+	assert.Error(t, err)
+}
+
+func TestGRPCClient_TransactionStatus(t *testing.T) {
+	for _, test := range []struct {
+		status g.ApplicationStatus
+		exp    proto.TransactionStatus
+	}{
+		{g.ApplicationStatus_SUCCEEDED, proto.TransactionSucceeded},
+		{g.ApplicationStatus_SCRIPT_EXECUTION_FAILED, proto.TransactionFailed},
+		{g.ApplicationStatus_ELIDED, proto.TransactionElided},
+	} {
+		assert.Equal(t, test.exp, transactionStatus(test.status))
+	}
+}
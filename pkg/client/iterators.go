@@ -0,0 +1,142 @@
+package client
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+// TransactionsByAddressIterator walks through the transactions history of the address page by page,
+// from the latest transactions to the oldest ones, following the 'after' cursor.
+//
+//	it := client.NewTransactionsByAddressIterator(c.Transactions, addr, 100)
+//	for it.Next(ctx) {
+//		for _, tx := range it.Page() {
+//			...
+//		}
+//	}
+//	if err := it.Err(); err != nil {
+//		// handle error
+//	}
+type TransactionsByAddressIterator struct {
+	transactions *Transactions
+	address      proto.WavesAddress
+	limit        uint
+	after        *crypto.Digest
+	page         []proto.Transaction
+	done         bool
+	err          error
+}
+
+// NewTransactionsByAddressIterator creates iterator which requests pages of the given size.
+func NewTransactionsByAddressIterator(
+	transactions *Transactions, address proto.WavesAddress, pageSize uint,
+) *TransactionsByAddressIterator {
+	return &TransactionsByAddressIterator{transactions: transactions, address: address, limit: pageSize}
+}
+
+// Next requests the next page. It returns false if there are no more transactions or an error occurred.
+func (it *TransactionsByAddressIterator) Next(ctx context.Context) bool {
+	it.page = nil
+	if it.done || it.err != nil {
+		return false
+	}
+	if it.limit == 0 {
+		it.err = errors.New("page size must be positive")
+		return false
+	}
+	txs, _, err := it.transactions.AddressAfter(ctx, it.address, it.limit, it.after)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	if uint(len(txs)) < it.limit {
+		it.done = true
+	}
+	if len(txs) == 0 {
+		return false
+	}
+	id, err := txs[len(txs)-1].GetID(it.transactions.options.ChainID)
+	if err != nil {
+		it.err = errors.Wrap(err, "failed to get ID of the last transaction on page")
+		return false
+	}
+	after, err := crypto.NewDigestFromBytes(id)
+	if err != nil {
+		it.err = errors.Wrap(err, "invalid ID of the last transaction on page")
+		return false
+	}
+	it.after = &after
+	it.page = txs
+	return true
+}
+
+// Page returns transactions of the current page.
+func (it *TransactionsByAddressIterator) Page() []proto.Transaction {
+	return it.page
+}
+
+// Err returns the error occurred during iteration, if any.
+func (it *TransactionsByAddressIterator) Err() error {
+	return it.err
+}
+
+// AssetDistributionIterator walks through the asset balance distribution at the given height page by page.
+type AssetDistributionIterator struct {
+	assets  *Assets
+	assetID crypto.Digest
+	height  uint64
+	limit   uint64
+	after   *proto.WavesAddress
+	page    map[proto.WavesAddress]uint64
+	done    bool
+	err     error
+}
+
+// NewAssetDistributionIterator creates iterator which requests pages of the given size.
+func NewAssetDistributionIterator(
+	assets *Assets, assetID crypto.Digest, height, pageSize uint64,
+) *AssetDistributionIterator {
+	return &AssetDistributionIterator{assets: assets, assetID: assetID, height: height, limit: pageSize}
+}
+
+// Next requests the next page. It returns false if there are no more items or an error occurred.
+func (it *AssetDistributionIterator) Next(ctx context.Context) bool {
+	it.page = nil
+	if it.done || it.err != nil {
+		return false
+	}
+	if it.limit == 0 {
+		it.err = errors.New("page size must be positive")
+		return false
+	}
+	d, _, err := it.assets.DistributionAtHeight(ctx, it.assetID, it.height, it.limit, it.after)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	if !d.HasNext {
+		it.done = true
+	} else {
+		lastItem := d.LastItem
+		it.after = &lastItem
+	}
+	if len(d.Items) == 0 {
+		return false
+	}
+	it.page = d.Items
+	return true
+}
+
+// Page returns balances of the current page.
+func (it *AssetDistributionIterator) Page() map[proto.WavesAddress]uint64 {
+	return it.page
+}
+
+// Err returns the error occurred during iteration, if any.
+func (it *AssetDistributionIterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+type pagesDoer struct {
+	pages []string
+	urls  []string
+}
+
+func (d *pagesDoer) Do(req *http.Request) (*http.Response, error) {
+	d.urls = append(d.urls, req.URL.String())
+	body := d.pages[0]
+	d.pages = d.pages[1:]
+	return &http.Response{
+		Request:    req,
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}, nil
+}
+
+func TestTransactionsByAddressIterator(t *testing.T) {
+	d := &pagesDoer{pages: []string{transactionsByAddressJson, "[[]]"}}
+	client, err := NewClient(Options{Client: d, BaseUrl: "https://testnodes.wavesnodes.com"})
+	require.NoError(t, err)
+	addr := proto.MustAddressFromString("3PJaDyprvekvPXPuAtxrapacuDJopgJRaU3")
+
+	it := NewTransactionsByAddressIterator(client.Transactions, addr, 1)
+	var txs []proto.Transaction
+	for it.Next(context.Background()) {
+		txs = append(txs, it.Page()...)
+	}
+	require.NoError(t, it.Err())
+	require.Len(t, txs, 1)
+	require.Len(t, d.urls, 2)
+	assert.Equal(t, "https://testnodes.wavesnodes.com/transactions/address/3PJaDyprvekvPXPuAtxrapacuDJopgJRaU3/limit/1", d.urls[0])
+	assert.Equal(t, "https://testnodes.wavesnodes.com/transactions/address/3PJaDyprvekvPXPuAtxrapacuDJopgJRaU3/limit/1?after=9ECrQ5oo3A6s4qtncRC5BCymWdVGYTV94YcSAEnbgQAj", d.urls[1])
+	assert.False(t, it.Next(context.Background()))
+
+	it = NewTransactionsByAddressIterator(client.Transactions, addr, 0)
+	assert.False(t, it.Next(context.Background()))
+	assert.Error(t, it.Err())
+}
+
+func TestAssetDistributionIterator(t *testing.T) {
+	const (
+		page1 = `{"hasNext": true, "lastItem": "3PQL81CriMZu5tXjdbS5HqBVnrVpy9eRzp2",
+			"items": {"3PJCh8EZ1toiXRM2schLUNG3Zy2L1fYvsGF": 172500, "3PQL81CriMZu5tXjdbS5HqBVnrVpy9eRzp2": 163275}}`
+		page2 = `{"hasNext": false, "lastItem": "3P76TmRjfjhdN9KEmwSnzQHpLrMRuf1qV29",
+			"items": {"3P76TmRjfjhdN9KEmwSnzQHpLrMRuf1qV29": 29198943}}`
+	)
+	d := &pagesDoer{pages: []string{page1, page2}}
+	client, err := NewClient(Options{Client: d, BaseUrl: "https://testnodes.wavesnodes.com"})
+	require.NoError(t, err)
+	assetID := crypto.MustDigestFromBase58("34N9YcEETLWn93qYQ64EsP1x89tSruJU44RrEMSXXEPJ")
+
+	it := NewAssetDistributionIterator(client.Assets, assetID, 100, 2)
+	items := make(map[proto.WavesAddress]uint64)
+	for it.Next(context.Background()) {
+		for k, v := range it.Page() {
+			items[k] = v
+		}
+	}
+	require.NoError(t, it.Err())
+	assert.Len(t, items, 3)
+	require.Len(t, d.urls, 2)
+	assert.Equal(t, "https://testnodes.wavesnodes.com/assets/34N9YcEETLWn93qYQ64EsP1x89tSruJU44RrEMSXXEPJ/distribution/100/limit/2?after=3PQL81CriMZu5tXjdbS5HqBVnrVpy9eRzp2", d.urls[1])
+}
@@ -1,10 +1,13 @@
 package client
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 
+	"github.com/wavesplatform/gowaves/pkg/crypto"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 )
 
@@ -39,3 +42,74 @@ func (a *Leasing) Active(ctx context.Context, address proto.WavesAddress) ([]*pr
 
 	return out, response, nil
 }
+
+// Lease statuses returned by the node.
+const (
+	LeaseStatusActive   = "active"
+	LeaseStatusCanceled = "canceled"
+	LeaseStatusExpired  = "expired"
+)
+
+// LeaseInfo describes the lease created either by Lease transaction or by Invoke transaction.
+type LeaseInfo struct {
+	ID                  crypto.Digest      `json:"id"`
+	OriginTransactionID crypto.Digest      `json:"originTransactionId"`
+	Sender              proto.WavesAddress `json:"sender"`
+	Recipient           proto.WavesAddress `json:"recipient"`
+	Amount              uint64             `json:"amount"`
+	Height              uint64             `json:"height"`
+	Status              string             `json:"status"`
+	CancelHeight        *uint64            `json:"cancelHeight,omitempty"`
+	CancelTransactionID *crypto.Digest     `json:"cancelTransactionId,omitempty"`
+}
+
+// Info gets lease details by lease ID.
+func (a *Leasing) Info(ctx context.Context, id crypto.Digest) (*LeaseInfo, *Response, error) {
+	url, err := joinUrl(a.options.BaseUrl, fmt.Sprintf("/leasing/info/%s", id.String()))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := http.NewRequest("GET", url.String(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	out := new(LeaseInfo)
+	response, err := doHttp(ctx, a.options, req, out)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return out, response, nil
+}
+
+// InfoByIDs gets details of several leases in one request.
+func (a *Leasing) InfoByIDs(ctx context.Context, ids []crypto.Digest) ([]*LeaseInfo, *Response, error) {
+	type leasingInfoIDs struct {
+		IDs []crypto.Digest `json:"ids"`
+	}
+
+	url, err := joinUrl(a.options.BaseUrl, "/leasing/info")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	b := new(bytes.Buffer)
+	if err = json.NewEncoder(b).Encode(leasingInfoIDs{IDs: ids}); err != nil {
+		return nil, nil, err
+	}
+
+	req, err := http.NewRequest("POST", url.String(), b)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var out []*LeaseInfo
+	response, err := doHttp(ctx, a.options, req, &out)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return out, response, nil
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 )
 
@@ -50,3 +52,47 @@ func TestLeasing_Active(t *testing.T) {
 	assert.EqualValues(t, proto.LeaseTransaction, body[0].Type)
 	assert.Equal(t, "https://testnode1.wavesnodes.com/leasing/active/3NBVqYXrapgJP9atQccdBPAgJPwHDKkh6A8", resp.Request.URL.String())
 }
+
+var leasingInfoJson = `
+{
+  "id": "B4hoL2R8SoWtmkbqjtDykSu3vZiuGkn4G7yYv5xkH4qs",
+  "originTransactionId": "B4hoL2R8SoWtmkbqjtDykSu3vZiuGkn4G7yYv5xkH4qs",
+  "sender": "3NBVqYXrapgJP9atQccdBPAgJPwHDKkh6A8",
+  "recipient": "3N5GRqzDBhjVXnCn44baHcz2GoZy5qLxtTh",
+  "amount": 1,
+  "height": 342137,
+  "status": "canceled",
+  "cancelHeight": 342140,
+  "cancelTransactionId": "5mpoVXjQhKzfQ5kkRzbCsh3QmMvhPhtKnZHjLYChwWBJ"
+}`
+
+func TestLeasing_Info(t *testing.T) {
+	id := crypto.MustDigestFromBase58("B4hoL2R8SoWtmkbqjtDykSu3vZiuGkn4G7yYv5xkH4qs")
+	client, err := NewClient(Options{
+		Client:  NewMockHttpRequestFromString(leasingInfoJson, 200),
+		BaseUrl: "https://testnode1.wavesnodes.com/",
+	})
+	require.NoError(t, err)
+	body, resp, err := client.Leasing.Info(context.Background(), id)
+	require.NoError(t, err)
+	assert.Equal(t, id, body.ID)
+	assert.Equal(t, LeaseStatusCanceled, body.Status)
+	require.NotNil(t, body.CancelHeight)
+	assert.EqualValues(t, 342140, *body.CancelHeight)
+	require.NotNil(t, body.CancelTransactionID)
+	assert.Equal(t, "5mpoVXjQhKzfQ5kkRzbCsh3QmMvhPhtKnZHjLYChwWBJ", body.CancelTransactionID.String())
+	assert.Equal(t,
+		"https://testnode1.wavesnodes.com/leasing/info/B4hoL2R8SoWtmkbqjtDykSu3vZiuGkn4G7yYv5xkH4qs",
+		resp.Request.URL.String())
+
+	client, err = NewClient(Options{
+		Client:  NewMockHttpRequestFromString("["+leasingInfoJson+"]", 200),
+		BaseUrl: "https://testnode1.wavesnodes.com/",
+	})
+	require.NoError(t, err)
+	infos, resp, err := client.Leasing.InfoByIDs(context.Background(), []crypto.Digest{id})
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+	assert.Equal(t, id, infos[0].ID)
+	assert.Equal(t, "POST", resp.Request.Method)
+}
@@ -0,0 +1,327 @@
+// Package mock provides a fake node serving a subset of the REST API from canned state. It allows to test
+// applications built on package client without a running node:
+//
+//	n := mock.NewNode(proto.TestNetScheme)
+//	defer n.Close()
+//	n.SetBalance(addr, 100_000_000)
+//	c, err := client.NewClient(n.Options())
+//	...
+package mock
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+
+	"github.com/wavesplatform/gowaves/pkg/client"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+const maxBroadcastBodySize = 1 << 20
+
+// BroadcastFunc decides on the broadcast transaction. The returned error rejects the transaction, if the error is
+// *client.APIError its code and message are returned to the client.
+type BroadcastFunc func(tx proto.Transaction) error
+
+// Node is the fake node. The state is set with its methods and can be changed while the node is running.
+type Node struct {
+	server *httptest.Server
+	scheme proto.Scheme
+
+	mu          sync.Mutex
+	blocks      []*client.Block
+	balances    map[proto.WavesAddress]uint64
+	scripts     map[proto.WavesAddress]client.AddressesScriptInfo
+	assets      map[crypto.Digest]client.AssetsDetail
+	broadcast   BroadcastFunc
+	unconfirmed []proto.Transaction
+}
+
+// NewNode starts the fake node of the network with the given scheme. The node has to be closed after use.
+func NewNode(scheme proto.Scheme) *Node {
+	n := &Node{
+		scheme:   scheme,
+		balances: make(map[proto.WavesAddress]uint64),
+		scripts:  make(map[proto.WavesAddress]client.AddressesScriptInfo),
+		assets:   make(map[crypto.Digest]client.AssetsDetail),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /blocks/height", n.height)
+	mux.HandleFunc("GET /blocks/last", n.lastBlock)
+	mux.HandleFunc("GET /blocks/at/{height}", n.blockAt)
+	mux.HandleFunc("GET /blocks/headers/last", n.lastHeaders)
+	mux.HandleFunc("GET /blocks/headers/at/{height}", n.headersAt)
+	mux.HandleFunc("GET /addresses/balance/{address}", n.balance)
+	mux.HandleFunc("GET /addresses/balance/details/{address}", n.balanceDetails)
+	mux.HandleFunc("GET /addresses/scriptInfo/{address}", n.scriptInfo)
+	mux.HandleFunc("GET /assets/details/{id}", n.assetDetails)
+	mux.HandleFunc("POST /transactions/broadcast", n.broadcastTx)
+	mux.HandleFunc("GET /transactions/unconfirmed", n.unconfirmedTxs)
+	mux.HandleFunc("GET /transactions/unconfirmed/size", n.unconfirmedSize)
+	n.server = httptest.NewServer(mux)
+	return n
+}
+
+// URL returns the base URL of the node.
+func (n *Node) URL() string {
+	return n.server.URL
+}
+
+// Options returns the client options to connect to the node.
+func (n *Node) Options() client.Options {
+	return client.Options{
+		BaseUrl: n.server.URL,
+		ChainID: n.scheme,
+		Client:  n.server.Client(),
+	}
+}
+
+// Close stops the node.
+func (n *Node) Close() {
+	n.server.Close()
+}
+
+// AddBlock appends the block to the chain, the height of the block is set accordingly. Unset ID of the block is
+// derived from the height and unset reference is set to the ID of the previous block.
+func (n *Node) AddBlock(b *client.Block) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	b.Height = uint64(len(n.blocks) + 1)
+	if len(b.ID.Bytes()) == 0 {
+		b.ID = proto.NewBlockIDFromDigest(crypto.MustFastHash(binary.BigEndian.AppendUint64(nil, b.Height)))
+	}
+	if len(b.Reference.Bytes()) == 0 {
+		b.Reference = proto.NewBlockIDFromDigest(crypto.Digest{})
+		if len(n.blocks) > 0 {
+			b.Reference = n.blocks[len(n.blocks)-1].ID
+		}
+	}
+	n.blocks = append(n.blocks, b)
+}
+
+// SetBalance sets the balance of the address in Waves. All kinds of balances in balance details are the same.
+func (n *Node) SetBalance(addr proto.WavesAddress, balance uint64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.balances[addr] = balance
+}
+
+// SetScriptInfo sets the script information of the address, by default the address has no script.
+func (n *Node) SetScriptInfo(info client.AddressesScriptInfo) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.scripts[info.Address] = info
+}
+
+// SetAsset makes the asset known to the node.
+func (n *Node) SetAsset(details client.AssetsDetail) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.assets[details.AssetId] = details
+}
+
+// SetBroadcastFunc sets the function deciding on broadcast transactions, by default all transactions are accepted.
+func (n *Node) SetBroadcastFunc(f BroadcastFunc) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.broadcast = f
+}
+
+// Unconfirmed returns the accepted broadcast transactions.
+func (n *Node) Unconfirmed() []proto.Transaction {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]proto.Transaction(nil), n.unconfirmed...)
+}
+
+func (n *Node) height(w http.ResponseWriter, _ *http.Request) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	sendJSON(w, client.BlocksHeight{Height: uint64(len(n.blocks))})
+}
+
+func (n *Node) lastBlock(w http.ResponseWriter, _ *http.Request) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if len(n.blocks) == 0 {
+		sendError(w, http.StatusNotFound, client.ErrBlockDoesNotExist)
+		return
+	}
+	sendJSON(w, n.blocks[len(n.blocks)-1])
+}
+
+func (n *Node) blockAt(w http.ResponseWriter, r *http.Request) {
+	b, ok := n.block(w, r)
+	if !ok {
+		return
+	}
+	sendJSON(w, b)
+}
+
+func (n *Node) lastHeaders(w http.ResponseWriter, _ *http.Request) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if len(n.blocks) == 0 {
+		sendError(w, http.StatusNotFound, client.ErrBlockDoesNotExist)
+		return
+	}
+	sendJSON(w, n.blocks[len(n.blocks)-1].Headers)
+}
+
+func (n *Node) headersAt(w http.ResponseWriter, r *http.Request) {
+	b, ok := n.block(w, r)
+	if !ok {
+		return
+	}
+	sendJSON(w, b.Headers)
+}
+
+func (n *Node) block(w http.ResponseWriter, r *http.Request) (*client.Block, bool) {
+	h, err := strconv.ParseUint(r.PathValue("height"), 10, 64)
+	if err != nil {
+		sendError(w, http.StatusBadRequest, client.ErrCustomValidation)
+		return nil, false
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if h == 0 || h > uint64(len(n.blocks)) {
+		sendError(w, http.StatusNotFound, client.ErrBlockDoesNotExist)
+		return nil, false
+	}
+	return n.blocks[h-1], true
+}
+
+func (n *Node) balance(w http.ResponseWriter, r *http.Request) {
+	addr, ok := n.address(w, r)
+	if !ok {
+		return
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	sendJSON(w, client.AddressesBalance{Address: addr, Balance: n.balances[addr]})
+}
+
+func (n *Node) balanceDetails(w http.ResponseWriter, r *http.Request) {
+	addr, ok := n.address(w, r)
+	if !ok {
+		return
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	b := n.balances[addr]
+	sendJSON(w, client.AddressesBalanceDetails{
+		Address: addr, Regular: b, Generating: b, Available: b, Effective: b,
+	})
+}
+
+func (n *Node) scriptInfo(w http.ResponseWriter, r *http.Request) {
+	addr, ok := n.address(w, r)
+	if !ok {
+		return
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	info, ok := n.scripts[addr]
+	if !ok {
+		info = client.AddressesScriptInfo{Address: addr}
+	}
+	sendJSON(w, info)
+}
+
+func (n *Node) address(w http.ResponseWriter, r *http.Request) (proto.WavesAddress, bool) {
+	addr, err := proto.NewAddressFromString(r.PathValue("address"))
+	if err != nil {
+		sendError(w, http.StatusBadRequest, client.ErrInvalidAddress)
+		return proto.WavesAddress{}, false
+	}
+	if ok, vErr := addr.Valid(n.scheme); !ok || vErr != nil {
+		sendError(w, http.StatusBadRequest, client.ErrInvalidAddress)
+		return proto.WavesAddress{}, false
+	}
+	return addr, true
+}
+
+func (n *Node) assetDetails(w http.ResponseWriter, r *http.Request) {
+	id, err := crypto.NewDigestFromBase58(r.PathValue("id"))
+	if err != nil {
+		sendError(w, http.StatusBadRequest, client.ErrInvalidAssetID)
+		return
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	d, ok := n.assets[id]
+	if !ok {
+		sendError(w, http.StatusNotFound, client.ErrAssetDoesNotExist)
+		return
+	}
+	sendJSON(w, d)
+}
+
+func (n *Node) broadcastTx(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBroadcastBodySize))
+	if err != nil {
+		sendError(w, http.StatusBadRequest, client.ErrWrongJSON)
+		return
+	}
+	tt := new(proto.TransactionTypeVersion)
+	if err = json.Unmarshal(body, tt); err != nil {
+		sendError(w, http.StatusBadRequest, client.ErrWrongJSON)
+		return
+	}
+	tx, err := proto.GuessTransactionType(tt)
+	if err != nil {
+		sendError(w, http.StatusBadRequest, client.ErrWrongJSON)
+		return
+	}
+	if err = json.Unmarshal(body, tx); err != nil {
+		sendError(w, http.StatusBadRequest, client.ErrWrongJSON)
+		return
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.broadcast != nil {
+		if bErr := n.broadcast(tx); bErr != nil {
+			apiErr, ok := bErr.(*client.APIError)
+			if !ok {
+				apiErr = &client.APIError{Code: client.StateCheckFailedErrorCode, Message: bErr.Error()}
+			}
+			sendError(w, http.StatusBadRequest, apiErr)
+			return
+		}
+	}
+	n.unconfirmed = append(n.unconfirmed, tx)
+	sendJSON(w, tx)
+}
+
+func (n *Node) unconfirmedTxs(w http.ResponseWriter, _ *http.Request) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	sendJSON(w, append([]proto.Transaction{}, n.unconfirmed...))
+}
+
+func (n *Node) unconfirmedSize(w http.ResponseWriter, _ *http.Request) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	sendJSON(w, struct {
+		Size int `json:"size"`
+	}{Size: len(n.unconfirmed)})
+}
+
+func sendJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, fmt.Sprintf("failed to encode response: %v", err), http.StatusInternalServerError)
+	}
+}
+
+func sendError(w http.ResponseWriter, status int, apiErr *client.APIError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(apiErr)
+}
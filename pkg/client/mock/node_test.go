@@ -0,0 +1,63 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/client"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+func TestNode(t *testing.T) {
+	n := NewNode(proto.TestNetScheme)
+	defer n.Close()
+	c, err := client.NewClient(n.Options())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	sk, pk, err := crypto.GenerateKeyPair([]byte("mock node seed"))
+	require.NoError(t, err)
+	addr, err := proto.NewAddressFromPublicKey(proto.TestNetScheme, pk)
+	require.NoError(t, err)
+
+	_, _, err = c.Blocks.Last(ctx)
+	assert.True(t, errors.Is(err, client.ErrBlockDoesNotExist))
+	n.AddBlock(&client.Block{Headers: client.Headers{Generator: addr, Timestamp: 1}})
+	n.AddBlock(&client.Block{Headers: client.Headers{Generator: addr, Timestamp: 2}})
+	h, _, err := c.Blocks.Height(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, h.Height)
+	b, _, err := c.Blocks.At(ctx, 1)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, b.Timestamp)
+	headers, _, err := c.Blocks.HeadersLast(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, headers.Height)
+	assert.Equal(t, addr, headers.Generator)
+	assert.Equal(t, b.ID, headers.Reference)
+
+	n.SetBalance(addr, 12345)
+	balance, _, err := c.Addresses.Balance(ctx, addr)
+	require.NoError(t, err)
+	assert.EqualValues(t, 12345, balance.Balance)
+
+	tx := proto.NewUnsignedTransferWithProofs(3, pk, proto.NewOptionalAssetWaves(), proto.NewOptionalAssetWaves(),
+		1000, 100, 100000, proto.NewRecipientFromAddress(addr), nil)
+	require.NoError(t, tx.Sign(proto.TestNetScheme, sk))
+	_, err = c.Transactions.Broadcast(ctx, tx)
+	require.NoError(t, err)
+	require.Len(t, n.Unconfirmed(), 1)
+	size, _, err := c.Transactions.UnconfirmedSize(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, size)
+
+	n.SetBroadcastFunc(func(proto.Transaction) error { return client.ErrAccountBalance })
+	_, err = c.Transactions.Broadcast(ctx, tx)
+	assert.True(t, errors.Is(err, client.ErrAccountBalance))
+	assert.Len(t, n.Unconfirmed(), 1)
+}
@@ -0,0 +1,143 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	DefaultRetryMaxAttempts    = 3
+	DefaultRetryInitialBackoff = 200 * time.Millisecond
+	DefaultRetryMaxBackoff     = 5 * time.Second
+	DefaultRetryMultiplier     = 2.0
+)
+
+// RetryPolicy describes how failed requests are retried.
+// Requests are retried on transport errors and on 429, 502, 503 and 504 responses. Only idempotent
+// requests (GET, HEAD, OPTIONS, PUT, DELETE) are retried unless RetryNonIdempotent is set.
+// Note that broadcasting of the same signed transaction is idempotent by its nature, so it's safe to enable
+// RetryNonIdempotent for the client used only to broadcast transactions.
+// The delay between attempts grows exponentially from InitialBackoff up to MaxBackoff, the value of
+// Retry-After header is used instead if present. If the server asks to wait longer than MaxBackoff
+// the response is returned as is.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first one.
+	MaxAttempts        int
+	InitialBackoff     time.Duration
+	MaxBackoff         time.Duration
+	Multiplier         float64
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns retry policy with default parameters.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    DefaultRetryMaxAttempts,
+		InitialBackoff: DefaultRetryInitialBackoff,
+		MaxBackoff:     DefaultRetryMaxBackoff,
+		Multiplier:     DefaultRetryMultiplier,
+	}
+}
+
+func (p *RetryPolicy) canRetry(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+	default:
+		if !p.RetryNonIdempotent {
+			return false
+		}
+	}
+	// Request body should be rewound before the next attempt.
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+func isRetryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoff returns delay before the next attempt. The attempt numbers start from 1.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	d := float64(p.InitialBackoff)
+	for i := 1; i < attempt; i++ {
+		d *= p.Multiplier
+		if p.MaxBackoff > 0 && d >= float64(p.MaxBackoff) {
+			return p.MaxBackoff
+		}
+	}
+	return time.Duration(d)
+}
+
+// retryAfter parses the value of Retry-After header, which could be either delay in seconds or HTTP date.
+func retryAfter(resp *http.Response, now time.Time) (time.Duration, bool) {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if s, err := strconv.ParseUint(v, 10, 32); err == nil {
+		return time.Duration(s) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+func drainAndClose(body io.ReadCloser) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(body, 4096)) // allows to reuse the connection
+	_ = body.Close()                                       // No error handling intentionally
+}
+
+// doWithRetries sends the request with the client from options and retries it according to the retry policy.
+func doWithRetries(ctx context.Context, options Options, req *http.Request) (*http.Response, error) {
+	p := options.Retry
+	if p == nil || p.MaxAttempts <= 1 || !p.canRetry(req) {
+		return options.Client.Do(req)
+	}
+	for attempt := 1; ; attempt++ {
+		resp, err := options.Client.Do(req)
+		if attempt >= p.MaxAttempts || ctx.Err() != nil {
+			return resp, err
+		}
+		delay := p.backoff(attempt)
+		switch {
+		case err != nil:
+		case isRetryableStatus(resp.StatusCode):
+			if ra, ok := retryAfter(resp, time.Now()); ok {
+				if p.MaxBackoff > 0 && ra > p.MaxBackoff {
+					return resp, nil
+				}
+				delay = ra
+			}
+			drainAndClose(resp.Body)
+		default:
+			return resp, nil
+		}
+		if req.GetBody != nil {
+			body, bErr := req.GetBody()
+			if bErr != nil {
+				return nil, errors.Wrap(bErr, "failed to rewind request body")
+			}
+			req.Body = body
+		}
+		t := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return nil, ctx.Err()
+		case <-t.C:
+		}
+	}
+}
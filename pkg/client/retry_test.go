@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sequenceDoer struct {
+	codes  []int
+	header http.Header
+	calls  int
+	bodies []string
+}
+
+func (d *sequenceDoer) Do(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		b, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		d.bodies = append(d.bodies, string(b))
+	}
+	code := d.codes[d.calls]
+	d.calls++
+	return &http.Response{
+		Request:    req,
+		StatusCode: code,
+		Header:     d.header,
+		Body:       io.NopCloser(strings.NewReader(`{}`)),
+	}, nil
+}
+
+func testRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond, Multiplier: 2}
+}
+
+func TestDoWithRetries(t *testing.T) {
+	ctx := context.Background()
+
+	d := &sequenceDoer{codes: []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK}}
+	req, err := http.NewRequest(http.MethodGet, "https://example.com", nil)
+	require.NoError(t, err)
+	resp, err := doWithRetries(ctx, Options{Client: d, Retry: testRetryPolicy()}, req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 3, d.calls)
+
+	// attempts are exhausted
+	d = &sequenceDoer{codes: []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway}}
+	resp, err = doWithRetries(ctx, Options{Client: d, Retry: testRetryPolicy()}, req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	assert.Equal(t, 3, d.calls)
+
+	// not retryable status
+	d = &sequenceDoer{codes: []int{http.StatusBadRequest, http.StatusOK}}
+	resp, err = doWithRetries(ctx, Options{Client: d, Retry: testRetryPolicy()}, req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, 1, d.calls)
+
+	// POST is not retried by default
+	post, err := http.NewRequest(http.MethodPost, "https://example.com", strings.NewReader("body"))
+	require.NoError(t, err)
+	d = &sequenceDoer{codes: []int{http.StatusServiceUnavailable, http.StatusOK}}
+	resp, err = doWithRetries(ctx, Options{Client: d, Retry: testRetryPolicy()}, post)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, 1, d.calls)
+
+	// POST is retried with rewound body if allowed
+	post, err = http.NewRequest(http.MethodPost, "https://example.com", strings.NewReader("body"))
+	require.NoError(t, err)
+	p := testRetryPolicy()
+	p.RetryNonIdempotent = true
+	d = &sequenceDoer{codes: []int{http.StatusServiceUnavailable, http.StatusOK}}
+	resp, err = doWithRetries(ctx, Options{Client: d, Retry: p}, post)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{"body", "body"}, d.bodies)
+
+	// Retry-After is longer than max backoff
+	d = &sequenceDoer{
+		codes:  []int{http.StatusTooManyRequests, http.StatusOK},
+		header: http.Header{"Retry-After": []string{"120"}},
+	}
+	resp, err = doWithRetries(ctx, Options{Client: d, Retry: testRetryPolicy()}, req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, 1, d.calls)
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := &RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 3}
+	assert.Equal(t, 100*time.Millisecond, p.backoff(1))
+	assert.Equal(t, 300*time.Millisecond, p.backoff(2))
+	assert.Equal(t, 900*time.Millisecond, p.backoff(3))
+	assert.Equal(t, time.Second, p.backoff(4))
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	resp := &http.Response{Header: http.Header{}}
+	_, ok := retryAfter(resp, now)
+	assert.False(t, ok)
+
+	resp.Header.Set("Retry-After", "3")
+	d, ok := retryAfter(resp, now)
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, d)
+
+	resp.Header.Set("Retry-After", now.Add(5*time.Second).Format(http.TimeFormat))
+	d, ok = retryAfter(resp, now)
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Second, d)
+
+	resp.Header.Set("Retry-After", "soon")
+	_, ok = retryAfter(resp, now)
+	assert.False(t, ok)
+}
@@ -0,0 +1,285 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/grpc/generated/waves/events"
+	eventsgrpc "github.com/wavesplatform/gowaves/pkg/grpc/generated/waves/events/grpc"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+const (
+	DefaultSubscriptionPollInterval = 5 * time.Second
+	DefaultSubscriptionMinReconnect = time.Second
+	DefaultSubscriptionMaxReconnect = time.Minute
+	// maxBlocksSeqLength is the maximum number of blocks returned by /blocks/seq endpoint.
+	maxBlocksSeqLength = 100
+)
+
+// SubscriptionOptions configures subscriptions. Zero values are replaced with defaults.
+// Subscriptions never stop on errors, they report errors to OnError callback (if set) and reconnect with
+// exponentially growing delay from MinReconnectDelay up to MaxReconnectDelay.
+type SubscriptionOptions struct {
+	// PollInterval is the interval between requests for subscriptions implemented by polling REST API.
+	PollInterval      time.Duration
+	MinReconnectDelay time.Duration
+	MaxReconnectDelay time.Duration
+	OnError           func(err error)
+	// OnRollback is called by blocks subscription when the node has switched to another fork, with the height of
+	// the last common block. Blocks above the height are sent again and replace the previously sent ones.
+	OnRollback func(height uint64)
+}
+
+func (o SubscriptionOptions) withDefaults() SubscriptionOptions {
+	if o.PollInterval <= 0 {
+		o.PollInterval = DefaultSubscriptionPollInterval
+	}
+	if o.MinReconnectDelay <= 0 {
+		o.MinReconnectDelay = DefaultSubscriptionMinReconnect
+	}
+	if o.MaxReconnectDelay < o.MinReconnectDelay {
+		o.MaxReconnectDelay = max(DefaultSubscriptionMaxReconnect, o.MinReconnectDelay)
+	}
+	return o
+}
+
+// reconnector keeps the state of subscription reconnection delays.
+type reconnector struct {
+	opts  SubscriptionOptions
+	delay time.Duration
+}
+
+func newReconnector(opts SubscriptionOptions) *reconnector {
+	return &reconnector{opts: opts, delay: opts.MinReconnectDelay}
+}
+
+func (r *reconnector) report(err error) {
+	if r.opts.OnError != nil {
+		r.opts.OnError(err)
+	}
+}
+
+// failed reports the error and waits before the next attempt, it returns false if context is done.
+func (r *reconnector) failed(ctx context.Context, err error) bool {
+	r.report(err)
+	ok := sleep(ctx, r.delay)
+	r.delay = min(2*r.delay, r.opts.MaxReconnectDelay)
+	return ok
+}
+
+func (r *reconnector) succeeded() {
+	r.delay = r.opts.MinReconnectDelay
+}
+
+func sleep(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
+
+// sentBlock is the block sent by blocks subscription, the last sent blocks are remembered to detect rollbacks.
+type sentBlock struct {
+	height uint64
+	id     proto.BlockID
+}
+
+// Subscribe polls the node for new blocks and sends them to the returned channel in order of heights, starting
+// from the block at fromHeight. Only the blocks below the top one are sent, because the top (liquid) block can
+// still be extended with microblocks. Subscription continues from the last sent block after errors.
+// If the next block doesn't reference the last sent one, the node has switched to another fork. Then the rollback
+// is reported to OnRollback callback and the blocks above the last common block are sent again. Only the last
+// 100 sent blocks are checked, if none of them is left in the blockchain, all of them are sent again.
+// The channel is closed when the context is done.
+func (a *Blocks) Subscribe(ctx context.Context, fromHeight uint64, opts SubscriptionOptions) <-chan *Block {
+	opts = opts.withDefaults()
+	out := make(chan *Block)
+	go func() {
+		defer close(out)
+		rc := newReconnector(opts)
+		next := max(fromHeight, 1)
+		var sent []sentBlock
+		for {
+			h, _, err := a.Height(ctx)
+			if err != nil {
+				if !rc.failed(ctx, err) {
+					return
+				}
+				continue
+			}
+			rc.succeeded()
+			for next < h.Height { // the top block is not complete yet
+				to := min(next+maxBlocksSeqLength-1, h.Height-1)
+				blocks, _, sErr := a.Seq(ctx, next, to)
+				if sErr != nil {
+					if !rc.failed(ctx, sErr) {
+						return
+					}
+					break // request the height again
+				}
+				if len(blocks) == 0 {
+					break
+				}
+				if n := len(sent); n > 0 && blocks[0].Reference != sent[n-1].id {
+					fork, fErr := a.forkHeight(ctx, sent)
+					if fErr != nil {
+						if !rc.failed(ctx, fErr) {
+							return
+						}
+						break
+					}
+					for len(sent) > 0 && sent[len(sent)-1].height > fork {
+						sent = sent[:len(sent)-1]
+					}
+					next = fork + 1
+					if opts.OnRollback != nil {
+						opts.OnRollback(fork)
+					}
+					continue
+				}
+				for _, b := range blocks {
+					select {
+					case out <- b:
+					case <-ctx.Done():
+						return
+					}
+					next = b.Height + 1
+					sent = append(sent, sentBlock{height: b.Height, id: b.ID})
+					if len(sent) > maxBlocksSeqLength {
+						sent = sent[1:]
+					}
+				}
+			}
+			if !sleep(ctx, opts.PollInterval) {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// forkHeight returns the height of the last sent block which is still in the blockchain. If none of the sent blocks
+// is found, the height below the lowest of them is returned.
+func (a *Blocks) forkHeight(ctx context.Context, sent []sentBlock) (uint64, error) {
+	headers, _, err := a.HeadersSeq(ctx, sent[0].height, sent[len(sent)-1].height)
+	if err != nil {
+		return 0, err
+	}
+	ids := make(map[uint64]proto.BlockID, len(headers))
+	for _, h := range headers {
+		ids[h.Height] = h.ID
+	}
+	for i := len(sent) - 1; i >= 0; i-- {
+		if id, ok := ids[sent[i].height]; ok && id == sent[i].id {
+			return sent[i].height, nil
+		}
+	}
+	return sent[0].height - 1, nil
+}
+
+// SubscribeUnconfirmed polls the node's UTX pool and sends every newly appeared transaction to the returned
+// channel. Transactions which were already sent are not repeated while they are staying in the pool.
+// The channel is closed when the context is done.
+func (a *Transactions) SubscribeUnconfirmed(ctx context.Context, opts SubscriptionOptions) <-chan proto.Transaction {
+	opts = opts.withDefaults()
+	out := make(chan proto.Transaction)
+	go func() {
+		defer close(out)
+		rc := newReconnector(opts)
+		seen := make(map[crypto.Digest]struct{})
+		for {
+			txs, _, err := a.Unconfirmed(ctx)
+			if err != nil {
+				if !rc.failed(ctx, err) {
+					return
+				}
+				continue
+			}
+			rc.succeeded()
+			current := make(map[crypto.Digest]struct{}, len(txs))
+			for _, tx := range txs {
+				id, idErr := tx.GetID(a.options.ChainID)
+				if idErr != nil {
+					rc.report(idErr)
+					continue
+				}
+				d, dErr := crypto.NewDigestFromBytes(id)
+				if dErr != nil {
+					rc.report(dErr)
+					continue
+				}
+				current[d] = struct{}{}
+				if _, ok := seen[d]; ok {
+					continue
+				}
+				select {
+				case out <- tx:
+				case <-ctx.Done():
+					return
+				}
+			}
+			seen = current // forget transactions that have left the pool
+			if !sleep(ctx, opts.PollInterval) {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// SubscribeBlockchainUpdates subscribes to the BlockchainUpdates gRPC stream of the node, starting from the
+// given height, and sends updates to the returned channel. If the stream breaks, the subscription is restored
+// from the height of the last received update, so the updates of this height are repeated after reconnection and
+// should be treated by consumer as replacing the previously received ones. Rollbacks are sent by the node as updates
+// with Rollback field set, OnRollback callback is not used for them, neither is PollInterval option.
+// The channel is closed when the context is done.
+func SubscribeBlockchainUpdates(
+	ctx context.Context, conn grpc.ClientConnInterface, fromHeight int32, opts SubscriptionOptions,
+) <-chan *events.BlockchainUpdated {
+	opts = opts.withDefaults()
+	out := make(chan *events.BlockchainUpdated)
+	api := eventsgrpc.NewBlockchainUpdatesApiClient(conn)
+	go func() {
+		defer close(out)
+		rc := newReconnector(opts)
+		from := max(fromHeight, 1)
+		for {
+			stream, err := api.Subscribe(ctx, &eventsgrpc.SubscribeRequest{FromHeight: from})
+			if err != nil {
+				if ctx.Err() != nil || !rc.failed(ctx, err) {
+					return
+				}
+				continue
+			}
+			for {
+				ev, rErr := stream.Recv()
+				if rErr != nil {
+					if ctx.Err() != nil || !rc.failed(ctx, rErr) {
+						return
+					}
+					break
+				}
+				rc.succeeded()
+				u := ev.GetUpdate()
+				if u == nil {
+					continue
+				}
+				select {
+				case out <- u:
+				case <-ctx.Done():
+					return
+				}
+				from = max(u.GetHeight(), 1)
+			}
+		}
+	}()
+	return out
+}
@@ -0,0 +1,135 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+)
+
+type routesDoer struct {
+	mu     sync.Mutex
+	routes map[string]string
+}
+
+func (d *routesDoer) Do(req *http.Request) (*http.Response, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	body, ok := d.routes[req.URL.Path]
+	code := http.StatusOK
+	if !ok {
+		code = http.StatusNotFound
+	}
+	return &http.Response{Request: req, StatusCode: code, Body: io.NopCloser(strings.NewReader(body))}, nil
+}
+
+func (d *routesDoer) set(path, body string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.routes[path] = body
+}
+
+func TestBlocks_Subscribe(t *testing.T) {
+	d := &routesDoer{routes: map[string]string{
+		"/blocks/height":  `{"height": 3}`,
+		"/blocks/seq/2/2": `[{"height": 2}]`,
+		"/blocks/seq/3/4": `[{"height": 3}, {"height": 4}]`,
+	}}
+	client, err := NewClient(Options{Client: d, BaseUrl: "https://testnodes.wavesnodes.com"})
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var errs []error
+	var errsMu sync.Mutex
+	ch := client.Blocks.Subscribe(ctx, 2, SubscriptionOptions{
+		PollInterval:      time.Millisecond,
+		MinReconnectDelay: time.Millisecond,
+		OnError: func(err error) {
+			errsMu.Lock()
+			defer errsMu.Unlock()
+			errs = append(errs, err)
+		},
+	})
+	b := <-ch
+	assert.EqualValues(t, 2, b.Height)
+
+	d.set("/blocks/height", `{"height": 5}`)
+	b = <-ch
+	assert.EqualValues(t, 3, b.Height)
+	b = <-ch
+	assert.EqualValues(t, 4, b.Height)
+
+	cancel()
+	for range ch { // wait for the channel to be closed
+	}
+	errsMu.Lock()
+	defer errsMu.Unlock()
+	assert.Empty(t, errs)
+}
+
+func TestBlocks_SubscribeRollback(t *testing.T) {
+	a, b, c := crypto.Digest{1}.String(), crypto.Digest{2}.String(), crypto.Digest{3}.String()
+	d := &routesDoer{routes: map[string]string{
+		"/blocks/height":  `{"height": 4}`,
+		"/blocks/seq/2/3": `[{"height": 2, "id": "` + a + `"}, {"height": 3, "id": "` + b + `", "reference": "` + a + `"}]`,
+	}}
+	client, err := NewClient(Options{Client: d, BaseUrl: "https://testnodes.wavesnodes.com"})
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rollbacks := make(chan uint64, 1)
+	ch := client.Blocks.Subscribe(ctx, 2, SubscriptionOptions{
+		PollInterval:      time.Millisecond,
+		MinReconnectDelay: time.Millisecond,
+		OnRollback:        func(height uint64) { rollbacks <- height },
+	})
+	assert.EqualValues(t, 2, (<-ch).Height)
+	assert.EqualValues(t, 3, (<-ch).Height)
+
+	// Block 3 is replaced by the block from another fork.
+	d.set("/blocks/seq/4/4", `[{"height": 4, "reference": "`+c+`"}]`)
+	d.set("/blocks/headers/seq/2/3", `[{"height": 2, "id": "`+a+`"}, {"height": 3, "id": "`+c+`"}]`)
+	d.set("/blocks/seq/3/4", `[{"height": 3, "id": "`+c+`", "reference": "`+a+`"}, {"height": 4, "reference": "`+c+`"}]`)
+	d.set("/blocks/height", `{"height": 5}`)
+	assert.EqualValues(t, 2, <-rollbacks)
+	blk := <-ch
+	assert.EqualValues(t, 3, blk.Height)
+	assert.Equal(t, c, blk.ID.String())
+	assert.EqualValues(t, 4, (<-ch).Height)
+
+	cancel()
+	for range ch { // wait for the channel to be closed
+	}
+}
+
+func TestTransactions_SubscribeUnconfirmed(t *testing.T) {
+	d := &routesDoer{routes: map[string]string{
+		"/transactions/unconfirmed": transactionUnconfirmedJson,
+	}}
+	client, err := NewClient(Options{Client: d, BaseUrl: "https://testnodes.wavesnodes.com"})
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := client.Transactions.SubscribeUnconfirmed(ctx, SubscriptionOptions{PollInterval: time.Millisecond})
+	tx := <-ch
+	require.NotNil(t, tx)
+	select {
+	case <-ch:
+		assert.Fail(t, "transaction must not be repeated")
+	case <-time.After(20 * time.Millisecond):
+	}
+	cancel()
+	for range ch { // wait for the channel to be closed
+	}
+}
@@ -137,7 +137,20 @@ func (a *Transactions) Info(ctx context.Context, id crypto.Digest) (TransactionI
 
 // Address gets list of transactions where specified address has been involved.
 func (a *Transactions) Address(ctx context.Context, address proto.WavesAddress, limit uint) ([]proto.Transaction, *Response, error) {
-	url, err := joinUrl(a.options.BaseUrl, fmt.Sprintf("/transactions/address/%s/limit/%d", address.String(), limit))
+	return a.AddressAfter(ctx, address, limit, nil)
+}
+
+// AddressAfter gets list of transactions where specified address has been involved, starting after the transaction
+// with the given ID. If after is nil the list starts from the latest transaction. Use TransactionsByAddressIterator
+// to walk through the whole history.
+func (a *Transactions) AddressAfter(
+	ctx context.Context, address proto.WavesAddress, limit uint, after *crypto.Digest,
+) ([]proto.Transaction, *Response, error) {
+	rawPath := fmt.Sprintf("/transactions/address/%s/limit/%d", address.String(), limit)
+	if after != nil {
+		rawPath += "?after=" + after.String()
+	}
+	url, err := joinUrl(a.options.BaseUrl, rawPath)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -182,3 +195,72 @@ func (a *Transactions) Broadcast(ctx context.Context, transaction proto.Transact
 	}
 	return doHttp(ctx, a.options, req, nil)
 }
+
+// CalculatedFee is the minimal fee of transaction accepted by the node.
+type CalculatedFee struct {
+	FeeAssetID proto.OptionalAsset `json:"feeAssetId"`
+	FeeAmount  uint64              `json:"feeAmount"`
+}
+
+// CalculateFee requests the minimal fee of the transaction in its fee asset. The fee includes extra fees for the
+// sender's account script and smart assets, the fee and the proofs of the transaction are ignored.
+func (a *Transactions) CalculateFee(ctx context.Context, transaction proto.Transaction) (*CalculatedFee, *Response, error) {
+	url, err := joinUrl(a.options.BaseUrl, "/transactions/calculateFee")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bts, err := json.Marshal(transaction)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := http.NewRequest("POST", url.String(), bytes.NewReader(bts))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	out := new(CalculatedFee)
+	response, err := doHttp(ctx, a.options, req, out)
+	if err != nil {
+		return nil, response, err
+	}
+	return out, response, nil
+}
+
+// TransactionMerkleProof is the proof of transaction inclusion into the block's transactions root.
+type TransactionMerkleProof struct {
+	ID               crypto.Digest    `json:"id"`
+	TransactionIndex uint32           `json:"transactionIndex"`
+	MerkleProof      []proto.B58Bytes `json:"merkleProof"`
+}
+
+// MerkleProofs gets merkle proofs of transactions inclusion into blocks.
+func (a *Transactions) MerkleProofs(ctx context.Context, ids []crypto.Digest) ([]*TransactionMerkleProof, *Response, error) {
+	type merkleProofIDs struct {
+		IDs []crypto.Digest `json:"ids"`
+	}
+
+	url, err := joinUrl(a.options.BaseUrl, "/transactions/merkleProof")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	b := new(bytes.Buffer)
+	if err = json.NewEncoder(b).Encode(merkleProofIDs{IDs: ids}); err != nil {
+		return nil, nil, err
+	}
+
+	req, err := http.NewRequest("POST", url.String(), b)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var out []*TransactionMerkleProof
+	response, err := doHttp(ctx, a.options, req, &out)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return out, response, nil
+}
@@ -349,3 +349,28 @@ func TestTransactions_Unconfirmed(t *testing.T) {
 	assert.Equal(t, "https://testnodes.wavesnodes.com/transactions/unconfirmed", resp.Request.URL.String())
 	assert.Equal(t, uint64(300000), body[0].(*proto.ExchangeWithSig).Fee)
 }
+
+var transactionsMerkleProofJson = `
+[
+  {
+    "id": "B4hoL2R8SoWtmkbqjtDykSu3vZiuGkn4G7yYv5xkH4qs",
+    "transactionIndex": 2,
+    "merkleProof": ["2xa2", "3Mu"]
+  }
+]`
+
+func TestTransactions_MerkleProofs(t *testing.T) {
+	id := crypto.MustDigestFromBase58("B4hoL2R8SoWtmkbqjtDykSu3vZiuGkn4G7yYv5xkH4qs")
+	client, err := NewClient(Options{
+		Client:  NewMockHttpRequestFromString(transactionsMerkleProofJson, 200),
+		BaseUrl: "https://testnodes.wavesnodes.com",
+	})
+	require.NoError(t, err)
+	body, resp, err := client.Transactions.MerkleProofs(context.Background(), []crypto.Digest{id})
+	require.NoError(t, err)
+	require.Len(t, body, 1)
+	assert.Equal(t, id, body[0].ID)
+	assert.EqualValues(t, 2, body[0].TransactionIndex)
+	assert.Len(t, body[0].MerkleProof, 2)
+	assert.Equal(t, "https://testnodes.wavesnodes.com/transactions/merkleProof", resp.Request.URL.String())
+}
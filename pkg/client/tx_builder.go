@@ -0,0 +1,244 @@
+package client
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+// FeeUnit is the minimal fee unit in wavelets.
+const FeeUnit = 100000
+
+// TransactionBuilder creates transactions signed with the given key and with the minimal fee accepted
+// by the node. The builder requests network scheme from the node if ChainID is not set in client options.
+// The fee is calculated by the node with the current fee rules, including extra fees for the sender's account
+// script and smart assets and the conversion to the sponsored fee asset.
+//
+//	b := client.NewTransactionBuilder(c, sk, pk).WithFeeAsset(sponsoredAsset)
+//	tx, err := b.Transfer(ctx, recipient, proto.NewOptionalAssetWaves(), amount, nil)
+//	...
+//	_, err = c.Transactions.Broadcast(ctx, tx)
+//
+// The builder is not safe for concurrent use.
+type TransactionBuilder struct {
+	client    *Client
+	sk        crypto.SecretKey
+	pk        crypto.PublicKey
+	scheme    proto.Scheme
+	feeAsset  proto.OptionalAsset
+	fee       uint64
+	timestamp uint64
+}
+
+// NewTransactionBuilder creates builder of transactions signed by the given key pair.
+func NewTransactionBuilder(client *Client, sk crypto.SecretKey, pk crypto.PublicKey) *TransactionBuilder {
+	b := &TransactionBuilder{
+		client:   client,
+		sk:       sk,
+		pk:       pk,
+		feeAsset: proto.NewOptionalAssetWaves(),
+	}
+	if client.chainIDSet {
+		b.scheme = client.options.ChainID
+	}
+	return b
+}
+
+// WithScheme sets the network scheme, so it's not requested from the node.
+func (b *TransactionBuilder) WithScheme(scheme proto.Scheme) *TransactionBuilder {
+	b.scheme = scheme
+	return b
+}
+
+// WithFeeAsset sets the sponsored asset to pay fees in. Fee in asset is calculated using asset's sponsorship rate.
+func (b *TransactionBuilder) WithFeeAsset(asset proto.OptionalAsset) *TransactionBuilder {
+	b.feeAsset = asset
+	return b
+}
+
+// WithFee sets the fixed fee, so it's not calculated. Zero value means automatic fee calculation.
+func (b *TransactionBuilder) WithFee(fee uint64) *TransactionBuilder {
+	b.fee = fee
+	return b
+}
+
+// WithTimestamp sets the timestamp of transactions. Zero value means current time.
+func (b *TransactionBuilder) WithTimestamp(timestamp uint64) *TransactionBuilder {
+	b.timestamp = timestamp
+	return b
+}
+
+// Transfer creates signed Transfer transaction.
+func (b *TransactionBuilder) Transfer(
+	ctx context.Context, recipient proto.Recipient, asset proto.OptionalAsset, amount uint64, attachment proto.Attachment,
+) (*proto.TransferWithProofs, error) {
+	return build(ctx, b, func(fee, ts uint64) *proto.TransferWithProofs {
+		return proto.NewUnsignedTransferWithProofs(proto.MaxTransferTransactionVersion, b.pk, asset, b.feeAsset,
+			ts, amount, fee, recipient, attachment)
+	})
+}
+
+// MassTransfer creates signed MassTransfer transaction.
+func (b *TransactionBuilder) MassTransfer(
+	ctx context.Context, asset proto.OptionalAsset, transfers []proto.MassTransferEntry, attachment proto.Attachment,
+) (*proto.MassTransferWithProofs, error) {
+	if err := b.wavesFeeOnly(); err != nil {
+		return nil, err
+	}
+	return build(ctx, b, func(fee, ts uint64) *proto.MassTransferWithProofs {
+		return proto.NewUnsignedMassTransferWithProofs(proto.MaxMassTransferTransactionVersion, b.pk, asset,
+			transfers, fee, ts, attachment)
+	})
+}
+
+// Burn creates signed Burn transaction.
+func (b *TransactionBuilder) Burn(
+	ctx context.Context, assetID crypto.Digest, amount uint64,
+) (*proto.BurnWithProofs, error) {
+	if err := b.wavesFeeOnly(); err != nil {
+		return nil, err
+	}
+	return build(ctx, b, func(fee, ts uint64) *proto.BurnWithProofs {
+		return proto.NewUnsignedBurnWithProofs(proto.MaxBurnTransactionVersion, b.pk, assetID, amount, ts, fee)
+	})
+}
+
+// Lease creates signed Lease transaction.
+func (b *TransactionBuilder) Lease(
+	ctx context.Context, recipient proto.Recipient, amount uint64,
+) (*proto.LeaseWithProofs, error) {
+	if err := b.wavesFeeOnly(); err != nil {
+		return nil, err
+	}
+	return build(ctx, b, func(fee, ts uint64) *proto.LeaseWithProofs {
+		return proto.NewUnsignedLeaseWithProofs(proto.MaxLeaseTransactionVersion, b.pk, recipient, amount, fee, ts)
+	})
+}
+
+// LeaseCancel creates signed LeaseCancel transaction.
+func (b *TransactionBuilder) LeaseCancel(
+	ctx context.Context, leaseID crypto.Digest,
+) (*proto.LeaseCancelWithProofs, error) {
+	if err := b.wavesFeeOnly(); err != nil {
+		return nil, err
+	}
+	return build(ctx, b, func(fee, ts uint64) *proto.LeaseCancelWithProofs {
+		return proto.NewUnsignedLeaseCancelWithProofs(proto.MaxLeaseCancelTransactionVersion, b.pk, leaseID, fee, ts)
+	})
+}
+
+// Data creates signed Data transaction.
+func (b *TransactionBuilder) Data(ctx context.Context, entries proto.DataEntries) (*proto.DataWithProofs, error) {
+	if err := b.wavesFeeOnly(); err != nil {
+		return nil, err
+	}
+	return build(ctx, b, func(fee, ts uint64) *proto.DataWithProofs {
+		tx := proto.NewUnsignedDataWithProofs(proto.MaxDataTransactionVersion, b.pk, fee, ts)
+		tx.Entries = entries
+		return tx
+	})
+}
+
+// InvokeScript creates signed InvokeScript transaction. Note that the fee doesn't include extra fee for issue
+// actions of the called function, set the fee explicitly with WithFee in this case.
+func (b *TransactionBuilder) InvokeScript(
+	ctx context.Context, dApp proto.Recipient, call proto.FunctionCall, payments proto.ScriptPayments,
+) (*proto.InvokeScriptWithProofs, error) {
+	return build(ctx, b, func(fee, ts uint64) *proto.InvokeScriptWithProofs {
+		return proto.NewUnsignedInvokeScriptWithProofs(proto.MaxInvokeScriptTransactionVersion, b.pk, dApp, call,
+			payments, b.feeAsset, fee, ts)
+	})
+}
+
+// UpdateAssetInfo creates signed UpdateAssetInfo transaction. Note that the node accepts it only
+// after MinUpdateAssetInfoInterval blocks since the asset was issued or updated last time.
+func (b *TransactionBuilder) UpdateAssetInfo(
+	ctx context.Context, assetID crypto.Digest, name, description string,
+) (*proto.UpdateAssetInfoWithProofs, error) {
+	if err := b.wavesFeeOnly(); err != nil {
+		return nil, err
+	}
+	return build(ctx, b, func(fee, ts uint64) *proto.UpdateAssetInfoWithProofs {
+		return proto.NewUnsignedUpdateAssetInfoWithProofs(proto.MaxUpdateAssetInfoTransactionVersion, assetID, b.pk,
+			name, description, ts, b.feeAsset, fee)
+	})
+}
+
+func (b *TransactionBuilder) wavesFeeOnly() error {
+	if b.feeAsset.Present {
+		return errors.New("fee in sponsored asset is allowed only for Transfer and InvokeScript transactions")
+	}
+	return nil
+}
+
+type signable interface {
+	proto.Transaction
+	Sign(scheme proto.Scheme, sk crypto.SecretKey) error
+}
+
+// build creates transaction with the fee calculated by the node and signs it.
+func build[T signable](ctx context.Context, b *TransactionBuilder, create func(fee, timestamp uint64) T) (T, error) {
+	var zero T
+	scheme, err := b.networkScheme(ctx)
+	if err != nil {
+		return zero, err
+	}
+	ts := b.timestamp
+	if ts == 0 {
+		ts = proto.NewTimestampFromTime(time.Now())
+	}
+	fee := b.fee
+	if fee == 0 {
+		fee, err = b.calculateFee(ctx, create(0, ts))
+		if err != nil {
+			return zero, err
+		}
+	}
+	tx := create(fee, ts)
+	if sErr := tx.Sign(scheme, b.sk); sErr != nil {
+		return zero, errors.Wrap(sErr, "failed to sign transaction")
+	}
+	return tx, nil
+}
+
+func (b *TransactionBuilder) networkScheme(ctx context.Context) (proto.Scheme, error) {
+	if b.scheme != 0 {
+		return b.scheme, nil
+	}
+	h, _, err := b.client.Blocks.HeadersLast(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to request network scheme")
+	}
+	b.scheme = h.Generator.Scheme()
+	return b.scheme, nil
+}
+
+// calculateFee requests the minimal fee of the unsigned transaction in the fee asset from the node.
+func (b *TransactionBuilder) calculateFee(ctx context.Context, tx proto.Transaction) (uint64, error) {
+	f, _, err := b.client.Transactions.CalculateFee(ctx, tx)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to calculate fee")
+	}
+	if !f.FeeAssetID.Eq(b.feeAsset) {
+		return 0, errors.Errorf("asset '%s' is not sponsored", b.feeAsset.String())
+	}
+	return f.FeeAmount, nil
+}
+
+// FeeInSponsoredAsset converts fee in Waves to the fee in sponsored asset with the given minimal sponsored fee.
+// The result is rounded up, so the fee in asset is never less than the fee in Waves.
+func FeeInSponsoredAsset(wavesFee, minSponsoredAssetFee uint64) (uint64, error) {
+	r := new(big.Int).SetUint64(wavesFee)
+	r.Mul(r, new(big.Int).SetUint64(minSponsoredAssetFee))
+	r.Add(r, big.NewInt(FeeUnit-1))
+	r.Div(r, big.NewInt(FeeUnit))
+	if !r.IsUint64() {
+		return 0, errors.New("fee in sponsored asset overflows")
+	}
+	return r.Uint64(), nil
+}
@@ -0,0 +1,172 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+// feeDoer responds to fee calculation requests with the fee in the requested fee asset, the fee in Waves is
+// returned for the assets which are not sponsored. Other requests are served by the routes.
+type feeDoer struct {
+	routesDoer
+	fees     map[proto.OptionalAsset]uint64
+	mu       sync.Mutex
+	requests []string
+}
+
+func (d *feeDoer) Do(req *http.Request) (*http.Response, error) {
+	if req.URL.Path != "/transactions/calculateFee" {
+		return d.routesDoer.Do(req)
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	d.mu.Lock()
+	d.requests = append(d.requests, string(body))
+	d.mu.Unlock()
+	var tx struct {
+		FeeAssetID proto.OptionalAsset `json:"feeAssetId"`
+	}
+	if uErr := json.Unmarshal(body, &tx); uErr != nil {
+		return nil, uErr
+	}
+	asset := tx.FeeAssetID
+	fee, ok := d.fees[asset]
+	if !ok {
+		asset = proto.NewOptionalAssetWaves()
+		fee = d.fees[asset]
+	}
+	resp, err := json.Marshal(CalculatedFee{FeeAssetID: asset, FeeAmount: fee})
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{Request: req, StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(resp))}, nil
+}
+
+func TestTransactionBuilder_Transfer(t *testing.T) {
+	sk, pk, err := crypto.GenerateKeyPair([]byte("test seed"))
+	require.NoError(t, err)
+	sender, err := proto.NewAddressFromPublicKey(proto.TestNetScheme, pk)
+	require.NoError(t, err)
+	asset := *proto.NewOptionalAssetFromDigest(crypto.MustDigestFromBase58("CMBHKDtyE8GMbZAZANNeE5n2HU4VDpsQaBLmfCw9ASbf"))
+	other := *proto.NewOptionalAssetFromDigest(crypto.MustDigestFromBase58("9Bj5sZ1tUJ9gBQkvE5ikDAX5ZNBHqQqSmkozoH4yVSiW"))
+	d := &feeDoer{
+		routesDoer: routesDoer{routes: map[string]string{
+			"/blocks/headers/last": fmt.Sprintf(`{"height": 1, "generator": "%s"}`, sender.String()),
+		}},
+		fees: map[proto.OptionalAsset]uint64{proto.NewOptionalAssetWaves(): 500000, asset: 50},
+	}
+	// ChainID is not set, so the network scheme is requested from the node.
+	client, err := NewClient(Options{Client: d, BaseUrl: "https://testnodes.wavesnodes.com"})
+	require.NoError(t, err)
+
+	b := NewTransactionBuilder(client, sk, pk).WithTimestamp(1234567890)
+	tx, err := b.Transfer(context.Background(), proto.NewRecipientFromAddress(sender),
+		proto.NewOptionalAssetWaves(), 100, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 500000, tx.Fee)
+	assert.EqualValues(t, 1234567890, tx.Timestamp)
+	ok, err := tx.Verify(proto.TestNetScheme, pk)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	require.Len(t, d.requests, 1)
+	assert.Contains(t, d.requests[0], `"senderPublicKey":"`+pk.String()+`"`)
+
+	b.WithFeeAsset(asset)
+	tx, err = b.Transfer(context.Background(), proto.NewRecipientFromAddress(sender),
+		proto.NewOptionalAssetWaves(), 100, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 50, tx.Fee)
+	assert.Equal(t, asset, tx.FeeAsset)
+
+	_, err = b.WithFeeAsset(other).Transfer(context.Background(), proto.NewRecipientFromAddress(sender),
+		proto.NewOptionalAssetWaves(), 100, nil)
+	assert.Error(t, err, "fee in the asset which is not sponsored")
+
+	_, err = b.WithFeeAsset(asset).Lease(context.Background(), proto.NewRecipientFromAddress(sender), 100)
+	assert.Error(t, err)
+
+	b.WithFeeAsset(proto.NewOptionalAssetWaves()).WithFee(12345)
+	lease, err := b.Lease(context.Background(), proto.NewRecipientFromAddress(sender), 100)
+	require.NoError(t, err)
+	assert.EqualValues(t, 12345, lease.Fee)
+	assert.Len(t, d.requests, 3, "fee must not be requested if it's set")
+}
+
+func TestTransactionBuilder_Scheme(t *testing.T) {
+	sk, pk, err := crypto.GenerateKeyPair([]byte("test seed"))
+	require.NoError(t, err)
+	d := &feeDoer{fees: map[proto.OptionalAsset]uint64{proto.NewOptionalAssetWaves(): 100000}}
+	client, err := NewClient(Options{Client: d, BaseUrl: "https://testnodes.wavesnodes.com",
+		ChainID: proto.TestNetScheme})
+	require.NoError(t, err)
+
+	// Headers are not requested, the scheme is set explicitly.
+	tx, err := NewTransactionBuilder(client, sk, pk).Data(context.Background(),
+		proto.DataEntries{&proto.StringDataEntry{Key: "key", Value: "value"}})
+	require.NoError(t, err)
+	assert.EqualValues(t, 100000, tx.Fee)
+	ok, err := tx.Verify(proto.TestNetScheme, pk)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	client, err = NewClient(Options{Client: d, BaseUrl: "https://testnodes.wavesnodes.com"})
+	require.NoError(t, err)
+	_, err = NewTransactionBuilder(client, sk, pk).Data(context.Background(),
+		proto.DataEntries{&proto.StringDataEntry{Key: "key", Value: "value"}})
+	assert.Error(t, err, "default ChainID must not be used instead of the network scheme")
+}
+
+func TestTransactionBuilder_UpdateAssetInfo(t *testing.T) {
+	sk, pk, err := crypto.GenerateKeyPair([]byte("test seed"))
+	require.NoError(t, err)
+	asset := crypto.MustDigestFromBase58("CMBHKDtyE8GMbZAZANNeE5n2HU4VDpsQaBLmfCw9ASbf")
+	d := &feeDoer{fees: map[proto.OptionalAsset]uint64{proto.NewOptionalAssetWaves(): 500000}}
+	client, err := NewClient(Options{Client: d, BaseUrl: "https://testnodes.wavesnodes.com"})
+	require.NoError(t, err)
+
+	b := NewTransactionBuilder(client, sk, pk).WithScheme(proto.TestNetScheme)
+	tx, err := b.UpdateAssetInfo(context.Background(), asset, "new name", "new description")
+	require.NoError(t, err)
+	assert.EqualValues(t, 500000, tx.Fee)
+	assert.Equal(t, "new name", tx.Name)
+	assert.False(t, tx.FeeAsset.Present)
+	ok, err := tx.Verify(proto.TestNetScheme, pk)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	_, err = b.WithFeeAsset(*proto.NewOptionalAssetFromDigest(asset)).
+		UpdateAssetInfo(context.Background(), asset, "new name", "new description")
+	assert.Error(t, err)
+}
+
+func TestFeeInSponsoredAsset(t *testing.T) {
+	for _, test := range []struct {
+		waves, minFee, exp uint64
+	}{
+		{100000, 10, 10},
+		{500000, 10, 50},
+		{100000, 3, 3},
+		{100001, 3, 4},
+		{150000, 1, 2},
+	} {
+		fee, err := FeeInSponsoredAsset(test.waves, test.minFee)
+		require.NoError(t, err)
+		assert.Equal(t, test.exp, fee)
+	}
+	_, err := FeeInSponsoredAsset(1<<63, 1<<63)
+	assert.Error(t, err)
+}
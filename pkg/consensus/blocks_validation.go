@@ -4,7 +4,6 @@ import (
 	stderrs "errors"
 	"fmt"
 
-	"github.com/mr-tron/base58"
 	"github.com/pkg/errors"
 
 	"github.com/wavesplatform/gowaves/pkg/errs"
@@ -28,13 +27,6 @@ var mainNetInvalidBlocks = map[string]uint64{
 	"5uZoDnRKeWZV9Thu2nvJVZ5dBvPB7k2gvpzFD618FMXCbBVBMN2rRyvKBZBhAGnGdgeh2LXEeSr9bJqruJxngsE7": 813207,
 }
 
-func isInvalidMainNetBlock(blockID proto.BlockID, height uint64) bool {
-	if h, ok := mainNetInvalidBlocks[blockID.String()]; ok {
-		return h == height
-	}
-	return false
-}
-
 //go:generate moq -out validator_moq_test.go . stateInfoProvider
 type stateInfoProvider interface {
 	HeaderByHeight(height uint64) (*proto.BlockHeader, error)
@@ -196,22 +188,8 @@ func (cv *Validator) validateGeneratingBalance(header *proto.BlockHeader, balanc
 	if err != nil {
 		return err
 	}
-	if smallerGeneratingBalance {
-		if balance < generatingBalanceForGenerator2 {
-			return errors.Errorf(
-				"generator's generating balance is less than required for generation: expected %d, found %d",
-				generatingBalanceForGenerator2, balance,
-			)
-		}
-		return nil
-	}
-	if balance < generatingBalanceForGenerator1 {
-		return errors.Errorf(
-			"generator's generating balance is less than required for generation: expected %d, found %d",
-			generatingBalanceForGenerator1, balance,
-		)
-	}
-	return nil
+	return CheckGeneratingBalance(header, balance, cv.settings.MinimalGeneratingBalanceCheckAfterTime,
+		smallerGeneratingBalance)
 }
 
 func (cv *Validator) minerGeneratingBalance(height uint64, header *proto.BlockHeader) (uint64, error) {
@@ -340,25 +318,7 @@ func (cv *Validator) validateBaseTarget(height uint64, header, parent, greatGran
 	if err != nil {
 		return err
 	}
-	greatGrandParentTimestamp := uint64(0)
-	if greatGrandParent != nil {
-		greatGrandParentTimestamp = greatGrandParent.Timestamp
-	}
-	expectedTarget, err := pos.CalculateBaseTarget(
-		cv.settings.AverageBlockDelaySeconds,
-		height,
-		parent.BaseTarget,
-		parent.Timestamp,
-		greatGrandParentTimestamp,
-		header.Timestamp,
-	)
-	if err != nil {
-		return err
-	}
-	if expectedTarget != header.BaseTarget {
-		return errors.Errorf("declared base target %d does not match calculated base target %d", header.BaseTarget, expectedTarget)
-	}
-	return nil
+	return CheckBaseTarget(pos, cv.settings.AverageBlockDelaySeconds, height, header, parent, greatGrandParent)
 }
 
 func (cv *Validator) generateAndCheckNextHitSource(height uint64, header *proto.BlockHeader) ([]byte, PosCalculator, GenerationSignatureProvider, bool, error) {
@@ -374,35 +334,19 @@ func (cv *Validator) generateAndCheckNextHitSource(height uint64, header *proto.
 	if err != nil {
 		return nil, nil, nil, false, errors.Wrap(err, "failed to generate hit source")
 	}
+	refHeight := height
 	if vrf {
-		refGenSig, err := cv.state.NewestHitSourceAtHeight(pos.HeightForHit(height))
-		if err != nil {
-			return nil, nil, nil, false, errors.Wrap(err, "failed to generate hit source")
-		}
-		ok, hs, err := gsp.VerifyGenerationSignature(header.GeneratorPublicKey, refGenSig, header.GenSignature)
-		if err != nil {
-			return nil, nil, nil, false, errors.Wrap(err, "failed to validate hit source")
-		}
-		if !ok {
-			return nil, nil, nil, false, errors.Errorf("invalid hit source '%s' of block '%s' at height %d (ref gen-sig '%s'), with vrf",
-				header.GenSignature.String(), header.ID.String(), height, base58.Encode(refGenSig))
-		}
-		return hs, pos, gsp, vrf, nil
-	} else {
-		refGenSig, err := cv.state.NewestHitSourceAtHeight(height)
-		if err != nil {
-			return nil, nil, nil, false, errors.Wrap(err, "failed to generate hit source")
-		}
-		ok, hs, err := gsp.VerifyGenerationSignature(header.GeneratorPublicKey, refGenSig, header.GenSignature)
-		if err != nil {
-			return nil, nil, nil, false, errors.Wrap(err, "failed to validate hit source")
-		}
-		if !ok {
-			return nil, nil, nil, false, errors.Errorf("invalid hit source '%s' of block '%s' at height %d (ref gen-sig '%s'), without vrf",
-				header.GenSignature.String(), header.ID.String(), height, base58.Encode(refGenSig))
-		}
-		return hs, pos, gsp, vrf, nil
+		refHeight = pos.HeightForHit(height)
+	}
+	refGenSig, err := cv.state.NewestHitSourceAtHeight(refHeight)
+	if err != nil {
+		return nil, nil, nil, false, errors.Wrap(err, "failed to generate hit source")
 	}
+	hs, err := CheckGenerationSignature(gsp, header, refGenSig)
+	if err != nil {
+		return nil, nil, nil, false, errors.Wrapf(err, "at height %d, vrf %t", height, vrf)
+	}
+	return hs, pos, gsp, vrf, nil
 }
 
 func (cv *Validator) validateGeneratorSignatureAndBlockDelay(height uint64, header *proto.BlockHeader) error {
@@ -420,7 +364,7 @@ func (cv *Validator) validateGeneratorSignatureAndBlockDelay(height uint64, head
 			return errors.Wrap(err, "failed to validate generation signature")
 		}
 	}
-	if cv.settings.Type == settings.MainNet && isInvalidMainNetBlock(header.BlockID(), height) {
+	if cv.settings.Type == settings.MainNet && IsInvalidMainNetBlock(header.BlockID(), height) {
 		return nil
 	}
 	parent, err := cv.headerByHeight(height)
@@ -434,20 +378,8 @@ func (cv *Validator) validateGeneratorSignatureAndBlockDelay(height uint64, head
 	if gbErr := cv.validateGeneratingBalance(header, generatingBalance, height); gbErr != nil {
 		return errors.Wrapf(gbErr, "invalid generating balance at height %d", height)
 	}
-	hit, err := GenHit(hitSource)
-	if err != nil {
-		return err
-	}
-	delay, err := pos.CalculateDelay(hit, parent.BaseTarget, generatingBalance)
-	if err != nil {
-		return errors.Wrap(err, "failed to calculate valid block delay")
-	}
-	minTimestamp := parent.Timestamp + delay
-	if header.Timestamp < minTimestamp {
-		return errors.Errorf(
-			"block '%s' at %d: invalid block timestamp %d: less than min valid timestamp %d (hit source %s)",
-			header.ID.String(), height, header.Timestamp, minTimestamp, base58.Encode(hitSource),
-		)
+	if dErr := CheckBlockDelay(pos, hitSource, header, parent, generatingBalance); dErr != nil {
+		return errors.Wrapf(dErr, "at height %d", height)
 	}
 	return nil
 }
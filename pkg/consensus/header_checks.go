@@ -0,0 +1,110 @@
+package consensus
+
+import (
+	"github.com/mr-tron/base58"
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+// The functions below implement consensus checks of block headers which don't depend on the blockchain state.
+// All the data the checks need, including the rules active at the height of the block, is passed explicitly,
+// so the checks are shared by Validator and header verifiers working without the state.
+
+// IsInvalidMainNetBlock reports whether the block is one of the MainNet blocks which don't pass generator's
+// checks but are already in the blockchain.
+func IsInvalidMainNetBlock(blockID proto.BlockID, height uint64) bool {
+	if h, ok := mainNetInvalidBlocks[blockID.String()]; ok {
+		return h == height
+	}
+	return false
+}
+
+// CheckGenerationSignature verifies the generation signature of the header made over the reference generation
+// signature and returns the output of the verification. In case of VRF the output is the hit source of the block.
+func CheckGenerationSignature(
+	gsp GenerationSignatureProvider, header *proto.BlockHeader, refGenSig []byte,
+) ([]byte, error) {
+	ok, out, err := gsp.VerifyGenerationSignature(header.GeneratorPublicKey, refGenSig, header.GenSignature)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to validate hit source")
+	}
+	if !ok {
+		return nil, errors.Errorf("invalid hit source '%s' of block '%s' (ref gen-sig '%s')",
+			header.GenSignature.String(), header.ID.String(), base58.Encode(refGenSig))
+	}
+	return out, nil
+}
+
+// CheckBaseTarget checks that the base target of the header equals to the one calculated from the parent and
+// the great-grandparent headers. The height is the height of the parent block, greatGrandParent is nil
+// if there is no such block.
+func CheckBaseTarget(
+	pos PosCalculator, averageBlockDelaySeconds, height uint64, header, parent, greatGrandParent *proto.BlockHeader,
+) error {
+	greatGrandParentTimestamp := uint64(0)
+	if greatGrandParent != nil {
+		greatGrandParentTimestamp = greatGrandParent.Timestamp
+	}
+	expectedTarget, err := pos.CalculateBaseTarget(
+		averageBlockDelaySeconds,
+		height,
+		parent.BaseTarget,
+		parent.Timestamp,
+		greatGrandParentTimestamp,
+		header.Timestamp,
+	)
+	if err != nil {
+		return err
+	}
+	if expectedTarget != header.BaseTarget {
+		return errors.Errorf("declared base target %d does not match calculated base target %d",
+			header.BaseTarget, expectedTarget)
+	}
+	return nil
+}
+
+// CheckGeneratingBalance checks that the generating balance of the block's generator is enough to generate blocks.
+// The check is applied to blocks generated after minimalBalanceCheckAfterTime, the required balance is lowered by
+// the SmallerMinimalGeneratingBalance feature.
+func CheckGeneratingBalance(
+	header *proto.BlockHeader, balance, minimalBalanceCheckAfterTime uint64, smallerMinimalBalance bool,
+) error {
+	if header.Timestamp < minimalBalanceCheckAfterTime {
+		return nil
+	}
+	required := generatingBalanceForGenerator1
+	if smallerMinimalBalance {
+		required = generatingBalanceForGenerator2
+	}
+	if balance < required {
+		return errors.Errorf(
+			"generator's generating balance is less than required for generation: expected %d, found %d",
+			required, balance,
+		)
+	}
+	return nil
+}
+
+// CheckBlockDelay checks that the block is generated not earlier than the delay calculated from the generator's hit
+// and generating balance allows.
+func CheckBlockDelay(
+	pos PosCalculator, hitSource []byte, header, parent *proto.BlockHeader, generatingBalance uint64,
+) error {
+	hit, err := GenHit(hitSource)
+	if err != nil {
+		return err
+	}
+	delay, err := pos.CalculateDelay(hit, parent.BaseTarget, generatingBalance)
+	if err != nil {
+		return errors.Wrap(err, "failed to calculate valid block delay")
+	}
+	minTimestamp := parent.Timestamp + delay
+	if header.Timestamp < minTimestamp {
+		return errors.Errorf(
+			"block '%s': invalid block timestamp %d: less than min valid timestamp %d (hit source %s)",
+			header.ID.String(), header.Timestamp, minTimestamp, base58.Encode(hitSource),
+		)
+	}
+	return nil
+}
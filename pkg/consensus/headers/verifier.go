@@ -0,0 +1,201 @@
+// Package headers verifies block headers against the consensus rules without the blockchain state of a node.
+//
+// Light clients and monitoring tools receiving headers from untrusted sources use Verifier to check the block
+// signature, the reference to the parent block, the generation signature (VRF since BlockV5), the base target and
+// the block delay allowed by the generator's hit and generating balance. The data of previous blocks, activated
+// features and generating balances are provided by ChainInfo, which is implemented by the node's state too.
+//
+// Checks which depend on the local clock (timestamps from the future) and on the blockchain state beyond ChainInfo
+// (block versions, scripted generator accounts) are left to the caller.
+package headers
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/consensus"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+)
+
+// ChainInfo provides the data of already verified blocks required to verify the next header.
+type ChainInfo interface {
+	// HeaderByHeight returns the header of the block at the given height.
+	HeaderByHeight(height proto.Height) (*proto.BlockHeader, error)
+	// HitSourceAtHeight returns the hit source of the block at the given height, as returned by Verifier.Verify.
+	HitSourceAtHeight(height proto.Height) ([]byte, error)
+	// IsActiveAtHeight reports whether the feature is active at the given height.
+	IsActiveAtHeight(featureID int16, height proto.Height) (bool, error)
+	// GeneratingBalance returns the generating balance of the account at the given height.
+	GeneratingBalance(account proto.Recipient, height proto.Height) (uint64, error)
+}
+
+// Verifier verifies headers of the blockchain with the given settings.
+type Verifier struct {
+	settings *settings.BlockchainSettings
+	chain    ChainInfo
+}
+
+// NewVerifier creates Verifier which takes the data of previous blocks from the chain.
+func NewVerifier(settings *settings.BlockchainSettings, chain ChainInfo) *Verifier {
+	return &Verifier{settings: settings, chain: chain}
+}
+
+// Verify checks the header of the block at the given height, the parent block must be available from ChainInfo.
+// On success the hit source of the block is returned, it has to be provided by ChainInfo to verify next headers.
+func (v *Verifier) Verify(header *proto.BlockHeader, height proto.Height) ([]byte, error) {
+	if height < 2 {
+		return nil, errors.Errorf("invalid height %d, the genesis block can't be verified", height)
+	}
+	parentHeight := height - 1
+	parent, err := v.chain.HeaderByHeight(parentHeight)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get parent header at height %d", parentHeight)
+	}
+	if err = v.verifyIdentity(header, parent); err != nil {
+		return nil, err
+	}
+	pos, err := v.posAlgo(parentHeight)
+	if err != nil {
+		return nil, err
+	}
+	vrf, err := v.chain.IsActiveAtHeight(int16(settings.BlockV5), height)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check BlockV5 activation")
+	}
+	gsp, refHeight := consensus.NXTGenerationSignatureProvider, parentHeight
+	if vrf {
+		gsp, refHeight = consensus.VRFGenerationSignatureProvider, pos.HeightForHit(parentHeight)
+	}
+	refGenSig, err := v.chain.HitSourceAtHeight(refHeight)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get hit source at height %d", refHeight)
+	}
+	hitSource, err := consensus.CheckGenerationSignature(gsp, header, refGenSig)
+	if err != nil {
+		return nil, err
+	}
+	if err = v.verifyBaseTarget(header, parent, parentHeight, pos); err != nil {
+		return nil, err
+	}
+	if v.settings.Type == settings.MainNet && consensus.IsInvalidMainNetBlock(header.BlockID(), parentHeight) {
+		return hitSource, nil
+	}
+	delayHitSource := hitSource
+	if !vrf {
+		prevHitSource, hsErr := v.chain.HitSourceAtHeight(pos.HeightForHit(parentHeight))
+		if hsErr != nil {
+			return nil, errors.Wrap(hsErr, "failed to get hit source")
+		}
+		delayHitSource, err = gsp.HitSource(header.GeneratorPublicKey, prevHitSource)
+		if err != nil {
+			return nil, err
+		}
+	}
+	balance, err := v.generatingBalance(header, parentHeight)
+	if err != nil {
+		return nil, err
+	}
+	smaller, err := v.chain.IsActiveAtHeight(int16(settings.SmallerMinimalGeneratingBalance), parentHeight)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check SmallerMinimalGeneratingBalance activation")
+	}
+	if err = consensus.CheckGeneratingBalance(header, balance, v.settings.MinimalGeneratingBalanceCheckAfterTime,
+		smaller); err != nil {
+		return nil, err
+	}
+	if err = consensus.CheckBlockDelay(pos, delayHitSource, header, parent, balance); err != nil {
+		return nil, err
+	}
+	return hitSource, nil
+}
+
+func (v *Verifier) verifyIdentity(header, parent *proto.BlockHeader) error {
+	if header.Parent != parent.BlockID() {
+		return errors.Errorf("block '%s' doesn't reference parent block '%s'", header.BlockID().String(),
+			parent.BlockID().String())
+	}
+	h := *header
+	if err := h.GenerateBlockID(v.settings.AddressSchemeCharacter); err != nil {
+		return errors.Wrap(err, "failed to generate block ID")
+	}
+	if h.BlockID() != header.BlockID() {
+		return errors.Errorf("block ID '%s' doesn't match the header", header.BlockID().String())
+	}
+	ok, err := (&proto.Block{BlockHeader: h}).VerifySignature(v.settings.AddressSchemeCharacter)
+	if err != nil {
+		return errors.Wrap(err, "failed to verify block signature")
+	}
+	if !ok {
+		return errors.Errorf("invalid signature of block '%s'", header.BlockID().String())
+	}
+	return nil
+}
+
+func (v *Verifier) posAlgo(height proto.Height) (consensus.PosCalculator, error) {
+	fair, err := v.chain.IsActiveAtHeight(int16(settings.FairPoS), height)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check FairPoS activation")
+	}
+	if !fair {
+		return consensus.NXTPosCalculator, nil
+	}
+	blockV5, err := v.chain.IsActiveAtHeight(int16(settings.BlockV5), height)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check BlockV5 activation")
+	}
+	if blockV5 {
+		return consensus.NewFairPosCalculator(v.settings.DelayDelta, v.settings.MinBlockTime), nil
+	}
+	return consensus.FairPosCalculatorV1, nil
+}
+
+func (v *Verifier) verifyBaseTarget(
+	header, parent *proto.BlockHeader, parentHeight proto.Height, pos consensus.PosCalculator,
+) error {
+	fair, err := v.chain.IsActiveAtHeight(int16(settings.FairPoS), parentHeight)
+	if err != nil {
+		return errors.Wrap(err, "failed to check FairPoS activation")
+	}
+	if fair && header.BaseTarget >= v.settings.MaxBaseTarget {
+		return errors.New("base target is greater than maximum value from blockchain settings")
+	}
+	var greatGrandParent *proto.BlockHeader
+	if parentHeight > 2 {
+		greatGrandParent, err = v.chain.HeaderByHeight(parentHeight - 2)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get header at height %d", parentHeight-2)
+		}
+	}
+	return consensus.CheckBaseTarget(pos, v.settings.AverageBlockDelaySeconds, parentHeight, header, parent,
+		greatGrandParent)
+}
+
+// generatingBalance returns the generating balance of the block's generator, the balance of the generator of
+// the challenged header is added as the challenger's bonus.
+func (v *Verifier) generatingBalance(header *proto.BlockHeader, height proto.Height) (uint64, error) {
+	balance, err := v.accountGeneratingBalance(header.GeneratorPublicKey, height)
+	if err != nil {
+		return 0, err
+	}
+	if ch, ok := header.GetChallengedHeader(); ok {
+		bonus, bErr := v.accountGeneratingBalance(ch.GeneratorPublicKey, height)
+		if bErr != nil {
+			return 0, bErr
+		}
+		balance += bonus
+	}
+	return balance, nil
+}
+
+func (v *Verifier) accountGeneratingBalance(pk crypto.PublicKey, height proto.Height) (uint64, error) {
+	addr, err := proto.NewAddressFromPublicKey(v.settings.AddressSchemeCharacter, pk)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get generator address")
+	}
+	balance, err := v.chain.GeneratingBalance(proto.NewRecipientFromAddress(addr), height)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to get generating balance of %s", addr.String())
+	}
+	return balance, nil
+}
@@ -0,0 +1,158 @@
+package headers
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/consensus"
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+	"github.com/wavesplatform/gowaves/pkg/state"
+)
+
+// The state of the node provides ChainInfo.
+var _ ChainInfo = state.StateInfo(nil)
+
+const generatingBalance = 5_000_000_000_000
+
+type testChain struct {
+	headers    []*proto.BlockHeader
+	hitSources [][]byte
+	balances   map[proto.WavesAddress]uint64
+}
+
+func (c *testChain) HeaderByHeight(height proto.Height) (*proto.BlockHeader, error) {
+	if height < 1 || height > uint64(len(c.headers)) {
+		return nil, errors.Errorf("no header at height %d", height)
+	}
+	return c.headers[height-1], nil
+}
+
+func (c *testChain) HitSourceAtHeight(height proto.Height) ([]byte, error) {
+	if height < 1 || height > uint64(len(c.hitSources)) {
+		return nil, errors.Errorf("no hit source at height %d", height)
+	}
+	return c.hitSources[height-1], nil
+}
+
+func (c *testChain) IsActiveAtHeight(int16, proto.Height) (bool, error) {
+	return true, nil
+}
+
+func (c *testChain) GeneratingBalance(account proto.Recipient, _ proto.Height) (uint64, error) {
+	return c.balances[*account.Address()], nil
+}
+
+type generator struct {
+	sk crypto.SecretKey
+	pk crypto.PublicKey
+}
+
+func newGenerator(t *testing.T, seed string) generator {
+	sk, pk, err := crypto.GenerateKeyPair([]byte(seed))
+	require.NoError(t, err)
+	return generator{sk: sk, pk: pk}
+}
+
+// nextHeader creates a valid header of the next block of the chain, the header can be modified by the function
+// before signing.
+func nextHeader(
+	t *testing.T, sets *settings.BlockchainSettings, chain *testChain, g generator, modify func(*proto.BlockHeader),
+) *proto.BlockHeader {
+	scheme := sets.AddressSchemeCharacter
+	parentHeight := uint64(len(chain.headers))
+	parent := chain.headers[parentHeight-1]
+	pos := consensus.NewFairPosCalculator(sets.DelayDelta, sets.MinBlockTime)
+	gsp := consensus.VRFGenerationSignatureProvider
+	ref := chain.hitSources[pos.HeightForHit(parentHeight)-1]
+	genSig, err := gsp.GenerationSignature(g.sk, ref)
+	require.NoError(t, err)
+	hs, err := gsp.HitSource(g.sk, ref)
+	require.NoError(t, err)
+	hit, err := consensus.GenHit(hs)
+	require.NoError(t, err)
+	delay, err := pos.CalculateDelay(hit, parent.BaseTarget, generatingBalance)
+	require.NoError(t, err)
+	ts := parent.Timestamp + delay
+	var ggpTimestamp uint64
+	if parentHeight > 2 {
+		ggpTimestamp = chain.headers[parentHeight-3].Timestamp
+	}
+	bt, err := pos.CalculateBaseTarget(sets.AverageBlockDelaySeconds, parentHeight, parent.BaseTarget,
+		parent.Timestamp, ggpTimestamp, ts)
+	require.NoError(t, err)
+	b, err := proto.CreateBlock(nil, ts, parent.BlockID(), g.pk,
+		proto.NxtConsensus{BaseTarget: bt, GenSignature: genSig}, proto.ProtobufBlockVersion, nil, -1, scheme, nil)
+	require.NoError(t, err)
+	if modify != nil {
+		modify(&b.BlockHeader)
+		require.NoError(t, b.GenerateBlockID(scheme))
+	}
+	require.NoError(t, b.Sign(scheme, g.sk))
+	return &b.BlockHeader
+}
+
+func newTestChain(t *testing.T, sets *settings.BlockchainSettings, g generator) *testChain {
+	genesis, err := proto.CreateBlock(nil, 1_600_000_000_000, proto.NewBlockIDFromDigest(crypto.Digest{}), g.pk,
+		proto.NxtConsensus{BaseTarget: 100, GenSignature: make([]byte, crypto.DigestSize)},
+		proto.ProtobufBlockVersion, nil, -1, sets.AddressSchemeCharacter, nil)
+	require.NoError(t, err)
+	require.NoError(t, genesis.Sign(sets.AddressSchemeCharacter, g.sk))
+	addr, err := proto.NewAddressFromPublicKey(sets.AddressSchemeCharacter, g.pk)
+	require.NoError(t, err)
+	return &testChain{
+		headers:    []*proto.BlockHeader{&genesis.BlockHeader},
+		hitSources: [][]byte{make([]byte, crypto.DigestSize)},
+		balances:   map[proto.WavesAddress]uint64{addr: generatingBalance},
+	}
+}
+
+func TestVerifier(t *testing.T) {
+	sets := settings.MustTestNetSettings()
+	g := newGenerator(t, "generator")
+	chain := newTestChain(t, sets, g)
+	v := NewVerifier(sets, chain)
+	for height := uint64(2); height <= 6; height++ {
+		h := nextHeader(t, sets, chain, g, nil)
+		hs, err := v.Verify(h, height)
+		require.NoError(t, err, "height %d", height)
+		chain.headers = append(chain.headers, h)
+		chain.hitSources = append(chain.hitSources, hs)
+	}
+}
+
+func TestVerifierInvalidHeaders(t *testing.T) {
+	sets := settings.MustTestNetSettings()
+	g := newGenerator(t, "generator")
+	chain := newTestChain(t, sets, g)
+	v := NewVerifier(sets, chain)
+	for _, test := range []struct {
+		name   string
+		modify func(*proto.BlockHeader)
+	}{
+		{"wrong parent", func(h *proto.BlockHeader) { h.Parent = proto.NewBlockIDFromDigest(crypto.Digest{1}) }},
+		{"wrong generation signature", func(h *proto.BlockHeader) { h.GenSignature[0] ^= 0xff }},
+		{"wrong base target", func(h *proto.BlockHeader) { h.BaseTarget++ }},
+		{"early timestamp", func(h *proto.BlockHeader) { h.Timestamp-- }},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			h := nextHeader(t, sets, chain, g, test.modify)
+			_, err := v.Verify(h, 2)
+			assert.Error(t, err)
+		})
+	}
+
+	h := nextHeader(t, sets, chain, g, nil)
+	h.BlockSignature[0] ^= 0xff
+	_, err := v.Verify(h, 2)
+	assert.Error(t, err, "invalid signature")
+
+	poor := newGenerator(t, "poor generator")
+	h = nextHeader(t, sets, chain, poor, nil)
+	_, err = v.Verify(h, 2)
+	assert.Error(t, err, "insufficient generating balance")
+}
@@ -0,0 +1,320 @@
+package crypto
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+	"math/big"
+
+	curveBls12 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/mr-tron/base58/base58"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/wavesplatform/gowaves/pkg/util/common"
+)
+
+// BLS signatures over BLS12-381 curve as defined in the IETF draft "BLS Signatures" (draft-irtf-cfrg-bls-signature-05)
+// with minimal public key size variant (public keys in G1, signatures in G2) and proof of possession scheme,
+// which makes aggregation of signatures of the same message safe against rogue key attacks.
+// The same ciphersuite is used by Ethereum consensus layer.
+
+const (
+	BLSSecretKeySize = 32
+	BLSPublicKeySize = curveBls12.SizeOfG1AffineCompressed
+	BLSSignatureSize = curveBls12.SizeOfG2AffineCompressed
+
+	blsKeyGenSalt   = "BLS-SIG-KEYGEN-SALT-"
+	blsMinIKMLength = 32
+)
+
+var (
+	blsSignatureDST = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+	blsPoPDST       = []byte("BLS_POP_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+)
+
+type BLSSecretKey [BLSSecretKeySize]byte
+
+type BLSPublicKey [BLSPublicKeySize]byte
+
+type BLSSignature [BLSSignatureSize]byte
+
+// GenerateBLSSecretKey deterministically derives the secret key from the input key material (seed) of at least
+// 32 bytes, as described by KeyGen procedure of the IETF draft.
+func GenerateBLSSecretKey(ikm []byte) (BLSSecretKey, error) {
+	if len(ikm) < blsMinIKMLength {
+		return BLSSecretKey{}, errors.Errorf("input key material must be at least %d bytes long", blsMinIKMLength)
+	}
+	const l = 48 // ceil((3 * ceil(log2(r))) / 16)
+	ikmZero := make([]byte, len(ikm)+1)
+	copy(ikmZero, ikm)
+	info := binary.BigEndian.AppendUint16(nil, l)
+	salt := []byte(blsKeyGenSalt)
+	r := fr.Modulus()
+	for {
+		h := sha256.Sum256(salt)
+		salt = h[:]
+		okm := make([]byte, l)
+		if _, err := io.ReadFull(hkdf.New(sha256.New, ikmZero, salt, info), okm); err != nil {
+			return BLSSecretKey{}, errors.Wrap(err, "failed to derive BLS secret key")
+		}
+		sk := new(big.Int).Mod(new(big.Int).SetBytes(okm), r)
+		if sk.Sign() != 0 {
+			var res BLSSecretKey
+			sk.FillBytes(res[:])
+			return res, nil
+		}
+	}
+}
+
+func NewBLSSecretKeyFromBytes(b []byte) (BLSSecretKey, error) {
+	var sk BLSSecretKey
+	if l := len(b); l != BLSSecretKeySize {
+		return sk, NewIncorrectLengthError("BLSSecretKey", l, BLSSecretKeySize)
+	}
+	copy(sk[:], b)
+	if _, err := sk.scalar(); err != nil {
+		return BLSSecretKey{}, err
+	}
+	return sk, nil
+}
+
+func (k BLSSecretKey) scalar() (*big.Int, error) {
+	s := new(big.Int).SetBytes(k[:])
+	if s.Sign() == 0 || s.Cmp(fr.Modulus()) >= 0 {
+		return nil, errors.New("invalid BLS secret key")
+	}
+	return s, nil
+}
+
+// PublicKey returns the public key of the secret key.
+func (k BLSSecretKey) PublicKey() (BLSPublicKey, error) {
+	s, err := k.scalar()
+	if err != nil {
+		return BLSPublicKey{}, err
+	}
+	var p curveBls12.G1Affine
+	p.ScalarMultiplicationBase(s)
+	return p.Bytes(), nil
+}
+
+// Sign creates the signature of the message.
+func (k BLSSecretKey) Sign(message []byte) (BLSSignature, error) {
+	return k.sign(message, blsSignatureDST)
+}
+
+// ProvePossession creates the proof of possession of the secret key, which is the signature of the public key.
+// Proofs should be checked for all the public keys before aggregation.
+func (k BLSSecretKey) ProvePossession() (BLSSignature, error) {
+	pk, err := k.PublicKey()
+	if err != nil {
+		return BLSSignature{}, err
+	}
+	return k.sign(pk[:], blsPoPDST)
+}
+
+func (k BLSSecretKey) sign(message, dst []byte) (BLSSignature, error) {
+	s, err := k.scalar()
+	if err != nil {
+		return BLSSignature{}, err
+	}
+	h, err := curveBls12.HashToG2(message, dst)
+	if err != nil {
+		return BLSSignature{}, errors.Wrap(err, "failed to hash message to curve")
+	}
+	var sig curveBls12.G2Affine
+	sig.ScalarMultiplication(&h, s)
+	return sig.Bytes(), nil
+}
+
+func NewBLSPublicKeyFromBytes(b []byte) (BLSPublicKey, error) {
+	var pk BLSPublicKey
+	if l := len(b); l != BLSPublicKeySize {
+		return pk, NewIncorrectLengthError("BLSPublicKey", l, BLSPublicKeySize)
+	}
+	copy(pk[:], b)
+	if _, err := pk.point(); err != nil {
+		return BLSPublicKey{}, err
+	}
+	return pk, nil
+}
+
+func NewBLSPublicKeyFromBase58(s string) (BLSPublicKey, error) {
+	b, err := base58.Decode(s)
+	if err != nil {
+		return BLSPublicKey{}, errors.Wrap(err, "invalid Base58 string")
+	}
+	return NewBLSPublicKeyFromBytes(b)
+}
+
+// point decodes the public key and checks that it's a valid point of G1 subgroup and not the identity element.
+func (k BLSPublicKey) point() (*curveBls12.G1Affine, error) {
+	var p curveBls12.G1Affine
+	if _, err := p.SetBytes(k[:]); err != nil {
+		return nil, errors.Wrap(err, "invalid BLS public key")
+	}
+	if p.IsInfinity() {
+		return nil, errors.New("invalid BLS public key: identity element")
+	}
+	return &p, nil
+}
+
+func (k BLSPublicKey) Bytes() []byte {
+	return k[:]
+}
+
+func (k BLSPublicKey) String() string {
+	return base58.Encode(k[:])
+}
+
+func (k BLSPublicKey) MarshalJSON() ([]byte, error) {
+	return common.ToBase58JSON(k[:]), nil
+}
+
+func (k *BLSPublicKey) UnmarshalJSON(value []byte) error {
+	b, err := common.FromBase58JSON(value, BLSPublicKeySize, "BLSPublicKey")
+	if err != nil {
+		return err
+	}
+	copy(k[:], b)
+	return nil
+}
+
+func NewBLSSignatureFromBytes(b []byte) (BLSSignature, error) {
+	var sig BLSSignature
+	if l := len(b); l != BLSSignatureSize {
+		return sig, NewIncorrectLengthError("BLSSignature", l, BLSSignatureSize)
+	}
+	copy(sig[:], b)
+	if _, err := sig.point(); err != nil {
+		return BLSSignature{}, err
+	}
+	return sig, nil
+}
+
+func NewBLSSignatureFromBase58(s string) (BLSSignature, error) {
+	b, err := base58.Decode(s)
+	if err != nil {
+		return BLSSignature{}, errors.Wrap(err, "invalid Base58 string")
+	}
+	return NewBLSSignatureFromBytes(b)
+}
+
+// point decodes the signature and checks that it's a valid point of G2 subgroup.
+func (s BLSSignature) point() (*curveBls12.G2Affine, error) {
+	var p curveBls12.G2Affine
+	if _, err := p.SetBytes(s[:]); err != nil {
+		return nil, errors.Wrap(err, "invalid BLS signature")
+	}
+	return &p, nil
+}
+
+func (s BLSSignature) Bytes() []byte {
+	return s[:]
+}
+
+func (s BLSSignature) String() string {
+	return base58.Encode(s[:])
+}
+
+func (s BLSSignature) MarshalJSON() ([]byte, error) {
+	return common.ToBase58JSON(s[:]), nil
+}
+
+func (s *BLSSignature) UnmarshalJSON(value []byte) error {
+	b, err := common.FromBase58JSON(value, BLSSignatureSize, "BLSSignature")
+	if err != nil {
+		return err
+	}
+	copy(s[:], b)
+	return nil
+}
+
+// BLSVerify checks the signature of the message. Invalid keys and signatures are reported as failed verification.
+func BLSVerify(pk BLSPublicKey, message []byte, sig BLSSignature) bool {
+	return blsCoreAggregateVerify([]BLSPublicKey{pk}, [][]byte{message}, sig, blsSignatureDST)
+}
+
+// BLSVerifyPossession checks the proof of possession of the secret key of the public key.
+func BLSVerifyPossession(pk BLSPublicKey, proof BLSSignature) bool {
+	return blsCoreAggregateVerify([]BLSPublicKey{pk}, [][]byte{pk[:]}, proof, blsPoPDST)
+}
+
+// BLSAggregateSignatures combines the signatures into one.
+func BLSAggregateSignatures(sigs []BLSSignature) (BLSSignature, error) {
+	if len(sigs) == 0 {
+		return BLSSignature{}, errors.New("no signatures to aggregate")
+	}
+	var acc curveBls12.G2Affine
+	for i, s := range sigs {
+		p, err := s.point()
+		if err != nil {
+			return BLSSignature{}, errors.Wrapf(err, "signature #%d", i)
+		}
+		acc.Add(&acc, p)
+	}
+	return acc.Bytes(), nil
+}
+
+// BLSAggregatePublicKeys combines the public keys into one, which could be used to verify the aggregated signature
+// of the same message. Proofs of possession of all the keys must be verified before aggregation.
+func BLSAggregatePublicKeys(pks []BLSPublicKey) (BLSPublicKey, error) {
+	if len(pks) == 0 {
+		return BLSPublicKey{}, errors.New("no public keys to aggregate")
+	}
+	var acc curveBls12.G1Affine
+	for i, pk := range pks {
+		p, err := pk.point()
+		if err != nil {
+			return BLSPublicKey{}, errors.Wrapf(err, "public key #%d", i)
+		}
+		acc.Add(&acc, p)
+	}
+	return acc.Bytes(), nil
+}
+
+// BLSFastAggregateVerify checks the aggregated signature of the same message by several signers.
+func BLSFastAggregateVerify(pks []BLSPublicKey, message []byte, sig BLSSignature) bool {
+	pk, err := BLSAggregatePublicKeys(pks)
+	if err != nil {
+		return false
+	}
+	return BLSVerify(pk, message, sig)
+}
+
+// BLSAggregateVerify checks the aggregated signature of different messages, i-th message is signed by i-th key.
+func BLSAggregateVerify(pks []BLSPublicKey, messages [][]byte, sig BLSSignature) bool {
+	return blsCoreAggregateVerify(pks, messages, sig, blsSignatureDST)
+}
+
+func blsCoreAggregateVerify(pks []BLSPublicKey, messages [][]byte, sig BLSSignature, dst []byte) bool {
+	if len(pks) == 0 || len(pks) != len(messages) {
+		return false
+	}
+	s, err := sig.point()
+	if err != nil {
+		return false
+	}
+	_, _, g1, _ := curveBls12.Generators()
+	var negG1 curveBls12.G1Affine
+	negG1.Neg(&g1)
+	g1s := make([]curveBls12.G1Affine, 0, len(pks)+1)
+	g2s := make([]curveBls12.G2Affine, 0, len(pks)+1)
+	g1s = append(g1s, negG1)
+	g2s = append(g2s, *s)
+	for i, pk := range pks {
+		p, pErr := pk.point()
+		if pErr != nil {
+			return false
+		}
+		h, hErr := curveBls12.HashToG2(messages[i], dst)
+		if hErr != nil {
+			return false
+		}
+		g1s = append(g1s, *p)
+		g2s = append(g2s, h)
+	}
+	ok, err := curveBls12.PairingCheck(g1s, g2s)
+	return err == nil && ok
+}
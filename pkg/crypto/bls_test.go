@@ -0,0 +1,131 @@
+package crypto
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func blsTestKeys(t *testing.T, n int) ([]BLSSecretKey, []BLSPublicKey) {
+	sks := make([]BLSSecretKey, n)
+	pks := make([]BLSPublicKey, n)
+	for i := range n {
+		sk, err := GenerateBLSSecretKey([]byte(fmt.Sprintf("input key material of the test key #%d", i)))
+		require.NoError(t, err)
+		pk, err := sk.PublicKey()
+		require.NoError(t, err)
+		sks[i], pks[i] = sk, pk
+	}
+	return sks, pks
+}
+
+func TestGenerateBLSSecretKey(t *testing.T) {
+	ikm := []byte("0123456789abcdef0123456789abcdef")
+	sk1, err := GenerateBLSSecretKey(ikm)
+	require.NoError(t, err)
+	sk2, err := GenerateBLSSecretKey(ikm)
+	require.NoError(t, err)
+	assert.Equal(t, sk1, sk2)
+	_, err = NewBLSSecretKeyFromBytes(sk1[:])
+	assert.NoError(t, err)
+
+	_, err = GenerateBLSSecretKey(ikm[:31])
+	assert.Error(t, err)
+	_, err = NewBLSSecretKeyFromBytes(make([]byte, BLSSecretKeySize))
+	assert.Error(t, err)
+	_, err = NewBLSSecretKeyFromBytes([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff})
+	assert.Error(t, err)
+}
+
+func TestBLSSignVerify(t *testing.T) {
+	sks, pks := blsTestKeys(t, 2)
+	msg := []byte("message")
+	sig, err := sks[0].Sign(msg)
+	require.NoError(t, err)
+	assert.True(t, BLSVerify(pks[0], msg, sig))
+	assert.False(t, BLSVerify(pks[1], msg, sig))
+	assert.False(t, BLSVerify(pks[0], []byte("other message"), sig))
+	assert.False(t, BLSVerify(BLSPublicKey{}, msg, sig))
+	assert.False(t, BLSVerify(pks[0], msg, BLSSignature{}))
+
+	pk, err := NewBLSPublicKeyFromBase58(pks[0].String())
+	require.NoError(t, err)
+	assert.Equal(t, pks[0], pk)
+	s, err := NewBLSSignatureFromBase58(sig.String())
+	require.NoError(t, err)
+	assert.Equal(t, sig, s)
+	_, err = NewBLSPublicKeyFromBytes(make([]byte, BLSPublicKeySize))
+	assert.Error(t, err)
+	_, err = NewBLSSignatureFromBytes(sig[1:])
+	assert.Error(t, err)
+}
+
+func TestBLSProofOfPossession(t *testing.T) {
+	sks, pks := blsTestKeys(t, 2)
+	proof, err := sks[0].ProvePossession()
+	require.NoError(t, err)
+	assert.True(t, BLSVerifyPossession(pks[0], proof))
+	assert.False(t, BLSVerifyPossession(pks[1], proof))
+	// Proof of possession is not a valid signature of the public key, because of the separate domain.
+	assert.False(t, BLSVerify(pks[0], pks[0][:], proof))
+}
+
+func TestBLSAggregation(t *testing.T) {
+	sks, pks := blsTestKeys(t, 4)
+	msg := []byte("common message")
+	sigs := make([]BLSSignature, len(sks))
+	msgs := make([][]byte, len(sks))
+	distinctSigs := make([]BLSSignature, len(sks))
+	for i, sk := range sks {
+		var err error
+		sigs[i], err = sk.Sign(msg)
+		require.NoError(t, err)
+		msgs[i] = []byte(fmt.Sprintf("message #%d", i))
+		distinctSigs[i], err = sk.Sign(msgs[i])
+		require.NoError(t, err)
+	}
+
+	agg, err := BLSAggregateSignatures(sigs)
+	require.NoError(t, err)
+	assert.True(t, BLSFastAggregateVerify(pks, msg, agg))
+	assert.False(t, BLSFastAggregateVerify(pks[1:], msg, agg))
+	aggPK, err := BLSAggregatePublicKeys(pks)
+	require.NoError(t, err)
+	assert.True(t, BLSVerify(aggPK, msg, agg))
+
+	aggDistinct, err := BLSAggregateSignatures(distinctSigs)
+	require.NoError(t, err)
+	assert.True(t, BLSAggregateVerify(pks, msgs, aggDistinct))
+	assert.False(t, BLSAggregateVerify(pks, msgs[1:], aggDistinct))
+	msgs[0], msgs[1] = msgs[1], msgs[0]
+	assert.False(t, BLSAggregateVerify(pks, msgs, aggDistinct))
+
+	_, err = BLSAggregateSignatures(nil)
+	assert.Error(t, err)
+	_, err = BLSAggregatePublicKeys([]BLSPublicKey{{}})
+	assert.Error(t, err)
+	assert.False(t, BLSFastAggregateVerify(nil, msg, agg))
+}
+
+func TestBLSJSONRoundTrip(t *testing.T) {
+	sks, pks := blsTestKeys(t, 1)
+	sig, err := sks[0].Sign([]byte("message"))
+	require.NoError(t, err)
+	js, err := json.Marshal(struct {
+		PK  BLSPublicKey `json:"pk"`
+		Sig BLSSignature `json:"sig"`
+	}{pks[0], sig})
+	require.NoError(t, err)
+	var v struct {
+		PK  BLSPublicKey `json:"pk"`
+		Sig BLSSignature `json:"sig"`
+	}
+	require.NoError(t, json.Unmarshal(js, &v))
+	assert.Equal(t, pks[0], v.PK)
+	assert.Equal(t, sig, v.Sig)
+}
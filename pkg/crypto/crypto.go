@@ -16,7 +16,6 @@ import (
 	"github.com/pkg/errors"
 	"github.com/wavesplatform/gowaves/pkg/util/common"
 	"golang.org/x/crypto/blake2b"
-	"golang.org/x/crypto/sha3"
 )
 
 const (
@@ -317,7 +316,8 @@ func MustKeccak256(data []byte) Digest {
 
 func Keccak256(data []byte) (Digest, error) {
 	var d Digest
-	h := sha3.NewLegacyKeccak256()
+	h := getKeccak256()
+	defer putKeccak256(h)
 	if _, err := h.Write(data); err != nil {
 		return d, err
 	}
@@ -330,16 +330,7 @@ func NewFastHash() (hash.Hash, error) {
 }
 
 func FastHash(data []byte) (Digest, error) {
-	var d Digest
-	h, err := blake2b.New256(nil)
-	if err != nil {
-		return d, err
-	}
-	if _, err := h.Write(data); err != nil {
-		return d, err
-	}
-	h.Sum(d[:0])
-	return d, nil
+	return blake2b.Sum256(data), nil
 }
 
 func MustFastHash(data []byte) Digest {
@@ -351,21 +342,8 @@ func MustFastHash(data []byte) Digest {
 }
 
 func SecureHash(data []byte) (Digest, error) {
-	var d Digest
-	fh, err := blake2b.New256(nil)
-	if err != nil {
-		return d, err
-	}
-	if _, err := fh.Write(data); err != nil {
-		return d, err
-	}
-	fh.Sum(d[:0])
-	h := sha3.NewLegacyKeccak256()
-	if _, err := h.Write(d[:DigestSize]); err != nil {
-		return d, err
-	}
-	h.Sum(d[:0])
-	return d, nil
+	d := blake2b.Sum256(data)
+	return Keccak256(d[:])
 }
 
 func GenerateSecretKey(seed []byte) SecretKey {
@@ -1,11 +1,15 @@
 package crypto
 
 import (
+	"bytes"
 	"crypto/rand"
+	"crypto/sha512"
 	"encoding/hex"
 	"fmt"
+	"sync"
 	"testing"
 
+	edwards "filippo.io/edwards25519"
 	"github.com/mr-tron/base58/base58"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -251,6 +255,7 @@ func BenchmarkVerify(b *testing.B) {
 }
 
 func BenchmarkFastHash(b *testing.B) {
+	b.Logf("Blake2b backend: %s", Blake2bBackend())
 	for size := 64; size <= 2048; size *= 2 {
 		b.Run(fmt.Sprintf("%dB", size), func(b *testing.B) {
 			data := make([]byte, size)
@@ -284,6 +289,48 @@ func BenchmarkSecureHash(b *testing.B) {
 	}
 }
 
+func BenchmarkKeccak256(b *testing.B) {
+	b.Logf("Keccak256 backend: %s", Keccak256Backend())
+	for size := 32; size <= 2048; size *= 4 {
+		b.Run(fmt.Sprintf("%dB", size), func(b *testing.B) {
+			data := make([]byte, size)
+			if _, err := rand.Read(data); err != nil {
+				b.Fatalf("rand.Read(): %v\n", err)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				if _, err := Keccak256(data); err != nil {
+					b.Fatalf("Keccak256(): %v\n", err)
+				}
+			}
+		})
+	}
+}
+
+func TestHashesConcurrentUse(t *testing.T) {
+	data := []byte("concurrent hashing")
+	expectedKeccak := MustKeccak256(data)
+	expectedSecure, err := SecureHash(data)
+	require.NoError(t, err)
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				assert.Equal(t, expectedKeccak, MustKeccak256(data))
+				sh, shErr := SecureHash(data)
+				assert.NoError(t, shErr)
+				assert.Equal(t, expectedSecure, sh)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.NotEmpty(t, Blake2bBackend())
+	assert.NotEmpty(t, Keccak256Backend())
+}
+
 func TestSecretKey_Marshal(t *testing.T) {
 	k := "YoLY4iripseWvtMt29sc89oJnjxzodDgQ9REmEPFHkK"
 	secretKey, err := NewSecretKeyFromBase58(k)
@@ -384,3 +431,60 @@ func TestDigest_Hex(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "9c50225b3c88651cd7ddf9268941cfa6d8737edea0f0ed49c380334953361634", d.Hex())
 }
+
+// signWithCommitment makes the signature with the given encoding of commitment R and its discrete logarithm r,
+// which allows to craft signatures with small order or non-canonical commitments.
+func signWithCommitment(t *testing.T, sk SecretKey, data, rb []byte, r *edwards.Scalar) Signature {
+	a, err := edwards.NewScalar().SetBytesWithClamping(sk[:])
+	require.NoError(t, err)
+	pkb := new(edwards.Point).ScalarBaseMult(a).Bytes()
+	h := sha512.New()
+	h.Write(rb)
+	h.Write(pkb)
+	h.Write(data)
+	k, err := edwards.NewScalar().SetUniformBytes(h.Sum(nil))
+	require.NoError(t, err)
+	s := edwards.NewScalar().MultiplyAdd(k, a, r)
+	var sig Signature
+	copy(sig[:32], rb)
+	copy(sig[32:], s.Bytes())
+	sig[63] |= pkb[31] & 0x80
+	return sig
+}
+
+// TestVerifyCommitmentEncoding fixes the cofactorless verification rules: the commitment must be exactly
+// the canonical encoding of [s]B - [k]A, so small order commitments are accepted only if the equation holds
+// without the cofactor, while non-canonical and mixed order commitments are rejected.
+func TestVerifyCommitmentEncoding(t *testing.T) {
+	sk, pk, err := GenerateKeyPair([]byte("commitment encoding seed"))
+	require.NoError(t, err)
+	data := []byte("message")
+
+	r, err := edwards.NewScalar().SetUniformBytes(bytes.Repeat([]byte{7}, 64))
+	require.NoError(t, err)
+	sig := signWithCommitment(t, sk, data, new(edwards.Point).ScalarBaseMult(r).Bytes(), r)
+	assert.True(t, Verify(pk, sig, data), "honest commitment")
+
+	identity := edwards.NewIdentityPoint().Bytes()
+	sig = signWithCommitment(t, sk, data, identity, edwards.NewScalar())
+	assert.True(t, Verify(pk, sig, data), "canonical identity commitment")
+
+	// The identity is encoded as y = 1, y = 1 + p is the non-canonical encoding of the same point.
+	nonCanonical := bytes.Repeat([]byte{0xff}, 32)
+	nonCanonical[0], nonCanonical[31] = 0xee, 0x7f
+	p, err := new(edwards.Point).SetBytes(nonCanonical)
+	require.NoError(t, err)
+	require.Equal(t, 1, p.Equal(edwards.NewIdentityPoint()))
+	sig = signWithCommitment(t, sk, data, nonCanonical, edwards.NewScalar())
+	assert.False(t, Verify(pk, sig, data), "non-canonical identity commitment")
+
+	// Commitment with the component of order 8 satisfies the verification equation multiplied by the cofactor.
+	t8, err := hex.DecodeString("26e8958fc2b227b045c3f489f2ef98f0d5dfac05d3c63339b13802886d53fc05")
+	require.NoError(t, err)
+	tp, err := new(edwards.Point).SetBytes(t8)
+	require.NoError(t, err)
+	require.Equal(t, 1, new(edwards.Point).MultByCofactor(tp).Equal(edwards.NewIdentityPoint()))
+	mixed := new(edwards.Point).Add(new(edwards.Point).ScalarBaseMult(r), tp)
+	sig = signWithCommitment(t, sk, data, mixed.Bytes(), r)
+	assert.False(t, Verify(pk, sig, data), "mixed order commitment")
+}
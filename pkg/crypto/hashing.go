@@ -0,0 +1,29 @@
+package crypto
+
+import (
+	"hash"
+	"sync"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// Blake2b-256 and Keccak-256 are the most used hash functions of the node, they are computed for every address
+// derivation and every node of merkle trees. The node has no hashing backends of its own, implementations from
+// golang.org/x/crypto are used: Blake2b selects AVX2, AVX or SSE4.1 version at runtime on amd64, Keccak-f
+// permutation is implemented in scalar assembly on amd64 and in Go elsewhere, it has no SIMD versions.
+// Here the Keccak hashers are reused to avoid allocations on each call.
+
+var keccak256Pool = sync.Pool{
+	New: func() any {
+		return sha3.NewLegacyKeccak256()
+	},
+}
+
+func getKeccak256() hash.Hash {
+	return keccak256Pool.Get().(hash.Hash)
+}
+
+func putKeccak256(h hash.Hash) {
+	h.Reset()
+	keccak256Pool.Put(h)
+}
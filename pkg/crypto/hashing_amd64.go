@@ -0,0 +1,24 @@
+//go:build amd64 && gc && !purego
+
+package crypto
+
+import "golang.org/x/sys/cpu"
+
+// Blake2bBackend returns the name of Blake2b implementation selected for the current CPU.
+func Blake2bBackend() string {
+	switch {
+	case cpu.X86.HasAVX2:
+		return "avx2"
+	case cpu.X86.HasAVX:
+		return "avx"
+	case cpu.X86.HasSSE41:
+		return "sse4.1"
+	default:
+		return "generic"
+	}
+}
+
+// Keccak256Backend returns the name of Keccak-f permutation implementation selected at build time.
+func Keccak256Backend() string {
+	return "amd64"
+}
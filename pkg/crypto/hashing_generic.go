@@ -0,0 +1,13 @@
+//go:build !amd64 || !gc || purego
+
+package crypto
+
+// Blake2bBackend returns the name of Blake2b implementation selected for the current CPU.
+func Blake2bBackend() string {
+	return "generic"
+}
+
+// Keccak256Backend returns the name of Keccak-f permutation implementation selected at build time.
+func Keccak256Backend() string {
+	return "generic"
+}
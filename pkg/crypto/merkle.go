@@ -2,6 +2,7 @@ package crypto
 
 import (
 	"hash"
+	"slices"
 
 	"github.com/pkg/errors"
 	"golang.org/x/crypto/blake2b"
@@ -77,6 +78,35 @@ func (t *MerkleTree) RebuildRoot(leaf Digest, proofsRootToLeafsOrder []Digest, i
 	return digest
 }
 
+// Proof returns the proof of inclusion of the leaf with the given index into the Merkle tree of all the leaves.
+// The digests of the proof are in root to leafs order, as expected by RebuildRoot.
+func (t *MerkleTree) Proof(leaves [][]byte, index uint64) ([]Digest, error) {
+	if index >= uint64(len(leaves)) {
+		return nil, errors.Errorf("leaf index %d is out of range [0, %d)", index, len(leaves))
+	}
+	level := make([]Digest, len(leaves))
+	for i, l := range leaves {
+		level[i] = t.leafDigest(l)
+	}
+	var proof []Digest
+	for {
+		if len(level)%2 != 0 {
+			level = append(level, ZeroDigest)
+		}
+		proof = append(proof, level[index^1])
+		next := make([]Digest, len(level)/2)
+		for i := range next {
+			next[i] = t.nodeDigest(level[2*i], level[2*i+1])
+		}
+		level, index = next, index/2
+		if len(level) == 1 {
+			break
+		}
+	}
+	slices.Reverse(proof)
+	return proof, nil
+}
+
 func (t *MerkleTree) leafDigest(data []byte) Digest {
 	t.h.Reset()
 	_, err := t.h.Write(data)
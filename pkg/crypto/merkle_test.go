@@ -113,6 +113,28 @@ func TestMerkleTreeRebuildRoot(t *testing.T) {
 	}
 }
 
+func TestMerkleTreeProof(t *testing.T) {
+	for n := 1; n <= 17; n++ {
+		leaves := make([][]byte, n)
+		tree, err := NewMerkleTree()
+		require.NoError(t, err)
+		for i := range leaves {
+			leaves[i] = []byte(fmt.Sprintf("leaf-%d", i))
+			tree.Push(leaves[i])
+		}
+		root := tree.Root()
+		for i := range leaves {
+			proof, err := tree.Proof(leaves, uint64(i))
+			require.NoError(t, err)
+			leaf, err := FastHash(leaves[i])
+			require.NoError(t, err)
+			assert.Equal(t, root, tree.RebuildRoot(leaf, proof, uint64(i)), "%d leaves, index %d", n, i)
+		}
+		_, err = tree.Proof(leaves, uint64(n))
+		assert.Error(t, err)
+	}
+}
+
 func TestStagenetFailure(t *testing.T) {
 	tree, err := NewMerkleTree()
 	require.NoError(t, err)
@@ -12,9 +12,17 @@ import (
 
 // This code is a port of the public domain, VXEdDSA C implementation by Trevor Perrin / Open Whisper Systems.
 // Specification: https://whispersystems.org/docs/specifications/xeddsa/#vxeddsa
+//
+// VRF (verifiable random function) is used by Fair PoS consensus. Block generator signs the hit source of
+// the reference block with VXEdDSA, the proof is stored in the block header as generation signature and the VRF
+// value becomes the hit source of the new block. Anyone can check the proof with the generator's public key and
+// get the same VRF value.
 
 const (
+	// ProofSize is the size of VRF proof in bytes.
 	ProofSize = 32 + 32 + 32
+	// VRFSize is the size of VRF value in bytes.
+	VRFSize = 32
 
 	labelMaxLen    = 128
 	labelSetMaxLen = 512
@@ -38,6 +46,8 @@ var (
 	defaultLabel4   = addLabel(defaultLabelSet, "4")
 )
 
+// SignVRF creates the VRF proof of the message with the secret key. The proof is randomized, so different proofs
+// are produced for the same message, but all of them correspond to the same VRF value, see ComputeVRF.
 func SignVRF(sk SecretKey, msg []byte) ([]byte, error) {
 	r := make([]byte, 32)
 	_, err := rand.Read(r)
@@ -141,6 +151,8 @@ func generateVRFSignature(random, sk, m []byte) ([]byte, error) {
 	return signature, nil
 }
 
+// VerifyVRF checks the VRF proof of the message against the public key. If the proof is valid, the VRF value is
+// returned along with true. Error is returned if the sizes of message or proof are invalid.
 func VerifyVRF(pk PublicKey, msg, signature []byte) (bool, []byte, error) {
 	return verifyVRFSignature(pk[:], msg, signature)
 }
@@ -348,7 +360,21 @@ func addLabel(set []byte, label string) []byte {
 	return r
 }
 
+// VRFFromProof checks the VRF proof of the message and returns the VRF value. In contrast to VerifyVRF, invalid
+// proof is reported as an error.
+func VRFFromProof(pk PublicKey, msg, proof []byte) ([]byte, error) {
+	ok, vrf, err := VerifyVRF(pk, msg, proof)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to verify VRF proof")
+	}
+	if !ok {
+		return nil, errors.New("invalid VRF proof")
+	}
+	return vrf, nil
+}
+
 // ComputeVRF generates the VRF value for the byte slice msg using given private key sk.
+// The value is the same as returned by VerifyVRF for any proof of the message created by SignVRF.
 func ComputeVRF(sk SecretKey, msg []byte) []byte {
 	var a, aNeg, A [32]byte
 	copy(a[:], sk[:SecretKeySize])
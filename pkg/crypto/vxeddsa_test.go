@@ -285,3 +285,21 @@ func BenchmarkComputeVRF(b *testing.B) {
 		})
 	}
 }
+
+func TestVRFFromProof(t *testing.T) {
+	sk, pk, err := GenerateKeyPair([]byte("vrf test seed"))
+	require.NoError(t, err)
+	msg := []byte("hit source of the reference block")
+	proof, err := SignVRF(sk, msg)
+	require.NoError(t, err)
+	require.Len(t, proof, ProofSize)
+	vrf, err := VRFFromProof(pk, msg, proof)
+	require.NoError(t, err)
+	assert.Len(t, vrf, VRFSize)
+	assert.Equal(t, ComputeVRF(sk, msg), vrf)
+
+	_, err = VRFFromProof(pk, []byte("other message"), proof)
+	assert.EqualError(t, err, "invalid VRF proof")
+	_, err = VRFFromProof(pk, msg, proof[1:])
+	assert.EqualError(t, err, "failed to verify VRF proof: invalid signature length")
+}
@@ -0,0 +1,74 @@
+// Package extensions provides extension points that allow adding functionality to the node without patching its
+// core. Extensions are either compiled-in, registering themselves with Register in the init function of their
+// package, or loaded from Go plugins with LoadPlugin. An extension implements Extension and any of the optional
+// hook interfaces.
+package extensions
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"google.golang.org/grpc"
+
+	"github.com/wavesplatform/gowaves/pkg/node/events"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/services"
+)
+
+// Extension is the base interface of the node extensions.
+type Extension interface {
+	// Name returns the unique name of the extension. Name is used in logs and as a prefix of extension's HTTP routes.
+	Name() string
+}
+
+// Initializer is implemented by extensions that have to be initialized on the node start.
+type Initializer interface {
+	Init(ctx context.Context, svs services.Services) error
+}
+
+// BlockAppliedHandler is implemented by extensions that are notified about blocks applied to the state.
+type BlockAppliedHandler interface {
+	OnBlockApplied(blockID proto.BlockID, height proto.Height)
+}
+
+// TxBroadcastHandler is implemented by extensions that are notified about transactions accepted to the UTX pool
+// and broadcast to the network.
+type TxBroadcastHandler interface {
+	OnTxBroadcast(tx proto.Transaction)
+}
+
+// RoutesRegistrar is implemented by extensions that add HTTP routes to the node's REST API. Routes are mounted
+// at /ext/<name>. The auth middleware protects routes with the node's API key.
+type RoutesRegistrar interface {
+	RegisterRoutes(r chi.Router, auth func(http.Handler) http.Handler)
+}
+
+// GRPCServicesRegistrar is implemented by extensions that add services to the node's gRPC API. The registrar
+// passed by the node also has the method RequireAPIKey(methods ...string) to protect methods with the API key.
+type GRPCServicesRegistrar interface {
+	RegisterGRPCServices(r grpc.ServiceRegistrar)
+}
+
+const hooksBufferSize = 1000
+
+// Init initializes registered extensions.
+func Init(ctx context.Context, svs services.Services) error {
+	return defaultRegistry.init(ctx, svs)
+}
+
+// Run delivers events from the bus to the hooks of registered extensions until the context is done.
+// Hooks are called sequentially in the order of extensions registration.
+func Run(ctx context.Context, bus *events.Bus) {
+	defaultRegistry.run(ctx, bus)
+}
+
+// RegisterRoutes adds HTTP routes of registered extensions to the router.
+func RegisterRoutes(r chi.Router, auth func(http.Handler) http.Handler) {
+	defaultRegistry.registerRoutes(r, auth)
+}
+
+// RegisterGRPCServices adds gRPC services of registered extensions.
+func RegisterGRPCServices(r grpc.ServiceRegistrar) {
+	defaultRegistry.registerGRPCServices(r)
+}
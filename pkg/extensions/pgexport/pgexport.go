@@ -0,0 +1,287 @@
+// Package pgexport is the node extension mirroring blocks, transactions, transfers and invoke results into
+// a PostgreSQL database as blocks are applied, so the blockchain can be queried with SQL. Rollbacks remove the rows
+// of the rolled back blocks. After the restart the export is resumed from the last exported block, blocks rolled
+// back while the node was stopped are removed first.
+package pgexport
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	_ "github.com/lib/pq" // PostgreSQL driver
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/wavesplatform/gowaves/pkg/node/events"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/services"
+	"github.com/wavesplatform/gowaves/pkg/state"
+	"github.com/wavesplatform/gowaves/pkg/state/stateerr"
+)
+
+// Name of the extension.
+const Name = "pgexport"
+
+const (
+	eventsBufferSize = 1000
+	initialBackoff   = time.Second
+	maxBackoff       = time.Minute
+)
+
+// Config of the export.
+type Config struct {
+	DSN string // PostgreSQL connection string.
+	// FromHeight is the height to start the export from if the database is empty.
+	FromHeight proto.Height
+}
+
+// Exporter is the extension writing blockchain data to PostgreSQL.
+type Exporter struct {
+	cfg     Config
+	db      *sql.DB
+	st      state.StateInfo
+	conv    *converter
+	backoff time.Duration
+	height  proto.Height // height of the last exported block, accessed by the export goroutine only
+}
+
+// New creates the export extension, it has to be registered with extensions.Register.
+func New(cfg Config) *Exporter {
+	return &Exporter{cfg: cfg, backoff: initialBackoff}
+}
+
+// Name implements extensions.Extension.
+func (e *Exporter) Name() string {
+	return Name
+}
+
+// Init connects to the database, creates the schema and starts the export.
+func (e *Exporter) Init(ctx context.Context, svs services.Services) error {
+	db, err := sql.Open("postgres", e.cfg.DSN)
+	if err != nil {
+		return errors.Wrap(err, "failed to open database")
+	}
+	if pErr := db.PingContext(ctx); pErr != nil {
+		_ = db.Close()
+		return errors.Wrap(pErr, "failed to connect to database")
+	}
+	if _, sErr := db.ExecContext(ctx, schema); sErr != nil {
+		_ = db.Close()
+		return errors.Wrap(sErr, "failed to create schema")
+	}
+	e.db = db
+	e.st = svs.State
+	e.conv = &converter{st: svs.State, scheme: svs.Scheme}
+	sub := svs.Events.Subscribe(eventsBufferSize, events.BlockApplied{}, events.Rollback{})
+	go func() {
+		defer sub.Close()
+		defer func() {
+			if cErr := db.Close(); cErr != nil {
+				zap.S().Warnf("PostgreSQL export: failed to close database: %v", cErr)
+			}
+		}()
+		if rErr := e.run(ctx, sub); rErr != nil && ctx.Err() == nil {
+			zap.S().Errorf("PostgreSQL export is stopped: %v", rErr)
+		}
+	}()
+	return nil
+}
+
+func (e *Exporter) run(ctx context.Context, sub *events.Subscription) error {
+	if err := e.retry(ctx, "resume", e.resume); err != nil {
+		return err
+	}
+	if err := e.exportUpTo(ctx, 0); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-sub.Events():
+			if !ok {
+				return nil
+			}
+			var err error
+			switch t := ev.(type) {
+			case events.BlockApplied:
+				err = e.blockApplied(ctx, t)
+			case events.Rollback:
+				err = e.rollback(ctx, t.Height)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// resume finds the last exported block which is still in the blockchain and removes the blocks above it.
+func (e *Exporter) resume(ctx context.Context) error {
+	var top sql.NullInt64
+	if err := e.db.QueryRowContext(ctx, "SELECT max(height) FROM blocks").Scan(&top); err != nil {
+		return errors.Wrap(err, "failed to get last exported height")
+	}
+	if !top.Valid {
+		e.height = max(e.cfg.FromHeight, 1) - 1
+		return nil
+	}
+	h := proto.Height(top.Int64)
+	for ; h > 0; h-- {
+		var exported string
+		if err := e.db.QueryRowContext(ctx, "SELECT id FROM blocks WHERE height = $1", int64(h)).Scan(&exported); err != nil {
+			return errors.Wrapf(err, "failed to get exported block at height %d", h)
+		}
+		id, err := e.st.HeightToBlockID(h)
+		if err == nil && id.String() == exported {
+			break
+		}
+		if err != nil && !stateerr.IsNotFound(err) && !stateerr.IsInvalidInput(err) {
+			return errors.Wrapf(err, "failed to get block ID at height %d", h)
+		}
+	}
+	if h < proto.Height(top.Int64) {
+		zap.S().Infof("PostgreSQL export: removing blocks above height %d rolled back since the last run", h)
+	}
+	return e.deleteAbove(ctx, h)
+}
+
+// exportUpTo exports blocks from the state after the last exported one up to the height, zero means the
+// current height of the state.
+func (e *Exporter) exportUpTo(ctx context.Context, height proto.Height) error {
+	if height == 0 {
+		h, err := e.st.Height()
+		if err != nil {
+			return errors.Wrap(err, "failed to get height")
+		}
+		height = h
+	}
+	for h := e.height + 1; h <= height; h++ {
+		block, err := e.st.BlockByHeight(h)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get block at height %d", h)
+		}
+		if xErr := e.export(ctx, h, block); xErr != nil {
+			return xErr
+		}
+	}
+	return nil
+}
+
+func (e *Exporter) blockApplied(ctx context.Context, ev events.BlockApplied) error {
+	if ev.Height <= e.height {
+		return nil // already exported on start
+	}
+	if err := e.exportUpTo(ctx, ev.Height-1); err != nil {
+		return err
+	}
+	block, err := e.st.Block(ev.BlockID)
+	if stateerr.IsNotFound(err) {
+		return nil // the block is already replaced, the event about its successor is following
+	} else if err != nil {
+		return errors.Wrapf(err, "failed to get block %s", ev.BlockID)
+	}
+	return e.export(ctx, ev.Height, block)
+}
+
+func (e *Exporter) rollback(ctx context.Context, height proto.Height) error {
+	if height >= e.height {
+		return nil
+	}
+	return e.retry(ctx, "rollback", func(ctx context.Context) error { return e.deleteAbove(ctx, height) })
+}
+
+func (e *Exporter) deleteAbove(ctx context.Context, height proto.Height) error {
+	if _, err := e.db.ExecContext(ctx, "DELETE FROM blocks WHERE height > $1", int64(height)); err != nil {
+		return errors.Wrapf(err, "failed to delete blocks above height %d", height)
+	}
+	e.height = height
+	return nil
+}
+
+func (e *Exporter) export(ctx context.Context, height proto.Height, block *proto.Block) error {
+	rows, err := e.conv.rows(height, block)
+	if err != nil {
+		return errors.Wrapf(err, "failed to convert block at height %d", height)
+	}
+	if rErr := e.retry(ctx, "export", func(ctx context.Context) error { return e.write(ctx, rows) }); rErr != nil {
+		return rErr
+	}
+	e.height = height
+	return nil
+}
+
+// write replaces the block at the height and the blocks above it with the rows in a single database transaction.
+func (e *Exporter) write(ctx context.Context, rows *blockRows) (retErr error) {
+	dbTx, err := e.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer func() {
+		if retErr != nil {
+			_ = dbTx.Rollback()
+		}
+	}()
+	b := rows.block
+	if _, err = dbTx.ExecContext(ctx, "DELETE FROM blocks WHERE height >= $1", int64(b.height)); err != nil {
+		return errors.Wrap(err, "failed to delete replaced blocks")
+	}
+	_, err = dbTx.ExecContext(ctx, `INSERT INTO blocks
+		(height, id, parent, version, timestamp, generator, base_target, transactions)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		int64(b.height), b.id, b.parent, int16(b.version), int64(b.timestamp), b.generator, int64(b.baseTarget),
+		b.transactions)
+	if err != nil {
+		return errors.Wrapf(err, "failed to insert block %s", b.id)
+	}
+	for _, t := range rows.txs {
+		_, err = dbTx.ExecContext(ctx, `INSERT INTO transactions
+			(id, height, position, type, version, sender, fee, fee_asset, timestamp, status, body)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+			t.id, int64(t.height), t.position, int16(t.txType), int16(t.version), t.sender, int64(t.fee), t.feeAsset,
+			int64(t.timestamp), t.status, string(t.body))
+		if err != nil {
+			return errors.Wrapf(err, "failed to insert transaction %s", t.id)
+		}
+	}
+	for _, t := range rows.transfers {
+		_, err = dbTx.ExecContext(ctx, `INSERT INTO transfers
+			(tx_id, position, height, sender, recipient, asset, amount)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			t.txID, t.position, int64(t.height), t.sender, t.recipient, t.asset, int64(t.amount))
+		if err != nil {
+			return errors.Wrapf(err, "failed to insert transfer %d of transaction %s", t.position, t.txID)
+		}
+	}
+	for _, r := range rows.invokes {
+		_, err = dbTx.ExecContext(ctx, `INSERT INTO invoke_results (tx_id, height, error, result)
+			VALUES ($1, $2, $3, $4)`,
+			r.txID, int64(r.height), r.err, string(r.result))
+		if err != nil {
+			return errors.Wrapf(err, "failed to insert invoke result of transaction %s", r.txID)
+		}
+	}
+	return errors.Wrap(dbTx.Commit(), "failed to commit transaction")
+}
+
+// retry calls the function until it succeeds or the context is done, database errors are considered temporary.
+func (e *Exporter) retry(ctx context.Context, op string, f func(ctx context.Context) error) error {
+	backoff := e.backoff
+	for {
+		err := f(ctx)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		zap.S().Warnf("PostgreSQL export: %s failed, retrying in %s: %v", op, backoff, err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, maxBackoff)
+	}
+}
@@ -0,0 +1,224 @@
+package pgexport
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/mr-tron/base58"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/state"
+	"github.com/wavesplatform/gowaves/pkg/state/stateerr"
+)
+
+type blockRow struct {
+	height       proto.Height
+	id           string
+	parent       string
+	version      proto.BlockVersion
+	timestamp    uint64
+	generator    string
+	baseTarget   uint64
+	transactions int
+}
+
+type txRow struct {
+	id        string
+	height    proto.Height
+	position  int
+	txType    proto.TransactionType
+	version   byte
+	sender    sql.NullString
+	fee       uint64
+	feeAsset  sql.NullString
+	timestamp uint64
+	status    string
+	body      []byte
+}
+
+type transferRow struct {
+	txID      string
+	position  int
+	height    proto.Height
+	sender    string
+	recipient string
+	asset     sql.NullString
+	amount    uint64
+}
+
+type invokeRow struct {
+	txID   string
+	height proto.Height
+	err    sql.NullString
+	result []byte
+}
+
+// blockRows are the rows of all tables exported for the block.
+type blockRows struct {
+	block     blockRow
+	txs       []txRow
+	transfers []transferRow
+	invokes   []invokeRow
+}
+
+// converter turns blocks into rows, the state provides transaction statuses, invoke results and aliases.
+type converter struct {
+	st     state.StateInfo
+	scheme proto.Scheme
+}
+
+func (c *converter) rows(height proto.Height, block *proto.Block) (*blockRows, error) {
+	generator, err := proto.NewAddressFromPublicKey(c.scheme, block.GeneratorPublicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get generator address")
+	}
+	res := &blockRows{block: blockRow{
+		height:       height,
+		id:           block.BlockID().String(),
+		parent:       block.Parent.String(),
+		version:      block.Version,
+		timestamp:    block.Timestamp,
+		generator:    generator.String(),
+		baseTarget:   block.BaseTarget,
+		transactions: len(block.Transactions),
+	}}
+	for i, tx := range block.Transactions {
+		if tErr := c.addTransaction(res, height, i, tx); tErr != nil {
+			return nil, tErr
+		}
+	}
+	return res, nil
+}
+
+func (c *converter) addTransaction(res *blockRows, height proto.Height, position int, tx proto.Transaction) error {
+	b, err := tx.GetID(c.scheme)
+	if err != nil {
+		return errors.Wrap(err, "failed to get transaction ID")
+	}
+	id := base58.Encode(b)
+	_, status, err := c.st.TransactionByIDWithStatus(b)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get status of transaction %s", id)
+	}
+	body, err := json.Marshal(tx)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal transaction %s", id)
+	}
+	row := txRow{
+		id:        id,
+		height:    height,
+		position:  position,
+		txType:    tx.GetType(),
+		version:   tx.GetVersion(),
+		fee:       tx.GetFee(),
+		feeAsset:  assetID(tx.GetFeeAsset()),
+		timestamp: tx.GetTimestamp(),
+		status:    status.String(),
+		body:      body,
+	}
+	if _, ok := tx.(*proto.Genesis); !ok {
+		sender, sErr := tx.GetSender(c.scheme)
+		if sErr != nil {
+			return errors.Wrapf(sErr, "failed to get sender of transaction %s", id)
+		}
+		row.sender = sql.NullString{String: sender.String(), Valid: true}
+	}
+	res.txs = append(res.txs, row)
+	if tErr := c.addTransfers(res, row, tx); tErr != nil {
+		return errors.Wrapf(tErr, "failed to get transfers of transaction %s", id)
+	}
+	if iErr := c.addInvokeResult(res, row, tx); iErr != nil {
+		return errors.Wrapf(iErr, "failed to get invoke result of transaction %s", id)
+	}
+	return nil
+}
+
+func (c *converter) addTransfers(res *blockRows, row txRow, tx proto.Transaction) error {
+	var (
+		asset   proto.OptionalAsset
+		entries []proto.MassTransferEntry
+	)
+	switch t := tx.(type) {
+	case *proto.TransferWithSig:
+		asset, entries = t.AmountAsset, []proto.MassTransferEntry{{Recipient: t.Recipient, Amount: t.Amount}}
+	case *proto.TransferWithProofs:
+		asset, entries = t.AmountAsset, []proto.MassTransferEntry{{Recipient: t.Recipient, Amount: t.Amount}}
+	case *proto.MassTransferWithProofs:
+		asset, entries = t.Asset, t.Transfers
+	default:
+		return nil
+	}
+	for i, e := range entries {
+		recipient, err := c.resolve(e.Recipient)
+		if err != nil {
+			return err
+		}
+		res.transfers = append(res.transfers, transferRow{
+			txID:      row.id,
+			position:  i,
+			height:    row.height,
+			sender:    row.sender.String,
+			recipient: recipient.String(),
+			asset:     assetID(asset),
+			amount:    e.Amount,
+		})
+	}
+	return nil
+}
+
+func (c *converter) resolve(r proto.Recipient) (proto.WavesAddress, error) {
+	if addr := r.Address(); addr != nil {
+		return *addr, nil
+	}
+	if alias := r.Alias(); alias != nil {
+		return c.st.AddrByAlias(*alias)
+	}
+	return proto.WavesAddress{}, errors.New("empty recipient")
+}
+
+func (c *converter) addInvokeResult(res *blockRows, row txRow, tx proto.Transaction) error {
+	switch tx.GetType() {
+	case proto.InvokeScriptTransaction, proto.InvokeExpressionTransaction, proto.EthereumMetamaskTransaction:
+	default:
+		return nil
+	}
+	b, err := tx.GetID(c.scheme)
+	if err != nil {
+		return err
+	}
+	id, err := crypto.NewDigestFromBytes(b)
+	if err != nil {
+		return err
+	}
+	sr, err := c.st.InvokeResultByID(id)
+	if stateerr.IsNotFound(err) {
+		// Ethereum transfers have no results and the state may keep no results without extended API.
+		return nil
+	} else if err != nil {
+		return err
+	}
+	p, err := sr.ToProtobuf()
+	if err != nil {
+		return err
+	}
+	result, err := protojson.Marshal(p)
+	if err != nil {
+		return err
+	}
+	ir := invokeRow{txID: row.id, height: row.height, result: result}
+	if sr.ErrorMsg.Text != "" {
+		ir.err = sql.NullString{String: sr.ErrorMsg.Text, Valid: true}
+	}
+	res.invokes = append(res.invokes, ir)
+	return nil
+}
+
+func assetID(a proto.OptionalAsset) sql.NullString {
+	if !a.Present {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: a.ID.String(), Valid: true}
+}
@@ -0,0 +1,79 @@
+package pgexport
+
+import (
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/mock"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+func TestConverterRows(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sk, pk, err := crypto.GenerateKeyPair([]byte("sender"))
+	require.NoError(t, err)
+	sender, err := proto.NewAddressFromPublicKey(proto.TestNetScheme, pk)
+	require.NoError(t, err)
+	_, rpk, err := crypto.GenerateKeyPair([]byte("recipient"))
+	require.NoError(t, err)
+	recipient, err := proto.NewAddressFromPublicKey(proto.TestNetScheme, rpk)
+	require.NoError(t, err)
+	alias := proto.NewAlias(proto.TestNetScheme, "recipient")
+	asset := proto.NewOptionalAssetFromDigest(crypto.Digest{1})
+
+	transfer := proto.NewUnsignedTransferWithProofs(3, pk, *asset, proto.NewOptionalAssetWaves(),
+		1700000000000, 100, 100000, proto.NewRecipientFromAlias(*alias), nil)
+	require.NoError(t, transfer.Sign(proto.TestNetScheme, sk))
+	mass := proto.NewUnsignedMassTransferWithProofs(2, pk, proto.NewOptionalAssetWaves(), []proto.MassTransferEntry{
+		{Recipient: proto.NewRecipientFromAddress(recipient), Amount: 1},
+		{Recipient: proto.NewRecipientFromAddress(sender), Amount: 2},
+	}, 200000, 1700000000001, nil)
+	require.NoError(t, mass.Sign(proto.TestNetScheme, sk))
+	block := &proto.Block{
+		BlockHeader: proto.BlockHeader{
+			Version:            proto.ProtobufBlockVersion,
+			ID:                 proto.NewBlockIDFromDigest(crypto.Digest{2}),
+			Timestamp:          1700000000002,
+			GeneratorPublicKey: pk,
+		},
+		Transactions: proto.Transactions{transfer, mass},
+	}
+
+	st := mock.NewMockState(ctrl)
+	st.EXPECT().TransactionByIDWithStatus(transfer.ID.Bytes()).Return(transfer, proto.TransactionSucceeded, nil)
+	st.EXPECT().TransactionByIDWithStatus(mass.ID.Bytes()).Return(mass, proto.TransactionSucceeded, nil)
+	st.EXPECT().AddrByAlias(*alias).Return(recipient, nil)
+
+	c := &converter{st: st, scheme: proto.TestNetScheme}
+	rows, err := c.rows(10, block)
+	require.NoError(t, err)
+
+	assert.Equal(t, blockRow{height: 10, id: block.BlockID().String(), parent: block.Parent.String(),
+		version: proto.ProtobufBlockVersion, timestamp: 1700000000002, generator: sender.String(), transactions: 2},
+		rows.block)
+	require.Len(t, rows.txs, 2)
+	tx := rows.txs[0]
+	assert.Equal(t, transfer.ID.String(), tx.id)
+	assert.Equal(t, proto.TransferTransaction, tx.txType)
+	assert.Equal(t, sql.NullString{String: sender.String(), Valid: true}, tx.sender)
+	assert.False(t, tx.feeAsset.Valid)
+	assert.Equal(t, "succeeded", tx.status)
+	assert.True(t, json.Valid(tx.body))
+	assert.Equal(t, 1, rows.txs[1].position)
+
+	assetID := sql.NullString{String: asset.ID.String(), Valid: true}
+	assert.Equal(t, []transferRow{
+		{txID: transfer.ID.String(), height: 10, sender: sender.String(), recipient: recipient.String(),
+			asset: assetID, amount: 100},
+		{txID: mass.ID.String(), height: 10, sender: sender.String(), recipient: recipient.String(), amount: 1},
+		{txID: mass.ID.String(), position: 1, height: 10, sender: sender.String(), recipient: sender.String(),
+			amount: 2},
+	}, rows.transfers)
+	assert.Empty(t, rows.invokes)
+}
@@ -0,0 +1,52 @@
+package pgexport
+
+// schema creates the tables of the export. Rows of transactions, transfers and invoke results are removed together
+// with their block, so a rollback is a single deletion of the blocks above the new top.
+const schema = `
+CREATE TABLE IF NOT EXISTS blocks (
+	height            BIGINT PRIMARY KEY,
+	id                TEXT NOT NULL UNIQUE,
+	parent            TEXT NOT NULL,
+	version           SMALLINT NOT NULL,
+	timestamp         BIGINT NOT NULL,
+	generator         TEXT NOT NULL,
+	base_target       BIGINT NOT NULL,
+	transactions      INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS transactions (
+	id                TEXT PRIMARY KEY,
+	height            BIGINT NOT NULL REFERENCES blocks (height) ON DELETE CASCADE,
+	position          INTEGER NOT NULL,
+	type              SMALLINT NOT NULL,
+	version           SMALLINT NOT NULL,
+	sender            TEXT,
+	fee               BIGINT NOT NULL,
+	fee_asset         TEXT,
+	timestamp         BIGINT NOT NULL,
+	status            TEXT NOT NULL,
+	body              JSONB NOT NULL
+);
+CREATE INDEX IF NOT EXISTS transactions_height_idx ON transactions (height);
+CREATE INDEX IF NOT EXISTS transactions_sender_idx ON transactions (sender);
+
+CREATE TABLE IF NOT EXISTS transfers (
+	tx_id             TEXT NOT NULL REFERENCES transactions (id) ON DELETE CASCADE,
+	position          INTEGER NOT NULL,
+	height            BIGINT NOT NULL,
+	sender            TEXT NOT NULL,
+	recipient         TEXT NOT NULL,
+	asset             TEXT,
+	amount            BIGINT NOT NULL,
+	PRIMARY KEY (tx_id, position)
+);
+CREATE INDEX IF NOT EXISTS transfers_sender_idx ON transfers (sender);
+CREATE INDEX IF NOT EXISTS transfers_recipient_idx ON transfers (recipient);
+
+CREATE TABLE IF NOT EXISTS invoke_results (
+	tx_id             TEXT PRIMARY KEY REFERENCES transactions (id) ON DELETE CASCADE,
+	height            BIGINT NOT NULL,
+	error             TEXT,
+	result            JSONB NOT NULL
+);
+`
@@ -0,0 +1,151 @@
+package extensions
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"plugin"
+	"sync"
+
+	"github.com/go-chi/chi"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	"github.com/wavesplatform/gowaves/pkg/node/events"
+	"github.com/wavesplatform/gowaves/pkg/services"
+)
+
+// PluginConstructor is the name of the function exported by the plugin. The function must have the signature
+// func() extensions.Extension.
+const PluginConstructor = "NewExtension"
+
+var defaultRegistry = &registry{}
+
+// Register adds the extension to the node. It panics if the extension with the same name is already registered.
+func Register(e Extension) {
+	if err := defaultRegistry.register(e); err != nil {
+		panic(err.Error())
+	}
+}
+
+// LoadPlugin opens the Go plugin and registers the extension returned by its constructor.
+func LoadPlugin(path string) error {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open plugin %q", path)
+	}
+	sym, err := p.Lookup(PluginConstructor)
+	if err != nil {
+		return errors.Wrapf(err, "failed to lookup constructor in plugin %q", path)
+	}
+	constructor, ok := sym.(func() Extension)
+	if !ok {
+		return errors.Errorf("invalid constructor type %T in plugin %q", sym, path)
+	}
+	return defaultRegistry.register(constructor())
+}
+
+// Registered returns the names of the registered extensions.
+func Registered() []string {
+	return defaultRegistry.names()
+}
+
+type registry struct {
+	mu         sync.Mutex
+	extensions []Extension
+}
+
+func (r *registry) register(e Extension) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, ext := range r.extensions {
+		if ext.Name() == e.Name() {
+			return fmt.Errorf("extension %q is already registered", e.Name())
+		}
+	}
+	r.extensions = append(r.extensions, e)
+	return nil
+}
+
+func (r *registry) all() []Extension {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Extension(nil), r.extensions...)
+}
+
+func (r *registry) names() []string {
+	exts := r.all()
+	res := make([]string, len(exts))
+	for i, e := range exts {
+		res[i] = e.Name()
+	}
+	return res
+}
+
+func (r *registry) init(ctx context.Context, svs services.Services) error {
+	for _, e := range r.all() {
+		if i, ok := e.(Initializer); ok {
+			if err := i.Init(ctx, svs); err != nil {
+				return errors.Wrapf(err, "failed to initialize extension %q", e.Name())
+			}
+		}
+		zap.S().Infof("Extension %q is initialized", e.Name())
+	}
+	return nil
+}
+
+func (r *registry) run(ctx context.Context, bus *events.Bus) {
+	var (
+		blocks []BlockAppliedHandler
+		txs    []TxBroadcastHandler
+	)
+	for _, e := range r.all() {
+		if h, ok := e.(BlockAppliedHandler); ok {
+			blocks = append(blocks, h)
+		}
+		if h, ok := e.(TxBroadcastHandler); ok {
+			txs = append(txs, h)
+		}
+	}
+	if len(blocks) == 0 && len(txs) == 0 {
+		return
+	}
+	sub := bus.Subscribe(hooksBufferSize, events.BlockApplied{}, events.TransactionAccepted{})
+	defer sub.Close()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e := <-sub.Events():
+			switch t := e.(type) {
+			case events.BlockApplied:
+				for _, h := range blocks {
+					h.OnBlockApplied(t.BlockID, t.Height)
+				}
+			case events.TransactionAccepted:
+				for _, h := range txs {
+					h.OnTxBroadcast(t.Transaction)
+				}
+			}
+		}
+	}
+}
+
+func (r *registry) registerRoutes(router chi.Router, auth func(http.Handler) http.Handler) {
+	for _, e := range r.all() {
+		if rr, ok := e.(RoutesRegistrar); ok {
+			router.Route("/ext/"+e.Name(), func(sr chi.Router) {
+				rr.RegisterRoutes(sr, auth)
+			})
+		}
+	}
+}
+
+func (r *registry) registerGRPCServices(sr grpc.ServiceRegistrar) {
+	for _, e := range r.all() {
+		if gr, ok := e.(GRPCServicesRegistrar); ok {
+			gr.RegisterGRPCServices(sr)
+		}
+	}
+}
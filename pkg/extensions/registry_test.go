@@ -0,0 +1,69 @@
+package extensions
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/node/events"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+type testExtension struct {
+	name    string
+	heights chan proto.Height
+}
+
+func (e *testExtension) Name() string {
+	return e.name
+}
+
+func (e *testExtension) OnBlockApplied(_ proto.BlockID, height proto.Height) {
+	e.heights <- height
+}
+
+func (e *testExtension) RegisterRoutes(r chi.Router, _ func(http.Handler) http.Handler) {
+	r.Get("/hello", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(e.name))
+	})
+}
+
+func TestRegistry(t *testing.T) {
+	r := &registry{}
+	ext := &testExtension{name: "test", heights: make(chan proto.Height, 1)}
+	require.NoError(t, r.register(ext))
+	require.Error(t, r.register(&testExtension{name: "test"}))
+	assert.Equal(t, []string{"test"}, r.names())
+
+	router := chi.NewRouter()
+	r.registerRoutes(router, nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/ext/test/hello", nil))
+	assert.Equal(t, "test", resp.Body.String())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	bus := events.NewBus()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		r.run(ctx, bus)
+	}()
+	require.Eventually(t, func() bool {
+		bus.Publish(events.BlockApplied{Height: 10})
+		select {
+		case h := <-ext.heights:
+			return h == 10
+		default:
+			return false
+		}
+	}, time.Second, 10*time.Millisecond)
+	cancel()
+	<-done
+}
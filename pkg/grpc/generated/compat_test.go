@@ -0,0 +1,142 @@
+package generated
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	_ "github.com/wavesplatform/gowaves/pkg/grpc/generated/waves"
+	_ "github.com/wavesplatform/gowaves/pkg/grpc/generated/waves/events"
+)
+
+// populateDepth limits the depth of nested messages filled by populate.
+const populateDepth = 4
+
+type vtMessage interface {
+	MarshalVTStrict() ([]byte, error)
+	UnmarshalVT([]byte) error
+	SizeVT() int
+}
+
+// TestVTProtoCompatibility checks that every message with generated vtproto code is serialized and deserialized
+// the same way as by the canonical protobuf implementation.
+func TestVTProtoCompatibility(t *testing.T) {
+	checked := 0
+	protoregistry.GlobalTypes.RangeMessages(func(mt protoreflect.MessageType) bool {
+		name := mt.Descriptor().FullName()
+		if !strings.HasPrefix(string(name), "waves.") {
+			return true
+		}
+		if _, ok := mt.New().Interface().(vtMessage); !ok {
+			return true
+		}
+		t.Run(string(name), func(t *testing.T) {
+			checkMessage(t, mt)
+		})
+		checked++
+		return true
+	})
+	assert.NotZero(t, checked)
+}
+
+func checkMessage(t *testing.T, mt protoreflect.MessageType) {
+	msg := mt.New()
+	populate(msg, populateDepth)
+	expected := msg.Interface()
+
+	canonical, err := proto.Marshal(expected)
+	require.NoError(t, err)
+	vt, err := expected.(vtMessage).MarshalVTStrict()
+	require.NoError(t, err)
+	assert.Equal(t, len(canonical), len(vt))
+	assert.Equal(t, len(vt), expected.(vtMessage).SizeVT())
+
+	fromCanonical := mt.New().Interface()
+	require.NoError(t, fromCanonical.(vtMessage).UnmarshalVT(canonical))
+	assert.True(t, proto.Equal(expected, fromCanonical), "vtproto failed to read canonical encoding")
+
+	fromVT := mt.New().Interface()
+	require.NoError(t, proto.Unmarshal(vt, fromVT))
+	assert.True(t, proto.Equal(expected, fromVT), "canonical implementation failed to read vtproto encoding")
+}
+
+// populate sets all fields of the message to non-default values. Only the first field of each oneof is set,
+// nested messages are populated up to the given depth.
+func populate(m protoreflect.Message, depth int) {
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() && m.WhichOneof(od) != nil {
+			continue
+		}
+		isMessage := fd.Message() != nil
+		if isMessage && depth == 0 && !fd.IsMap() {
+			continue
+		}
+		switch {
+		case fd.IsMap():
+			mv := fd.MapValue()
+			if mv.Message() != nil && depth == 0 {
+				continue
+			}
+			mp := m.Mutable(fd).Map()
+			key := scalarValue(fd.MapKey(), 1).MapKey()
+			if mv.Message() != nil {
+				v := mp.NewValue()
+				populate(v.Message(), depth-1)
+				mp.Set(key, v)
+			} else {
+				mp.Set(key, scalarValue(mv, 1))
+			}
+		case fd.IsList():
+			l := m.Mutable(fd).List()
+			for n := 1; n <= 2; n++ {
+				if isMessage {
+					v := l.NewElement()
+					populate(v.Message(), depth-1)
+					l.Append(v)
+				} else {
+					l.Append(scalarValue(fd, n))
+				}
+			}
+		case isMessage:
+			populate(m.Mutable(fd).Message(), depth-1)
+		default:
+			m.Set(fd, scalarValue(fd, 1))
+		}
+	}
+}
+
+func scalarValue(fd protoreflect.FieldDescriptor, n int) protoreflect.Value {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return protoreflect.ValueOfBool(true)
+	case protoreflect.EnumKind:
+		values := fd.Enum().Values()
+		return protoreflect.ValueOfEnum(values.Get(min(n, values.Len()-1)).Number())
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return protoreflect.ValueOfInt32(-int32(n) * 123456)
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return protoreflect.ValueOfInt64(-int64(n) * 1234567890123)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return protoreflect.ValueOfUint32(uint32(n) * 123456789)
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return protoreflect.ValueOfUint64(uint64(n) * 1234567890123)
+	case protoreflect.FloatKind:
+		return protoreflect.ValueOfFloat32(float32(n) + 0.5)
+	case protoreflect.DoubleKind:
+		return protoreflect.ValueOfFloat64(float64(n) + 0.25)
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(fmt.Sprintf("%s-%d", fd.Name(), n))
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte{byte(n), 0, 0xff})
+	default:
+		panic(fmt.Sprintf("unexpected kind %s of field %s", fd.Kind(), fd.FullName()))
+	}
+}
@@ -0,0 +1,13 @@
+// Package generated contains the code generated from the Waves protobuf schemas. The schemas are vendored as
+// the git submodule pkg/grpc/protobuf-schemas, the code is regenerated with
+//
+//	go generate ./pkg/grpc/generated
+//
+// or `make proto` from the root of repository. The compatibility tests of this package check that the generated
+// fast (vtproto) serialization is interchangeable with the canonical protobuf wire format for every message.
+package generated
+
+//go:generate sh -c "protoc --proto_path=../protobuf-schemas/proto/ --go_out=../../.. --go_opt=module=github.com/wavesplatform/gowaves --go-vtproto_out=../../.. --go-vtproto_opt=features=marshal_strict+unmarshal+size --go-vtproto_opt=module=github.com/wavesplatform/gowaves ../protobuf-schemas/proto/waves/*.proto"
+//go:generate sh -c "protoc --proto_path=../protobuf-schemas/proto/ --go_out=../../.. --go_opt=module=github.com/wavesplatform/gowaves --go-grpc_out=../../.. --go-grpc_opt=require_unimplemented_servers=false --go-grpc_opt=module=github.com/wavesplatform/gowaves ../protobuf-schemas/proto/waves/node/grpc/*.proto"
+//go:generate sh -c "protoc --proto_path=../protobuf-schemas/proto/ --go_out=../../.. --go_opt=module=github.com/wavesplatform/gowaves --go-vtproto_out=../../.. --go-vtproto_opt=features=marshal_strict+unmarshal+size --go-vtproto_opt=module=github.com/wavesplatform/gowaves ../protobuf-schemas/proto/waves/events/*.proto"
+//go:generate sh -c "protoc --proto_path=../protobuf-schemas/proto/ --go_out=../../.. --go_opt=module=github.com/wavesplatform/gowaves --go-grpc_out=../../.. --go-grpc_opt=require_unimplemented_servers=false --go-grpc_opt=module=github.com/wavesplatform/gowaves ../protobuf-schemas/proto/waves/events/grpc/*.proto"
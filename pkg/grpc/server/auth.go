@@ -0,0 +1,135 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// APIKeyMetadataKey is the metadata key to pass the API key in, it's the same as the header used by REST API.
+	APIKeyMetadataKey = "x-api-key"
+	// AuthorizationMetadataKey is the metadata key to pass the bearer token in as "Bearer <token>", it's the same as
+	// the header used by REST API.
+	AuthorizationMetadataKey = "authorization"
+
+	bearerPrefix = "Bearer "
+)
+
+// APIKeyChecker returns an error if the API key is not valid.
+type APIKeyChecker func(key string) error
+
+// BearerTokenChecker returns an error if the bearer token is not valid.
+type BearerTokenChecker func(ctx context.Context, token string) error
+
+// defaultPrivilegedMethods are the methods that require the API key, the same as the signing routes of REST API.
+var defaultPrivilegedMethods = []string{
+	"/waves.node.grpc.TransactionsApi/Sign",
+}
+
+// auth keeps the API key and bearer token checkers and the privileged methods. Methods are full gRPC method names,
+// the name ending with a slash covers all methods of the service.
+type auth struct {
+	mu         sync.RWMutex
+	check      APIKeyChecker
+	checkToken BearerTokenChecker
+	privileged map[string]struct{}
+}
+
+func newAuth() *auth {
+	a := &auth{privileged: make(map[string]struct{})}
+	a.require(defaultPrivilegedMethods...)
+	return a
+}
+
+func (a *auth) require(methods ...string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, m := range methods {
+		a.privileged[m] = struct{}{}
+	}
+}
+
+func (a *auth) setChecker(check APIKeyChecker) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.check = check
+}
+
+func (a *auth) setTokenChecker(check BearerTokenChecker) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.checkToken = check
+}
+
+func (a *auth) isPrivileged(method string) bool {
+	if _, ok := a.privileged[method]; ok {
+		return true
+	}
+	if i := strings.LastIndexByte(method, '/'); i > 0 {
+		_, ok := a.privileged[method[:i+1]]
+		return ok
+	}
+	return false
+}
+
+// authorize checks the API key or the bearer token from the incoming metadata if the method is privileged.
+// The bearer token is checked only if the token checker is set, as REST API does when JWT authentication is enabled.
+// Privileged methods are rejected if there is no API key checker, as REST API does when the API key is not set.
+func (a *auth) authorize(ctx context.Context, method string) error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if !a.isPrivileged(method) {
+		return nil
+	}
+	var key, token string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(APIKeyMetadataKey); len(values) > 0 {
+			key = values[0]
+		}
+		if values := md.Get(AuthorizationMetadataKey); len(values) > 0 {
+			if v := values[0]; len(v) > len(bearerPrefix) && strings.EqualFold(v[:len(bearerPrefix)], bearerPrefix) {
+				token = v[len(bearerPrefix):]
+			}
+		}
+	}
+	if token != "" && a.checkToken != nil {
+		if err := a.checkToken(ctx, token); err != nil {
+			return status.Error(codes.PermissionDenied, "bearer token is not valid")
+		}
+		return nil
+	}
+	if key == "" {
+		return status.Errorf(codes.Unauthenticated, "API key is required for method %s", method)
+	}
+	if a.check == nil {
+		return status.Error(codes.PermissionDenied, "API key is not set on the node")
+	}
+	if err := a.check(key); err != nil {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return nil
+}
+
+func (a *auth) unaryInterceptor(
+	ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+) (any, error) {
+	if err := a.authorize(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *auth) streamInterceptor(
+	srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler,
+) error {
+	if err := a.authorize(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
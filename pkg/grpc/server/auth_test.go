@@ -0,0 +1,70 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthorize(t *testing.T) {
+	a := newAuth()
+	a.require("/test.DebugApi/")
+	withKey := func(key string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(APIKeyMetadataKey, key))
+	}
+	const sign = "/waves.node.grpc.TransactionsApi/Sign"
+
+	assert.NoError(t, a.authorize(context.Background(), "/waves.node.grpc.BlocksApi/GetBlock"))
+	assert.Equal(t, codes.Unauthenticated, status.Code(a.authorize(context.Background(), sign)))
+	assert.Equal(t, codes.PermissionDenied, status.Code(a.authorize(withKey("key"), sign)), "no checker")
+
+	a.setChecker(func(key string) error {
+		if key != "key" {
+			return errors.New("invalid key")
+		}
+		return nil
+	})
+	assert.NoError(t, a.authorize(withKey("key"), sign))
+	assert.NoError(t, a.authorize(withKey("key"), "/test.DebugApi/Dump"))
+	assert.Equal(t, codes.PermissionDenied, status.Code(a.authorize(withKey("other"), "/test.DebugApi/Dump")))
+	assert.Equal(t, codes.Unauthenticated, status.Code(a.authorize(context.Background(), "/test.DebugApi/Dump")))
+}
+
+func TestAuthorizeBearerToken(t *testing.T) {
+	a := newAuth()
+	a.setChecker(func(key string) error {
+		if key != "key" {
+			return errors.New("invalid key")
+		}
+		return nil
+	})
+	withMD := func(kv ...string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(kv...))
+	}
+	const sign = "/waves.node.grpc.TransactionsApi/Sign"
+
+	// Bearer tokens are ignored without the token checker.
+	assert.Equal(t, codes.Unauthenticated,
+		status.Code(a.authorize(withMD(AuthorizationMetadataKey, "Bearer token"), sign)))
+
+	a.setTokenChecker(func(_ context.Context, token string) error {
+		if token != "token" {
+			return errors.New("invalid token")
+		}
+		return nil
+	})
+	assert.NoError(t, a.authorize(withMD(AuthorizationMetadataKey, "Bearer token"), sign))
+	assert.NoError(t, a.authorize(withMD(AuthorizationMetadataKey, "bearer token"), sign))
+	assert.Equal(t, codes.PermissionDenied,
+		status.Code(a.authorize(withMD(AuthorizationMetadataKey, "Bearer other"), sign)))
+	assert.Equal(t, codes.PermissionDenied,
+		status.Code(a.authorize(withMD(AuthorizationMetadataKey, "Bearer other", APIKeyMetadataKey, "key"), sign)))
+	assert.Equal(t, codes.Unauthenticated,
+		status.Code(a.authorize(withMD(AuthorizationMetadataKey, "Basic token"), sign)))
+	assert.NoError(t, a.authorize(withMD(AuthorizationMetadataKey, "Basic token", APIKeyMetadataKey, "key"), sign))
+}
@@ -20,6 +20,10 @@ func (s *Server) headerByHeight(height proto.Height) (*g.BlockWithHeight, error)
 	if err != nil {
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
+	return s.headerWithHeight(header, height)
+}
+
+func (s *Server) headerWithHeight(header *proto.BlockHeader, height proto.Height) (*g.BlockWithHeight, error) {
 	vrf, rewards, err := calculateVRFAndRewards(s.state, s.scheme, header, height)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
@@ -100,24 +104,26 @@ func (s *Server) GetBlock(ctx context.Context, req *g.BlockRequest) (*g.BlockWit
 	}
 }
 
+// GetBlockRange streams blocks or only their headers in the given range of heights.
+// Filters are checked against the block headers, so blocks are read only if they are going to be sent.
 func (s *Server) GetBlockRange(req *g.BlockRangeRequest, srv g.BlocksApi_GetBlockRangeServer) error {
-	var filter func(b *g.BlockWithHeight) bool
+	var filter func(h *proto.BlockHeader) bool
 	switch t := req.Filter.(type) {
 	case *g.BlockRangeRequest_GeneratorPublicKey:
-		filter = func(b *g.BlockWithHeight) bool {
-			return bytes.Equal(t.GeneratorPublicKey, b.Block.Header.Generator)
+		filter = func(h *proto.BlockHeader) bool {
+			return bytes.Equal(t.GeneratorPublicKey, h.GeneratorPublicKey.Bytes())
 		}
 	case *g.BlockRangeRequest_GeneratorAddress:
 		addr, err := proto.RebuildAddress(s.scheme, t.GeneratorAddress)
 		if err != nil {
 			return status.Errorf(codes.InvalidArgument, "Invalid address: %s", err.Error())
 		}
-		filter = func(b *g.BlockWithHeight) bool {
-			genaddr, _ := proto.RebuildAddress(s.scheme, t.GeneratorAddress)
-			return addr == genaddr
+		filter = func(h *proto.BlockHeader) bool {
+			genAddr, err := proto.NewAddressFromPublicKey(s.scheme, h.GeneratorPublicKey)
+			return err == nil && addr == genAddr
 		}
 	default:
-		filter = func(b *g.BlockWithHeight) bool {
+		filter = func(*proto.BlockHeader) bool {
 			return true
 		}
 	}
@@ -128,14 +134,26 @@ func (s *Server) GetBlockRange(req *g.BlockRangeRequest, srv g.BlocksApi_GetBloc
 	if req.ToHeight > uint32(stateHeight) {
 		req.ToHeight = uint32(stateHeight)
 	}
-	for height := proto.Height(req.FromHeight); height <= proto.Height(req.ToHeight); height++ {
-		block, err := s.headerOrBlockByHeight(height, req.IncludeTransactions)
+	for height := proto.Height(max(req.FromHeight, 1)); height <= proto.Height(req.ToHeight); height++ {
+		if ctxErr := srv.Context().Err(); ctxErr != nil {
+			return status.FromContextError(ctxErr).Err()
+		}
+		header, err := s.state.HeaderByHeight(height)
 		if err != nil {
 			return status.Error(codes.NotFound, err.Error())
 		}
-		if !filter(block) {
+		if !filter(header) {
 			continue
 		}
+		var block *g.BlockWithHeight
+		if req.IncludeTransactions {
+			block, err = s.blockByHeight(height)
+		} else {
+			block, err = s.headerWithHeight(header, height)
+		}
+		if err != nil {
+			return err
+		}
 		if err := srv.Send(block); err != nil {
 			return status.Error(codes.Internal, err.Error())
 		}
@@ -152,6 +152,24 @@ func TestGetBlockRange(t *testing.T) {
 	}
 	_, err = stream.Recv()
 	assert.Equal(t, io.EOF, err)
+
+	// Filter by generator address.
+	genAddr, err := proto.NewAddressFromPublicKey(proto.MainNetScheme, gen)
+	assert.NoError(t, err)
+	req.Filter = &g.BlockRangeRequest_GeneratorAddress{GeneratorAddress: genAddr.Body()}
+	stream, err = cl.GetBlockRange(ctx, req)
+	assert.NoError(t, err)
+	for h := startHeight; h <= endHeight; h++ {
+		correctBlock := headerFromState(t, h, st)
+		if !bytes.Equal(correctBlock.Block.Header.Generator, genBytes) {
+			continue
+		}
+		block, err := stream.Recv()
+		assert.NoError(t, err)
+		assert.True(t, protobuf.Equal(correctBlock, block))
+	}
+	_, err = stream.Recv()
+	assert.Equal(t, io.EOF, err)
 }
 
 func TestGetCurrentHeight(t *testing.T) {
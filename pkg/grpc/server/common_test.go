@@ -30,8 +30,9 @@ import (
 )
 
 const (
-	sleepTime = 2 * time.Second
-	utxSize   = 1000
+	sleepTime  = 2 * time.Second
+	utxSize    = 1000
+	testAPIKey = "test-api-key"
 )
 
 var (
@@ -134,6 +135,12 @@ func TestMain(m *testing.M) {
 	if err != nil {
 		log.Fatalf("Failed to create new gRPC server: %v", err)
 	}
+	server.SetAPIKeyChecker(func(key string) error {
+		if key != testAPIKey {
+			return errors.New("provided API key is not correct")
+		}
+		return nil
+	})
 	grpcTestAddr = fmt.Sprintf("127.0.0.1:%d", freeport.GetPort())
 	ctx, cancel := context.WithCancel(context.Background())
 	go func() {
@@ -20,7 +20,8 @@ import (
 )
 
 const (
-	DefaultMaxConnections = 128
+	DefaultMaxConnections  = 128
+	DefaultShutdownTimeout = 5 * time.Second
 )
 
 type Server struct {
@@ -29,22 +30,26 @@ type Server struct {
 	utx        types.UtxPool
 	wallet     types.EmbeddedWallet
 	services   services.Services
+	auth       *auth
 	grpcServer *grpc.Server
 }
 
 type RunOptions struct {
 	MaxConnections int
+	// ShutdownTimeout is the time given to in-flight calls to complete before the server is stopped forcibly.
+	ShutdownTimeout time.Duration
 }
 
 func DefaultRunOptions() *RunOptions {
 	return &RunOptions{
-		MaxConnections: DefaultMaxConnections,
+		MaxConnections:  DefaultMaxConnections,
+		ShutdownTimeout: DefaultShutdownTimeout,
 	}
 }
 
 func NewServer(services services.Services) (*Server, error) {
-	s := &Server{}
-	s.grpcServer = createGRPCServerWithHandlers(s)
+	s := &Server{auth: newAuth()}
+	s.grpcServer = createGRPCServerWithHandlers(s, s.auth)
 	s.services = services
 	if err := s.initServer(services.State, services.UtxPool, services.Wallet); err != nil {
 		return nil, err
@@ -52,12 +57,14 @@ func NewServer(services services.Services) (*Server, error) {
 	return s, nil
 }
 
-func createGRPCServerWithHandlers(handlers GrpcHandlers) *grpc.Server {
+func createGRPCServerWithHandlers(handlers GrpcHandlers, a *auth) *grpc.Server {
 	grpcServer := grpc.NewServer(
 		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
 			MinTime:             10 * time.Second,
 			PermitWithoutStream: true,
 		}),
+		grpc.ChainUnaryInterceptor(a.unaryInterceptor),
+		grpc.ChainStreamInterceptor(a.streamInterceptor),
 	)
 	g.RegisterAccountsApiServer(grpcServer, handlers)
 	g.RegisterAssetsApiServer(grpcServer, handlers)
@@ -101,17 +108,59 @@ func (s *Server) Run(ctx context.Context, address string, opts *RunOptions) erro
 	go func() {
 		<-ctx.Done()
 		zap.S().Info("Shutting down gRPC server...")
-		s.Stop()
+		s.GracefulStop(opts.ShutdownTimeout)
 	}()
 	zap.S().Infof("Starting gRPC server on '%s'", address)
 	return s.Serve(conn)
 }
 
+// RegisterService registers additional service on the underlying gRPC server, it must be called before Run.
+func (s *Server) RegisterService(desc *grpc.ServiceDesc, impl any) {
+	s.grpcServer.RegisterService(desc, impl)
+}
+
+// SetAPIKeyChecker sets the function checking the API key passed in APIKeyMetadataKey metadata to privileged
+// methods. Without the checker privileged methods are rejected.
+func (s *Server) SetAPIKeyChecker(check APIKeyChecker) {
+	s.auth.setChecker(check)
+}
+
+// SetBearerTokenChecker sets the function checking the bearer token passed in AuthorizationMetadataKey metadata to
+// privileged methods. Without the checker bearer tokens are ignored.
+func (s *Server) SetBearerTokenChecker(check BearerTokenChecker) {
+	s.auth.setTokenChecker(check)
+}
+
+// RequireAPIKey makes the methods privileged. Methods are full gRPC method names like "/package.Service/Method",
+// the name "/package.Service/" covers all methods of the service. Extensions can use it to protect their services.
+func (s *Server) RequireAPIKey(methods ...string) {
+	s.auth.require(methods...)
+}
+
 // Stop calls underlying gRPC server stop method.
 func (s *Server) Stop() {
 	s.grpcServer.Stop()
 }
 
+// GracefulStop stops the server from accepting new connections and waits for in-flight calls to complete.
+// If the calls aren't finished in the given timeout the server is stopped forcibly.
+func (s *Server) GracefulStop(timeout time.Duration) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.grpcServer.GracefulStop()
+	}()
+	t := time.NewTimer(timeout)
+	defer t.Stop()
+	select {
+	case <-done:
+	case <-t.C:
+		zap.S().Warnf("gRPC server failed to stop gracefully in %s, stopping forcibly", timeout)
+		s.grpcServer.Stop()
+		<-done
+	}
+}
+
 // Serve calls underlying gRPC server serve method with provided net.Listener. This call is blocking.
 func (s *Server) Serve(l net.Listener) error {
 	return s.grpcServer.Serve(l)
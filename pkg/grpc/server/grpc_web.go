@@ -0,0 +1,295 @@
+package server
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	grpcWebContentType     = "application/grpc-web"
+	grpcWebTextContentType = "application/grpc-web-text"
+	grpcContentType        = "application/grpc"
+
+	grpcWebTrailerFlag = 0x80
+	// grpcWebMaxTextRequestSize limits the size of base64 encoded requests, which are decoded in memory.
+	grpcWebMaxTextRequestSize = 8 * 1024 * 1024
+)
+
+var (
+	grpcWebExposedHeaders = []string{"grpc-status", "grpc-message", "grpc-status-details-bin"}
+	grpcWebAllowedHeaders = []string{
+		"content-type", "x-grpc-web", "x-user-agent", "grpc-timeout", APIKeyMetadataKey, AuthorizationMetadataKey,
+	}
+)
+
+// GRPCWebOptions configures serving of gRPC-Web requests.
+type GRPCWebOptions struct {
+	// AllowedOrigins lists the origins of web pages allowed to call gRPC API, e.g. "https://dapp.example.com",
+	// "*" allows any origin. Requests without Origin header and requests of the pages served from the same host
+	// are always allowed.
+	AllowedOrigins []string
+	// MaxConnections limits the number of simultaneous gRPC-Web calls the same way as the number of connections
+	// to gRPC server is limited. Zero means no limit.
+	MaxConnections int
+}
+
+// ParseAllowedOrigins parses the comma separated list of origins allowed to make gRPC-Web requests.
+func ParseAllowedOrigins(s string) ([]string, error) {
+	var res []string
+	for _, o := range strings.Split(s, ",") {
+		o = strings.TrimSpace(o)
+		if o == "" {
+			continue
+		}
+		if o != "*" {
+			u, err := url.Parse(o)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+				(u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+				return nil, errors.Errorf("invalid origin '%s'", o)
+			}
+			o = u.Scheme + "://" + u.Host
+		}
+		res = append(res, o)
+	}
+	return res, nil
+}
+
+type grpcWebOrigins struct {
+	any     bool
+	allowed map[string]struct{}
+}
+
+func newGRPCWebOrigins(origins []string) grpcWebOrigins {
+	res := grpcWebOrigins{allowed: make(map[string]struct{}, len(origins))}
+	for _, o := range origins {
+		if o == "*" {
+			res.any = true
+		}
+		res.allowed[strings.ToLower(o)] = struct{}{}
+	}
+	return res
+}
+
+// isAllowed checks the Origin header of the request. Requests from the same host are allowed, because browsers
+// send Origin header with POST requests of the same origin too.
+func (o grpcWebOrigins) isAllowed(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" || o.any {
+		return true
+	}
+	if _, ok := o.allowed[strings.ToLower(origin)]; ok {
+		return true
+	}
+	u, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(u.Host, r.Host)
+}
+
+// GRPCWebMiddleware returns the HTTP middleware that serves gRPC-Web requests by the gRPC server and passes other
+// requests to the next handler. It makes gRPC APIs, including server streaming, available to browsers and other
+// HTTP/1.1 clients on the port of REST API without a separate proxy. Both binary and text (base64) encodings of
+// gRPC-Web protocol are supported, CORS preflight requests of gRPC-Web clients are answered too.
+// Requests from the origins which are not allowed by the options are rejected.
+func (s *Server) GRPCWebMiddleware(opts GRPCWebOptions) func(next http.Handler) http.Handler {
+	origins := newGRPCWebOrigins(opts.AllowedOrigins)
+	var sem chan struct{}
+	if opts.MaxConnections > 0 {
+		sem = make(chan struct{}, opts.MaxConnections)
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case isGRPCWebRequest(r):
+				w.Header().Add("Vary", "Origin")
+				if !origins.isAllowed(r) {
+					http.Error(w, "origin is not allowed", http.StatusForbidden)
+					return
+				}
+				if sem != nil {
+					select {
+					case sem <- struct{}{}:
+						defer func() { <-sem }()
+					default:
+						http.Error(w, "too many simultaneous gRPC-Web calls", http.StatusServiceUnavailable)
+						return
+					}
+				}
+				s.serveGRPCWeb(w, r)
+			case isGRPCWebPreflightRequest(r):
+				h := w.Header()
+				h.Add("Vary", "Origin")
+				if !origins.isAllowed(r) {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+				h.Set("Access-Control-Allow-Origin", r.Header.Get("Origin"))
+				h.Set("Access-Control-Allow-Methods", http.MethodPost)
+				h.Set("Access-Control-Allow-Headers", strings.Join(grpcWebAllowedHeaders, ", "))
+				h.Set("Access-Control-Max-Age", "600")
+				w.WriteHeader(http.StatusNoContent)
+			default:
+				next.ServeHTTP(w, r)
+			}
+		})
+	}
+}
+
+func isGRPCWebRequest(r *http.Request) bool {
+	return r.Method == http.MethodPost && strings.HasPrefix(r.Header.Get("Content-Type"), grpcWebContentType)
+}
+
+func isGRPCWebPreflightRequest(r *http.Request) bool {
+	if r.Method != http.MethodOptions || r.Header.Get("Access-Control-Request-Method") != http.MethodPost {
+		return false
+	}
+	for _, h := range strings.Split(r.Header.Get("Access-Control-Request-Headers"), ",") {
+		if strings.EqualFold(strings.TrimSpace(h), "x-grpc-web") {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) serveGRPCWeb(w http.ResponseWriter, r *http.Request) {
+	ct := r.Header.Get("Content-Type")
+	text := strings.HasPrefix(ct, grpcWebTextContentType)
+	subtype := strings.TrimPrefix(strings.TrimPrefix(ct, grpcWebTextContentType), grpcWebContentType)
+
+	req := r.Clone(r.Context())
+	req.ProtoMajor, req.ProtoMinor, req.Proto = 2, 0, "HTTP/2"
+	req.Header.Set("Content-Type", grpcContentType+subtype)
+	req.Header.Del("Content-Length")
+	req.ContentLength = -1
+	if text {
+		body, err := decodeGRPCWebText(http.MaxBytesReader(w, r.Body, grpcWebMaxTextRequestSize))
+		if err != nil {
+			http.Error(w, "invalid base64 request body", http.StatusBadRequest)
+			return
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	if origin := r.Header.Get("Origin"); origin != "" {
+		h := w.Header()
+		h.Set("Access-Control-Allow-Origin", origin)
+		h.Set("Access-Control-Expose-Headers", strings.Join(grpcWebExposedHeaders, ", "))
+	}
+	ww := &grpcWebResponseWriter{w: w, header: make(http.Header), contentType: ct, text: text}
+	s.grpcServer.ServeHTTP(ww, req)
+	ww.finish()
+}
+
+// decodeGRPCWebText decodes base64 request body, which could be a concatenation of padded base64 chunks.
+func decodeGRPCWebText(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]byte, 0, base64.StdEncoding.DecodedLen(len(data)))
+	for len(data) > 0 {
+		n := bytes.IndexByte(data, '=')
+		if n < 0 {
+			n = len(data)
+		} else {
+			for n < len(data) && data[n] == '=' {
+				n++
+			}
+		}
+		chunk := make([]byte, base64.StdEncoding.DecodedLen(n))
+		m, dErr := base64.StdEncoding.Decode(chunk, data[:n])
+		if dErr != nil {
+			return nil, dErr
+		}
+		res = append(res, chunk[:m]...)
+		data = data[n:]
+	}
+	return res, nil
+}
+
+// grpcWebResponseWriter translates the response of gRPC server to gRPC-Web protocol. Headers are written as is,
+// trailers are written as the last message of the body.
+type grpcWebResponseWriter struct {
+	w             http.ResponseWriter
+	header        http.Header
+	contentType   string
+	text          bool
+	headerWritten bool
+}
+
+func (ww *grpcWebResponseWriter) Header() http.Header {
+	return ww.header
+}
+
+func (ww *grpcWebResponseWriter) WriteHeader(code int) {
+	if ww.headerWritten {
+		return
+	}
+	ww.headerWritten = true
+	h := ww.w.Header()
+	declared := ww.header.Values("Trailer")
+	for k, vv := range ww.header {
+		if k == "Trailer" || strings.HasPrefix(k, http.TrailerPrefix) || containsFold(declared, k) {
+			continue
+		}
+		h[k] = vv
+	}
+	h.Set("Content-Type", ww.contentType)
+	h.Del("Content-Length")
+	ww.w.WriteHeader(code)
+}
+
+func (ww *grpcWebResponseWriter) Write(b []byte) (int, error) {
+	ww.WriteHeader(http.StatusOK)
+	if ww.text {
+		if _, err := io.WriteString(ww.w, base64.StdEncoding.EncodeToString(b)); err != nil {
+			return 0, err
+		}
+		return len(b), nil
+	}
+	return ww.w.Write(b)
+}
+
+func (ww *grpcWebResponseWriter) Flush() {
+	ww.WriteHeader(http.StatusOK)
+	if f, ok := ww.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// finish writes trailers set by gRPC server as the trailers frame.
+func (ww *grpcWebResponseWriter) finish() {
+	var buf bytes.Buffer
+	declared := ww.header.Values("Trailer")
+	for k, vv := range ww.header {
+		name, ok := strings.CutPrefix(k, http.TrailerPrefix)
+		if !ok && !containsFold(declared, k) {
+			continue
+		}
+		for _, v := range vv {
+			buf.WriteString(strings.ToLower(name))
+			buf.WriteString(": ")
+			buf.WriteString(v)
+			buf.WriteString("\r\n")
+		}
+	}
+	frame := make([]byte, 5, 5+buf.Len())
+	frame[0] = grpcWebTrailerFlag
+	binary.BigEndian.PutUint32(frame[1:], uint32(buf.Len()))
+	frame = append(frame, buf.Bytes()...)
+	_, _ = ww.Write(frame)
+	ww.Flush()
+}
+
+func containsFold(ss []string, s string) bool {
+	for _, v := range ss {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
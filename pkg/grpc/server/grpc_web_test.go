@@ -0,0 +1,212 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	pb "google.golang.org/protobuf/proto"
+)
+
+func grpcWebFrame(t *testing.T, m pb.Message) []byte {
+	b, err := pb.Marshal(m)
+	require.NoError(t, err)
+	frame := make([]byte, 5, 5+len(b))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(b)))
+	return append(frame, b...)
+}
+
+// parseGRPCWebResponse splits the response body to messages and trailers.
+func parseGRPCWebResponse(t *testing.T, body []byte) ([][]byte, map[string]string) {
+	var messages [][]byte
+	trailers := make(map[string]string)
+	for len(body) > 0 {
+		require.GreaterOrEqual(t, len(body), 5)
+		n := int(binary.BigEndian.Uint32(body[1:5]))
+		require.GreaterOrEqual(t, len(body), 5+n)
+		data := body[5 : 5+n]
+		if body[0]&grpcWebTrailerFlag != 0 {
+			for _, l := range strings.Split(strings.TrimSpace(string(data)), "\r\n") {
+				k, v, ok := strings.Cut(l, ": ")
+				require.True(t, ok)
+				trailers[k] = v
+			}
+		} else {
+			messages = append(messages, data)
+		}
+		body = body[5+n:]
+	}
+	return messages, trailers
+}
+
+func TestGRPCWebMiddleware(t *testing.T) {
+	hs := health.NewServer()
+	hs.SetServingStatus("waves", healthpb.HealthCheckResponse_SERVING)
+	s := &Server{auth: newAuth(), grpcServer: grpc.NewServer()}
+	healthpb.RegisterHealthServer(s.grpcServer, hs)
+	mw := s.GRPCWebMiddleware(GRPCWebOptions{AllowedOrigins: []string{"https://dapp.example.com"}})
+	srv := httptest.NewServer(mw(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("REST"))
+	})))
+	defer srv.Close()
+
+	postFrom := func(origin, contentType string, body []byte) *http.Response {
+		req, err := http.NewRequest(http.MethodPost, srv.URL+"/grpc.health.v1.Health/Check", bytes.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", contentType)
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		resp, err := srv.Client().Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+	post := func(contentType string, body []byte) *http.Response {
+		return postFrom("https://dapp.example.com", contentType, body)
+	}
+	read := func(resp *http.Response) []byte {
+		defer func() { _ = resp.Body.Close() }()
+		b, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return b
+	}
+
+	t.Run("binary", func(t *testing.T) {
+		resp := post("application/grpc-web+proto", grpcWebFrame(t, &healthpb.HealthCheckRequest{Service: "waves"}))
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/grpc-web+proto", resp.Header.Get("Content-Type"))
+		assert.Equal(t, "https://dapp.example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+		messages, trailers := parseGRPCWebResponse(t, read(resp))
+		require.Len(t, messages, 1)
+		var res healthpb.HealthCheckResponse
+		require.NoError(t, pb.Unmarshal(messages[0], &res))
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, res.GetStatus())
+		assert.Equal(t, "0", trailers["grpc-status"])
+	})
+	t.Run("text", func(t *testing.T) {
+		frame := grpcWebFrame(t, &healthpb.HealthCheckRequest{Service: "waves"})
+		// Request is split to padded chunks as some clients do.
+		body := base64.StdEncoding.EncodeToString(frame[:4]) + base64.StdEncoding.EncodeToString(frame[4:])
+		resp := post("application/grpc-web-text", []byte(body))
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/grpc-web-text", resp.Header.Get("Content-Type"))
+		decoded, err := decodeGRPCWebText(bytes.NewReader(read(resp)))
+		require.NoError(t, err)
+		messages, trailers := parseGRPCWebResponse(t, decoded)
+		require.Len(t, messages, 1)
+		assert.Equal(t, "0", trailers["grpc-status"])
+	})
+	t.Run("error status", func(t *testing.T) {
+		resp := post("application/grpc-web", grpcWebFrame(t, &healthpb.HealthCheckRequest{Service: "unknown"}))
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		messages, trailers := parseGRPCWebResponse(t, read(resp))
+		assert.Empty(t, messages)
+		assert.Equal(t, "5", trailers["grpc-status"]) // NOT_FOUND
+		assert.NotEmpty(t, trailers["grpc-message"])
+	})
+	preflight := func(origin, headers string) *http.Response {
+		req, err := http.NewRequest(http.MethodOptions, srv.URL+"/grpc.health.v1.Health/Check", nil)
+		require.NoError(t, err)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		req.Header.Set("Access-Control-Request-Headers", headers)
+		resp, err := srv.Client().Do(req)
+		require.NoError(t, err)
+		_ = read(resp)
+		return resp
+	}
+	t.Run("preflight", func(t *testing.T) {
+		resp := preflight("https://dapp.example.com", "content-type,x-grpc-web,x-user-agent,x-custom")
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		assert.Equal(t, "https://dapp.example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, strings.Join(grpcWebAllowedHeaders, ", "), resp.Header.Get("Access-Control-Allow-Headers"))
+	})
+	t.Run("not allowed origin", func(t *testing.T) {
+		resp := preflight("https://evil.example.com", "content-type,x-grpc-web")
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		assert.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+
+		frame := grpcWebFrame(t, &healthpb.HealthCheckRequest{Service: "waves"})
+		resp = postFrom("https://evil.example.com", "application/grpc-web+proto", frame)
+		_ = read(resp)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		assert.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+	})
+	t.Run("same origin and no origin", func(t *testing.T) {
+		frame := grpcWebFrame(t, &healthpb.HealthCheckRequest{Service: "waves"})
+		for _, origin := range []string{srv.URL, ""} {
+			resp := postFrom(origin, "application/grpc-web+proto", frame)
+			_ = read(resp)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		}
+	})
+	t.Run("other requests", func(t *testing.T) {
+		resp := post("application/json", []byte("{}"))
+		assert.Equal(t, "REST", string(read(resp)))
+	})
+}
+
+func TestGRPCWebMiddlewareMaxConnections(t *testing.T) {
+	hs := &blockingHealthServer{started: make(chan struct{}), block: make(chan struct{})}
+	s := &Server{auth: newAuth(), grpcServer: grpc.NewServer()}
+	healthpb.RegisterHealthServer(s.grpcServer, hs)
+	h := s.GRPCWebMiddleware(GRPCWebOptions{MaxConnections: 1})(http.NotFoundHandler())
+	call := func() int {
+		frame := grpcWebFrame(t, &healthpb.HealthCheckRequest{Service: "waves"})
+		req := httptest.NewRequest(http.MethodPost, "/grpc.health.v1.Health/Check", bytes.NewReader(frame))
+		req.Header.Set("Content-Type", "application/grpc-web")
+		resp := httptest.NewRecorder()
+		h.ServeHTTP(resp, req)
+		return resp.Code
+	}
+
+	done := make(chan int)
+	go func() { done <- call() }() // The first call is blocked in the handler.
+	<-hs.started
+	assert.Equal(t, http.StatusServiceUnavailable, call())
+	close(hs.block)
+	assert.Equal(t, http.StatusOK, <-done)
+	assert.Equal(t, http.StatusOK, call())
+}
+
+type blockingHealthServer struct {
+	healthpb.UnimplementedHealthServer
+	once    sync.Once
+	started chan struct{}
+	block   chan struct{}
+}
+
+func (b *blockingHealthServer) Check(
+	context.Context, *healthpb.HealthCheckRequest,
+) (*healthpb.HealthCheckResponse, error) {
+	b.once.Do(func() {
+		close(b.started)
+		<-b.block
+	})
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
+
+func TestParseAllowedOrigins(t *testing.T) {
+	origins, err := ParseAllowedOrigins(" https://dapp.example.com, http://localhost:3000/ ,*")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://dapp.example.com", "http://localhost:3000", "*"}, origins)
+	origins, err = ParseAllowedOrigins("")
+	require.NoError(t, err)
+	assert.Empty(t, origins)
+	for _, invalid := range []string{"dapp.example.com", "ftp://dapp.example.com", "https://dapp.example.com/path"} {
+		_, err = ParseAllowedOrigins(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	"github.com/wavesplatform/gowaves/pkg/crypto"
@@ -276,6 +277,10 @@ func TestSign(t *testing.T) {
 	cl := g.NewTransactionsApiClient(conn)
 	req := &g.SignRequest{Transaction: txProto, SignerPublicKey: pk.Bytes()}
 	_, err = cl.Sign(ctx, req)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = cl.Sign(metadata.AppendToOutgoingContext(ctx, APIKeyMetadataKey, "wrong"), req)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = cl.Sign(metadata.AppendToOutgoingContext(ctx, APIKeyMetadataKey, testAPIKey), req)
 	require.Error(t, err)
 	s, ok := status.FromError(err)
 	require.True(t, ok)
@@ -290,7 +295,7 @@ func TestBroadcast(t *testing.T) {
 	h := mock.NewMockGrpcHandlers(ctrl)
 	h.EXPECT().Broadcast(gomock.Any(), gomock.Any()).Return(&pb.SignedTransaction{}, nil)
 
-	gRPCServer := createGRPCServerWithHandlers(h)
+	gRPCServer := createGRPCServerWithHandlers(h, newAuth())
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
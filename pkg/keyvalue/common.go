@@ -4,7 +4,10 @@ import (
 	"github.com/pkg/errors"
 )
 
-var ErrNotFound = errors.New("not found")
+var (
+	ErrNotFound = errors.New("not found")
+	ErrReadOnly = errors.New("database is opened in read-only mode")
+)
 
 type KeyValue interface {
 	NewBatch() (Batch, error)
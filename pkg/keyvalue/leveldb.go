@@ -91,10 +91,11 @@ func (b *batch) Reset() {
 }
 
 type KeyVal struct {
-	db     *leveldb.DB
-	filter BloomFilter
-	cache  *freecache.Cache
-	mu     *sync.RWMutex
+	db       *leveldb.DB
+	filter   BloomFilter
+	cache    *freecache.Cache
+	mu       *sync.RWMutex
+	readOnly bool
 }
 
 func initBloomFilter(kv *KeyVal, params BloomFilterParams) error {
@@ -138,6 +139,9 @@ type KeyValParams struct {
 	CompactionTableSize    int
 	CompactionTotalSize    int
 	OpenFilesCacheCapacity int
+	// ReadOnly opens the database in read-only mode, all modifications are rejected with ErrReadOnly.
+	// Bloom filter is not used and not stored in this mode.
+	ReadOnly bool
 }
 
 func NewKeyVal(path string, params KeyValParams) (*KeyVal, error) {
@@ -158,13 +162,19 @@ func NewKeyVal(path string, params KeyValParams) (*KeyVal, error) {
 		CompactionTableSize:    params.CompactionTableSize,
 		CompactionTotalSize:    params.CompactionTotalSize,
 		OpenFilesCacheCapacity: openFilesCacheCapacity,
+		ReadOnly:               params.ReadOnly,
+		ErrorIfMissing:         params.ReadOnly,
 	}
 	db, err := leveldb.OpenFile(path, dbOptions)
 	if err != nil {
 		return nil, err
 	}
 	cache := freecache.NewCache(params.CacheSize)
-	kv := &KeyVal{db: db, cache: cache, mu: &sync.RWMutex{}}
+	kv := &KeyVal{db: db, cache: cache, mu: &sync.RWMutex{}, readOnly: params.ReadOnly}
+	if params.ReadOnly {
+		kv.filter = NewBloomFilterStub(params.BloomFilterParams)
+		return kv, nil
+	}
 	if err := initBloomFilter(kv, params.BloomFilterParams); err != nil {
 		return nil, err
 	}
@@ -225,6 +235,9 @@ func (k *KeyVal) Has(key []byte) (bool, error) {
 }
 
 func (k *KeyVal) Delete(key []byte) error {
+	if k.readOnly {
+		return ErrReadOnly
+	}
 	k.mu.Lock()
 	defer k.mu.Unlock()
 	k.cache.Del(key)
@@ -232,6 +245,9 @@ func (k *KeyVal) Delete(key []byte) error {
 }
 
 func (k *KeyVal) Put(key, val []byte) error {
+	if k.readOnly {
+		return ErrReadOnly
+	}
 	k.mu.Lock()
 	defer k.mu.Unlock()
 	if err := k.db.Put(key, val, nil); err != nil {
@@ -245,6 +261,9 @@ func (k *KeyVal) Put(key, val []byte) error {
 }
 
 func (k *KeyVal) Flush(b1 Batch) error {
+	if k.readOnly {
+		return ErrReadOnly
+	}
 	k.mu.Lock()
 	defer k.mu.Unlock()
 	b, ok := b1.(*batch)
@@ -276,6 +295,9 @@ func (k *KeyVal) Close() error {
 	k.mu.Lock()
 	defer k.mu.Unlock()
 	zap.S().Infof("Cache hit rate: %v", k.cache.HitRate())
+	if k.readOnly {
+		return k.db.Close()
+	}
 	err := storeBloomFilter(k.filter)
 	if err != nil {
 		zap.S().Errorf("Failed to save bloom filter: %v", err)
@@ -284,3 +306,38 @@ func (k *KeyVal) Close() error {
 	}
 	return k.db.Close()
 }
+
+// Snapshot is a frozen read-only view of the database at the moment of its creation.
+type Snapshot struct {
+	s *leveldb.Snapshot
+}
+
+// NewSnapshot returns a snapshot of the current database state, it must be released after use.
+func (k *KeyVal) NewSnapshot() (*Snapshot, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	s, err := k.db.GetSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	return &Snapshot{s: s}, nil
+}
+
+func (s *Snapshot) Get(key []byte) ([]byte, error) {
+	val, err := s.s.Get(key, nil)
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return nil, ErrNotFound
+	}
+	return val, err
+}
+
+func (s *Snapshot) NewKeyIterator(prefix []byte) (Iterator, error) {
+	if prefix != nil {
+		return s.s.NewIterator(util.BytesPrefix(prefix), nil), nil
+	}
+	return s.s.NewIterator(nil, nil), nil
+}
+
+func (s *Snapshot) Release() {
+	s.s.Release()
+}
@@ -97,3 +97,108 @@ func TestKeyVal(t *testing.T) {
 	err = iter.Error()
 	assert.NoError(t, err, "iterator error")
 }
+
+func TestKeyValReadOnly(t *testing.T) {
+	dbDir := t.TempDir()
+	params := KeyValParams{
+		CacheParams:         CacheParams{cacheSize},
+		BloomFilterParams:   BloomFilterParams{n, falsePositiveProbability, NoOpStore{}, false},
+		WriteBuffer:         writeBuffer,
+		CompactionTableSize: sstableSize,
+		CompactionTotalSize: compactionTotalSize,
+	}
+	roParams := params
+	roParams.ReadOnly = true
+	_, err := NewKeyVal(dbDir, roParams)
+	assert.Error(t, err, "read-only database must exist")
+
+	kv, err := NewKeyVal(dbDir, params)
+	assert.NoError(t, err, "NewKeyVal() failed")
+	key := []byte("sampleKey")
+	val := []byte("sampleValue")
+	err = kv.Put(key, val)
+	assert.NoError(t, err, "Put() failed")
+	err = kv.Close()
+	assert.NoError(t, err, "Close() failed")
+
+	ro, err := NewKeyVal(dbDir, roParams)
+	assert.NoError(t, err, "NewKeyVal() failed")
+	t.Cleanup(func() {
+		err = ro.Close()
+		assert.NoError(t, err, "Close() failed")
+	})
+	receivedVal, err := ro.Get(key)
+	assert.NoError(t, err, "Get() failed")
+	assert.Equal(t, val, receivedVal)
+	assert.ErrorIs(t, ro.Put(key, val), ErrReadOnly)
+	assert.ErrorIs(t, ro.Delete(key), ErrReadOnly)
+	batch, err := ro.NewBatch()
+	assert.NoError(t, err, "NewBatch() failed")
+	batch.Put(key, val)
+	assert.ErrorIs(t, ro.Flush(batch), ErrReadOnly)
+}
+
+func TestKeyValSnapshot(t *testing.T) {
+	params := KeyValParams{
+		CacheParams:       CacheParams{cacheSize},
+		BloomFilterParams: BloomFilterParams{n, falsePositiveProbability, NoOpStore{}, false},
+	}
+	kv, err := NewKeyVal(t.TempDir(), params)
+	assert.NoError(t, err, "NewKeyVal() failed")
+	t.Cleanup(func() {
+		assert.NoError(t, kv.Close(), "Close() failed")
+	})
+
+	key, val := []byte("key"), []byte("value")
+	assert.NoError(t, kv.Put(key, val))
+	s, err := kv.NewSnapshot()
+	assert.NoError(t, err, "NewSnapshot() failed")
+	defer s.Release()
+
+	assert.NoError(t, kv.Put(key, []byte("changed")))
+	assert.NoError(t, kv.Put([]byte("new"), val))
+
+	got, err := s.Get(key)
+	assert.NoError(t, err)
+	assert.Equal(t, val, got)
+	_, err = s.Get([]byte("new"))
+	assert.ErrorIs(t, err, ErrNotFound)
+	iter, err := s.NewKeyIterator(nil)
+	assert.NoError(t, err)
+	defer iter.Release()
+	count := 0
+	for iter.Next() {
+		count++
+	}
+	assert.NoError(t, iter.Error())
+	assert.Equal(t, 1, count)
+}
+
+func TestKeyValStats(t *testing.T) {
+	params := KeyValParams{
+		CacheParams:       CacheParams{cacheSize},
+		BloomFilterParams: BloomFilterParams{n, falsePositiveProbability, NoOpStore{}, false},
+	}
+	kv, err := NewKeyVal(t.TempDir(), params)
+	assert.NoError(t, err, "NewKeyVal() failed")
+	t.Cleanup(func() {
+		assert.NoError(t, kv.Close(), "Close() failed")
+	})
+
+	key := []byte("key")
+	assert.NoError(t, kv.Put(key, []byte("value")))
+	_, err = kv.Get(key) // values are cached on writes
+	assert.NoError(t, err)
+	_, err = kv.Get([]byte("unknown"))
+	assert.ErrorIs(t, err, ErrNotFound)
+	iter, err := kv.NewKeyIterator(nil)
+	assert.NoError(t, err)
+	defer iter.Release()
+
+	st, err := kv.Stats()
+	assert.NoError(t, err, "Stats() failed")
+	assert.EqualValues(t, 1, st.AliveIterators)
+	assert.EqualValues(t, 1, st.Cache.Hits)
+	assert.EqualValues(t, 1, st.Cache.Misses)
+	assert.EqualValues(t, 1, st.Cache.Entries)
+}
@@ -0,0 +1,88 @@
+package keyvalue
+
+import (
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// LevelStats are the statistics of one level of the database.
+type LevelStats struct {
+	Level              int           `json:"level"`
+	Tables             int           `json:"tables"`
+	Size               int64         `json:"size"`
+	Read               int64         `json:"read"`
+	Write              int64         `json:"write"`
+	CompactionDuration time.Duration `json:"compactionDurationNs"`
+}
+
+// CacheStats are the statistics of the in-memory cache in front of the database.
+type CacheStats struct {
+	Entries     int64   `json:"entries"`
+	Hits        int64   `json:"hits"`
+	Misses      int64   `json:"misses"`
+	HitRate     float64 `json:"hitRate"`
+	Evacuations int64   `json:"evacuations"`
+}
+
+// Stats are the statistics of the database. Growing number of tables on level 0 together with delayed or paused
+// writes means that compactions don't keep up with the writes.
+type Stats struct {
+	Levels               []LevelStats  `json:"levels"`
+	MemCompactions       uint32        `json:"memCompactions"`
+	Level0Compactions    uint32        `json:"level0Compactions"`
+	NonLevel0Compactions uint32        `json:"nonLevel0Compactions"`
+	SeekCompactions      uint32        `json:"seekCompactions"`
+	WriteDelayCount      int32         `json:"writeDelayCount"`
+	WriteDelayDuration   time.Duration `json:"writeDelayDurationNs"`
+	WritePaused          bool          `json:"writePaused"`
+	IORead               uint64        `json:"ioRead"`
+	IOWrite              uint64        `json:"ioWrite"`
+	OpenedTables         int           `json:"openedTables"`
+	AliveSnapshots       int32         `json:"aliveSnapshots"`
+	AliveIterators       int32         `json:"aliveIterators"`
+	BlockCacheSize       int           `json:"blockCacheSize"`
+	Cache                CacheStats    `json:"cache"`
+}
+
+// Stats returns the current statistics of the database.
+func (k *KeyVal) Stats() (*Stats, error) {
+	var ds leveldb.DBStats
+	if err := k.db.Stats(&ds); err != nil {
+		return nil, err
+	}
+	levels := make([]LevelStats, len(ds.LevelSizes))
+	for i := range levels {
+		levels[i] = LevelStats{
+			Level:              i,
+			Tables:             ds.LevelTablesCounts[i],
+			Size:               ds.LevelSizes[i],
+			Read:               ds.LevelRead[i],
+			Write:              ds.LevelWrite[i],
+			CompactionDuration: ds.LevelDurations[i],
+		}
+	}
+	return &Stats{
+		Levels:               levels,
+		MemCompactions:       ds.MemComp,
+		Level0Compactions:    ds.Level0Comp,
+		NonLevel0Compactions: ds.NonLevel0Comp,
+		SeekCompactions:      ds.SeekComp,
+		WriteDelayCount:      ds.WriteDelayCount,
+		WriteDelayDuration:   ds.WriteDelayDuration,
+		WritePaused:          ds.WritePaused,
+		IORead:               ds.IORead,
+		IOWrite:              ds.IOWrite,
+		OpenedTables:         ds.OpenedTablesCount,
+		AliveSnapshots:       ds.AliveSnapshots,
+		AliveIterators:       ds.AliveIterators,
+		BlockCacheSize:       ds.BlockCacheSize,
+		Cache: CacheStats{
+			Entries:     k.cache.EntryCount(),
+			Hits:        k.cache.HitCount(),
+			Misses:      k.cache.MissCount(),
+			HitRate:     k.cache.HitRate(),
+			Evacuations: k.cache.EvacuateCount(),
+		},
+	}, nil
+}
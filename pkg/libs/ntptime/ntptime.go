@@ -6,9 +6,22 @@ import (
 	"time"
 
 	"github.com/beevik/ntp"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 )
 
+var metricClockOffset = prometheus.NewGauge(
+	prometheus.GaugeOpts{
+		Namespace: "ntp",
+		Name:      "clock_offset_seconds",
+		Help:      "Offset of the local clock from NTP server time.",
+	},
+)
+
+func init() {
+	prometheus.MustRegister(metricClockOffset)
+}
+
 type inner interface {
 	Query(addr string) (*ntp.Response, error)
 }
@@ -29,10 +42,11 @@ func (ntpInner) Query(addr string) (*ntp.Response, error) {
 }
 
 type ntpTimeImpl struct {
-	mu     sync.RWMutex
-	offset time.Duration
-	addr   string
-	inner  inner
+	mu       sync.RWMutex
+	offset   time.Duration
+	measured time.Time
+	addr     string
+	inner    inner
 }
 
 func TryNew(addr string, tries uint) (*ntpTimeImpl, error) {
@@ -60,7 +74,7 @@ func newNTPTime(addr string, inner inner) (*ntpTimeImpl, error) {
 	if err != nil {
 		return nil, err
 	}
-	a.offset = tm.ClockOffset
+	a.setOffset(tm.ClockOffset)
 	return a, nil
 }
 
@@ -82,13 +96,26 @@ func (a *ntpTimeImpl) Run(ctx context.Context, duration time.Duration) {
 				zap.S().Debug("ntpTimeImpl Run: ", err)
 				continue
 			}
-			a.mu.Lock()
-			a.offset = tm.ClockOffset
-			a.mu.Unlock()
+			a.setOffset(tm.ClockOffset)
 		}
 	}
 }
 
+func (a *ntpTimeImpl) setOffset(offset time.Duration) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.offset = offset
+	a.measured = time.Now()
+	metricClockOffset.Set(offset.Seconds())
+}
+
+// ClockOffset returns the last measured offset of the local clock from NTP server time and the time of measurement.
+func (a *ntpTimeImpl) ClockOffset() (time.Duration, time.Time) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.offset, a.measured
+}
+
 func (a *ntpTimeImpl) Now() time.Time {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
@@ -21,6 +21,9 @@ func TestNtpTimeImpl_Run(t *testing.T) {
 	require.NoError(t, err)
 	rs := tm.Now()
 	require.NotEmpty(t, rs)
+	offset, measured := tm.ClockOffset()
+	require.Equal(t, time.Second, offset)
+	require.False(t, measured.IsZero())
 
 	ctx, cancel := context.WithCancel(context.Background())
 	go tm.Run(ctx, 0)
@@ -21,3 +21,8 @@ type Stub struct {
 func (s Stub) Now() time.Time {
 	return time.Now()
 }
+
+// ClockOffset of Stub is never measured.
+func (s Stub) ClockOffset() (time.Duration, time.Time) {
+	return 0, time.Time{}
+}
@@ -3,16 +3,49 @@ package logging
 import (
 	"fmt"
 	"os"
+	"strings"
 
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
+	"go.uber.org/zap/buffer"
 	"go.uber.org/zap/zapcore"
 	"moul.io/zapfilter"
 )
 
+// Format is the format of log output.
+type Format string
+
+const (
+	// TextFormat is the human-readable output.
+	TextFormat Format = "text"
+	// JSONFormat is the output of one JSON object per line with the time, level, subsystem, message and fields
+	// of the event.
+	JSONFormat Format = "json"
+)
+
+// defaultSubsystem is set in JSON output for events logged without the namespace.
+const defaultSubsystem = "node"
+
+func (f Format) MarshalText() ([]byte, error) {
+	return []byte(f), nil
+}
+
+func (f *Format) UnmarshalText(text []byte) error {
+	switch v := Format(strings.ToLower(string(text))); v {
+	case TextFormat, JSONFormat:
+		*f = v
+		return nil
+	default:
+		return errors.Errorf("unknown log format '%s'", text)
+	}
+}
+
 type config struct {
 	filter zapfilter.FilterFunc
 	opts   []zap.Option
 	ec     zapcore.EncoderConfig
+	format Format
+	out    zapcore.WriteSyncer
 }
 
 func newConfig(opts []Option) *config {
@@ -23,6 +56,8 @@ func newConfig(opts []Option) *config {
 	c := &config{
 		filter: f,
 		ec:     zap.NewDevelopmentEncoderConfig(),
+		format: TextFormat,
+		out:    os.Stdout,
 	}
 	for _, o := range opts {
 		o.apply(c)
@@ -30,8 +65,15 @@ func newConfig(opts []Option) *config {
 	return c
 }
 
+func (c *config) encoder() zapcore.Encoder {
+	if c.format == JSONFormat {
+		return subsystemEncoder{Encoder: zapcore.NewJSONEncoder(jsonEncoderConfig())}
+	}
+	return zapcore.NewConsoleEncoder(c.ec)
+}
+
 func (c *config) logger(level zapcore.Level) *zap.Logger {
-	core := zapcore.NewCore(zapcore.NewConsoleEncoder(c.ec), zapcore.Lock(os.Stdout), level)
+	core := zapcore.NewCore(c.encoder(), zapcore.Lock(c.out), level)
 	logger := zap.New(zapfilter.NewFilteringCore(core, c.filter))
 	zap.ReplaceGlobals(logger.WithOptions(c.opts...))
 
@@ -72,6 +114,13 @@ func FSMFilter(flag bool) Option {
 	})
 }
 
+// OutputFormat sets the format of log output, the text format is used by default.
+func OutputFormat(f Format) Option {
+	return optionFunc(func(c *config) {
+		c.format = f
+	})
+}
+
 func DevelopmentFlag(flag bool) Option {
 	return optionFunc(func(c *config) {
 		if flag {
@@ -90,3 +139,36 @@ func SetupLogger(level zapcore.Level, opts ...Option) *zap.Logger {
 	zap.ReplaceGlobals(logger)
 	return logger
 }
+
+func jsonEncoderConfig() zapcore.EncoderConfig {
+	return zapcore.EncoderConfig{
+		TimeKey:        "time",
+		LevelKey:       "level",
+		NameKey:        "subsystem",
+		CallerKey:      "caller",
+		FunctionKey:    zapcore.OmitKey,
+		MessageKey:     "msg",
+		StacktraceKey:  "stacktrace",
+		LineEnding:     zapcore.DefaultLineEnding,
+		EncodeLevel:    zapcore.LowercaseLevelEncoder,
+		EncodeTime:     zapcore.RFC3339NanoTimeEncoder,
+		EncodeDuration: zapcore.StringDurationEncoder,
+		EncodeCaller:   zapcore.ShortCallerEncoder,
+	}
+}
+
+// subsystemEncoder sets the default subsystem of events, so every JSON object has the subsystem key.
+type subsystemEncoder struct {
+	zapcore.Encoder
+}
+
+func (e subsystemEncoder) Clone() zapcore.Encoder {
+	return subsystemEncoder{Encoder: e.Encoder.Clone()}
+}
+
+func (e subsystemEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	if ent.LoggerName == "" {
+		ent.LoggerName = defaultSubsystem
+	}
+	return e.Encoder.EncodeEntry(ent, fields)
+}
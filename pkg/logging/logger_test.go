@@ -0,0 +1,66 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestFormatUnmarshalText(t *testing.T) {
+	for _, test := range []struct {
+		text   string
+		format Format
+		ok     bool
+	}{
+		{"text", TextFormat, true},
+		{"json", JSONFormat, true},
+		{"JSON", JSONFormat, true},
+		{"logfmt", "", false},
+		{"", "", false},
+	} {
+		var f Format
+		err := f.UnmarshalText([]byte(test.text))
+		if test.ok {
+			require.NoError(t, err, test.text)
+			assert.Equal(t, test.format, f, test.text)
+		} else {
+			assert.Error(t, err, test.text)
+		}
+	}
+}
+
+func TestJSONFormat(t *testing.T) {
+	var out bytes.Buffer
+	c := newConfig([]Option{OutputFormat(JSONFormat), FSMFilter(false)})
+	c.out = zapcore.AddSync(&out)
+	logger := c.logger(zapcore.DebugLevel)
+
+	logger.Sugar().Infow("Block applied", "height", 42, "id", "abc")
+	logger.Named(NetworkNamespace).Warn("Peer suspended", zap.String("peer", "1.2.3.4:6868"))
+	logger.Named(FSMNamespace).Info("Filtered out")
+	require.NoError(t, logger.Sync())
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 2)
+	var events []map[string]any
+	for _, l := range lines {
+		var e map[string]any
+		require.NoError(t, json.Unmarshal([]byte(l), &e), l)
+		assert.NotEmpty(t, e["time"])
+		events = append(events, e)
+	}
+	assert.Equal(t, "info", events[0]["level"])
+	assert.Equal(t, defaultSubsystem, events[0]["subsystem"])
+	assert.Equal(t, "Block applied", events[0]["msg"])
+	assert.Equal(t, 42.0, events[0]["height"])
+	assert.Equal(t, "abc", events[0]["id"])
+	assert.Equal(t, "warn", events[1]["level"])
+	assert.Equal(t, NetworkNamespace, events[1]["subsystem"])
+	assert.Equal(t, "1.2.3.4:6868", events[1]["peer"])
+}
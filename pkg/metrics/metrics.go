@@ -7,6 +7,7 @@ import (
 	"path"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	influx "github.com/influxdata/influxdb1-client/v2"
@@ -23,6 +24,11 @@ const (
 	reportInterval = time.Second
 	bufferSize     = 2000
 
+	statsInterval     = 10 * time.Second // interval of aggregation of UTX churn and API latencies
+	batchSize         = 500              // number of points written before the report interval ends
+	maxBufferedPoints = 20000            // points kept while InfluxDB is unavailable
+	maxRetryInterval  = time.Minute
+
 	eventInv      = "Inv"
 	eventReceived = "Received"
 	eventApplied  = "Applied"
@@ -252,6 +258,39 @@ func FSMScore(fsm string, score *proto.Score, source string) {
 	reportFSM(t, f)
 }
 
+// ForkSwitch reports the switch of the node to another chain. The kind is either "micro_fork" for the rollback
+// of the liquid block to one of its micro-blocks, or "key_block" for the replacement of the top key-block.
+func ForkSwitch(kind string, d time.Duration) {
+	if rep == nil {
+		return
+	}
+	t := newTags().withKind(kind)
+	f := emptyFields().withDuration(d)
+	rep.send("fork", t, f)
+}
+
+// UtxTransactionAccepted reports the transaction accepted to the UTX pool from the network or API.
+// The churn of the pool is reported only after the pool is set with WatchUtx.
+func UtxTransactionAccepted() {
+	if rep == nil {
+		return
+	}
+	rep.utx.accept()
+}
+
+// WatchUtx sets the UTX pool to report its size and churn.
+func WatchUtx(pool UtxPool) {
+	utxPool.Store(&pool)
+}
+
+// APIRequest reports the duration of the API request. Percentiles of durations are reported periodically.
+func APIRequest(d time.Duration) {
+	if rep == nil {
+		return
+	}
+	rep.api.observe(d)
+}
+
 type tags map[string]string
 
 func emptyTags() tags {
@@ -350,6 +389,11 @@ func (t tags) withExtension() tags {
 	return t
 }
 
+func (t tags) withKind(kind string) tags {
+	t["kind"] = kind
+	return t
+}
+
 type fields map[string]interface{}
 
 func emptyFields() fields {
@@ -428,20 +472,58 @@ func (f fields) withHeight(height proto.Height) fields {
 	return f
 }
 
+func (f fields) withDuration(d time.Duration) fields {
+	f["duration_ms"] = float64(d) / float64(time.Millisecond)
+	return f
+}
+
+func (f fields) withAccepted(n int) fields {
+	f["accepted"] = n
+	return f
+}
+
+func (f fields) withRemoved(n int) fields {
+	f["removed"] = n
+	return f
+}
+
+func (f fields) withSize(n int) fields {
+	f["size"] = n
+	return f
+}
+
+func (f fields) withLatencies(l latencyPercentiles) fields {
+	f["count"] = l.count
+	f["p50_ms"] = float64(l.p50) / float64(time.Millisecond)
+	f["p90_ms"] = float64(l.p90) / float64(time.Millisecond)
+	f["p99_ms"] = float64(l.p99) / float64(time.Millisecond)
+	f["max_ms"] = float64(l.max) / float64(time.Millisecond)
+	return f
+}
+
 type reporter struct {
 	c         influx.Client
 	id        int
+	tags      tags // common tags of all points
 	batchConf influx.BatchPointsConfig
 	interval  time.Duration
 	points    []*influx.Point
 	in        chan *influx.Point
+	dropped   atomic.Uint64 // number of points dropped since the last successful report
+	failures  int           // number of consecutive failed reports
+	retryAt   time.Time     // reports are postponed until this time after a failure
+	utx       utxChurn
+	api       latencies
 }
 
-func Start(ctx context.Context, id int, url string) error {
+// Start starts reporting of metrics to InfluxDB or Telegraf at the given URL. Points are tagged with the node's ID,
+// the name of the network and the version of the node.
+func Start(ctx context.Context, id int, url, network, version string) error {
 	cfg, db, err := parseURL(url)
 	if err != nil {
 		return err
 	}
+	cfg.Timeout = defaultTimeout
 	c, err := influx.NewHTTPClient(cfg)
 	if err != nil {
 		return err
@@ -455,21 +537,28 @@ func Start(ctx context.Context, id int, url string) error {
 		return errors.Errorf("invalid metrics ID %d", id)
 	}
 	once.Do(func() {
-		rep = &reporter{
-			c:         c,
-			id:        id,
-			batchConf: influx.BatchPointsConfig{Database: db},
-			interval:  reportInterval,
-			in:        make(chan *influx.Point, bufferSize),
-		}
+		rep = newReporter(c, id, db, network, version)
 		go rep.run(ctx)
 	})
 	return nil
 }
 
+func newReporter(c influx.Client, id int, db, network, version string) *reporter {
+	return &reporter{
+		c:         c,
+		id:        id,
+		tags:      tags{"node": strconv.Itoa(id), "network": network, "version": version},
+		batchConf: influx.BatchPointsConfig{Database: db},
+		interval:  reportInterval,
+		in:        make(chan *influx.Point, bufferSize),
+	}
+}
+
 func (r *reporter) run(ctx context.Context) {
 	ticker := time.NewTicker(r.interval)
 	defer ticker.Stop()
+	statsTicker := time.NewTicker(statsInterval)
+	defer statsTicker.Stop()
 	for {
 		select {
 		case <-ctx.Done():
@@ -480,17 +569,65 @@ func (r *reporter) run(ctx context.Context) {
 			}
 			return
 		case <-ticker.C:
-			err := r.report()
-			if err != nil {
-				zap.S().Warnf("Failed to report metrics: %v", err)
-			}
-			r.points = r.points[:0]
+			r.flush(time.Now())
+		case <-statsTicker.C:
+			r.collectStats()
 		case p := <-r.in:
-			r.points = append(r.points, p)
+			r.add(p)
+			if len(r.points) >= batchSize {
+				r.flush(time.Now())
+			}
 		}
 	}
 }
 
+// send queues the point for reporting without blocking, the point is dropped if the queue is full.
+func (r *reporter) send(measurement string, t tags, f fields) {
+	p := r.newPoint(measurement, t, f)
+	if p == nil {
+		return
+	}
+	select {
+	case r.in <- p:
+	default:
+		r.dropped.Add(1)
+	}
+}
+
+// add buffers the point, the oldest points are dropped if the buffer is full because InfluxDB is unavailable.
+func (r *reporter) add(p *influx.Point) {
+	if len(r.points) >= maxBufferedPoints {
+		n := len(r.points) - maxBufferedPoints + 1
+		r.points = append(r.points[:0], r.points[n:]...)
+		r.dropped.Add(uint64(n))
+	}
+	r.points = append(r.points, p)
+}
+
+// flush writes the buffered points. After a failure the points are kept and the next attempt is postponed,
+// the delay between attempts grows exponentially up to maxRetryInterval.
+func (r *reporter) flush(now time.Time) {
+	if len(r.points) == 0 || now.Before(r.retryAt) {
+		return
+	}
+	if err := r.report(); err != nil {
+		r.failures++
+		delay := min(r.interval<<min(r.failures, 16), maxRetryInterval)
+		r.retryAt = now.Add(delay)
+		zap.S().Warnf("Failed to report %d metrics points, next attempt in %s: %v", len(r.points), delay, err)
+		return
+	}
+	if r.failures > 0 {
+		zap.S().Infof("Metrics reporting restored after %d failed attempts", r.failures)
+	}
+	r.failures = 0
+	r.retryAt = time.Time{}
+	r.points = r.points[:0]
+	if d := r.dropped.Swap(0); d > 0 {
+		zap.S().Warnf("%d metrics points were dropped", d)
+	}
+}
+
 func (r *reporter) report() error {
 	batch, err := influx.NewBatchPoints(r.batchConf)
 	if err != nil {
@@ -500,6 +637,38 @@ func (r *reporter) report() error {
 	return r.c.Write(batch)
 }
 
+// collectStats reports the aggregated series collected since the previous call.
+func (r *reporter) collectStats() {
+	var points []*influx.Point
+	if accepted, removed, size, ok := r.utx.collect(); ok {
+		f := emptyFields().withAccepted(accepted).withRemoved(removed).withSize(size)
+		points = append(points, r.newPoint("utx", emptyTags(), f))
+	}
+	if l := r.api.collect(); l.count > 0 {
+		points = append(points, r.newPoint("api", emptyTags(), emptyFields().withLatencies(l)))
+	}
+	for _, p := range points {
+		if p != nil {
+			r.add(p)
+		}
+	}
+}
+
+// newPoint creates the point with the common tags of the reporter, nil is returned if the point is invalid.
+func (r *reporter) newPoint(measurement string, t tags, f fields) *influx.Point {
+	for k, v := range r.tags {
+		if _, ok := t[k]; !ok {
+			t[k] = v
+		}
+	}
+	p, err := influx.NewPoint(measurement, t, f, time.Now())
+	if err != nil {
+		zap.S().Warnf("Failed to create metrics point '%s': %v", measurement, err)
+		return nil
+	}
+	return p
+}
+
 func parseURL(s string) (influx.HTTPConfig, string, error) {
 	uri, err := url.Parse(s)
 	if err != nil {
@@ -536,21 +705,11 @@ func parseURL(s string) (influx.HTTPConfig, string, error) {
 }
 
 func reportBlock(t tags, f fields) {
-	p, err := influx.NewPoint("block", t, f, time.Now())
-	if err != nil {
-		zap.S().Warnf("Failed to create metrics point 'block': %v", err)
-		return
-	}
-	rep.in <- p
+	rep.send("block", t, f)
 }
 
 func reportFSM(t tags, f fields) {
-	p, err := influx.NewPoint("fsm", t, f, time.Now())
-	if err != nil {
-		zap.S().Warnf("Failed to create metrics point 'fsm': %v", err)
-		return
-	}
-	rep.in <- p
+	rep.send("fsm", t, f)
 }
 
 func shortID(id proto.BlockID) string {
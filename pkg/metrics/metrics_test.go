@@ -2,7 +2,10 @@ package metrics
 
 import (
 	"testing"
+	"time"
 
+	influx "github.com/influxdata/influxdb1-client/v2"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -31,3 +34,127 @@ func TestParseURL(t *testing.T) {
 		}
 	}
 }
+
+type testClient struct {
+	influx.Client
+	fail   bool
+	writes [][]*influx.Point
+}
+
+func (c *testClient) Write(bp influx.BatchPoints) error {
+	if c.fail {
+		return errors.New("unavailable")
+	}
+	c.writes = append(c.writes, bp.Points())
+	return nil
+}
+
+func TestReporterTags(t *testing.T) {
+	r := newReporter(&testClient{}, 7, "db", "testnet", "v1.2.3")
+	r.send("fork", tags{"kind": "key_block"}, fields{"duration_ms": 1.0})
+	p := <-r.in
+	assert.Equal(t, map[string]string{"node": "7", "network": "testnet", "version": "v1.2.3", "kind": "key_block"},
+		p.Tags())
+}
+
+func TestReporterBackpressure(t *testing.T) {
+	c := &testClient{fail: true}
+	r := newReporter(c, 1, "db", "testnet", "v1.2.3")
+	for range bufferSize + 10 {
+		r.send("fsm", tags{}, fields{"f": 1})
+	}
+	assert.Len(t, r.in, bufferSize)
+	assert.EqualValues(t, 10, r.dropped.Load(), "points are dropped without blocking")
+
+	for len(r.in) > 0 {
+		r.add(<-r.in)
+	}
+	now := time.Now()
+	r.flush(now)
+	assert.Len(t, r.points, bufferSize, "points are kept after failure")
+	assert.Equal(t, 1, r.failures)
+	c.fail = false
+	r.flush(now.Add(r.interval / 2))
+	assert.Empty(t, c.writes, "next attempt is postponed")
+	r.flush(now.Add(2 * r.interval))
+	require.Len(t, c.writes, 1)
+	assert.Len(t, c.writes[0], bufferSize)
+	assert.Empty(t, r.points)
+	assert.Zero(t, r.failures)
+	assert.Zero(t, r.dropped.Load())
+}
+
+func TestReporterBufferLimit(t *testing.T) {
+	r := newReporter(&testClient{}, 1, "db", "testnet", "v1.2.3")
+	for i := range maxBufferedPoints + 5 {
+		r.add(r.newPoint("fsm", tags{}, fields{"i": i}))
+	}
+	require.Len(t, r.points, maxBufferedPoints)
+	assert.EqualValues(t, 5, r.dropped.Load())
+	f, err := r.points[0].Fields()
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, f["i"], "the oldest points are dropped")
+}
+
+type testPool int
+
+func (p *testPool) Count() int { return int(*p) }
+
+func TestUtxChurn(t *testing.T) {
+	defer utxPool.Store(nil)
+	var c utxChurn
+	_, _, _, ok := c.collect()
+	assert.False(t, ok)
+
+	pool := testPool(0)
+	WatchUtx(&pool)
+	for _, test := range []struct {
+		accepted int
+		size     int
+		removed  int
+	}{
+		{0, 0, 0},
+		{10, 10, 0},
+		{5, 12, 3},
+		{0, 2, 10}, // transactions are taken by the miner
+		{0, 7, 0},  // 5 transactions are returned to the pool
+		{1, 4, 0},  // the deficit is not covered yet
+		{0, 0, 3},
+		{3, 3, 0},
+	} {
+		for range test.accepted {
+			c.accept()
+		}
+		pool = testPool(test.size)
+		accepted, removed, size, ok := c.collect()
+		require.True(t, ok)
+		assert.Equal(t, test.accepted, accepted)
+		assert.Equal(t, test.removed, removed)
+		assert.Equal(t, test.size, size)
+	}
+}
+
+func TestLatencies(t *testing.T) {
+	var l latencies
+	assert.Zero(t, l.collect().count)
+	for i := 100; i >= 1; i-- {
+		l.observe(time.Duration(i) * time.Millisecond)
+	}
+	p := l.collect()
+	assert.Equal(t, latencyPercentiles{
+		count: 100,
+		p50:   50 * time.Millisecond,
+		p90:   90 * time.Millisecond,
+		p99:   99 * time.Millisecond,
+		max:   100 * time.Millisecond,
+	}, p)
+	assert.Zero(t, l.collect().count, "durations are reset")
+
+	for i := range 2 * maxLatencySamples {
+		l.observe(time.Duration(i))
+	}
+	assert.Len(t, l.samples, maxLatencySamples)
+	p = l.collect()
+	assert.Equal(t, 2*maxLatencySamples, p.count)
+	assert.Equal(t, time.Duration(2*maxLatencySamples-1), p.max)
+}
@@ -0,0 +1,113 @@
+package metrics
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// maxLatencySamples is the size of the reservoir of API request durations collected during the stats interval.
+const maxLatencySamples = 10000
+
+// UtxPool is the UTX pool which size and churn are reported.
+type UtxPool interface {
+	Count() int
+}
+
+var utxPool atomic.Pointer[UtxPool]
+
+// utxChurn counts transactions entering and leaving the UTX pool. Only accepted transactions are counted
+// explicitly, the number of removed transactions is derived from the change of the pool size.
+type utxChurn struct {
+	accepted    atomic.Uint64
+	initialized bool
+	size        int
+	deficit     int
+}
+
+func (c *utxChurn) accept() {
+	c.accepted.Add(1)
+}
+
+// collect returns the number of transactions accepted and removed since the previous call and the current size of
+// the pool. The miner and the cleaner take transactions out of the pool for a while and return them back, such
+// transactions are not counted as removed until the pool size is back to the expected one.
+func (c *utxChurn) collect() (accepted, removed, size int, ok bool) {
+	p := utxPool.Load()
+	if p == nil {
+		return 0, 0, 0, false
+	}
+	size = (*p).Count()
+	accepted = int(c.accepted.Swap(0))
+	if !c.initialized {
+		c.initialized = true
+		c.size = size
+	}
+	removed = c.size + accepted - size + c.deficit
+	c.size = size
+	c.deficit = 0
+	if removed < 0 {
+		c.deficit = removed
+		removed = 0
+	}
+	return accepted, removed, size, true
+}
+
+type latencyPercentiles struct {
+	count int
+	p50   time.Duration
+	p90   time.Duration
+	p99   time.Duration
+	max   time.Duration
+}
+
+// latencies collects durations of API requests. If there are more requests than maxLatencySamples in the interval,
+// a uniformly random subset of durations is kept to calculate the percentiles.
+type latencies struct {
+	mu      sync.Mutex
+	count   int
+	max     time.Duration
+	samples []time.Duration
+}
+
+func (l *latencies) observe(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.count++
+	l.max = max(l.max, d)
+	if len(l.samples) < maxLatencySamples {
+		l.samples = append(l.samples, d)
+		return
+	}
+	if i := rand.IntN(l.count); i < maxLatencySamples {
+		l.samples[i] = d
+	}
+}
+
+// collect returns the percentiles of durations observed since the previous call.
+func (l *latencies) collect() latencyPercentiles {
+	l.mu.Lock()
+	samples, count, maxDuration := l.samples, l.count, l.max
+	l.samples, l.count, l.max = nil, 0, 0
+	l.mu.Unlock()
+	if count == 0 {
+		return latencyPercentiles{}
+	}
+	slices.Sort(samples)
+	return latencyPercentiles{
+		count: count,
+		p50:   percentile(samples, 0.5),
+		p90:   percentile(samples, 0.9),
+		p99:   percentile(samples, 0.99),
+		max:   maxDuration,
+	}
+}
+
+// percentile returns the nearest-rank percentile of the sorted durations.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	i := int(math.Ceil(p*float64(len(sorted)))) - 1
+	return sorted[max(i, 0)]
+}
@@ -0,0 +1,138 @@
+package miner
+
+import (
+	"slices"
+
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/services"
+	"github.com/wavesplatform/gowaves/pkg/state"
+	"github.com/wavesplatform/gowaves/pkg/state/stateerr"
+	"github.com/wavesplatform/gowaves/pkg/types"
+)
+
+// DryRunTransaction is a transaction that would be packed into the block.
+type DryRunTransaction struct {
+	ID       crypto.Digest       `json:"id"`
+	Size     int                 `json:"size"`
+	Fee      uint64              `json:"fee"`
+	FeeAsset proto.OptionalAsset `json:"feeAssetId"`
+}
+
+// DryRunRejection is a transaction that would not be packed into the block.
+type DryRunRejection struct {
+	ID     crypto.Digest `json:"id"`
+	Reason string        `json:"reason"`
+}
+
+// DryRunResult describes the block that would be generated on top of the current state.
+type DryRunResult struct {
+	Height         proto.Height        `json:"height"`
+	BlockVersion   proto.BlockVersion  `json:"blockVersion"`
+	Size           int                 `json:"size"`
+	MaxSize        int                 `json:"maxSize"`
+	TotalWavesFee  uint64              `json:"totalWavesFee"`
+	TotalAssetFees map[string]uint64   `json:"totalAssetFees"` // Fees by Base58 encoded asset IDs.
+	Transactions   []DryRunTransaction `json:"transactions"`
+	Rejected       []DryRunRejection   `json:"rejected"`
+}
+
+// DryRun simulates packing of transactions from UTX pool into the next block under the given constraints and
+// optional filter. UTX pool and state are not modified. Transactions are taken in the same order as the miner does.
+func DryRun(
+	st state.State, utx types.UtxPool, scheme proto.Scheme, constraints Constraints,
+	filter services.TransactionFilter, timestamp uint64,
+) (*DryRunResult, error) {
+	txs := utx.AllTransactions()
+	slices.SortStableFunc(txs, func(a, b *types.TransactionWithBytes) int {
+		// Same priority as in UTX pool: higher fee per byte first.
+		fa, fb := a.T.GetFee()/uint64(len(a.B)), b.T.GetFee()/uint64(len(b.B))
+		switch {
+		case fa > fb:
+			return -1
+		case fa < fb:
+			return 1
+		default:
+			return 0
+		}
+	})
+	height, err := st.Height()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get height")
+	}
+	version, err := blockVersion(st)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get block version")
+	}
+	maxCount := proto.MaxTransactionsPerBlock
+	if version < proto.NgBlockVersion {
+		maxCount = constraints.ClassicAmountOfTxsInBlock
+	}
+	parentTimestamp := st.TopBlock().Timestamp
+	res := &DryRunResult{
+		Height:         height + 1,
+		BlockVersion:   version,
+		MaxSize:        constraints.MaxTxsSizeInBytes,
+		TotalAssetFees: make(map[string]uint64),
+		Transactions:   make([]DryRunTransaction, 0, len(txs)),
+	}
+	// Aliases are resolved by the filter before the validation, which locks the state.
+	filtered := make(map[*types.TransactionWithBytes]error)
+	if filter != nil {
+		for _, tx := range txs {
+			if fErr := filter.Check(tx.T, st); fErr != nil {
+				filtered[tx] = fErr
+			}
+		}
+	}
+	err = st.TxValidation(func(validation state.TxValidation) error {
+		const transactionLenBytes = 4
+		for _, tx := range txs {
+			id, idErr := tx.T.GetID(scheme)
+			if idErr != nil {
+				return errors.Wrap(idErr, "failed to get transaction ID")
+			}
+			txID, idErr := crypto.NewDigestFromBytes(id)
+			if idErr != nil {
+				return errors.Wrap(idErr, "invalid transaction ID")
+			}
+			if fErr, ok := filtered[tx]; ok {
+				res.Rejected = append(res.Rejected, DryRunRejection{ID: txID, Reason: fErr.Error()})
+				continue
+			}
+			if len(res.Transactions) >= maxCount {
+				res.Rejected = append(res.Rejected, DryRunRejection{ID: txID, Reason: "transactions count limit"})
+				continue
+			}
+			size := len(tx.B) + transactionLenBytes
+			if res.Size+size > constraints.MaxTxsSizeInBytes {
+				res.Rejected = append(res.Rejected, DryRunRejection{ID: txID, Reason: "block size limit"})
+				continue
+			}
+			if _, vErr := validation.ValidateNextTx(tx.T, timestamp, parentTimestamp, version, true); vErr != nil {
+				if stateerr.IsTxCommitmentError(vErr) {
+					return errors.Wrap(vErr, "failed to validate transactions")
+				}
+				res.Rejected = append(res.Rejected, DryRunRejection{ID: txID, Reason: vErr.Error()})
+				continue
+			}
+			fee, feeAsset := tx.T.GetFee(), tx.T.GetFeeAsset()
+			if feeAsset.Present {
+				res.TotalAssetFees[feeAsset.ID.String()] += fee
+			} else {
+				res.TotalWavesFee += fee
+			}
+			res.Size += size
+			res.Transactions = append(res.Transactions, DryRunTransaction{
+				ID: txID, Size: len(tx.B), Fee: fee, FeeAsset: feeAsset,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -25,6 +25,7 @@ type MicroMiner struct {
 	state  state.State
 	utx    types.UtxPool
 	scheme proto.Scheme
+	filter services.TransactionFilter
 }
 
 func NewMicroMiner(services services.Services) *MicroMiner {
@@ -32,6 +33,7 @@ func NewMicroMiner(services services.Services) *MicroMiner {
 		state:  services.State,
 		utx:    services.UtxPool,
 		scheme: services.Scheme,
+		filter: services.MinerFilter,
 	}
 }
 
@@ -85,6 +87,13 @@ func (a *MicroMiner) Micro(minedBlock *proto.Block, rest proto.MiningLimits, key
 				inapplicable = append(inapplicable, t)
 				continue
 			}
+			if a.filter != nil {
+				if fErr := a.filter.Check(t.T, s); fErr != nil {
+					zap.S().Debugf("[MICRO MINER] Transaction is not packed: %v", fErr)
+					inapplicable = append(inapplicable, t)
+					continue
+				}
+			}
 
 			// In the miner we pack transactions from UTX into new block.
 			// We should accept failed transactions here.
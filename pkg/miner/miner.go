@@ -22,18 +22,18 @@ type MicroblockMiner struct {
 	peer        peers.PeerManager
 	constraints Constraints
 	services    services.Services
-	features    Features
+	votes       *FeatureVotes
 	reward      int64
 }
 
-func NewMicroblockMiner(services services.Services, features Features, reward int64) *MicroblockMiner {
+func NewMicroblockMiner(services services.Services, votes *FeatureVotes, reward int64) *MicroblockMiner {
 	return &MicroblockMiner{
 		utx:         services.UtxPool,
 		state:       services.State,
 		peer:        services.Peers,
 		constraints: DefaultConstraints(),
 		services:    services,
-		features:    features,
+		votes:       votes,
 		reward:      reward,
 	}
 }
@@ -51,7 +51,7 @@ func (a *MicroblockMiner) MineKeyBlock(
 		if err != nil {
 			return nil, err
 		}
-		validatedFeatured, err := ValidateFeatures(info, a.features)
+		validatedFeatured, err := ValidateFeatures(info, a.votes.Support())
 		if err != nil {
 			return nil, err
 		}
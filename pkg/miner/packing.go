@@ -0,0 +1,163 @@
+package miner
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/services"
+	"github.com/wavesplatform/gowaves/pkg/state"
+)
+
+// PackingRules are operator defined rules of selection of transactions from UTX pool into the blocks generated by
+// the node. Unlike UTX admission rules they don't affect transactions relaying and acceptance of blocks of other
+// generators.
+type PackingRules struct {
+	// ExcludedSenders are addresses, transactions from which are not packed.
+	ExcludedSenders []proto.WavesAddress `json:"excludedSenders"`
+	// ExcludedDApps are dApps, invocations of which are not packed.
+	ExcludedDApps []proto.WavesAddress `json:"excludedDApps"`
+	// MinFeeMultiplier requires the fee in Waves to be not less than the base fee of the transaction type multiplied
+	// by the value. Zero disables the check.
+	MinFeeMultiplier float64 `json:"minFeeMultiplier"`
+	// MinFeeMultipliers override MinFeeMultiplier for the transaction types, keys are numeric transaction types.
+	MinFeeMultipliers map[proto.TransactionType]float64 `json:"minFeeMultipliers"`
+}
+
+// ReadPackingRules reads packing rules in JSON format.
+func ReadPackingRules(r io.Reader) (*PackingRules, error) {
+	rules := new(PackingRules)
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(rules); err != nil {
+		return nil, errors.Wrap(err, "failed to decode packing rules")
+	}
+	if !validFeeMultiplier(rules.MinFeeMultiplier) {
+		return nil, errors.Errorf("invalid min fee multiplier %v", rules.MinFeeMultiplier)
+	}
+	for txType, m := range rules.MinFeeMultipliers {
+		if _, ok := state.BaseFee(txType); !ok {
+			return nil, errors.Errorf("unsupported transaction type %d", txType)
+		}
+		if !validFeeMultiplier(m) {
+			return nil, errors.Errorf("invalid min fee multiplier %v of transaction type %d", m, txType)
+		}
+	}
+	return rules, nil
+}
+
+func validFeeMultiplier(m float64) bool {
+	return m >= 0 && !math.IsNaN(m) && !math.IsInf(m, 0)
+}
+
+// PackingFilter checks transactions against the packing rules, it implements services.TransactionFilter.
+type PackingFilter struct {
+	scheme         proto.Scheme
+	senders        map[proto.WavesAddress]struct{}
+	dApps          map[proto.WavesAddress]struct{}
+	feeMultiplier  float64
+	feeMultipliers map[proto.TransactionType]float64
+}
+
+func NewPackingFilter(rules *PackingRules, scheme proto.Scheme) *PackingFilter {
+	f := &PackingFilter{
+		scheme:         scheme,
+		senders:        make(map[proto.WavesAddress]struct{}, len(rules.ExcludedSenders)),
+		dApps:          make(map[proto.WavesAddress]struct{}, len(rules.ExcludedDApps)),
+		feeMultiplier:  rules.MinFeeMultiplier,
+		feeMultipliers: rules.MinFeeMultipliers,
+	}
+	for _, addr := range rules.ExcludedSenders {
+		f.senders[addr] = struct{}{}
+	}
+	for _, addr := range rules.ExcludedDApps {
+		f.dApps[addr] = struct{}{}
+	}
+	return f
+}
+
+func (f *PackingFilter) Check(tx proto.Transaction, aliases services.AliasResolver) error {
+	if err := f.checkFee(tx); err != nil {
+		return err
+	}
+	if len(f.senders) > 0 {
+		sender, err := tx.GetSender(f.scheme)
+		if err != nil {
+			return errors.Wrap(err, "failed to get sender")
+		}
+		addr, err := sender.ToWavesAddress(f.scheme)
+		if err != nil {
+			return errors.Wrap(err, "failed to get sender address")
+		}
+		if _, ok := f.senders[addr]; ok {
+			return errors.Errorf("sender %s is excluded", addr.String())
+		}
+	}
+	if len(f.dApps) > 0 {
+		dApp, ok, err := f.invokedDApp(tx, aliases)
+		if err != nil {
+			return err
+		}
+		if _, excluded := f.dApps[dApp]; ok && excluded {
+			return errors.Errorf("invocation of dApp %s is excluded", dApp.String())
+		}
+	}
+	return nil
+}
+
+// checkFee checks the fee of transactions paid in Waves, fees in sponsored assets are not checked.
+func (f *PackingFilter) checkFee(tx proto.Transaction) error {
+	if tx.GetFeeAsset().Present {
+		return nil
+	}
+	m, ok := f.feeMultipliers[tx.GetType()]
+	if !ok {
+		m = f.feeMultiplier
+	}
+	if m == 0 {
+		return nil
+	}
+	base, ok := state.BaseFee(tx.GetType())
+	if !ok {
+		return nil
+	}
+	minFee := uint64(math.Ceil(float64(base) * m))
+	if fee := tx.GetFee(); fee < minFee {
+		return errors.Errorf("fee %d is less than required %d", fee, minFee)
+	}
+	return nil
+}
+
+func (f *PackingFilter) invokedDApp(
+	tx proto.Transaction, aliases services.AliasResolver,
+) (proto.WavesAddress, bool, error) {
+	switch t := tx.(type) {
+	case *proto.InvokeScriptWithProofs:
+		if addr := t.ScriptRecipient.Address(); addr != nil {
+			return *addr, true, nil
+		}
+		alias := t.ScriptRecipient.Alias()
+		if alias == nil {
+			return proto.WavesAddress{}, false, errors.New("empty dApp recipient")
+		}
+		addr, err := aliases.AddrByAlias(*alias)
+		if err != nil {
+			return proto.WavesAddress{}, false, errors.Wrapf(err, "failed to resolve alias %s", alias.String())
+		}
+		return addr, true, nil
+	case *proto.EthereumTransaction:
+		if _, ok := t.TxKind.(*proto.EthereumInvokeScriptTxKind); !ok || t.To() == nil {
+			return proto.WavesAddress{}, false, nil
+		}
+		addr, err := t.To().ToWavesAddress(f.scheme)
+		if err != nil {
+			return proto.WavesAddress{}, false, errors.Wrap(err, "failed to convert ethereum address")
+		}
+		return addr, true, nil
+	default:
+		return proto.WavesAddress{}, false, nil
+	}
+}
@@ -0,0 +1,120 @@
+package miner
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+type testAliases map[string]proto.WavesAddress
+
+func (a testAliases) AddrByAlias(alias proto.Alias) (proto.WavesAddress, error) {
+	addr, ok := a[alias.Alias]
+	if !ok {
+		return proto.WavesAddress{}, assert.AnError
+	}
+	return addr, nil
+}
+
+func testAddress(t *testing.T, seed string) (crypto.PublicKey, proto.WavesAddress) {
+	_, pk, err := crypto.GenerateKeyPair([]byte(seed))
+	require.NoError(t, err)
+	addr, err := proto.NewAddressFromPublicKey(proto.TestNetScheme, pk)
+	require.NoError(t, err)
+	return pk, addr
+}
+
+func TestReadPackingRules(t *testing.T) {
+	_, addr := testAddress(t, "excluded")
+	rules, err := ReadPackingRules(strings.NewReader(`{"excludedDApps": ["` + addr.String() + `"]}`))
+	require.NoError(t, err)
+	assert.Empty(t, rules.ExcludedSenders)
+	assert.Equal(t, []proto.WavesAddress{addr}, rules.ExcludedDApps)
+
+	rules, err = ReadPackingRules(strings.NewReader(`{"minFeeMultiplier": 1.5, "minFeeMultipliers": {"16": 3}}`))
+	require.NoError(t, err)
+	assert.Equal(t, 1.5, rules.MinFeeMultiplier)
+	assert.Equal(t, map[proto.TransactionType]float64{proto.InvokeScriptTransaction: 3}, rules.MinFeeMultipliers)
+
+	for _, rules := range []string{
+		`{"unknown": 1}`,
+		`{"minFeeMultiplier": -1}`,
+		`{"minFeeMultipliers": {"16": -1}}`,
+		`{"minFeeMultipliers": {"255": 1}}`,
+	} {
+		_, err = ReadPackingRules(strings.NewReader(rules))
+		assert.Error(t, err, rules)
+	}
+}
+
+func TestPackingFilterFees(t *testing.T) {
+	pk, addr := testAddress(t, "sender")
+	f := NewPackingFilter(&PackingRules{
+		MinFeeMultiplier:  2,
+		MinFeeMultipliers: map[proto.TransactionType]float64{proto.InvokeScriptTransaction: 1.5},
+	}, proto.TestNetScheme)
+	transfer := func(feeAsset proto.OptionalAsset, fee uint64) proto.Transaction {
+		return proto.NewUnsignedTransferWithProofs(2, pk, proto.NewOptionalAssetWaves(), feeAsset,
+			0, 1, fee, proto.NewRecipientFromAddress(addr), nil)
+	}
+	invoke := func(fee uint64) proto.Transaction {
+		return proto.NewUnsignedInvokeScriptWithProofs(1, pk, proto.NewRecipientFromAddress(addr),
+			proto.NewFunctionCall("call", nil), nil, proto.NewOptionalAssetWaves(), fee, 0)
+	}
+	waves := proto.NewOptionalAssetWaves()
+	asset := *proto.NewOptionalAssetFromDigest(crypto.MustDigestFromBase58("8LQW8f7P5d5PZM7GtZEBgaqRPGSzS3DfPuiXrURJ4AJS"))
+	assert.NoError(t, f.Check(transfer(waves, 200_000), nil))
+	assert.ErrorContains(t, f.Check(transfer(waves, 199_999), nil), "fee 199999 is less than required 200000")
+	assert.NoError(t, f.Check(transfer(asset, 1), nil))
+	assert.NoError(t, f.Check(invoke(750_000), nil))
+	assert.ErrorContains(t, f.Check(invoke(700_000), nil), "less than required 750000")
+}
+
+func TestPackingFilter(t *testing.T) {
+	senderPK, _ := testAddress(t, "sender")
+	excludedPK, excluded := testAddress(t, "excluded")
+	_, dApp := testAddress(t, "dApp")
+	_, other := testAddress(t, "other")
+	aliases := testAliases{"dapp": dApp}
+	f := NewPackingFilter(&PackingRules{
+		ExcludedSenders: []proto.WavesAddress{excluded},
+		ExcludedDApps:   []proto.WavesAddress{dApp},
+	}, proto.TestNetScheme)
+
+	transfer := func(pk crypto.PublicKey, r proto.Recipient) proto.Transaction {
+		return proto.NewUnsignedTransferWithProofs(2, pk, proto.NewOptionalAssetWaves(), proto.NewOptionalAssetWaves(),
+			0, 1, 100_000, r, nil)
+	}
+	invoke := func(r proto.Recipient) proto.Transaction {
+		return proto.NewUnsignedInvokeScriptWithProofs(1, senderPK, r, proto.NewFunctionCall("call", nil), nil,
+			proto.NewOptionalAssetWaves(), 500_000, 0)
+	}
+	alias := func(name string) proto.Recipient {
+		return proto.NewRecipientFromAlias(*proto.NewAlias(proto.TestNetScheme, name))
+	}
+	for i, test := range []struct {
+		tx  proto.Transaction
+		err string
+	}{
+		{transfer(senderPK, proto.NewRecipientFromAddress(other)), ""},
+		{transfer(senderPK, proto.NewRecipientFromAddress(excluded)), ""}, // only senders are excluded
+		{transfer(senderPK, proto.NewRecipientFromAddress(dApp)), ""},
+		{transfer(excludedPK, proto.NewRecipientFromAddress(other)), "sender"},
+		{invoke(proto.NewRecipientFromAddress(dApp)), "invocation of dApp"},
+		{invoke(alias("dapp")), "invocation of dApp"},
+		{invoke(alias("unknown")), "failed to resolve alias"},
+		{invoke(proto.NewRecipientFromAddress(other)), ""},
+	} {
+		err := f.Check(test.tx, aliases)
+		if test.err == "" {
+			assert.NoError(t, err, i)
+		} else {
+			assert.ErrorContains(t, err, test.err, i)
+		}
+	}
+}
@@ -1,5 +1,13 @@
 package scheduler
 
+import (
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/wavesplatform/gowaves/pkg/types"
+)
+
 type connectedCount interface {
 	ConnectedCount() int
 }
@@ -20,6 +28,26 @@ func (a MinerConsensusImpl) IsMiningAllowed() bool {
 	return a.count.ConnectedCount() >= a.atLeastConnectedPeers
 }
 
+// ClockDriftGuard forbids mining if the offset of the local clock exceeds the limit, otherwise the decision is
+// made by the next consensus.
+type ClockDriftGuard struct {
+	next     types.MinerConsensus
+	clock    types.ClockDrift
+	maxDrift time.Duration
+}
+
+func NewClockDriftGuard(next types.MinerConsensus, clock types.ClockDrift, maxDrift time.Duration) ClockDriftGuard {
+	return ClockDriftGuard{next: next, clock: clock, maxDrift: maxDrift}
+}
+
+func (a ClockDriftGuard) IsMiningAllowed() bool {
+	if offset, _ := a.clock.ClockOffset(); offset.Abs() > a.maxDrift {
+		zap.S().Warnf("Mining is not allowed because local clock offset %s exceeds %s", offset, a.maxDrift)
+		return false
+	}
+	return a.next.IsMiningAllowed()
+}
+
 type StubConsensus struct {
 }
 
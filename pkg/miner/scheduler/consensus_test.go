@@ -2,6 +2,7 @@ package scheduler
 
 import (
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -22,3 +23,16 @@ func TestMinerConsensus(t *testing.T) {
 	a = NewMinerConsensus(m, 1)
 	assert.False(t, a.IsMiningAllowed())
 }
+
+type testClock time.Duration
+
+func (c testClock) ClockOffset() (time.Duration, time.Time) {
+	return time.Duration(c), time.Now()
+}
+
+func TestClockDriftGuard(t *testing.T) {
+	assert.True(t, NewClockDriftGuard(StubConsensus{}, testClock(time.Second), 2*time.Second).IsMiningAllowed())
+	assert.True(t, NewClockDriftGuard(StubConsensus{}, testClock(-time.Second), 2*time.Second).IsMiningAllowed())
+	assert.False(t, NewClockDriftGuard(StubConsensus{}, testClock(3*time.Second), 2*time.Second).IsMiningAllowed())
+	assert.False(t, NewClockDriftGuard(StubConsensus{}, testClock(-3*time.Second), 2*time.Second).IsMiningAllowed())
+}
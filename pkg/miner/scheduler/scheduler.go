@@ -2,6 +2,7 @@ package scheduler
 
 import (
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/mr-tron/base58"
@@ -39,6 +40,7 @@ type Default struct {
 	tm           types.Time
 	consensus    types.MinerConsensus
 	obsolescence time.Duration
+	paused       atomic.Bool
 }
 
 type internal interface {
@@ -348,7 +350,43 @@ func (a *Default) Mine() chan Emit {
 	return a.mine
 }
 
+// Pause stops block generation until Resume is called. Already scheduled emits are canceled, the node keeps
+// processing blocks of other generators.
+func (a *Default) Pause() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.paused.Store(true)
+	for _, cancel := range a.cancel {
+		cancel()
+	}
+	a.cancel = nil
+	a.emits = nil
+	select {
+	case <-a.mine: // drop the emit that has not been consumed by miner yet
+	default:
+	}
+	zap.S().Info("Scheduler: Mining is paused")
+}
+
+// Resume restarts block generation paused by Pause.
+func (a *Default) Resume() {
+	if !a.paused.Swap(false) {
+		return
+	}
+	zap.S().Info("Scheduler: Mining is resumed")
+	a.Reschedule()
+}
+
+// Paused reports whether block generation is paused.
+func (a *Default) Paused() bool {
+	return a.paused.Load()
+}
+
 func (a *Default) Reschedule() {
+	if a.paused.Load() {
+		zap.S().Debug("Scheduler: Mining is paused")
+		return
+	}
 	if len(a.seeder.AccountSeeds()) == 0 {
 		zap.S().Debug("Scheduler: Mining is not possible because no seeds registered")
 		return
@@ -392,6 +430,9 @@ func (a *Default) reschedule(confirmedBlock *proto.Block, confirmedBlockHeight u
 	}
 	a.mu.Lock()
 	defer a.mu.Unlock()
+	if a.paused.Load() {
+		return
+	}
 
 	// stop previous timeouts
 	for _, cancel := range a.cancel {
@@ -423,6 +464,9 @@ func (a *Default) reschedule(confirmedBlock *proto.Block, confirmedBlockHeight u
 			cancel := cancellable.After(time.Duration(timeout)*time.Millisecond, func() {
 				// hack for integrations tests
 				common.EnsureTimeout(a.tm, emit_.Timestamp)
+				if a.paused.Load() {
+					return
+				}
 				select {
 				case a.mine <- emit_:
 				default:
@@ -2,9 +2,14 @@ package scheduler
 
 import (
 	"testing"
+	"time"
 
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/wavesplatform/gowaves/pkg/mock"
+
 	"github.com/wavesplatform/gowaves/pkg/proto"
 	"github.com/wavesplatform/gowaves/pkg/settings"
 	"github.com/wavesplatform/gowaves/pkg/state"
@@ -30,3 +35,68 @@ func TestSchedulerImpl_Emits(t *testing.T) {
 
 	require.EqualValues(t, []Emit([]Emit(nil)), rs)
 }
+
+type emitInternal struct {
+	emits []Emit
+}
+
+func (a emitInternal) schedule(
+	state.StateInfo,
+	[]proto.KeyPair,
+	*settings.BlockchainSettings,
+	*proto.Block,
+	uint64,
+) ([]Emit, error) {
+	return a.emits, nil
+}
+
+type testSeeder struct{}
+
+func (testSeeder) AccountSeeds() [][]byte {
+	return [][]byte{[]byte("seed")}
+}
+
+type testTime struct{}
+
+func (testTime) Now() time.Time {
+	return time.Now()
+}
+
+type miningAllowed struct{}
+
+func (miningAllowed) IsMiningAllowed() bool {
+	return true
+}
+
+func TestSchedulerPauseResume(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	st := mock.NewMockState(ctrl)
+	st.EXPECT().MapR(gomock.Any()).DoAndReturn(func(f func(state.StateInfo) (interface{}, error)) (interface{}, error) {
+		return f(nil)
+	}).AnyTimes()
+	top := &proto.Block{BlockHeader: proto.BlockHeader{Timestamp: proto.NewTimestampFromTime(time.Now())}}
+	st.EXPECT().TopBlock().Return(top).AnyTimes()
+	st.EXPECT().Height().Return(uint64(1), nil).AnyTimes()
+	st.EXPECT().BlockByHeight(uint64(1)).Return(top, nil).AnyTimes()
+	emit := Emit{Timestamp: 1}
+	sch := newScheduler(emitInternal{emits: []Emit{emit}}, st, testSeeder{}, nil, testTime{}, miningAllowed{},
+		time.Minute)
+
+	sch.Reschedule()
+	require.Len(t, sch.Emits(), 1)
+	<-sch.Mine()
+	sch.reschedule(top, 1)
+	sch.Pause()
+	assert.True(t, sch.Paused())
+	assert.Empty(t, sch.Emits())
+	assert.Empty(t, sch.Mine()) // pending emit is dropped
+
+	sch.Reschedule()
+	assert.Empty(t, sch.Emits())
+	assert.Empty(t, sch.Mine())
+
+	sch.Resume()
+	assert.False(t, sch.Paused())
+	assert.Len(t, sch.Emits(), 1)
+	assert.Len(t, sch.Mine(), 1)
+}
@@ -0,0 +1,208 @@
+package utxpool
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/state"
+)
+
+// AdmissionRules are operator defined rules of admission of transactions to the UTX pool.
+type AdmissionRules struct {
+	// RejectedAddresses are addresses, transactions from or to which are rejected.
+	RejectedAddresses []proto.WavesAddress `json:"rejectedAddresses"`
+	// RejectedDApps are dApps, invocations of which are rejected.
+	RejectedDApps []proto.WavesAddress `json:"rejectedDApps"`
+	// MinFeeMultiplier requires the fee in Waves to be not less than the base fee of the transaction type multiplied
+	// by the value. Zero disables the check.
+	MinFeeMultiplier float64 `json:"minFeeMultiplier"`
+}
+
+// ReadAdmissionRules reads admission rules in JSON format.
+func ReadAdmissionRules(r io.Reader) (*AdmissionRules, error) {
+	rules := new(AdmissionRules)
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(rules); err != nil {
+		return nil, errors.Wrap(err, "failed to decode admission rules")
+	}
+	if rules.MinFeeMultiplier < 0 || math.IsNaN(rules.MinFeeMultiplier) || math.IsInf(rules.MinFeeMultiplier, 0) {
+		return nil, errors.Errorf("invalid min fee multiplier %v", rules.MinFeeMultiplier)
+	}
+	return rules, nil
+}
+
+type aliasResolver interface {
+	AddrByAlias(alias proto.Alias) (proto.WavesAddress, error)
+}
+
+// AdmissionValidator checks transactions against the admission rules before passing them to the next validator.
+type AdmissionValidator struct {
+	next             Validator
+	aliases          aliasResolver
+	scheme           proto.Scheme
+	rejected         map[proto.WavesAddress]struct{}
+	rejectedDApps    map[proto.WavesAddress]struct{}
+	minFeeMultiplier float64
+}
+
+func NewAdmissionValidator(
+	next Validator,
+	rules *AdmissionRules,
+	aliases aliasResolver,
+	scheme proto.Scheme,
+) *AdmissionValidator {
+	v := &AdmissionValidator{
+		next:             next,
+		aliases:          aliases,
+		scheme:           scheme,
+		rejected:         make(map[proto.WavesAddress]struct{}, len(rules.RejectedAddresses)),
+		rejectedDApps:    make(map[proto.WavesAddress]struct{}, len(rules.RejectedDApps)),
+		minFeeMultiplier: rules.MinFeeMultiplier,
+	}
+	for _, addr := range rules.RejectedAddresses {
+		v.rejected[addr] = struct{}{}
+	}
+	for _, addr := range rules.RejectedDApps {
+		v.rejectedDApps[addr] = struct{}{}
+	}
+	return v
+}
+
+func (a *AdmissionValidator) Validate(tx proto.Transaction) error {
+	if err := a.admit(tx); err != nil {
+		return errors.Wrap(err, "transaction is rejected by admission rules")
+	}
+	return a.next.Validate(tx)
+}
+
+func (a *AdmissionValidator) admit(tx proto.Transaction) error {
+	if err := a.checkFee(tx); err != nil {
+		return err
+	}
+	sender, err := tx.GetSender(a.scheme)
+	if err != nil {
+		return errors.Wrap(err, "failed to get sender")
+	}
+	senderAddr, err := sender.ToWavesAddress(a.scheme)
+	if err != nil {
+		return errors.Wrap(err, "failed to get sender address")
+	}
+	if _, ok := a.rejected[senderAddr]; ok {
+		return errors.Errorf("sender %s is rejected", senderAddr.String())
+	}
+	recipients, dApp, err := a.recipients(tx)
+	if err != nil {
+		return err
+	}
+	for _, r := range recipients {
+		addr, rErr := a.resolve(r)
+		if rErr != nil {
+			return rErr
+		}
+		if _, ok := a.rejected[addr]; ok {
+			return errors.Errorf("recipient %s is rejected", addr.String())
+		}
+	}
+	if dApp != nil {
+		addr, rErr := a.resolve(*dApp)
+		if rErr != nil {
+			return rErr
+		}
+		if _, ok := a.rejectedDApps[addr]; ok {
+			return errors.Errorf("invocation of dApp %s is rejected", addr.String())
+		}
+		if _, ok := a.rejected[addr]; ok {
+			return errors.Errorf("recipient %s is rejected", addr.String())
+		}
+	}
+	return nil
+}
+
+func (a *AdmissionValidator) checkFee(tx proto.Transaction) error {
+	if a.minFeeMultiplier == 0 || tx.GetFeeAsset().Present {
+		return nil
+	}
+	base, ok := state.BaseFee(tx.GetType())
+	if !ok {
+		return nil
+	}
+	minFee := uint64(math.Ceil(float64(base) * a.minFeeMultiplier))
+	if fee := tx.GetFee(); fee < minFee {
+		return errors.Errorf("fee %d is less than required %d", fee, minFee)
+	}
+	return nil
+}
+
+// recipients returns recipients of the transaction and the invoked dApp if any.
+func (a *AdmissionValidator) recipients(tx proto.Transaction) ([]proto.Recipient, *proto.Recipient, error) {
+	switch t := tx.(type) {
+	case *proto.Payment:
+		return []proto.Recipient{proto.NewRecipientFromAddress(t.Recipient)}, nil, nil
+	case *proto.TransferWithSig:
+		return []proto.Recipient{t.Recipient}, nil, nil
+	case *proto.TransferWithProofs:
+		return []proto.Recipient{t.Recipient}, nil, nil
+	case *proto.MassTransferWithProofs:
+		res := make([]proto.Recipient, len(t.Transfers))
+		for i := range t.Transfers {
+			res[i] = t.Transfers[i].Recipient
+		}
+		return res, nil, nil
+	case *proto.LeaseWithSig:
+		return []proto.Recipient{t.Recipient}, nil, nil
+	case *proto.LeaseWithProofs:
+		return []proto.Recipient{t.Recipient}, nil, nil
+	case *proto.InvokeScriptWithProofs:
+		return nil, &t.ScriptRecipient, nil
+	case *proto.EthereumTransaction:
+		return a.ethereumRecipients(t)
+	default:
+		return nil, nil, nil
+	}
+}
+
+func (a *AdmissionValidator) ethereumRecipients(
+	tx *proto.EthereumTransaction,
+) ([]proto.Recipient, *proto.Recipient, error) {
+	to := tx.To()
+	if to == nil {
+		return nil, nil, nil
+	}
+	toAddr, err := to.ToWavesAddress(a.scheme)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to convert ethereum address")
+	}
+	switch kind := tx.TxKind.(type) {
+	case *proto.EthereumInvokeScriptTxKind:
+		dApp := proto.NewRecipientFromAddress(toAddr)
+		return nil, &dApp, nil
+	case *proto.EthereumTransferAssetsErc20TxKind:
+		addr, aErr := proto.EthereumAddress(kind.Arguments.Recipient).ToWavesAddress(a.scheme)
+		if aErr != nil {
+			return nil, nil, errors.Wrap(aErr, "failed to convert ethereum address")
+		}
+		return []proto.Recipient{proto.NewRecipientFromAddress(addr)}, nil, nil
+	default:
+		return []proto.Recipient{proto.NewRecipientFromAddress(toAddr)}, nil, nil
+	}
+}
+
+func (a *AdmissionValidator) resolve(r proto.Recipient) (proto.WavesAddress, error) {
+	if addr := r.Address(); addr != nil {
+		return *addr, nil
+	}
+	alias := r.Alias()
+	if alias == nil {
+		return proto.WavesAddress{}, errors.New("empty recipient")
+	}
+	addr, err := a.aliases.AddrByAlias(*alias)
+	if err != nil {
+		return proto.WavesAddress{}, errors.Wrapf(err, "failed to resolve alias %s", alias.String())
+	}
+	return addr, nil
+}
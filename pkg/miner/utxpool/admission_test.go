@@ -0,0 +1,87 @@
+package utxpool
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+type testAliases map[string]proto.WavesAddress
+
+func (a testAliases) AddrByAlias(alias proto.Alias) (proto.WavesAddress, error) {
+	addr, ok := a[alias.Alias]
+	if !ok {
+		return proto.WavesAddress{}, assert.AnError
+	}
+	return addr, nil
+}
+
+func testAddress(t *testing.T, seed string) (crypto.PublicKey, proto.WavesAddress) {
+	_, pk, err := crypto.GenerateKeyPair([]byte(seed))
+	require.NoError(t, err)
+	addr, err := proto.NewAddressFromPublicKey(proto.TestNetScheme, pk)
+	require.NoError(t, err)
+	return pk, addr
+}
+
+func TestReadAdmissionRules(t *testing.T) {
+	_, addr := testAddress(t, "sanctioned")
+	rules, err := ReadAdmissionRules(strings.NewReader(
+		`{"rejectedAddresses": ["` + addr.String() + `"], "minFeeMultiplier": 1.5}`))
+	require.NoError(t, err)
+	assert.Equal(t, []proto.WavesAddress{addr}, rules.RejectedAddresses)
+	assert.Equal(t, 1.5, rules.MinFeeMultiplier)
+
+	_, err = ReadAdmissionRules(strings.NewReader(`{"minFeeMultiplier": -1}`))
+	assert.Error(t, err)
+	_, err = ReadAdmissionRules(strings.NewReader(`{"unknown": 1}`))
+	assert.Error(t, err)
+}
+
+func TestAdmissionValidator(t *testing.T) {
+	senderPK, _ := testAddress(t, "sender")
+	rejectedPK, rejected := testAddress(t, "sanctioned")
+	_, dApp := testAddress(t, "dApp")
+	_, other := testAddress(t, "other")
+	aliases := testAliases{"sanctioned": rejected}
+	rules := &AdmissionRules{
+		RejectedAddresses: []proto.WavesAddress{rejected},
+		RejectedDApps:     []proto.WavesAddress{dApp},
+		MinFeeMultiplier:  2,
+	}
+	v := NewAdmissionValidator(NoOpValidator{}, rules, aliases, proto.TestNetScheme)
+
+	transfer := func(pk crypto.PublicKey, r proto.Recipient, fee uint64) proto.Transaction {
+		return proto.NewUnsignedTransferWithProofs(2, pk, proto.NewOptionalAssetWaves(), proto.NewOptionalAssetWaves(),
+			0, 1, fee, r, nil)
+	}
+	invoke := func(r proto.Recipient) proto.Transaction {
+		return proto.NewUnsignedInvokeScriptWithProofs(1, senderPK, r, proto.NewFunctionCall("call", nil), nil,
+			proto.NewOptionalAssetWaves(), 1_000_000, 0)
+	}
+	for i, test := range []struct {
+		tx  proto.Transaction
+		err string
+	}{
+		{transfer(senderPK, proto.NewRecipientFromAddress(other), 200_000), ""},
+		{transfer(senderPK, proto.NewRecipientFromAddress(other), 100_000), "fee 100000 is less than required 200000"},
+		{transfer(rejectedPK, proto.NewRecipientFromAddress(other), 200_000), "sender"},
+		{transfer(senderPK, proto.NewRecipientFromAddress(rejected), 200_000), "recipient"},
+		{transfer(senderPK, proto.NewRecipientFromAlias(*proto.NewAlias(proto.TestNetScheme, "sanctioned")), 200_000),
+			"recipient"},
+		{invoke(proto.NewRecipientFromAddress(dApp)), "invocation of dApp"},
+		{invoke(proto.NewRecipientFromAddress(other)), ""},
+	} {
+		err := v.Validate(test.tx)
+		if test.err == "" {
+			assert.NoError(t, err, i)
+		} else {
+			assert.ErrorContains(t, err, test.err, i)
+		}
+	}
+}
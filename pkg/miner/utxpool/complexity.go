@@ -0,0 +1,158 @@
+package utxpool
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/state/stateerr"
+)
+
+// ComplexityLimits limit the cumulative estimated complexity of scripts of transactions in UTX pool. Scripts are
+// executed on insertion of a transaction, so failing transactions are rejected, and executed again on packing of
+// the block. The limits prevent a sender or all senders together from occupying the miner with script executions.
+// Zero value disables the limit.
+type ComplexityLimits struct {
+	PerSender uint64
+	Total     uint64
+}
+
+// ComplexityEstimator estimates the complexity of scripts executed on validation of a transaction.
+type ComplexityEstimator interface {
+	Estimate(tx proto.Transaction) (sender proto.WavesAddress, complexity uint64, err error)
+}
+
+type scriptsInfo interface {
+	ScriptBasicInfoByAccount(account proto.Recipient) (*proto.ScriptBasicInfo, error)
+	ScriptInfoByAccount(account proto.Recipient) (*proto.ScriptInfo, error)
+}
+
+// StateComplexityEstimator estimates the complexity of transaction as the sum of estimations of sender's account
+// script and the script of invoked dApp.
+type StateComplexityEstimator struct {
+	scripts scriptsInfo
+	scheme  proto.Scheme
+}
+
+func NewStateComplexityEstimator(scripts scriptsInfo, scheme proto.Scheme) *StateComplexityEstimator {
+	return &StateComplexityEstimator{scripts: scripts, scheme: scheme}
+}
+
+func (e *StateComplexityEstimator) Estimate(tx proto.Transaction) (proto.WavesAddress, uint64, error) {
+	sender, err := tx.GetSender(e.scheme)
+	if err != nil {
+		return proto.WavesAddress{}, 0, errors.Wrap(err, "failed to get sender")
+	}
+	addr, err := sender.ToWavesAddress(e.scheme)
+	if err != nil {
+		return proto.WavesAddress{}, 0, errors.Wrap(err, "failed to get sender address")
+	}
+	var complexity uint64
+	if _, ok := tx.(*proto.EthereumTransaction); !ok {
+		c, vErr := e.verifierComplexity(proto.NewRecipientFromAddress(addr))
+		if vErr != nil {
+			return proto.WavesAddress{}, 0, vErr
+		}
+		complexity += c
+	}
+	var dApp *proto.Recipient
+	switch t := tx.(type) {
+	case *proto.InvokeScriptWithProofs:
+		dApp = &t.ScriptRecipient
+	case *proto.EthereumTransaction:
+		if _, ok := t.TxKind.(*proto.EthereumInvokeScriptTxKind); ok && t.To() != nil {
+			to, cErr := t.To().ToWavesAddress(e.scheme)
+			if cErr != nil {
+				return proto.WavesAddress{}, 0, errors.Wrap(cErr, "failed to convert ethereum address")
+			}
+			r := proto.NewRecipientFromAddress(to)
+			dApp = &r
+		}
+	}
+	if dApp != nil {
+		info, sErr := e.scripts.ScriptInfoByAccount(*dApp)
+		if sErr != nil {
+			return proto.WavesAddress{}, 0, errors.Wrapf(sErr, "failed to get script of dApp %s", dApp.String())
+		}
+		complexity += info.Complexity
+	}
+	return addr, complexity, nil
+}
+
+func (e *StateComplexityEstimator) verifierComplexity(account proto.Recipient) (uint64, error) {
+	basic, err := e.scripts.ScriptBasicInfoByAccount(account)
+	if err != nil {
+		if stateerr.IsNotFound(err) {
+			return 0, nil
+		}
+		return 0, errors.Wrap(err, "failed to get sender's script")
+	}
+	if !basic.HasVerifier {
+		return 0, nil
+	}
+	info, err := e.scripts.ScriptInfoByAccount(account)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get sender's script")
+	}
+	return info.Complexity, nil
+}
+
+type txComplexity struct {
+	sender     proto.WavesAddress
+	complexity uint64
+}
+
+// complexityLimiter accounts the complexity of transactions in UTX pool.
+type complexityLimiter struct {
+	estimator ComplexityEstimator
+	limits    ComplexityLimits
+	total     uint64
+	bySender  map[proto.WavesAddress]uint64
+	byTx      map[crypto.Digest]txComplexity
+}
+
+func newComplexityLimiter(estimator ComplexityEstimator, limits ComplexityLimits) *complexityLimiter {
+	return &complexityLimiter{
+		estimator: estimator,
+		limits:    limits,
+		bySender:  make(map[proto.WavesAddress]uint64),
+		byTx:      make(map[crypto.Digest]txComplexity),
+	}
+}
+
+// add checks the limits and accounts the complexity of the transaction.
+func (l *complexityLimiter) add(id crypto.Digest, tx proto.Transaction) error {
+	sender, complexity, err := l.estimator.Estimate(tx)
+	if err != nil {
+		return errors.Wrap(err, "failed to estimate complexity")
+	}
+	if complexity == 0 {
+		return nil
+	}
+	if l.limits.PerSender > 0 && l.bySender[sender]+complexity > l.limits.PerSender {
+		return errors.Errorf("sender's complexity limit exceeded, current: %d, transaction: %d, limit: %d",
+			l.bySender[sender], complexity, l.limits.PerSender)
+	}
+	if l.limits.Total > 0 && l.total+complexity > l.limits.Total {
+		return errors.Errorf("total complexity limit exceeded, current: %d, transaction: %d, limit: %d",
+			l.total, complexity, l.limits.Total)
+	}
+	l.total += complexity
+	l.bySender[sender] += complexity
+	l.byTx[id] = txComplexity{sender: sender, complexity: complexity}
+	return nil
+}
+
+func (l *complexityLimiter) remove(id crypto.Digest) {
+	c, ok := l.byTx[id]
+	if !ok {
+		return
+	}
+	delete(l.byTx, id)
+	l.total -= c.complexity
+	if rest := l.bySender[c.sender] - c.complexity; rest > 0 {
+		l.bySender[c.sender] = rest
+	} else {
+		delete(l.bySender, c.sender)
+	}
+}
@@ -0,0 +1,93 @@
+package utxpool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+)
+
+type testScripts map[proto.WavesAddress]*proto.ScriptInfo
+
+func (s testScripts) ScriptBasicInfoByAccount(account proto.Recipient) (*proto.ScriptBasicInfo, error) {
+	info, ok := s[*account.Address()]
+	if !ok {
+		return nil, proto.ErrNotFound
+	}
+	return &proto.ScriptBasicInfo{HasVerifier: info.Version > 0}, nil
+}
+
+func (s testScripts) ScriptInfoByAccount(account proto.Recipient) (*proto.ScriptInfo, error) {
+	info, ok := s[*account.Address()]
+	if !ok {
+		return nil, proto.ErrNotFound
+	}
+	return info, nil
+}
+
+type failingValidator struct{}
+
+func (failingValidator) Validate(proto.Transaction) error {
+	return assert.AnError
+}
+
+func TestUtxImpl_ComplexityLimits(t *testing.T) {
+	scriptedPK, scripted := testAddress(t, "scripted")
+	otherPK, other := testAddress(t, "other")
+	plainPK, _ := testAddress(t, "plain")
+	_, dApp := testAddress(t, "dApp")
+	scripts := testScripts{
+		scripted: {Version: 1, Complexity: 200}, // Version is used as the flag of verifier in the test
+		other:    {Version: 1, Complexity: 200},
+		dApp:     {Version: 0, Complexity: 1000},
+	}
+	cfg := settings.MustTestNetSettings()
+	est := NewStateComplexityEstimator(scripts, cfg.AddressSchemeCharacter)
+	utx := New(1_000_000, NoOpValidator{}, cfg)
+	utx.SetComplexityLimits(est, ComplexityLimits{PerSender: 500, Total: 700})
+
+	ts := uint64(0)
+	add := func(tx proto.Transaction) error {
+		return utx.AddWithBytes(tx, []byte{1})
+	}
+	transfer := func(pk crypto.PublicKey) proto.Transaction {
+		ts++
+		return proto.NewUnsignedTransferWithProofs(2, pk, proto.NewOptionalAssetWaves(), proto.NewOptionalAssetWaves(),
+			ts, 1, 100_000, proto.NewRecipientFromAddress(dApp), nil)
+	}
+	invoke := func(pk crypto.PublicKey) proto.Transaction {
+		ts++
+		return proto.NewUnsignedInvokeScriptWithProofs(1, pk, proto.NewRecipientFromAddress(dApp),
+			proto.NewFunctionCall("call", nil), nil, proto.NewOptionalAssetWaves(), 500_000, ts)
+	}
+
+	_, c, err := est.Estimate(invoke(scriptedPK))
+	require.NoError(t, err)
+	assert.EqualValues(t, 1200, c)
+
+	require.NoError(t, add(transfer(scriptedPK)))
+	require.NoError(t, add(transfer(scriptedPK)))
+	assert.ErrorContains(t, add(transfer(scriptedPK)), "sender's complexity limit exceeded")
+	require.NoError(t, add(transfer(otherPK)))
+	assert.ErrorContains(t, add(transfer(otherPK)), "total complexity limit exceeded")
+	assert.ErrorContains(t, add(invoke(plainPK)), "sender's complexity limit exceeded")
+	require.NoError(t, add(transfer(plainPK))) // no scripts involved
+	assert.Equal(t, 4, utx.Count())
+
+	// Complexity is released when transactions leave the pool.
+	for utx.Pop() != nil {
+	}
+	require.NoError(t, add(transfer(otherPK)))
+	require.NoError(t, add(transfer(otherPK)))
+
+	// Complexity of rejected transactions is not accounted.
+	utx.validator = failingValidator{}
+	assert.ErrorIs(t, add(transfer(scriptedPK)), assert.AnError)
+	utx.validator = NoOpValidator{}
+	require.NoError(t, add(transfer(scriptedPK)))
+	assert.EqualValues(t, 600, utx.complexity.total)
+}
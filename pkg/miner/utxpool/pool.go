@@ -47,6 +47,7 @@ type UtxImpl struct {
 	curSize        uint64
 	validator      Validator
 	settings       *settings.BlockchainSettings
+	complexity     *complexityLimiter
 }
 
 func New(sizeLimit uint64, validator Validator, settings *settings.BlockchainSettings) *UtxImpl {
@@ -58,6 +59,14 @@ func New(sizeLimit uint64, validator Validator, settings *settings.BlockchainSet
 	}
 }
 
+// SetComplexityLimits enables limits of cumulative complexity of scripts of transactions in the pool. The limits
+// are checked before the validation of transaction, so scripts of transactions exceeding them are not executed.
+func (a *UtxImpl) SetComplexityLimits(estimator ComplexityEstimator, limits ComplexityLimits) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.complexity = newComplexityLimiter(estimator, limits)
+}
+
 func (a *UtxImpl) AllTransactions() []*types.TransactionWithBytes {
 	a.mu.Lock()
 	defer a.mu.Unlock()
@@ -115,8 +124,17 @@ func (a *UtxImpl) addWithBytes(t proto.Transaction, b []byte) error {
 	if a.exists(t) {
 		return proto.NewInfoMsg(errors.Errorf("transaction with id %s exists", base58.Encode(tID)))
 	}
+	id := makeDigest(tID, nil)
+	if a.complexity != nil {
+		if cErr := a.complexity.add(id, t); cErr != nil {
+			return cErr
+		}
+	}
 	err = a.validator.Validate(t)
 	if err != nil {
+		if a.complexity != nil {
+			a.complexity.remove(id)
+		}
 		return err
 	}
 	tb := &types.TransactionWithBytes{
@@ -124,7 +142,6 @@ func (a *UtxImpl) addWithBytes(t proto.Transaction, b []byte) error {
 		B: b,
 	}
 	heap.Push(&a.transactions, tb)
-	id := makeDigest(t.GetID(a.settings.AddressSchemeCharacter))
 	a.transactionIds[id] = struct{}{}
 	a.curSize += uint64(len(b))
 	return nil
@@ -169,7 +186,11 @@ func (a *UtxImpl) Pop() *types.TransactionWithBytes {
 	defer a.mu.Unlock()
 	if a.transactions.Len() > 0 {
 		tb := heap.Pop(&a.transactions).(*types.TransactionWithBytes)
-		delete(a.transactionIds, makeDigest(tb.T.GetID(a.settings.AddressSchemeCharacter)))
+		id := makeDigest(tb.T.GetID(a.settings.AddressSchemeCharacter))
+		delete(a.transactionIds, id)
+		if a.complexity != nil {
+			a.complexity.remove(id)
+		}
 		if uint64(len(tb.B)) > a.curSize {
 			panic(fmt.Sprintf("UtxImpl Pop: size of transaction %d > than current size %d", len(tb.B), a.curSize))
 		}
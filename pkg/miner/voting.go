@@ -0,0 +1,103 @@
+package miner
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/settings"
+)
+
+// votingFile is the JSON representation of the features voting configuration file.
+type votingFile struct {
+	Support []int16 `json:"support"`
+	Against []int16 `json:"against"`
+}
+
+// FeatureVotes holds the features voting configuration of the miner. Features from Against list are never voted for,
+// even if they are in Support list. Configuration can be replaced at runtime.
+type FeatureVotes struct {
+	mu      sync.RWMutex
+	support Features
+	against Features
+}
+
+func NewFeatureVotes(support, against Features) (*FeatureVotes, error) {
+	v := new(FeatureVotes)
+	if err := v.Set(support, against); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Set replaces the voting configuration. Features must be known and implemented.
+func (v *FeatureVotes) Set(support, against Features) error {
+	for _, f := range slices.Concat(support, against) {
+		info, ok := settings.FeaturesInfo[f]
+		if !ok {
+			return errors.Errorf("unknown feature %d", f)
+		}
+		if !info.Implemented {
+			return errors.Errorf("feature '%s'(%d) not implemented", info.Description, f)
+		}
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.support = slices.Clone(support)
+	v.against = slices.Clone(against)
+	return nil
+}
+
+// Support returns the features to vote for.
+func (v *FeatureVotes) Support() Features {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	out := make(Features, 0, len(v.support))
+	for _, f := range v.support {
+		if !slices.Contains(v.against, f) {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// Against returns the features that are never voted for.
+func (v *FeatureVotes) Against() Features {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return slices.Clone(v.against)
+}
+
+// LoadFeatureVotes reads the voting configuration from JSON file of form {"support": [19, 20], "against": [21]}.
+// Features are validated against the state like the features of '-vote' flag, so already activated or approved
+// features are dropped from the lists.
+func LoadFeatureVotes(state featureState, path string) (Features, Features, error) {
+	b, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to read features voting file")
+	}
+	var vf votingFile
+	if err = json.Unmarshal(b, &vf); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to parse features voting file")
+	}
+	toFeatures := func(ids []int16) Features {
+		out := make(Features, len(ids))
+		for i, id := range ids {
+			out[i] = settings.Feature(id)
+		}
+		return out
+	}
+	support, err := ValidateFeatures(state, toFeatures(vf.Support))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to validate supported features")
+	}
+	against, err := ValidateFeatures(state, toFeatures(vf.Against))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to validate rejected features")
+	}
+	return support, against, nil
+}
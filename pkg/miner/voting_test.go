@@ -0,0 +1,63 @@
+package miner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeatureVotes(t *testing.T) {
+	v, err := NewFeatureVotes(Features{13, 14, 15}, Features{14})
+	require.NoError(t, err)
+	assert.Equal(t, Features{13, 15}, v.Support())
+	assert.Equal(t, Features{14}, v.Against())
+
+	require.NoError(t, v.Set(Features{14}, nil))
+	assert.Equal(t, Features{14}, v.Support())
+	assert.Empty(t, v.Against())
+
+	err = v.Set(Features{13, 1000}, nil)
+	assert.EqualError(t, err, "unknown feature 1000")
+	assert.Equal(t, Features{14}, v.Support(), "votes must not be changed on error")
+}
+
+type featureStateStub struct {
+	activated map[int16]bool
+	approved  map[int16]bool
+}
+
+func (s featureStateStub) IsActivated(featureID int16) (bool, error) {
+	return s.activated[featureID], nil
+}
+
+func (s featureStateStub) IsApproved(featureID int16) (bool, error) {
+	return s.approved[featureID], nil
+}
+
+func TestLoadFeatureVotes(t *testing.T) {
+	st := featureStateStub{activated: map[int16]bool{13: true}, approved: map[int16]bool{16: true}}
+	path := filepath.Join(t.TempDir(), "votes.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"support":[14,15],"against":[17]}`), 0600))
+	support, against, err := LoadFeatureVotes(st, path)
+	require.NoError(t, err)
+	assert.Equal(t, Features{14, 15}, support)
+	assert.Equal(t, Features{17}, against)
+
+	// activated and approved features are dropped like the features of '-vote' flag
+	require.NoError(t, os.WriteFile(path, []byte(`{"support":[13,14,16],"against":[16]}`), 0600))
+	support, against, err = LoadFeatureVotes(st, path)
+	require.NoError(t, err)
+	assert.Equal(t, Features{14}, support)
+	assert.Empty(t, against)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"support":[1000]}`), 0600))
+	_, _, err = LoadFeatureVotes(st, path)
+	assert.EqualError(t, err, "failed to validate supported features: unknown feature 1000")
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"support":`), 0600))
+	_, _, err = LoadFeatureVotes(st, path)
+	assert.Error(t, err)
+}
@@ -77,6 +77,30 @@ func (mr *MockPeerManagerMockRecorder) BlackList() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlackList", reflect.TypeOf((*MockPeerManager)(nil).BlackList))
 }
 
+// BlockDelivered mocks base method.
+func (m *MockPeerManager) BlockDelivered(id string, rtt time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "BlockDelivered", id, rtt)
+}
+
+// BlockDelivered indicates an expected call of BlockDelivered.
+func (mr *MockPeerManagerMockRecorder) BlockDelivered(id, rtt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockDelivered", reflect.TypeOf((*MockPeerManager)(nil).BlockDelivered), id, rtt)
+}
+
+// BlockStalled mocks base method.
+func (m *MockPeerManager) BlockStalled(id string, waited time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "BlockStalled", id, waited)
+}
+
+// BlockStalled indicates an expected call of BlockStalled.
+func (mr *MockPeerManagerMockRecorder) BlockStalled(id, waited interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockStalled", reflect.TypeOf((*MockPeerManager)(nil).BlockStalled), id, waited)
+}
+
 // CheckPeerInLargestScoreGroup mocks base method.
 func (m *MockPeerManager) CheckPeerInLargestScoreGroup(p peer.Peer) (peer.Peer, bool) {
 	m.ctrl.T.Helper()
@@ -201,6 +225,20 @@ func (mr *MockPeerManagerMockRecorder) KnownPeers() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KnownPeers", reflect.TypeOf((*MockPeerManager)(nil).KnownPeers))
 }
 
+// KnownPeersRecords mocks base method.
+func (m *MockPeerManager) KnownPeersRecords() []storage.KnownPeerRecord {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KnownPeersRecords")
+	ret0, _ := ret[0].([]storage.KnownPeerRecord)
+	return ret0
+}
+
+// KnownPeersRecords indicates an expected call of KnownPeersRecords.
+func (mr *MockPeerManagerMockRecorder) KnownPeersRecords() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KnownPeersRecords", reflect.TypeOf((*MockPeerManager)(nil).KnownPeersRecords))
+}
+
 // NewConnection mocks base method.
 func (m *MockPeerManager) NewConnection(arg0 peer.Peer) error {
 	m.ctrl.T.Helper()
@@ -215,6 +253,34 @@ func (mr *MockPeerManagerMockRecorder) NewConnection(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewConnection", reflect.TypeOf((*MockPeerManager)(nil).NewConnection), arg0)
 }
 
+// Pin mocks base method.
+func (m *MockPeerManager) Pin(addr proto.TCPAddr) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pin", addr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Pin indicates an expected call of Pin.
+func (mr *MockPeerManagerMockRecorder) Pin(addr interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pin", reflect.TypeOf((*MockPeerManager)(nil).Pin), addr)
+}
+
+// Pinned mocks base method.
+func (m *MockPeerManager) Pinned() []proto.IpPort {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pinned")
+	ret0, _ := ret[0].([]proto.IpPort)
+	return ret0
+}
+
+// Pinned indicates an expected call of Pinned.
+func (mr *MockPeerManagerMockRecorder) Pinned() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pinned", reflect.TypeOf((*MockPeerManager)(nil).Pinned))
+}
+
 // Score mocks base method.
 func (m *MockPeerManager) Score(p peer.Peer) (*proto.Score, error) {
 	m.ctrl.T.Helper()
@@ -296,6 +362,21 @@ func (mr *MockPeerManagerMockRecorder) Suspended() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suspended", reflect.TypeOf((*MockPeerManager)(nil).Suspended))
 }
 
+// Unpin mocks base method.
+func (m *MockPeerManager) Unpin(addr proto.TCPAddr) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unpin", addr)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Unpin indicates an expected call of Unpin.
+func (mr *MockPeerManagerMockRecorder) Unpin(addr interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unpin", reflect.TypeOf((*MockPeerManager)(nil).Unpin), addr)
+}
+
 // UpdateKnownPeers mocks base method.
 func (m *MockPeerManager) UpdateKnownPeers(arg0 []storage.KnownPeer) error {
 	m.ctrl.T.Helper()
@@ -49,6 +49,20 @@ func (mr *MockPeerStorageMockRecorder) AddOrUpdateKnown(known, now interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOrUpdateKnown", reflect.TypeOf((*MockPeerStorage)(nil).AddOrUpdateKnown), known, now)
 }
 
+// AddPinned mocks base method.
+func (m *MockPeerStorage) AddPinned(pinned storage.KnownPeer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPinned", pinned)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddPinned indicates an expected call of AddPinned.
+func (mr *MockPeerStorageMockRecorder) AddPinned(pinned interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPinned", reflect.TypeOf((*MockPeerStorage)(nil).AddPinned), pinned)
+}
+
 // AddSuspended mocks base method.
 func (m *MockPeerStorage) AddSuspended(suspended []storage.SuspendedPeer) error {
 	m.ctrl.T.Helper()
@@ -119,6 +133,20 @@ func (mr *MockPeerStorageMockRecorder) DeleteKnown(known interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteKnown", reflect.TypeOf((*MockPeerStorage)(nil).DeleteKnown), known)
 }
 
+// DeletePinned mocks base method.
+func (m *MockPeerStorage) DeletePinned(pinned storage.KnownPeer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePinned", pinned)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePinned indicates an expected call of DeletePinned.
+func (mr *MockPeerStorageMockRecorder) DeletePinned(pinned interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePinned", reflect.TypeOf((*MockPeerStorage)(nil).DeletePinned), pinned)
+}
+
 // DeleteSuspendedByIP mocks base method.
 func (m *MockPeerStorage) DeleteSuspendedByIP(suspended []storage.SuspendedPeer) error {
 	m.ctrl.T.Helper()
@@ -259,6 +287,62 @@ func (mr *MockPeerStorageMockRecorder) Known(limit interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Known", reflect.TypeOf((*MockPeerStorage)(nil).Known), limit)
 }
 
+// KnownRecords mocks base method.
+func (m *MockPeerStorage) KnownRecords(limit int) []storage.KnownPeerRecord {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KnownRecords", limit)
+	ret0, _ := ret[0].([]storage.KnownPeerRecord)
+	return ret0
+}
+
+// KnownRecords indicates an expected call of KnownRecords.
+func (mr *MockPeerStorageMockRecorder) KnownRecords(limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KnownRecords", reflect.TypeOf((*MockPeerStorage)(nil).KnownRecords), limit)
+}
+
+// Pinned mocks base method.
+func (m *MockPeerStorage) Pinned() []storage.KnownPeer {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pinned")
+	ret0, _ := ret[0].([]storage.KnownPeer)
+	return ret0
+}
+
+// Pinned indicates an expected call of Pinned.
+func (mr *MockPeerStorageMockRecorder) Pinned() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pinned", reflect.TypeOf((*MockPeerStorage)(nil).Pinned))
+}
+
+// RecordKnownFailure mocks base method.
+func (m *MockPeerStorage) RecordKnownFailure(known storage.KnownPeer, attempt, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordKnownFailure", known, attempt, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordKnownFailure indicates an expected call of RecordKnownFailure.
+func (mr *MockPeerStorageMockRecorder) RecordKnownFailure(known, attempt, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordKnownFailure", reflect.TypeOf((*MockPeerStorage)(nil).RecordKnownFailure), known, attempt, now)
+}
+
+// RecordKnownHandshake mocks base method.
+func (m *MockPeerStorage) RecordKnownHandshake(known storage.KnownPeer, version, appName string, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordKnownHandshake", known, version, appName, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordKnownHandshake indicates an expected call of RecordKnownHandshake.
+func (mr *MockPeerStorageMockRecorder) RecordKnownHandshake(known, version, appName, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordKnownHandshake", reflect.TypeOf((*MockPeerStorage)(nil).RecordKnownHandshake), known, version, appName, now)
+}
+
 // RefreshBlackList mocks base method.
 func (m *MockPeerStorage) RefreshBlackList(now time.Time) error {
 	m.ctrl.T.Helper()
@@ -5,11 +5,13 @@
 package mock
 
 import (
+	io "io"
 	big "math/big"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
 	crypto "github.com/wavesplatform/gowaves/pkg/crypto"
+	keyvalue "github.com/wavesplatform/gowaves/pkg/keyvalue"
 	proto "github.com/wavesplatform/gowaves/pkg/proto"
 	ast "github.com/wavesplatform/gowaves/pkg/ride/ast"
 	settings "github.com/wavesplatform/gowaves/pkg/settings"
@@ -238,6 +240,35 @@ func (mr *MockStateInfoMockRecorder) AssetIsSponsored(assetID interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssetIsSponsored", reflect.TypeOf((*MockStateInfo)(nil).AssetIsSponsored), assetID)
 }
 
+// BalanceAtHeight mocks base method.
+func (m *MockStateInfo) BalanceAtHeight(account proto.Recipient, asset proto.OptionalAsset, height proto.Height) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BalanceAtHeight", account, asset, height)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BalanceAtHeight indicates an expected call of BalanceAtHeight.
+func (mr *MockStateInfoMockRecorder) BalanceAtHeight(account, asset, height interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BalanceAtHeight", reflect.TypeOf((*MockStateInfo)(nil).BalanceAtHeight), account, asset, height)
+}
+
+// BalancesAtHeight mocks base method.
+func (m *MockStateInfo) BalancesAtHeight(height proto.Height, fn func(state.BalanceRecord) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BalancesAtHeight", height, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BalancesAtHeight indicates an expected call of BalancesAtHeight.
+func (mr *MockStateInfoMockRecorder) BalancesAtHeight(height, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BalancesAtHeight", reflect.TypeOf((*MockStateInfo)(nil).BalancesAtHeight), height, fn)
+}
+
 // Block mocks base method.
 func (m *MockStateInfo) Block(blockID proto.BlockID) (*proto.Block, error) {
 	m.ctrl.T.Helper()
@@ -358,6 +389,35 @@ func (mr *MockStateInfoMockRecorder) CurrentScore() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CurrentScore", reflect.TypeOf((*MockStateInfo)(nil).CurrentScore))
 }
 
+// DataEntriesAtHeight mocks base method.
+func (m *MockStateInfo) DataEntriesAtHeight(height proto.Height, fn func(state.DataEntryRecord) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DataEntriesAtHeight", height, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DataEntriesAtHeight indicates an expected call of DataEntriesAtHeight.
+func (mr *MockStateInfoMockRecorder) DataEntriesAtHeight(height, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DataEntriesAtHeight", reflect.TypeOf((*MockStateInfo)(nil).DataEntriesAtHeight), height, fn)
+}
+
+// EffectiveBalancesAtHeight mocks base method.
+func (m *MockStateInfo) EffectiveBalancesAtHeight(account proto.Recipient, height proto.Height) (*proto.EffectiveBalances, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EffectiveBalancesAtHeight", account, height)
+	ret0, _ := ret[0].(*proto.EffectiveBalances)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EffectiveBalancesAtHeight indicates an expected call of EffectiveBalancesAtHeight.
+func (mr *MockStateInfoMockRecorder) EffectiveBalancesAtHeight(account, height interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EffectiveBalancesAtHeight", reflect.TypeOf((*MockStateInfo)(nil).EffectiveBalancesAtHeight), account, height)
+}
+
 // EnrichedFullAssetInfo mocks base method.
 func (m *MockStateInfo) EnrichedFullAssetInfo(assetID proto.AssetID) (*proto.EnrichedFullAssetInfo, error) {
 	m.ctrl.T.Helper()
@@ -628,6 +688,35 @@ func (mr *MockStateInfoMockRecorder) IsAssetExist(assetID interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAssetExist", reflect.TypeOf((*MockStateInfo)(nil).IsAssetExist), assetID)
 }
 
+// LeaseInfo mocks base method.
+func (m *MockStateInfo) LeaseInfo(leaseID crypto.Digest) (*proto.LeaseDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LeaseInfo", leaseID)
+	ret0, _ := ret[0].(*proto.LeaseDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LeaseInfo indicates an expected call of LeaseInfo.
+func (mr *MockStateInfoMockRecorder) LeaseInfo(leaseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeaseInfo", reflect.TypeOf((*MockStateInfo)(nil).LeaseInfo), leaseID)
+}
+
+// LeasesAtHeight mocks base method.
+func (m *MockStateInfo) LeasesAtHeight(height proto.Height, fn func(*proto.LeaseDetails) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LeasesAtHeight", height, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LeasesAtHeight indicates an expected call of LeasesAtHeight.
+func (mr *MockStateInfoMockRecorder) LeasesAtHeight(height, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeasesAtHeight", reflect.TypeOf((*MockStateInfo)(nil).LeasesAtHeight), height, fn)
+}
+
 // LegacyStateHashAtHeight mocks base method.
 func (m *MockStateInfo) LegacyStateHashAtHeight(height proto.Height) (*proto.StateHash, error) {
 	m.ctrl.T.Helper()
@@ -718,6 +807,22 @@ func (mr *MockStateInfoMockRecorder) NewestScriptBytesByAccount(account interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewestScriptBytesByAccount", reflect.TypeOf((*MockStateInfo)(nil).NewestScriptBytesByAccount), account)
 }
 
+// OrderFilled mocks base method.
+func (m *MockStateInfo) OrderFilled(orderID []byte) (uint64, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrderFilled", orderID)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// OrderFilled indicates an expected call of OrderFilled.
+func (mr *MockStateInfoMockRecorder) OrderFilled(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrderFilled", reflect.TypeOf((*MockStateInfo)(nil).OrderFilled), orderID)
+}
+
 // ProvidesExtendedApi mocks base method.
 func (m *MockStateInfo) ProvidesExtendedApi() (bool, error) {
 	m.ctrl.T.Helper()
@@ -808,6 +913,21 @@ func (mr *MockStateInfoMockRecorder) RetrieveEntry(account, key interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveEntry", reflect.TypeOf((*MockStateInfo)(nil).RetrieveEntry), account, key)
 }
 
+// RetrieveEntryAtHeight mocks base method.
+func (m *MockStateInfo) RetrieveEntryAtHeight(account proto.Recipient, key string, height proto.Height) (proto.DataEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetrieveEntryAtHeight", account, key, height)
+	ret0, _ := ret[0].(proto.DataEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetrieveEntryAtHeight indicates an expected call of RetrieveEntryAtHeight.
+func (mr *MockStateInfoMockRecorder) RetrieveEntryAtHeight(account, key, height interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveEntryAtHeight", reflect.TypeOf((*MockStateInfo)(nil).RetrieveEntryAtHeight), account, key, height)
+}
+
 // RetrieveIntegerEntry mocks base method.
 func (m *MockStateInfo) RetrieveIntegerEntry(account proto.Recipient, key string) (*proto.IntegerDataEntry, error) {
 	m.ctrl.T.Helper()
@@ -868,6 +988,21 @@ func (mr *MockStateInfoMockRecorder) RewardVotes(height interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RewardVotes", reflect.TypeOf((*MockStateInfo)(nil).RewardVotes), height)
 }
 
+// Richlist mocks base method.
+func (m *MockStateInfo) Richlist(asset proto.OptionalAsset, limit uint64) ([]state.BalanceRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Richlist", asset, limit)
+	ret0, _ := ret[0].([]state.BalanceRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Richlist indicates an expected call of Richlist.
+func (mr *MockStateInfoMockRecorder) Richlist(asset, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Richlist", reflect.TypeOf((*MockStateInfo)(nil).Richlist), asset, limit)
+}
+
 // ScoreAtHeight mocks base method.
 func (m *MockStateInfo) ScoreAtHeight(height proto.Height) (*big.Int, error) {
 	m.ctrl.T.Helper()
@@ -898,6 +1033,21 @@ func (mr *MockStateInfoMockRecorder) ScriptBasicInfoByAccount(account interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScriptBasicInfoByAccount", reflect.TypeOf((*MockStateInfo)(nil).ScriptBasicInfoByAccount), account)
 }
 
+// ScriptHistoryByAccount mocks base method.
+func (m *MockStateInfo) ScriptHistoryByAccount(account proto.Recipient) ([]proto.ScriptChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScriptHistoryByAccount", account)
+	ret0, _ := ret[0].([]proto.ScriptChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScriptHistoryByAccount indicates an expected call of ScriptHistoryByAccount.
+func (mr *MockStateInfoMockRecorder) ScriptHistoryByAccount(account interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScriptHistoryByAccount", reflect.TypeOf((*MockStateInfo)(nil).ScriptHistoryByAccount), account)
+}
+
 // ScriptInfoByAccount mocks base method.
 func (m *MockStateInfo) ScriptInfoByAccount(account proto.Recipient) (*proto.ScriptInfo, error) {
 	m.ctrl.T.Helper()
@@ -928,6 +1078,21 @@ func (mr *MockStateInfoMockRecorder) ScriptInfoByAsset(assetID interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScriptInfoByAsset", reflect.TypeOf((*MockStateInfo)(nil).ScriptInfoByAsset), assetID)
 }
 
+// SearchAssets mocks base method.
+func (m *MockStateInfo) SearchAssets(query string, limit uint64, afterAssetID *proto.AssetID) ([]crypto.Digest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchAssets", query, limit, afterAssetID)
+	ret0, _ := ret[0].([]crypto.Digest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchAssets indicates an expected call of SearchAssets.
+func (mr *MockStateInfoMockRecorder) SearchAssets(query, limit, afterAssetID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchAssets", reflect.TypeOf((*MockStateInfo)(nil).SearchAssets), query, limit, afterAssetID)
+}
+
 // ShouldPersistAddressTransactions mocks base method.
 func (m *MockStateInfo) ShouldPersistAddressTransactions() (bool, error) {
 	m.ctrl.T.Helper()
@@ -973,6 +1138,21 @@ func (mr *MockStateInfoMockRecorder) SnapshotsAtHeight(height interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnapshotsAtHeight", reflect.TypeOf((*MockStateInfo)(nil).SnapshotsAtHeight), height)
 }
 
+// SponsorshipStatus mocks base method.
+func (m *MockStateInfo) SponsorshipStatus(assetID proto.AssetID) (*proto.SponsorshipStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SponsorshipStatus", assetID)
+	ret0, _ := ret[0].(*proto.SponsorshipStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SponsorshipStatus indicates an expected call of SponsorshipStatus.
+func (mr *MockStateInfoMockRecorder) SponsorshipStatus(assetID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SponsorshipStatus", reflect.TypeOf((*MockStateInfo)(nil).SponsorshipStatus), assetID)
+}
+
 // TopBlock mocks base method.
 func (m *MockStateInfo) TopBlock() *proto.Block {
 	m.ctrl.T.Helper()
@@ -1108,6 +1288,21 @@ func (mr *MockStateInfoMockRecorder) WavesBalance(account interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WavesBalance", reflect.TypeOf((*MockStateInfo)(nil).WavesBalance), account)
 }
 
+// WavesBalanceHistory mocks base method.
+func (m *MockStateInfo) WavesBalanceHistory(account proto.Recipient) ([]proto.BalanceAtHeight, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WavesBalanceHistory", account)
+	ret0, _ := ret[0].([]proto.BalanceAtHeight)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WavesBalanceHistory indicates an expected call of WavesBalanceHistory.
+func (mr *MockStateInfoMockRecorder) WavesBalanceHistory(account interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WavesBalanceHistory", reflect.TypeOf((*MockStateInfo)(nil).WavesBalanceHistory), account)
+}
+
 // MockStateModifier is a mock of StateModifier interface.
 type MockStateModifier struct {
 	ctrl     *gomock.Controller
@@ -1219,6 +1414,34 @@ func (mr *MockStateModifierMockRecorder) AddDeserializedBlocksWithSnapshots(bloc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDeserializedBlocksWithSnapshots", reflect.TypeOf((*MockStateModifier)(nil).AddDeserializedBlocksWithSnapshots), blocks, snapshots)
 }
 
+// Backup mocks base method.
+func (m *MockStateModifier) Backup(dir string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Backup", dir)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Backup indicates an expected call of Backup.
+func (mr *MockStateModifierMockRecorder) Backup(dir interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Backup", reflect.TypeOf((*MockStateModifier)(nil).Backup), dir)
+}
+
+// BackupTar mocks base method.
+func (m *MockStateModifier) BackupTar(w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackupTar", w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BackupTar indicates an expected call of BackupTar.
+func (mr *MockStateModifierMockRecorder) BackupTar(w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackupTar", reflect.TypeOf((*MockStateModifier)(nil).BackupTar), w)
+}
+
 // Close mocks base method.
 func (m *MockStateModifier) Close() error {
 	m.ctrl.T.Helper()
@@ -1315,6 +1538,21 @@ func (mr *MockStateModifierMockRecorder) StartProvidingExtendedApi() *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartProvidingExtendedApi", reflect.TypeOf((*MockStateModifier)(nil).StartProvidingExtendedApi))
 }
 
+// StorageStats mocks base method.
+func (m *MockStateModifier) StorageStats() (*keyvalue.Stats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StorageStats")
+	ret0, _ := ret[0].(*keyvalue.Stats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StorageStats indicates an expected call of StorageStats.
+func (mr *MockStateModifierMockRecorder) StorageStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StorageStats", reflect.TypeOf((*MockStateModifier)(nil).StorageStats))
+}
+
 // TxValidation mocks base method.
 func (m *MockStateModifier) TxValidation(arg0 func(state.TxValidation) error) error {
 	m.ctrl.T.Helper()
@@ -1613,6 +1851,63 @@ func (mr *MockStateMockRecorder) AssetIsSponsored(assetID interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssetIsSponsored", reflect.TypeOf((*MockState)(nil).AssetIsSponsored), assetID)
 }
 
+// Backup mocks base method.
+func (m *MockState) Backup(dir string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Backup", dir)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Backup indicates an expected call of Backup.
+func (mr *MockStateMockRecorder) Backup(dir interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Backup", reflect.TypeOf((*MockState)(nil).Backup), dir)
+}
+
+// BackupTar mocks base method.
+func (m *MockState) BackupTar(w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackupTar", w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BackupTar indicates an expected call of BackupTar.
+func (mr *MockStateMockRecorder) BackupTar(w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackupTar", reflect.TypeOf((*MockState)(nil).BackupTar), w)
+}
+
+// BalanceAtHeight mocks base method.
+func (m *MockState) BalanceAtHeight(account proto.Recipient, asset proto.OptionalAsset, height proto.Height) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BalanceAtHeight", account, asset, height)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BalanceAtHeight indicates an expected call of BalanceAtHeight.
+func (mr *MockStateMockRecorder) BalanceAtHeight(account, asset, height interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BalanceAtHeight", reflect.TypeOf((*MockState)(nil).BalanceAtHeight), account, asset, height)
+}
+
+// BalancesAtHeight mocks base method.
+func (m *MockState) BalancesAtHeight(height proto.Height, fn func(state.BalanceRecord) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BalancesAtHeight", height, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BalancesAtHeight indicates an expected call of BalancesAtHeight.
+func (mr *MockStateMockRecorder) BalancesAtHeight(height, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BalancesAtHeight", reflect.TypeOf((*MockState)(nil).BalancesAtHeight), height, fn)
+}
+
 // Block mocks base method.
 func (m *MockState) Block(blockID proto.BlockID) (*proto.Block, error) {
 	m.ctrl.T.Helper()
@@ -1747,6 +2042,35 @@ func (mr *MockStateMockRecorder) CurrentScore() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CurrentScore", reflect.TypeOf((*MockState)(nil).CurrentScore))
 }
 
+// DataEntriesAtHeight mocks base method.
+func (m *MockState) DataEntriesAtHeight(height proto.Height, fn func(state.DataEntryRecord) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DataEntriesAtHeight", height, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DataEntriesAtHeight indicates an expected call of DataEntriesAtHeight.
+func (mr *MockStateMockRecorder) DataEntriesAtHeight(height, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DataEntriesAtHeight", reflect.TypeOf((*MockState)(nil).DataEntriesAtHeight), height, fn)
+}
+
+// EffectiveBalancesAtHeight mocks base method.
+func (m *MockState) EffectiveBalancesAtHeight(account proto.Recipient, height proto.Height) (*proto.EffectiveBalances, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EffectiveBalancesAtHeight", account, height)
+	ret0, _ := ret[0].(*proto.EffectiveBalances)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EffectiveBalancesAtHeight indicates an expected call of EffectiveBalancesAtHeight.
+func (mr *MockStateMockRecorder) EffectiveBalancesAtHeight(account, height interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EffectiveBalancesAtHeight", reflect.TypeOf((*MockState)(nil).EffectiveBalancesAtHeight), account, height)
+}
+
 // EnrichedFullAssetInfo mocks base method.
 func (m *MockState) EnrichedFullAssetInfo(assetID proto.AssetID) (*proto.EnrichedFullAssetInfo, error) {
 	m.ctrl.T.Helper()
@@ -2017,6 +2341,35 @@ func (mr *MockStateMockRecorder) IsAssetExist(assetID interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAssetExist", reflect.TypeOf((*MockState)(nil).IsAssetExist), assetID)
 }
 
+// LeaseInfo mocks base method.
+func (m *MockState) LeaseInfo(leaseID crypto.Digest) (*proto.LeaseDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LeaseInfo", leaseID)
+	ret0, _ := ret[0].(*proto.LeaseDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LeaseInfo indicates an expected call of LeaseInfo.
+func (mr *MockStateMockRecorder) LeaseInfo(leaseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeaseInfo", reflect.TypeOf((*MockState)(nil).LeaseInfo), leaseID)
+}
+
+// LeasesAtHeight mocks base method.
+func (m *MockState) LeasesAtHeight(height proto.Height, fn func(*proto.LeaseDetails) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LeasesAtHeight", height, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LeasesAtHeight indicates an expected call of LeasesAtHeight.
+func (mr *MockStateMockRecorder) LeasesAtHeight(height, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeasesAtHeight", reflect.TypeOf((*MockState)(nil).LeasesAtHeight), height, fn)
+}
+
 // LegacyStateHashAtHeight mocks base method.
 func (m *MockState) LegacyStateHashAtHeight(height proto.Height) (*proto.StateHash, error) {
 	m.ctrl.T.Helper()
@@ -2121,6 +2474,22 @@ func (mr *MockStateMockRecorder) NewestScriptBytesByAccount(account interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewestScriptBytesByAccount", reflect.TypeOf((*MockState)(nil).NewestScriptBytesByAccount), account)
 }
 
+// OrderFilled mocks base method.
+func (m *MockState) OrderFilled(orderID []byte) (uint64, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrderFilled", orderID)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// OrderFilled indicates an expected call of OrderFilled.
+func (mr *MockStateMockRecorder) OrderFilled(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrderFilled", reflect.TypeOf((*MockState)(nil).OrderFilled), orderID)
+}
+
 // PersistAddressTransactions mocks base method.
 func (m *MockState) PersistAddressTransactions() error {
 	m.ctrl.T.Helper()
@@ -2237,6 +2606,21 @@ func (mr *MockStateMockRecorder) RetrieveEntry(account, key interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveEntry", reflect.TypeOf((*MockState)(nil).RetrieveEntry), account, key)
 }
 
+// RetrieveEntryAtHeight mocks base method.
+func (m *MockState) RetrieveEntryAtHeight(account proto.Recipient, key string, height proto.Height) (proto.DataEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetrieveEntryAtHeight", account, key, height)
+	ret0, _ := ret[0].(proto.DataEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetrieveEntryAtHeight indicates an expected call of RetrieveEntryAtHeight.
+func (mr *MockStateMockRecorder) RetrieveEntryAtHeight(account, key, height interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveEntryAtHeight", reflect.TypeOf((*MockState)(nil).RetrieveEntryAtHeight), account, key, height)
+}
+
 // RetrieveIntegerEntry mocks base method.
 func (m *MockState) RetrieveIntegerEntry(account proto.Recipient, key string) (*proto.IntegerDataEntry, error) {
 	m.ctrl.T.Helper()
@@ -2297,6 +2681,21 @@ func (mr *MockStateMockRecorder) RewardVotes(height interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RewardVotes", reflect.TypeOf((*MockState)(nil).RewardVotes), height)
 }
 
+// Richlist mocks base method.
+func (m *MockState) Richlist(asset proto.OptionalAsset, limit uint64) ([]state.BalanceRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Richlist", asset, limit)
+	ret0, _ := ret[0].([]state.BalanceRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Richlist indicates an expected call of Richlist.
+func (mr *MockStateMockRecorder) Richlist(asset, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Richlist", reflect.TypeOf((*MockState)(nil).Richlist), asset, limit)
+}
+
 // RollbackTo mocks base method.
 func (m *MockState) RollbackTo(removalEdge proto.BlockID) error {
 	m.ctrl.T.Helper()
@@ -2355,6 +2754,21 @@ func (mr *MockStateMockRecorder) ScriptBasicInfoByAccount(account interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScriptBasicInfoByAccount", reflect.TypeOf((*MockState)(nil).ScriptBasicInfoByAccount), account)
 }
 
+// ScriptHistoryByAccount mocks base method.
+func (m *MockState) ScriptHistoryByAccount(account proto.Recipient) ([]proto.ScriptChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScriptHistoryByAccount", account)
+	ret0, _ := ret[0].([]proto.ScriptChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScriptHistoryByAccount indicates an expected call of ScriptHistoryByAccount.
+func (mr *MockStateMockRecorder) ScriptHistoryByAccount(account interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScriptHistoryByAccount", reflect.TypeOf((*MockState)(nil).ScriptHistoryByAccount), account)
+}
+
 // ScriptInfoByAccount mocks base method.
 func (m *MockState) ScriptInfoByAccount(account proto.Recipient) (*proto.ScriptInfo, error) {
 	m.ctrl.T.Helper()
@@ -2385,6 +2799,21 @@ func (mr *MockStateMockRecorder) ScriptInfoByAsset(assetID interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScriptInfoByAsset", reflect.TypeOf((*MockState)(nil).ScriptInfoByAsset), assetID)
 }
 
+// SearchAssets mocks base method.
+func (m *MockState) SearchAssets(query string, limit uint64, afterAssetID *proto.AssetID) ([]crypto.Digest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchAssets", query, limit, afterAssetID)
+	ret0, _ := ret[0].([]crypto.Digest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchAssets indicates an expected call of SearchAssets.
+func (mr *MockStateMockRecorder) SearchAssets(query, limit, afterAssetID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchAssets", reflect.TypeOf((*MockState)(nil).SearchAssets), query, limit, afterAssetID)
+}
+
 // ShouldPersistAddressTransactions mocks base method.
 func (m *MockState) ShouldPersistAddressTransactions() (bool, error) {
 	m.ctrl.T.Helper()
@@ -2430,6 +2859,21 @@ func (mr *MockStateMockRecorder) SnapshotsAtHeight(height interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnapshotsAtHeight", reflect.TypeOf((*MockState)(nil).SnapshotsAtHeight), height)
 }
 
+// SponsorshipStatus mocks base method.
+func (m *MockState) SponsorshipStatus(assetID proto.AssetID) (*proto.SponsorshipStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SponsorshipStatus", assetID)
+	ret0, _ := ret[0].(*proto.SponsorshipStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SponsorshipStatus indicates an expected call of SponsorshipStatus.
+func (mr *MockStateMockRecorder) SponsorshipStatus(assetID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SponsorshipStatus", reflect.TypeOf((*MockState)(nil).SponsorshipStatus), assetID)
+}
+
 // StartProvidingExtendedApi mocks base method.
 func (m *MockState) StartProvidingExtendedApi() error {
 	m.ctrl.T.Helper()
@@ -2444,6 +2888,21 @@ func (mr *MockStateMockRecorder) StartProvidingExtendedApi() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartProvidingExtendedApi", reflect.TypeOf((*MockState)(nil).StartProvidingExtendedApi))
 }
 
+// StorageStats mocks base method.
+func (m *MockState) StorageStats() (*keyvalue.Stats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StorageStats")
+	ret0, _ := ret[0].(*keyvalue.Stats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StorageStats indicates an expected call of StorageStats.
+func (mr *MockStateMockRecorder) StorageStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StorageStats", reflect.TypeOf((*MockState)(nil).StorageStats))
+}
+
 // TopBlock mocks base method.
 func (m *MockState) TopBlock() *proto.Block {
 	m.ctrl.T.Helper()
@@ -2607,3 +3066,18 @@ func (mr *MockStateMockRecorder) WavesBalance(account interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WavesBalance", reflect.TypeOf((*MockState)(nil).WavesBalance), account)
 }
+
+// WavesBalanceHistory mocks base method.
+func (m *MockState) WavesBalanceHistory(account proto.Recipient) ([]proto.BalanceAtHeight, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WavesBalanceHistory", account)
+	ret0, _ := ret[0].([]proto.BalanceAtHeight)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WavesBalanceHistory indicates an expected call of WavesBalanceHistory.
+func (mr *MockStateMockRecorder) WavesBalanceHistory(account interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WavesBalanceHistory", reflect.TypeOf((*MockState)(nil).WavesBalanceHistory), account)
+}
@@ -73,7 +73,7 @@ func GetBlockAction(services services.Services, mess peer.ProtoMessage, _ *fsm.F
 	if err != nil {
 		return nil, err
 	}
-	bm, err := proto.MessageByBlock(block, services.Scheme)
+	bm, err := extension.ProtocolOf(mess.ID).BlockMessage(block, services.Scheme)
 	if err != nil {
 		return nil, err
 	}
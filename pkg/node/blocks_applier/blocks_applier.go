@@ -6,14 +6,16 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/wavesplatform/gowaves/pkg/node/forks"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 	"github.com/wavesplatform/gowaves/pkg/state"
 	"github.com/wavesplatform/gowaves/pkg/state/stateerr"
 )
 
-const maxRollbackDeltaHeight = 100
+const MaxRollbackDeltaHeight = 100
 
 type innerBlocksApplier struct {
+	forks *forks.Registry
 }
 
 type innerState interface {
@@ -46,7 +48,7 @@ func (a *innerBlocksApplier) apply(
 	if len(blocks) == 0 {
 		return 0, errors.New("empty blocks")
 	}
-	currentHeight, parentHeight, err := a.getParentAndCurrentHeight(storage, blocks)
+	currentHeight, parentHeight, currentScore, err := a.getParentAndCurrentHeight(storage, blocks)
 	if err != nil {
 		return 0, err
 	}
@@ -63,9 +65,9 @@ func (a *innerBlocksApplier) apply(
 	}
 
 	deltaHeight := currentHeight - parentHeight
-	if deltaHeight > maxRollbackDeltaHeight { // max number that we can rollback
+	if deltaHeight > MaxRollbackDeltaHeight { // max number that we can rollback
 		return 0, errors.Errorf(
-			"can't apply new blocks, rollback more than %d blocks, %d", maxRollbackDeltaHeight, deltaHeight)
+			"can't apply new blocks, rollback more than %d blocks, %d", MaxRollbackDeltaHeight, deltaHeight)
 	}
 
 	// save previously added blocks. If new firstBlock failed to add, then return them back
@@ -78,6 +80,7 @@ func (a *innerBlocksApplier) apply(
 	if err != nil {
 		return 0, errors.Wrapf(err, "failed to rollback to height %d", parentHeight)
 	}
+	a.addReplacedChain(rollbackBlocks, parentHeight, currentScore)
 	// applying new blocks
 	_, err = storage.AddDeserializedBlocks(blocks)
 	if err != nil {
@@ -96,48 +99,72 @@ func (a *innerBlocksApplier) apply(
 func (a *innerBlocksApplier) getParentAndCurrentHeight(
 	storage innerState,
 	blocks []*proto.Block,
-) (proto.Height, proto.Height, error) {
+) (proto.Height, proto.Height, *big.Int, error) {
 	firstBlock := blocks[0]
 	// check first block if exists
 	_, err := storage.Block(firstBlock.BlockID())
 	if err == nil {
-		return 0, 0, proto.NewInfoMsg(errors.Errorf("first block %s exists", firstBlock.BlockID().String()))
+		return 0, 0, nil, proto.NewInfoMsg(errors.Errorf("first block %s exists", firstBlock.BlockID().String()))
 	}
 	if !stateerr.IsNotFound(err) {
-		return 0, 0, errors.Wrap(err, "unknown error")
+		return 0, 0, nil, errors.Wrap(err, "unknown error")
 	}
 	currentHeight, err := storage.Height()
 	if err != nil {
-		return 0, 0, err
+		return 0, 0, nil, err
 	}
 	// current score. Main idea is to find parent block, and check if score
 	// of all passed blocks higher than currentScore. If yes, we can add blocks
 	currentScore, err := storage.ScoreAtHeight(currentHeight)
 	if err != nil {
-		return 0, 0, err
+		return 0, 0, nil, err
+	}
+	// calculate score of all passed blocks
+	forkScore, err := calcMultipleScore(blocks)
+	if err != nil {
+		return 0, 0, nil, errors.Wrap(err, "failed calculate score of passed blocks")
 	}
 	// try to find parent. If not - we can't add blocks, skip it
 	parentHeight, err := storage.BlockIDToHeight(firstBlock.Parent)
 	if err != nil {
-		return 0, 0, proto.NewInfoMsg(errors.Wrapf(err, "failed get parent height, firstBlock id %s, for firstBlock %s",
+		// blocks may continue one of the known forks
+		a.forks.Extend(blocks, forkScore)
+		return 0, 0, nil, proto.NewInfoMsg(errors.Wrapf(err,
+			"failed get parent height, firstBlock id %s, for firstBlock %s",
 			firstBlock.Parent.String(), firstBlock.BlockID().String()))
 	}
-	// calculate score of all passed blocks
-	forkScore, err := calcMultipleScore(blocks)
-	if err != nil {
-		return 0, 0, errors.Wrap(err, "failed calculate score of passed blocks")
-	}
 	parentScore, err := storage.ScoreAtHeight(parentHeight)
 	if err != nil {
-		return 0, 0, errors.Wrapf(err, "failed get score at %d", parentHeight)
+		return 0, 0, nil, errors.Wrapf(err, "failed get score at %d", parentHeight)
 	}
 	cumulativeScore := forkScore.Add(forkScore, parentScore)
 	if currentScore.Cmp(cumulativeScore) >= 0 { // current score is higher or the same as fork score - do not apply blocks
-		return 0, 0, proto.NewInfoMsg(errors.Errorf(
+		if parentHeight < currentHeight {
+			a.forks.Add(forks.Fork{
+				Tip:          blocks[len(blocks)-1].BlockID(),
+				CommonHeight: parentHeight,
+				Length:       uint64(len(blocks)),
+				Score:        cumulativeScore,
+			})
+		}
+		return 0, 0, nil, proto.NewInfoMsg(errors.Errorf(
 			"low fork score: current blockchain score (%s) is higher than or equal to fork's score (%s)",
 			currentScore.String(), cumulativeScore.String()))
 	}
-	return currentHeight, parentHeight, nil
+	return currentHeight, parentHeight, currentScore, nil
+}
+
+// addReplacedChain records the blocks removed from the main chain by switching to a fork as a fork.
+func (a *innerBlocksApplier) addReplacedChain(removed []*proto.Block, commonHeight proto.Height, score *big.Int) {
+	if len(removed) == 0 {
+		return
+	}
+	a.forks.Add(forks.Fork{
+		Tip:          removed[len(removed)-1].BlockID(),
+		CommonHeight: commonHeight,
+		Length:       uint64(len(removed)),
+		Score:        score,
+	})
 }
 
 func (a *innerBlocksApplier) applyWithSnapshots(
@@ -148,7 +175,7 @@ func (a *innerBlocksApplier) applyWithSnapshots(
 	if len(blocks) == 0 {
 		return 0, errors.New("empty blocks")
 	}
-	currentHeight, parentHeight, err := a.getParentAndCurrentHeight(storage, blocks)
+	currentHeight, parentHeight, currentScore, err := a.getParentAndCurrentHeight(storage, blocks)
 	if err != nil {
 		return 0, err
 	}
@@ -165,9 +192,9 @@ func (a *innerBlocksApplier) applyWithSnapshots(
 	}
 
 	deltaHeight := currentHeight - parentHeight
-	if deltaHeight > maxRollbackDeltaHeight { // max number that we can rollback
+	if deltaHeight > MaxRollbackDeltaHeight { // max number that we can rollback
 		return 0, errors.Errorf(
-			"can't apply new blocks, rollback more than %d blocks, %d", maxRollbackDeltaHeight, deltaHeight)
+			"can't apply new blocks, rollback more than %d blocks, %d", MaxRollbackDeltaHeight, deltaHeight)
 	}
 
 	// save previously added blocks. If new firstBlock failed to add, then return them back
@@ -180,6 +207,7 @@ func (a *innerBlocksApplier) applyWithSnapshots(
 	if err != nil {
 		return 0, errors.Wrapf(err, "failed to rollback to height %d", parentHeight)
 	}
+	a.addReplacedChain(rollbackBlocks, parentHeight, currentScore)
 	// applying new blocks
 	_, err = storage.AddDeserializedBlocksWithSnapshots(blocks, snapshots)
 	if err != nil {
@@ -346,10 +374,15 @@ type BlocksApplier struct {
 
 func NewBlocksApplier() *BlocksApplier {
 	return &BlocksApplier{
-		inner: innerBlocksApplier{},
+		inner: innerBlocksApplier{forks: forks.NewRegistry(MaxRollbackDeltaHeight)},
 	}
 }
 
+// Forks returns the registry of competing chains seen while applying blocks.
+func (a *BlocksApplier) Forks() *forks.Registry {
+	return a.inner.forks
+}
+
 func (a *BlocksApplier) BlockExists(state state.State, block *proto.Block) (bool, error) {
 	return a.inner.exists(state, block)
 }
@@ -106,6 +106,18 @@ func (a *MockStateManager) WavesAddressesNumber() (uint64, error) {
 	panic("implement me")
 }
 
+func (a *MockStateManager) BalancesAtHeight(_ proto.Height, _ func(state.BalanceRecord) error) error {
+	panic("implement me")
+}
+
+func (a *MockStateManager) Richlist(_ proto.OptionalAsset, _ uint64) ([]state.BalanceRecord, error) {
+	panic("implement me")
+}
+
+func (a *MockStateManager) SearchAssets(_ string, _ uint64, _ *proto.AssetID) ([]crypto.Digest, error) {
+	panic("implement me")
+}
+
 func (a *MockStateManager) AddressesNumber(_ bool) (uint64, error) {
 	panic("implement me")
 }
@@ -173,6 +185,10 @@ func (a *MockStateManager) RetrieveEntry(_ proto.Recipient, _ string) (proto.Dat
 	panic("implement me")
 }
 
+func (a *MockStateManager) RetrieveEntryAtHeight(_ proto.Recipient, _ string, _ proto.Height) (proto.DataEntry, error) {
+	panic("implement me")
+}
+
 func (a *MockStateManager) RetrieveIntegerEntry(_ proto.Recipient, _ string) (*proto.IntegerDataEntry, error) {
 	panic("implement me")
 }
@@ -188,6 +204,10 @@ func (a *MockStateManager) RetrieveBinaryEntry(_ proto.Recipient, _ string) (*pr
 	panic("implement me")
 }
 
+func (a *MockStateManager) DataEntriesAtHeight(_ proto.Height, _ func(state.DataEntryRecord) error) error {
+	panic("implement me")
+}
+
 func (a *MockStateManager) TransactionHeightByID(_ []byte) (proto.Height, error) {
 	panic("implement me")
 }
@@ -208,6 +228,10 @@ func (a *MockStateManager) AssetInfo(_ crypto.Digest) (*proto.AssetInfo, error)
 	panic("implement me")
 }
 
+func (a *MockStateManager) SponsorshipStatus(_ proto.AssetID) (*proto.SponsorshipStatus, error) {
+	panic("implement me")
+}
+
 func (a *MockStateManager) FullAssetInfo(_ crypto.Digest) (*proto.FullAssetInfo, error) {
 	panic("implement me")
 }
@@ -224,6 +248,18 @@ func (a *MockStateManager) IsActiveLeasing(_ crypto.Digest) (bool, error) {
 	panic("implement me")
 }
 
+func (a *MockStateManager) ScriptHistoryByAccount(_ proto.Recipient) ([]proto.ScriptChange, error) {
+	panic("implement me")
+}
+
+func (a *MockStateManager) LeaseInfo(_ crypto.Digest) (*proto.LeaseDetails, error) {
+	panic("implement me")
+}
+
+func (a *MockStateManager) LeasesAtHeight(_ proto.Height, _ func(*proto.LeaseDetails) error) error {
+	panic("implement me")
+}
+
 func (a *MockStateManager) InvokeResultByID(_ crypto.Digest) (*proto.ScriptResult, error) {
 	panic("implement me")
 }
@@ -264,6 +300,18 @@ func (a *MockStateManager) FullWavesBalance(_ proto.Recipient) (*proto.FullWaves
 	panic("implement me")
 }
 
+func (a *MockStateManager) WavesBalanceHistory(_ proto.Recipient) ([]proto.BalanceAtHeight, error) {
+	panic("implement me")
+}
+
+func (a *MockStateManager) BalanceAtHeight(_ proto.Recipient, _ proto.OptionalAsset, _ proto.Height) (uint64, error) {
+	panic("implement me")
+}
+
+func (a *MockStateManager) EffectiveBalancesAtHeight(_ proto.Recipient, _ proto.Height) (*proto.EffectiveBalances, error) {
+	panic("implement me")
+}
+
 func (a *MockStateManager) AccountBalance(_ proto.Recipient, _ []byte) (uint64, error) {
 	panic("implement me")
 }
@@ -0,0 +1,98 @@
+package events
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// Bus delivers published events to subscribers. Publishing never blocks, events are dropped for subscribers
+// that don't keep up. All methods of Bus are safe to call on the nil value, which discards published events.
+type Bus struct {
+	mu   sync.RWMutex
+	subs map[*Subscription]struct{}
+}
+
+func NewBus() *Bus {
+	return &Bus{subs: make(map[*Subscription]struct{})}
+}
+
+// Subscribe returns a subscription with the buffer of the given size. If types are given, only events of the same
+// types are delivered, for example, Subscribe(10, BlockApplied{}, Rollback{}).
+func (b *Bus) Subscribe(size int, types ...Event) *Subscription {
+	s := &Subscription{bus: b, ch: make(chan Event, size)}
+	if len(types) > 0 {
+		s.types = make(map[reflect.Type]struct{}, len(types))
+		for _, t := range types {
+			s.types[reflect.TypeOf(t)] = struct{}{}
+		}
+	}
+	if b == nil {
+		return s
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subs[s] = struct{}{}
+	return s
+}
+
+// Publish delivers the event to all interested subscribers.
+func (b *Bus) Publish(e Event) {
+	if b == nil {
+		return
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for s := range b.subs {
+		s.deliver(e)
+	}
+}
+
+func (b *Bus) unsubscribe(s *Subscription) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.subs, s)
+}
+
+// Subscription receives events published on the Bus.
+type Subscription struct {
+	bus     *Bus
+	ch      chan Event
+	types   map[reflect.Type]struct{} // nil means all events
+	dropped atomic.Uint64
+	once    sync.Once
+}
+
+// Events returns the channel of events, the channel is closed by Close.
+func (s *Subscription) Events() <-chan Event {
+	return s.ch
+}
+
+// Dropped returns the number of events that were not delivered because the buffer was full.
+func (s *Subscription) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Close unsubscribes from the Bus and closes the events channel.
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		s.bus.unsubscribe(s)
+		close(s.ch)
+	})
+}
+
+func (s *Subscription) deliver(e Event) {
+	if s.types != nil {
+		if _, ok := s.types[reflect.TypeOf(e)]; !ok {
+			return
+		}
+	}
+	select {
+	case s.ch <- e:
+	default:
+		s.dropped.Add(1)
+	}
+}
@@ -0,0 +1,48 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+func TestBus(t *testing.T) {
+	bus := NewBus()
+	all := bus.Subscribe(10)
+	blocks := bus.Subscribe(10, BlockApplied{}, Rollback{})
+	small := bus.Subscribe(1)
+
+	bus.Publish(BlockApplied{Height: 1})
+	bus.Publish(TransactionAccepted{})
+	bus.Publish(Rollback{Height: 0})
+
+	require.Len(t, all.Events(), 3)
+	assert.Equal(t, BlockApplied{Height: 1}, <-all.Events())
+	assert.IsType(t, TransactionAccepted{}, <-all.Events())
+	assert.Equal(t, Rollback{Height: 0}, <-all.Events())
+
+	require.Len(t, blocks.Events(), 2)
+	assert.Equal(t, BlockApplied{Height: 1}, <-blocks.Events())
+	assert.Equal(t, Rollback{Height: 0}, <-blocks.Events())
+
+	assert.Len(t, small.Events(), 1)
+	assert.Equal(t, uint64(2), small.Dropped())
+
+	all.Close()
+	all.Close() // second close is a no-op
+	_, ok := <-all.Events()
+	assert.False(t, ok)
+	bus.Publish(BlockApplied{Height: 2})
+	assert.Len(t, blocks.Events(), 1)
+}
+
+func TestNilBus(t *testing.T) {
+	var bus *Bus
+	s := bus.Subscribe(1)
+	bus.Publish(BlockApplied{Height: proto.Height(1)})
+	assert.Empty(t, s.Events())
+	s.Close()
+}
@@ -0,0 +1,62 @@
+package events
+
+import (
+	"github.com/wavesplatform/gowaves/pkg/p2p/peer"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+// Event is a notification about the change of the node published on the Bus.
+type Event interface {
+	event()
+}
+
+// BlockApplied is published when a block is added to the state. Application of a micro block is reported as
+// Rollback of the previous version of the liquid block followed by BlockApplied of its new version.
+type BlockApplied struct {
+	BlockID proto.BlockID
+	Height  proto.Height
+}
+
+func (BlockApplied) event() {}
+
+// Rollback is published when the state is rolled back. BlockID and Height describe the new top block.
+type Rollback struct {
+	BlockID proto.BlockID
+	Height  proto.Height
+}
+
+func (Rollback) event() {}
+
+// TransactionAccepted is published when a transaction is accepted to the UTX pool.
+type TransactionAccepted struct {
+	Transaction proto.Transaction
+}
+
+func (TransactionAccepted) event() {}
+
+// WatchedTransaction is published when a transaction involving a watched address is included in a block.
+// Incoming and Outgoing tell whether the address receives funds with the transaction or is its sender.
+type WatchedTransaction struct {
+	Address     proto.WavesAddress
+	Transaction proto.Transaction
+	BlockID     proto.BlockID
+	Height      proto.Height
+	Incoming    bool
+	Outgoing    bool
+}
+
+func (WatchedTransaction) event() {}
+
+// PeerConnected is published when a connection with the peer is established.
+type PeerConnected struct {
+	Peer peer.Peer
+}
+
+func (PeerConnected) event() {}
+
+// PeerDisconnected is published when the peer is disconnected.
+type PeerDisconnected struct {
+	Peer peer.Peer
+}
+
+func (PeerDisconnected) event() {}
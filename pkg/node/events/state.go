@@ -0,0 +1,136 @@
+package events
+
+import (
+	"go.uber.org/zap"
+
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/state"
+)
+
+// notifyingState publishes BlockApplied and Rollback events on successful modifications of the wrapped state.
+type notifyingState struct {
+	state.State
+	bus *Bus
+}
+
+// NewNotifyingState wraps the state to publish events about applied blocks and rollbacks on the bus.
+func NewNotifyingState(s state.State, bus *Bus) state.State {
+	return &notifyingState{State: s, bus: bus}
+}
+
+func (s *notifyingState) AddBlock(block []byte) (*proto.Block, error) {
+	b, err := s.State.AddBlock(block)
+	if err != nil {
+		return nil, err
+	}
+	s.applied([]*proto.Block{b})
+	return b, nil
+}
+
+func (s *notifyingState) AddDeserializedBlock(block *proto.Block) (*proto.Block, error) {
+	b, err := s.State.AddDeserializedBlock(block)
+	if err != nil {
+		return nil, err
+	}
+	s.applied([]*proto.Block{block})
+	return b, nil
+}
+
+func (s *notifyingState) AddBlocks(blocks [][]byte) error {
+	if err := s.State.AddBlocks(blocks); err != nil {
+		return err
+	}
+	s.appliedRaw(len(blocks))
+	return nil
+}
+
+func (s *notifyingState) AddBlocksWithSnapshots(blocks [][]byte, snapshots []*proto.BlockSnapshot) error {
+	if err := s.State.AddBlocksWithSnapshots(blocks, snapshots); err != nil {
+		return err
+	}
+	s.appliedRaw(len(blocks))
+	return nil
+}
+
+func (s *notifyingState) AddDeserializedBlocks(blocks []*proto.Block) (*proto.Block, error) {
+	b, err := s.State.AddDeserializedBlocks(blocks)
+	if err != nil {
+		return nil, err
+	}
+	s.applied(blocks)
+	return b, nil
+}
+
+func (s *notifyingState) AddDeserializedBlocksWithSnapshots(
+	blocks []*proto.Block,
+	snapshots []*proto.BlockSnapshot,
+) (*proto.Block, error) {
+	b, err := s.State.AddDeserializedBlocksWithSnapshots(blocks, snapshots)
+	if err != nil {
+		return nil, err
+	}
+	s.applied(blocks)
+	return b, nil
+}
+
+func (s *notifyingState) RollbackToHeight(height proto.Height) error {
+	if err := s.State.RollbackToHeight(height); err != nil {
+		return err
+	}
+	s.rolledBack()
+	return nil
+}
+
+func (s *notifyingState) RollbackTo(removalEdge proto.BlockID) error {
+	if err := s.State.RollbackTo(removalEdge); err != nil {
+		return err
+	}
+	s.rolledBack()
+	return nil
+}
+
+// Map passes the wrapped non thread safe state to the function, so modifications made by it are reported too.
+func (s *notifyingState) Map(fn func(state.NonThreadSafeState) error) error {
+	return s.State.Map(func(inner state.NonThreadSafeState) error {
+		return fn(&notifyingState{State: inner, bus: s.bus})
+	})
+}
+
+// applied publishes events for the blocks that were added on top of the state.
+func (s *notifyingState) applied(blocks []*proto.Block) {
+	height, err := s.State.Height()
+	if err != nil {
+		zap.S().Warnf("Failed to get height to publish applied blocks: %v", err)
+		return
+	}
+	first := height - proto.Height(len(blocks)) + 1
+	for i, b := range blocks {
+		s.bus.Publish(BlockApplied{BlockID: b.BlockID(), Height: first + proto.Height(i)})
+	}
+}
+
+// appliedRaw publishes events for the given number of blocks on top of the state.
+func (s *notifyingState) appliedRaw(n int) {
+	height, err := s.State.Height()
+	if err != nil {
+		zap.S().Warnf("Failed to get height to publish applied blocks: %v", err)
+		return
+	}
+	for h := height - proto.Height(n) + 1; h <= height; h++ {
+		header, hErr := s.State.HeaderByHeight(h)
+		if hErr != nil {
+			zap.S().Warnf("Failed to get header at height %d to publish applied block: %v", h, hErr)
+			return
+		}
+		s.bus.Publish(BlockApplied{BlockID: header.BlockID(), Height: h})
+	}
+}
+
+func (s *notifyingState) rolledBack() {
+	height, err := s.State.Height()
+	if err != nil {
+		zap.S().Warnf("Failed to get height to publish rollback: %v", err)
+		return
+	}
+	s.bus.Publish(Rollback{BlockID: s.State.TopBlock().BlockID(), Height: height})
+}
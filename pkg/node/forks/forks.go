@@ -0,0 +1,111 @@
+// Package forks keeps track of the competing chains seen by the node.
+package forks
+
+import (
+	"cmp"
+	"math/big"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+const defaultLimit = 100
+
+// Fork is a chain competing with the main chain of the node.
+type Fork struct {
+	Tip          proto.BlockID
+	CommonHeight proto.Height // height of the last block shared with the main chain
+	Length       uint64       // number of blocks after the common block
+	Score        *big.Int     // cumulative score of the chain
+	LastSeen     time.Time
+}
+
+// Registry stores the forks that can still replace the main chain, i.e. the forks which common block is not deeper
+// than the rollback depth. Forks are kept in memory only and are lost on restart.
+// Nil Registry is valid and records nothing.
+type Registry struct {
+	mu    sync.Mutex
+	depth uint64
+	limit int
+	forks map[proto.BlockID]Fork
+}
+
+// NewRegistry creates the registry of forks with the given maximal rollback depth.
+func NewRegistry(depth uint64) *Registry {
+	return &Registry{depth: depth, limit: defaultLimit, forks: make(map[proto.BlockID]Fork)}
+}
+
+// Add records the fork, the oldest fork is forgotten if there are too many of them.
+func (r *Registry) Add(f Fork) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.add(f)
+}
+
+func (r *Registry) add(f Fork) {
+	if f.LastSeen.IsZero() {
+		f.LastSeen = time.Now()
+	}
+	r.forks[f.Tip] = f
+	for len(r.forks) > r.limit {
+		var oldest Fork
+		for _, c := range r.forks {
+			if oldest.LastSeen.IsZero() || c.LastSeen.Before(oldest.LastSeen) {
+				oldest = c
+			}
+		}
+		delete(r.forks, oldest.Tip)
+	}
+}
+
+// Extend moves the tip of the fork ending with the parent of the first block to the last of the blocks.
+// Blocks must be a continuous chain. Function returns false if there is no such fork.
+func (r *Registry) Extend(blocks []*proto.Block, score *big.Int) bool {
+	if r == nil || len(blocks) == 0 {
+		return false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	f, ok := r.forks[blocks[0].Parent]
+	if !ok {
+		return false
+	}
+	delete(r.forks, f.Tip)
+	r.add(Fork{
+		Tip:          blocks[len(blocks)-1].BlockID(),
+		CommonHeight: f.CommonHeight,
+		Length:       f.Length + uint64(len(blocks)),
+		Score:        new(big.Int).Add(f.Score, score),
+	})
+	return true
+}
+
+// List returns the forks which are not deeper than the rollback depth from the given height of the main chain
+// and which tips are not in the main chain, sorted by score in descending order. Other forks are forgotten.
+func (r *Registry) List(height proto.Height, inMainChain func(id proto.BlockID) bool) []Fork {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	res := make([]Fork, 0, len(r.forks))
+	for id, f := range r.forks {
+		if f.CommonHeight+r.depth < height || inMainChain(id) {
+			delete(r.forks, id)
+			continue
+		}
+		res = append(res, f)
+	}
+	slices.SortFunc(res, func(a, b Fork) int {
+		if c := b.Score.Cmp(a.Score); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.CommonHeight, b.CommonHeight)
+	})
+	return res
+}
@@ -0,0 +1,62 @@
+package forks
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+func testBlock(n byte, parent proto.BlockID) *proto.Block {
+	return &proto.Block{BlockHeader: proto.BlockHeader{Parent: parent, BlockSignature: crypto.Signature{n}}}
+}
+
+func notInMainChain(proto.BlockID) bool { return false }
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry(10)
+	b1 := testBlock(1, proto.BlockID{})
+	b2 := testBlock(2, b1.BlockID())
+	b3 := testBlock(3, b2.BlockID())
+	r.Add(Fork{Tip: b1.BlockID(), CommonHeight: 5, Length: 1, Score: big.NewInt(10)})
+	require.True(t, r.Extend([]*proto.Block{b2, b3}, big.NewInt(5)))
+	assert.False(t, r.Extend([]*proto.Block{testBlock(4, proto.NewBlockIDFromSignature(crypto.Signature{42}))}, big.NewInt(5)))
+	r.Add(Fork{Tip: proto.NewBlockIDFromSignature(crypto.Signature{5}), CommonHeight: 8, Length: 1,
+		Score: big.NewInt(20)})
+
+	forks := r.List(10, notInMainChain)
+	require.Len(t, forks, 2)
+	assert.Equal(t, int64(20), forks[0].Score.Int64())
+	assert.Equal(t, b3.BlockID(), forks[1].Tip)
+	assert.Equal(t, proto.Height(5), forks[1].CommonHeight)
+	assert.Equal(t, uint64(3), forks[1].Length)
+	assert.Equal(t, int64(15), forks[1].Score.Int64())
+
+	// forks deeper than rollback depth and the ones that became the main chain are forgotten
+	forks = r.List(16, func(id proto.BlockID) bool { return id == b3.BlockID() })
+	require.Len(t, forks, 1)
+	forks = r.List(19, notInMainChain)
+	assert.Empty(t, forks)
+}
+
+func TestRegistryLimit(t *testing.T) {
+	r := NewRegistry(10)
+	r.limit = 2
+	now := time.Now()
+	for i := range 3 {
+		r.Add(Fork{Tip: proto.NewBlockIDFromSignature(crypto.Signature{byte(i)}), Score: big.NewInt(int64(i)),
+			LastSeen: now.Add(time.Duration(i) * time.Second)})
+	}
+	forks := r.List(0, notInMainChain)
+	require.Len(t, forks, 2)
+	assert.Equal(t, int64(2), forks[0].Score.Int64())
+	assert.Equal(t, int64(1), forks[1].Score.Int64())
+	var nilRegistry *Registry
+	nilRegistry.Add(Fork{})
+	assert.Nil(t, nilRegistry.List(0, notInMainChain))
+}
@@ -0,0 +1,125 @@
+package forks
+
+import (
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/wavesplatform/gowaves/pkg/metrics"
+)
+
+const metricsNamespace = "forks"
+
+var (
+	metricMicroForkRollbacks = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "micro_fork_rollbacks",
+			Help:      "Counter of rollbacks of the liquid block to one of its previous micro-blocks.",
+		},
+	)
+	metricKeyBlockSwitches = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "key_block_switches",
+			Help:      "Counter of replacements of the top key-block with a competing one.",
+		},
+	)
+	metricDiscardedMicroBlocks = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "discarded_micro_blocks",
+			Help:      "Counter of received micro-blocks that were not applied.",
+		},
+		[]string{"reason"},
+	)
+	metricSwitchDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "switch_duration_seconds",
+			Help:      "Duration of switching the node to another micro-fork or key-block.",
+		},
+		[]string{"kind"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		metricMicroForkRollbacks,
+		metricKeyBlockSwitches,
+		metricDiscardedMicroBlocks,
+		metricSwitchDuration,
+	)
+}
+
+// Reasons of discarding a micro-block.
+const (
+	DiscardReasonReference = "reference" // micro-block doesn't refer to the top block
+	DiscardReasonInvalid   = "invalid"   // micro-block can't be applied
+)
+
+// SwitchStats describes the switches of one kind.
+type SwitchStats struct {
+	Count         uint64        `json:"count"`
+	TotalDuration time.Duration `json:"totalDurationNs"`
+	LastDuration  time.Duration `json:"lastDurationNs"`
+	LastTime      time.Time     `json:"lastTime"`
+}
+
+func (s *SwitchStats) observe(d time.Duration) {
+	s.Count++
+	s.TotalDuration += d
+	s.LastDuration = d
+	s.LastTime = time.Now()
+}
+
+// Stats are the counters of chain switches since the node's start.
+type Stats struct {
+	MicroForkRollbacks   SwitchStats       `json:"microForkRollbacks"`
+	KeyBlockSwitches     SwitchStats       `json:"keyBlockSwitches"`
+	DiscardedMicroBlocks map[string]uint64 `json:"discardedMicroBlocks"`
+}
+
+var (
+	statsMu sync.Mutex
+	stats   = Stats{DiscardedMicroBlocks: make(map[string]uint64)}
+)
+
+// ObserveMicroForkRollback records the rollback of the liquid block to one of its micro-blocks.
+func ObserveMicroForkRollback(d time.Duration) {
+	metricMicroForkRollbacks.Inc()
+	metricSwitchDuration.WithLabelValues("micro_fork").Observe(d.Seconds())
+	metrics.ForkSwitch("micro_fork", d)
+	statsMu.Lock()
+	defer statsMu.Unlock()
+	stats.MicroForkRollbacks.observe(d)
+}
+
+// ObserveKeyBlockSwitch records the replacement of the top key-block with a competing one.
+func ObserveKeyBlockSwitch(d time.Duration) {
+	metricKeyBlockSwitches.Inc()
+	metricSwitchDuration.WithLabelValues("key_block").Observe(d.Seconds())
+	metrics.ForkSwitch("key_block", d)
+	statsMu.Lock()
+	defer statsMu.Unlock()
+	stats.KeyBlockSwitches.observe(d)
+}
+
+// ObserveDiscardedMicroBlock records the micro-block that was not applied for the given reason.
+func ObserveDiscardedMicroBlock(reason string) {
+	metricDiscardedMicroBlocks.WithLabelValues(reason).Inc()
+	statsMu.Lock()
+	defer statsMu.Unlock()
+	stats.DiscardedMicroBlocks[reason]++
+}
+
+// CurrentStats returns a copy of the collected statistics.
+func CurrentStats() Stats {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+	res := stats
+	res.DiscardedMicroBlocks = maps.Clone(stats.DiscardedMicroBlocks)
+	return res
+}
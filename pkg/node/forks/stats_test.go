@@ -0,0 +1,28 @@
+package forks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStats(t *testing.T) {
+	before := CurrentStats()
+	ObserveMicroForkRollback(time.Second)
+	ObserveKeyBlockSwitch(2 * time.Second)
+	ObserveKeyBlockSwitch(3 * time.Second)
+	ObserveDiscardedMicroBlock(DiscardReasonReference)
+	after := CurrentStats()
+
+	assert.Equal(t, before.MicroForkRollbacks.Count+1, after.MicroForkRollbacks.Count)
+	assert.Equal(t, before.KeyBlockSwitches.Count+2, after.KeyBlockSwitches.Count)
+	assert.Equal(t, before.KeyBlockSwitches.TotalDuration+5*time.Second, after.KeyBlockSwitches.TotalDuration)
+	assert.Equal(t, 3*time.Second, after.KeyBlockSwitches.LastDuration)
+	assert.Equal(t, before.DiscardedMicroBlocks[DiscardReasonReference]+1,
+		after.DiscardedMicroBlocks[DiscardReasonReference])
+
+	after.DiscardedMicroBlocks[DiscardReasonInvalid] = 100 // returned stats is a copy
+	assert.Equal(t, before.DiscardedMicroBlocks[DiscardReasonInvalid],
+		CurrentStats().DiscardedMicroBlocks[DiscardReasonInvalid])
+}
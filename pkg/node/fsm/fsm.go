@@ -10,6 +10,7 @@ import (
 	"github.com/wavesplatform/gowaves/pkg/libs/microblock_cache"
 	"github.com/wavesplatform/gowaves/pkg/miner"
 	"github.com/wavesplatform/gowaves/pkg/miner/utxpool"
+	"github.com/wavesplatform/gowaves/pkg/node/events"
 	"github.com/wavesplatform/gowaves/pkg/node/fsm/ng"
 	"github.com/wavesplatform/gowaves/pkg/node/fsm/tasks"
 	"github.com/wavesplatform/gowaves/pkg/node/messages"
@@ -81,6 +82,8 @@ type BaseInfo struct {
 	syncPeer *network.SyncPeer
 
 	enableLightMode bool
+
+	events *events.Bus
 }
 
 func (a *BaseInfo) BroadcastTransaction(t proto.Transaction, receivedFrom peer.Peer) {
@@ -182,6 +185,8 @@ func NewFSM(
 		skipMessageList: services.SkipMessageList,
 		syncPeer:        syncPeer,
 		enableLightMode: enableLightMode,
+
+		events: services.Events,
 	}
 
 	info.scheduler.Reschedule()
@@ -13,6 +13,8 @@ import (
 
 	"github.com/wavesplatform/gowaves/pkg/libs/signatures"
 	"github.com/wavesplatform/gowaves/pkg/logging"
+	"github.com/wavesplatform/gowaves/pkg/metrics"
+	"github.com/wavesplatform/gowaves/pkg/node/events"
 	"github.com/wavesplatform/gowaves/pkg/node/fsm/sync_internal"
 	"github.com/wavesplatform/gowaves/pkg/node/fsm/tasks"
 	"github.com/wavesplatform/gowaves/pkg/p2p/peer"
@@ -24,6 +26,9 @@ import (
 const (
 	askPeersInterval   = 5 * time.Minute
 	defaultSyncTimeout = 30 * time.Second
+
+	blockRequestTimeout = 10 * time.Second
+	maxBlocksSources    = 4
 )
 
 // Set args types for events.
@@ -87,14 +92,22 @@ func syncWithNewPeer(state State, baseInfo BaseInfo, p peer.Peer) (State, Async,
 	if err != nil {
 		return state, nil, err
 	}
+	downloader := sync_internal.NewDownloader(
+		blocksSources(baseInfo, p),
+		blockRequestTimeout,
+		baseInfo.tm,
+		baseInfo.enableLightMode,
+		baseInfo.peers,
+	)
 	internal := sync_internal.InternalFromLastSignatures(
-		extension.NewPeerExtension(p, baseInfo.scheme),
+		downloader,
 		lastSignatures,
 		baseInfo.enableLightMode,
 	)
 	c := conf{
 		peerSyncWith: p,
 		timeout:      defaultSyncTimeout,
+		downloader:   downloader,
 	}
 	zap.S().Named(logging.FSMNamespace).Debugf("[%s] Starting synchronization with peer '%s'",
 		state.String(), p.ID())
@@ -106,6 +119,26 @@ func syncWithNewPeer(state State, baseInfo BaseInfo, p peer.Peer) (State, Async,
 	}, nil, nil
 }
 
+// blocksSources returns the sync peer followed by the other connected peers with the same or higher score
+// to download blocks from in parallel.
+func blocksSources(baseInfo BaseInfo, p peer.Peer) []sync_internal.Source {
+	sources := []sync_internal.Source{{ID: p.ID().String(), Extension: extension.NewPeerExtension(p, baseInfo.scheme)}}
+	syncScore, err := baseInfo.peers.Score(p)
+	if err != nil {
+		return sources
+	}
+	baseInfo.peers.EachConnected(func(cp peer.Peer, score *proto.Score) {
+		if len(sources) >= maxBlocksSources || cp.Equal(p) || score == nil || score.Cmp(syncScore) < 0 {
+			return
+		}
+		sources = append(sources, sync_internal.Source{
+			ID:        cp.ID().String(),
+			Extension: extension.NewPeerExtension(cp, baseInfo.scheme),
+		})
+	})
+	return sources
+}
+
 func tryBroadcastTransaction(
 	fsm State, baseInfo BaseInfo, p peer.Peer, t proto.Transaction,
 ) (_ State, _ Async, err error) {
@@ -151,16 +184,20 @@ func tryBroadcastTransaction(
 		err = errors.Wrap(err, "failed to add transaction to utx")
 		return fsm, nil, err
 	}
+	metrics.UtxTransactionAccepted()
+	baseInfo.events.Publish(events.TransactionAccepted{Transaction: t})
 	baseInfo.BroadcastTransaction(t, p)
 	return fsm, nil, nil
 }
 
+// fsmErrorf prefixes the error with the name of the state. The original error is kept in the chain, so
+// the reason of rejection, e.g. of the broadcasted transaction, can be examined by the caller.
 func fsmErrorf(state State, err error) error {
 	infoMsg := &proto.InfoMsg{}
 	if errors.As(err, &infoMsg) {
-		return proto.NewInfoMsg(errors.Errorf("[%s] %s", state.String(), err.Error()))
+		return proto.NewInfoMsg(fmt.Errorf("[%s] %w", state.String(), err))
 	}
-	return errors.Errorf("[%s] %s", state.String(), err.Error())
+	return fmt.Errorf("[%s] %w", state.String(), err)
 }
 
 func createPermitDynamicCallback(
@@ -26,6 +26,9 @@ func (a *HaltState) Errorf(err error) error {
 
 func newHaltState(info BaseInfo) (State, Async, error) {
 	zap.S().Named(logging.FSMNamespace).Debugf("[Halt] Entered the Halt state")
+	if cnt := info.utx.Count(); cnt > 0 {
+		zap.S().Named(logging.FSMNamespace).Infof("[Halt] %d unconfirmed transactions are dropped from UTX pool", cnt)
+	}
 	var errs []error
 	if err := info.peers.Close(); err != nil {
 		errs = append(errs, errors.Wrap(err, "failed to close peers"))
@@ -2,6 +2,7 @@ package fsm
 
 import (
 	"context"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/qmuntal/stateless"
@@ -10,6 +11,7 @@ import (
 	"github.com/wavesplatform/gowaves/pkg/logging"
 	"github.com/wavesplatform/gowaves/pkg/metrics"
 	"github.com/wavesplatform/gowaves/pkg/miner"
+	"github.com/wavesplatform/gowaves/pkg/node/forks"
 	"github.com/wavesplatform/gowaves/pkg/node/fsm/tasks"
 	"github.com/wavesplatform/gowaves/pkg/p2p/peer"
 	"github.com/wavesplatform/gowaves/pkg/p2p/peer/extension"
@@ -160,19 +162,22 @@ func (a *NGState) Block(peer peer.Peer, block *proto.Block) (State, Async, error
 			"[%s] Key-block '%s' has parent '%s' which is not the top block '%s'",
 			a, block.ID.String(), block.Parent.String(), top.ID.String(),
 		)
+		start := time.Now()
+		blockFromCache, inCache := a.blocksCache.Get(block.Parent)
 		if a.baseInfo.enableLightMode {
 			if err = a.rollbackToStateFromCacheInLightNode(block.Parent); err != nil {
 				return a, nil, a.Errorf(err)
 			}
-		} else {
-			if blockFromCache, okGet := a.blocksCache.Get(block.Parent); okGet {
-				zap.S().Named(logging.FSMNamespace).Debugf("[%s] Re-applying block '%s' from cache",
-					a, blockFromCache.ID.String())
-				if err = a.rollbackToStateFromCache(blockFromCache); err != nil {
-					return a, nil, a.Errorf(err)
-				}
+		} else if inCache {
+			zap.S().Named(logging.FSMNamespace).Debugf("[%s] Re-applying block '%s' from cache",
+				a, blockFromCache.ID.String())
+			if err = a.rollbackToStateFromCache(blockFromCache); err != nil {
+				return a, nil, a.Errorf(err)
 			}
 		}
+		if inCache {
+			forks.ObserveMicroForkRollback(time.Since(start))
+		}
 	}
 
 	if a.baseInfo.enableLightMode {
@@ -183,6 +188,8 @@ func (a *NGState) Block(peer peer.Peer, block *proto.Block) (State, Async, error
 		st, timeoutTask := newWaitSnapshotState(a.baseInfo, block, a.blocksCache)
 		return st, tasks.Tasks(timeoutTask), nil
 	}
+	switching := a.baseInfo.storage.TopBlock().BlockID() != block.Parent
+	start := time.Now()
 	_, err = a.baseInfo.blocksApplier.Apply(
 		a.baseInfo.storage,
 		[]*proto.Block{block},
@@ -190,6 +197,9 @@ func (a *NGState) Block(peer peer.Peer, block *proto.Block) (State, Async, error
 	if err != nil {
 		return a, nil, a.Errorf(errors.Wrapf(err, "failed to apply block %s", block.BlockID()))
 	}
+	if switching {
+		forks.ObserveKeyBlockSwitch(time.Since(start))
+	}
 	a.blocksCache.Clear()
 	a.blocksCache.AddBlockState(block)
 	a.baseInfo.scheduler.Reschedule()
@@ -336,6 +346,7 @@ func (a *NGState) checkAndAppendMicroBlock(
 		err := errors.Errorf("microblock TBID '%s' refer to block ID '%s' but last block ID is '%s'",
 			micro.TotalBlockID.String(), micro.Reference.String(), top.BlockID().String())
 		metrics.FSMMicroBlockDeclined("ng", micro, err)
+		forks.ObserveDiscardedMicroBlock(forks.DiscardReasonReference)
 		return &proto.Block{}, proto.NewInfoMsg(err)
 	}
 	ok, err := micro.VerifySignature(a.baseInfo.scheme)
@@ -370,6 +381,7 @@ func (a *NGState) checkAndAppendMicroBlock(
 
 	if err != nil {
 		metrics.FSMMicroBlockDeclined("ng", micro, err)
+		forks.ObserveDiscardedMicroBlock(forks.DiscardReasonInvalid)
 		return nil, errors.Wrap(err, "failed to apply created from micro block")
 	}
 	metrics.FSMMicroBlockApplied("ng", micro)
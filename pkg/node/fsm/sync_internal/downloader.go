@@ -0,0 +1,157 @@
+package sync_internal
+
+import (
+	"time"
+
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/types"
+)
+
+// Source is a peer that blocks can be downloaded from.
+type Source struct {
+	ID        string
+	Extension PeerExtension
+}
+
+// DeliveryObserver is notified about the time sources take to deliver blocks and about requests they failed
+// to answer in time.
+type DeliveryObserver interface {
+	BlockDelivered(source string, rtt time.Duration)
+	BlockStalled(source string, waited time.Duration)
+}
+
+type pendingBlock struct {
+	source      string
+	requestedAt time.Time
+	block       bool // block is received
+	snapshot    bool // snapshot is received
+}
+
+// Downloader distributes requests of blocks between several peers. Block IDs are always requested from the first
+// source which is the peer the node synchronizes with. Requests that are not answered in time are reassigned to
+// other sources, and the sources that failed to answer are excluded from the following distribution, except for
+// the sync peer.
+// Downloader implements PeerExtension, so it can be used in place of the single peer.
+type Downloader struct {
+	sources     []Source
+	next        int
+	pending     map[proto.BlockID]*pendingBlock
+	timeout     time.Duration
+	tm          types.Time
+	isLightNode bool
+	observer    DeliveryObserver
+}
+
+// NewDownloader creates Downloader, the observer is optional.
+func NewDownloader(
+	sources []Source, timeout time.Duration, tm types.Time, isLightNode bool, observer DeliveryObserver,
+) *Downloader {
+	return &Downloader{
+		sources:     sources,
+		pending:     make(map[proto.BlockID]*pendingBlock),
+		timeout:     timeout,
+		tm:          tm,
+		isLightNode: isLightNode,
+		observer:    observer,
+	}
+}
+
+// Serves checks that the peer with given ID is one of the sources.
+func (d *Downloader) Serves(id string) bool {
+	return d.source(id) >= 0
+}
+
+func (d *Downloader) source(id string) int {
+	for i := range d.sources {
+		if d.sources[i].ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (d *Downloader) AskBlocksIDs(ids []proto.BlockID) {
+	d.sources[0].Extension.AskBlocksIDs(ids)
+}
+
+func (d *Downloader) AskBlock(id proto.BlockID) {
+	s := d.sources[d.next%len(d.sources)]
+	d.next++
+	d.pending[id] = &pendingBlock{source: s.ID, requestedAt: d.tm.Now()}
+	s.Extension.AskBlock(id)
+}
+
+// AskBlockSnapshot requests the snapshot from the same source as the block.
+func (d *Downloader) AskBlockSnapshot(id proto.BlockID) {
+	i := 0
+	if p, ok := d.pending[id]; ok {
+		i = max(d.source(p.source), 0)
+	}
+	d.sources[i].Extension.AskBlockSnapshot(id)
+}
+
+// BlockReceived marks the block as received.
+func (d *Downloader) BlockReceived(id proto.BlockID) {
+	if p, ok := d.pending[id]; ok {
+		if !p.block && d.observer != nil {
+			d.observer.BlockDelivered(p.source, d.tm.Now().Sub(p.requestedAt))
+		}
+		p.block = true
+		d.done(id, p)
+	}
+}
+
+// SnapshotReceived marks the block snapshot as received.
+func (d *Downloader) SnapshotReceived(id proto.BlockID) {
+	if p, ok := d.pending[id]; ok {
+		p.snapshot = true
+		d.done(id, p)
+	}
+}
+
+func (d *Downloader) done(id proto.BlockID, p *pendingBlock) {
+	if p.block && (p.snapshot || !d.isLightNode) {
+		delete(d.pending, id)
+	}
+}
+
+// PendingCount returns the number of requested but not yet received blocks.
+func (d *Downloader) PendingCount() int {
+	return len(d.pending)
+}
+
+// Reassign requests again the blocks which were not received in time from the other sources and returns
+// the number of reassigned requests.
+func (d *Downloader) Reassign() int {
+	now := d.tm.Now()
+	n := 0
+	for id, p := range d.pending {
+		waited := now.Sub(p.requestedAt)
+		if waited < d.timeout {
+			continue
+		}
+		if d.observer != nil {
+			d.observer.BlockStalled(p.source, waited)
+		}
+		d.exclude(p.source)
+		s := d.sources[d.next%len(d.sources)]
+		d.next++
+		p.source = s.ID
+		p.requestedAt = now
+		if !p.block {
+			s.Extension.AskBlock(id)
+		}
+		if d.isLightNode && !p.snapshot {
+			s.Extension.AskBlockSnapshot(id)
+		}
+		n++
+	}
+	return n
+}
+
+// exclude removes the stalled source from the distribution, the sync peer is never removed.
+func (d *Downloader) exclude(id string) {
+	if i := d.source(id); i > 0 {
+		d.sources = append(d.sources[:i], d.sources[i+1:]...)
+	}
+}
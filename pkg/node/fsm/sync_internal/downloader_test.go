@@ -0,0 +1,112 @@
+package sync_internal_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/wavesplatform/gowaves/pkg/node/fsm/sync_internal"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+type manualTime struct {
+	now time.Time
+}
+
+func (t *manualTime) Now() time.Time {
+	return t.now
+}
+
+type recordingPeer struct {
+	ids       int
+	blocks    []proto.BlockID
+	snapshots []proto.BlockID
+}
+
+func (p *recordingPeer) AskBlocksIDs(_ []proto.BlockID) {
+	p.ids++
+}
+
+func (p *recordingPeer) AskBlock(id proto.BlockID) {
+	p.blocks = append(p.blocks, id)
+}
+
+func (p *recordingPeer) AskBlockSnapshot(id proto.BlockID) {
+	p.snapshots = append(p.snapshots, id)
+}
+
+type recordingObserver struct {
+	delivered map[string][]time.Duration
+	stalled   map[string][]time.Duration
+}
+
+func newRecordingObserver() *recordingObserver {
+	return &recordingObserver{delivered: make(map[string][]time.Duration), stalled: make(map[string][]time.Duration)}
+}
+
+func (o *recordingObserver) BlockDelivered(source string, rtt time.Duration) {
+	o.delivered[source] = append(o.delivered[source], rtt)
+}
+
+func (o *recordingObserver) BlockStalled(source string, waited time.Duration) {
+	o.stalled[source] = append(o.stalled[source], waited)
+}
+
+func TestDownloader(t *testing.T) {
+	tm := &manualTime{now: time.Now()}
+	syncPeer, helper := &recordingPeer{}, &recordingPeer{}
+	observer := newRecordingObserver()
+	d := NewDownloader([]Source{{ID: "sync", Extension: syncPeer}, {ID: "helper", Extension: helper}},
+		time.Second, tm, false, observer)
+	assert.True(t, d.Serves("helper"))
+	assert.False(t, d.Serves("other"))
+
+	ids := blocksFromSigs(sig1, sig2, sig3)
+	d.AskBlocksIDs(ids)
+	assert.Equal(t, 1, syncPeer.ids)
+	assert.Zero(t, helper.ids)
+	for _, id := range ids {
+		d.AskBlock(id)
+	}
+	assert.Equal(t, []proto.BlockID{ids[0], ids[2]}, syncPeer.blocks)
+	assert.Equal(t, []proto.BlockID{ids[1]}, helper.blocks)
+	assert.Equal(t, 3, d.PendingCount())
+
+	tm.now = tm.now.Add(100 * time.Millisecond)
+	d.BlockReceived(ids[0])
+	d.BlockReceived(ids[2])
+	assert.Zero(t, d.Reassign()) // not timed out yet
+	assert.Equal(t, []time.Duration{100 * time.Millisecond, 100 * time.Millisecond}, observer.delivered["sync"])
+
+	tm.now = tm.now.Add(2 * time.Second)
+	require.Equal(t, 1, d.Reassign())
+	assert.Equal(t, []proto.BlockID{ids[0], ids[2], ids[1]}, syncPeer.blocks)
+	assert.False(t, d.Serves("helper"), "stalled helper must be excluded")
+	assert.Equal(t, []time.Duration{2100 * time.Millisecond}, observer.stalled["helper"])
+	d.BlockReceived(ids[1])
+	assert.Zero(t, d.PendingCount())
+}
+
+func TestDownloaderLightNode(t *testing.T) {
+	tm := &manualTime{now: time.Now()}
+	syncPeer, helper := &recordingPeer{}, &recordingPeer{}
+	d := NewDownloader([]Source{{ID: "sync", Extension: syncPeer}, {ID: "helper", Extension: helper}},
+		time.Second, tm, true, nil)
+	ids := blocksFromSigs(sig1, sig2)
+	for _, id := range ids {
+		d.AskBlock(id)
+		d.AskBlockSnapshot(id)
+	}
+	assert.Equal(t, []proto.BlockID{ids[0]}, syncPeer.snapshots)
+	assert.Equal(t, []proto.BlockID{ids[1]}, helper.snapshots)
+
+	d.BlockReceived(ids[1])
+	assert.Equal(t, 2, d.PendingCount()) // snapshot is still expected
+	tm.now = tm.now.Add(2 * time.Second)
+	d.SnapshotReceived(ids[0])
+	require.Equal(t, 2, d.Reassign())
+	assert.Equal(t, []proto.BlockID{ids[1]}, syncPeer.snapshots[1:], "only missing parts are requested again")
+	assert.Equal(t, []proto.BlockID{ids[0]}, syncPeer.blocks[1:])
+}
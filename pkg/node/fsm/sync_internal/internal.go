@@ -5,7 +5,6 @@ import (
 
 	"github.com/wavesplatform/gowaves/pkg/libs/ordered_blocks"
 	"github.com/wavesplatform/gowaves/pkg/libs/signatures"
-	"github.com/wavesplatform/gowaves/pkg/p2p/peer/extension"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 )
 
@@ -16,6 +15,7 @@ type BlockApplied bool
 
 var NoSignaturesExpectedErr = proto.NewInfoMsg(errors.New("no signatures expected"))
 var UnexpectedBlockErr = proto.NewInfoMsg(errors.New("unexpected block"))
+var InvalidBlockIDsErr = proto.NewInfoMsg(errors.New("invalid sequence of block IDs"))
 
 type PeerExtension interface {
 	AskBlocksIDs(id []proto.BlockID)
@@ -31,7 +31,7 @@ type Internal struct {
 }
 
 func InternalFromLastSignatures(
-	p extension.PeerExtension,
+	p peerExtension,
 	signatures *signatures.ReverseOrdering,
 	isLightNode bool,
 ) Internal {
@@ -57,6 +57,9 @@ func (a Internal) BlockIDs(p PeerExtension, ids []proto.BlockID) (Internal, erro
 	if !a.waitingForSignatures {
 		return a, NoSignaturesExpectedErr
 	}
+	if !a.validBlockIDs(ids) {
+		return a, InvalidBlockIDsErr
+	}
 	var newIDs []proto.BlockID
 	for _, id := range ids {
 		if a.respondedSignatures.Exists(id) {
@@ -74,6 +77,33 @@ func (a Internal) BlockIDs(p PeerExtension, ids []proto.BlockID) (Internal, erro
 	return NewInternal(a.orderedBlocks, respondedSignatures, false, a.isLightNode), nil
 }
 
+// validBlockIDs checks the received chain of IDs before any block is requested. Chain must start from one of the
+// IDs sent to the peer, if any were sent, and the new IDs must follow the known ones without repetitions.
+func (a Internal) validBlockIDs(ids []proto.BlockID) bool {
+	if len(ids) == 0 {
+		return true
+	}
+	if a.respondedSignatures.Len() > 0 && !a.respondedSignatures.Exists(ids[0]) {
+		return false
+	}
+	seen := make(map[proto.BlockID]struct{}, len(ids))
+	known := true
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			return false
+		}
+		seen[id] = struct{}{}
+		if a.respondedSignatures.Exists(id) {
+			if !known {
+				return false
+			}
+			continue
+		}
+		known = false
+	}
+	return true
+}
+
 func (a Internal) WaitingForSignatures() bool {
 	return a.waitingForSignatures
 }
@@ -85,3 +85,29 @@ func TestSigFSM_BlockGetSignatures(t *testing.T) {
 	_, bs, _, _ := NewInternal(or, sigs, false, false).Blocks()
 	require.Nil(t, bs)
 }
+
+var sig3 = crypto.MustSignatureFromBase58("4bFStXwxHhDmnuEMAm4FjHNLbiRVXVcN3fkrYnkyCtJmMWdYYRWkMCJdMPcd5TuR71iJcE7M5NPMrJ7PknMe1KMU")
+
+func TestSigFSM_InvalidBlockIDs(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		sent []proto.BlockID
+		ids  []proto.BlockID
+	}{
+		{"not starting from sent IDs", blocksFromSigs(sig1), blocksFromSigs(sig2, sig3)},
+		{"duplicated IDs", blocksFromSigs(sig1), blocksFromSigs(sig1, sig2, sig2)},
+		{"known ID after new one", blocksFromSigs(sig1, sig3), blocksFromSigs(sig1, sig2, sig3)},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			fsm := NewInternal(ordered_blocks.NewOrderedBlocks(), signatures.NewSignatures(test.sent...), true, false)
+			rs, err := fsm.BlockIDs(noopWrapper{}, test.ids)
+			require.Equal(t, InvalidBlockIDsErr, err)
+			require.True(t, rs.WaitingForSignatures())
+			require.Zero(t, rs.RequestedCount())
+		})
+	}
+	fsm := NewInternal(ordered_blocks.NewOrderedBlocks(), signatures.NewSignatures(blocksFromSigs(sig1)...), true, false)
+	rs, err := fsm.BlockIDs(noopWrapper{}, blocksFromSigs(sig1, sig2, sig3))
+	require.NoError(t, err)
+	require.Equal(t, 2, rs.RequestedCount())
+}
@@ -14,7 +14,6 @@ import (
 	"github.com/wavesplatform/gowaves/pkg/node/fsm/sync_internal"
 	"github.com/wavesplatform/gowaves/pkg/node/fsm/tasks"
 	"github.com/wavesplatform/gowaves/pkg/p2p/peer"
-	"github.com/wavesplatform/gowaves/pkg/p2p/peer/extension"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 	"github.com/wavesplatform/gowaves/pkg/state"
 	"github.com/wavesplatform/gowaves/pkg/types"
@@ -28,6 +27,8 @@ type conf struct {
 	lastReceiveTime time.Time
 
 	timeout time.Duration
+	// downloader distributes requests of blocks between the sync peer and its helpers
+	downloader *sync_internal.Downloader
 }
 
 func (c conf) Now(tm types.Time) conf {
@@ -35,6 +36,7 @@ func (c conf) Now(tm types.Time) conf {
 		peerSyncWith:    c.peerSyncWith,
 		lastReceiveTime: tm.Now(),
 		timeout:         c.timeout,
+		downloader:      c.downloader,
 	}
 }
 
@@ -106,6 +108,9 @@ func (a *SyncState) Task(task tasks.AsyncTask) (State, Async, error) {
 				a.conf.timeout.String(), a.conf.peerSyncWith.ID())
 			return newIdleState(a.baseInfo), nil, a.Errorf(TimeoutErr)
 		}
+		if n := a.conf.downloader.Reassign(); n > 0 {
+			zap.S().Named(logging.FSMNamespace).Debugf("[Sync] Requests of %d stalled blocks were reassigned", n)
+		}
 		return a, nil, nil
 	case tasks.MineMicro:
 		return a, nil, nil
@@ -132,8 +137,23 @@ func (a *SyncState) BlockIDs(peer peer.Peer, signatures []proto.BlockID) (State,
 			peer.ID().String(), a.baseInfo.syncPeer.GetPeer().ID().String())
 		return a, nil, nil
 	}
-	internal, err := a.internal.BlockIDs(extension.NewPeerExtension(peer, a.baseInfo.scheme), signatures)
+	if a.internal.WaitingForSignatures() && !a.peerHasBetterChain(peer) {
+		// The chain of the peer is not better than ours, there is no reason to download its blocks.
+		if np, ok := a.changePeerIfRequired(); ok {
+			zap.S().Named(logging.FSMNamespace).Debugf("[Sync] Changing sync peer to '%s'", np.ID().String())
+			return syncWithNewPeer(a, a.baseInfo, np)
+		}
+		zap.S().Named(logging.FSMNamespace).Debugf("[Sync] Peer '%s' has no better chain, skipping its blocks",
+			peer.ID().String())
+		return a.switchToNG()
+	}
+	internal, err := a.internal.BlockIDs(a.conf.downloader, signatures)
 	if err != nil {
+		if errors.Is(err, sync_internal.InvalidBlockIDsErr) {
+			zap.S().Named(logging.FSMNamespace).Debugf("[Sync] Invalid chain of block IDs received from peer '%s'",
+				peer.ID().String())
+			return newIdleState(a.baseInfo), nil, a.Errorf(err)
+		}
 		zap.S().Named(logging.FSMNamespace).Debugf("[Sync] No signatures expected from peer '%s' but received",
 			peer.ID().String())
 		return newSyncState(a.baseInfo, a.conf, internal), nil, a.Errorf(err)
@@ -146,13 +166,31 @@ func (a *SyncState) BlockIDs(peer peer.Peer, signatures []proto.BlockID) (State,
 	}
 	zap.S().Named(logging.FSMNamespace).Debugf("[Sync] Continue to NG state")
 	// No blocks were request, switching to NG working mode
-	err = a.baseInfo.storage.StartProvidingExtendedApi()
-	if err != nil {
+	return a.switchToNG()
+}
+
+func (a *SyncState) switchToNG() (State, Async, error) {
+	if err := a.baseInfo.storage.StartProvidingExtendedApi(); err != nil {
 		return newIdleState(a.baseInfo), nil, a.Errorf(err)
 	}
 	return newNGState(a.baseInfo), nil, nil
 }
 
+// peerHasBetterChain compares the last known score of the peer with the score of the node. Bodies of the blocks are
+// requested only after the chain of IDs is received and only if it leads to the chain with the higher score.
+// If the score of the peer is unknown the peer is trusted.
+func (a *SyncState) peerHasBetterChain(p peer.Peer) bool {
+	peerScore, err := a.baseInfo.peers.Score(p)
+	if err != nil {
+		return true
+	}
+	nodeScore, err := a.baseInfo.storage.CurrentScore()
+	if err != nil {
+		return true
+	}
+	return peerScore.Cmp(nodeScore) > 0
+}
+
 func (a *SyncState) Score(p peer.Peer, score *proto.Score) (State, Async, error) {
 	metrics.FSMScore("sync", score, p.Handshake().NodeName)
 	zap.S().Named(logging.FSMNamespace).Debugf("[Sync] Score message received from peer '%s', score %s",
@@ -164,7 +202,7 @@ func (a *SyncState) Score(p peer.Peer, score *proto.Score) (State, Async, error)
 }
 
 func (a *SyncState) Block(p peer.Peer, block *proto.Block) (State, Async, error) {
-	if !p.Equal(a.conf.peerSyncWith) {
+	if !a.conf.downloader.Serves(p.ID().String()) {
 		return a, nil, nil
 	}
 	metrics.FSMKeyBlockReceived("sync", block, p.Handshake().NodeName)
@@ -174,6 +212,7 @@ func (a *SyncState) Block(p peer.Peer, block *proto.Block) (State, Async, error)
 	if err != nil {
 		return newSyncState(a.baseInfo, a.conf, internal), nil, a.Errorf(err)
 	}
+	a.conf.downloader.BlockReceived(block.BlockID())
 	return a.applyBlocksWithSnapshots(a.baseInfo, a.conf.Now(a.baseInfo.tm), internal)
 }
 
@@ -182,7 +221,7 @@ func (a *SyncState) BlockSnapshot(
 	blockID proto.BlockID,
 	snapshot proto.BlockSnapshot,
 ) (State, Async, error) {
-	if !p.Equal(a.conf.peerSyncWith) {
+	if !a.conf.downloader.Serves(p.ID().String()) {
 		return a, nil, nil
 	}
 	zap.S().Named(logging.FSMNamespace).Debugf("[Sync][%s] Received snapshot for block %s", p.ID(), blockID.String())
@@ -190,6 +229,7 @@ func (a *SyncState) BlockSnapshot(
 	if err != nil {
 		return newSyncState(a.baseInfo, a.conf, internal), nil, a.Errorf(err)
 	}
+	a.conf.downloader.SnapshotReceived(blockID)
 	return a.applyBlocksWithSnapshots(a.baseInfo, a.conf.Now(a.baseInfo.tm), internal)
 }
 
@@ -296,7 +336,7 @@ func (a *SyncState) applyBlocksWithSnapshots(
 		zap.S().Named(logging.FSMNamespace).Debugf("[Sync] Changing sync peer to '%s'", np.ID().String())
 		return syncWithNewPeer(a, a.baseInfo, np)
 	}
-	a.internal.AskBlocksIDs(extension.NewPeerExtension(a.conf.peerSyncWith, a.baseInfo.scheme))
+	a.internal.AskBlocksIDs(a.conf.downloader)
 	return newSyncState(baseInfo, conf, internal), nil, nil
 }
 
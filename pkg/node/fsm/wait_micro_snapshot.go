@@ -10,6 +10,7 @@ import (
 
 	"github.com/wavesplatform/gowaves/pkg/logging"
 	"github.com/wavesplatform/gowaves/pkg/metrics"
+	"github.com/wavesplatform/gowaves/pkg/node/forks"
 	"github.com/wavesplatform/gowaves/pkg/node/fsm/tasks"
 	"github.com/wavesplatform/gowaves/pkg/p2p/peer"
 	"github.com/wavesplatform/gowaves/pkg/proto"
@@ -147,6 +148,7 @@ func (a *WaitMicroSnapshotState) checkAndAppendMicroBlock(
 		err := errors.Errorf("microblock TBID '%s' refer to block ID '%s' but last block ID is '%s'",
 			micro.TotalBlockID.String(), micro.Reference.String(), top.BlockID().String())
 		metrics.FSMMicroBlockDeclined("ng", micro, err)
+		forks.ObserveDiscardedMicroBlock(forks.DiscardReasonReference)
 		return &proto.Block{}, proto.NewInfoMsg(err)
 	}
 	ok, err := micro.VerifySignature(a.baseInfo.scheme)
@@ -196,6 +198,7 @@ func (a *WaitMicroSnapshotState) checkAndAppendMicroBlock(
 
 	if err != nil {
 		metrics.FSMMicroBlockDeclined("ng", micro, err)
+		forks.ObserveDiscardedMicroBlock(forks.DiscardReasonInvalid)
 		return nil, errors.Wrap(err, "failed to apply created from micro block")
 	}
 	metrics.FSMMicroBlockApplied("ng", micro)
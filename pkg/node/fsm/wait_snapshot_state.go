@@ -10,6 +10,7 @@ import (
 
 	"github.com/wavesplatform/gowaves/pkg/logging"
 	"github.com/wavesplatform/gowaves/pkg/metrics"
+	"github.com/wavesplatform/gowaves/pkg/node/forks"
 	"github.com/wavesplatform/gowaves/pkg/node/fsm/tasks"
 	"github.com/wavesplatform/gowaves/pkg/p2p/peer"
 	"github.com/wavesplatform/gowaves/pkg/proto"
@@ -108,6 +109,8 @@ func (a *WaitSnapshotState) BlockSnapshot(
 	}
 
 	defer a.cleanupBeforeTransition()
+	switching := a.baseInfo.storage.TopBlock().BlockID() != a.blockWaitingForSnapshot.Parent
+	start := time.Now()
 	_, err := a.baseInfo.blocksApplier.ApplyWithSnapshots(
 		a.baseInfo.storage,
 		[]*proto.Block{a.blockWaitingForSnapshot},
@@ -119,6 +122,9 @@ func (a *WaitSnapshotState) BlockSnapshot(
 	}
 
 	metrics.FSMKeyBlockApplied("ng", a.blockWaitingForSnapshot)
+	if switching {
+		forks.ObserveKeyBlockSwitch(time.Since(start))
+	}
 	zap.S().Named(logging.FSMNamespace).Debugf("[%s] Handle received key block message: block '%s' applied to state",
 		a, blockID)
 
@@ -1,6 +1,12 @@
 package node
 
-import "github.com/prometheus/client_golang/prometheus"
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/wavesplatform/gowaves/pkg/node/events"
+)
 
 var metricInternalChannelSize = prometheus.NewGauge(
 	prometheus.GaugeOpts{
@@ -41,10 +47,60 @@ var metricGetBlockMessage = prometheus.NewCounter(
 	},
 )
 
+var metricEvents = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "events",
+		Name:      "published",
+		Help:      "Counter of events published on the internal bus.",
+	},
+	[]string{"type"},
+)
+
+var metricEventsDropped = prometheus.NewGauge(
+	prometheus.GaugeOpts{
+		Namespace: "events",
+		Name:      "metrics_dropped",
+		Help:      "The number of events that were dropped by metrics subscriber.",
+	},
+)
+
 func init() {
 	prometheus.MustRegister(metricInternalChannelSize)
 	prometheus.MustRegister(metricPeersMessage)
 	prometheus.MustRegister(metricGetPeersMessage)
 	prometheus.MustRegister(metricBlockMessage)
 	prometheus.MustRegister(metricGetBlockMessage)
+	prometheus.MustRegister(metricEvents)
+	prometheus.MustRegister(metricEventsDropped)
+}
+
+func runEventsMetrics(ctx context.Context, bus *events.Bus) {
+	sub := bus.Subscribe(eventsMetricsBufferSize)
+	defer sub.Close()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e := <-sub.Events():
+			var t string
+			switch e.(type) {
+			case events.BlockApplied:
+				t = "block_applied"
+			case events.Rollback:
+				t = "rollback"
+			case events.TransactionAccepted:
+				t = "transaction_accepted"
+			case events.WatchedTransaction:
+				t = "watched_transaction"
+			case events.PeerConnected:
+				t = "peer_connected"
+			case events.PeerDisconnected:
+				t = "peer_disconnected"
+			default:
+				t = "unknown"
+			}
+			metricEvents.WithLabelValues(t).Inc()
+			metricEventsDropped.Set(float64(sub.Dropped()))
+		}
+	}
 }
@@ -8,6 +8,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/wavesplatform/gowaves/pkg/logging"
+	"github.com/wavesplatform/gowaves/pkg/node/events"
 	"github.com/wavesplatform/gowaves/pkg/node/peers"
 	"github.com/wavesplatform/gowaves/pkg/p2p/peer"
 	"github.com/wavesplatform/gowaves/pkg/proto"
@@ -63,6 +64,7 @@ type Network struct {
 	tm            types.Time
 	minPeerMining int
 	obsolescence  time.Duration
+	events        *events.Bus
 }
 
 func NewNetwork(
@@ -80,6 +82,7 @@ func NewNetwork(
 		tm:            services.Time,
 		minPeerMining: services.MinPeersMining,
 		obsolescence:  obsolescence,
+		events:        services.Events,
 	}, nch
 }
 
@@ -120,6 +123,7 @@ func (n *Network) handleConnected(msg *peer.Connected) {
 	if n.peers.ConnectedCount() == n.minPeerMining { // TODO: Consider producing duplicate events here
 		n.networkInfoCh <- StartMining{}
 	}
+	n.events.Publish(events.PeerConnected{Peer: msg.Peer})
 	sendScore(msg.Peer, n.storage)
 
 	//TODO: Do we need to check it here after async operation of sending score to the peer. Possibly we don't
@@ -129,6 +133,7 @@ func (n *Network) handleConnected(msg *peer.Connected) {
 
 func (n *Network) handleInternalErr(msg peer.InfoMessage) {
 	n.peers.Disconnect(msg.Peer)
+	n.events.Publish(events.PeerDisconnected{Peer: msg.Peer})
 	if n.peers.ConnectedCount() < n.minPeerMining {
 		// TODO: Consider handling of duplicate events in consumer
 		n.networkInfoCh <- StopMining{}
@@ -27,6 +27,7 @@ import (
 const (
 	spawnOutgoingConnectionsInterval        = 1 * time.Minute
 	metricInternalChannelSizeUpdateInterval = 1 * time.Second
+	eventsMetricsBufferSize                 = 1000
 )
 
 type Config struct {
@@ -156,6 +157,7 @@ func (a *Node) Run(
 ) {
 	go a.runOutgoingConnections(ctx)
 	go a.runInternalMetrics(ctx, p.MessageCh)
+	go runEventsMetrics(ctx, a.services.Events)
 	go a.runIncomingConnections(ctx)
 
 	tasksCh := make(chan tasks.AsyncTask, 10)
@@ -2,7 +2,9 @@ package peers
 
 import (
 	"math/big"
+	"slices"
 	"sort"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -16,11 +18,51 @@ type activePeers struct {
 }
 
 func newActivePeers() activePeers {
-	return activePeers{
+	ap := activePeers{
 		m:             make(map[peer.ID]peerInfo),
 		sortedByScore: make([]peer.ID, 0),
 		selector:      newScoreSelector(),
 	}
+	ap.selector.pick = func(ids []peer.ID) peer.ID { return choose(ids, ap.cost) }
+	return ap
+}
+
+// cost returns the cost of getting blocks from the peer, see peerQuality.cost.
+func (ap *activePeers) cost(id peer.ID) float64 {
+	return ap.m[id].quality.cost()
+}
+
+// observe records the block delivery time of the peer with the given string ID.
+func (ap *activePeers) observe(id string, rtt time.Duration, stalled bool) {
+	for pid, info := range ap.m {
+		if pid.String() == id {
+			info.quality.observe(rtt, stalled)
+			ap.m[pid] = info
+			return
+		}
+	}
+}
+
+// forEachByQuality calls the function for the peers starting from the one with the lowest cost.
+func (ap *activePeers) forEachByQuality(f func(peer.ID, peerInfo)) {
+	ids := make([]peer.ID, 0, len(ap.m))
+	for id := range ap.m {
+		ids = append(ids, id)
+	}
+	slices.SortStableFunc(ids, func(a, b peer.ID) int {
+		ca, cb := ap.cost(a), ap.cost(b)
+		switch {
+		case ca < cb:
+			return -1
+		case ca > cb:
+			return 1
+		default:
+			return 0
+		}
+	})
+	for _, id := range ids {
+		f(id, ap.m[id])
+	}
 }
 
 func (ap *activePeers) add(p peer.Peer) {
@@ -83,6 +125,22 @@ func (ap *activePeers) getPeerWithMaxScore() (peerInfo, bool) {
 	return ap.m[ap.sortedByScore[0]], true
 }
 
+// getBestPeerWithMaxScore returns the peer with the lowest cost among the peers with the maximal score.
+func (ap *activePeers) getBestPeerWithMaxScore() (peerInfo, bool) {
+	top, ok := ap.getPeerWithMaxScore()
+	if !ok {
+		return peerInfo{}, false
+	}
+	var candidates []peer.ID
+	for _, id := range ap.sortedByScore {
+		if ap.m[id].score.Cmp(top.score) != 0 {
+			break
+		}
+		candidates = append(candidates, id)
+	}
+	return ap.m[choose(candidates, ap.cost)], true
+}
+
 func (ap *activePeers) getPeerFromLargestPeerGroup(p peer.Peer) (peerInfo, bool) {
 	var pid peer.ID
 	if p != nil {
@@ -15,6 +15,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/wavesplatform/gowaves/pkg/p2p/peer"
+	"github.com/wavesplatform/gowaves/pkg/p2p/peer/extension"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 )
 
@@ -24,8 +25,9 @@ const (
 )
 
 type peerInfo struct {
-	score *big.Int
-	peer  peer.Peer
+	score   *big.Int
+	peer    peer.Peer
+	quality peerQuality
 }
 
 func newPeerInfo(peer peer.Peer) peerInfo {
@@ -46,6 +48,8 @@ type PeerManager interface {
 	ClearBlackList() error
 	UpdateScore(p peer.Peer, score *proto.Score) error
 	KnownPeers() []storage.KnownPeer
+	// KnownPeersRecords returns all known peers with their connection history in the order of reconnection.
+	KnownPeersRecords() []storage.KnownPeerRecord
 	UpdateKnownPeers([]storage.KnownPeer) error
 	Close() error
 	SpawnOutgoingConnections(context.Context)
@@ -60,7 +64,17 @@ type PeerManager interface {
 	CheckPeerWithMaxScore(p peer.Peer) (peer.Peer, bool)
 	CheckPeerInLargestScoreGroup(p peer.Peer) (peer.Peer, bool)
 
+	// BlockDelivered and BlockStalled report the time the peer with the given ID took to deliver the requested
+	// block or the time the node waited for it in vain. Peers delivering blocks faster are preferred on selection.
+	BlockDelivered(id string, rtt time.Duration)
+	BlockStalled(id string, waited time.Duration)
+
 	Disconnect(peer.Peer)
+
+	// Pin, Unpin and Pinned manage the peers which connections are always maintained.
+	Pin(addr proto.TCPAddr) error
+	Unpin(addr proto.TCPAddr) (bool, error)
+	Pinned() []proto.IpPort
 }
 
 type PeerManagerImpl struct {
@@ -69,6 +83,8 @@ type PeerManagerImpl struct {
 	mu                        sync.RWMutex
 	peerStorage               PeerStorage
 	spawned                   map[proto.IpPort]struct{}
+	pinned                    map[proto.IpPort]struct{}
+	pinnedCh                  chan struct{}
 	enableOutboundConnections bool
 	blackListDuration         time.Duration
 	limitConnections          int
@@ -81,11 +97,13 @@ func NewPeerManager(spawner PeerSpawner, storage PeerStorage, limitConnections i
 	networkName string, enableOutboundConnections bool, newConnectionsLimit int,
 	blackListDuration time.Duration) *PeerManagerImpl {
 
-	return &PeerManagerImpl{
+	pm := &PeerManagerImpl{
 		spawner:                   spawner,
 		active:                    newActivePeers(),
 		peerStorage:               storage,
 		spawned:                   make(map[proto.IpPort]struct{}),
+		pinned:                    make(map[proto.IpPort]struct{}),
+		pinnedCh:                  make(chan struct{}, 1),
 		enableOutboundConnections: enableOutboundConnections,
 		blackListDuration:         blackListDuration,
 		limitConnections:          limitConnections,
@@ -93,6 +111,12 @@ func NewPeerManager(spawner PeerSpawner, storage PeerStorage, limitConnections i
 		version:                   version,
 		networkName:               networkName,
 	}
+	if storage != nil {
+		for _, p := range storage.Pinned() {
+			pm.pinned[p.IpPort()] = struct{}{}
+		}
+	}
+	return pm
 }
 
 func (a *PeerManagerImpl) NewConnection(p peer.Peer) (err error) {
@@ -103,22 +127,18 @@ func (a *PeerManagerImpl) NewConnection(p peer.Peer) (err error) {
 	}
 
 	now := time.Now()
-	if p.Direction() == peer.Outgoing && a.suspended(p, now) {
+	pinned := a.isPinned(p)
+	if p.Direction() == peer.Outgoing && !pinned && a.suspended(p, now) {
 		_ = p.Close()
 		return errors.Errorf("peer '%s' is suspended", p.ID())
 	}
-	if p.Direction() == peer.Incoming && a.blackListed(p, now) {
+	if p.Direction() == peer.Incoming && !pinned && a.blackListed(p, now) {
 		_ = p.Close()
 		return errors.Errorf("peer '%s' is in black list", p.ID())
 	}
 
-	if p.Handshake().Version.CmpMinor(a.version) >= 2 {
-		err := errors.Errorf(
-			"versions are too different, current %s, connected %s (peer '%s')",
-			a.version.String(),
-			p.Handshake().Version.String(),
-			p.ID(),
-		)
+	if _, err := extension.NegotiateProtocol(a.version, p.Handshake().Version); err != nil {
+		err = errors.Wrapf(err, "peer '%s'", p.ID())
 		a.restrict(p, now, err.Error())
 		_ = p.Close()
 		return proto.NewInfoMsg(err)
@@ -133,17 +153,23 @@ func (a *PeerManagerImpl) NewConnection(p peer.Peer) (err error) {
 	in, out := a.countDirections()
 	switch p.Direction() {
 	case peer.Incoming:
-		if in >= a.limitConnections {
+		if in >= a.limitConnections && !pinned {
 			_ = p.Close()
 			return proto.NewInfoMsg(errors.Errorf("exceed incoming connections limit, incoming peer '%s'", p.ID()))
 		}
 	case peer.Outgoing:
-		if !p.Handshake().DeclaredAddr.Empty() {
-			known := storage.KnownPeer(proto.TCPAddr(p.Handshake().DeclaredAddr).ToIpPort())
+		hs := p.Handshake()
+		dialed := storage.KnownPeer(p.RemoteAddr().ToIpPort())
+		if err := a.peerStorage.RecordKnownHandshake(dialed, hs.Version.String(), hs.AppName, now); err != nil {
+			zap.S().Named(logging.NetworkNamespace).Debugf("Failed to record handshake with peer '%s': %v",
+				p.ID(), err)
+		}
+		if !hs.DeclaredAddr.Empty() {
+			known := storage.KnownPeer(proto.TCPAddr(hs.DeclaredAddr).ToIpPort())
 			// TODO(nickeskov): maybe log error?
 			_ = a.peerStorage.AddOrUpdateKnown([]storage.KnownPeer{known}, now)
 		}
-		if out >= a.limitConnections {
+		if out >= a.limitConnections && !pinned {
 			_ = p.Close()
 			return proto.NewInfoMsg(errors.Errorf("exceed outgoing connections limit, outgoing peer '%s'", p.ID()))
 		}
@@ -161,18 +187,33 @@ func (a *PeerManagerImpl) ConnectedCount() int {
 	return a.unsafeConnectedCount()
 }
 
+// EachConnected calls the function for connected peers, the pinned peers go first followed by the peers
+// delivering blocks faster.
 func (a *PeerManagerImpl) EachConnected(f func(peer peer.Peer, score *big.Int)) {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
-	a.active.forEach(
+	var rest []peerInfo
+	a.active.forEachByQuality(
 		func(_ peer.ID, info peerInfo) {
+			if !a.unsafeIsPinned(info.peer) {
+				rest = append(rest, info)
+				return
+			}
 			f(info.peer, info.score)
 		},
 	)
+	for _, info := range rest {
+		f(info.peer, info.score)
+	}
 }
 
 func (a *PeerManagerImpl) Suspend(p peer.Peer, suspendTime time.Time, reason string) {
+	if a.isPinned(p) {
+		zap.S().Named(logging.NetworkNamespace).Debugf("[%s] Pinned peer is not suspended, reason: %s",
+			p.ID(), reason)
+		return
+	}
 	a.Disconnect(p)
 	a.mu.Lock()
 	defer a.mu.Unlock()
@@ -197,6 +238,11 @@ func (a *PeerManagerImpl) AddToBlackList(p peer.Peer, blockTime time.Time, reaso
 	if a.blackListDuration <= 0 {
 		return
 	}
+	if a.isPinned(p) {
+		zap.S().Named(logging.NetworkNamespace).Debugf("[%s] Pinned peer is not black listed, reason: %s",
+			p.ID(), reason)
+		return
+	}
 
 	a.Disconnect(p)
 	a.mu.Lock()
@@ -236,6 +282,10 @@ func (a *PeerManagerImpl) KnownPeers() []storage.KnownPeer {
 	return a.peerStorage.Known(a.newConnectionsLimit)
 }
 
+func (a *PeerManagerImpl) KnownPeersRecords() []storage.KnownPeerRecord {
+	return a.peerStorage.KnownRecords(-1)
+}
+
 func (a *PeerManagerImpl) UpdateKnownPeers(known []storage.KnownPeer) error {
 	if len(known) == 0 {
 		return nil
@@ -312,14 +362,20 @@ func (a *PeerManagerImpl) SpawnOutgoingConnections(ctx context.Context) {
 		go func(ipPort proto.IpPort) {
 			addr := proto.NewTCPAddr(ipPort.Addr(), ipPort.Port())
 			defer a.removeSpawned(addr)
+			attempt := time.Now()
 			if err := a.spawner.SpawnOutgoing(ctx, addr); err != nil {
 				zap.S().Named(logging.NetworkNamespace).Debugf("[%s] Failed to establish outbound connection: %v",
 					ipPort.String(), err)
+				// The failure is not counted if the connection was closed after the successful handshake.
+				rErr := a.peerStorage.RecordKnownFailure(storage.KnownPeer(ipPort), attempt, time.Now())
+				if rErr != nil {
+					zap.S().Errorf("[%s] Failed to update peer info in peer storage: %v", ipPort.String(), rErr)
+				}
+				return
 			}
 			if err := a.UpdateKnownPeers([]storage.KnownPeer{storage.KnownPeer(ipPort)}); err != nil {
 				zap.S().Errorf("[%s] Failed to update peer info in peer storage: %v", ipPort.String(), err)
 			}
-
 		}(ipPort)
 	}
 }
@@ -410,12 +466,18 @@ func (a *PeerManagerImpl) Disconnect(p peer.Peer) {
 func (a *PeerManagerImpl) Run(ctx context.Context) {
 	ticker := time.NewTicker(clearRestrictedPeersInterval)
 	defer ticker.Stop()
+	pinnedTicker := time.NewTicker(reconnectPinnedInterval)
+	defer pinnedTicker.Stop()
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
 			a.clearRestrictedPeers(time.Now())
+		case <-pinnedTicker.C:
+			a.reconnectPinned(ctx)
+		case <-a.pinnedCh:
+			a.reconnectPinned(ctx)
 		}
 	}
 }
@@ -451,7 +513,7 @@ func (a *PeerManagerImpl) CheckPeerWithMaxScore(p peer.Peer) (peer.Peer, bool) {
 	if !ok {
 		return nil, false
 	}
-	npi, ok := a.active.getPeerWithMaxScore()
+	npi, ok := a.active.getBestPeerWithMaxScore()
 	if !ok { // No need to change peer
 		zap.S().Named(logging.NetworkNamespace).Debugf("No need to change peer with max score '%s'", pIDStr)
 		return p, false
@@ -485,6 +547,18 @@ func (a *PeerManagerImpl) CheckPeerInLargestScoreGroup(p peer.Peer) (peer.Peer,
 	return np.peer, true
 }
 
+func (a *PeerManagerImpl) BlockDelivered(id string, rtt time.Duration) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.active.observe(id, rtt, false)
+}
+
+func (a *PeerManagerImpl) BlockStalled(id string, waited time.Duration) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.active.observe(id, waited, true)
+}
+
 func (a *PeerManagerImpl) connected(p peer.Peer) (peer.Peer, bool) {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
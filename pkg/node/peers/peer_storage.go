@@ -8,7 +8,10 @@ import (
 
 type PeerStorage interface {
 	Known(limit int) []storage.KnownPeer
+	KnownRecords(limit int) []storage.KnownPeerRecord
 	AddOrUpdateKnown(known []storage.KnownPeer, now time.Time) error
+	RecordKnownHandshake(known storage.KnownPeer, version, appName string, now time.Time) error
+	RecordKnownFailure(known storage.KnownPeer, attempt, now time.Time) error
 	DeleteKnown(known []storage.KnownPeer) error
 	DropKnown() error
 
@@ -28,5 +31,9 @@ type PeerStorage interface {
 	RefreshBlackList(now time.Time) error
 	DropBlackList() error
 
+	Pinned() []storage.KnownPeer
+	AddPinned(pinned storage.KnownPeer) error
+	DeletePinned(pinned storage.KnownPeer) error
+
 	DropStorage() error
 }
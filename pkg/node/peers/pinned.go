@@ -0,0 +1,141 @@
+package peers
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/wavesplatform/gowaves/pkg/logging"
+	"github.com/wavesplatform/gowaves/pkg/node/peers/storage"
+	"github.com/wavesplatform/gowaves/pkg/p2p/peer"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+// reconnectPinnedInterval is the interval of checking that connections to pinned peers are established.
+const reconnectPinnedInterval = 10 * time.Second
+
+// Pin makes the node maintain the connection to the peer with the given address. The connection is re-established
+// when lost, it isn't limited, the peer isn't suspended or black listed and it's the first to receive new blocks.
+// The connection is established by the Run loop. The pin is saved in the peers storage and is restored on restart.
+func (a *PeerManagerImpl) Pin(addr proto.TCPAddr) error {
+	if a.peerStorage != nil {
+		if err := a.peerStorage.AddPinned(storage.KnownPeer(addr.ToIpPort())); err != nil {
+			return errors.Wrapf(err, "failed to save pinned peer '%s'", addr.String())
+		}
+	}
+	a.pin(addr)
+	return nil
+}
+
+// PinTransient pins the peer like Pin, but the pin isn't saved in the peers storage and lasts until the node is
+// stopped. It's used for the peers pinned by the node settings.
+func (a *PeerManagerImpl) PinTransient(addr proto.TCPAddr) {
+	a.pin(addr)
+}
+
+func (a *PeerManagerImpl) pin(addr proto.TCPAddr) {
+	a.mu.Lock()
+	a.pinned[addr.ToIpPort()] = struct{}{}
+	a.mu.Unlock()
+	zap.S().Named(logging.NetworkNamespace).Infof("Peer '%s' is pinned", addr.String())
+	select {
+	case a.pinnedCh <- struct{}{}:
+	default: // Reconnection is already requested.
+	}
+}
+
+// Unpin makes the connection to the peer ordinary, the connection is not closed. The pin is removed from the peers
+// storage too.
+func (a *PeerManagerImpl) Unpin(addr proto.TCPAddr) (bool, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, ok := a.pinned[addr.ToIpPort()]; !ok {
+		return false, nil
+	}
+	if a.peerStorage != nil {
+		if err := a.peerStorage.DeletePinned(storage.KnownPeer(addr.ToIpPort())); err != nil {
+			return false, errors.Wrapf(err, "failed to delete pinned peer '%s'", addr.String())
+		}
+	}
+	delete(a.pinned, addr.ToIpPort())
+	zap.S().Named(logging.NetworkNamespace).Infof("Peer '%s' is unpinned", addr.String())
+	return true, nil
+}
+
+// Pinned returns addresses of pinned peers.
+func (a *PeerManagerImpl) Pinned() []proto.IpPort {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	out := make([]proto.IpPort, 0, len(a.pinned))
+	for k := range a.pinned {
+		out = append(out, k)
+	}
+	slices.SortFunc(out, func(a, b proto.IpPort) int {
+		return slices.Compare(a[:], b[:])
+	})
+	return out
+}
+
+func (a *PeerManagerImpl) isPinned(p peer.Peer) bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.unsafeIsPinned(p)
+}
+
+// unsafeIsPinned checks the remote address of outgoing connection. Incoming connections come from an arbitrary port,
+// so they are matched by the declared address, the peer without one isn't considered pinned.
+func (a *PeerManagerImpl) unsafeIsPinned(p peer.Peer) bool {
+	if len(a.pinned) == 0 {
+		return false
+	}
+	remote := p.RemoteAddr().ToIpPort()
+	if p.Direction() == peer.Outgoing {
+		_, ok := a.pinned[remote]
+		return ok
+	}
+	declared := p.Handshake().DeclaredAddr
+	if declared.Empty() {
+		return false
+	}
+	_, ok := a.pinned[declared.ToIpPort()]
+	return ok
+}
+
+// reconnectPinned spawns outgoing connections to the pinned peers, which are not connected.
+func (a *PeerManagerImpl) reconnectPinned(ctx context.Context) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if len(a.pinned) == 0 {
+		return
+	}
+	connected := make(map[proto.IpPort]struct{})
+	a.active.forEach(func(_ peer.ID, info peerInfo) {
+		if info.peer.Direction() == peer.Outgoing {
+			connected[info.peer.RemoteAddr().ToIpPort()] = struct{}{}
+			return
+		}
+		// Incoming connection from pinned peer is enough.
+		if declared := info.peer.Handshake().DeclaredAddr; !declared.Empty() {
+			connected[declared.ToIpPort()] = struct{}{}
+		}
+	})
+	for ipPort := range a.pinned {
+		if _, ok := connected[ipPort]; ok {
+			continue
+		}
+		if _, ok := a.spawned[ipPort]; ok {
+			continue
+		}
+		a.spawned[ipPort] = struct{}{}
+		go func(addr proto.TCPAddr) {
+			defer a.removeSpawned(addr)
+			if err := a.spawner.SpawnOutgoing(ctx, addr); err != nil {
+				zap.S().Named(logging.NetworkNamespace).Debugf("[%s] Failed to connect to pinned peer: %v",
+					addr.String(), err)
+			}
+		}(proto.NewTCPAddr(ipPort.Addr(), ipPort.Port()))
+	}
+}
@@ -0,0 +1,95 @@
+package peers
+
+import (
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/node/peers/storage"
+	"github.com/wavesplatform/gowaves/pkg/p2p/mock"
+	"github.com/wavesplatform/gowaves/pkg/p2p/peer"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+type directedPeer struct {
+	*mock.Peer
+	direction peer.Direction
+}
+
+func (p directedPeer) Direction() peer.Direction {
+	return p.direction
+}
+
+func newDirectedPeer(ip string, port int, direction peer.Direction) directedPeer {
+	return directedPeer{
+		Peer:      &mock.Peer{Addr: net.JoinHostPort(ip, "0"), RemoteAddress: proto.NewTCPAddr(net.ParseIP(ip), port)},
+		direction: direction,
+	}
+}
+
+func TestPinnedPeers(t *testing.T) {
+	pm := NewPeerManager(nil, nil, 10, proto.ProtocolVersion(), "wavesW", true, 10, 0)
+	require.NoError(t, pm.Pin(proto.NewTCPAddrFromString("10.0.0.1:6868")))
+	pm.PinTransient(proto.NewTCPAddrFromString("10.0.0.2:6868"))
+	require.Len(t, pm.Pinned(), 2)
+
+	assert.True(t, pm.isPinned(newDirectedPeer("10.0.0.1", 6868, peer.Outgoing)))
+	assert.False(t, pm.isPinned(newDirectedPeer("10.0.0.1", 6869, peer.Outgoing)))
+	incoming := newDirectedPeer("10.0.0.2", 51234, peer.Incoming)
+	assert.False(t, pm.isPinned(incoming), "incoming connections without declared address are not matched by IP")
+	incoming.HandshakeField.DeclaredAddr = proto.NewHandshakeTCPAddr(net.ParseIP("10.0.0.2"), 6868)
+	assert.True(t, pm.isPinned(incoming), "incoming connections are matched by declared address")
+	incoming.HandshakeField.DeclaredAddr = proto.NewHandshakeTCPAddr(net.ParseIP("10.0.0.2"), 6869)
+	assert.False(t, pm.isPinned(incoming))
+	assert.False(t, pm.isPinned(newDirectedPeer("10.0.0.3", 6868, peer.Incoming)))
+
+	unpinned, err := pm.Unpin(proto.NewTCPAddrFromString("10.0.0.2:6868"))
+	require.NoError(t, err)
+	assert.True(t, unpinned)
+	unpinned, err = pm.Unpin(proto.NewTCPAddrFromString("10.0.0.2:6868"))
+	require.NoError(t, err)
+	assert.False(t, unpinned)
+	assert.Equal(t, []proto.IpPort{proto.NewTCPAddrFromString("10.0.0.1:6868").ToIpPort()}, pm.Pinned())
+}
+
+func TestPinnedPeersStored(t *testing.T) {
+	dir := t.TempDir()
+	s, err := storage.NewCBORStorage(dir, time.Now())
+	require.NoError(t, err)
+	pm := NewPeerManager(nil, s, 10, proto.ProtocolVersion(), "wavesW", true, 10, 0)
+	stored := proto.NewTCPAddrFromString("10.0.0.1:6868")
+	removed := proto.NewTCPAddrFromString("10.0.0.2:6868")
+	require.NoError(t, pm.Pin(stored))
+	require.NoError(t, pm.Pin(removed))
+	pm.PinTransient(proto.NewTCPAddrFromString("10.0.0.3:6868"))
+	_, err = pm.Unpin(removed)
+	require.NoError(t, err)
+
+	s, err = storage.NewCBORStorage(dir, time.Now())
+	require.NoError(t, err)
+	pm = NewPeerManager(nil, s, 10, proto.ProtocolVersion(), "wavesW", true, 10, 0)
+	assert.Equal(t, []proto.IpPort{stored.ToIpPort()}, pm.Pinned())
+}
+
+func TestEachConnectedPinnedFirst(t *testing.T) {
+	pm := NewPeerManager(nil, nil, 10, proto.ProtocolVersion(), "wavesW", true, 10, 0)
+	fast := newDirectedPeer("10.0.0.1", 6868, peer.Outgoing)
+	pinned := newDirectedPeer("10.0.0.2", 6868, peer.Outgoing)
+	for _, p := range []peer.Peer{fast, pinned} {
+		pm.active.add(p)
+		require.NoError(t, pm.active.updateScore(p.ID(), big.NewInt(1)))
+	}
+	pm.active.observe(fast.ID().String(), 0, false)
+	pm.active.observe(pinned.ID().String(), 1<<40, true)
+	pm.PinTransient(pinned.RemoteAddr())
+
+	var order []peer.Peer
+	pm.EachConnected(func(p peer.Peer, _ *proto.Score) {
+		order = append(order, p)
+	})
+	assert.Equal(t, []peer.Peer{pinned, fast}, order)
+}
@@ -0,0 +1,70 @@
+package peers
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/wavesplatform/gowaves/pkg/p2p/peer"
+)
+
+const (
+	// rttSmoothing is the weight of the new sample in the moving average of the block delivery time.
+	rttSmoothing = 0.2
+	// explorationRate is the probability to choose a random peer instead of the best one. It keeps the selection
+	// diverse and gives a chance to the peers whose quality is stale.
+	explorationRate = 0.1
+)
+
+// peerQuality describes how well the peer serves blocks.
+type peerQuality struct {
+	rtt       time.Duration // exponentially weighted moving average of the block delivery time
+	delivered uint64        // number of blocks delivered in time
+	stalled   uint64        // number of blocks not delivered in time
+}
+
+func (q *peerQuality) observe(rtt time.Duration, stalled bool) {
+	if q.delivered+q.stalled == 0 {
+		q.rtt = rtt
+	} else {
+		q.rtt = time.Duration(rttSmoothing*float64(rtt) + (1-rttSmoothing)*float64(q.rtt))
+	}
+	if stalled {
+		q.stalled++
+	} else {
+		q.delivered++
+	}
+}
+
+// cost is the expected time to get a block from the peer, the lower the better. Peers without observations have
+// zero cost, so they are tried first.
+func (q peerQuality) cost() float64 {
+	total := q.delivered + q.stalled
+	if total == 0 {
+		return 0
+	}
+	successRate := float64(q.delivered+1) / float64(total+1)
+	return float64(q.rtt) / successRate
+}
+
+// choose returns the peer with the lowest cost, ties are broken randomly. With the probability of explorationRate
+// a random peer is returned.
+func choose(ids []peer.ID, cost func(peer.ID) float64) peer.ID {
+	if len(ids) == 0 {
+		return nil
+	}
+	if rand.Float64() < explorationRate { // #nosec: it's ok to use math/rand/v2 here
+		return ids[rand.IntN(len(ids))] // #nosec: it's ok to use math/rand/v2 here
+	}
+	var best []peer.ID
+	bestCost := 0.0
+	for _, id := range ids {
+		c := cost(id)
+		switch {
+		case len(best) == 0 || c < bestCost:
+			best, bestCost = append(best[:0], id), c
+		case c == bestCost:
+			best = append(best, id)
+		}
+	}
+	return best[rand.IntN(len(best))] // #nosec: it's ok to use math/rand/v2 here
+}
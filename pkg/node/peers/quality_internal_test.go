@@ -0,0 +1,71 @@
+package peers
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/p2p/mock"
+	"github.com/wavesplatform/gowaves/pkg/p2p/peer"
+)
+
+func TestPeerQuality(t *testing.T) {
+	var q peerQuality
+	assert.Zero(t, q.cost())
+	q.observe(100*time.Millisecond, false)
+	assert.Equal(t, 100*time.Millisecond, q.rtt)
+	q.observe(200*time.Millisecond, false)
+	assert.Equal(t, 120*time.Millisecond, q.rtt)
+	assert.InDelta(t, float64(120*time.Millisecond), q.cost(), 1)
+
+	stalled := q
+	stalled.observe(120*time.Millisecond, true)
+	assert.Equal(t, 120*time.Millisecond, stalled.rtt)
+	assert.Greater(t, stalled.cost(), q.cost(), "stalled requests increase the cost")
+}
+
+func TestChoosePrefersCheapPeers(t *testing.T) {
+	fast, slow := &mockPeerID{"fast"}, &mockPeerID{"slow"}
+	costs := map[peer.ID]float64{fast: 1, slow: 10}
+	ids := []peer.ID{slow, fast}
+	n := 0
+	for range 1000 {
+		if choose(ids, func(id peer.ID) float64 { return costs[id] }) == fast {
+			n++
+		}
+	}
+	assert.Greater(t, n, 850)
+	assert.Less(t, n, 1000, "random peers are chosen sometimes")
+	assert.Nil(t, choose(nil, func(peer.ID) float64 { return 0 }))
+}
+
+func TestActivePeersQuality(t *testing.T) {
+	active := newActivePeers()
+	peers := []*mock.Peer{{Addr: "127.0.0.1"}, {Addr: "127.0.0.2"}, {Addr: "127.0.0.3"}}
+	for _, p := range peers {
+		active.add(p)
+		require.NoError(t, active.updateScore(p.ID(), big.NewInt(100)))
+	}
+	active.observe(peers[0].ID().String(), time.Second, false)
+	active.observe(peers[1].ID().String(), time.Second, true)
+	active.observe(peers[2].ID().String(), 10*time.Millisecond, false)
+
+	var order []peer.Peer
+	active.forEachByQuality(func(_ peer.ID, info peerInfo) {
+		order = append(order, info.peer)
+	})
+	assert.Equal(t, []peer.Peer{peers[2], peers[0], peers[1]}, order)
+
+	n := 0
+	for range 100 {
+		info, ok := active.getBestPeerWithMaxScore()
+		require.True(t, ok)
+		if info.peer == peers[2] {
+			n++
+		}
+	}
+	assert.Greater(t, n, 50)
+}
@@ -65,6 +65,7 @@ type scoreSelector struct {
 	scoreKeyToGroup map[scoreKey]*group // key made of score's string points to the group of peers
 	peerToScoreKey  map[peerID]scoreKey
 	groups          *groupsHeap
+	pick            func([]peer.ID) peer.ID // chooses the new best peer from the group, random by default
 }
 
 func newScoreSelector() *scoreSelector {
@@ -72,6 +73,9 @@ func newScoreSelector() *scoreSelector {
 		scoreKeyToGroup: make(map[scoreKey]*group),
 		peerToScoreKey:  make(map[peerID]scoreKey),
 		groups:          newGroupsHeap(),
+		pick: func(ids []peer.ID) peer.ID {
+			return ids[rand.IntN(len(ids))] // #nosec: it's ok to use math/rand/v2 here
+		},
 	}
 }
 
@@ -156,10 +160,10 @@ func (s *scoreSelector) selectBestPeer(currentBest peer.ID) (peer.ID, *proto.Sco
 			}
 		}
 		// The peer was not found in the larges group, time to change the peer.
-		// Select the random peer from the group and return it along with a new score value.
-		i := rand.IntN(len(g.peers)) // #nosec: it's ok to use math/rand/v2 here
+		// Select the peer from the group and return it along with a new score value.
+		best := s.pick(g.peers)
 		heap.Push(s.groups, g)
-		return g.peers[i], g.score
+		return best, g.score
 	}
 	panic(fmt.Sprintf("scoreSelector: invalid element type of score selector: expected (*group), got (%T)", e))
 }
@@ -38,9 +38,13 @@ type PeerSpawnerImpl struct {
 	nodeName     string
 	nodeNonce    uint64
 	version      proto.Version
+	throttle     *conn.Throttle
 }
 
-func NewPeerSpawner(parent peer.Parent, WavesNetwork string, declAddr proto.TCPAddr, nodeName string, nodeNonce uint64, version proto.Version) *PeerSpawnerImpl {
+func NewPeerSpawner(
+	parent peer.Parent, WavesNetwork string, declAddr proto.TCPAddr, nodeName string, nodeNonce uint64,
+	version proto.Version, throttle *conn.Throttle,
+) *PeerSpawnerImpl {
 	return &PeerSpawnerImpl{
 		skipFunc:     NewSkipFilter(parent.SkipMessageList),
 		parent:       parent,
@@ -49,6 +53,7 @@ func NewPeerSpawner(parent peer.Parent, WavesNetwork string, declAddr proto.TCPA
 		nodeName:     nodeName,
 		nodeNonce:    nodeNonce,
 		version:      version,
+		throttle:     throttle,
 	}
 }
 
@@ -61,6 +66,7 @@ func (a *PeerSpawnerImpl) SpawnOutgoing(ctx context.Context, address proto.TCPAd
 		Skip:         a.skipFunc,
 		NodeName:     a.nodeName,
 		NodeNonce:    a.nodeNonce,
+		Throttle:     a.throttle,
 	}
 
 	return outgoing.EstablishConnection(ctx, params, a.version)
@@ -76,6 +82,7 @@ func (a *PeerSpawnerImpl) SpawnIncoming(ctx context.Context, c net.Conn) error {
 		NodeName:     a.nodeName,
 		NodeNonce:    a.nodeNonce,
 		Version:      a.version,
+		Throttle:     a.throttle,
 	}
 
 	return incoming.RunIncomingPeer(ctx, params)
@@ -17,7 +17,7 @@ import (
 
 const (
 	// if you change peers storage data format, you have to increment peersStorageCurrentVersion
-	peersStorageCurrentVersion = 2
+	peersStorageCurrentVersion = 3
 	peersStorageDir            = "peers_storage"
 )
 
@@ -28,8 +28,10 @@ type CBORStorage struct {
 	blackList         restrictedPeers
 	suspendedFilePath string
 	blackListFilePath string
-	known             knownPeers // Map of all ever known peers with a publicly available declared address and the last connection attempt timestamp.
+	known             knownPeers // Map of all ever known peers with a publicly available declared address and their records.
 	knownFilePath     string
+	pinned            pinnedPeers // Pinned peers are set by the node operator and aren't dropped with the storage.
+	pinnedFilePath    string
 }
 
 type restrictedPeersID byte
@@ -83,6 +85,10 @@ func newCBORStorageInDir(storageDir string, now time.Time, currVersion int) (*CB
 	if err := createFileIfNotExist(blackListFile); err != nil {
 		return nil, errors.Wrapf(err, "failed to create black list peers storage file")
 	}
+	pinnedFile := pinnedFilePath(storageDir)
+	if err := createFileIfNotExist(pinnedFile); err != nil {
+		return nil, errors.Wrap(err, "failed to create pinned peers storage file")
+	}
 
 	storage := &CBORStorage{
 		storageDir:        storageDir,
@@ -92,6 +98,8 @@ func newCBORStorageInDir(storageDir string, now time.Time, currVersion int) (*CB
 		blackListFilePath: blackListFile,
 		known:             knownPeers{},
 		knownFilePath:     knownFile,
+		pinned:            pinnedPeers{},
+		pinnedFilePath:    pinnedFile,
 	}
 
 	versionFile := storageVersionFilePath(storageDir)
@@ -127,6 +135,9 @@ func newCBORStorageInDir(storageDir string, now time.Time, currVersion int) (*CB
 	if err := unmarshalCborFromFile(blackListFile, &storage.blackList); err != nil && err != io.EOF {
 		return nil, errors.Wrapf(err, "failed to load black list peers from file %q", blackListFile)
 	}
+	if err := unmarshalCborFromFile(pinnedFile, &storage.pinned); err != nil && err != io.EOF {
+		return nil, errors.Wrapf(err, "failed to load pinned peers from file %q", pinnedFile)
+	}
 
 	if len(storage.suspended) != 0 {
 		// Remove expired peers
@@ -149,7 +160,15 @@ func newCBORStorageInDir(storageDir string, now time.Time, currVersion int) (*CB
 func (bs *CBORStorage) Known(limit int) []KnownPeer {
 	bs.rwMutex.RLock()
 	defer bs.rwMutex.RUnlock()
-	return bs.known.OldestFirst(limit)
+	return bs.known.ReconnectionOrder(limit)
+}
+
+// KnownRecords returns at most limit known peers with their records in the order of reconnection,
+// negative limit means no limit.
+func (bs *CBORStorage) KnownRecords(limit int) []KnownPeerRecord {
+	bs.rwMutex.RLock()
+	defer bs.rwMutex.RUnlock()
+	return bs.known.Records(limit)
 }
 
 // AddOrUpdateKnown adds known peers with now timestamp into peers storage with strong error guarantees.
@@ -164,9 +183,10 @@ func (bs *CBORStorage) AddOrUpdateKnown(known []KnownPeer, now time.Time) error
 	// Save existing known peers with their last attempt timestamps in backup
 	backup := bs.unsafeKnownIntersection(known)
 
-	nowInt := now.UnixNano()
 	for _, k := range known {
-		bs.known[k] = nowInt
+		info := bs.unsafeKnownInfo(k, now)
+		info.LastAttemptNanos = now.UnixNano()
+		bs.known[k] = info
 	}
 
 	if err := bs.unsafeSyncKnown(known, backup); err != nil {
@@ -175,6 +195,48 @@ func (bs *CBORStorage) AddOrUpdateKnown(known []KnownPeer, now time.Time) error
 	return nil
 }
 
+// RecordKnownHandshake stores the successful handshake with the known peer, the failures counter is reset.
+func (bs *CBORStorage) RecordKnownHandshake(known KnownPeer, version, appName string, now time.Time) error {
+	bs.rwMutex.Lock()
+	defer bs.rwMutex.Unlock()
+
+	backup := bs.unsafeKnownIntersection([]KnownPeer{known})
+
+	info := bs.unsafeKnownInfo(known, now)
+	info.LastAttemptNanos = now.UnixNano()
+	info.LastHandshakeMillis = now.UnixMilli()
+	info.Failures = 0
+	info.Version = version
+	info.AppName = appName
+	bs.known[known] = info
+
+	if err := bs.unsafeSyncKnown([]KnownPeer{known}, backup); err != nil {
+		return errors.Wrapf(err, "failed to record handshake with known peer %q", known.String())
+	}
+	return nil
+}
+
+// RecordKnownFailure stores the failed connection attempt started at the given time. The attempt is not counted
+// as failed if the handshake has been completed after the start of the attempt.
+func (bs *CBORStorage) RecordKnownFailure(known KnownPeer, attempt, now time.Time) error {
+	bs.rwMutex.Lock()
+	defer bs.rwMutex.Unlock()
+
+	backup := bs.unsafeKnownIntersection([]KnownPeer{known})
+
+	info := bs.unsafeKnownInfo(known, now)
+	info.LastAttemptNanos = now.UnixNano()
+	if info.LastHandshakeMillis < attempt.UnixMilli() {
+		info.Failures++
+	}
+	bs.known[known] = info
+
+	if err := bs.unsafeSyncKnown([]KnownPeer{known}, backup); err != nil {
+		return errors.Wrapf(err, "failed to record connection failure of known peer %q", known.String())
+	}
+	return nil
+}
+
 // DeleteKnown removes known peers from peers storage with strong error guarantees.
 func (bs *CBORStorage) DeleteKnown(known []KnownPeer) error {
 	if len(known) == 0 {
@@ -205,6 +267,47 @@ func (bs *CBORStorage) DropKnown() error {
 	return bs.unsafeDropKnown()
 }
 
+// Pinned returns addresses of the pinned peers.
+func (bs *CBORStorage) Pinned() []KnownPeer {
+	bs.rwMutex.RLock()
+	defer bs.rwMutex.RUnlock()
+	out := make([]KnownPeer, 0, len(bs.pinned))
+	for p := range bs.pinned {
+		out = append(out, p)
+	}
+	return out
+}
+
+// AddPinned adds the pinned peer into peers storage with strong error guarantees.
+func (bs *CBORStorage) AddPinned(pinned KnownPeer) error {
+	bs.rwMutex.Lock()
+	defer bs.rwMutex.Unlock()
+	if _, ok := bs.pinned[pinned]; ok {
+		return nil
+	}
+	bs.pinned[pinned] = struct{}{}
+	if err := marshalToCborAndSyncToFile(bs.pinnedFilePath, bs.pinned); err != nil {
+		delete(bs.pinned, pinned)
+		return errors.Wrapf(err, "failed to add pinned peer %q", pinned.String())
+	}
+	return nil
+}
+
+// DeletePinned removes the pinned peer from peers storage with strong error guarantees.
+func (bs *CBORStorage) DeletePinned(pinned KnownPeer) error {
+	bs.rwMutex.Lock()
+	defer bs.rwMutex.Unlock()
+	if _, ok := bs.pinned[pinned]; !ok {
+		return nil
+	}
+	delete(bs.pinned, pinned)
+	if err := marshalToCborAndSyncToFile(bs.pinnedFilePath, bs.pinned); err != nil {
+		bs.pinned[pinned] = struct{}{}
+		return errors.Wrapf(err, "failed to delete pinned peer %q", pinned.String())
+	}
+	return nil
+}
+
 func (bs *CBORStorage) restricted(now time.Time, restrictedID restrictedPeersID) []restrictedPeer {
 	bs.rwMutex.RLock()
 	defer bs.rwMutex.RUnlock()
@@ -376,7 +479,7 @@ func (bs *CBORStorage) DropBlackList() error {
 	return bs.dropRestricted(blackListedPeersID)
 }
 
-// DropStorage clear storage memory cache and truncates storage files.
+// DropStorage clear storage memory cache and truncates storage files. Pinned peers are kept.
 // In case of error we can lose suspended peers storage file, but honestly it's almost impossible case.
 func (bs *CBORStorage) DropStorage() error {
 	bs.rwMutex.Lock()
@@ -494,6 +597,15 @@ func (bs *CBORStorage) unsafeDropRestricted(restrictedID restrictedPeersID) erro
 	return nil
 }
 
+// unsafeKnownInfo returns the record of the known peer or the new record if the peer is seen for the first time.
+func (bs *CBORStorage) unsafeKnownInfo(known KnownPeer, now time.Time) KnownPeerInfo {
+	info, ok := bs.known[known]
+	if !ok || info.FirstSeenMillis == 0 {
+		info.FirstSeenMillis = now.UnixMilli()
+	}
+	return info
+}
+
 // unsafeKnownIntersection returns values from known map which intersects with input values
 func (bs *CBORStorage) unsafeKnownIntersection(known []KnownPeer) knownPeers {
 	intersection := knownPeers{}
@@ -566,6 +678,10 @@ func blackListFilePath(storageDir string) string {
 	return filepath.Join(storageDir, "peers_black_list.cbor")
 }
 
+func pinnedFilePath(storageDir string) string {
+	return filepath.Join(storageDir, "peers_pinned.cbor")
+}
+
 func storageVersionFilePath(storageDir string) string {
 	return filepath.Join(storageDir, "peers_storage_version.txt")
 }
@@ -115,13 +115,18 @@ func (s *binaryStorageCborSuite) TestCBORStorageKnown() {
 		KnownPeer(proto.NewIpPortFromTcpAddr(proto.NewTCPAddrFromString("42.54.1.6:54356"))),
 	}
 
-	setPeersTs := func(m knownPeers, list []KnownPeer, ts int64) {
+	setPeersTs := func(m knownPeers, list []KnownPeer, ts time.Time) {
 		for _, v := range list {
-			m[v] = ts
+			info := m[v]
+			if info.FirstSeenMillis == 0 {
+				info.FirstSeenMillis = ts.UnixMilli()
+			}
+			info.LastAttemptNanos = ts.UnixNano()
+			m[v] = info
 		}
 	}
 
-	initKnownMap := func(list []KnownPeer, ts int64) knownPeers {
+	initKnownMap := func(list []KnownPeer, ts time.Time) knownPeers {
 		knownMap := make(knownPeers)
 		setPeersTs(knownMap, list, ts)
 		return knownMap
@@ -132,16 +137,16 @@ func (s *binaryStorageCborSuite) TestCBORStorageKnown() {
 		require.NoError(s.T(), unmarshalCborFromFile(s.storage.knownFilePath, &unmarshalled))
 		assert.Equal(s.T(), len(known), len(unmarshalled))
 		// nickeskov: check that all marshaled data saved in file
-		for expectedPeer, expectedTs := range known {
-			ts, in := unmarshalled[expectedPeer]
+		for expectedPeer, expectedInfo := range known {
+			info, in := unmarshalled[expectedPeer]
 			require.True(s.T(), in)
-			require.Equal(s.T(), expectedTs, ts)
+			require.Equal(s.T(), expectedInfo, info)
 		}
 
 		// nickeskov: check that all data saved in cache
 		cachedKnown := make(knownPeers)
 		for _, k := range s.storage.Known(10) {
-			cachedKnown[k] = 0
+			cachedKnown[k] = KnownPeerInfo{}
 		}
 
 		for k := range cachedKnown {
@@ -154,16 +159,16 @@ func (s *binaryStorageCborSuite) TestCBORStorageKnown() {
 		// nickeskov: check empty input
 		require.NoError(s.T(), s.storage.AddOrUpdateKnown(nil, time.Time{}))
 
-		knownMap := initKnownMap(knownList, 0)
+		knownMap := make(knownPeers)
 
 		ts := time.Now()
-		setPeersTs(knownMap, knownList, ts.UnixNano())
+		setPeersTs(knownMap, knownList, ts)
 		require.NoError(s.T(), s.storage.AddOrUpdateKnown(knownList, ts))
 		check(knownMap)
 
 		// check input with same addresses and new timestamps
-		newTs := time.Now()
-		setPeersTs(knownMap, knownList, newTs.UnixNano())
+		newTs := ts.Add(time.Second)
+		setPeersTs(knownMap, knownList, newTs)
 		err := s.storage.AddOrUpdateKnown(knownList, newTs)
 		require.NoError(s.T(), err)
 		check(knownMap)
@@ -178,7 +183,7 @@ func (s *binaryStorageCborSuite) TestCBORStorageKnown() {
 
 		// fill known in storage
 		ts := time.Now()
-		knownMap := initKnownMap(knownList, ts.UnixNano())
+		knownMap := initKnownMap(knownList, ts)
 		require.NoError(s.T(), s.storage.AddOrUpdateKnown(knownList, ts))
 
 		// nickeskov: remove first entry
@@ -193,6 +198,41 @@ func (s *binaryStorageCborSuite) TestCBORStorageKnown() {
 		require.NoError(s.T(), s.storage.DropKnown())
 	})
 
+	s.Run("record handshakes and failures of known peers", func() {
+		ts := time.UnixMilli(time.Now().UnixMilli())
+		require.NoError(s.T(), s.storage.AddOrUpdateKnown(knownList, ts))
+
+		hsTs := ts.Add(time.Second)
+		require.NoError(s.T(), s.storage.RecordKnownHandshake(knownList[1], "1.5.0", "wavesW", hsTs))
+		require.NoError(s.T(), s.storage.RecordKnownFailure(knownList[0], ts, ts.Add(time.Second)))
+		require.NoError(s.T(), s.storage.RecordKnownFailure(knownList[0], ts, ts.Add(2*time.Second)))
+		// the connection was closed after the successful handshake, it's not a failure
+		require.NoError(s.T(), s.storage.RecordKnownFailure(knownList[1], ts, ts.Add(time.Minute)))
+
+		expected := initKnownMap(knownList, ts)
+		expected[knownList[0]] = KnownPeerInfo{
+			LastAttemptNanos: ts.Add(2 * time.Second).UnixNano(),
+			FirstSeenMillis:  ts.UnixMilli(),
+			Failures:         2,
+		}
+		expected[knownList[1]] = KnownPeerInfo{
+			LastAttemptNanos:    ts.Add(time.Minute).UnixNano(),
+			FirstSeenMillis:     ts.UnixMilli(),
+			LastHandshakeMillis: hsTs.UnixMilli(),
+			Version:             "1.5.0",
+			AppName:             "wavesW",
+		}
+		check(expected)
+
+		records := s.storage.KnownRecords(-1)
+		require.Len(s.T(), records, len(knownList))
+		assert.Equal(s.T(), knownList[1], records[0].Peer)
+		assert.Equal(s.T(), knownList[0], records[len(records)-1].Peer)
+		assert.Equal(s.T(), knownList[1], s.storage.Known(1)[0])
+
+		require.NoError(s.T(), s.storage.DropKnown())
+	})
+
 	s.Run("unsafe sync known peers bad storage file", func() {
 		defer func(knownStorageFile string) {
 			require.NoError(s.T(), os.Remove(s.storage.knownFilePath))
@@ -617,3 +657,25 @@ func (s *binaryStorageCborSuite) TestCBORStorageDropsAndVersioning() {
 		checkKnownStorageFile()
 	})
 }
+
+func (s *binaryStorageCborSuite) TestCBORStoragePinned() {
+	first := KnownPeer(proto.NewIpPortFromTcpAddr(proto.NewTCPAddrFromString("13.3.4.1:2345")))
+	second := KnownPeer(proto.NewIpPortFromTcpAddr(proto.NewTCPAddrFromString("3.54.1.9:1454")))
+
+	require.NoError(s.T(), s.storage.AddPinned(first))
+	require.NoError(s.T(), s.storage.AddPinned(second))
+	require.NoError(s.T(), s.storage.AddPinned(second))
+	require.NoError(s.T(), s.storage.DeletePinned(first))
+	require.NoError(s.T(), s.storage.DeletePinned(first))
+	assert.Equal(s.T(), []KnownPeer{second}, s.storage.Pinned())
+
+	var unmarshalled pinnedPeers
+	require.NoError(s.T(), unmarshalCborFromFile(s.storage.pinnedFilePath, &unmarshalled))
+	assert.Equal(s.T(), pinnedPeers{second: {}}, unmarshalled)
+
+	// Pinned peers are set by the node operator, so they are kept when the storage is dropped.
+	require.NoError(s.T(), s.storage.DropStorage())
+	storage, err := newCBORStorageInDir(s.storage.storageDir, s.now, peersStorageCurrentVersion)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []KnownPeer{second}, storage.Pinned())
+}
@@ -90,36 +90,74 @@ func NewBlackListedPeer(ip IP, blackListTimestampMillis int64, blackListDuration
 
 type blackListedPeers = restrictedPeers
 
-type pair struct {
-	peer KnownPeer
-	ts   int64
+// KnownPeerInfo is the record about the known peer kept in the peers storage.
+type KnownPeerInfo struct {
+	LastAttemptNanos    int64  `cbor:"0,keyasint,omitempty"` // Time of the last connection attempt.
+	FirstSeenMillis     int64  `cbor:"1,keyasint,omitempty"` // Time the peer address was learned.
+	LastHandshakeMillis int64  `cbor:"2,keyasint,omitempty"` // Time of the last successful handshake, zero if none.
+	Failures            uint32 `cbor:"3,keyasint,omitempty"` // Connection failures since the last successful handshake.
+	Version             string `cbor:"4,keyasint,omitempty"` // Protocol version advertised in the last handshake.
+	AppName             string `cbor:"5,keyasint,omitempty"` // Application name advertised in the last handshake.
 }
 
-type pairs []pair
+func (i *KnownPeerInfo) FirstSeen() time.Time {
+	return time.UnixMilli(i.FirstSeenMillis)
+}
+
+func (i *KnownPeerInfo) LastAttempt() time.Time {
+	return time.Unix(0, i.LastAttemptNanos)
+}
+
+func (i *KnownPeerInfo) LastHandshake() time.Time {
+	return time.UnixMilli(i.LastHandshakeMillis)
+}
+
+func (i *KnownPeerInfo) HasHandshake() bool {
+	return i.LastHandshakeMillis != 0
+}
 
-func (p pairs) Len() int { return len(p) }
+// KnownPeerRecord is the known peer together with its record.
+type KnownPeerRecord struct {
+	Peer KnownPeer
+	KnownPeerInfo
+}
 
-func (p pairs) Less(i, j int) bool { return p[i].ts < p[j].ts }
+// reconnectBefore reports whether the peer a has to be tried before the peer b. Peers with fewer failures go first,
+// among them the peers which have ever completed the handshake, then the peers which were tried long ago.
+func reconnectBefore(a, b KnownPeerInfo) bool {
+	if a.Failures != b.Failures {
+		return a.Failures < b.Failures
+	}
+	if a.HasHandshake() != b.HasHandshake() {
+		return a.HasHandshake()
+	}
+	return a.LastAttemptNanos < b.LastAttemptNanos
+}
 
-func (p pairs) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+type knownPeers map[KnownPeer]KnownPeerInfo
 
-type knownPeers map[KnownPeer]int64
+// pinnedPeers is the set of addresses of the peers pinned by the node operator.
+type pinnedPeers map[KnownPeer]struct{}
 
-func (a knownPeers) OldestFirst(limit int) []KnownPeer {
-	ps := make(pairs, len(a))
-	i := 0
+// Records returns at most limit known peers in the order of reconnection, negative limit means no limit.
+func (a knownPeers) Records(limit int) []KnownPeerRecord {
+	r := make([]KnownPeerRecord, 0, len(a))
 	for k, v := range a {
-		ps[i] = pair{k, v}
-		i++
+		r = append(r, KnownPeerRecord{Peer: k, KnownPeerInfo: v})
 	}
-	sort.Sort(ps)
-	l := len(ps)
-	if l > limit {
-		l = limit
+	sort.Slice(r, func(i, j int) bool { return reconnectBefore(r[i].KnownPeerInfo, r[j].KnownPeerInfo) })
+	if limit >= 0 && len(r) > limit {
+		r = r[:limit]
 	}
-	r := make([]KnownPeer, l)
-	for i := 0; i < l; i++ {
-		r[i] = ps[i].peer
+	return r
+}
+
+// ReconnectionOrder returns at most limit known peers in the order they have to be tried to connect to.
+func (a knownPeers) ReconnectionOrder(limit int) []KnownPeer {
+	records := a.Records(limit)
+	r := make([]KnownPeer, len(records))
+	for i := range records {
+		r[i] = records[i].Peer
 	}
 	return r
 }
@@ -31,18 +31,22 @@ func TestKnownPeer_IpPort(t *testing.T) {
 	require.Equal(t, ipPort.String(), k.String())
 }
 
-func TestKnownOldestFirst(t *testing.T) {
+func TestKnownReconnectionOrder(t *testing.T) {
 	p1 := KnownPeer(proto.NewIpPortFromTcpAddr(proto.NewTCPAddrFromString("1.2.3.4:1")))
 	p2 := KnownPeer(proto.NewIpPortFromTcpAddr(proto.NewTCPAddrFromString("1.2.3.4:2")))
 	p3 := KnownPeer(proto.NewIpPortFromTcpAddr(proto.NewTCPAddrFromString("1.2.3.4:3")))
 	p4 := KnownPeer(proto.NewIpPortFromTcpAddr(proto.NewTCPAddrFromString("1.2.3.4:4")))
+	p5 := KnownPeer(proto.NewIpPortFromTcpAddr(proto.NewTCPAddrFromString("1.2.3.4:5")))
 	ps := knownPeers{}
-	ps[p1] = 3
-	ps[p2] = 2
-	ps[p3] = 1
-	ps[p4] = 0
-
-	r := ps.OldestFirst(10)
-	expected := []KnownPeer{p4, p3, p2, p1}
+	ps[p1] = KnownPeerInfo{LastAttemptNanos: 3}
+	ps[p2] = KnownPeerInfo{LastAttemptNanos: 2}
+	ps[p3] = KnownPeerInfo{LastAttemptNanos: 1, Failures: 1}
+	ps[p4] = KnownPeerInfo{LastAttemptNanos: 0, Failures: 3}
+	ps[p5] = KnownPeerInfo{LastAttemptNanos: 4, LastHandshakeMillis: 1}
+
+	r := ps.ReconnectionOrder(10)
+	expected := []KnownPeer{p5, p2, p1, p3, p4}
 	assert.Equal(t, expected, r)
+	assert.Equal(t, expected[:2], ps.ReconnectionOrder(2))
+	assert.Len(t, ps.Records(-1), 5)
 }
@@ -0,0 +1,54 @@
+package publisher
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/pkg/errors"
+)
+
+const natsStreamMaxAge = 7 * 24 * time.Hour
+
+// NATSSink publishes messages to the JetStream stream. The stream capturing all subjects with the prefix is created
+// if it doesn't exist. Message IDs are passed to the server to drop duplicates of the retried messages.
+type NATSSink struct {
+	nc *nats.Conn
+	js jetstream.JetStream
+}
+
+// NewNATSSink connects to the NATS server by the URL and creates the stream for the subjects with the prefix.
+func NewNATSSink(ctx context.Context, url, prefix string) (*NATSSink, error) {
+	nc, err := nats.Connect(url, nats.Name("gowaves"), nats.MaxReconnects(-1))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to connect to NATS server '%s'", url)
+	}
+	js, err := jetstream.New(nc)
+	if err != nil {
+		nc.Close()
+		return nil, errors.Wrap(err, "failed to initialize JetStream")
+	}
+	_, err = js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:     strings.ToUpper(strings.ReplaceAll(prefix, ".", "_")),
+		Subjects: []string{prefix + ".>"},
+		MaxAge:   natsStreamMaxAge,
+	})
+	if err != nil {
+		nc.Close()
+		return nil, errors.Wrapf(err, "failed to create JetStream stream for '%s'", prefix)
+	}
+	return &NATSSink{nc: nc, js: js}, nil
+}
+
+// Publish sends the message and waits for the acknowledgement of the stream.
+func (s *NATSSink) Publish(ctx context.Context, subject, id string, data []byte) error {
+	_, err := s.js.Publish(ctx, subject, data, jetstream.WithMsgID(id))
+	return err
+}
+
+// Close drains the connection.
+func (s *NATSSink) Close() error {
+	return s.nc.Drain()
+}
@@ -0,0 +1,312 @@
+// Package publisher pushes blockchain updates to a message broker. Every applied block is published together with
+// its transactions and state changes, rollbacks are published as separate messages. Delivery is at-least-once:
+// a message is retried until the broker acknowledges it, and the height of the last published block is saved to
+// the cursor file, so publishing resumes from the next height after the restart.
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mr-tron/base58"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/wavesplatform/gowaves/pkg/node/events"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/state"
+	"github.com/wavesplatform/gowaves/pkg/state/stateerr"
+)
+
+// DefaultPrefix is the default prefix of the subjects messages are published to.
+const DefaultPrefix = "waves"
+
+const (
+	eventsBufferSize = 1000
+	initialBackoff   = time.Second
+	maxBackoff       = time.Minute
+)
+
+// Subjects the messages are published to, they are prefixed with Config.Prefix and a dot.
+const (
+	SubjectBlocks       = "blocks"
+	SubjectTransactions = "transactions"
+	SubjectStateChanges = "state_changes"
+	SubjectRollbacks    = "rollbacks"
+)
+
+// Sink is the message broker messages are published to. Publish must return only after the broker acknowledged
+// the message. ID is unique for the message content and can be used by the broker to drop duplicates.
+type Sink interface {
+	Publish(ctx context.Context, subject, id string, data []byte) error
+	Close() error
+}
+
+// Config of the publisher.
+type Config struct {
+	Prefix string // Prefix of the subjects.
+	// CursorFile keeps the height of the last published block, empty path disables resuming after restart.
+	CursorFile string
+	// FromHeight is the height to start publishing from if there is no cursor, zero means the next applied block.
+	FromHeight proto.Height
+}
+
+// Validate checks the configuration.
+func (c Config) Validate() error {
+	if c.Prefix == "" || strings.ContainsAny(c.Prefix, " *>") {
+		return errors.Errorf("invalid subject prefix %q", c.Prefix)
+	}
+	return nil
+}
+
+// BlockMessage is published to SubjectBlocks.
+type BlockMessage struct {
+	Height proto.Height `json:"height"`
+	Block  *proto.Block `json:"block"`
+}
+
+// TransactionMessage is published to SubjectTransactions for every transaction of the block.
+type TransactionMessage struct {
+	Height      proto.Height      `json:"height"`
+	BlockID     proto.BlockID     `json:"blockId"`
+	Transaction proto.Transaction `json:"transaction"`
+}
+
+// StateChangesMessage is published to SubjectStateChanges if the state keeps snapshots of blocks.
+type StateChangesMessage struct {
+	Height   proto.Height        `json:"height"`
+	BlockID  proto.BlockID       `json:"blockId"`
+	Snapshot proto.BlockSnapshot `json:"snapshot"`
+}
+
+// RollbackMessage is published to SubjectRollbacks, the block with the ID is the new top block.
+type RollbackMessage struct {
+	Height  proto.Height  `json:"height"`
+	BlockID proto.BlockID `json:"blockId"`
+}
+
+// Publisher publishes blockchain updates to the sink.
+type Publisher struct {
+	cfg     Config
+	st      state.StateInfo
+	sink    Sink
+	scheme  proto.Scheme
+	backoff time.Duration
+	height  proto.Height // height of the last published block, accessed by Run goroutine only
+}
+
+// New creates Publisher and reads the cursor.
+func New(cfg Config, st state.StateInfo, sink Sink, scheme proto.Scheme) (*Publisher, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	p := &Publisher{cfg: cfg, st: st, sink: sink, scheme: scheme, backoff: initialBackoff}
+	h, ok, err := p.readCursor()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read cursor from '%s'", cfg.CursorFile)
+	}
+	switch {
+	case ok:
+		p.height = h
+	case cfg.FromHeight > 0:
+		p.height = cfg.FromHeight - 1
+	default:
+		top, hErr := st.Height()
+		if hErr != nil {
+			return nil, errors.Wrap(hErr, "failed to get height")
+		}
+		p.height = top
+	}
+	return p, nil
+}
+
+func (p *Publisher) readCursor() (proto.Height, bool, error) {
+	if p.cfg.CursorFile == "" {
+		return 0, false, nil
+	}
+	data, err := os.ReadFile(filepath.Clean(p.cfg.CursorFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, err
+	}
+	h, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, false, err
+	}
+	return h, true, nil
+}
+
+func (p *Publisher) saveCursor(h proto.Height) error {
+	p.height = h
+	if p.cfg.CursorFile == "" {
+		return nil
+	}
+	tmp := p.cfg.CursorFile + ".tmp"
+	if err := os.WriteFile(tmp, []byte(strconv.FormatUint(h, 10)), 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, p.cfg.CursorFile)
+}
+
+// Run publishes the blocks missed since the cursor and then blockchain updates from the bus until the context
+// is done. The sink is closed on return.
+func (p *Publisher) Run(ctx context.Context, bus *events.Bus) {
+	sub := bus.Subscribe(eventsBufferSize, events.BlockApplied{}, events.Rollback{})
+	defer sub.Close()
+	defer func() {
+		if err := p.sink.Close(); err != nil {
+			zap.S().Warnf("Publisher: failed to close sink: %v", err)
+		}
+	}()
+	if err := p.catchUp(ctx); err != nil {
+		p.logError(ctx, err)
+		return
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-sub.Events():
+			if !ok {
+				return
+			}
+			var err error
+			switch t := e.(type) {
+			case events.BlockApplied:
+				err = p.blockApplied(ctx, t)
+			case events.Rollback:
+				err = p.rollback(ctx, t)
+			}
+			if err != nil {
+				p.logError(ctx, err)
+				return
+			}
+		}
+	}
+}
+
+func (p *Publisher) logError(ctx context.Context, err error) {
+	if ctx.Err() == nil {
+		zap.S().Errorf("Publisher is stopped: %v", err)
+	}
+}
+
+// catchUp publishes blocks from the one after the cursor up to the current height. If the blockchain was rolled
+// back below the cursor while the node was stopped, the rollback is published first.
+func (p *Publisher) catchUp(ctx context.Context) error {
+	top, err := p.st.Height()
+	if err != nil {
+		return errors.Wrap(err, "failed to get height")
+	}
+	if top < p.height {
+		id, hErr := p.st.HeightToBlockID(top)
+		if hErr != nil {
+			return errors.Wrapf(hErr, "failed to get block ID at height %d", top)
+		}
+		if rErr := p.rollback(ctx, events.Rollback{BlockID: id, Height: top}); rErr != nil {
+			return rErr
+		}
+	}
+	return p.publishUpTo(ctx, top)
+}
+
+// publishUpTo publishes blocks from the state after the last published one up to the given height.
+func (p *Publisher) publishUpTo(ctx context.Context, height proto.Height) error {
+	for h := p.height + 1; h <= height; h++ {
+		block, err := p.st.BlockByHeight(h)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get block at height %d", h)
+		}
+		if pErr := p.publishBlock(ctx, h, block); pErr != nil {
+			return pErr
+		}
+	}
+	return nil
+}
+
+func (p *Publisher) blockApplied(ctx context.Context, e events.BlockApplied) error {
+	if e.Height <= p.height {
+		return nil // already published on catch up
+	}
+	if err := p.publishUpTo(ctx, e.Height-1); err != nil {
+		return err
+	}
+	block, err := p.st.Block(e.BlockID)
+	if stateerr.IsNotFound(err) {
+		// The block is already replaced, the event about its successor is following.
+		return nil
+	} else if err != nil {
+		return errors.Wrapf(err, "failed to get block %s", e.BlockID)
+	}
+	return p.publishBlock(ctx, e.Height, block)
+}
+
+func (p *Publisher) rollback(ctx context.Context, e events.Rollback) error {
+	if e.Height >= p.height {
+		return nil // nothing published above the new top block
+	}
+	// Rollback to the same block may happen more than once, so the message ID must differ.
+	id := "rollback-" + e.BlockID.String() + "-" + strconv.FormatInt(time.Now().UnixNano(), 10)
+	if err := p.publish(ctx, SubjectRollbacks, id, RollbackMessage{Height: e.Height, BlockID: e.BlockID}); err != nil {
+		return err
+	}
+	return errors.Wrap(p.saveCursor(e.Height), "failed to save cursor")
+}
+
+// publishBlock publishes the block, its transactions and state changes and moves the cursor to the height.
+func (p *Publisher) publishBlock(ctx context.Context, height proto.Height, block *proto.Block) error {
+	id := block.BlockID()
+	if err := p.publish(ctx, SubjectBlocks, "block-"+id.String(), BlockMessage{Height: height, Block: block}); err != nil {
+		return err
+	}
+	for _, tx := range block.Transactions {
+		txID, err := tx.GetID(p.scheme)
+		if err != nil {
+			return errors.Wrap(err, "failed to get transaction ID")
+		}
+		msg := TransactionMessage{Height: height, BlockID: id, Transaction: tx}
+		if pErr := p.publish(ctx, SubjectTransactions, "tx-"+base58.Encode(txID)+"-"+id.String(), msg); pErr != nil {
+			return pErr
+		}
+	}
+	snapshot, err := p.st.SnapshotsAtHeight(height)
+	switch {
+	case err == nil:
+		msg := StateChangesMessage{Height: height, BlockID: id, Snapshot: snapshot}
+		if pErr := p.publish(ctx, SubjectStateChanges, "state-"+id.String(), msg); pErr != nil {
+			return pErr
+		}
+	case !stateerr.IsNotFound(err) && !stateerr.IsInvalidInput(err):
+		return errors.Wrapf(err, "failed to get state changes at height %d", height)
+	}
+	return errors.Wrap(p.saveCursor(height), "failed to save cursor")
+}
+
+// publish sends the message to the sink retrying until it's acknowledged or the context is done.
+func (p *Publisher) publish(ctx context.Context, subject, id string, msg any) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal message %s", id)
+	}
+	subject = p.cfg.Prefix + "." + subject
+	backoff := p.backoff
+	for {
+		pErr := p.sink.Publish(ctx, subject, id, data)
+		if pErr == nil {
+			return nil
+		}
+		zap.S().Warnf("Publisher: failed to publish message %s to %s, retrying in %s: %v", id, subject, backoff, pErr)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, maxBackoff)
+	}
+}
@@ -0,0 +1,127 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/keyvalue"
+	"github.com/wavesplatform/gowaves/pkg/mock"
+	"github.com/wavesplatform/gowaves/pkg/node/events"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+type message struct {
+	subject string
+	id      string
+	data    []byte
+}
+
+type memorySink struct {
+	mu       sync.Mutex
+	failures int
+	messages []message
+}
+
+func (s *memorySink) Publish(_ context.Context, subject, id string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("not acknowledged")
+	}
+	s.messages = append(s.messages, message{subject: subject, id: id, data: data})
+	return nil
+}
+
+func (s *memorySink) Close() error { return nil }
+
+func (s *memorySink) published() []message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]message(nil), s.messages...)
+}
+
+func newBlock(id byte, txs ...proto.Transaction) *proto.Block {
+	return &proto.Block{
+		BlockHeader: proto.BlockHeader{
+			Version: proto.ProtobufBlockVersion,
+			ID:      proto.NewBlockIDFromDigest(crypto.Digest{id}),
+		},
+		Transactions: txs,
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	require.NoError(t, Config{Prefix: DefaultPrefix}.Validate())
+	for _, prefix := range []string{"", "waves.>", "wa ves", "*"} {
+		assert.Error(t, Config{Prefix: prefix}.Validate(), prefix)
+	}
+}
+
+func TestPublisherResume(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sk, pk, err := crypto.GenerateKeyPair([]byte("sender"))
+	require.NoError(t, err)
+	tx := proto.NewUnsignedTransferWithProofs(3, pk, proto.NewOptionalAssetWaves(), proto.NewOptionalAssetWaves(),
+		1700000000000, 100, 100000, proto.NewRecipientFromAddress(proto.WavesAddress{}), nil)
+	require.NoError(t, tx.Sign(proto.TestNetScheme, sk))
+	block3 := newBlock(3, tx)
+	block4 := newBlock(4)
+	cursor := filepath.Join(t.TempDir(), "cursor")
+	require.NoError(t, os.WriteFile(cursor, []byte("2"), 0600))
+
+	st := mock.NewMockState(ctrl)
+	st.EXPECT().Height().Return(uint64(3), nil)
+	st.EXPECT().BlockByHeight(uint64(3)).Return(block3, nil)
+	st.EXPECT().SnapshotsAtHeight(uint64(3)).Return(proto.BlockSnapshot{}, nil)
+	st.EXPECT().Block(block4.BlockID()).Return(block4, nil)
+	st.EXPECT().SnapshotsAtHeight(uint64(4)).Return(proto.BlockSnapshot{}, keyvalue.ErrNotFound)
+
+	sink := &memorySink{failures: 2}
+	p, err := New(Config{Prefix: DefaultPrefix, CursorFile: cursor, FromHeight: 1}, st, sink, proto.TestNetScheme)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), p.height, "cursor takes precedence over the start height")
+	p.backoff = time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	bus := events.NewBus()
+	done := make(chan struct{})
+	go func() {
+		p.Run(ctx, bus)
+		close(done)
+	}()
+	require.Eventually(t, func() bool { return len(sink.published()) == 3 }, 5*time.Second, time.Millisecond)
+	bus.Publish(events.BlockApplied{BlockID: block3.BlockID(), Height: 3}) // already published on catch up
+	bus.Publish(events.BlockApplied{BlockID: block4.BlockID(), Height: 4})
+	bus.Publish(events.Rollback{BlockID: block3.BlockID(), Height: 3})
+	require.Eventually(t, func() bool { return len(sink.published()) == 5 }, 5*time.Second, time.Millisecond)
+	cancel()
+	<-done
+
+	msgs := sink.published()
+	subjects := make([]string, len(msgs))
+	for i, m := range msgs {
+		subjects[i] = m.subject
+	}
+	assert.Equal(t, []string{"waves.blocks", "waves.transactions", "waves.state_changes", "waves.blocks",
+		"waves.rollbacks"}, subjects)
+	assert.Equal(t, "tx-"+tx.ID.String()+"-"+block3.BlockID().String(), msgs[1].id)
+	var rollback RollbackMessage
+	require.NoError(t, json.Unmarshal(msgs[4].data, &rollback))
+	assert.Equal(t, RollbackMessage{Height: 3, BlockID: block3.BlockID()}, rollback)
+	data, err := os.ReadFile(cursor)
+	require.NoError(t, err)
+	assert.Equal(t, "3", string(data))
+}
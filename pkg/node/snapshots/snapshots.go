@@ -0,0 +1,213 @@
+// Package snapshots makes periodic verified copies of the node's state which can be used for quick recovery or
+// to bootstrap other nodes. Snapshot is a regular state directory, the node can be started on its copy.
+package snapshots
+
+import (
+	"cmp"
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/wavesplatform/gowaves/pkg/node/events"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+	"github.com/wavesplatform/gowaves/pkg/state"
+)
+
+// DefaultKeep is the default number of kept snapshots.
+const DefaultKeep = 3
+
+const (
+	dirPrefix        = "snapshot-"
+	tmpDir           = "snapshot.tmp"
+	eventsBufferSize = 100
+)
+
+// Config of the snapshots maker.
+type Config struct {
+	Dir   string // Directory to keep snapshots in.
+	Every uint64 // Snapshot is made when the height reaches the multiple of Every.
+	Keep  int    // Number of the most recent snapshots to keep.
+}
+
+// Validate checks the configuration.
+func (c Config) Validate() error {
+	if c.Dir == "" {
+		return errors.New("empty snapshots directory")
+	}
+	if c.Every == 0 {
+		return errors.New("snapshots interval must be positive")
+	}
+	if c.Keep <= 0 {
+		return errors.New("number of kept snapshots must be positive")
+	}
+	return nil
+}
+
+// Maker creates snapshots of the state when the blockchain height reaches the multiple of the configured interval.
+type Maker struct {
+	cfg      Config
+	st       state.State
+	settings *settings.BlockchainSettings
+	params   state.StateParams
+}
+
+// NewMaker creates Maker. Params are used to open snapshots for verification, they must be the same as the
+// parameters of the state.
+func NewMaker(cfg Config, st state.State, bs *settings.BlockchainSettings, params state.StateParams) (*Maker, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(cfg.Dir, 0750); err != nil {
+		return nil, errors.Wrap(err, "failed to create snapshots directory")
+	}
+	return &Maker{cfg: cfg, st: st, settings: bs, params: params}, nil
+}
+
+// Run makes snapshots on BlockApplied events until the context is done.
+func (m *Maker) Run(ctx context.Context, bus *events.Bus) {
+	sub := bus.Subscribe(eventsBufferSize, events.BlockApplied{})
+	defer sub.Close()
+	next := m.nextHeight()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-sub.Events():
+			if !ok {
+				return
+			}
+			ba, ok := e.(events.BlockApplied)
+			if !ok || ba.Height < next {
+				continue
+			}
+			if err := m.Make(); err != nil {
+				zap.S().Errorf("Failed to make state snapshot: %v", err)
+			}
+			next = (ba.Height/m.cfg.Every + 1) * m.cfg.Every
+		}
+	}
+}
+
+// nextHeight returns the height of the next snapshot after the existing ones.
+func (m *Maker) nextHeight() proto.Height {
+	existing, err := m.List()
+	if err != nil || len(existing) == 0 {
+		return m.cfg.Every
+	}
+	last := existing[len(existing)-1].Height
+	return (last/m.cfg.Every + 1) * m.cfg.Every
+}
+
+// Make creates a new snapshot, verifies it and removes the outdated ones. The state is blocked only while the
+// consistent point of the snapshot is captured, blocks are applied and rolled back while the snapshot is written.
+func (m *Maker) Make() error {
+	tmp := filepath.Join(m.cfg.Dir, tmpDir)
+	if err := os.RemoveAll(tmp); err != nil {
+		return errors.Wrap(err, "failed to clean up unfinished snapshot")
+	}
+	if err := m.st.Backup(tmp); err != nil {
+		return m.discard(tmp, errors.Wrap(err, "failed to backup state"))
+	}
+	height, err := m.verify(tmp)
+	if err != nil {
+		return m.discard(tmp, errors.Wrap(err, "snapshot verification failed"))
+	}
+	path := filepath.Join(m.cfg.Dir, dirPrefix+strconv.FormatUint(height, 10))
+	if rmErr := os.RemoveAll(path); rmErr != nil {
+		return m.discard(tmp, errors.Wrap(rmErr, "failed to remove previous snapshot at the same height"))
+	}
+	if rnErr := os.Rename(tmp, path); rnErr != nil {
+		return m.discard(tmp, errors.Wrap(rnErr, "failed to finish snapshot"))
+	}
+	zap.S().Infof("State snapshot at height %d is created in '%s'", height, path)
+	return m.rotate()
+}
+
+func (m *Maker) discard(dir string, err error) error {
+	if rmErr := os.RemoveAll(dir); rmErr != nil {
+		zap.S().Errorf("Failed to remove discarded snapshot '%s': %v", dir, rmErr)
+	}
+	return err
+}
+
+// verify opens the snapshot in read-only mode, checks that its top block is readable and returns its height.
+func (m *Maker) verify(dir string) (_ proto.Height, retErr error) {
+	params := m.params
+	params.DbParams.ReadOnly = true
+	snapshot, err := state.NewState(dir, false, params, m.settings, false)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to open snapshot")
+	}
+	defer func() {
+		if clErr := snapshot.Close(); clErr != nil && retErr == nil {
+			retErr = errors.Wrap(clErr, "failed to close snapshot")
+		}
+	}()
+	height, err := snapshot.Height()
+	if err != nil {
+		return 0, err
+	}
+	id, err := snapshot.HeightToBlockID(height)
+	if err != nil {
+		return 0, err
+	}
+	if _, hErr := snapshot.HeaderByHeight(height); hErr != nil {
+		return 0, errors.Wrapf(hErr, "failed to read top block header at height %d", height)
+	}
+	if expected, eErr := m.st.HeightToBlockID(height); eErr == nil && expected != id {
+		// The node has switched to another fork after the snapshot was made, it's still a valid state.
+		zap.S().Warnf("Snapshot top block %s at height %d is not in the current blockchain", id, height)
+	}
+	return height, nil
+}
+
+// Snapshot describes a stored snapshot.
+type Snapshot struct {
+	Path   string
+	Height proto.Height
+}
+
+// List returns the stored snapshots ordered by height.
+func (m *Maker) List() ([]Snapshot, error) {
+	entries, err := os.ReadDir(m.cfg.Dir)
+	if err != nil {
+		return nil, err
+	}
+	var res []Snapshot
+	for _, e := range entries {
+		name := e.Name()
+		if !e.IsDir() || !strings.HasPrefix(name, dirPrefix) {
+			continue
+		}
+		h, pErr := strconv.ParseUint(strings.TrimPrefix(name, dirPrefix), 10, 64)
+		if pErr != nil {
+			continue
+		}
+		res = append(res, Snapshot{Path: filepath.Join(m.cfg.Dir, name), Height: h})
+	}
+	slices.SortFunc(res, func(a, b Snapshot) int { return cmp.Compare(a.Height, b.Height) })
+	return res, nil
+}
+
+// rotate removes the oldest snapshots exceeding the configured number.
+func (m *Maker) rotate() error {
+	existing, err := m.List()
+	if err != nil {
+		return errors.Wrap(err, "failed to list snapshots")
+	}
+	for len(existing) > m.cfg.Keep {
+		if rmErr := os.RemoveAll(existing[0].Path); rmErr != nil {
+			return errors.Wrapf(rmErr, "failed to remove outdated snapshot '%s'", existing[0].Path)
+		}
+		zap.S().Infof("Outdated state snapshot '%s' is removed", existing[0].Path)
+		existing = existing[1:]
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package snapshots
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+	"github.com/wavesplatform/gowaves/pkg/state"
+)
+
+func TestConfigValidate(t *testing.T) {
+	assert.NoError(t, Config{Dir: "dir", Every: 1000, Keep: 1}.Validate())
+	assert.Error(t, Config{Every: 1000, Keep: 1}.Validate())
+	assert.Error(t, Config{Dir: "dir", Keep: 1}.Validate())
+	assert.Error(t, Config{Dir: "dir", Every: 1000}.Validate())
+}
+
+func TestMakerListAndRotate(t *testing.T) {
+	dir := t.TempDir()
+	m, err := NewMaker(Config{Dir: dir, Every: 1000, Keep: 2}, nil, nil, state.DefaultStateParams())
+	require.NoError(t, err)
+	assert.Equal(t, proto.Height(1000), m.nextHeight())
+
+	for _, name := range []string{"snapshot-3000", "snapshot-1000", "snapshot-2000", tmpDir, "other"} {
+		require.NoError(t, os.Mkdir(filepath.Join(dir, name), 0750))
+	}
+	list, err := m.List()
+	require.NoError(t, err)
+	require.Len(t, list, 3)
+	assert.Equal(t, proto.Height(1000), list[0].Height)
+	assert.Equal(t, proto.Height(3000), list[2].Height)
+	assert.Equal(t, proto.Height(4000), m.nextHeight())
+
+	require.NoError(t, m.rotate())
+	list, err = m.List()
+	require.NoError(t, err)
+	assert.Equal(t, []Snapshot{
+		{Path: filepath.Join(dir, "snapshot-2000"), Height: 2000},
+		{Path: filepath.Join(dir, "snapshot-3000"), Height: 3000},
+	}, list)
+	_, err = os.Stat(filepath.Join(dir, "other"))
+	assert.NoError(t, err)
+}
+
+func TestMakerMake(t *testing.T) {
+	bs := settings.MustMainNetSettings()
+	params := state.DefaultTestingStateParams()
+	st, err := state.NewState(t.TempDir(), true, params, bs, false)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, st.Close()) }()
+
+	dir := t.TempDir()
+	m, err := NewMaker(Config{Dir: dir, Every: 1, Keep: 1}, st, bs, params)
+	require.NoError(t, err)
+	require.NoError(t, m.Make())
+	list, err := m.List()
+	require.NoError(t, err)
+	assert.Equal(t, []Snapshot{{Path: filepath.Join(dir, "snapshot-1"), Height: 1}}, list)
+	_, err = os.Stat(filepath.Join(dir, tmpDir))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,307 @@
+// Package watchlist tracks transactions of the addresses registered by the node operator. Transactions of watched
+// addresses are indexed in memory as soon as they are included in a block and published on the event bus as
+// WatchedTransaction events, so they are delivered to subscribers such as webhooks.
+package watchlist
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/mr-tron/base58"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/wavesplatform/gowaves/pkg/node/events"
+	"github.com/wavesplatform/gowaves/pkg/node/webhooks"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/state"
+)
+
+// DefaultIndexSize is the default number of the most recent transactions indexed per address.
+const DefaultIndexSize = 1000
+
+const eventsBufferSize = 1000
+
+// ErrNotWatched is returned for the address which is not in the watch list.
+var ErrNotWatched = errors.New("address is not watched")
+
+// Match is an indexed transaction of the watched address.
+type Match struct {
+	Transaction string        `json:"id"`
+	Height      proto.Height  `json:"height"`
+	BlockID     proto.BlockID `json:"blockId"`
+	Incoming    bool          `json:"incoming"`
+	Outgoing    bool          `json:"outgoing"`
+}
+
+// Watchlist keeps the watched addresses and the index of their recent transactions. Addresses are saved to the file
+// if the path is set, the index is built from the moment the address is added and is not persisted.
+type Watchlist struct {
+	mu     sync.Mutex
+	path   string
+	st     state.StateInfo
+	scheme proto.Scheme
+	size   int
+	index  map[proto.WavesAddress][]Match // oldest transactions go first
+
+	// Fields below are accessed by Run goroutine only.
+	notified       map[string]struct{} // IDs of published transactions of the block at notifiedHeight
+	notifiedHeight proto.Height
+}
+
+// New creates Watchlist which indexes the given number of the most recent transactions per address and loads
+// the addresses saved to the file, empty path disables persistence.
+func New(path string, st state.StateInfo, scheme proto.Scheme, size int) (*Watchlist, error) {
+	if size <= 0 {
+		return nil, errors.New("index size must be positive")
+	}
+	w := &Watchlist{
+		path:     path,
+		st:       st,
+		scheme:   scheme,
+		size:     size,
+		index:    make(map[proto.WavesAddress][]Match),
+		notified: make(map[string]struct{}),
+	}
+	if err := w.load(); err != nil {
+		return nil, errors.Wrapf(err, "failed to load watch list from '%s'", path)
+	}
+	return w, nil
+}
+
+func (w *Watchlist) load() error {
+	if w.path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(filepath.Clean(w.path))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	var addresses []proto.WavesAddress
+	if uErr := json.Unmarshal(data, &addresses); uErr != nil {
+		return uErr
+	}
+	for _, a := range addresses {
+		w.index[a] = nil
+	}
+	return nil
+}
+
+// save writes the addresses to the file, must be called under the lock.
+func (w *Watchlist) save() error {
+	if w.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(w.addresses(), "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := w.path + ".tmp"
+	if wErr := os.WriteFile(tmp, data, 0600); wErr != nil {
+		return wErr
+	}
+	return os.Rename(tmp, w.path)
+}
+
+// Add puts the addresses to the watch list, already watched addresses are ignored.
+func (w *Watchlist) Add(addresses ...proto.WavesAddress) error {
+	for _, a := range addresses {
+		if ok, err := a.Valid(w.scheme); !ok {
+			return errors.Wrapf(err, "invalid address %q", a.String())
+		}
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var added []proto.WavesAddress
+	for _, a := range addresses {
+		if _, ok := w.index[a]; !ok {
+			w.index[a] = nil
+			added = append(added, a)
+		}
+	}
+	if err := w.save(); err != nil {
+		for _, a := range added {
+			delete(w.index, a)
+		}
+		return errors.Wrap(err, "failed to save watch list")
+	}
+	return nil
+}
+
+// Remove deletes the address and its indexed transactions from the watch list.
+func (w *Watchlist) Remove(addr proto.WavesAddress) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	matches, ok := w.index[addr]
+	if !ok {
+		return ErrNotWatched
+	}
+	delete(w.index, addr)
+	if err := w.save(); err != nil {
+		w.index[addr] = matches
+		return errors.Wrap(err, "failed to save watch list")
+	}
+	return nil
+}
+
+// Addresses returns the watched addresses.
+func (w *Watchlist) Addresses() []proto.WavesAddress {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.addresses()
+}
+
+func (w *Watchlist) addresses() []proto.WavesAddress {
+	res := make([]proto.WavesAddress, 0, len(w.index))
+	for a := range w.index {
+		res = append(res, a)
+	}
+	slices.SortFunc(res, func(a, b proto.WavesAddress) int { return strings.Compare(a.String(), b.String()) })
+	return res
+}
+
+// Transactions returns the indexed transactions of the watched address, the most recent go first.
+func (w *Watchlist) Transactions(addr proto.WavesAddress) ([]Match, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	matches, ok := w.index[addr]
+	if !ok {
+		return nil, ErrNotWatched
+	}
+	res := append(make([]Match, 0, len(matches)), matches...)
+	slices.Reverse(res)
+	return res, nil
+}
+
+// Run indexes transactions of the applied blocks and publishes WatchedTransaction events on the bus until
+// the context is done.
+func (w *Watchlist) Run(ctx context.Context, bus *events.Bus) {
+	sub := bus.Subscribe(eventsBufferSize, events.BlockApplied{}, events.Rollback{})
+	defer sub.Close()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-sub.Events():
+			if !ok {
+				return
+			}
+			switch t := e.(type) {
+			case events.BlockApplied:
+				w.blockApplied(bus, t)
+			case events.Rollback:
+				w.rollback(t)
+			}
+		}
+	}
+}
+
+func (w *Watchlist) empty() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.index) == 0
+}
+
+func (w *Watchlist) blockApplied(bus *events.Bus, e events.BlockApplied) {
+	if e.Height != w.notifiedHeight {
+		// New key block is applied, otherwise it's a new version of the liquid block with the same transactions
+		// at the beginning, so the published transactions are kept.
+		clear(w.notified)
+		w.notifiedHeight = e.Height
+	}
+	if w.empty() {
+		return
+	}
+	block, err := w.st.Block(e.BlockID)
+	if err != nil {
+		zap.S().Warnf("Watch list: failed to get block %s: %v", e.BlockID, err)
+		return
+	}
+	for _, tx := range block.Transactions {
+		if mErr := w.match(bus, tx, e); mErr != nil {
+			zap.S().Warnf("Watch list: failed to match transaction: %v", mErr)
+		}
+	}
+}
+
+func (w *Watchlist) match(bus *events.Bus, tx proto.Transaction, e events.BlockApplied) error {
+	b, err := tx.GetID(w.scheme)
+	if err != nil {
+		return errors.Wrap(err, "failed to get transaction ID")
+	}
+	id := base58.Encode(b)
+	recipients, err := webhooks.TransactionRecipients(tx, w.scheme, w.st.AddrByAlias)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get recipients of transaction %s", id)
+	}
+	var sender proto.WavesAddress
+	if _, ok := tx.(*proto.Genesis); !ok {
+		s, sErr := tx.GetSender(w.scheme)
+		if sErr != nil {
+			return errors.Wrapf(sErr, "failed to get sender of transaction %s", id)
+		}
+		if sender, sErr = s.ToWavesAddress(w.scheme); sErr != nil {
+			return sErr
+		}
+	}
+	var found []events.WatchedTransaction
+	w.mu.Lock()
+	for addr := range w.index {
+		m := Match{Transaction: id, Height: e.Height, BlockID: e.BlockID,
+			Incoming: slices.Contains(recipients, addr), Outgoing: addr == sender}
+		if !m.Incoming && !m.Outgoing {
+			continue
+		}
+		w.add(addr, m)
+		found = append(found, events.WatchedTransaction{Address: addr, Transaction: tx, BlockID: e.BlockID,
+			Height: e.Height, Incoming: m.Incoming, Outgoing: m.Outgoing})
+	}
+	w.mu.Unlock()
+	if _, ok := w.notified[id]; ok || len(found) == 0 {
+		return nil
+	}
+	w.notified[id] = struct{}{}
+	for _, f := range found {
+		bus.Publish(f)
+	}
+	return nil
+}
+
+// add appends the match to the index of the address, must be called under the lock.
+func (w *Watchlist) add(addr proto.WavesAddress, m Match) {
+	matches := w.index[addr]
+	for i := len(matches) - 1; i >= 0 && matches[i].Height == m.Height; i-- {
+		if matches[i].Transaction == m.Transaction {
+			matches[i] = m // the same transaction in the new version of the liquid block
+			return
+		}
+	}
+	matches = append(matches, m)
+	if len(matches) > w.size {
+		matches = slices.Delete(matches, 0, len(matches)-w.size)
+	}
+	w.index[addr] = matches
+}
+
+// rollback removes the transactions of the rolled back blocks from the index.
+func (w *Watchlist) rollback(e events.Rollback) {
+	if e.Height+1 < w.notifiedHeight {
+		clear(w.notified) // the block with published transactions is removed
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for addr, matches := range w.index {
+		i := len(matches)
+		for i > 0 && matches[i-1].Height > e.Height {
+			i--
+		}
+		w.index[addr] = matches[:i]
+	}
+}
@@ -0,0 +1,113 @@
+package watchlist
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/mock"
+	"github.com/wavesplatform/gowaves/pkg/node/events"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+func newAddress(t *testing.T, seed string) (crypto.SecretKey, crypto.PublicKey, proto.WavesAddress) {
+	sk, pk, err := crypto.GenerateKeyPair([]byte(seed))
+	require.NoError(t, err)
+	addr, err := proto.NewAddressFromPublicKey(proto.TestNetScheme, pk)
+	require.NoError(t, err)
+	return sk, pk, addr
+}
+
+func TestWatchlistPersistence(t *testing.T) {
+	_, _, addr1 := newAddress(t, "first")
+	_, _, addr2 := newAddress(t, "second")
+	path := filepath.Join(t.TempDir(), "watchlist.json")
+	w, err := New(path, nil, proto.TestNetScheme, DefaultIndexSize)
+	require.NoError(t, err)
+	require.NoError(t, w.Add(addr1, addr2, addr1))
+	assert.ElementsMatch(t, []proto.WavesAddress{addr1, addr2}, w.Addresses())
+	assert.Error(t, w.Add(proto.WavesAddress{}))
+
+	restored, err := New(path, nil, proto.TestNetScheme, DefaultIndexSize)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []proto.WavesAddress{addr1, addr2}, restored.Addresses())
+	require.NoError(t, restored.Remove(addr1))
+	assert.ErrorIs(t, restored.Remove(addr1), ErrNotWatched)
+	_, err = restored.Transactions(addr1)
+	assert.ErrorIs(t, err, ErrNotWatched)
+
+	restored, err = New(path, nil, proto.TestNetScheme, DefaultIndexSize)
+	require.NoError(t, err)
+	assert.Equal(t, []proto.WavesAddress{addr2}, restored.Addresses())
+}
+
+func TestWatchlistIndex(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sk, senderPK, sender := newAddress(t, "sender")
+	_, _, recipient := newAddress(t, "recipient")
+	tx := proto.NewUnsignedTransferWithProofs(3, senderPK, proto.NewOptionalAssetWaves(),
+		proto.NewOptionalAssetWaves(), 1700000000000, 100, 100000, proto.NewRecipientFromAddress(recipient), nil)
+	require.NoError(t, tx.Sign(proto.TestNetScheme, sk))
+	id := proto.NewBlockIDFromDigest(crypto.MustDigestFromBase58("8vgMmBp3KS3VKXJmz3LNQvgQ4ELRRtvXPbXTbEd3iJyo"))
+
+	st := mock.NewMockState(ctrl)
+	st.EXPECT().Block(id).Return(&proto.Block{Transactions: proto.Transactions{tx}}, nil).Times(2)
+	w, err := New("", st, proto.TestNetScheme, DefaultIndexSize)
+	require.NoError(t, err)
+	require.NoError(t, w.Add(sender, recipient))
+
+	bus := events.NewBus()
+	watched := bus.Subscribe(10, events.WatchedTransaction{})
+	defer watched.Close()
+	w.blockApplied(bus, events.BlockApplied{BlockID: id, Height: 10})
+	w.rollback(events.Rollback{BlockID: id, Height: 9}) // liquid block is replaced with its new version
+	w.blockApplied(bus, events.BlockApplied{BlockID: id, Height: 10})
+
+	var published []events.WatchedTransaction
+	for len(published) < 2 {
+		select {
+		case e := <-watched.Events():
+			published = append(published, e.(events.WatchedTransaction))
+		case <-time.After(time.Second):
+			require.FailNow(t, "event is not published")
+		}
+	}
+	for _, e := range published {
+		assert.Equal(t, e.Address == recipient, e.Incoming)
+		assert.Equal(t, e.Address == sender, e.Outgoing)
+	}
+	assert.Empty(t, watched.Events(), "transaction of the liquid block is published once")
+
+	matches, err := w.Transactions(recipient)
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, Match{Transaction: tx.ID.String(), Height: 10, BlockID: id, Incoming: true}, matches[0])
+
+	w.rollback(events.Rollback{BlockID: id, Height: 5})
+	matches, err = w.Transactions(sender)
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+}
+
+func TestWatchlistRun(t *testing.T) {
+	w, err := New("", nil, proto.TestNetScheme, 2)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		w.Run(ctx, events.NewBus())
+		close(done)
+	}()
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "watch list is not stopped")
+	}
+}
@@ -0,0 +1,91 @@
+package webhooks
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+// TransactionAddresses returns the sender and the recipients of the transaction. Aliases of recipients are
+// resolved with the given function. Addresses affected only by the script execution are not returned.
+func TransactionAddresses(
+	tx proto.Transaction, scheme proto.Scheme, resolve func(alias proto.Alias) (proto.WavesAddress, error),
+) ([]proto.WavesAddress, error) {
+	recipients, err := TransactionRecipients(tx, scheme, resolve)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := tx.(*proto.Genesis); ok {
+		return recipients, nil
+	}
+	sender, err := tx.GetSender(scheme)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get transaction sender")
+	}
+	wa, err := sender.ToWavesAddress(scheme)
+	if err != nil {
+		return nil, err
+	}
+	return append([]proto.WavesAddress{wa}, recipients...), nil
+}
+
+// TransactionRecipients returns the addresses receiving funds with the transaction. For exchange transactions
+// the senders of both orders are returned. Aliases are resolved with the given function.
+func TransactionRecipients(
+	tx proto.Transaction, scheme proto.Scheme, resolve func(alias proto.Alias) (proto.WavesAddress, error),
+) ([]proto.WavesAddress, error) {
+	var res []proto.WavesAddress
+	addRecipient := func(r proto.Recipient) error {
+		if addr := r.Address(); addr != nil {
+			res = append(res, *addr)
+			return nil
+		}
+		if alias := r.Alias(); alias != nil {
+			addr, err := resolve(*alias)
+			if err != nil {
+				return errors.Wrapf(err, "failed to resolve alias %q", alias.String())
+			}
+			res = append(res, addr)
+		}
+		return nil
+	}
+	var err error
+	switch t := tx.(type) {
+	case *proto.Genesis:
+		res = append(res, t.Recipient)
+	case *proto.Payment:
+		res = append(res, t.Recipient)
+	case *proto.TransferWithSig:
+		err = addRecipient(t.Recipient)
+	case *proto.TransferWithProofs:
+		err = addRecipient(t.Recipient)
+	case *proto.MassTransferWithProofs:
+		for i := range t.Transfers {
+			if err = addRecipient(t.Transfers[i].Recipient); err != nil {
+				break
+			}
+		}
+	case *proto.LeaseWithSig:
+		err = addRecipient(t.Recipient)
+	case *proto.LeaseWithProofs:
+		err = addRecipient(t.Recipient)
+	case *proto.InvokeScriptWithProofs:
+		err = addRecipient(t.ScriptRecipient)
+	case proto.Exchange:
+		for _, o := range []proto.Order{t.GetOrder1(), t.GetOrder2()} {
+			sender, sErr := o.GetSender(scheme)
+			if sErr != nil {
+				return nil, errors.Wrap(sErr, "failed to get order sender")
+			}
+			wa, wErr := sender.ToWavesAddress(scheme)
+			if wErr != nil {
+				return nil, wErr
+			}
+			res = append(res, wa)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
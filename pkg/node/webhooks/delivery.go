@@ -0,0 +1,122 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	// SignatureHeader contains hex encoded HMAC-SHA256 of the payload prefixed with "sha256=".
+	SignatureHeader = "X-Waves-Signature"
+	// EventHeader contains the type of the event.
+	EventHeader = "X-Waves-Event"
+
+	queueSize      = 1000
+	maxAttempts    = 5
+	initialBackoff = time.Second
+	requestTimeout = 10 * time.Second
+)
+
+// Sign returns the value of SignatureHeader for the payload signed with the secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// sender delivers payloads of one webhook in order of their appearance.
+type sender struct {
+	hook    Webhook
+	client  *http.Client
+	queue   chan Payload
+	backoff time.Duration
+	cancel  context.CancelFunc
+}
+
+func newSender(hook Webhook, client *http.Client, backoff time.Duration) *sender {
+	return &sender{hook: hook, client: client, queue: make(chan Payload, queueSize), backoff: backoff}
+}
+
+// enqueue schedules the delivery of the payload, the payload is dropped if the endpoint doesn't keep up.
+func (s *sender) enqueue(p Payload) {
+	select {
+	case s.queue <- p:
+	default:
+		zap.S().Warnf("Webhook %s: queue is full, %q notification at height %d is dropped", s.hook.ID, p.Event, p.Height)
+	}
+}
+
+func (s *sender) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case p := <-s.queue:
+			s.deliver(ctx, p)
+		}
+	}
+}
+
+// deliver sends the payload retrying with exponential backoff until the endpoint replies with 2xx status.
+func (s *sender) deliver(ctx context.Context, p Payload) {
+	body, err := json.Marshal(p)
+	if err != nil {
+		zap.S().Errorf("Webhook %s: failed to marshal payload: %v", s.hook.ID, err)
+		return
+	}
+	backoff := s.backoff
+	for attempt := 1; ; attempt++ {
+		err = s.post(ctx, p.Event, body)
+		if err == nil {
+			return
+		}
+		if attempt == maxAttempts {
+			zap.S().Warnf("Webhook %s: %q notification is dropped after %d attempts: %v",
+				s.hook.ID, p.Event, attempt, err)
+			return
+		}
+		zap.S().Debugf("Webhook %s: attempt %d failed: %v", s.hook.ID, attempt, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+			backoff *= 2
+		}
+	}
+}
+
+func (s *sender) post(ctx context.Context, event EventType, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, string(event))
+	if s.hook.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(s.hook.Secret, body))
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return errors.Errorf("unexpected status %q", resp.Status)
+	}
+	return nil
+}
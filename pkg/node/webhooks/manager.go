@@ -0,0 +1,319 @@
+package webhooks
+
+import (
+	"cmp"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/mr-tron/base58"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/wavesplatform/gowaves/pkg/node/events"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/state"
+)
+
+const (
+	eventsBufferSize = 1000
+	idSize           = 8
+)
+
+// ErrNotFound is returned on removal of unknown webhook.
+var ErrNotFound = errors.New("webhook not found")
+
+// Manager keeps the registered webhooks and sends them notifications about events published on the bus.
+// If the path is set, webhooks are saved to the file and restored on the next start.
+type Manager struct {
+	mu      sync.Mutex
+	path    string
+	st      state.StateInfo
+	scheme  proto.Scheme
+	client  *http.Client
+	backoff time.Duration
+	ctx     context.Context // set by Run, senders are started when it's set
+	senders map[string]*sender
+
+	// Fields below are accessed by Run goroutine only.
+	height          proto.Height        // height of the top block
+	confirmed       map[string]struct{} // IDs of notified transactions of the block at confirmedHeight
+	confirmedHeight proto.Height
+}
+
+// NewManager creates Manager and loads the webhooks saved to the file, empty path disables persistence.
+func NewManager(path string, st state.StateInfo, scheme proto.Scheme) (*Manager, error) {
+	m := &Manager{
+		path:      path,
+		st:        st,
+		scheme:    scheme,
+		client:    &http.Client{},
+		backoff:   initialBackoff,
+		senders:   make(map[string]*sender),
+		confirmed: make(map[string]struct{}),
+	}
+	if err := m.load(); err != nil {
+		return nil, errors.Wrapf(err, "failed to load webhooks from '%s'", path)
+	}
+	return m, nil
+}
+
+func (m *Manager) load() error {
+	if m.path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(filepath.Clean(m.path))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	var hooks []Webhook
+	if uErr := json.Unmarshal(data, &hooks); uErr != nil {
+		return uErr
+	}
+	for _, h := range hooks {
+		if vErr := h.Validate(); vErr != nil {
+			return errors.Wrapf(vErr, "invalid webhook %q", h.ID)
+		}
+		m.senders[h.ID] = newSender(h, m.client, m.backoff)
+	}
+	return nil
+}
+
+// save writes the webhooks to the file, must be called under the lock.
+func (m *Manager) save() error {
+	if m.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(m.list(), "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := m.path + ".tmp"
+	if wErr := os.WriteFile(tmp, data, 0600); wErr != nil {
+		return wErr
+	}
+	return os.Rename(tmp, m.path)
+}
+
+// Add registers the webhook and returns it with the assigned ID.
+func (m *Manager) Add(w Webhook) (Webhook, error) {
+	if err := w.Validate(); err != nil {
+		return Webhook{}, err
+	}
+	id := make([]byte, idSize)
+	if _, err := rand.Read(id); err != nil {
+		return Webhook{}, errors.Wrap(err, "failed to generate webhook ID")
+	}
+	w.ID = hex.EncodeToString(id)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := newSender(w, m.client, m.backoff)
+	m.senders[w.ID] = s
+	if err := m.save(); err != nil {
+		delete(m.senders, w.ID)
+		return Webhook{}, errors.Wrap(err, "failed to save webhooks")
+	}
+	m.start(s)
+	zap.S().Infof("Webhook %s for %v is registered", w.ID, w.Events)
+	return w, nil
+}
+
+// Remove unregisters the webhook, undelivered notifications are dropped.
+func (m *Manager) Remove(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.senders[id]
+	if !ok {
+		return ErrNotFound
+	}
+	delete(m.senders, id)
+	if err := m.save(); err != nil {
+		m.senders[id] = s
+		return errors.Wrap(err, "failed to save webhooks")
+	}
+	if s.cancel != nil {
+		s.cancel()
+	}
+	zap.S().Infof("Webhook %s is removed", id)
+	return nil
+}
+
+// List returns the registered webhooks ordered by ID without secrets.
+func (m *Manager) List() []Webhook {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	res := m.list()
+	for i := range res {
+		res[i].Secret = ""
+	}
+	return res
+}
+
+func (m *Manager) list() []Webhook {
+	res := make([]Webhook, 0, len(m.senders))
+	for _, s := range m.senders {
+		res = append(res, s.hook)
+	}
+	slices.SortFunc(res, func(a, b Webhook) int { return cmp.Compare(a.ID, b.ID) })
+	return res
+}
+
+// start runs the sender if the manager is running, must be called under the lock.
+func (m *Manager) start(s *sender) {
+	if m.ctx == nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(m.ctx)
+	s.cancel = cancel
+	go s.run(ctx)
+}
+
+// Run sends notifications about events published on the bus until the context is done.
+func (m *Manager) Run(ctx context.Context, bus *events.Bus) {
+	sub := bus.Subscribe(eventsBufferSize, events.BlockApplied{}, events.Rollback{}, events.WatchedTransaction{})
+	defer sub.Close()
+	m.mu.Lock()
+	m.ctx = ctx
+	for _, s := range m.senders {
+		m.start(s)
+	}
+	m.mu.Unlock()
+	if h, err := m.st.Height(); err == nil {
+		m.height = h
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-sub.Events():
+			if !ok {
+				return
+			}
+			switch t := e.(type) {
+			case events.BlockApplied:
+				m.blockApplied(t)
+			case events.Rollback:
+				m.rollback(t)
+			case events.WatchedTransaction:
+				m.watchedTransaction(t)
+			}
+		}
+	}
+}
+
+// subscribed returns the senders of webhooks subscribed to the event.
+func (m *Manager) subscribed(t EventType) []*sender {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var res []*sender
+	for _, s := range m.senders {
+		if s.hook.subscribed(t) {
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
+func (m *Manager) blockApplied(e events.BlockApplied) {
+	if e.Height != m.confirmedHeight {
+		// New key block is applied, otherwise it's a new version of the liquid block with the same transactions
+		// at the beginning, so the notified transactions are kept.
+		clear(m.confirmed)
+		m.confirmedHeight = e.Height
+	}
+	m.height = e.Height
+	now := time.Now().UnixMilli()
+	for _, s := range m.subscribed(EventBlockApplied) {
+		s.enqueue(Payload{Event: EventBlockApplied, Webhook: s.hook.ID, Timestamp: now, Height: e.Height,
+			BlockID: e.BlockID})
+	}
+	watchers := m.subscribed(EventTransactionConfirmed)
+	if len(watchers) == 0 {
+		return
+	}
+	block, err := m.st.Block(e.BlockID)
+	if err != nil {
+		zap.S().Warnf("Webhooks: failed to get block %s: %v", e.BlockID, err)
+		return
+	}
+	for _, tx := range block.Transactions {
+		m.transactionConfirmed(tx, e, now, watchers)
+	}
+}
+
+func (m *Manager) transactionConfirmed(tx proto.Transaction, e events.BlockApplied, now int64, watchers []*sender) {
+	b, err := tx.GetID(m.scheme)
+	if err != nil {
+		zap.S().Warnf("Webhooks: failed to get transaction ID: %v", err)
+		return
+	}
+	if _, ok := m.confirmed[string(b)]; ok {
+		return
+	}
+	m.confirmed[string(b)] = struct{}{}
+	id := base58.Encode(b)
+	addresses, err := TransactionAddresses(tx, m.scheme, m.st.AddrByAlias)
+	if err != nil {
+		zap.S().Warnf("Webhooks: failed to get addresses of transaction %s: %v", id, err)
+		return
+	}
+	for _, s := range watchers {
+		var matched []proto.WavesAddress
+		for _, a := range addresses {
+			if slices.Contains(s.hook.Addresses, a) && !slices.Contains(matched, a) {
+				matched = append(matched, a)
+			}
+		}
+		if len(matched) == 0 {
+			continue
+		}
+		s.enqueue(Payload{Event: EventTransactionConfirmed, Webhook: s.hook.ID, Timestamp: now, Height: e.Height,
+			BlockID: e.BlockID, Transaction: id, Addresses: matched})
+	}
+}
+
+func (m *Manager) rollback(e events.Rollback) {
+	var depth uint64
+	if m.height > e.Height {
+		depth = m.height - e.Height
+	}
+	if e.Height+1 < m.confirmedHeight {
+		clear(m.confirmed) // the block with notified transactions is removed
+	}
+	m.height = e.Height
+	now := time.Now().UnixMilli()
+	for _, s := range m.subscribed(EventRollback) {
+		if depth <= s.hook.RollbackDepth {
+			continue
+		}
+		s.enqueue(Payload{Event: EventRollback, Webhook: s.hook.ID, Timestamp: now, Height: e.Height,
+			BlockID: e.BlockID, Depth: depth})
+	}
+}
+
+func (m *Manager) watchedTransaction(e events.WatchedTransaction) {
+	hooks := m.subscribed(EventWatchedTransaction)
+	if len(hooks) == 0 {
+		return
+	}
+	id, err := e.Transaction.GetID(m.scheme)
+	if err != nil {
+		zap.S().Warnf("Webhooks: failed to get transaction ID: %v", err)
+		return
+	}
+	now := time.Now().UnixMilli()
+	for _, s := range hooks {
+		s.enqueue(Payload{Event: EventWatchedTransaction, Webhook: s.hook.ID, Timestamp: now, Height: e.Height,
+			BlockID: e.BlockID, Transaction: base58.Encode(id), Addresses: []proto.WavesAddress{e.Address},
+			Incoming: e.Incoming, Outgoing: e.Outgoing})
+	}
+}
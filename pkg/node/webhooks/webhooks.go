@@ -0,0 +1,96 @@
+// Package webhooks notifies external HTTP endpoints about the node's events. Notifications are JSON payloads sent
+// with POST requests, they are retried on failures and signed with HMAC-SHA256 if the webhook has a secret.
+package webhooks
+
+import (
+	"net/url"
+	"slices"
+
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+// EventType is the kind of notifications a webhook is subscribed to.
+type EventType string
+
+const (
+	// EventBlockApplied is sent for every block added to the blockchain, including new versions of the liquid block.
+	EventBlockApplied EventType = "block_applied"
+	// EventTransactionConfirmed is sent for the transactions involving any of the watched addresses once
+	// the transaction is included in a block.
+	EventTransactionConfirmed EventType = "transaction_confirmed"
+	// EventRollback is sent when the blockchain is rolled back deeper than the webhook's rollback depth.
+	EventRollback EventType = "rollback"
+	// EventWatchedTransaction is sent for the transactions of the addresses in the node's watch list.
+	EventWatchedTransaction EventType = "watched_transaction"
+)
+
+func (t EventType) valid() bool {
+	switch t {
+	case EventBlockApplied, EventTransactionConfirmed, EventRollback, EventWatchedTransaction:
+		return true
+	default:
+		return false
+	}
+}
+
+// Webhook describes the endpoint and the events it is subscribed to.
+type Webhook struct {
+	ID     string      `json:"id"`
+	URL    string      `json:"url"`
+	Events []EventType `json:"events"`
+	// Addresses are watched for EventTransactionConfirmed.
+	Addresses []proto.WavesAddress `json:"addresses,omitempty"`
+	// RollbackDepth is the number of blocks a rollback must exceed to be reported, rollbacks of the liquid block
+	// are never reported.
+	RollbackDepth uint64 `json:"rollbackDepth,omitempty"`
+	// Secret is the key to sign payloads with, it is never returned by API.
+	Secret string `json:"secret,omitempty"`
+}
+
+// Validate checks the webhook and sets defaults of the omitted fields.
+func (w *Webhook) Validate() error {
+	u, err := url.Parse(w.URL)
+	if err != nil {
+		return errors.Wrap(err, "invalid webhook URL")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return errors.Errorf("invalid webhook URL %q, absolute HTTP(S) URL expected", w.URL)
+	}
+	if len(w.Events) == 0 {
+		return errors.New("no events to subscribe to")
+	}
+	for _, e := range w.Events {
+		if !e.valid() {
+			return errors.Errorf("unknown event type %q", e)
+		}
+	}
+	if w.subscribed(EventTransactionConfirmed) && len(w.Addresses) == 0 {
+		return errors.Errorf("no addresses to watch for event %q", EventTransactionConfirmed)
+	}
+	w.RollbackDepth = max(w.RollbackDepth, 1)
+	return nil
+}
+
+func (w *Webhook) subscribed(t EventType) bool {
+	return slices.Contains(w.Events, t)
+}
+
+// Payload is the body of the notification.
+type Payload struct {
+	Event     EventType     `json:"event"`
+	Webhook   string        `json:"webhook"`
+	Timestamp int64         `json:"timestamp"`
+	Height    proto.Height  `json:"height"`
+	BlockID   proto.BlockID `json:"blockId"`
+	// Transaction is set for EventTransactionConfirmed and EventWatchedTransaction.
+	Transaction string `json:"transaction,omitempty"`
+	// Addresses are the watched addresses involved in the transaction.
+	Addresses []proto.WavesAddress `json:"addresses,omitempty"`
+	// Incoming and Outgoing tell whether the address of EventWatchedTransaction receives funds or is the sender.
+	Incoming bool `json:"incoming,omitempty"`
+	Outgoing bool `json:"outgoing,omitempty"`
+	// Depth is the number of rolled back blocks for EventRollback.
+	Depth uint64 `json:"depth,omitempty"`
+}
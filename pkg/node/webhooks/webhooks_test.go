@@ -0,0 +1,168 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/mock"
+	"github.com/wavesplatform/gowaves/pkg/node/events"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+type request struct {
+	payload   Payload
+	signature string
+}
+
+func newEndpoint(t *testing.T, failures int) (*httptest.Server, <-chan request) {
+	ch := make(chan request, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var p Payload
+		require.NoError(t, json.Unmarshal(body, &p))
+		assert.Equal(t, string(p.Event), r.Header.Get(EventHeader))
+		ch <- request{payload: p, signature: r.Header.Get(SignatureHeader)}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, ch
+}
+
+func receive(t *testing.T, ch <-chan request) request {
+	select {
+	case r := <-ch:
+		return r
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "notification is not received")
+		return request{}
+	}
+}
+
+func TestWebhookValidate(t *testing.T) {
+	w := Webhook{URL: "https://example.com/hook", Events: []EventType{EventRollback}}
+	require.NoError(t, w.Validate())
+	assert.Equal(t, uint64(1), w.RollbackDepth)
+
+	for _, invalid := range []Webhook{
+		{URL: "example.com", Events: []EventType{EventBlockApplied}},
+		{URL: "ftp://example.com", Events: []EventType{EventBlockApplied}},
+		{URL: "https://example.com"},
+		{URL: "https://example.com", Events: []EventType{"unknown"}},
+		{URL: "https://example.com", Events: []EventType{EventTransactionConfirmed}},
+	} {
+		assert.Error(t, invalid.Validate(), "%+v", invalid)
+	}
+}
+
+func TestManagerNotifications(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	_, pk, err := crypto.GenerateKeyPair([]byte("webhooks"))
+	require.NoError(t, err)
+	watched, err := proto.NewAddressFromPublicKey(proto.TestNetScheme, pk)
+	require.NoError(t, err)
+	sk, senderPK, err := crypto.GenerateKeyPair([]byte("sender"))
+	require.NoError(t, err)
+	tx := proto.NewUnsignedTransferWithProofs(3, senderPK, proto.NewOptionalAssetWaves(),
+		proto.NewOptionalAssetWaves(), 1700000000000, 100, 100000, proto.NewRecipientFromAddress(watched), nil)
+	require.NoError(t, tx.Sign(proto.TestNetScheme, sk))
+	block := &proto.Block{Transactions: proto.Transactions{tx}}
+	id := proto.NewBlockIDFromDigest(crypto.MustDigestFromBase58("8vgMmBp3KS3VKXJmz3LNQvgQ4ELRRtvXPbXTbEd3iJyo"))
+
+	st := mock.NewMockState(ctrl)
+	st.EXPECT().Height().Return(uint64(9), nil)
+	st.EXPECT().Block(id).Return(block, nil).Times(2)
+
+	m, err := NewManager("", st, proto.TestNetScheme)
+	require.NoError(t, err)
+	m.backoff = time.Millisecond
+	srv, ch := newEndpoint(t, 2)
+	blocksHook, err := m.Add(Webhook{URL: srv.URL, Events: []EventType{EventBlockApplied}, Secret: "secret"})
+	require.NoError(t, err)
+	_, err = m.Add(Webhook{URL: srv.URL, Events: []EventType{EventTransactionConfirmed, EventRollback},
+		Addresses: []proto.WavesAddress{watched}, RollbackDepth: 2})
+	require.NoError(t, err)
+	for _, h := range m.List() {
+		assert.Empty(t, h.Secret)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	bus := events.NewBus()
+	done := make(chan struct{})
+	go func() {
+		m.Run(ctx, bus)
+		close(done)
+	}()
+	require.Eventually(t, func() bool {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		return m.ctx != nil
+	}, 5*time.Second, time.Millisecond)
+
+	bus.Publish(events.BlockApplied{BlockID: id, Height: 10})
+	bus.Publish(events.Rollback{BlockID: id, Height: 9}) // liquid block is replaced with its new version
+	bus.Publish(events.BlockApplied{BlockID: id, Height: 10})
+	bus.Publish(events.Rollback{BlockID: id, Height: 7})
+
+	var blocks, txs, rollbacks []request
+	for range 4 {
+		r := receive(t, ch)
+		switch r.payload.Event {
+		case EventBlockApplied:
+			blocks = append(blocks, r)
+		case EventTransactionConfirmed:
+			txs = append(txs, r)
+		case EventRollback:
+			rollbacks = append(rollbacks, r)
+		}
+	}
+	require.Len(t, blocks, 2)
+	assert.Equal(t, blocksHook.ID, blocks[0].payload.Webhook)
+	body, err := json.Marshal(blocks[0].payload)
+	require.NoError(t, err)
+	assert.Equal(t, Sign("secret", body), blocks[0].signature)
+	require.Len(t, txs, 1, "transaction of the liquid block is notified once")
+	assert.Equal(t, tx.ID.String(), txs[0].payload.Transaction)
+	assert.Equal(t, []proto.WavesAddress{watched}, txs[0].payload.Addresses)
+	assert.Empty(t, txs[0].signature)
+	require.Len(t, rollbacks, 1)
+	assert.Equal(t, uint64(3), rollbacks[0].payload.Depth)
+
+	cancel()
+	<-done
+}
+
+func TestManagerPersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "webhooks.json")
+	m, err := NewManager(path, nil, proto.TestNetScheme)
+	require.NoError(t, err)
+	h, err := m.Add(Webhook{URL: "https://example.com/hook", Events: []EventType{EventBlockApplied}, Secret: "s"})
+	require.NoError(t, err)
+
+	restored, err := NewManager(path, nil, proto.TestNetScheme)
+	require.NoError(t, err)
+	require.Len(t, restored.List(), 1)
+	assert.Equal(t, "s", restored.senders[h.ID].hook.Secret)
+	require.NoError(t, restored.Remove(h.ID))
+	assert.ErrorIs(t, restored.Remove(h.ID), ErrNotFound)
+
+	restored, err = NewManager(path, nil, proto.TestNetScheme)
+	require.NoError(t, err)
+	assert.Empty(t, restored.List())
+}
@@ -0,0 +1,126 @@
+package conn
+
+import (
+	"context"
+	"net"
+
+	"golang.org/x/time/rate"
+)
+
+// maxThrottleChunk is the maximum size of data read or written at once by the throttled connection.
+const maxThrottleChunk = 64 * KiB
+
+// BandwidthLimits are the limits of the traffic in bytes per second, zero means no limit.
+type BandwidthLimits struct {
+	Upload   int
+	Download int
+}
+
+func (l BandwidthLimits) unlimited() bool {
+	return l.Upload <= 0 && l.Download <= 0
+}
+
+// Throttle limits the bandwidth of all connections together and of every connection separately.
+type Throttle struct {
+	upload   *rate.Limiter
+	download *rate.Limiter
+	peer     BandwidthLimits
+}
+
+// NewThrottle creates the throttle with the total limits and the limits of a single connection. It returns nil
+// if there are no limits, nil throttle leaves connections as is.
+func NewThrottle(total, peer BandwidthLimits) *Throttle {
+	if total.unlimited() && peer.unlimited() {
+		return nil
+	}
+	return &Throttle{upload: newLimiter(total.Upload), download: newLimiter(total.Download), peer: peer}
+}
+
+// Wrap returns the connection limited by the throttle.
+func (t *Throttle) Wrap(c net.Conn) net.Conn {
+	if t == nil {
+		return c
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &throttledConn{
+		Conn:     c,
+		ctx:      ctx,
+		cancel:   cancel,
+		upload:   limiters(t.upload, newLimiter(t.peer.Upload)),
+		download: limiters(t.download, newLimiter(t.peer.Download)),
+	}
+}
+
+func newLimiter(bytesPerSecond int) *rate.Limiter {
+	if bytesPerSecond <= 0 {
+		return nil
+	}
+	return rate.NewLimiter(rate.Limit(bytesPerSecond), max(bytesPerSecond, maxThrottleChunk))
+}
+
+func limiters(ls ...*rate.Limiter) []*rate.Limiter {
+	r := make([]*rate.Limiter, 0, len(ls))
+	for _, l := range ls {
+		if l != nil {
+			r = append(r, l)
+		}
+	}
+	return r
+}
+
+// throttledConn waits for the limiters before writing the data and after reading it. Waiting is interrupted
+// when the connection is closed.
+type throttledConn struct {
+	net.Conn
+	ctx      context.Context
+	cancel   context.CancelFunc
+	upload   []*rate.Limiter
+	download []*rate.Limiter
+}
+
+func (c *throttledConn) Read(p []byte) (int, error) {
+	if len(c.download) == 0 {
+		return c.Conn.Read(p)
+	}
+	n, err := c.Conn.Read(p[:min(len(p), maxThrottleChunk)])
+	if n > 0 {
+		if wErr := wait(c.ctx, c.download, n); wErr != nil && err == nil {
+			err = wErr
+		}
+	}
+	return n, err
+}
+
+func (c *throttledConn) Write(p []byte) (int, error) {
+	if len(c.upload) == 0 {
+		return c.Conn.Write(p)
+	}
+	written := 0
+	for len(p) > 0 {
+		chunk := p[:min(len(p), maxThrottleChunk)]
+		if err := wait(c.ctx, c.upload, len(chunk)); err != nil {
+			return written, err
+		}
+		n, err := c.Conn.Write(chunk)
+		written += n
+		if err != nil {
+			return written, err
+		}
+		p = p[n:]
+	}
+	return written, nil
+}
+
+func (c *throttledConn) Close() error {
+	c.cancel()
+	return c.Conn.Close()
+}
+
+func wait(ctx context.Context, ls []*rate.Limiter, n int) error {
+	for _, l := range ls {
+		if err := l.WaitN(ctx, n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package conn
+
+import (
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThrottleWithoutLimits(t *testing.T) {
+	th := NewThrottle(BandwidthLimits{}, BandwidthLimits{})
+	assert.Nil(t, th)
+	c, _ := net.Pipe()
+	assert.Same(t, c, th.Wrap(c))
+}
+
+func TestThrottleLimitsUpload(t *testing.T) {
+	const limit = maxThrottleChunk // burst equals the limit, the first chunk is sent at once
+	th := NewThrottle(BandwidthLimits{}, BandwidthLimits{Upload: limit})
+	require.NotNil(t, th)
+
+	local, remote := net.Pipe()
+	c := th.Wrap(local)
+	defer func() { _ = c.Close() }()
+	received := make(chan int64)
+	go func() {
+		n, _ := io.Copy(io.Discard, remote)
+		received <- n
+	}()
+
+	start := time.Now()
+	n, err := c.Write(make([]byte, 2*limit))
+	require.NoError(t, err)
+	assert.Equal(t, 2*limit, n)
+	assert.GreaterOrEqual(t, time.Since(start), 900*time.Millisecond)
+
+	require.NoError(t, c.Close())
+	assert.Equal(t, int64(2*limit), <-received)
+}
+
+func TestThrottleCloseInterruptsWaiting(t *testing.T) {
+	th := NewThrottle(BandwidthLimits{Upload: 1}, BandwidthLimits{})
+	local, remote := net.Pipe()
+	go func() { _, _ = io.Copy(io.Discard, remote) }()
+	c := th.Wrap(local)
+	_, err := c.Write(make([]byte, maxThrottleChunk)) // spends the whole burst
+	require.NoError(t, err)
+
+	done := make(chan error)
+	go func() {
+		_, wErr := c.Write([]byte{1})
+		done <- wErr
+	}()
+	time.Sleep(50 * time.Millisecond)
+	require.NoError(t, c.Close())
+	select {
+	case wErr := <-done:
+		assert.Error(t, wErr)
+	case <-time.After(5 * time.Second):
+		t.Fatal("write is not interrupted by close")
+	}
+}
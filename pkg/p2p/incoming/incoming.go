@@ -23,6 +23,7 @@ type PeerParams struct {
 	NodeName     string
 	NodeNonce    uint64
 	Version      proto.Version
+	Throttle     *conn.Throttle // Limits the bandwidth of the connection, nil means no limits.
 }
 
 func RunIncomingPeer(ctx context.Context, params PeerParams) error {
@@ -32,7 +33,7 @@ func RunIncomingPeer(ctx context.Context, params PeerParams) error {
 }
 
 func runIncomingPeer(ctx context.Context, cancel context.CancelFunc, params PeerParams) error {
-	c := params.Conn
+	c := params.Throttle.Wrap(params.Conn)
 
 	readHandshake := proto.Handshake{}
 	_, err := readHandshake.ReadFrom(c)
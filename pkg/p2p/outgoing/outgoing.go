@@ -11,6 +11,7 @@ import (
 	"github.com/wavesplatform/gowaves/pkg/logging"
 	"github.com/wavesplatform/gowaves/pkg/p2p/conn"
 	"github.com/wavesplatform/gowaves/pkg/p2p/peer"
+	"github.com/wavesplatform/gowaves/pkg/p2p/peer/extension"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 )
 
@@ -24,6 +25,7 @@ type EstablishParams struct {
 	Skip         conn.SkipFilter
 	NodeName     string
 	NodeNonce    uint64
+	Throttle     *conn.Throttle // Limits the bandwidth of the connection, nil means no limits.
 }
 
 func EstablishConnection(ctx context.Context, params EstablishParams, v proto.Version) error {
@@ -38,6 +40,17 @@ func EstablishConnection(ctx context.Context, params EstablishParams, v proto.Ve
 	addr := params.Address.String()
 
 	connection, handshake, err := p.connect(ctx, addr, outgoingPeerDialTimeout, v)
+	var hsErr *handshakeError
+	if errors.As(err, &hsErr) {
+		// The peer closes the connection without the handshake if it doesn't accept our version,
+		// try to connect with the previous version, the node still speaks it.
+		if prev, ok := extension.PreviousVersion(v); ok {
+			zap.S().Named(logging.NetworkNamespace).Debugf(
+				"Handshake with address '%s' failed with version %s, retrying with version %s: %v",
+				addr, v, prev, err)
+			connection, handshake, err = p.connect(ctx, addr, outgoingPeerDialTimeout, prev)
+		}
+	}
 	if err != nil {
 		zap.S().Named(logging.NetworkNamespace).Debugf("Outgoing connection to address '%s' failed with error: %v",
 			addr, err)
@@ -58,6 +71,15 @@ func EstablishConnection(ctx context.Context, params EstablishParams, v proto.Ve
 	return peer.Handle(ctx, peerImpl, params.Parent, remote)
 }
 
+// handshakeError is returned if the peer hasn't responded with the handshake.
+type handshakeError struct {
+	error
+}
+
+func (e *handshakeError) Unwrap() error {
+	return e.error
+}
+
 type connector struct {
 	params EstablishParams
 	remote peer.Remote
@@ -65,10 +87,11 @@ type connector struct {
 
 func (a *connector) connect(ctx context.Context, addr string, dialTimeout time.Duration, v proto.Version) (_ conn.Connection, _ proto.Handshake, err error) {
 	dialer := net.Dialer{Timeout: dialTimeout}
-	c, err := dialer.DialContext(ctx, "tcp", addr)
+	rawConn, err := dialer.DialContext(ctx, "tcp", addr)
 	if err != nil {
 		return nil, proto.Handshake{}, errors.Wrapf(err, "failed to dial with addr %q", addr)
 	}
+	c := a.params.Throttle.Wrap(rawConn)
 	defer func() {
 		if err != nil { // close connection on error
 			if err := c.Close(); err != nil {
@@ -101,7 +124,9 @@ func (a *connector) connect(ctx context.Context, addr string, dialTimeout time.D
 		addr := a.params.Address.String()
 		zap.S().Named(logging.NetworkNamespace).Debugf("Failed to read handshake with addr %q: %v",
 			a.params.Address.String(), err)
-		return nil, proto.Handshake{}, errors.Wrapf(err, "failed to read handshake with addr %q", addr)
+		return nil, proto.Handshake{}, &handshakeError{
+			error: errors.Wrapf(err, "failed to read handshake with addr %q", addr),
+		}
 	}
 	select {
 	case <-ctx.Done():
@@ -3,7 +3,6 @@ package extension
 import (
 	"go.uber.org/zap"
 
-	"github.com/wavesplatform/gowaves/pkg/crypto"
 	"github.com/wavesplatform/gowaves/pkg/logging"
 	"github.com/wavesplatform/gowaves/pkg/p2p/peer"
 	"github.com/wavesplatform/gowaves/pkg/proto"
@@ -21,45 +20,34 @@ type PeerExtension interface {
 }
 
 type PeerWrapperImpl struct {
-	p      peer.Peer
-	scheme proto.Scheme
+	p        peer.Peer
+	scheme   proto.Scheme
+	protocol Protocol
 }
 
 func (a PeerWrapperImpl) SendTransaction(t proto.Transaction) error {
-	if a.p.Handshake().Version.Cmp(peerVersionWithProtobuf) < 0 {
-		bts, err := t.MarshalBinary(a.scheme)
-		if err != nil {
-			return err
-		}
-		a.p.SendMessage(&proto.TransactionMessage{Transaction: bts})
-	} else {
-		bts, err := t.MarshalSignedToProtobuf(a.scheme)
-		if err != nil {
-			return err
-		}
-		a.p.SendMessage(&proto.PBTransactionMessage{Transaction: bts})
+	msg, err := a.protocol.TransactionMessage(t, a.scheme)
+	if err != nil {
+		return err
 	}
+	a.p.SendMessage(msg)
 	return nil
 }
 
 func NewPeerExtension(p peer.Peer, scheme proto.Scheme) PeerExtension {
-	return PeerWrapperImpl{p: p, scheme: scheme}
+	return PeerWrapperImpl{p: p, scheme: scheme, protocol: ProtocolOf(p)}
 }
 
 func (a PeerWrapperImpl) AskBlocksIDs(ids []proto.BlockID) {
-	if a.p.Handshake().Version.Cmp(peerVersionWithProtobuf) < 0 {
-		sigs := make([]crypto.Signature, len(ids))
-		for i, b := range ids {
-			sigs[i] = b.Signature()
-		}
+	msg := a.protocol.BlockIDsRequest(ids)
+	if _, ok := msg.(*proto.GetSignaturesMessage); ok {
 		zap.S().Named(logging.NetworkNamespace).Debugf("[%s] Requesting signatures for signatures range [%s...%s]",
-			a.p.ID().String(), sigs[0].ShortString(), sigs[len(sigs)-1].ShortString())
-		a.p.SendMessage(&proto.GetSignaturesMessage{Signatures: sigs})
+			a.p.ID().String(), ids[0].ShortString(), ids[len(ids)-1].ShortString())
 	} else {
 		zap.S().Named(logging.NetworkNamespace).Debugf("[%s] Requesting blocks IDs for IDs range [%s...%s]",
 			a.p.ID().String(), ids[0].ShortString(), ids[len(ids)-1].ShortString())
-		a.p.SendMessage(&proto.GetBlockIDsMessage{Blocks: ids})
 	}
+	a.p.SendMessage(msg)
 }
 
 func (a PeerWrapperImpl) AskBlock(id proto.BlockID) {
@@ -82,18 +70,10 @@ func (a PeerWrapperImpl) AskMicroBlockSnapshot(id proto.BlockID) {
 }
 
 func (a PeerWrapperImpl) SendMicroBlock(micro *proto.MicroBlock) error {
-	if a.p.Handshake().Version.Cmp(peerVersionWithProtobuf) < 0 {
-		bts, err := micro.MarshalBinary(a.scheme)
-		if err != nil {
-			return err
-		}
-		a.p.SendMessage(&proto.MicroBlockMessage{Body: bts})
-	} else {
-		bts, err := micro.MarshalToProtobuf(a.scheme)
-		if err != nil {
-			return err
-		}
-		a.p.SendMessage(&proto.PBMicroBlockMessage{MicroBlockBytes: bts})
+	msg, err := a.protocol.MicroBlockMessage(micro, a.scheme)
+	if err != nil {
+		return err
 	}
+	a.p.SendMessage(msg)
 	return nil
 }
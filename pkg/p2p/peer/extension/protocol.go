@@ -0,0 +1,115 @@
+package extension
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/p2p/peer"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+// ErrUnsupportedByPeer is returned if the message can't be encoded in the format the peer understands.
+var ErrUnsupportedByPeer = errors.New("message is not supported by the peer protocol version")
+
+// Protocol is the set of message encodings used to communicate with the peer. It's defined by the lower of
+// the versions sent in the handshakes, the node understands all encodings of the previous versions.
+type Protocol struct {
+	version proto.Version
+}
+
+// NegotiateProtocol returns the protocol for the local and remote versions. Versions with different major numbers
+// or with minor numbers differing by more than one are incompatible, but the node speaks the previous version too,
+// so the remote version is accepted if it's compatible with the local or the previous version.
+func NegotiateProtocol(local, remote proto.Version) (Protocol, error) {
+	compatible := local.CmpMinor(remote) < 2
+	if !compatible {
+		if prev, ok := PreviousVersion(local); ok {
+			compatible = prev.CmpMinor(remote) < 2
+		}
+	}
+	if !compatible {
+		return Protocol{}, errors.Errorf("versions are too different, current %s, remote %s", local, remote)
+	}
+	if remote.Cmp(local) < 0 {
+		return Protocol{version: remote}, nil
+	}
+	return Protocol{version: local}, nil
+}
+
+// ProtocolOf returns the protocol of the connected peer. The version of the node is never lower than the version
+// of the first protocol with the features known to the node, so the protocol is defined by the peer version.
+func ProtocolOf(p peer.Peer) Protocol {
+	return Protocol{version: p.Handshake().Version}
+}
+
+// PreviousVersion returns the first release of the previous minor version, the node falls back to it if the peer
+// rejects the handshake with the current version.
+func PreviousVersion(v proto.Version) (proto.Version, bool) {
+	if v.Minor() == 0 {
+		return proto.Version{}, false
+	}
+	return proto.NewVersion(v.Major(), v.Minor()-1, 0), true
+}
+
+func (p Protocol) Version() proto.Version {
+	return p.version
+}
+
+// Protobuf reports whether the peer understands protobuf encoded blocks and transactions.
+func (p Protocol) Protobuf() bool {
+	return p.version.Cmp(peerVersionWithProtobuf) >= 0
+}
+
+// TransactionMessage returns the message with the transaction encoded for the peer.
+func (p Protocol) TransactionMessage(t proto.Transaction, scheme proto.Scheme) (proto.Message, error) {
+	if !p.Protobuf() {
+		bts, err := t.MarshalBinary(scheme)
+		if err != nil {
+			return nil, err
+		}
+		return &proto.TransactionMessage{Transaction: bts}, nil
+	}
+	bts, err := t.MarshalSignedToProtobuf(scheme)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.PBTransactionMessage{Transaction: bts}, nil
+}
+
+// MicroBlockMessage returns the message with the micro block encoded for the peer.
+func (p Protocol) MicroBlockMessage(micro *proto.MicroBlock, scheme proto.Scheme) (proto.Message, error) {
+	if !p.Protobuf() {
+		bts, err := micro.MarshalBinary(scheme)
+		if err != nil {
+			return nil, err
+		}
+		return &proto.MicroBlockMessage{Body: bts}, nil
+	}
+	bts, err := micro.MarshalToProtobuf(scheme)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.PBMicroBlockMessage{MicroBlockBytes: bts}, nil
+}
+
+// BlockMessage returns the message with the block encoded for the peer. Blocks of the versions before protobuf
+// are sent in the binary encoding to all peers, protobuf blocks can't be sent to the peers of the old versions.
+func (p Protocol) BlockMessage(block *proto.Block, scheme proto.Scheme) (proto.Message, error) {
+	if block.Version >= proto.ProtobufBlockVersion && !p.Protobuf() {
+		return nil, errors.Wrapf(ErrUnsupportedByPeer, "block %s of version %d for protocol %s",
+			block.BlockID().String(), block.Version, p.version)
+	}
+	return proto.MessageByBlock(block, scheme)
+}
+
+// BlockIDsRequest returns the message requesting the IDs of blocks following the given ones.
+func (p Protocol) BlockIDsRequest(ids []proto.BlockID) proto.Message {
+	if !p.Protobuf() {
+		sigs := make([]crypto.Signature, len(ids))
+		for i, b := range ids {
+			sigs[i] = b.Signature()
+		}
+		return &proto.GetSignaturesMessage{Signatures: sigs}
+	}
+	return &proto.GetBlockIDsMessage{Blocks: ids}
+}
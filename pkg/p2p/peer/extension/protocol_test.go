@@ -0,0 +1,58 @@
+package extension
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/p2p/mock"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+func TestNegotiateProtocol(t *testing.T) {
+	for _, test := range []struct {
+		local, remote, expected proto.Version
+		fail                    bool
+	}{
+		{local: proto.NewVersion(1, 5, 3), remote: proto.NewVersion(1, 5, 0), expected: proto.NewVersion(1, 5, 0)},
+		{local: proto.NewVersion(1, 5, 3), remote: proto.NewVersion(1, 6, 1), expected: proto.NewVersion(1, 5, 3)},
+		{local: proto.NewVersion(1, 5, 3), remote: proto.NewVersion(1, 4, 2), expected: proto.NewVersion(1, 4, 2)},
+		{local: proto.NewVersion(1, 5, 3), remote: proto.NewVersion(1, 3, 0), expected: proto.NewVersion(1, 3, 0)},
+		{local: proto.NewVersion(1, 5, 3), remote: proto.NewVersion(1, 2, 0), fail: true},
+		{local: proto.NewVersion(1, 5, 3), remote: proto.NewVersion(1, 7, 0), fail: true},
+		{local: proto.NewVersion(1, 0, 0), remote: proto.NewVersion(2, 0, 0), fail: true},
+	} {
+		p, err := NegotiateProtocol(test.local, test.remote)
+		if test.fail {
+			assert.Error(t, err, "%s and %s", test.local, test.remote)
+			continue
+		}
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, p.Version())
+	}
+}
+
+func TestPreviousVersion(t *testing.T) {
+	prev, ok := PreviousVersion(proto.NewVersion(1, 5, 3))
+	require.True(t, ok)
+	assert.Equal(t, proto.NewVersion(1, 4, 0), prev)
+	_, ok = PreviousVersion(proto.NewVersion(1, 0, 1))
+	assert.False(t, ok)
+}
+
+func TestProtocolMessages(t *testing.T) {
+	legacy := ProtocolOf(&mock.Peer{HandshakeField: proto.Handshake{Version: proto.NewVersion(1, 1, 0)}})
+	current := ProtocolOf(&mock.Peer{HandshakeField: proto.Handshake{Version: proto.NewVersion(1, 5, 0)}})
+	assert.False(t, legacy.Protobuf())
+	assert.True(t, current.Protobuf())
+
+	ids := []proto.BlockID{proto.NewBlockIDFromSignature(crypto.Signature{1})}
+	assert.IsType(t, &proto.GetSignaturesMessage{}, legacy.BlockIDsRequest(ids))
+	assert.IsType(t, &proto.GetBlockIDsMessage{}, current.BlockIDsRequest(ids))
+
+	block := &proto.Block{BlockHeader: proto.BlockHeader{Version: proto.ProtobufBlockVersion}}
+	_, err := legacy.BlockMessage(block, proto.TestNetScheme)
+	assert.ErrorIs(t, err, ErrUnsupportedByPeer)
+}
@@ -558,6 +558,9 @@ func (b *BlockHeader) UnmarshalHeaderFromBinary(data []byte, scheme Scheme) (err
 		}
 	}()
 
+	if err := checkLimit("block size", len(data), MaxBlockSize); err != nil {
+		return err
+	}
 	b.Version = BlockVersion(data[0])
 	if b.Version >= ProtobufBlockVersion {
 		return errors.New("binary format is not defined for Block versions > 4")
@@ -581,6 +584,9 @@ func (b *BlockHeader) UnmarshalHeaderFromBinary(data []byte, scheme Scheme) (err
 		}
 		b.TransactionCount = int(binary.BigEndian.Uint32(data[121:125]))
 		b.FeaturesCount = int(binary.BigEndian.Uint32(data[125:129]))
+		if err := checkLimit("features count", b.FeaturesCount, MaxFeaturesPerBlock); err != nil {
+			return err
+		}
 		b.Features = make([]int16, b.FeaturesCount)
 		fb, err := featuresFromBinary(data[129 : 129+2*b.FeaturesCount])
 		if err != nil {
@@ -791,11 +797,17 @@ func (b *Block) Marshaller() Marshaller {
 }
 
 func (b *Block) UnmarshalFromProtobuf(data []byte) error {
+	if err := checkLimit("block size", len(data), MaxBlockSize); err != nil {
+		return err
+	}
 	var pbBlock = &g.Block{}
 	err := pbBlock.UnmarshalVT(data)
 	if err != nil {
 		return err
 	}
+	if err := checkLimit("transactions count", len(pbBlock.GetTransactions()), MaxTransactionsPerBlock); err != nil {
+		return err
+	}
 	var c ProtobufConverter
 	res, err := c.Block(pbBlock)
 	if err != nil {
@@ -892,6 +904,9 @@ func (b *Block) UnmarshalBinary(data []byte, scheme Scheme) (err error) {
 		}
 	}()
 
+	if err := checkLimit("block size", len(data), MaxBlockSize); err != nil {
+		return err
+	}
 	b.Version = BlockVersion(data[0])
 	if b.Version >= ProtobufBlockVersion {
 		return errors.New("binary format is not defined for Block versions > 4")
@@ -923,6 +938,9 @@ func (b *Block) UnmarshalBinary(data []byte, scheme Scheme) (err error) {
 		}
 		featuresStart := txEnd + 4
 		b.FeaturesCount = int(binary.BigEndian.Uint32(data[txEnd:featuresStart]))
+		if err := checkLimit("features count", b.FeaturesCount, MaxFeaturesPerBlock); err != nil {
+			return err
+		}
 		b.Features = make([]int16, b.FeaturesCount)
 		fb, err := featuresFromBinary(data[featuresStart : featuresStart+uint32(2*b.FeaturesCount)])
 		if err != nil {
@@ -1128,6 +1146,12 @@ func (a Transactions) ToProtobuf(scheme Scheme) ([]*g.SignedTransaction, error)
 func (a *Transactions) UnmarshalFromProtobuf(data []byte) error {
 	transactions := Transactions{}
 	for len(data) > 0 {
+		if len(data) < 4 {
+			return errors.New("invalid data size")
+		}
+		if err := checkLimit("transactions count", len(transactions)+1, MaxTransactionsPerBlock); err != nil {
+			return err
+		}
 		txSize := int(binary.BigEndian.Uint32(data[0:4]))
 		if txSize+4 > len(data) {
 			return errors.New("invalid data size")
@@ -1159,7 +1183,18 @@ func (a Transactions) MarshalJSON() ([]byte, error) {
 	if len(a) == 0 {
 		return []byte("[]"), nil
 	}
-	return json.Marshal([]Transaction(a))
+	b := make([]byte, 0, len(a)*transactionJSONSizeHint)
+	b = append(b, '[')
+	for i, tx := range a {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		var err error
+		if b, err = appendTransactionJSON(b, tx); err != nil {
+			return nil, errors.Wrapf(err, "failed to marshal transaction #%d to JSON", i)
+		}
+	}
+	return append(b, ']'), nil
 }
 
 func (a *Transactions) UnmarshalJSON(data []byte) error {
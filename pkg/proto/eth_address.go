@@ -0,0 +1,71 @@
+package proto
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+)
+
+// Waves and Ethereum addresses share the same 20 bytes of address ID, so any Waves address has its Ethereum
+// counterpart and vice versa. The scheme of network is required only to build the Waves address.
+
+// EthereumAddressFromPublicKey returns Ethereum address of the account with the given Waves public key.
+func EthereumAddressFromPublicKey(publicKey crypto.PublicKey) (EthereumAddress, error) {
+	h, err := crypto.SecureHash(publicKey[:])
+	if err != nil {
+		return EthereumAddress{}, errors.Wrap(err, "failed to produce Digest from PublicKey")
+	}
+	return BytesToEthereumAddress(h[:EthereumAddressSize]), nil
+}
+
+// NewAddressFromEthereumPublicKey returns Waves address of the account with the given Ethereum public key.
+func NewAddressFromEthereumPublicKey(scheme Scheme, publicKey *EthereumPublicKey) (WavesAddress, error) {
+	return publicKey.EthereumAddress().ToWavesAddress(scheme)
+}
+
+// NewAddressFromEthereumHexString converts Ethereum address in hex format (with or without '0x' prefix)
+// to the Waves address.
+func NewAddressFromEthereumHexString(scheme Scheme, s string) (WavesAddress, error) {
+	if !strings.HasPrefix(s, "0x") && !strings.HasPrefix(s, "0X") {
+		s = "0x" + s
+	}
+	ea, err := NewEthereumAddressFromHexString(s)
+	if err != nil {
+		return WavesAddress{}, errors.Wrapf(err, "invalid Ethereum address '%s'", s)
+	}
+	return ea.ToWavesAddress(scheme)
+}
+
+// NewAddressFromWavesOrEthereumString parses either Base58 Waves address or hex Ethereum address with '0x' prefix,
+// the result is the Waves address with the given scheme.
+func NewAddressFromWavesOrEthereumString(scheme Scheme, s string) (WavesAddress, error) {
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		return NewAddressFromEthereumHexString(scheme, s)
+	}
+	addr, err := NewAddressFromString(s)
+	if err != nil {
+		return WavesAddress{}, err
+	}
+	if ok, vErr := addr.Valid(scheme); !ok {
+		return WavesAddress{}, errors.Wrapf(vErr, "invalid address '%s'", s)
+	}
+	return addr, nil
+}
+
+// ERC20Address returns address of ERC20 token contract which represents the asset in Ethereum transactions.
+func ERC20Address(assetID crypto.Digest) EthereumAddress {
+	return EthereumAddress(AssetIDFromDigest(assetID))
+}
+
+// AssetID returns short asset ID of the asset represented by the ERC20 token contract address. The full asset ID
+// can't be restored from address and should be looked up in the state by the short one.
+func (ea EthereumAddress) AssetID() AssetID {
+	return AssetID(ea)
+}
+
+// ERC20Address returns address of ERC20 token contract of the asset.
+func (a AssetID) ERC20Address() EthereumAddress {
+	return EthereumAddress(a)
+}
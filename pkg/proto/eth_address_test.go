@@ -0,0 +1,50 @@
+package proto
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+)
+
+func TestEthereumAddressConversions(t *testing.T) {
+	_, pk, err := crypto.GenerateKeyPair([]byte("test seed"))
+	require.NoError(t, err)
+	addr, err := NewAddressFromPublicKey(TestNetScheme, pk)
+	require.NoError(t, err)
+
+	ea, err := EthereumAddressFromPublicKey(pk)
+	require.NoError(t, err)
+	assert.Equal(t, addr.EthereumAddress(), ea)
+
+	for _, s := range []string{ea.Hex(), ea.Hex()[2:], addr.String()} {
+		wa, cErr := NewAddressFromWavesOrEthereumString(TestNetScheme, s)
+		if s == ea.Hex()[2:] { // Ethereum address without prefix is not distinguishable from Waves address
+			assert.Error(t, cErr)
+			wa, cErr = NewAddressFromEthereumHexString(TestNetScheme, s)
+		}
+		require.NoError(t, cErr)
+		assert.Equal(t, addr, wa)
+	}
+	_, err = NewAddressFromWavesOrEthereumString(MainNetScheme, addr.String())
+	assert.Error(t, err)
+	_, err = NewAddressFromEthereumHexString(TestNetScheme, "0x1234")
+	assert.Error(t, err)
+
+	// The well-known address of Ethereum private key 0x01.
+	esk, _ := btcec.PrivKeyFromBytes([]byte{1})
+	wa, err := NewAddressFromEthereumPublicKey(TestNetScheme, (*EthereumPrivateKey)(esk).EthereumPublicKey())
+	require.NoError(t, err)
+	assert.Equal(t, "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf", wa.EthereumAddress().Hex())
+}
+
+func TestERC20Address(t *testing.T) {
+	assetID := crypto.MustDigestFromBase58("CMBHKDtyE8GMbZAZANNeE5n2HU4VDpsQaBLmfCw9ASbf")
+	ea := ERC20Address(assetID)
+	assert.Equal(t, assetID[:EthereumAddressSize], ea.Bytes())
+	assert.Equal(t, AssetIDFromDigest(assetID), ea.AssetID())
+	assert.Equal(t, ea, ea.AssetID().ERC20Address())
+}
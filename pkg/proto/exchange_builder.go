@@ -0,0 +1,138 @@
+package proto
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+)
+
+// PriceDecimals is the number of decimals of the price in OrderPriceModeFixedDecimals mode and
+// of the price of ExchangeTransaction version 3.
+const PriceDecimals = 8
+
+// NewSignedOrderV4 creates OrderV4 for the given asset pair and signs it with the sender's secret key.
+// The price is interpreted according to the price mode, see NormalizedOrderPrice.
+func NewSignedOrderV4(scheme Scheme, sk crypto.SecretKey, matcherPK crypto.PublicKey, pair AssetPair,
+	orderType OrderType, priceMode OrderPriceMode, price, amount, timestamp, expiration, matcherFee uint64,
+	matcherFeeAsset OptionalAsset,
+) (*OrderV4, error) {
+	o := NewUnsignedOrderV4(crypto.GeneratePublicKey(sk), matcherPK, pair.AmountAsset, pair.PriceAsset, orderType,
+		price, amount, timestamp, expiration, matcherFee, matcherFeeAsset, priceMode, nil)
+	if ok, vErr := o.Valid(); !ok {
+		return nil, errors.Wrap(vErr, "invalid order")
+	}
+	if expiration <= timestamp {
+		return nil, errors.New("order expiration should be after its timestamp")
+	}
+	if expiration-timestamp > MaxOrderTTL {
+		return nil, errors.New("order expiration should be earlier than 30 days")
+	}
+	if sErr := o.Sign(scheme, sk); sErr != nil {
+		return nil, sErr
+	}
+	return o, nil
+}
+
+// NormalizedOrderPrice returns the order price in units of ExchangeTransaction version 3, which has
+// PriceDecimals decimals. The prices of orders of versions before 4 and of orders in OrderPriceModeAssetDecimals
+// mode are given in 10^(8 + priceDecimals - amountDecimals) units and are converted the same way the state does.
+func NormalizedOrderPrice(o Order, amountDecimals, priceDecimals int) (uint64, error) {
+	price := o.GetPrice()
+	if o.GetVersion() >= 4 && o.GetPriceMode() != OrderPriceModeAssetDecimals {
+		return price, nil
+	}
+	r := new(big.Int).SetUint64(price)
+	scaleByPow10(r, amountDecimals-priceDecimals)
+	if !r.IsInt64() {
+		return 0, errors.New("price overflows int64")
+	}
+	if r.Sign() <= 0 {
+		return 0, errors.New("price should be positive")
+	}
+	return r.Uint64(), nil
+}
+
+// ExchangePriceAssetAmount returns the amount of price asset paid for the given amount of amount asset at
+// the given price of ExchangeTransaction version 3. The result is truncated as it is by the state.
+func ExchangePriceAssetAmount(amount, price uint64, amountDecimals, priceDecimals int) (uint64, error) {
+	r := new(big.Int).SetUint64(amount)
+	r.Mul(r, new(big.Int).SetUint64(price))
+	scaleByPow10(r, priceDecimals-amountDecimals-PriceDecimals)
+	if !r.IsInt64() {
+		return 0, errors.New("price asset amount overflows int64")
+	}
+	return r.Uint64(), nil
+}
+
+// PartialMatcherFee returns the part of the order's matcher fee for the matched amount of the order.
+func PartialMatcherFee(o Order, matchedAmount uint64) uint64 {
+	if o.GetAmount() == 0 {
+		return 0
+	}
+	r := new(big.Int).SetUint64(o.GetMatcherFee())
+	r.Mul(r, new(big.Int).SetUint64(matchedAmount))
+	r.Quo(r, new(big.Int).SetUint64(o.GetAmount()))
+	return r.Uint64()
+}
+
+// NewSignedExchangeV3 creates ExchangeTransaction version 3 matching the given orders and signs it with
+// the matcher's secret key. The amount and price are the matched amount of amount asset and the execution price
+// with PriceDecimals decimals. Matcher fees are taken from the orders in proportion to the matched amount.
+// Besides the transaction validation the function checks the rules applied by the state: the price is within
+// the orders prices, the amount doesn't exceed the orders amounts and the price asset amount is positive.
+// Filled volumes of orders are not checked.
+func NewSignedExchangeV3(scheme Scheme, matcherSK crypto.SecretKey, buy, sell Order,
+	amount, price, fee, timestamp uint64, amountDecimals, priceDecimals int,
+) (*ExchangeWithProofs, error) {
+	if buy.GetOrderType() != Buy || sell.GetOrderType() != Sell {
+		return nil, errors.New("invalid order types")
+	}
+	if crypto.GeneratePublicKey(matcherSK) != buy.GetMatcherPK() {
+		return nil, errors.New("orders are for another matcher")
+	}
+	if amount > buy.GetAmount() || amount > sell.GetAmount() {
+		return nil, errors.Errorf("amount %d exceeds orders amounts", amount)
+	}
+	buyPrice, err := NormalizedOrderPrice(buy, amountDecimals, priceDecimals)
+	if err != nil {
+		return nil, errors.Wrap(err, "buy order")
+	}
+	sellPrice, err := NormalizedOrderPrice(sell, amountDecimals, priceDecimals)
+	if err != nil {
+		return nil, errors.Wrap(err, "sell order")
+	}
+	if price > buyPrice || price < sellPrice {
+		return nil, errors.Errorf("invalid exchange price (%d), should be between %d and %d",
+			price, sellPrice, buyPrice)
+	}
+	pa, err := ExchangePriceAssetAmount(amount, price, amountDecimals, priceDecimals)
+	if err != nil {
+		return nil, err
+	}
+	if pa == 0 {
+		return nil, errors.New("price asset amount should be positive")
+	}
+	tx := NewUnsignedExchangeWithProofs(3, buy, sell, price, amount,
+		PartialMatcherFee(buy, amount), PartialMatcherFee(sell, amount), fee, timestamp)
+	if _, vErr := tx.Validate(TransactionValidationParams{Scheme: scheme, CheckVersion: true}); vErr != nil {
+		return nil, errors.Wrap(vErr, "invalid exchange transaction")
+	}
+	if sErr := tx.Sign(scheme, matcherSK); sErr != nil {
+		return nil, sErr
+	}
+	return tx, nil
+}
+
+// scaleByPow10 multiplies the value by 10^exp, the result is truncated if exp is negative.
+func scaleByPow10(v *big.Int, exp int) {
+	if exp == 0 {
+		return
+	}
+	if exp > 0 {
+		v.Mul(v, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil))
+		return
+	}
+	v.Quo(v, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-exp)), nil))
+}
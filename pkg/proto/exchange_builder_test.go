@@ -0,0 +1,81 @@
+package proto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+)
+
+func TestNormalizedOrderPrice(t *testing.T) {
+	pair := AssetPair{AmountAsset: NewOptionalAssetWaves(), PriceAsset: NewOptionalAssetWaves()}
+	for _, test := range []struct {
+		mode           OrderPriceMode
+		price          uint64
+		amountDecimals int
+		priceDecimals  int
+		exp            uint64
+	}{
+		{OrderPriceModeFixedDecimals, 123456789, 8, 2, 123456789},
+		{OrderPriceModeDefault, 123456789, 8, 2, 123456789},
+		{OrderPriceModeAssetDecimals, 1234, 8, 2, 1234000000},
+		{OrderPriceModeAssetDecimals, 123456789, 2, 8, 123},
+	} {
+		o := NewUnsignedOrderV4(crypto.PublicKey{}, crypto.PublicKey{}, pair.AmountAsset, pair.PriceAsset, Buy,
+			test.price, 1, 0, 1, 0, NewOptionalAssetWaves(), test.mode, nil)
+		p, err := NormalizedOrderPrice(o, test.amountDecimals, test.priceDecimals)
+		require.NoError(t, err)
+		assert.Equal(t, test.exp, p)
+	}
+	o := NewUnsignedOrderV4(crypto.PublicKey{}, crypto.PublicKey{}, pair.AmountAsset, pair.PriceAsset, Buy,
+		1, 1, 0, 1, 0, NewOptionalAssetWaves(), OrderPriceModeAssetDecimals, nil)
+	_, err := NormalizedOrderPrice(o, 2, 8)
+	assert.Error(t, err)
+}
+
+func TestNewSignedExchangeV3(t *testing.T) {
+	const ts = 1700000000000
+	buyerSK, _, err := crypto.GenerateKeyPair([]byte("buyer"))
+	require.NoError(t, err)
+	sellerSK, _, err := crypto.GenerateKeyPair([]byte("seller"))
+	require.NoError(t, err)
+	matcherSK, matcherPK, err := crypto.GenerateKeyPair([]byte("matcher"))
+	require.NoError(t, err)
+	asset := crypto.MustDigestFromBase58("CMBHKDtyE8GMbZAZANNeE5n2HU4VDpsQaBLmfCw9ASbf")
+	pair := AssetPair{AmountAsset: NewOptionalAssetWaves(), PriceAsset: *NewOptionalAssetFromDigest(asset)}
+
+	// Price asset has 2 decimals, 1 Waves costs 1.50 in the price asset.
+	buy, err := NewSignedOrderV4(TestNetScheme, buyerSK, matcherPK, pair, Buy, OrderPriceModeAssetDecimals,
+		150, 10*PriceConstant, ts, ts+1000, 300000, NewOptionalAssetWaves())
+	require.NoError(t, err)
+	sell, err := NewSignedOrderV4(TestNetScheme, sellerSK, matcherPK, pair, Sell, OrderPriceModeFixedDecimals,
+		140000000, 4*PriceConstant, ts, ts+1000, 300000, NewOptionalAssetWaves())
+	require.NoError(t, err)
+	ok, err := buy.Verify(TestNetScheme)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	tx, err := NewSignedExchangeV3(TestNetScheme, matcherSK, buy, sell, 2*PriceConstant, 145000000, 300000, ts,
+		8, 2)
+	require.NoError(t, err)
+	assert.EqualValues(t, 60000, tx.BuyMatcherFee)
+	assert.EqualValues(t, 150000, tx.SellMatcherFee)
+	ok, err = tx.Verify(TestNetScheme, matcherPK)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	pa, err := ExchangePriceAssetAmount(tx.Amount, tx.Price, 8, 2)
+	require.NoError(t, err)
+	assert.EqualValues(t, 290, pa)
+
+	_, err = NewSignedExchangeV3(TestNetScheme, matcherSK, buy, sell, 2*PriceConstant, 155000000, 300000, ts, 8, 2)
+	assert.Error(t, err, "price is above the buy order price")
+	_, err = NewSignedExchangeV3(TestNetScheme, matcherSK, buy, sell, 5*PriceConstant, 145000000, 300000, ts, 8, 2)
+	assert.Error(t, err, "amount exceeds the sell order amount")
+	_, err = NewSignedExchangeV3(TestNetScheme, buyerSK, buy, sell, 2*PriceConstant, 145000000, 300000, ts, 8, 2)
+	assert.Error(t, err, "wrong matcher")
+	_, err = NewSignedOrderV4(TestNetScheme, buyerSK, matcherPK, pair, Buy, OrderPriceModeFixedDecimals,
+		150, 10*PriceConstant, ts, ts+MaxOrderTTL+1, 300000, NewOptionalAssetWaves())
+	assert.Error(t, err)
+}
@@ -98,6 +98,17 @@ func (i *FullAssetInfo) ToProtobuf(scheme Scheme) (*g.AssetInfoResponse, error)
 	return res, nil
 }
 
+// SponsorshipStatus describes the fee sponsorship of the asset. MinSponsoredFee is the amount of asset equivalent to
+// 0.001 Waves, it's zero if asset is not sponsored. SponsorBalance is the available Waves balance of the sponsor
+// (asset issuer) which is spent to pay fees of transactions with fee in the asset.
+type SponsorshipStatus struct {
+	AssetID         crypto.Digest
+	IsSponsored     bool
+	MinSponsoredFee uint64
+	Sponsor         WavesAddress
+	SponsorBalance  uint64
+}
+
 type AssetConstInfo struct {
 	ID          crypto.Digest
 	IssueHeight Height
@@ -1,8 +1,24 @@
 package proto
 
+import "github.com/wavesplatform/gowaves/pkg/crypto"
+
 type LeaseInfo struct {
 	IsActive    bool
 	LeaseAmount uint64
 	Recipient   WavesAddress
 	Sender      WavesAddress
 }
+
+// LeaseDetails describes the lease created either by Lease transaction or by Invoke transaction.
+type LeaseDetails struct {
+	ID                  crypto.Digest
+	OriginTransactionID crypto.Digest
+	Sender              WavesAddress
+	SenderPK            crypto.PublicKey
+	Recipient           WavesAddress
+	Amount              uint64
+	Height              Height
+	IsActive            bool
+	CancelHeight        Height         // zero if the lease is active
+	CancelTransactionID *crypto.Digest // nil if the lease is active or was cancelled without transaction
+}
@@ -0,0 +1,40 @@
+package proto
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// Limits of the data received from the network. Parsers check them before allocating memory, because the sizes
+// and the counts of elements in binary formats are taken from the input and can't be trusted.
+const (
+	// MaxBlockSize is the maximum size of serialized block or transaction in bytes.
+	MaxBlockSize = 2 * MiB
+	// MaxTransactionsPerBlock is the maximum number of transactions in block or micro-block.
+	MaxTransactionsPerBlock = 6000
+	// MaxFeaturesPerBlock is the maximum number of features votes in block header.
+	MaxFeaturesPerBlock = 64
+)
+
+// LimitError is returned by parsers if the size or the number of elements in the input data exceeds the limit.
+type LimitError struct {
+	Subject string
+	Value   uint64
+	Limit   uint64
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%s %d exceeds the limit %d", e.Subject, e.Value, e.Limit)
+}
+
+// checkLimit returns LimitError if the value is greater than the limit. Negative values are not allowed.
+func checkLimit(subject string, value, limit int) error {
+	if value < 0 {
+		return errors.Errorf("invalid %s %d", subject, value)
+	}
+	if value > limit {
+		return &LimitError{Subject: subject, Value: uint64(value), Limit: uint64(limit)}
+	}
+	return nil
+}
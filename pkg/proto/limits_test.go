@@ -0,0 +1,99 @@
+package proto
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsingLimits(t *testing.T) {
+	var le *LimitError
+
+	_, err := BytesToTransactions(MaxTransactionsPerBlock+1, nil, TestNetScheme)
+	require.Error(t, err)
+	require.True(t, errors.As(err, &le))
+	assert.EqualValues(t, MaxTransactionsPerBlock, le.Limit)
+
+	_, err = BytesToTransactions(1, []byte{0, 0}, TestNetScheme)
+	assert.Error(t, err)
+
+	_, err = BytesToTransaction(make([]byte, MaxBlockSize+1), TestNetScheme)
+	assert.True(t, errors.As(err, &le))
+
+	var b Block
+	err = b.UnmarshalFromProtobuf(make([]byte, MaxBlockSize+1))
+	assert.True(t, errors.As(err, &le))
+
+	var txs Transactions
+	assert.Error(t, txs.UnmarshalFromProtobuf([]byte{0, 0}))
+
+	// Block with the huge number of features.
+	data, err := hex.DecodeString(blockTests[0].hexEncoded)
+	require.NoError(t, err)
+	txEnd := 121 + binary.BigEndian.Uint32(data[117:121])
+	binary.BigEndian.PutUint32(data[txEnd:txEnd+4], 0xffffffff)
+	err = b.UnmarshalBinary(data, MainNetScheme)
+	require.Error(t, err)
+	assert.True(t, errors.As(err, &le))
+}
+
+// fuzzSeedBlock returns the block from test vectors, it's used to make seeds of fuzz tests.
+func fuzzSeedBlock(f *testing.F) (*Block, []byte) {
+	data, err := hex.DecodeString(blockTests[0].hexEncoded)
+	require.NoError(f, err)
+	b := new(Block)
+	require.NoError(f, b.UnmarshalBinary(data, MainNetScheme))
+	return b, data
+}
+
+func FuzzBytesToTransaction(f *testing.F) {
+	b, _ := fuzzSeedBlock(f)
+	data, err := b.Transactions[0].MarshalBinary(MainNetScheme)
+	require.NoError(f, err)
+	f.Add(data)
+	f.Fuzz(func(_ *testing.T, data []byte) {
+		_, _ = BytesToTransaction(data, MainNetScheme)
+	})
+}
+
+func FuzzSignedTxFromProtobuf(f *testing.F) {
+	b, _ := fuzzSeedBlock(f)
+	data, err := b.Transactions[0].MarshalSignedToProtobuf(MainNetScheme)
+	require.NoError(f, err)
+	f.Add(data)
+	f.Fuzz(func(_ *testing.T, data []byte) {
+		_, _ = SignedTxFromProtobuf(data)
+	})
+}
+
+func FuzzBlockUnmarshalBinary(f *testing.F) {
+	_, data := fuzzSeedBlock(f)
+	f.Add(data)
+	f.Fuzz(func(_ *testing.T, data []byte) {
+		var b Block
+		_ = b.UnmarshalBinary(data, MainNetScheme)
+	})
+}
+
+func FuzzBlockUnmarshalFromProtobuf(f *testing.F) {
+	b, _ := fuzzSeedBlock(f)
+	data, err := b.MarshalToProtobuf(MainNetScheme)
+	require.NoError(f, err)
+	f.Add(data)
+	f.Fuzz(func(_ *testing.T, data []byte) {
+		var b Block
+		_ = b.UnmarshalFromProtobuf(data)
+	})
+}
+
+func FuzzMicroBlockUnmarshalBinary(f *testing.F) {
+	f.Add([]byte{3})
+	f.Fuzz(func(_ *testing.T, data []byte) {
+		var mb MicroBlock
+		_ = mb.UnmarshalBinary(data, MainNetScheme)
+	})
+}
@@ -48,10 +48,17 @@ func (a *MicroBlock) GetStateHash() (crypto.Digest, bool) {
 }
 
 func (a *MicroBlock) UnmarshalFromProtobuf(b []byte) error {
+	if err := checkLimit("micro-block size", len(b), MaxBlockSize); err != nil {
+		return err
+	}
 	var pbMicroBlock = &g.SignedMicroBlock{}
 	if err := pbMicroBlock.UnmarshalVT(b); err != nil {
 		return errors.Wrap(err, "SignedMicroBlock: failed to unmarshal")
 	}
+	txCount := len(pbMicroBlock.GetMicroBlock().GetTransactions())
+	if err := checkLimit("transactions count", txCount, MaxTransactionsPerBlock); err != nil {
+		return err
+	}
 	var c ProtobufConverter
 	res, err := c.MicroBlock(pbMicroBlock)
 	if err != nil {
@@ -102,6 +109,9 @@ func (a *MicroBlock) ToProtobuf(scheme Scheme) (*g.SignedMicroBlock, error) {
 }
 
 func (a *MicroBlock) UnmarshalBinary(b []byte, scheme Scheme) error {
+	if err := checkLimit("micro-block size", len(b), MaxBlockSize); err != nil {
+		return err
+	}
 	var err error
 	d := deserializer.NewDeserializer(b)
 
@@ -140,6 +150,9 @@ func (a *MicroBlock) UnmarshalBinary(b []byte, scheme Scheme) error {
 		return errors.Wrap(err, "failed to unmarshal microblock transaction count")
 	}
 
+	if tBytesLength < 4 {
+		return errors.Errorf("invalid microblock transaction bytes len %d", tBytesLength)
+	}
 	bts, err := d.Bytes(uint(tBytesLength) - 4)
 	if err != nil {
 		return errors.Wrap(err, "failed to unmarshal microblock transaction bytes")
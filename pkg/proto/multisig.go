@@ -0,0 +1,276 @@
+package proto
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+)
+
+// transactionProofs returns the proofs of transaction, creating them if absent, so the result could be modified
+// in place. Transactions with signatures are not supported because they can't hold more than one proof.
+func transactionProofs(tx Transaction) (*ProofsV1, error) {
+	var p **ProofsV1
+	switch t := tx.(type) {
+	case *IssueWithProofs:
+		p = &t.Proofs
+	case *TransferWithProofs:
+		p = &t.Proofs
+	case *ReissueWithProofs:
+		p = &t.Proofs
+	case *BurnWithProofs:
+		p = &t.Proofs
+	case *ExchangeWithProofs:
+		p = &t.Proofs
+	case *LeaseWithProofs:
+		p = &t.Proofs
+	case *LeaseCancelWithProofs:
+		p = &t.Proofs
+	case *CreateAliasWithProofs:
+		p = &t.Proofs
+	case *MassTransferWithProofs:
+		p = &t.Proofs
+	case *DataWithProofs:
+		p = &t.Proofs
+	case *SetScriptWithProofs:
+		p = &t.Proofs
+	case *SponsorshipWithProofs:
+		p = &t.Proofs
+	case *SetAssetScriptWithProofs:
+		p = &t.Proofs
+	case *InvokeScriptWithProofs:
+		p = &t.Proofs
+	case *UpdateAssetInfoWithProofs:
+		p = &t.Proofs
+	case *InvokeExpressionTransactionWithProofs:
+		p = &t.Proofs
+	default:
+		return nil, errors.Errorf("transaction of type %T can't be signed by multiple signers", tx)
+	}
+	if *p == nil {
+		*p = NewProofs()
+	}
+	return *p, nil
+}
+
+// MultiSigTransaction collects proofs of the transaction from several signers. Each signer has its own proof slot,
+// the index of slot is the position of signer's public key in the list of signers, so the list should follow
+// the order of keys in the verifier script, for example
+//
+//	sigVerify(tx.bodyBytes, tx.proofs[0], alicePK) && sigVerify(tx.bodyBytes, tx.proofs[1], bobPK)
+//
+// requires the signers list [alicePK, bobPK]. Incomplete transaction could be serialized to JSON and passed to
+// the next co-signer, all the proofs are verified on deserialization and merge.
+type MultiSigTransaction struct {
+	scheme  Scheme
+	tx      Transaction
+	signers []crypto.PublicKey
+	body    []byte
+}
+
+// NewMultiSigTransaction creates the multi-signature container for the unsigned transaction.
+// The existing proofs of transaction are verified against the signers at the same positions.
+func NewMultiSigTransaction(scheme Scheme, tx Transaction, signers []crypto.PublicKey) (*MultiSigTransaction, error) {
+	if len(signers) == 0 || len(signers) > proofsMaxCount {
+		return nil, errors.Errorf("invalid number of signers %d, expected from 1 to %d", len(signers), proofsMaxCount)
+	}
+	for i, pk := range signers {
+		for _, other := range signers[:i] {
+			if pk == other {
+				return nil, errors.Errorf("duplicate signer '%s'", pk.String())
+			}
+		}
+	}
+	proofs, err := transactionProofs(tx)
+	if err != nil {
+		return nil, err
+	}
+	if len(proofs.Proofs) > len(signers) {
+		return nil, errors.Errorf("transaction has %d proofs, but only %d signers", len(proofs.Proofs), len(signers))
+	}
+	body, err := MarshalTxBody(scheme, tx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal transaction body")
+	}
+	if err := tx.GenerateID(scheme); err != nil {
+		return nil, errors.Wrap(err, "failed to generate transaction ID")
+	}
+	m := &MultiSigTransaction{scheme: scheme, tx: tx, signers: signers, body: body}
+	for i, proof := range proofs.Proofs {
+		if len(proof) == 0 {
+			continue
+		}
+		if vErr := m.verify(i, proof); vErr != nil {
+			return nil, vErr
+		}
+	}
+	return m, nil
+}
+
+// Transaction returns the transaction with the collected proofs.
+func (m *MultiSigTransaction) Transaction() Transaction {
+	return m.tx
+}
+
+// Signers returns public keys of signers in order of proof slots.
+func (m *MultiSigTransaction) Signers() []crypto.PublicKey {
+	return m.signers
+}
+
+// Slot returns the index of signer's proof.
+func (m *MultiSigTransaction) Slot(pk crypto.PublicKey) (int, bool) {
+	for i, s := range m.signers {
+		if s == pk {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// Sign adds the proof of the signer with the given secret key to its slot.
+func (m *MultiSigTransaction) Sign(sk crypto.SecretKey) error {
+	pk := crypto.GeneratePublicKey(sk)
+	slot, ok := m.Slot(pk)
+	if !ok {
+		return errors.Errorf("'%s' is not a signer of transaction", pk.String())
+	}
+	sig, err := crypto.Sign(sk, m.body)
+	if err != nil {
+		return errors.Wrap(err, "failed to sign transaction")
+	}
+	return m.setProof(slot, sig.Bytes())
+}
+
+// AddProof verifies the proof of signer and puts it to the signer's slot.
+func (m *MultiSigTransaction) AddProof(pk crypto.PublicKey, proof []byte) error {
+	slot, ok := m.Slot(pk)
+	if !ok {
+		return errors.Errorf("'%s' is not a signer of transaction", pk.String())
+	}
+	if err := m.verify(slot, proof); err != nil {
+		return err
+	}
+	return m.setProof(slot, proof)
+}
+
+// Merge adds proofs from the other copy of the same transaction, e.g. signed by other co-signer.
+func (m *MultiSigTransaction) Merge(other *MultiSigTransaction) error {
+	if !bytes.Equal(m.body, other.body) {
+		return errors.New("failed to merge proofs of different transactions")
+	}
+	if len(m.signers) != len(other.signers) {
+		return errors.New("failed to merge proofs of transactions with different signers")
+	}
+	for i := range m.signers {
+		if m.signers[i] != other.signers[i] {
+			return errors.New("failed to merge proofs of transactions with different signers")
+		}
+	}
+	for i, proof := range other.proofs() {
+		if len(proof) == 0 {
+			continue
+		}
+		if err := m.setProof(i, proof); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Signed returns public keys of signers which have provided their proofs.
+func (m *MultiSigTransaction) Signed() []crypto.PublicKey {
+	proofs := m.proofs()
+	r := make([]crypto.PublicKey, 0, len(m.signers))
+	for i, pk := range m.signers {
+		if i < len(proofs) && len(proofs[i]) != 0 {
+			r = append(r, pk)
+		}
+	}
+	return r
+}
+
+// Missing returns public keys of signers which haven't provided their proofs yet.
+func (m *MultiSigTransaction) Missing() []crypto.PublicKey {
+	proofs := m.proofs()
+	r := make([]crypto.PublicKey, 0, len(m.signers))
+	for i, pk := range m.signers {
+		if i >= len(proofs) || len(proofs[i]) == 0 {
+			r = append(r, pk)
+		}
+	}
+	return r
+}
+
+func (m *MultiSigTransaction) proofs() []B58Bytes {
+	p, err := transactionProofs(m.tx)
+	if err != nil { // unreachable, the transaction type is checked on creation
+		panic(err)
+	}
+	return p.Proofs
+}
+
+func (m *MultiSigTransaction) verify(slot int, proof []byte) error {
+	sig, err := crypto.NewSignatureFromBytes(proof)
+	if err != nil {
+		return errors.Wrapf(err, "invalid proof #%d", slot)
+	}
+	if !crypto.Verify(m.signers[slot], sig, m.body) {
+		return errors.Errorf("proof #%d is not a valid signature of '%s'", slot, m.signers[slot].String())
+	}
+	return nil
+}
+
+// setProof puts the proof to the slot, empty proofs are added to fill the gaps before the slot.
+func (m *MultiSigTransaction) setProof(slot int, proof []byte) error {
+	p, err := transactionProofs(m.tx)
+	if err != nil {
+		return err
+	}
+	for len(p.Proofs) <= slot {
+		p.Proofs = append(p.Proofs, B58Bytes{})
+	}
+	p.Proofs[slot] = append(B58Bytes{}, proof...)
+	return nil
+}
+
+type multiSigTransactionJSON struct {
+	Scheme      Scheme             `json:"scheme"`
+	Signers     []crypto.PublicKey `json:"signers"`
+	Transaction json.RawMessage    `json:"transaction"`
+}
+
+// MarshalJSON writes the transaction with the collected proofs and the list of signers to JSON.
+func (m *MultiSigTransaction) MarshalJSON() ([]byte, error) {
+	tx, err := json.Marshal(m.tx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal transaction")
+	}
+	return json.Marshal(multiSigTransactionJSON{Scheme: m.scheme, Signers: m.signers, Transaction: tx})
+}
+
+// UnmarshalJSON reads the transaction from JSON and verifies all the proofs it contains.
+func (m *MultiSigTransaction) UnmarshalJSON(data []byte) error {
+	var tmp multiSigTransactionJSON
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return errors.Wrap(err, "failed to unmarshal multi-signature transaction")
+	}
+	tv := new(TransactionTypeVersion)
+	if err := json.Unmarshal(tmp.Transaction, tv); err != nil {
+		return errors.Wrap(err, "failed to unmarshal transaction type")
+	}
+	tx, err := GuessTransactionType(tv)
+	if err != nil {
+		return err
+	}
+	if err := UnmarshalTransactionFromJSON(tmp.Transaction, tmp.Scheme, tx); err != nil {
+		return errors.Wrap(err, "failed to unmarshal transaction")
+	}
+	r, err := NewMultiSigTransaction(tmp.Scheme, tx, tmp.Signers)
+	if err != nil {
+		return err
+	}
+	*m = *r
+	return nil
+}
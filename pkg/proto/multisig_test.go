@@ -0,0 +1,88 @@
+package proto
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+)
+
+func TestMultiSigTransaction(t *testing.T) {
+	var (
+		sks = make([]crypto.SecretKey, 3)
+		pks = make([]crypto.PublicKey, 3)
+	)
+	for i, seed := range []string{"alice", "bob", "carol"} {
+		sk, pk, err := crypto.GenerateKeyPair([]byte(seed))
+		require.NoError(t, err)
+		sks[i], pks[i] = sk, pk
+	}
+	addr, err := NewAddressFromPublicKey(TestNetScheme, pks[0])
+	require.NoError(t, err)
+	tx := NewUnsignedTransferWithProofs(3, pks[0], NewOptionalAssetWaves(), NewOptionalAssetWaves(),
+		1234567890, 100, 500000, NewRecipientFromAddress(addr), nil)
+
+	alice, err := NewMultiSigTransaction(TestNetScheme, tx, pks)
+	require.NoError(t, err)
+	require.NoError(t, alice.Sign(sks[2])) // carol signs first
+	assert.Equal(t, []crypto.PublicKey{pks[2]}, alice.Signed())
+	assert.Equal(t, []crypto.PublicKey{pks[0], pks[1]}, alice.Missing())
+
+	// Incomplete transaction is passed to bob as JSON.
+	js, err := json.Marshal(alice)
+	require.NoError(t, err)
+	bob := new(MultiSigTransaction)
+	require.NoError(t, json.Unmarshal(js, bob))
+	require.NoError(t, bob.Sign(sks[1]))
+	assert.Equal(t, []crypto.PublicKey{pks[1], pks[2]}, bob.Signed())
+
+	require.NoError(t, alice.Sign(sks[0]))
+	require.NoError(t, alice.Merge(bob))
+	assert.Empty(t, alice.Missing())
+
+	signed, ok := alice.Transaction().(*TransferWithProofs)
+	require.True(t, ok)
+	require.Len(t, signed.Proofs.Proofs, 3)
+	body, err := MarshalTxBody(TestNetScheme, signed)
+	require.NoError(t, err)
+	for i, pk := range pks {
+		sig, sErr := crypto.NewSignatureFromBytes(signed.Proofs.Proofs[i])
+		require.NoError(t, sErr)
+		assert.True(t, crypto.Verify(pk, sig, body))
+	}
+
+	// Proofs in wrong slots are rejected.
+	sig, err := crypto.Sign(sks[0], body)
+	require.NoError(t, err)
+	assert.Error(t, bob.AddProof(pks[1], sig.Bytes()))
+	_, other, err := crypto.GenerateKeyPair([]byte("mallory"))
+	require.NoError(t, err)
+	assert.Error(t, bob.AddProof(other, sig.Bytes()))
+
+	// Tampered transaction is rejected on deserialization.
+	tampered := NewUnsignedTransferWithProofs(3, pks[0], NewOptionalAssetWaves(), NewOptionalAssetWaves(),
+		1234567890, 1000000, 500000, NewRecipientFromAddress(addr), nil)
+	tampered.Proofs = signed.Proofs
+	_, err = NewMultiSigTransaction(TestNetScheme, tampered, pks)
+	assert.Error(t, err)
+}
+
+func TestMultiSigTransactionInvalidSigners(t *testing.T) {
+	_, pk, err := crypto.GenerateKeyPair([]byte("alice"))
+	require.NoError(t, err)
+	addr, err := NewAddressFromPublicKey(TestNetScheme, pk)
+	require.NoError(t, err)
+	tx := NewUnsignedTransferWithProofs(3, pk, NewOptionalAssetWaves(), NewOptionalAssetWaves(),
+		1234567890, 100, 500000, NewRecipientFromAddress(addr), nil)
+	_, err = NewMultiSigTransaction(TestNetScheme, tx, nil)
+	assert.Error(t, err)
+	_, err = NewMultiSigTransaction(TestNetScheme, tx, []crypto.PublicKey{pk, pk})
+	assert.Error(t, err)
+	sigTx := NewUnsignedTransferWithSig(pk, NewOptionalAssetWaves(), NewOptionalAssetWaves(),
+		1234567890, 100, 500000, NewRecipientFromAddress(addr), nil)
+	_, err = NewMultiSigTransaction(TestNetScheme, sigTx, []crypto.PublicKey{pk})
+	assert.Error(t, err)
+}
@@ -36,6 +36,9 @@ func MarshalSignedTxDeterministic(tx Transaction, scheme Scheme) ([]byte, error)
 }
 
 func TxFromProtobuf(data []byte) (Transaction, error) {
+	if err := checkLimit("transaction size", len(data), MaxBlockSize); err != nil {
+		return nil, err
+	}
 	var pbTx = &g.Transaction{}
 	err := pbTx.UnmarshalVT(data)
 	if err != nil {
@@ -50,6 +53,9 @@ func TxFromProtobuf(data []byte) (Transaction, error) {
 }
 
 func SignedTxFromProtobuf(data []byte) (Transaction, error) {
+	if err := checkLimit("transaction size", len(data), MaxBlockSize); err != nil {
+		return nil, err
+	}
 	var pbTx = &g.SignedTransaction{}
 	err := pbTx.UnmarshalVT(data)
 	if err != nil {
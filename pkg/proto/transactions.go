@@ -258,6 +258,9 @@ func BytesToTransaction(tx []byte, scheme Scheme) (Transaction, error) {
 	if len(tx) < 2 {
 		return nil, errors.New("invalid size of transaction's bytes slice")
 	}
+	if err := checkLimit("transaction size", len(tx), MaxBlockSize); err != nil {
+		return nil, err
+	}
 	if tx[0] == 0 {
 		transactionType, ok := bytesToTransactionsV2[TransactionType(tx[1])]
 		if !ok {
@@ -288,8 +291,14 @@ func BytesToTransaction(tx []byte, scheme Scheme) (Transaction, error) {
 }
 
 func BytesToTransactions(count int, txs []byte, scheme Scheme) ([]Transaction, error) {
+	if err := checkLimit("transactions count", count, MaxTransactionsPerBlock); err != nil {
+		return nil, err
+	}
 	res := make([]Transaction, count)
 	for i := 0; i < count; i++ {
+		if len(txs) < 4 {
+			return nil, errors.New("invalid tx size: exceeds bytes slice bounds")
+		}
 		n := int(binary.BigEndian.Uint32(txs[0:4]))
 		if n+4 > len(txs) {
 			return nil, errors.New("invalid tx size: exceeds bytes slice bounds")
@@ -396,6 +405,7 @@ type unmarshalerWithScheme interface {
 var (
 	_ = unmarshalerWithScheme(&CreateAliasWithProofs{})
 	_ = unmarshalerWithScheme(&CreateAliasWithSig{})
+	_ = unmarshalerWithScheme(&UpdateAssetInfoWithProofs{})
 )
 
 func UnmarshalTransactionFromJSON(data []byte, scheme Scheme, tx Transaction) (err error) {
@@ -0,0 +1,128 @@
+package proto
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/mr-tron/base58/base58"
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+)
+
+// Hand-written JSON writers of the most frequent transactions. They are used to serialize the lists of transactions
+// in blocks and API responses instead of reflection-based encoding/json, the output is byte-identical.
+// Methods MarshalJSON are not defined on transactions intentionally, because they would be promoted to the
+// structures which embed the transactions and break their JSON representation.
+
+// transactionJSONSizeHint is the approximate size of transaction in JSON, used to preallocate buffers.
+const transactionJSONSizeHint = 512
+
+func appendTransactionJSON(b []byte, tx Transaction) ([]byte, error) {
+	switch t := tx.(type) {
+	case *TransferWithProofs:
+		if t != nil {
+			return t.appendJSON(b)
+		}
+	case *TransferWithSig:
+		if t != nil {
+			return t.appendJSON(b)
+		}
+	}
+	js, err := json.Marshal(tx)
+	if err != nil {
+		return nil, err
+	}
+	return append(b, js...), nil
+}
+
+func (tx *TransferWithProofs) appendJSON(b []byte) ([]byte, error) {
+	b = append(b, `{"type":`...)
+	b = strconv.AppendUint(b, uint64(tx.Type), 10)
+	b = appendVersionAndIDJSON(b, tx.Version, tx.ID)
+	if tx.Proofs != nil {
+		b = append(b, `,"proofs":`...)
+		b = tx.Proofs.appendJSON(b)
+	}
+	return tx.Transfer.appendJSON(b)
+}
+
+func (tx *TransferWithSig) appendJSON(b []byte) ([]byte, error) {
+	b = append(b, `{"type":`...)
+	b = strconv.AppendUint(b, uint64(tx.Type), 10)
+	b = appendVersionAndIDJSON(b, tx.Version, tx.ID)
+	if tx.Signature != nil {
+		b = append(b, `,"signature":`...)
+		b = appendBase58JSON(b, tx.Signature[:])
+	}
+	return tx.Transfer.appendJSON(b)
+}
+
+// appendJSON writes the fields of Transfer and closes the JSON object of transaction.
+func (tr *Transfer) appendJSON(b []byte) ([]byte, error) {
+	b = append(b, `,"senderPublicKey":`...)
+	b = appendBase58JSON(b, tr.SenderPK[:])
+	b = append(b, `,"assetId":`...)
+	b = tr.AmountAsset.appendJSON(b)
+	b = append(b, `,"feeAssetId":`...)
+	b = tr.FeeAsset.appendJSON(b)
+	if tr.Timestamp != 0 {
+		b = append(b, `,"timestamp":`...)
+		b = strconv.AppendUint(b, tr.Timestamp, 10)
+	}
+	b = append(b, `,"amount":`...)
+	b = strconv.AppendUint(b, tr.Amount, 10)
+	b = append(b, `,"fee":`...)
+	b = strconv.AppendUint(b, tr.Fee, 10)
+	b = append(b, `,"recipient":`...)
+	r, err := tr.Recipient.MarshalJSON()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal recipient")
+	}
+	b = append(b, r...)
+	if len(tr.Attachment) != 0 {
+		b = append(b, `,"attachment":`...)
+		b = appendBase58JSON(b, tr.Attachment)
+	}
+	return append(b, '}'), nil
+}
+
+func appendVersionAndIDJSON(b []byte, version byte, id *crypto.Digest) []byte {
+	if version != 0 {
+		b = append(b, `,"version":`...)
+		b = strconv.AppendUint(b, uint64(version), 10)
+	}
+	if id != nil {
+		b = append(b, `,"id":`...)
+		b = appendBase58JSON(b, id[:])
+	}
+	return b
+}
+
+func (a OptionalAsset) appendJSON(b []byte) []byte {
+	if !a.Present {
+		return append(b, jsonNull...)
+	}
+	return appendBase58JSON(b, a.ID[:])
+}
+
+func (p *ProofsV1) appendJSON(b []byte) []byte {
+	if p.Proofs == nil {
+		return append(b, jsonNull...)
+	}
+	b = append(b, '[')
+	for i, proof := range p.Proofs {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = appendBase58JSON(b, proof)
+	}
+	return append(b, ']')
+}
+
+// appendBase58JSON writes bytes as quoted Base58 string, Base58 alphabet doesn't require escaping.
+func appendBase58JSON(b, v []byte) []byte {
+	b = append(b, '"')
+	b = append(b, base58.Encode(v)...)
+	return append(b, '"')
+}
@@ -0,0 +1,81 @@
+package proto
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+)
+
+func testTransactionsForJSON(t testing.TB) Transactions {
+	sk, pk, err := crypto.GenerateKeyPair([]byte("test seed"))
+	require.NoError(t, err)
+	addr, err := NewAddressFromPublicKey(TestNetScheme, pk)
+	require.NoError(t, err)
+	asset := *NewOptionalAssetFromDigest(crypto.MustDigestFromBase58("CMBHKDtyE8GMbZAZANNeE5n2HU4VDpsQaBLmfCw9ASbf"))
+	alias := NewRecipientFromAlias(*NewAlias(TestNetScheme, "some-alias"))
+
+	signedProofs := NewUnsignedTransferWithProofs(3, pk, asset, NewOptionalAssetWaves(), 1234567890, 100, 100000,
+		NewRecipientFromAddress(addr), Attachment("attachment"))
+	require.NoError(t, signedProofs.Sign(TestNetScheme, sk))
+	signedSig := NewUnsignedTransferWithSig(pk, NewOptionalAssetWaves(), asset, 1234567890, 100, 100000, alias, nil)
+	require.NoError(t, signedSig.Sign(TestNetScheme, sk))
+	emptyProofs := NewUnsignedTransferWithProofs(2, pk, NewOptionalAssetWaves(), NewOptionalAssetWaves(), 0, 1, 1,
+		alias, nil)
+	emptyProofs.Proofs = &ProofsV1{Proofs: []B58Bytes{{}, {1, 2, 3}}}
+	lease := NewUnsignedLeaseWithProofs(3, pk, NewRecipientFromAddress(addr), 100, 100000, 1234567890)
+	require.NoError(t, lease.Sign(TestNetScheme, sk))
+
+	return Transactions{
+		signedProofs,
+		signedSig,
+		NewUnsignedTransferWithProofs(1, pk, asset, asset, 1, 2, 3, NewRecipientFromAddress(addr), nil),
+		emptyProofs,
+		&TransferWithProofs{Type: TransferTransaction, Transfer: Transfer{Recipient: alias}},
+		lease,
+		(*TransferWithProofs)(nil),
+	}
+}
+
+func TestTransactionsMarshalJSON(t *testing.T) {
+	txs := testTransactionsForJSON(t)
+	for _, tx := range txs {
+		expected, err := json.Marshal([]Transaction{tx})
+		require.NoError(t, err)
+		actual, err := json.Marshal(Transactions{tx})
+		require.NoError(t, err)
+		assert.Equal(t, string(expected), string(actual))
+	}
+	expected, err := json.Marshal([]Transaction(txs))
+	require.NoError(t, err)
+	actual, err := json.Marshal(txs)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(actual))
+
+	_, err = json.Marshal(Transactions{&TransferWithProofs{Type: TransferTransaction}}) // empty recipient
+	assert.Error(t, err)
+}
+
+func BenchmarkTransactionsMarshalJSON(b *testing.B) {
+	txs := testTransactionsForJSON(b)
+	txs = txs[:len(txs)-1]
+	b.Run("reflection", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := json.Marshal([]Transaction(txs)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("writer", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := txs.MarshalJSON(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -6657,6 +6657,38 @@ func TestUpdateAssetInfoWithProofsFromJSON(t *testing.T) {
 	assert.Equal(t, "J8shEVBrQ4BLqsuYw5j6vQGCFJGMLBxr5nu2XvUWFEAR", tx.FeeAsset.String())
 }
 
+func TestUpdateAssetInfoWithProofsJSONRoundTrip(t *testing.T) {
+	var js = `{"type":17,"version":1,"chainId":84,"senderPublicKey":"3qTkgmBYFjdSEtib9C4b3yHiEexyJ59A5ZVjSvXsg569","assetId":"BJ3Q8kNPByCWHwJ3RLn55UPzUDVgnh64EwYAU5iCj6z6","name":"AssetName","description":"description of asset","feeAssetId":null,"fee":100000,"timestamp":1583406542756}`
+	seed, _ := base58.Decode("3TUPTbbpiM5UmZDhMmzdsKKNgMvyHwZQncKWfJrxk3bc")
+	sk, pk, err := crypto.GenerateKeyPair(seed)
+	require.NoError(t, err)
+
+	tx := new(UpdateAssetInfoWithProofs)
+	err = UnmarshalTransactionFromJSON([]byte(js), MainNetScheme, tx)
+	assert.Error(t, err)
+	err = UnmarshalTransactionFromJSON([]byte(js), TestNetScheme, tx)
+	require.NoError(t, err)
+	tx.SenderPK = pk
+	require.NoError(t, tx.Sign(TestNetScheme, sk))
+
+	pb, err := tx.ToProtobufSigned(TestNetScheme)
+	require.NoError(t, err)
+	c := ProtobufConverter{FallbackChainID: TestNetScheme}
+	ptx, err := c.SignedTransaction(pb)
+	require.NoError(t, err)
+	assert.Equal(t, tx, ptx)
+
+	b, err := json.Marshal(ptx)
+	require.NoError(t, err)
+	jtx := new(UpdateAssetInfoWithProofs)
+	require.NoError(t, UnmarshalTransactionFromJSON(b, TestNetScheme, jtx))
+	require.NoError(t, jtx.GenerateID(TestNetScheme))
+	assert.Equal(t, tx, jtx)
+	ok, err := jtx.Verify(TestNetScheme, pk)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
 func BenchmarkBytesToTransaction_WithReflection(b *testing.B) {
 	b.ReportAllocs()
 	bts := []byte{0, 4, 2, 132, 79, 148, 251, 4, 38, 180, 107, 148, 225, 225, 107, 146, 125, 26, 243, 25, 35, 202, 83, 226, 142, 64, 8, 106, 72, 250, 228, 237, 132, 90, 16, 0, 0, 0, 0, 1, 104, 225, 147, 43, 220, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 1, 134, 160, 1, 68, 152, 220, 142, 172, 155, 208, 202, 105, 149, 210, 120, 159, 30, 146, 64, 212, 101, 147, 228, 250, 36, 56, 81, 55, 0, 3, 102, 111, 111, 1, 0, 1, 0, 64, 154, 86, 48, 50, 47, 58, 64, 254, 146, 85, 72, 252, 23, 49, 64, 40, 34, 104, 117, 225, 126, 65, 235, 225, 38, 13, 114, 120, 7, 30, 240, 209, 37, 144, 166, 15, 14, 241, 232, 101, 103, 82, 232, 163, 165, 82, 96, 52, 132, 191, 194, 160, 155, 237, 106, 43, 82, 203, 125, 122, 219, 35, 186, 8}
@@ -4978,6 +4978,23 @@ func NewUnsignedUpdateAssetInfoWithProofs(v byte, assetID crypto.Digest, senderP
 	}
 }
 
+// UnmarshalJSONWithScheme reads transaction from JSON and checks that optional "chainId" field,
+// which is sent by Scala nodes and clients, matches the given scheme. Zero scheme disables the check.
+func (tx *UpdateAssetInfoWithProofs) UnmarshalJSONWithScheme(data []byte, scheme Scheme) error {
+	type shadowed UpdateAssetInfoWithProofs
+	tmp := struct {
+		ChainID Scheme `json:"chainId,omitempty"`
+		*shadowed
+	}{shadowed: (*shadowed)(tx)}
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return errors.Wrap(err, "failed to unmarshal UpdateAssetInfoWithProofs from JSON")
+	}
+	if scheme != 0 && tmp.ChainID != 0 && tmp.ChainID != scheme {
+		return errors.Errorf("invalid chain ID %d of UpdateAssetInfoWithProofs, expected %d", tmp.ChainID, scheme)
+	}
+	return nil
+}
+
 func (tx *UpdateAssetInfoWithProofs) MarshalBinary(Scheme) ([]byte, error) {
 	return nil, errors.New("binary format is not defined for UpdateAssetInfoTransaction")
 }
@@ -3279,6 +3279,14 @@ type ScriptBasicInfo struct {
 	IsDApp         bool
 }
 
+// ScriptChange describes the change of account script made by SetScript transaction.
+// ScriptHash is the Blake2b-256 hash of the script bytes or nil if the script was removed.
+type ScriptChange struct {
+	Height        Height         `json:"height"`
+	TransactionID crypto.Digest  `json:"transactionId"`
+	ScriptHash    *crypto.Digest `json:"scriptHash"`
+}
+
 type Script []byte
 
 // IsEmpty checks that script bytes slice is nil or slice length equals zero
@@ -4197,6 +4205,19 @@ func (b *FullWavesBalance) ToProtobuf() *pb.BalanceResponse_WavesBalances {
 	}
 }
 
+// BalanceAtHeight is the value of balance set at the given height.
+type BalanceAtHeight struct {
+	Height  Height `json:"height"`
+	Balance uint64 `json:"balance"`
+}
+
+// EffectiveBalances holds effective and generating Waves balances of an account at the given height.
+type EffectiveBalances struct {
+	Height     Height `json:"height"`
+	Effective  uint64 `json:"effective"`
+	Generating uint64 `json:"generating"`
+}
+
 type StateHash struct {
 	BlockID BlockID
 	SumHash crypto.Digest
@@ -26,6 +26,7 @@ import (
 const (
 	invocationsLimit = 100
 	maxInputsSize    = 16 * 32
+	maxBLSPublicKeys = 128
 )
 
 func containsAddress(addr proto.WavesAddress, list []proto.WavesAddress) bool {
@@ -1283,6 +1284,68 @@ func bn256Groth16Verify(_ environment, args ...rideType) (rideType, error) {
 	return rideBoolean(ok), nil
 }
 
+func blsVerify(_ environment, args ...rideType) (rideType, error) {
+	if err := checkArgs(args, 3); err != nil {
+		return nil, errors.Wrap(err, "blsVerify")
+	}
+	pk, ok := args[0].(rideByteVector)
+	if !ok {
+		return nil, errors.Errorf("blsVerify: unexpected argument type '%s'", args[0].instanceOf())
+	}
+	msg, ok := args[1].(rideByteVector)
+	if !ok {
+		return nil, errors.Errorf("blsVerify: unexpected argument type '%s'", args[1].instanceOf())
+	}
+	sig, ok := args[2].(rideByteVector)
+	if !ok {
+		return nil, errors.Errorf("blsVerify: unexpected argument type '%s'", args[2].instanceOf())
+	}
+	if l := len(pk); l != crypto.BLSPublicKeySize {
+		return nil, errors.Errorf("blsVerify: invalid public key size %d", l)
+	}
+	if l := len(sig); l != crypto.BLSSignatureSize {
+		return nil, errors.Errorf("blsVerify: invalid signature size %d", l)
+	}
+	return rideBoolean(crypto.BLSVerify(crypto.BLSPublicKey(pk), msg, crypto.BLSSignature(sig))), nil
+}
+
+func blsFastAggregateVerify(_ environment, args ...rideType) (rideType, error) {
+	if err := checkArgs(args, 3); err != nil {
+		return nil, errors.Wrap(err, "blsFastAggregateVerify")
+	}
+	list, ok := args[0].(rideList)
+	if !ok {
+		return nil, errors.Errorf("blsFastAggregateVerify: unexpected argument type '%s'", args[0].instanceOf())
+	}
+	msg, ok := args[1].(rideByteVector)
+	if !ok {
+		return nil, errors.Errorf("blsFastAggregateVerify: unexpected argument type '%s'", args[1].instanceOf())
+	}
+	sig, ok := args[2].(rideByteVector)
+	if !ok {
+		return nil, errors.Errorf("blsFastAggregateVerify: unexpected argument type '%s'", args[2].instanceOf())
+	}
+	if l := len(list); l == 0 || l > maxBLSPublicKeys {
+		return nil, errors.Errorf("blsFastAggregateVerify: invalid number of public keys %d, must be from 1 to %d",
+			l, maxBLSPublicKeys)
+	}
+	if l := len(sig); l != crypto.BLSSignatureSize {
+		return nil, errors.Errorf("blsFastAggregateVerify: invalid signature size %d", l)
+	}
+	pks := make([]crypto.BLSPublicKey, len(list))
+	for i, item := range list {
+		pk, ok := item.(rideByteVector)
+		if !ok {
+			return nil, errors.Errorf("blsFastAggregateVerify: unexpected type of public key '%s'", item.instanceOf())
+		}
+		if l := len(pk); l != crypto.BLSPublicKeySize {
+			return nil, errors.Errorf("blsFastAggregateVerify: invalid public key size %d", l)
+		}
+		pks[i] = crypto.BLSPublicKey(pk)
+	}
+	return rideBoolean(crypto.BLSFastAggregateVerify(pks, msg, crypto.BLSSignature(sig))), nil
+}
+
 func ecRecover(_ environment, args ...rideType) (rideType, error) {
 	digest, signature, err := bytesArgs2(args)
 	if err != nil {
@@ -1779,3 +1779,44 @@ func TestGroth16VerifyInvalidArguments(t *testing.T) {
 		})
 	}
 }
+
+func TestBLSVerify(t *testing.T) {
+	te := &mockRideEnvironment{}
+	msg := rideByteVector("message")
+	var pks rideList
+	var sigs []crypto.BLSSignature
+	for i := range 3 {
+		sk, err := crypto.GenerateBLSSecretKey([]byte(fmt.Sprintf("input key material of the test key #%d", i)))
+		require.NoError(t, err)
+		pk, err := sk.PublicKey()
+		require.NoError(t, err)
+		sig, err := sk.Sign(msg)
+		require.NoError(t, err)
+		pks = append(pks, rideByteVector(pk.Bytes()))
+		sigs = append(sigs, sig)
+	}
+	agg, err := crypto.BLSAggregateSignatures(sigs)
+	require.NoError(t, err)
+
+	r, err := blsVerify(te, pks[0], msg, rideByteVector(sigs[0].Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, rideBoolean(true), r)
+	r, err = blsVerify(te, pks[1], msg, rideByteVector(sigs[0].Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, rideBoolean(false), r)
+	r, err = blsFastAggregateVerify(te, pks, msg, rideByteVector(agg.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, rideBoolean(true), r)
+	r, err = blsFastAggregateVerify(te, pks[:2], msg, rideByteVector(agg.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, rideBoolean(false), r)
+
+	_, err = blsVerify(te, pks[0], msg, rideByteVector{1, 2, 3})
+	assert.ErrorContains(t, err, "invalid signature size 3")
+	_, err = blsVerify(te, rideUnit{}, msg, rideByteVector(agg.Bytes()))
+	assert.ErrorContains(t, err, "unexpected argument type 'Unit'")
+	_, err = blsFastAggregateVerify(te, rideList{}, msg, rideByteVector(agg.Bytes()))
+	assert.ErrorContains(t, err, "invalid number of public keys 0")
+	_, err = blsFastAggregateVerify(te, rideList{rideInt(1)}, msg, rideByteVector(agg.Bytes()))
+	assert.ErrorContains(t, err, "unexpected type of public key 'Int'")
+}
@@ -820,6 +820,8 @@ func functionsV7() map[string]string {
 
 func functionsV8() map[string]string {
 	m := functionsV7()
+	m["802"] = "blsVerify"
+	m["803"] = "blsFastAggregateVerify"
 	m["901"] = "calculateDelay"
 	m["1106"] = "listReplaceByIndex"
 	constructorsFunctions(ast.LibV8, m)
@@ -898,6 +900,8 @@ func catalogueV8() map[string]int {
 	m["423"] = 1
 	m["424"] = 1
 	m["425"] = 35
+	m["802"] = 2700
+	m["803"] = 3200
 	m["901"] = 1
 	m["1105"] = 4
 	m["1106"] = 4
@@ -6142,6 +6142,77 @@ func TestNewRideV8Functions(t *testing.T) {
 		const expectedComplexity = 12
 		assert.Equal(t, expectedComplexity, res.Complexity())
 	})
+	blsKeys := make([]crypto.BLSPublicKey, 3)
+	blsSigs := make([]crypto.BLSSignature, 3)
+	for i := range blsKeys {
+		sk, skErr := crypto.GenerateBLSSecretKey([]byte(fmt.Sprintf("BLS secret key seed of the signer #%d", i)))
+		require.NoError(t, skErr)
+		blsKeys[i], err = sk.PublicKey()
+		require.NoError(t, err)
+		blsSigs[i], err = sk.Sign([]byte("message"))
+		require.NoError(t, err)
+	}
+	aggregated, err := crypto.BLSAggregateSignatures(blsSigs)
+	require.NoError(t, err)
+	t.Run("blsVerify", func(t *testing.T) {
+		const src = `
+		{-# STDLIB_VERSION 8 #-}
+		{-# CONTENT_TYPE DAPP #-}
+		{-# SCRIPT_TYPE ACCOUNT #-}
+
+		@Callable(i)
+		func call() = {
+		  let pk = base58'%s'
+		  let sig = base58'%s'
+		  strict valid = blsVerify(pk, toBytes("message"), sig)
+		  strict other = blsVerify(pk, toBytes("other message"), sig)
+		  if !valid || other then
+			throw("unexpected verification result")
+		  else
+		  []
+		}
+`
+		tree, errs := ridec.CompileToTree(fmt.Sprintf(src, blsKeys[0].String(), blsSigs[0].String()))
+		require.Empty(t, errs)
+		te := createEnv(t, tree)
+		env := te.toEnv()
+		res, callErr := CallFunction(env, tree, proto.NewFunctionCall("call", proto.Arguments{}))
+		assert.NoError(t, callErr)
+		assert.True(t, res.Result())
+		assert.Empty(t, res.ScriptActions())
+		const expectedComplexity = 5419
+		assert.Equal(t, expectedComplexity, res.Complexity())
+	})
+	t.Run("blsFastAggregateVerify", func(t *testing.T) {
+		const src = `
+		{-# STDLIB_VERSION 8 #-}
+		{-# CONTENT_TYPE DAPP #-}
+		{-# SCRIPT_TYPE ACCOUNT #-}
+
+		@Callable(i)
+		func call() = {
+		  let pks = [base58'%s', base58'%s', base58'%s']
+		  let sig = base58'%s'
+		  strict valid = blsFastAggregateVerify(pks, toBytes("message"), sig)
+		  strict partial = blsFastAggregateVerify([pks[0], pks[1]], toBytes("message"), sig)
+		  if !valid || partial then
+			throw("unexpected verification result")
+		  else
+		  []
+		}
+`
+		tree, errs := ridec.CompileToTree(fmt.Sprintf(src,
+			blsKeys[0].String(), blsKeys[1].String(), blsKeys[2].String(), aggregated.String()))
+		require.Empty(t, errs)
+		te := createEnv(t, tree)
+		env := te.toEnv()
+		res, callErr := CallFunction(env, tree, proto.NewFunctionCall("call", proto.Arguments{}))
+		assert.NoError(t, callErr)
+		assert.True(t, res.Result())
+		assert.Empty(t, res.ScriptActions())
+		const expectedComplexity = 6428
+		assert.Equal(t, expectedComplexity, res.Complexity())
+	})
 }
 
 func TestZeroComplexitySanityCheckInComplexityCalculator(t *testing.T) {
@@ -1,8 +1,12 @@
 package services
 
 import (
+	"github.com/wavesplatform/gowaves/pkg/node/events"
+	"github.com/wavesplatform/gowaves/pkg/node/forks"
 	"github.com/wavesplatform/gowaves/pkg/node/messages"
 	"github.com/wavesplatform/gowaves/pkg/node/peers"
+	"github.com/wavesplatform/gowaves/pkg/node/watchlist"
+	"github.com/wavesplatform/gowaves/pkg/node/webhooks"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 	"github.com/wavesplatform/gowaves/pkg/state"
 	"github.com/wavesplatform/gowaves/pkg/types"
@@ -42,6 +46,16 @@ type MicroBlockInvCache interface {
 	Get(proto.BlockID) (*proto.MicroBlockInv, bool)
 }
 
+type AliasResolver interface {
+	AddrByAlias(alias proto.Alias) (proto.WavesAddress, error)
+}
+
+// TransactionFilter decides whether the miner packs the transaction into its own blocks. Transactions rejected by
+// the filter stay in UTX pool and are relayed as usual.
+type TransactionFilter interface {
+	Check(tx proto.Transaction, aliases AliasResolver) error
+}
+
 type Services struct {
 	NodeName        string
 	State           state.State
@@ -57,4 +71,9 @@ type Services struct {
 	InternalChannel chan messages.InternalMessage
 	MinPeersMining  int
 	SkipMessageList *messages.SkipMessageList
+	Events          *events.Bus
+	Forks           *forks.Registry
+	Webhooks        *webhooks.Manager
+	Watchlist       *watchlist.Watchlist
+	MinerFilter     TransactionFilter // optional, all applicable transactions are packed if not set
 }
@@ -180,13 +180,14 @@ func (f *FunctionalitySettings) RangeForGeneratingBalanceByHeight(height proto.H
 
 type BlockchainSettings struct {
 	FunctionalitySettings
-	Type    BlockchainType `json:"type"`
-	Genesis proto.Block    `json:"genesis"`
+	Type        BlockchainType `json:"type"`
+	Genesis     proto.Block    `json:"genesis"`
+	Checkpoints Checkpoints    `json:"checkpoints,omitempty"`
 }
 
 func (s *BlockchainSettings) UnmarshalJSON(bytes []byte) error {
-	type shadowed *BlockchainSettings
-	if err := json.Unmarshal(bytes, shadowed(s)); err != nil {
+	type shadowed BlockchainSettings // the type without methods to avoid recursive calls of UnmarshalJSON
+	if err := json.Unmarshal(bytes, (*shadowed)(s)); err != nil {
 		return err
 	}
 	return s.validate()
@@ -200,6 +201,9 @@ func (s *BlockchainSettings) validate() error {
 	if s.BlockRewardTermAfter20 < s.BlockRewardVotingPeriod {
 		return errors.New("'block_reward_term_after_20' cannot be greater than 'block_reward_voting_period'")
 	}
+	if err := s.Checkpoints.validate(); err != nil {
+		return errors.Wrap(err, "invalid 'checkpoints'")
+	}
 	return nil
 }
 
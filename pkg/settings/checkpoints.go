@@ -0,0 +1,80 @@
+package settings
+
+import (
+	"cmp"
+	"encoding/json"
+	"io"
+	"slices"
+
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+// Checkpoint is the block trusted to be in the blockchain at the given height.
+type Checkpoint struct {
+	Height  proto.Height  `json:"height"`
+	BlockID proto.BlockID `json:"block_id"`
+}
+
+// Checkpoints is the list of trusted blocks. Chains with other blocks at the heights of checkpoints are rejected
+// and signatures of protobuf blocks below the last checkpoint are not verified if they are applied in the same pack
+// with the checkpoint block linked to them by parent IDs.
+type Checkpoints []Checkpoint
+
+// ReadCheckpoints reads JSON array of checkpoints.
+func ReadCheckpoints(r io.Reader) (Checkpoints, error) {
+	var cps Checkpoints
+	if err := json.NewDecoder(r).Decode(&cps); err != nil {
+		return nil, errors.Wrap(err, "failed to read checkpoints")
+	}
+	if err := cps.validate(); err != nil {
+		return nil, err
+	}
+	return cps, nil
+}
+
+func (c Checkpoints) validate() error {
+	heights := make(map[proto.Height]struct{}, len(c))
+	for _, cp := range c {
+		if cp.Height == 0 {
+			return errors.New("checkpoint height must be positive")
+		}
+		if _, ok := heights[cp.Height]; ok {
+			return errors.Errorf("duplicate checkpoint at height %d", cp.Height)
+		}
+		heights[cp.Height] = struct{}{}
+	}
+	return nil
+}
+
+// Merge returns the checkpoints of both lists, the other list takes precedence at the same heights.
+func (c Checkpoints) Merge(other Checkpoints) Checkpoints {
+	r := slices.Clone(other)
+	for _, cp := range c {
+		if _, ok := other.At(cp.Height); !ok {
+			r = append(r, cp)
+		}
+	}
+	slices.SortFunc(r, func(a, b Checkpoint) int { return cmp.Compare(a.Height, b.Height) })
+	return r
+}
+
+// At returns the ID of the trusted block at the height.
+func (c Checkpoints) At(height proto.Height) (proto.BlockID, bool) {
+	for _, cp := range c {
+		if cp.Height == height {
+			return cp.BlockID, true
+		}
+	}
+	return proto.BlockID{}, false
+}
+
+// LastHeight returns the height of the last checkpoint, zero if there are no checkpoints.
+func (c Checkpoints) LastHeight() proto.Height {
+	var h proto.Height
+	for _, cp := range c {
+		h = max(h, cp.Height)
+	}
+	return h
+}
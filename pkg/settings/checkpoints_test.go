@@ -0,0 +1,42 @@
+package settings
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+func TestReadCheckpoints(t *testing.T) {
+	id1 := proto.NewBlockIDFromDigest(crypto.Digest{1})
+	id2 := proto.NewBlockIDFromDigest(crypto.Digest{2})
+	cps, err := ReadCheckpoints(strings.NewReader(
+		`[{"height":20,"block_id":"` + id2.String() + `"},{"height":10,"block_id":"` + id1.String() + `"}]`))
+	require.NoError(t, err)
+	id, ok := cps.At(10)
+	assert.True(t, ok)
+	assert.Equal(t, id1, id)
+	_, ok = cps.At(15)
+	assert.False(t, ok)
+	assert.Equal(t, proto.Height(20), cps.LastHeight())
+	assert.Zero(t, Checkpoints(nil).LastHeight())
+
+	_, err = ReadCheckpoints(strings.NewReader(`[{"height":0,"block_id":"` + id1.String() + `"}]`))
+	assert.Error(t, err)
+	_, err = ReadCheckpoints(strings.NewReader(
+		`[{"height":1,"block_id":"` + id1.String() + `"},{"height":1,"block_id":"` + id2.String() + `"}]`))
+	assert.Error(t, err)
+}
+
+func TestMergeCheckpoints(t *testing.T) {
+	id1 := proto.NewBlockIDFromDigest(crypto.Digest{1})
+	id2 := proto.NewBlockIDFromDigest(crypto.Digest{2})
+	id3 := proto.NewBlockIDFromDigest(crypto.Digest{3})
+	embedded := Checkpoints{{Height: 30, BlockID: id3}, {Height: 10, BlockID: id1}}
+	merged := embedded.Merge(Checkpoints{{Height: 10, BlockID: id2}})
+	assert.Equal(t, Checkpoints{{Height: 10, BlockID: id2}, {Height: 30, BlockID: id3}}, merged)
+}
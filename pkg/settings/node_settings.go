@@ -6,18 +6,52 @@ import (
 	"strings"
 )
 
+// NodeMode defines the role of the node.
+type NodeMode string
+
+const (
+	// FullNodeMode is the default mode with all features enabled.
+	FullNodeMode NodeMode = "full"
+	// APIOnlyNodeMode disables mining and wallet related API endpoints.
+	APIOnlyNodeMode NodeMode = "api"
+	// ValidatorOnlyNodeMode disables the most of public API endpoints, only node's status and
+	// API key protected endpoints are available.
+	ValidatorOnlyNodeMode NodeMode = "validator"
+)
+
+// NewNodeModeFromString parses the node mode, empty string is treated as FullNodeMode.
+func NewNodeModeFromString(s string) (NodeMode, error) {
+	switch m := NodeMode(strings.ToLower(strings.TrimSpace(s))); m {
+	case "":
+		return FullNodeMode, nil
+	case FullNodeMode, APIOnlyNodeMode, ValidatorOnlyNodeMode:
+		return m, nil
+	default:
+		return "", errors.Errorf("unknown node mode %q", s)
+	}
+}
+
+// MiningAllowed reports whether the node is allowed to generate blocks in this mode.
+func (m NodeMode) MiningAllowed() bool {
+	return m != APIOnlyNodeMode
+}
+
 type NodeSettings struct {
 	DeclaredAddr string
 	WavesNetwork string
 	Addresses    string
 	HttpAddr     string
 	GrpcAddr     string
+	Mode         NodeMode
 }
 
 func (a NodeSettings) Validate() error {
 	if len(a.WavesNetwork) == 0 {
 		return errors.Errorf("empty WavesNetwork")
 	}
+	if _, err := NewNodeModeFromString(string(a.Mode)); err != nil {
+		return err
+	}
 	return nil
 }
 
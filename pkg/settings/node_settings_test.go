@@ -10,3 +10,27 @@ func TestFromEnvironString(t *testing.T) {
 	FromJavaEnvironString(settings, "-Dwaves.miner.quorum=0 -Dwaves.network.node-name=node01 -Dwaves.wallet.seed=wzd2MzQ8-Dlogback.stdout.level=TRACE -Dlogback.file.level=OFF -Dwaves.network.declared-address=10.147.77.193:6863")
 	require.Equal(t, "10.147.77.193:6863", settings.DeclaredAddr)
 }
+
+func TestNewNodeModeFromString(t *testing.T) {
+	for _, tc := range []struct {
+		s    string
+		mode NodeMode
+		ok   bool
+	}{
+		{"", FullNodeMode, true},
+		{"full", FullNodeMode, true},
+		{"API", APIOnlyNodeMode, true},
+		{" validator ", ValidatorOnlyNodeMode, true},
+		{"observer", "", false},
+	} {
+		m, err := NewNodeModeFromString(tc.s)
+		if tc.ok {
+			require.NoError(t, err)
+			require.Equal(t, tc.mode, m)
+		} else {
+			require.Error(t, err)
+		}
+	}
+	require.False(t, APIOnlyNodeMode.MiningAllowed())
+	require.True(t, ValidatorOnlyNodeMode.MiningAllowed())
+}
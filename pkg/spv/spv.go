@@ -0,0 +1,192 @@
+// Package spv implements simplified payment verification of Waves transactions.
+//
+// A Proof consists of the transaction, the Merkle proof of its inclusion into the transactions root of the block and
+// the chain of signed block headers starting from that block. The proof is verified against the ID of a block trusted
+// by the verifier, no access to the blockchain state is required. It allows cross-chain bridges and light clients to
+// check transactions received from untrusted sources. Note that failed transactions are included into blocks too,
+// so the proof doesn't tell whether the transaction was applied successfully.
+//
+// The state of the blockchain is not merkleized, so there are no proofs of account state. Since protocol version 1.5
+// block headers include the state hash of the block, which commits to the state changes made by the block.
+package spv
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	g "github.com/wavesplatform/gowaves/pkg/grpc/generated/waves"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+// MaxHeaders is the maximum number of block headers in a proof.
+const MaxHeaders = 1000
+
+// Proof is the SPV package proving the inclusion of the transaction into the blockchain.
+type Proof struct {
+	TransactionID crypto.Digest `json:"transactionId"`
+	// Transaction is the signed transaction in protobuf format.
+	Transaction      proto.B64Bytes `json:"transaction"`
+	TransactionIndex uint64         `json:"transactionIndex"`
+	// MerkleProof is the proof of inclusion of the transaction into the transactions root of the first block,
+	// digests are in root to leafs order.
+	MerkleProof []crypto.Digest `json:"merkleProof"`
+	// Height is the height of the first block.
+	Height proto.Height `json:"height"`
+	// Headers are the signed block headers in protobuf format. The first header is the header of the block containing
+	// the transaction, every next header references the previous one.
+	Headers []proto.B64Bytes `json:"headers"`
+}
+
+// NewProof creates the proof of the transaction with the given ID included into the block at the given height.
+// Headers of the blocks following the block are appended to the proof, they must form a chain.
+func NewProof(
+	scheme proto.Scheme, block *proto.Block, height proto.Height, txID crypto.Digest, next []*proto.BlockHeader,
+) (*Proof, error) {
+	if block.Version < proto.ProtobufBlockVersion {
+		return nil, errors.Errorf("no transactions root in block of version %d", block.Version)
+	}
+	if len(next)+1 > MaxHeaders {
+		return nil, errors.Errorf("too many headers %d, maximum is %d", len(next)+1, MaxHeaders)
+	}
+	leaves := make([][]byte, len(block.Transactions))
+	index := -1
+	for i, tx := range block.Transactions {
+		id, err := tx.GetID(scheme)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get ID of transaction #%d", i)
+		}
+		if bytes.Equal(id, txID.Bytes()) {
+			index = i
+		}
+		leaves[i], err = tx.MerkleBytes(scheme)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get merkle bytes of transaction #%d", i)
+		}
+	}
+	if index < 0 {
+		return nil, errors.Errorf("transaction %s is not in block %s", txID.String(), block.BlockID().String())
+	}
+	tree, err := crypto.NewMerkleTree()
+	if err != nil {
+		return nil, err
+	}
+	merkleProof, err := tree.Proof(leaves, uint64(index))
+	if err != nil {
+		return nil, err
+	}
+	txBytes, err := proto.MarshalSignedTxDeterministic(block.Transactions[index], scheme)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal transaction")
+	}
+	headers := make([]proto.B64Bytes, 0, len(next)+1)
+	prev := &block.BlockHeader
+	for _, h := range append([]*proto.BlockHeader{prev}, next...) {
+		if h != prev && h.Parent != prev.BlockID() {
+			return nil, errors.Errorf("block %s doesn't reference block %s", h.BlockID().String(),
+				prev.BlockID().String())
+		}
+		hb, mErr := h.MarshalHeaderToProtobuf(scheme)
+		if mErr != nil {
+			return nil, errors.Wrapf(mErr, "failed to marshal header of block %s", h.BlockID().String())
+		}
+		headers = append(headers, hb)
+		prev = h
+	}
+	return &Proof{
+		TransactionID:    txID,
+		Transaction:      txBytes,
+		TransactionIndex: uint64(index),
+		MerkleProof:      merkleProof,
+		Height:           height,
+		Headers:          headers,
+	}, nil
+}
+
+// Verify checks the proof and returns the proven transaction. The last header of the proof must be the header of
+// the trusted block. Signatures of all headers and their links to each other are checked, the transaction must be
+// included into the first block.
+func (p *Proof) Verify(scheme proto.Scheme, trusted proto.BlockID) (proto.Transaction, error) {
+	if len(p.Headers) == 0 {
+		return nil, errors.New("no headers")
+	}
+	if len(p.Headers) > MaxHeaders {
+		return nil, errors.Errorf("too many headers %d, maximum is %d", len(p.Headers), MaxHeaders)
+	}
+	var first, prev *proto.BlockHeader
+	for i, hb := range p.Headers {
+		h, err := verifiedHeader(scheme, hb)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid header #%d", i)
+		}
+		if prev != nil && h.Parent != prev.BlockID() {
+			return nil, errors.Errorf("header #%d doesn't reference the previous header", i)
+		}
+		if first == nil {
+			first = h
+		}
+		prev = h
+	}
+	if prev.BlockID() != trusted {
+		return nil, errors.Errorf("last block %s is not the trusted block %s", prev.BlockID().String(),
+			trusted.String())
+	}
+	tx, err := proto.SignedTxFromProtobuf(p.Transaction)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal transaction")
+	}
+	id, err := tx.GetID(scheme)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get transaction ID")
+	}
+	if !bytes.Equal(id, p.TransactionID.Bytes()) {
+		return nil, errors.Errorf("transaction ID mismatch, expected %s", p.TransactionID.String())
+	}
+	mb, err := tx.MerkleBytes(scheme)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get transaction merkle bytes")
+	}
+	leaf, err := crypto.FastHash(mb)
+	if err != nil {
+		return nil, err
+	}
+	if len(p.MerkleProof) == 0 || len(p.MerkleProof) < 64 && p.TransactionIndex>>len(p.MerkleProof) != 0 {
+		return nil, errors.New("transaction index doesn't match merkle proof")
+	}
+	tree, err := crypto.NewMerkleTree()
+	if err != nil {
+		return nil, err
+	}
+	root := tree.RebuildRoot(leaf, p.MerkleProof, p.TransactionIndex)
+	if !bytes.Equal(root.Bytes(), first.TransactionsRoot) {
+		return nil, errors.New("transaction is not included into the block")
+	}
+	return tx, nil
+}
+
+func verifiedHeader(scheme proto.Scheme, data []byte) (*proto.BlockHeader, error) {
+	pb := new(g.Block)
+	if err := pb.UnmarshalVT(data); err != nil {
+		return nil, err
+	}
+	if pb.GetHeader().GetChainId() != int32(scheme) {
+		return nil, errors.Errorf("invalid chain ID %d", pb.GetHeader().GetChainId())
+	}
+	var c proto.ProtobufConverter
+	h, err := c.BlockHeader(pb)
+	if err != nil {
+		return nil, err
+	}
+	if h.Version < proto.ProtobufBlockVersion {
+		return nil, errors.Errorf("unsupported block version %d", h.Version)
+	}
+	ok, err := (&proto.Block{BlockHeader: h}).VerifySignature(scheme)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("invalid signature")
+	}
+	return &h, nil
+}
@@ -0,0 +1,122 @@
+package spv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+const scheme = proto.TestNetScheme
+
+func newBlock(t *testing.T, sk crypto.SecretKey, parent proto.BlockID, ts uint64, txs proto.Transactions) *proto.Block {
+	b, err := proto.CreateBlock(txs, ts, parent, crypto.GeneratePublicKey(sk),
+		proto.NxtConsensus{BaseTarget: 65, GenSignature: make([]byte, crypto.KeySize)},
+		proto.ProtobufBlockVersion, nil, -1, scheme, nil)
+	require.NoError(t, err)
+	require.NoError(t, b.Sign(scheme, sk))
+	return b
+}
+
+func newChain(t *testing.T, n int) ([]*proto.Block, proto.Transactions) {
+	sk, pk, err := crypto.GenerateKeyPair([]byte("spv"))
+	require.NoError(t, err)
+	addr, err := proto.NewAddressFromPublicKey(scheme, pk)
+	require.NoError(t, err)
+	txs := make(proto.Transactions, 5)
+	for i := range txs {
+		tx := proto.NewUnsignedTransferWithProofs(3, pk, proto.NewOptionalAssetWaves(), proto.NewOptionalAssetWaves(),
+			uint64(1000+i), uint64(100+i), 100000, proto.NewRecipientFromAddress(addr), nil)
+		require.NoError(t, tx.Sign(scheme, sk))
+		txs[i] = tx
+	}
+	blocks := []*proto.Block{newBlock(t, sk, proto.NewBlockIDFromDigest(crypto.Digest{}), 1000, txs)}
+	for i := 1; i < n; i++ {
+		blocks = append(blocks, newBlock(t, sk, blocks[i-1].BlockID(), uint64(1000+i), nil))
+	}
+	return blocks, txs
+}
+
+func headers(blocks []*proto.Block) []*proto.BlockHeader {
+	r := make([]*proto.BlockHeader, len(blocks))
+	for i, b := range blocks {
+		r[i] = &b.BlockHeader
+	}
+	return r
+}
+
+func TestProof(t *testing.T) {
+	blocks, txs := newChain(t, 4)
+	trusted := blocks[len(blocks)-1].BlockID()
+	for i, tx := range txs {
+		id, err := tx.GetID(scheme)
+		require.NoError(t, err)
+		txID, err := crypto.NewDigestFromBytes(id)
+		require.NoError(t, err)
+		p, err := NewProof(scheme, blocks[0], 10, txID, headers(blocks[1:]))
+		require.NoError(t, err)
+		assert.EqualValues(t, i, p.TransactionIndex)
+		assert.Len(t, p.Headers, 4)
+		verified, err := p.Verify(scheme, trusted)
+		require.NoError(t, err)
+		assert.Equal(t, id, mustID(t, verified))
+
+		_, err = p.Verify(scheme, blocks[1].BlockID())
+		assert.Error(t, err, "untrusted last block")
+		_, err = p.Verify(proto.MainNetScheme, trusted)
+		assert.Error(t, err, "another network")
+	}
+}
+
+func TestProofTampered(t *testing.T) {
+	blocks, txs := newChain(t, 3)
+	trusted := blocks[len(blocks)-1].BlockID()
+	id, err := txs[2].GetID(scheme)
+	require.NoError(t, err)
+	txID, err := crypto.NewDigestFromBytes(id)
+	require.NoError(t, err)
+	newProof := func() *Proof {
+		p, pErr := NewProof(scheme, blocks[0], 10, txID, headers(blocks[1:]))
+		require.NoError(t, pErr)
+		return p
+	}
+
+	p := newProof()
+	p.TransactionIndex = 3
+	_, err = p.Verify(scheme, trusted)
+	assert.Error(t, err)
+
+	p = newProof()
+	p.Transaction, err = proto.MarshalSignedTxDeterministic(txs[1], scheme)
+	require.NoError(t, err)
+	_, err = p.Verify(scheme, trusted)
+	assert.Error(t, err)
+
+	p = newProof()
+	p.TransactionID, err = crypto.NewDigestFromBytes(mustID(t, txs[1]))
+	require.NoError(t, err)
+	_, err = p.Verify(scheme, trusted)
+	assert.Error(t, err)
+
+	p = newProof()
+	p.Headers[1][len(p.Headers[1])-1] ^= 0xff
+	_, err = p.Verify(scheme, trusted)
+	assert.Error(t, err)
+
+	p = newProof()
+	p.Headers = append(p.Headers[:1], p.Headers[2:]...)
+	_, err = p.Verify(scheme, trusted)
+	assert.Error(t, err)
+
+	_, err = NewProof(scheme, blocks[0], 10, txID, headers(blocks[2:]))
+	assert.Error(t, err, "headers don't form a chain")
+}
+
+func mustID(t *testing.T, tx proto.Transaction) []byte {
+	id, err := tx.GetID(scheme)
+	require.NoError(t, err)
+	return id
+}
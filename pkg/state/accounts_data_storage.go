@@ -347,6 +347,96 @@ func (s *accountsDataStorage) retrieveEntry(addr proto.Address, key string) (pro
 	return entry, nil
 }
 
+// retrieveEntryAtHeight returns the value of the entry at the given height. The height must be within
+// the retained history, otherwise the result is not reliable.
+func (s *accountsDataStorage) retrieveEntryAtHeight(
+	addr proto.Address, key string, height proto.Height,
+) (proto.DataEntry, error) {
+	addrNum, err := s.addrToNum(addr)
+	if err != nil {
+		return nil, err
+	}
+	storKey := accountsDataStorKey{addrNum, key}
+	recordBytes, err := s.hs.entryDataAtHeight(storKey.bytes(), height)
+	if err != nil {
+		return nil, err
+	}
+	if recordBytes == nil { // entry was not set at the height
+		return nil, keyvalue.ErrNotFound
+	}
+	var record dataEntryRecord
+	if err := record.unmarshalBinary(recordBytes); err != nil {
+		return nil, err
+	}
+	entry, err := proto.NewDataEntryFromValueBytes(record.value)
+	if err != nil {
+		return nil, err
+	}
+	if entry.GetValueType() == proto.DataDelete {
+		return nil, errors.Wrapf(keyvalue.ErrNotFound, "entry '%s' was removed", key)
+	}
+	entry.SetKey(key)
+	return entry, nil
+}
+
+// entriesAtHeight calls fn for every data entry of every account at the given height, removed entries are skipped.
+// Height must be in the retained part of the history.
+func (s *accountsDataStorage) entriesAtHeight(
+	scheme proto.Scheme, height proto.Height, fn func(DataEntryRecord) error,
+) error {
+	addresses, err := s.addrNums()
+	if err != nil {
+		return errors.Wrap(err, "failed to load account numbers")
+	}
+	return s.hs.iterateEntriesAtHeight([]byte{accountsDataStorKeyPrefix}, height, func(key, data []byte) error {
+		var k accountsDataStorKey
+		if err := k.unmarshal(key); err != nil {
+			return err
+		}
+		var r dataEntryRecord
+		if err := r.unmarshalBinary(data); err != nil {
+			return errors.Wrapf(err, "failed to unmarshal data to %T", r)
+		}
+		entry, err := proto.NewDataEntryFromValueBytes(r.value)
+		if err != nil {
+			return err
+		}
+		if entry.GetValueType() == proto.DataDelete {
+			return nil
+		}
+		entry.SetKey(k.entryKey)
+		id, ok := addresses[k.addrNum]
+		if !ok {
+			return errors.Errorf("no address for account number %d", k.addrNum)
+		}
+		addr, err := id.ToWavesAddress(scheme)
+		if err != nil {
+			return err
+		}
+		return fn(DataEntryRecord{Address: addr, Entry: entry})
+	})
+}
+
+// addrNums returns the addresses by their numbers in accounts data storage.
+func (s *accountsDataStorage) addrNums() (map[uint64]proto.AddressID, error) {
+	iter, err := s.db.NewKeyIterator([]byte{accountStorAddrToNumKeyPrefix})
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Release()
+	r := make(map[uint64]proto.AddressID)
+	for iter.Next() {
+		key, value := iter.Key(), iter.Value()
+		if len(key) != 1+proto.AddressIDSize || len(value) != 8 {
+			return nil, errInvalidDataSize
+		}
+		var id proto.AddressID
+		copy(id[:], key[1:])
+		r[binary.LittleEndian.Uint64(value)] = id
+	}
+	return r, iter.Error()
+}
+
 func (s *accountsDataStorage) retrieveNewestIntegerEntry(addr proto.Address, key string) (*proto.IntegerDataEntry, error) {
 	id := entryId{addr.ID(), key}
 	if entry, ok := s.uncertainEntries[id]; ok {
@@ -4,6 +4,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/keyvalue"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 )
 
@@ -284,3 +287,69 @@ func TestRetrieveBinaryEntry(t *testing.T) {
 	assert.NoError(t, err, "retrieveBinaryEntry failed")
 	assert.Equal(t, entry1, entry)
 }
+
+func TestRetrieveEntryAtHeight(t *testing.T) {
+	to := createAccountsDataStorage(t, true)
+
+	addr0 := testGlobal.senderInfo.addr
+	entry0 := &proto.IntegerDataEntry{Key: "Whatever", Value: int64(100500)}
+	entry1 := &proto.StringDataEntry{Key: "Whatever", Value: "changed"}
+	to.stor.addBlock(t, blockID0)
+	to.stor.addBlockAndDo(t, blockID1, func(id proto.BlockID) {
+		err := to.accountsDataStor.appendEntry(addr0, entry0, id)
+		require.NoError(t, err)
+	})
+	to.stor.addBlockAndDo(t, blockID2, func(id proto.BlockID) {
+		err := to.accountsDataStor.appendEntry(addr0, entry1, id)
+		require.NoError(t, err)
+	})
+	to.stor.flush(t)
+	heights := make([]proto.Height, 3)
+	for i, id := range []proto.BlockID{blockID0, blockID1, blockID2} {
+		h, err := to.stor.rw.heightByBlockID(id)
+		require.NoError(t, err)
+		heights[i] = h
+	}
+
+	_, err := to.accountsDataStor.retrieveEntryAtHeight(addr0, entry0.Key, heights[0])
+	assert.ErrorIs(t, err, keyvalue.ErrNotFound)
+	entry, err := to.accountsDataStor.retrieveEntryAtHeight(addr0, entry0.Key, heights[1])
+	require.NoError(t, err)
+	assert.Equal(t, entry0, entry)
+	entry, err = to.accountsDataStor.retrieveEntryAtHeight(addr0, entry0.Key, heights[2])
+	require.NoError(t, err)
+	assert.Equal(t, entry1, entry)
+	_, err = to.accountsDataStor.retrieveEntryAtHeight(addr0, "unknown", heights[2])
+	assert.ErrorIs(t, err, keyvalue.ErrNotFound)
+}
+
+func TestEntriesAtHeight(t *testing.T) {
+	to := createAccountsDataStorage(t, true)
+
+	addr0 := testGlobal.senderInfo.addr
+	addr1 := testGlobal.recipientInfo.addr
+	entry0 := &proto.IntegerDataEntry{Key: "Whatever", Value: int64(100500)}
+	entry1 := &proto.StringDataEntry{Key: "Another", Value: "value"}
+	to.stor.addBlockAndDo(t, blockID0, func(id proto.BlockID) {
+		require.NoError(t, to.accountsDataStor.appendEntry(addr0, entry0, id))
+	})
+	to.stor.addBlockAndDo(t, blockID1, func(id proto.BlockID) {
+		require.NoError(t, to.accountsDataStor.appendEntry(addr1, entry1, id))
+		require.NoError(t, to.accountsDataStor.appendEntry(addr0, &proto.DeleteDataEntry{Key: entry0.Key}, id))
+	})
+	to.stor.flush(t)
+
+	collect := func(id proto.BlockID) map[proto.WavesAddress][]proto.DataEntry {
+		h, err := to.stor.rw.heightByBlockID(id)
+		require.NoError(t, err)
+		r := make(map[proto.WavesAddress][]proto.DataEntry)
+		err = to.accountsDataStor.entriesAtHeight(proto.MainNetScheme, h, func(rec DataEntryRecord) error {
+			r[rec.Address] = append(r[rec.Address], rec.Entry)
+			return nil
+		})
+		require.NoError(t, err)
+		return r
+	}
+	assert.Equal(t, map[proto.WavesAddress][]proto.DataEntry{addr0: {entry0}}, collect(blockID0))
+	assert.Equal(t, map[proto.WavesAddress][]proto.DataEntry{addr1: {entry1}}, collect(blockID1))
+}
@@ -146,6 +146,7 @@ type addressTransactions struct {
 	filePath            string
 	addrTransactions    *os.File
 	addrTransactionsBuf *bufio.Writer
+	backupGuard         *backupGuard // Preserves the data of the file captured for the backup on truncation.
 
 	params *addressTransactionsParams
 }
@@ -163,7 +164,7 @@ func newAddressTransactions(
 		prefix:       transactionIdsPrefix,
 	}
 	filePath := filepath.Join(filepath.Clean(params.dir), "address_transactions")
-	addrTransactionsFile, _, err := openOrCreateForAppending(filePath)
+	addrTransactionsFile, _, err := openStorageFile(filePath, stateDB.readOnly)
 	if err != nil {
 		return nil, err
 	}
@@ -174,8 +175,10 @@ func newAddressTransactions(
 			}
 		}
 	}()
-	if err := manageFile(addrTransactionsFile, db); err != nil {
-		return nil, err
+	if !stateDB.readOnly {
+		if err := manageFile(addrTransactionsFile, db); err != nil {
+			return nil, err
+		}
 	}
 	stor, err := newBatchedStorage(db, stateDB, bsParams, params.batchedStorMemLimit, params.batchedStorMaxKeys, amend)
 	if err != nil {
@@ -191,7 +194,7 @@ func newAddressTransactions(
 		params:              params,
 		amend:               amend,
 	}
-	if params.providesData {
+	if params.providesData && !stateDB.readOnly {
 		if pErr := atx.persist(); pErr != nil { // no need to close atx here because all resources will be closed above
 			return nil, errors.Wrap(pErr, "failed to persist")
 		}
@@ -380,7 +383,7 @@ func (at *addressTransactions) persist() error {
 	// Clear batchedStorage.
 	at.stor.reset()
 	// Clear address transactions file.
-	if err := at.addrTransactions.Truncate(0); err != nil {
+	if err := at.backupGuard.truncate(at.addrTransactions, 0); err != nil {
 		return err
 	}
 	if _, err := at.addrTransactions.Seek(0, 0); err != nil {
@@ -168,9 +168,20 @@ func (a *aliases) createAlias(aliasStr string, addr proto.WavesAddress, blockID
 	key := aliasKey{aliasStr}
 	keyBytes := key.bytes()
 	keyStr := string(keyBytes)
+	stolen := a.exists(aliasStr)
+	if stolen {
+		// Keep the reverse index consistent, the alias no longer belongs to the previous owner.
+		prev, err := a.newestRecordByAlias(keyBytes)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get previous owner of alias %q", aliasStr)
+		}
+		if err := a.removeAliasByAddressID(prev.info.addressID, aliasStr, blockID); err != nil {
+			return err
+		}
+	}
 	r := aliasRecord{
 		info: aliasInfo{
-			stolen:    a.exists(aliasStr),
+			stolen:    stolen,
 			addressID: addr.ID(),
 		},
 	}
@@ -209,7 +220,7 @@ func (a *aliases) addOrUpdateAddressToAliasesRecord(aliasStr string, addr proto.
 	if err != nil {
 		return err
 	}
-	if err := a.hs.addNewEntry(addressToAliasesKeySize, keyBytes, recordBytes, blockID); err != nil {
+	if err := a.hs.addNewEntry(addressToAliases, keyBytes, recordBytes, blockID); err != nil {
 		return errors.Wrapf(err, "failed to add address to aliases record with new alias %q for addr %q, blockID %q",
 			aliasStr, addr.String(), blockID.String(),
 		)
@@ -355,7 +366,11 @@ func (a *aliases) removeAliasByAddressID(id proto.AddressID, aliasStr string, bl
 	if ok := record.removeIfExists(aliasStr); !ok {
 		return errors.Errorf("alias %q is not found for the given address", aliasStr)
 	}
-	if err := a.hs.addNewEntry(addressToAliasesKeySize, keyBytes, recordBytes, blockID); err != nil {
+	recordBytes, err = record.marshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal address to aliases record")
+	}
+	if err := a.hs.addNewEntry(addressToAliases, keyBytes, recordBytes, blockID); err != nil {
 		return errors.Wrap(err, "failed to update address to aliases record")
 	}
 	return nil
@@ -83,6 +83,14 @@ func TestDisableStolenAliases(t *testing.T) {
 	assert.Equal(t, true, newestDisabled)
 	assert.Equal(t, true, to.entities.aliases.exists(aliasStr))
 
+	// stolen alias is removed from the reverse index of both owners
+	aliases, err := to.entities.aliases.aliasesByAddr(aliasAddr)
+	assert.NoError(t, err, "aliasesByAddr() failed")
+	assert.Empty(t, aliases)
+	aliases, err = to.entities.aliases.aliasesByAddr(stealAddr)
+	assert.NoError(t, err, "aliasesByAddr() failed")
+	assert.Empty(t, aliases)
+
 	// failed to get stolen alias
 	_, err = to.entities.aliases.addrByAlias(aliasStr)
 	assert.Equal(t, errAliasDisabled, err)
@@ -124,3 +132,34 @@ func TestAddressToAliasesRecord_removeIfExists(t *testing.T) {
 	ok = r.removeIfExists("keke")
 	require.False(t, ok)
 }
+
+func TestRebuildAddressToAliases(t *testing.T) {
+	to := createStorageObjects(t, true)
+
+	first, err := proto.NewAddressFromString(addr0)
+	require.NoError(t, err)
+	second, err := proto.NewAddressFromString(addr1)
+	require.NoError(t, err)
+
+	to.addBlock(t, blockID0)
+	require.NoError(t, to.entities.aliases.createAlias("alias1", first, blockID0))
+	require.NoError(t, to.entities.aliases.createAlias("alias2", first, blockID0))
+	to.addBlock(t, blockID1)
+	require.NoError(t, to.entities.aliases.createAlias("alias2", second, blockID1)) // steal alias
+	to.flush(t)
+
+	require.NoError(t, to.entities.aliases.dropAddressToAliases())
+	aliases, err := to.entities.aliases.aliasesByAddr(first)
+	require.NoError(t, err)
+	require.Empty(t, aliases)
+
+	n, err := to.entities.aliases.rebuildAddressToAliases(0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	aliases, err = to.entities.aliases.aliasesByAddr(first)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alias1"}, aliases)
+	aliases, err = to.entities.aliases.aliasesByAddr(second)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alias2"}, aliases)
+}
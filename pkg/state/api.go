@@ -1,6 +1,7 @@
 package state
 
 import (
+	"io"
 	"math/big"
 	"runtime"
 
@@ -28,6 +29,19 @@ type TransactionIterator interface {
 	Error() error
 }
 
+// BalanceRecord is a balance of an address in Waves or in an asset.
+type BalanceRecord struct {
+	Address proto.WavesAddress
+	Asset   proto.OptionalAsset
+	Balance uint64
+}
+
+// DataEntryRecord is a data entry of an account.
+type DataEntryRecord struct {
+	Address proto.WavesAddress
+	Entry   proto.DataEntry
+}
+
 // StateInfo returns information that corresponds to latest fully applied block.
 // This should be used for APIs and other modules where stable, fully verified state is needed.
 // Methods of this interface are thread-safe.
@@ -47,12 +61,28 @@ type StateInfo interface {
 	WavesBalance(account proto.Recipient) (uint64, error)
 	// FullWavesBalance returns complete Waves balance record.
 	FullWavesBalance(account proto.Recipient) (*proto.FullWavesBalance, error)
+	// WavesBalanceHistory returns regular Waves balances of account by heights they were changed at, newest first.
+	// Only the retained part of the balance history is available, usually it's the rollback window.
+	WavesBalanceHistory(account proto.Recipient) ([]proto.BalanceAtHeight, error)
 	GeneratingBalance(account proto.Recipient, height proto.Height) (uint64, error)
+	// EffectiveBalancesAtHeight returns effective and generating Waves balances of account at the given height.
+	// The whole range of blocks used to calculate generating balance must be in the retained history.
+	EffectiveBalancesAtHeight(account proto.Recipient, height proto.Height) (*proto.EffectiveBalances, error)
 	// AssetBalance retrieves balance of account in specific currency, asset is asset's ID.
 	AssetBalance(account proto.Recipient, assetID proto.AssetID) (uint64, error)
+	// BalanceAtHeight returns regular balance of account in Waves or in the asset at the given height.
+	// Height must be in the retained part of the history.
+	BalanceAtHeight(account proto.Recipient, asset proto.OptionalAsset, height proto.Height) (uint64, error)
 	// WavesAddressesNumber returns total number of Waves addresses in state.
 	// It is extremely slow, so it is recommended to only use for testing purposes.
 	WavesAddressesNumber() (uint64, error)
+	// BalancesAtHeight streams all non-zero Waves balances and then all non-zero asset balances at the given height
+	// to fn. Iteration stops on the first error returned by fn. Height must be in the retained part of the history.
+	// It iterates over the whole state, so it is very slow.
+	BalancesAtHeight(height proto.Height, fn func(BalanceRecord) error) error
+	// Richlist returns up to limit addresses with the largest balances of the asset, the largest balance goes first.
+	// Balances index of an asset is built on the first request, so it could take a while.
+	Richlist(asset proto.OptionalAsset, limit uint64) ([]BalanceRecord, error)
 
 	// Get cumulative blocks score at given height.
 	ScoreAtHeight(height proto.Height) (*big.Int, error)
@@ -82,10 +112,16 @@ type StateInfo interface {
 	// Accounts data storage.
 	RetrieveEntries(account proto.Recipient) ([]proto.DataEntry, error)
 	RetrieveEntry(account proto.Recipient, key string) (proto.DataEntry, error)
+	// RetrieveEntryAtHeight returns the value of the entry at the given height, which should be not less than
+	// the lowest height of retained history.
+	RetrieveEntryAtHeight(account proto.Recipient, key string, height proto.Height) (proto.DataEntry, error)
 	RetrieveIntegerEntry(account proto.Recipient, key string) (*proto.IntegerDataEntry, error)
 	RetrieveBooleanEntry(account proto.Recipient, key string) (*proto.BooleanDataEntry, error)
 	RetrieveStringEntry(account proto.Recipient, key string) (*proto.StringDataEntry, error)
 	RetrieveBinaryEntry(account proto.Recipient, key string) (*proto.BinaryDataEntry, error)
+	// DataEntriesAtHeight streams all data entries of all accounts at the given height to fn. Iteration stops on
+	// the first error returned by fn. Height must be in the retained part of the history. It is very slow.
+	DataEntriesAtHeight(height proto.Height, fn func(DataEntryRecord) error) error
 
 	// Transactions.
 	TransactionByID(id []byte) (proto.Transaction, error)
@@ -95,6 +131,9 @@ type StateInfo interface {
 	// given address.
 	// Iterator will move in range from most recent to oldest transactions.
 	NewAddrTransactionsIterator(addr proto.Address) (TransactionIterator, error)
+	// OrderFilled returns the amount and the matcher fee filled by the order with the given ID.
+	// Zeroes are returned for unknown orders.
+	OrderFilled(orderID []byte) (amount uint64, fee uint64, err error)
 
 	// Asset fee sponsorship.
 	AssetIsSponsored(assetID proto.AssetID) (bool, error)
@@ -102,16 +141,28 @@ type StateInfo interface {
 	AssetInfo(assetID proto.AssetID) (*proto.AssetInfo, error)
 	FullAssetInfo(assetID proto.AssetID) (*proto.FullAssetInfo, error)
 	EnrichedFullAssetInfo(assetID proto.AssetID) (*proto.EnrichedFullAssetInfo, error)
+	// SponsorshipStatus returns the fee sponsorship state of the asset and the available balance of its sponsor.
+	SponsorshipStatus(assetID proto.AssetID) (*proto.SponsorshipStatus, error)
 	NFTList(account proto.Recipient, limit uint64, afterAssetID *proto.AssetID) ([]*proto.FullAssetInfo, error)
+	// SearchAssets returns up to limit IDs of assets which names start with the query ignoring case, ordered by names.
+	// If afterAssetID is set the search continues from the asset next to it.
+	SearchAssets(query string, limit uint64, afterAssetID *proto.AssetID) ([]crypto.Digest, error)
 	// Script information.
 	ScriptBasicInfoByAccount(account proto.Recipient) (*proto.ScriptBasicInfo, error)
 	ScriptInfoByAccount(account proto.Recipient) (*proto.ScriptInfo, error)
 	ScriptInfoByAsset(assetID proto.AssetID) (*proto.ScriptInfo, error)
 	NewestScriptByAccount(account proto.Recipient) (*ast.Tree, error)
 	NewestScriptBytesByAccount(account proto.Recipient) (proto.Script, error)
+	// ScriptHistoryByAccount returns the changes of account script from the most recent to the oldest.
+	// Works only if state provides extended API.
+	ScriptHistoryByAccount(account proto.Recipient) ([]proto.ScriptChange, error)
 
 	// Leases.
 	IsActiveLeasing(leaseID crypto.Digest) (bool, error)
+	LeaseInfo(leaseID crypto.Digest) (*proto.LeaseDetails, error)
+	// LeasesAtHeight streams all leases, active and cancelled, at the given height to fn. Iteration stops on
+	// the first error returned by fn. Height must be in the retained part of the history. It is very slow.
+	LeasesAtHeight(height proto.Height, fn func(*proto.LeaseDetails) error) error
 
 	// Invoke results.
 	InvokeResultByID(invokeID crypto.Digest) (*proto.ScriptResult, error)
@@ -202,6 +253,13 @@ type StateModifier interface {
 	// PersistAddressTransactions sorts and saves transactions to storage.
 	PersistAddressTransactions() error
 
+	// Backup writes a consistent copy of the state to the empty or absent directory.
+	Backup(dir string) error
+	// BackupTar writes a consistent copy of the state to w as a tar archive.
+	BackupTar(w io.Writer) error
+	// StorageStats returns the statistics of the state database.
+	StorageStats() (*keyvalue.Stats, error)
+
 	Close() error
 }
 
@@ -244,6 +302,10 @@ type StorageParams struct {
 	OffsetLen       int
 	HeaderOffsetLen int
 	DbParams        keyvalue.KeyValParams
+	// RecentBlocksCacheSize is the number of the most recent blocks kept decoded in memory, zero disables the cache.
+	RecentBlocksCacheSize int
+	// RecentBlocksCacheBytes limits the estimated memory used by the recent blocks, zero means no limit.
+	RecentBlocksCacheBytes int
 }
 
 func DefaultStorageParams() StorageParams {
@@ -260,9 +322,11 @@ func DefaultStorageParams() StorageParams {
 		OpenFilesCacheCapacity: DefaultOpenFilesCacheCapacity,
 	}
 	return StorageParams{
-		OffsetLen:       DefaultOffsetLen,
-		HeaderOffsetLen: DefaultHeaderOffsetLen,
-		DbParams:        dbParams,
+		OffsetLen:              DefaultOffsetLen,
+		HeaderOffsetLen:        DefaultHeaderOffsetLen,
+		DbParams:               dbParams,
+		RecentBlocksCacheSize:  DefaultRecentBlocksCacheSize,
+		RecentBlocksCacheBytes: DefaultRecentBlocksCacheBytes,
 	}
 }
 
@@ -282,6 +346,7 @@ type ValidationParams struct {
 type StateParams struct {
 	StorageParams
 	ValidationParams
+	MigrationParams
 	// When StoreExtendedApiData is true, state builds additional data required for API.
 	StoreExtendedApiData bool
 	// ProvideExtendedApi specifies whether state must provide data for extended API.
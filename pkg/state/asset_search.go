@@ -0,0 +1,189 @@
+package state
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
+
+	"github.com/wavesplatform/gowaves/pkg/keyvalue"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+type assetNameEntry struct {
+	name    string // lower case name of the asset
+	assetID proto.AssetID
+}
+
+func compareAssetNameEntries(a, b assetNameEntry) int {
+	if c := strings.Compare(a.name, b.name); c != 0 {
+		return c
+	}
+	return bytes.Compare(a.assetID[:], b.assetID[:])
+}
+
+// assetNames is an in-memory case-insensitive index of names of the stored assets. The index is built by the full
+// scan of assets on the first request and then kept up to date with asset changes flushed to disk. Names of the
+// assets changed by the blocks removed on rollback are read again, assets issued by those blocks are dropped.
+type assetNames struct {
+	mu     sync.Mutex
+	names  map[proto.AssetID]string // nil until the index is built
+	sorted []assetNameEntry
+	dirty  bool
+	builds *rate.Limiter
+
+	// Assets changed since the last flush and the journal of the flushed changes, accessed only by the state
+	// modifying goroutine.
+	changed map[proto.AssetID]struct{}
+	journal changesJournal[proto.AssetID]
+}
+
+func newAssetNames() *assetNames {
+	return &assetNames{changed: make(map[proto.AssetID]struct{}), builds: newIndexBuildLimiter()}
+}
+
+func (n *assetNames) assetChanged(assetID proto.AssetID) {
+	n.changed[assetID] = struct{}{}
+}
+
+// reset discards changes that were not flushed.
+func (n *assetNames) reset() {
+	clear(n.changed)
+}
+
+func (n *assetNames) set(assetID proto.AssetID, name string) {
+	n.names[assetID] = strings.ToLower(name)
+	n.dirty = true
+}
+
+func (n *assetNames) remove(assetID proto.AssetID) {
+	delete(n.names, assetID)
+	n.dirty = true
+}
+
+// search returns up to limit IDs of assets which names start with the query ignoring case, ordered by names.
+// If after is not nil, the search continues from the asset next to it, the asset must be in the index.
+func (n *assetNames) search(query string, limit uint64, after *proto.AssetID) ([]proto.AssetID, error) {
+	if n.dirty {
+		n.sorted = n.sorted[:0]
+		for id, name := range n.names {
+			n.sorted = append(n.sorted, assetNameEntry{name: name, assetID: id})
+		}
+		slices.SortFunc(n.sorted, compareAssetNameEntries)
+		n.dirty = false
+	}
+	query = strings.ToLower(query)
+	start, _ := slices.BinarySearchFunc(n.sorted, assetNameEntry{name: query}, compareAssetNameEntries)
+	if after != nil {
+		name, ok := n.names[*after]
+		if !ok {
+			return nil, errors.Errorf("unknown asset %q to search after", after.String())
+		}
+		pos, found := slices.BinarySearchFunc(n.sorted, assetNameEntry{name: name, assetID: *after},
+			compareAssetNameEntries)
+		if found {
+			pos++
+		}
+		start = max(start, pos)
+	}
+	res := make([]proto.AssetID, 0, min(limit, uint64(len(n.sorted)-start)))
+	for _, e := range n.sorted[start:] {
+		if uint64(len(res)) >= limit || !strings.HasPrefix(e.name, query) {
+			break
+		}
+		res = append(res, e.assetID)
+	}
+	return res, nil
+}
+
+// updateNamesIndex applies asset changes flushed with the state moved from height from to height to
+// to the index if it's already built.
+func (a *assets) updateNamesIndex(from, to proto.Height) error {
+	n := a.names
+	defer n.reset()
+	n.journal.record(from, to, n.changed)
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return a.updateNames(n.changed)
+}
+
+// rollbackNamesIndex reads again the names of the assets changed by the blocks above the height.
+func (a *assets) rollbackNamesIndex(height proto.Height) error {
+	n := a.names
+	n.reset()
+	changed, ok := n.journal.rollback(height)
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if !ok {
+		n.names = nil
+		n.sorted = nil
+		return nil
+	}
+	return a.updateNames(changed)
+}
+
+func (a *assets) updateNames(changed map[proto.AssetID]struct{}) error {
+	n := a.names
+	if n.names == nil {
+		return nil
+	}
+	for assetID := range changed {
+		histKey := assetHistKey{assetID: assetID}
+		data, err := a.hs.topEntryData(histKey.bytes())
+		if isNotFoundInHistoryOrDBErr(err) {
+			n.remove(assetID) // The asset was issued by a removed block.
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to get info of asset %q", assetID.String())
+		}
+		var r assetHistoryRecord
+		if uErr := r.unmarshalBinary(data); uErr != nil {
+			return errors.Wrapf(uErr, "failed to unmarshal info of asset %q", assetID.String())
+		}
+		n.set(assetID, r.name)
+	}
+	return nil
+}
+
+// searchByName returns up to limit IDs of assets which names start with the query ignoring case.
+func (a *assets) searchByName(query string, limit uint64, after *proto.AssetID) ([]proto.AssetID, error) {
+	n := a.names
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.names == nil {
+		if !n.builds.Allow() {
+			return nil, ErrIndexBuildLimited
+		}
+		names := make(map[proto.AssetID]string)
+		if err := a.iterateNames(func(id proto.AssetID, name string) { names[id] = strings.ToLower(name) }); err != nil {
+			return nil, errors.Wrap(err, "failed to build asset names index")
+		}
+		n.names = names
+		n.dirty = true
+	}
+	return n.search(query, limit, after)
+}
+
+func (a *assets) iterateNames(fn func(id proto.AssetID, name string)) error {
+	iter, err := a.hs.newTopEntryIterator(asset)
+	if err != nil {
+		return err
+	}
+	defer iter.Release()
+	for iter.Next() {
+		var k assetHistKey
+		if kErr := k.unmarshal(keyvalue.SafeKey(iter)); kErr != nil {
+			return kErr
+		}
+		var r assetHistoryRecord
+		if rErr := r.unmarshalBinary(keyvalue.SafeValue(iter)); rErr != nil {
+			return rErr
+		}
+		fn(k.assetID, r.name)
+	}
+	return iter.Error()
+}
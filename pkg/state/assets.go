@@ -137,6 +137,8 @@ type assets struct {
 	freshConstInfo map[proto.AssetID]assetConstInfo
 
 	uncertainAssetInfo map[proto.AssetID]wrappedUncertainInfo
+
+	names *assetNames
 }
 
 func newAssets(db keyvalue.KeyValue, dbBatch keyvalue.Batch, hs *historyStorage) *assets {
@@ -146,6 +148,7 @@ func newAssets(db keyvalue.KeyValue, dbBatch keyvalue.Batch, hs *historyStorage)
 		hs:                 hs,
 		freshConstInfo:     make(map[proto.AssetID]assetConstInfo),
 		uncertainAssetInfo: make(map[proto.AssetID]wrappedUncertainInfo),
+		names:              newAssetNames(),
 	}
 }
 
@@ -156,7 +159,11 @@ func (a *assets) addNewRecord(assetID proto.AssetID, record *assetHistoryRecord,
 	}
 	// Add new record to history.
 	histKey := assetHistKey{assetID: assetID}
-	return a.hs.addNewEntry(asset, histKey.bytes(), recordBytes, blockID)
+	if err := a.hs.addNewEntry(asset, histKey.bytes(), recordBytes, blockID); err != nil {
+		return err
+	}
+	a.names.assetChanged(assetID)
+	return nil
 }
 
 func (a *assets) storeAssetInfo(assetID proto.AssetID, asset *assetInfo, blockID proto.BlockID) error {
@@ -406,4 +413,5 @@ func (a *assets) dropUncertain() {
 
 func (a *assets) reset() {
 	a.freshConstInfo = make(map[proto.AssetID]assetConstInfo)
+	a.names.reset()
 }
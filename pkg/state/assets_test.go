@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/wavesplatform/gowaves/pkg/crypto"
 	"github.com/wavesplatform/gowaves/pkg/proto"
@@ -228,3 +229,73 @@ func TestAssetConstInfoRoundTrip(t *testing.T) {
 
 	assert.Equal(t, expectedInfo, info)
 }
+
+func TestAssetNamesSearch(t *testing.T) {
+	n := newAssetNames()
+	n.names = make(map[proto.AssetID]string)
+	id := func(b byte) proto.AssetID { return proto.AssetID{b} }
+	n.set(id(1), "USDN")
+	n.set(id(2), "usd-coin")
+	n.set(id(3), "Waves Token")
+	n.set(id(4), "USDN")
+	n.set(id(5), "BTC")
+	search := func(query string, limit uint64, after *proto.AssetID) []proto.AssetID {
+		res, err := n.search(query, limit, after)
+		require.NoError(t, err)
+		return res
+	}
+
+	assert.Equal(t, []proto.AssetID{id(2), id(1), id(4)}, search("usd", 10, nil))
+	assert.Equal(t, []proto.AssetID{id(2), id(1)}, search("USD", 2, nil))
+	after := id(1)
+	assert.Equal(t, []proto.AssetID{id(4)}, search("usd", 2, &after))
+	assert.Equal(t, []proto.AssetID{id(3)}, search("wav", 10, nil))
+	assert.Empty(t, search("eth", 10, nil))
+
+	n.set(id(2), "Circle")
+	assert.Equal(t, []proto.AssetID{id(1), id(4)}, search("usd", 10, nil))
+	assert.Equal(t, []proto.AssetID{id(5), id(2)}, search("", 2, nil))
+
+	n.remove(id(1))
+	assert.Equal(t, []proto.AssetID{id(4)}, search("usd", 10, nil))
+	_, err := n.search("usd", 10, &after)
+	assert.Error(t, err, "unknown asset to search after")
+}
+
+func TestAssetNamesRollback(t *testing.T) {
+	to := createAssets(t)
+	height := func() proto.Height {
+		h, err := to.stor.stateDB.getHeight()
+		require.NoError(t, err)
+		return h
+	}
+	issue := func(assetID crypto.Digest, name string, blockID proto.BlockID) {
+		from := height()
+		to.stor.addBlock(t, blockID)
+		info := defaultAssetInfo(proto.DigestTail(assetID), false)
+		info.name = name
+		require.NoError(t, to.assets.issueAsset(proto.AssetIDFromDigest(assetID), info, blockID))
+		to.stor.flush(t)
+		require.NoError(t, to.assets.updateNamesIndex(from, height()))
+	}
+	search := func() []proto.AssetID {
+		res, err := to.assets.searchByName("usd", 10, nil)
+		require.NoError(t, err)
+		return res
+	}
+	asset1 := proto.AssetIDFromDigest(testGlobal.asset1.assetID)
+	asset2 := proto.AssetIDFromDigest(testGlobal.asset2.assetID)
+
+	issue(testGlobal.asset1.assetID, "USDN", blockID0)
+	assert.Equal(t, []proto.AssetID{asset1}, search())
+	issue(testGlobal.asset2.assetID, "USD-coin", blockID1)
+	assert.Equal(t, []proto.AssetID{asset2, asset1}, search())
+
+	// Asset issued by the removed block is dropped without the full rebuild.
+	to.stor.fullRollbackBlockClearCache(t, blockID0)
+	require.NoError(t, to.assets.rollbackNamesIndex(height()))
+	require.NotNil(t, to.assets.names.names)
+	assert.Equal(t, []proto.AssetID{asset1}, search())
+	_, err := to.assets.searchByName("usd", 10, &asset2)
+	assert.Error(t, err, "unknown asset to search after")
+}
@@ -0,0 +1,422 @@
+package state
+
+import (
+	"archive/tar"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/wavesplatform/gowaves/pkg/keyvalue"
+	"github.com/wavesplatform/gowaves/pkg/state/stateerr"
+)
+
+const (
+	backupBatchSize = 10000
+	backupChunkSize = 1 << 20
+)
+
+type keyIterable interface {
+	NewKeyIterator(prefix []byte) (keyvalue.Iterator, error)
+}
+
+type snapshotter interface {
+	NewSnapshot() (*keyvalue.Snapshot, error)
+}
+
+// backupSource is implemented by the state which captures the consistent point of backup separately from
+// copying of the data, so the state modifications have to be blocked only for the capturing.
+type backupSource interface {
+	captureBackup() (*stateBackup, error)
+}
+
+// stateBackup is a captured consistent point of the state. Block storage files are append only between rollbacks,
+// so it's enough to copy them up to the captured sizes, trailing data is cut on opening the state anyway.
+// The data cut by rollbacks while the backup is written is preserved by backupGuard.
+type stateBackup struct {
+	snapshot  *keyvalue.Snapshot
+	dbParams  keyvalue.KeyValParams
+	files     []*capturedFile
+	releaseFn func()
+}
+
+// captureBackup captures the consistent point of the state for the backup, it's fast and doesn't copy any data.
+func (s *stateManager) captureBackup() (_ *stateBackup, retErr error) {
+	db, ok := s.stateDB.db.(snapshotter)
+	if !ok {
+		return nil, errors.New("state database doesn't support snapshots")
+	}
+	if !s.backupMu.TryLock() {
+		return nil, errors.New("another backup is in progress")
+	}
+	defer func() {
+		if retErr != nil {
+			s.backupMu.Unlock()
+		}
+	}()
+	storageFiles := []*os.File{s.rw.blockchain, s.rw.headers, s.rw.blockHeight2ID, s.atx.addrTransactions}
+	files := make([]*capturedFile, 0, len(storageFiles))
+	for _, f := range storageFiles {
+		info, err := f.Stat()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get size of '%s'", f.Name())
+		}
+		files = append(files, &capturedFile{
+			name:      filepath.Base(f.Name()),
+			src:       f,
+			size:      info.Size(),
+			preserved: info.Size(),
+		})
+	}
+	snapshot, err := db.NewSnapshot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create database snapshot")
+	}
+	s.backupGuard.add(files)
+	return &stateBackup{
+		snapshot: snapshot,
+		dbParams: s.dbParams,
+		files:    files,
+		releaseFn: func() {
+			s.backupGuard.remove(files)
+			s.backupMu.Unlock()
+		},
+	}, nil
+}
+
+// Backup writes a consistent copy of the state to the empty or absent directory dir.
+// Method is not thread-safe, see ThreadSafeWriteWrapper.Backup.
+func (s *stateManager) Backup(dir string) error {
+	return writeBackup(s, func(b *stateBackup) error { return b.writeDir(dir) })
+}
+
+// BackupTar writes a consistent copy of the state to w as a tar archive.
+// Method is not thread-safe, see ThreadSafeWriteWrapper.BackupTar.
+func (s *stateManager) BackupTar(w io.Writer) error {
+	return writeBackup(s, func(b *stateBackup) error { return b.writeTar(w) })
+}
+
+func writeBackup(src backupSource, write func(b *stateBackup) error) error {
+	b, err := src.captureBackup()
+	if err != nil {
+		return wrapErr(stateerr.Other, err)
+	}
+	defer b.release()
+	if wErr := write(b); wErr != nil {
+		return wrapErr(stateerr.Other, wErr)
+	}
+	return nil
+}
+
+func (b *stateBackup) writeDir(dir string) error {
+	if err := prepareBackupDir(dir); err != nil {
+		return err
+	}
+	blocksDir := filepath.Join(dir, blocksStorDir)
+	if err := os.Mkdir(blocksDir, 0750); err != nil {
+		return errors.Wrap(err, "failed to create blocks directory")
+	}
+	for _, f := range b.files {
+		if err := f.copyToFile(filepath.Join(blocksDir, f.name)); err != nil {
+			return errors.Wrapf(err, "failed to copy '%s'", f.name)
+		}
+	}
+	if err := copyKeyValue(b.snapshot, filepath.Join(dir, keyvalueDir), b.dbParams); err != nil {
+		return errors.Wrap(err, "failed to copy database")
+	}
+	zap.S().Infof("State backup is written to '%s'", dir)
+	return nil
+}
+
+// writeTar streams block storage files from the capture. LevelDB can't export its files from a snapshot, so only
+// the database is copied to a temporary directory before it's added to the archive.
+func (b *stateBackup) writeTar(w io.Writer) error {
+	tw := tar.NewWriter(w)
+	now := time.Now()
+	dirHdr := &tar.Header{Typeflag: tar.TypeDir, Name: blocksStorDir + "/", Mode: 0750, ModTime: now}
+	if err := tw.WriteHeader(dirHdr); err != nil {
+		return err
+	}
+	for _, f := range b.files {
+		hdr := &tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     path.Join(blocksStorDir, f.name),
+			Size:     f.size,
+			Mode:     0600,
+			ModTime:  now,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if err := f.copyTo(tw); err != nil {
+			return errors.Wrapf(err, "failed to copy '%s'", f.name)
+		}
+	}
+	tmp, err := os.MkdirTemp("", "gowaves-backup-")
+	if err != nil {
+		return errors.Wrap(err, "failed to create temporary directory")
+	}
+	defer func() {
+		if rmErr := os.RemoveAll(tmp); rmErr != nil {
+			zap.S().Errorf("Failed to remove temporary backup directory '%s': %v", tmp, rmErr)
+		}
+	}()
+	dbDir := filepath.Join(tmp, keyvalueDir)
+	if cErr := copyKeyValue(b.snapshot, dbDir, b.dbParams); cErr != nil {
+		return errors.Wrap(cErr, "failed to copy database")
+	}
+	if aErr := addDirToTar(tw, dbDir, keyvalueDir); aErr != nil {
+		return errors.Wrap(aErr, "failed to add database to archive")
+	}
+	return tw.Close()
+}
+
+func (b *stateBackup) release() {
+	b.snapshot.Release()
+	b.releaseFn()
+}
+
+// capturedFile is a block storage file captured for the backup. Data of the file below the preserved offset is
+// untouched since the capture, the rest of the captured data is kept in the tail.
+type capturedFile struct {
+	name string
+	src  *os.File
+	size int64 // Captured size of the file.
+
+	mu        sync.Mutex
+	preserved int64
+	tail      *os.File // Sparse temporary file which holds the preserved data at the original offsets.
+	released  bool
+}
+
+// preserve saves the captured data which is going to be cut by truncation of the file to the size.
+func (f *capturedFile) preserve(size int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.released || size >= f.preserved {
+		return nil
+	}
+	if f.tail == nil {
+		tail, err := os.CreateTemp("", "gowaves-backup-"+f.name+"-")
+		if err != nil {
+			return err
+		}
+		f.tail = tail
+	}
+	cut := io.NewSectionReader(f.src, size, f.preserved-size)
+	if _, err := io.Copy(io.NewOffsetWriter(f.tail, size), cut); err != nil {
+		return err
+	}
+	f.preserved = size
+	return nil
+}
+
+// readAt reads captured data of the file, len(p) must not exceed the captured size after the offset.
+func (f *capturedFile) readAt(p []byte, off int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	live := min(int64(len(p)), max(f.preserved-off, 0))
+	if live > 0 {
+		if _, err := f.src.ReadAt(p[:live], off); err != nil {
+			return err
+		}
+	}
+	if live < int64(len(p)) {
+		if _, err := f.tail.ReadAt(p[live:], off+live); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyTo writes captured data of the file to w.
+func (f *capturedFile) copyTo(w io.Writer) error {
+	buf := make([]byte, backupChunkSize)
+	for off := int64(0); off < f.size; {
+		chunk := buf[:min(int64(len(buf)), f.size-off)]
+		if err := f.readAt(chunk, off); err != nil {
+			return err
+		}
+		if _, err := w.Write(chunk); err != nil {
+			return err
+		}
+		off += int64(len(chunk))
+	}
+	return nil
+}
+
+func (f *capturedFile) copyToFile(dst string) (err error) {
+	out, err := os.OpenFile(filepath.Clean(dst), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if clErr := out.Close(); clErr != nil && err == nil {
+			err = clErr
+		}
+	}()
+	if err = f.copyTo(out); err != nil {
+		return err
+	}
+	return out.Sync()
+}
+
+func (f *capturedFile) release() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.released = true
+	if f.tail == nil {
+		return
+	}
+	name := f.tail.Name()
+	if err := f.tail.Close(); err != nil {
+		zap.S().Errorf("Failed to close temporary backup file '%s': %v", name, err)
+	}
+	if err := os.Remove(name); err != nil {
+		zap.S().Errorf("Failed to remove temporary backup file '%s': %v", name, err)
+	}
+	f.tail = nil
+}
+
+// backupGuard keeps track of the files captured for the backup, block storage truncates files through it, so the
+// captured data is preserved until the backup is written. Zero guard is ready to use.
+type backupGuard struct {
+	mu    sync.Mutex
+	files map[*os.File]*capturedFile
+}
+
+func (g *backupGuard) add(files []*capturedFile) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.files == nil {
+		g.files = make(map[*os.File]*capturedFile, len(files))
+	}
+	for _, f := range files {
+		g.files[f.src] = f
+	}
+}
+
+func (g *backupGuard) remove(files []*capturedFile) {
+	g.mu.Lock()
+	for _, f := range files {
+		delete(g.files, f.src)
+	}
+	g.mu.Unlock()
+	for _, f := range files {
+		f.release()
+	}
+}
+
+// truncate changes the size of the file preserving the data captured for the backup. Nil guard just truncates.
+func (g *backupGuard) truncate(file *os.File, size int64) error {
+	if g != nil {
+		g.mu.Lock()
+		f, ok := g.files[file]
+		g.mu.Unlock()
+		if ok {
+			if err := f.preserve(size); err != nil {
+				return errors.Wrapf(err, "failed to preserve backup data of '%s'", file.Name())
+			}
+		}
+	}
+	return file.Truncate(size)
+}
+
+func prepareBackupDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return errors.Wrap(os.MkdirAll(dir, 0750), "failed to create backup directory")
+	case err != nil:
+		return errors.Wrap(err, "failed to read backup directory")
+	case len(entries) > 0:
+		return errors.Errorf("backup directory '%s' is not empty", dir)
+	default:
+		return nil
+	}
+}
+
+// addDirToTar adds the content of the directory dir to the archive under the name prefix.
+func addDirToTar(tw *tar.Writer, dir, prefix string) error {
+	return filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = path.Join(prefix, filepath.ToSlash(rel))
+		if d.IsDir() {
+			hdr.Name += "/"
+		}
+		if wErr := tw.WriteHeader(hdr); wErr != nil {
+			return wErr
+		}
+		if d.IsDir() {
+			return nil
+		}
+		f, err := os.Open(filepath.Clean(p))
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+}
+
+// copyKeyValue copies all records of the source to a new database in the empty directory dir.
+func copyKeyValue(src keyIterable, dir string, params keyvalue.KeyValParams) (err error) {
+	if entries, rErr := os.ReadDir(dir); rErr == nil && len(entries) > 0 {
+		return errors.Errorf("directory '%s' is not empty", dir)
+	}
+	params.DisableBloomFilter = true
+	params.BloomFilterStore = keyvalue.NoOpStore{}
+	params.ReadOnly = false
+	dst, err := keyvalue.NewKeyVal(dir, params)
+	if err != nil {
+		return errors.Wrap(err, "failed to create database")
+	}
+	defer func() {
+		if clErr := dst.Close(); clErr != nil && err == nil {
+			err = errors.Wrap(clErr, "failed to close database")
+		}
+	}()
+	iter, err := src.NewKeyIterator(nil)
+	if err != nil {
+		return err
+	}
+	defer iter.Release()
+	batch, err := dst.NewBatch()
+	if err != nil {
+		return err
+	}
+	n := 0
+	for iter.Next() {
+		batch.Put(keyvalue.SafeKey(iter), keyvalue.SafeValue(iter))
+		if n++; n%backupBatchSize == 0 {
+			if fErr := dst.Flush(batch); fErr != nil {
+				return fErr
+			}
+		}
+	}
+	if iErr := iter.Error(); iErr != nil {
+		return iErr
+	}
+	return dst.Flush(batch)
+}
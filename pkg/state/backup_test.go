@@ -0,0 +1,130 @@
+package state
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/importer"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+)
+
+func importTestBlocks(t *testing.T, st State, bs *settings.BlockchainSettings) proto.Height {
+	blocksPath, err := blocksPath()
+	require.NoError(t, err)
+	err = importer.ApplyFromFile(
+		context.Background(),
+		importer.ImportParams{Schema: bs.AddressSchemeCharacter, BlockchainPath: blocksPath, LightNodeMode: false},
+		st, blocksToImport, 1,
+	)
+	require.NoError(t, err)
+	height, err := st.Height()
+	require.NoError(t, err)
+	return height
+}
+
+func checkRestoredState(t *testing.T, dir string, params StateParams, bs *settings.BlockchainSettings,
+	height proto.Height, topBlock *proto.Block) {
+	restored, err := NewState(dir, true, params, bs, false)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, restored.Close()) }()
+	restoredHeight, err := restored.Height()
+	require.NoError(t, err)
+	assert.Equal(t, height, restoredHeight)
+	restoredBlock, err := restored.BlockByHeight(height)
+	require.NoError(t, err)
+	assert.Equal(t, topBlock.BlockID(), restoredBlock.BlockID())
+}
+
+func TestBackup(t *testing.T) {
+	bs := settings.MustMainNetSettings()
+	params := DefaultTestingStateParams()
+	st := newTestStateManager(t, true, params, bs)
+	height := importTestBlocks(t, st, bs)
+	topBlock, err := st.BlockByHeight(height)
+	require.NoError(t, err)
+
+	b, err := st.captureBackup()
+	require.NoError(t, err)
+	_, err = st.captureBackup()
+	assert.Error(t, err, "another backup is in progress")
+	// Rollback doesn't wait for the backup, blocks removed after the capture are still included.
+	require.NoError(t, st.RollbackToHeight(height-10))
+	dir := filepath.Join(t.TempDir(), "backup")
+	require.NoError(t, b.writeDir(dir))
+	b.release()
+	assert.Error(t, st.Backup(dir), "backup directory is not empty")
+
+	checkRestoredState(t, dir, params, bs, height, topBlock)
+}
+
+func TestBackupTar(t *testing.T) {
+	bs := settings.MustMainNetSettings()
+	params := DefaultTestingStateParams()
+	st := newTestState(t, true, params, bs)
+	height := importTestBlocks(t, st, bs)
+	topBlock, err := st.BlockByHeight(height)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, st.BackupTar(&buf))
+
+	dir := t.TempDir()
+	tr := tar.NewReader(&buf)
+	for {
+		hdr, nErr := tr.Next()
+		if errors.Is(nErr, io.EOF) {
+			break
+		}
+		require.NoError(t, nErr)
+		p := filepath.Join(dir, filepath.FromSlash(hdr.Name))
+		if hdr.Typeflag == tar.TypeDir {
+			require.NoError(t, os.MkdirAll(p, 0750))
+			continue
+		}
+		data, rErr := io.ReadAll(tr)
+		require.NoError(t, rErr)
+		require.NoError(t, os.WriteFile(p, data, 0600))
+	}
+	checkRestoredState(t, dir, params, bs, height, topBlock)
+}
+
+func TestBackupGuard(t *testing.T) {
+	src, err := os.Create(filepath.Join(t.TempDir(), "src"))
+	require.NoError(t, err)
+	defer func() { require.NoError(t, src.Close()) }()
+	_, err = src.WriteString("0123456789")
+	require.NoError(t, err)
+
+	var g backupGuard
+	f := &capturedFile{name: "src", src: src, size: 10, preserved: 10}
+	g.add([]*capturedFile{f})
+	require.NoError(t, g.truncate(src, 4))
+	_, err = src.WriteAt([]byte("abcdef"), 4)
+	require.NoError(t, err)
+	require.NoError(t, g.truncate(src, 2))
+	_, err = src.WriteAt([]byte("xy"), 2)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, f.copyTo(&buf))
+	assert.Equal(t, "0123456789", buf.String())
+
+	tail := f.tail.Name()
+	g.remove([]*capturedFile{f})
+	_, err = os.Stat(tail)
+	assert.ErrorIs(t, err, os.ErrNotExist, "temporary file must be removed")
+	require.NoError(t, g.truncate(src, 0))
+	info, err := src.Stat()
+	require.NoError(t, err)
+	assert.Zero(t, info.Size())
+}
@@ -240,6 +240,8 @@ type balances struct {
 
 	calculateHashes bool
 	sets            *settings.BlockchainSettings
+
+	richlist *richlist
 }
 
 func newBalances(
@@ -266,6 +268,7 @@ func newBalances(
 		assetsHashes:      make(map[proto.BlockID]crypto.Digest),
 		leaseHashesState:  make(map[proto.BlockID]*stateForHashes),
 		leaseHashes:       make(map[proto.BlockID]crypto.Digest),
+		richlist:          newRichlist(),
 	}, nil
 }
 
@@ -861,6 +864,123 @@ func (s *balances) wavesBalance(addr proto.AddressID) (balanceProfile, error) {
 	return r.balanceProfile, nil
 }
 
+// wavesBalanceHistory returns stored regular Waves balances of the address by heights they were changed at,
+// the newest changes go first. Only the retained part of the history is available, usually it's the rollback window.
+// IMPORTANT NOTE: this method returns saved on disk data.
+func (s *balances) wavesBalanceHistory(addr proto.AddressID) ([]proto.BalanceAtHeight, error) {
+	key := wavesBalanceKey{address: addr}
+	history, err := s.hs.getHistory(key.bytes(), false)
+	if errors.Is(err, keyvalue.ErrNotFound) || errors.Is(err, errEmptyHist) {
+		return nil, nil // Unknown address, it has no history
+	} else if err != nil {
+		return nil, err
+	}
+	res := make([]proto.BalanceAtHeight, 0, len(history.entries))
+	for i := len(history.entries) - 1; i >= 0; i-- {
+		entry := history.entries[i]
+		var record wavesBalanceRecord
+		if uErr := record.unmarshalBinary(entry.data); uErr != nil {
+			return nil, errors.Wrapf(uErr, "failed to unmarshal data to %T", record)
+		}
+		blockID, bErr := s.hs.stateDB.blockNumToId(entry.blockNum)
+		if bErr != nil {
+			return nil, errors.Wrapf(bErr, "failed to get block ID by number %d", entry.blockNum)
+		}
+		height, hErr := s.hs.stateDB.rw.heightByBlockID(blockID)
+		if hErr != nil {
+			return nil, errors.Wrapf(hErr, "failed to get height of block '%s'", blockID.String())
+		}
+		res = append(res, proto.BalanceAtHeight{Height: height, Balance: record.balance})
+	}
+	return res, nil
+}
+
+// balanceAtHeight returns the regular balance of the address in Waves or in the asset at the given height.
+// Height must be in the retained part of the history.
+// IMPORTANT NOTE: this method returns saved on disk data.
+func (s *balances) balanceAtHeight(
+	addr proto.AddressID, asset proto.OptionalAsset, height proto.Height,
+) (uint64, error) {
+	var key []byte
+	if asset.Present {
+		key = (&assetBalanceKey{address: addr, asset: proto.AssetIDFromDigest(asset.ID)}).bytes()
+	} else {
+		key = (&wavesBalanceKey{address: addr}).bytes()
+	}
+	data, err := s.hs.entryDataAtHeight(key, height)
+	if errors.Is(err, keyvalue.ErrNotFound) || errors.Is(err, errEmptyHist) {
+		return 0, nil // Unknown address, it has no balance
+	} else if err != nil {
+		return 0, err
+	}
+	if len(data) == 0 {
+		return 0, nil // No changes of the balance before the height
+	}
+	if asset.Present {
+		return s.assetBalanceFromRecordBytes(data)
+	}
+	var record wavesBalanceRecord
+	if uErr := record.unmarshalBinary(data); uErr != nil {
+		return 0, errors.Wrapf(uErr, "failed to unmarshal data to %T", record)
+	}
+	return record.balance, nil
+}
+
+// balancesAtHeight calls fn for every non-zero Waves balance and then for every non-zero asset balance
+// at the given height. Height must be in the retained part of the history.
+// IMPORTANT NOTE: this method iterates over saved on disk data.
+func (s *balances) balancesAtHeight(height proto.Height, fn func(BalanceRecord) error) error {
+	scheme := s.sets.AddressSchemeCharacter
+	err := s.hs.iterateEntriesAtHeight([]byte{wavesBalanceKeyPrefix}, height, func(key, data []byte) error {
+		var k wavesBalanceKey
+		if err := k.unmarshal(key); err != nil {
+			return err
+		}
+		var r wavesBalanceRecord
+		if err := r.unmarshalBinary(data); err != nil {
+			return errors.Wrapf(err, "failed to unmarshal data to %T", r)
+		}
+		if r.balance == 0 {
+			return nil
+		}
+		addr, err := k.address.ToWavesAddress(scheme)
+		if err != nil {
+			return err
+		}
+		return fn(BalanceRecord{Address: addr, Asset: proto.NewOptionalAssetWaves(), Balance: r.balance})
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to iterate Waves balances")
+	}
+	err = s.hs.iterateEntriesAtHeight([]byte{assetBalanceKeyPrefix}, height, func(key, data []byte) error {
+		var k assetBalanceKey
+		if err := k.unmarshal(key); err != nil {
+			return err
+		}
+		var r assetBalanceRecord
+		if err := r.unmarshalBinary(data); err != nil {
+			return errors.Wrapf(err, "failed to unmarshal data to %T", r)
+		}
+		if r.balance == 0 {
+			return nil
+		}
+		addr, err := k.address.ToWavesAddress(scheme)
+		if err != nil {
+			return err
+		}
+		ai, err := s.assets.assetInfo(k.asset)
+		if err != nil {
+			return errors.Wrap(err, "failed to get asset info")
+		}
+		asset := proto.NewOptionalAssetFromDigest(proto.ReconstructDigest(k.asset, ai.Tail))
+		return fn(BalanceRecord{Address: addr, Asset: *asset, Balance: r.balance})
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to iterate asset balances")
+	}
+	return nil
+}
+
 func (s *balances) calculateStateHashesAssetBalance(addr proto.AddressID, assetID proto.AssetID,
 	balance uint64, blockID proto.BlockID, keyStr string) error {
 	info, err := s.assets.newestConstInfo(assetID)
@@ -899,7 +1019,11 @@ func (s *balances) setAssetBalance(addr proto.AddressID, assetID proto.AssetID,
 			return shErr
 		}
 	}
-	return s.hs.addNewEntry(assetBalance, keyBytes, recordBytes, blockID)
+	if err := s.hs.addNewEntry(assetBalance, keyBytes, recordBytes, blockID); err != nil {
+		return err
+	}
+	s.richlist.assetChanged(key)
+	return nil
 }
 
 func (s *balances) calculateStateHashesWavesBalance(addr proto.AddressID, balance wavesValue,
@@ -947,7 +1071,11 @@ func (s *balances) setWavesBalance(addr proto.AddressID, balance wavesValue, blo
 			return shErr
 		}
 	}
-	return s.hs.addNewEntry(wavesBalance, keyBytes, recordBytes, blockID)
+	if err := s.hs.addNewEntry(wavesBalance, keyBytes, recordBytes, blockID); err != nil {
+		return err
+	}
+	s.richlist.wavesChanged(addr)
+	return nil
 }
 
 func (s *balances) prepareHashes() error {
@@ -976,6 +1104,7 @@ func (s *balances) prepareHashes() error {
 }
 
 func (s *balances) reset() {
+	s.richlist.reset()
 	if !s.calculateHashes {
 		return
 	}
@@ -490,3 +490,94 @@ func TestNftList(t *testing.T) {
 	assert.Equal(t, []crypto.Digest(nil), nfts)
 
 }
+
+func TestRichlist(t *testing.T) {
+	to := createBalances(t)
+	height := func() proto.Height {
+		h, err := to.stor.stateDB.getHeight()
+		require.NoError(t, err)
+		return h
+	}
+	flush := func() {
+		from := height()
+		require.NoError(t, to.stor.rw.flush())
+		require.NoError(t, to.stor.entities.flush())
+		require.NoError(t, to.stor.stateDB.flush())
+		require.NoError(t, to.balances.updateRichlist(from, height()))
+		to.stor.rw.reset()
+		to.stor.entities.reset()
+		to.stor.stateDB.reset()
+	}
+	sender := testGlobal.senderInfo.addr.ID()
+	recipient := testGlobal.recipientInfo.addr.ID()
+	asset := proto.AssetIDFromDigest(testGlobal.asset1.assetID)
+
+	to.stor.createAsset(t, testGlobal.asset1.assetID)
+	require.NoError(t, to.balances.setWavesBalance(sender, newWavesValueFromProfile(balanceProfile{100, 0, 0}), blockID0))
+	require.NoError(t, to.balances.setWavesBalance(recipient, newWavesValueFromProfile(balanceProfile{200, 0, 0}),
+		blockID0))
+	require.NoError(t, to.balances.setAssetBalance(sender, asset, 5, blockID0))
+	flush()
+
+	top, err := to.balances.wavesRichlist(10)
+	require.NoError(t, err)
+	assert.Equal(t, []richlistEntry{{recipient, 200}, {sender, 100}}, top)
+	top, err = to.balances.assetRichlist(asset, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []richlistEntry{{sender, 5}}, top)
+
+	to.stor.addBlock(t, blockID1)
+	require.NoError(t, to.balances.setWavesBalance(sender, newWavesValueFromProfile(balanceProfile{300, 0, 0}), blockID1))
+	require.NoError(t, to.balances.setWavesBalance(recipient, newWavesValueFromProfile(balanceProfile{0, 0, 0}),
+		blockID1))
+	require.NoError(t, to.balances.setAssetBalance(recipient, asset, 7, blockID1))
+	flush()
+
+	top, err = to.balances.wavesRichlist(10)
+	require.NoError(t, err)
+	assert.Equal(t, []richlistEntry{{sender, 300}}, top)
+	top, err = to.balances.assetRichlist(asset, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []richlistEntry{{recipient, 7}}, top)
+
+	// Balances changed by the removed block are read again instead of the full rebuild.
+	to.stor.fullRollbackBlockClearCache(t, blockID0)
+	require.NoError(t, to.balances.rollbackRichlist(height()))
+	require.NotNil(t, to.balances.richlist.waves)
+	top, err = to.balances.wavesRichlist(10)
+	require.NoError(t, err)
+	assert.Equal(t, []richlistEntry{{recipient, 200}, {sender, 100}}, top)
+	top, err = to.balances.assetRichlist(asset, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []richlistEntry{{sender, 5}}, top)
+
+	// Rollback deeper than the journal drops the index.
+	require.NoError(t, to.balances.rollbackRichlist(0))
+	assert.Nil(t, to.balances.richlist.waves)
+	assert.Empty(t, to.balances.richlist.assets)
+}
+
+func TestRichlistLimits(t *testing.T) {
+	to := createBalances(t)
+	r := to.balances.richlist
+	for i := range maxRichlistAssets + 1 {
+		r.builds = newIndexBuildLimiter()
+		_, err := to.balances.assetRichlist(proto.AssetID{byte(i)}, 10)
+		require.NoError(t, err)
+	}
+	assert.Len(t, r.assets, maxRichlistAssets)
+	assert.NotContains(t, r.assets, proto.AssetID{0}, "the least recently used ranking is dropped")
+
+	r.builds = newIndexBuildLimiter()
+	for range indexBuildBurst {
+		_, err := to.balances.wavesRichlist(10)
+		require.NoError(t, err)
+		r.waves = nil
+	}
+	_, err := to.balances.wavesRichlist(10)
+	assert.ErrorIs(t, err, ErrIndexBuildLimited)
+	_, err = to.balances.assetRichlist(proto.AssetID{0}, 10)
+	assert.ErrorIs(t, err, ErrIndexBuildLimited)
+	_, err = to.balances.assetRichlist(proto.AssetID{1}, 10)
+	assert.NoError(t, err, "built ranking doesn't need the full scan")
+}
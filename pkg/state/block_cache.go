@@ -0,0 +1,124 @@
+package state
+
+import (
+	"sync"
+
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+// blockHeaderSizeEstimation is an approximate size of the decoded block header in memory.
+const blockHeaderSizeEstimation = 1024
+
+type cachedBlock struct {
+	block  *proto.Block
+	height proto.Height
+	size   int
+}
+
+// recentBlocks keeps the most recent applied blocks decoded in memory. Blocks are added only after they have been
+// flushed to the storage, so the cache is consistent with the stable (not newest) state readers.
+// Cache is limited by the number of blocks and by the estimated size of the blocks, the lowest blocks are evicted
+// first. Zero limit of blocks disables the cache.
+type recentBlocks struct {
+	mu        sync.Mutex
+	maxBlocks int
+	maxBytes  int
+	blocks    map[proto.BlockID]cachedBlock
+	size      int
+}
+
+func newRecentBlocks(maxBlocks, maxBytes int) *recentBlocks {
+	return &recentBlocks{
+		maxBlocks: maxBlocks,
+		maxBytes:  maxBytes,
+		blocks:    make(map[proto.BlockID]cachedBlock, max(maxBlocks, 0)),
+	}
+}
+
+func blockSizeEstimation(b *proto.Block) int {
+	size := blockHeaderSizeEstimation
+	for _, tx := range b.Transactions {
+		size += tx.BinarySize()
+	}
+	return size
+}
+
+func (c *recentBlocks) enabled() bool {
+	return c.maxBlocks > 0
+}
+
+func (c *recentBlocks) add(b *proto.Block, height proto.Height) {
+	if !c.enabled() {
+		return
+	}
+	size := blockSizeEstimation(b)
+	if c.maxBytes > 0 && size > c.maxBytes {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	id := b.BlockID()
+	if prev, ok := c.blocks[id]; ok {
+		c.size -= prev.size
+	}
+	c.blocks[id] = cachedBlock{block: b, height: height, size: size}
+	c.size += size
+	for len(c.blocks) > c.maxBlocks || (c.maxBytes > 0 && c.size > c.maxBytes) {
+		c.evictLowest()
+	}
+}
+
+func (c *recentBlocks) evictLowest() {
+	var (
+		lowestID proto.BlockID
+		lowest   cachedBlock
+		found    bool
+	)
+	for id, cb := range c.blocks {
+		if !found || cb.height < lowest.height {
+			lowestID, lowest, found = id, cb, true
+		}
+	}
+	if found {
+		delete(c.blocks, lowestID)
+		c.size -= lowest.size
+	}
+}
+
+// block returns a shallow copy of the cached block, so the callers can't replace the fields of the cached one.
+func (c *recentBlocks) block(id proto.BlockID) (*proto.Block, bool) {
+	if !c.enabled() {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cb, ok := c.blocks[id]
+	if !ok {
+		return nil, false
+	}
+	b := *cb.block
+	return &b, true
+}
+
+func (c *recentBlocks) header(id proto.BlockID) (*proto.BlockHeader, bool) {
+	b, ok := c.block(id)
+	if !ok {
+		return nil, false
+	}
+	return &b.BlockHeader, true
+}
+
+// removeAbove drops the blocks above the height after rollback.
+func (c *recentBlocks) removeAbove(height proto.Height) {
+	if !c.enabled() {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for id, cb := range c.blocks {
+		if cb.height > height {
+			delete(c.blocks, id)
+			c.size -= cb.size
+		}
+	}
+}
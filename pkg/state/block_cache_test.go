@@ -0,0 +1,61 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+func testCachedBlock(t *testing.T, n byte) *proto.Block {
+	sig := crypto.Signature{n}
+	b := &proto.Block{BlockHeader: proto.BlockHeader{Version: proto.NgBlockVersion, BlockSignature: sig}}
+	require.NoError(t, b.GenerateBlockID(proto.TestNetScheme))
+	return b
+}
+
+func TestRecentBlocks(t *testing.T) {
+	c := newRecentBlocks(2, 0)
+	b1, b2, b3 := testCachedBlock(t, 1), testCachedBlock(t, 2), testCachedBlock(t, 3)
+	c.add(b1, 1)
+	c.add(b2, 2)
+	got, ok := c.block(b1.BlockID())
+	require.True(t, ok)
+	assert.Equal(t, b1.BlockID(), got.BlockID())
+	got.Timestamp = 100 // modification of the returned block doesn't affect the cache
+	h, ok := c.header(b1.BlockID())
+	require.True(t, ok)
+	assert.Zero(t, h.Timestamp)
+
+	c.add(b3, 3) // the lowest block is evicted
+	_, ok = c.block(b1.BlockID())
+	assert.False(t, ok)
+	_, ok = c.block(b3.BlockID())
+	assert.True(t, ok)
+
+	c.removeAbove(2)
+	_, ok = c.block(b3.BlockID())
+	assert.False(t, ok)
+	_, ok = c.block(b2.BlockID())
+	assert.True(t, ok)
+	assert.Equal(t, blockHeaderSizeEstimation, c.size)
+}
+
+func TestRecentBlocksLimits(t *testing.T) {
+	disabled := newRecentBlocks(0, 0)
+	b1, b2 := testCachedBlock(t, 1), testCachedBlock(t, 2)
+	disabled.add(b1, 1)
+	_, ok := disabled.block(b1.BlockID())
+	assert.False(t, ok)
+
+	c := newRecentBlocks(10, blockHeaderSizeEstimation)
+	c.add(b1, 1)
+	c.add(b2, 2) // byte budget allows only one block
+	_, ok = c.block(b1.BlockID())
+	assert.False(t, ok)
+	_, ok = c.block(b2.BlockID())
+	assert.True(t, ok)
+}
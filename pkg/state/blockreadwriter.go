@@ -167,6 +167,9 @@ type blockReadWriter struct {
 
 	addingBlock bool
 
+	// backupGuard preserves the data of files captured for the backup on truncation.
+	backupGuard *backupGuard
+
 	// Protobuf-related stuff.
 	protobufInfoWithActivation
 
@@ -195,6 +198,27 @@ func openOrCreateForAppending(path string) (*os.File, uint64, error) {
 	return file, uint64(size), nil
 }
 
+// openForReading function opens existing file in read-only mode.
+func openForReading(path string) (*os.File, uint64, error) {
+	file, err := os.Open(path) // #nosec: in this case check for prevent G304 (CWE-22) is not necessary
+	if err != nil {
+		return nil, 0, err
+	}
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, 0, stderrs.Join(err, file.Close())
+	}
+	return file, uint64(stat.Size()), nil
+}
+
+// openStorageFile opens block storage file for appending or, in read-only mode, for reading only.
+func openStorageFile(path string, readOnly bool) (*os.File, uint64, error) {
+	if readOnly {
+		return openForReading(path)
+	}
+	return openOrCreateForAppending(path)
+}
+
 func newBlockReadWriter(
 	dir string,
 	offsetLen int,
@@ -205,7 +229,7 @@ func newBlockReadWriter(
 	if offsetLen < 0 {
 		return nil, errors.New("negative offset length")
 	}
-	blockchain, blockchainSize, err := openOrCreateForAppending(filepath.Join(dir, "blockchain"))
+	blockchain, blockchainSize, err := openStorageFile(filepath.Join(dir, "blockchain"), stateDB.readOnly)
 	if err != nil {
 		return nil, err
 	}
@@ -216,7 +240,7 @@ func newBlockReadWriter(
 			}
 		}
 	}()
-	headers, headersSize, err := openOrCreateForAppending(filepath.Join(dir, "headers"))
+	headers, headersSize, err := openStorageFile(filepath.Join(dir, "headers"), stateDB.readOnly)
 	if err != nil {
 		return nil, err
 	}
@@ -227,7 +251,7 @@ func newBlockReadWriter(
 			}
 		}
 	}()
-	blockHeight2ID, _, err := openOrCreateForAppending(filepath.Join(dir, "block_height_to_id"))
+	blockHeight2ID, _, err := openStorageFile(filepath.Join(dir, "block_height_to_id"), stateDB.readOnly)
 	if err != nil {
 		return nil, err
 	}
@@ -277,6 +301,10 @@ func newBlockReadWriter(
 		height:                     height,
 		protobufInfoWithActivation: pbInfo,
 	}
+	if stateDB.readOnly {
+		// Files could be longer than the database says, but this tail is never addressed, so leave it as is.
+		return rw, nil
+	}
 	if err := rw.syncWithDb(); err != nil {
 		return nil, err // no need to close rw because all resources will be closed above
 	}
@@ -785,14 +813,14 @@ func (rw *blockReadWriter) truncate(newHeight, newBlockchainLen, newHeadersLen u
 	defer rw.mtx.Unlock()
 
 	// Remove transactions.
-	if err := rw.blockchain.Truncate(int64(newBlockchainLen)); err != nil {
+	if err := rw.backupGuard.truncate(rw.blockchain, int64(newBlockchainLen)); err != nil {
 		return err
 	}
 	if _, err := rw.blockchain.Seek(int64(newBlockchainLen), 0); err != nil {
 		return err
 	}
 	// Remove headers.
-	if err := rw.headers.Truncate(int64(newHeadersLen)); err != nil {
+	if err := rw.backupGuard.truncate(rw.headers, int64(newHeadersLen)); err != nil {
 		return err
 	}
 	if _, err := rw.headers.Seek(int64(newHeadersLen), 0); err != nil {
@@ -800,7 +828,7 @@ func (rw *blockReadWriter) truncate(newHeight, newBlockchainLen, newHeadersLen u
 	}
 	// Remove blockIDs from blockHeight2ID file.
 	newOffset := int64(rw.heightToIDOffset(newHeight))
-	if err := rw.blockHeight2ID.Truncate(newOffset); err != nil {
+	if err := rw.backupGuard.truncate(rw.blockHeight2ID, newOffset); err != nil {
 		return err
 	}
 	if _, err := rw.blockHeight2ID.Seek(newOffset, 0); err != nil {
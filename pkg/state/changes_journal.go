@@ -0,0 +1,99 @@
+package state
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
+
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+const (
+	// changesJournalDepth is the number of the most recent blocks which changes are remembered by changesJournal.
+	changesJournalDepth = 100
+
+	indexBuildInterval = 10 * time.Second
+	indexBuildBurst    = 5
+)
+
+// ErrIndexBuildLimited is returned when an in-memory index has to be built by the full scan of the state,
+// but such scans are requested too often.
+var ErrIndexBuildLimited = errors.New("index is built too often, try again later")
+
+func newIndexBuildLimiter() *rate.Limiter {
+	return rate.NewLimiter(rate.Every(indexBuildInterval), indexBuildBurst)
+}
+
+type journalEntry[K comparable] struct {
+	height proto.Height // Height of the state after the flush.
+	keys   map[K]struct{}
+}
+
+// changesJournal remembers the keys changed by the recently flushed blocks, so in-memory indexes are updated
+// on rollback instead of being rebuilt from scratch. Changes of the blocks above the floor height are remembered.
+type changesJournal[K comparable] struct {
+	floor   proto.Height
+	entries []journalEntry[K]
+}
+
+// record remembers the keys changed by the flush which moved the state from height from to height to.
+func (j *changesJournal[K]) record(from, to proto.Height, keys map[K]struct{}) {
+	if len(j.entries) == 0 || j.entries[len(j.entries)-1].height != from {
+		// Changes below the height are unknown, e.g. right after the start.
+		j.entries = j.entries[:0]
+		j.floor = from
+	}
+	j.entries = append(j.entries, journalEntry[K]{height: to, keys: cloneSet(keys)})
+	for len(j.entries) > 1 && j.entries[0].height+changesJournalDepth <= to {
+		j.floor = j.entries[0].height
+		j.entries = j.entries[1:]
+	}
+}
+
+// rollback forgets the changes above the height and returns the keys they changed.
+// It returns false if the changes are not known, then the indexes have to be rebuilt.
+func (j *changesJournal[K]) rollback(height proto.Height) (map[K]struct{}, bool) {
+	if height < j.floor || len(j.entries) == 0 && height != j.floor {
+		j.entries = j.entries[:0]
+		j.floor = height
+		return nil, false
+	}
+	keys := make(map[K]struct{})
+	for len(j.entries) > 0 {
+		last := j.entries[len(j.entries)-1]
+		if last.height <= height {
+			break
+		}
+		for k := range last.keys {
+			keys[k] = struct{}{}
+		}
+		j.entries = j.entries[:len(j.entries)-1]
+		if lower := j.top(); lower < height {
+			// The flush covered the blocks on both sides of the height, its keys are still needed for
+			// the rollbacks below the height.
+			j.entries = append(j.entries, journalEntry[K]{height: height, keys: last.keys})
+			break
+		}
+	}
+	if len(j.entries) == 0 {
+		j.floor = height
+	}
+	return keys, true
+}
+
+// top returns the height of the last recorded flush.
+func (j *changesJournal[K]) top() proto.Height {
+	if len(j.entries) == 0 {
+		return j.floor
+	}
+	return j.entries[len(j.entries)-1].height
+}
+
+func cloneSet[K comparable](s map[K]struct{}) map[K]struct{} {
+	res := make(map[K]struct{}, len(s))
+	for k := range s {
+		res[k] = struct{}{}
+	}
+	return res
+}
@@ -0,0 +1,60 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+func TestChangesJournal(t *testing.T) {
+	set := func(keys ...int) map[int]struct{} {
+		res := make(map[int]struct{}, len(keys))
+		for _, k := range keys {
+			res[k] = struct{}{}
+		}
+		return res
+	}
+	var j changesJournal[int]
+	_, ok := j.rollback(5)
+	assert.False(t, ok, "nothing is recorded")
+
+	j.record(10, 11, set(1))
+	j.record(11, 15, set(2, 3))
+	j.record(15, 16, set(4))
+
+	keys, ok := j.rollback(16)
+	assert.True(t, ok)
+	assert.Empty(t, keys)
+	keys, ok = j.rollback(15)
+	assert.True(t, ok)
+	assert.Equal(t, set(4), keys)
+	// Keys of the flush covering blocks on both sides of the height are kept for deeper rollbacks.
+	keys, ok = j.rollback(13)
+	assert.True(t, ok)
+	assert.Equal(t, set(2, 3), keys)
+	keys, ok = j.rollback(11)
+	assert.True(t, ok)
+	assert.Equal(t, set(2, 3), keys)
+	keys, ok = j.rollback(10)
+	assert.True(t, ok)
+	assert.Equal(t, set(1), keys)
+	_, ok = j.rollback(9)
+	assert.False(t, ok, "rollback below the first recorded flush")
+
+	// Gap between flushes drops the journal.
+	j.record(20, 21, set(1))
+	j.record(30, 31, set(2))
+	_, ok = j.rollback(25)
+	assert.False(t, ok)
+
+	// Old flushes are forgotten.
+	j.record(0, 1, set(1))
+	for h := proto.Height(1); h <= changesJournalDepth+1; h++ {
+		j.record(h, h+1, set(int(h)))
+	}
+	assert.Equal(t, proto.Height(2), j.floor)
+	_, ok = j.rollback(1)
+	assert.False(t, ok)
+}
@@ -17,6 +17,10 @@ const (
 	DefaultCompactionTableSize = 8 * 1024 * 1024
 	DefaultCompactionTotalSize = 10 * 1024 * 1024
 
+	// Recent blocks cache parameters.
+	DefaultRecentBlocksCacheSize  = 100
+	DefaultRecentBlocksCacheBytes = 64 * 1024 * 1024
+
 	// Block storage parameters.
 	// DefaultOffsetLen is the amount of bytes needed to store offset of transactions in blockchain file.
 	DefaultOffsetLen = 8
@@ -25,7 +29,8 @@ const (
 
 	// StateVersion is current version of state internal storage formats.
 	// It increases when backward compatibility with previous storage version is lost.
-	StateVersion = 26
+	// Add a migration from the previous version to avoid re-import of existing states.
+	StateVersion = 27
 
 	// Memory limit for address transactions. flush() is called when this
 	// limit is exceeded.
@@ -65,6 +65,7 @@ type stateDB struct {
 	dbBatch     keyvalue.Batch
 	dbWriteLock *sync.Mutex // `dbWriteLock` is lock for writing to database.
 	rw          *blockReadWriter
+	readOnly    bool // `readOnly` is true if database is opened in read-only mode.
 
 	newestBlockId2Num map[proto.BlockID]uint32
 	newestBlockNum2Id map[uint32]proto.BlockID
@@ -73,6 +74,23 @@ type stateDB struct {
 }
 
 func newStateDB(db keyvalue.KeyValue, dbBatch keyvalue.Batch, params StateParams) (*stateDB, error) {
+	if params.DbParams.ReadOnly {
+		has, err := db.Has(dbHeightKeyBytes)
+		if err != nil {
+			return nil, err
+		}
+		if !has {
+			return nil, errors.New("state is empty, nothing to open in read-only mode")
+		}
+		return &stateDB{
+			db:                db,
+			dbBatch:           dbBatch,
+			dbWriteLock:       &sync.Mutex{},
+			newestBlockId2Num: make(map[proto.BlockID]uint32),
+			newestBlockNum2Id: make(map[uint32]proto.BlockID),
+			readOnly:          true,
+		}, nil
+	}
 	heightBuf := make([]byte, 8)
 	has, err := db.Has(dbHeightKeyBytes)
 	if err != nil {
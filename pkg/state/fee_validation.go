@@ -41,6 +41,12 @@ var feeConstants = map[proto.TransactionType]uint64{
 	proto.InvokeExpressionTransaction: 5,
 }
 
+// BaseFee returns the minimal fee in Waves for the transaction type without extra fees for scripts and assets.
+func BaseFee(txType proto.TransactionType) (uint64, bool) {
+	c, ok := feeConstants[txType]
+	return c * FeeUnit, ok
+}
+
 type feeValidationParams struct {
 	stor            *blockchainEntitiesStorage
 	settings        *settings.BlockchainSettings
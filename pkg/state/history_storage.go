@@ -727,6 +727,32 @@ func (hs *historyStorage) entryDataAtHeight(key []byte, height uint64) ([]byte,
 	return hs.entryDataWithHeightFilter(key, height, cmp)
 }
 
+// iterateEntriesAtHeight calls fn with key and data of the history entry actual at the given height
+// for all keys with the given prefix. Keys without entries at the height are skipped.
+func (hs *historyStorage) iterateEntriesAtHeight(
+	prefix []byte, height proto.Height, fn func(key, data []byte) error,
+) error {
+	iter, err := hs.db.NewKeyIterator(prefix)
+	if err != nil {
+		return err
+	}
+	defer iter.Release()
+	for iter.Next() {
+		key := keyvalue.SafeKey(iter)
+		data, dErr := hs.entryDataAtHeight(key, height)
+		if dErr != nil {
+			return errors.Wrapf(dErr, "failed to get entry at height %d", height)
+		}
+		if len(data) == 0 {
+			continue
+		}
+		if fErr := fn(key, data); fErr != nil {
+			return fErr
+		}
+	}
+	return iter.Error()
+}
+
 // blockRangeEntries() returns list of entries corresponding to given block interval.
 // IMPORTANTLY, it does not simply return list of entries with block nums between startBlockNum and endBlockNum,
 // instead this function returns values which are relevant for this block range.
@@ -349,6 +349,17 @@ func (k *assetHistKey) bytes() []byte {
 	return buf
 }
 
+func (k *assetHistKey) unmarshal(data []byte) error {
+	if len(data) != 1+proto.AssetIDSize {
+		return errInvalidDataSize
+	}
+	if data[0] != assetHistKeyPrefix {
+		return errInvalidPrefix
+	}
+	copy(k.assetID[:], data[1:])
+	return nil
+}
+
 type leaseKey struct {
 	leaseID crypto.Digest
 }
@@ -59,6 +59,28 @@ func (l *leasing) isActive() bool {
 	return l.Status == LeaseActive
 }
 
+func (l *leasing) details(scheme proto.Scheme, id crypto.Digest) (*proto.LeaseDetails, error) {
+	sender, err := proto.NewAddressFromPublicKey(scheme, l.SenderPK)
+	if err != nil {
+		return nil, err
+	}
+	d := &proto.LeaseDetails{
+		ID:                  id,
+		Sender:              sender,
+		SenderPK:            l.SenderPK,
+		Recipient:           l.RecipientAddr,
+		Amount:              l.Amount,
+		Height:              l.OriginHeight,
+		IsActive:            l.isActive(),
+		CancelHeight:        l.CancelHeight,
+		CancelTransactionID: l.CancelTransactionID,
+	}
+	if l.OriginTransactionID != nil {
+		d.OriginTransactionID = *l.OriginTransactionID
+	}
+	return d, nil
+}
+
 func (l *leasing) marshalBinary() ([]byte, error) {
 	return cbor.Marshal(l)
 }
@@ -256,6 +278,28 @@ func (l *leases) leasingInfo(id crypto.Digest) (*leasing, error) {
 	return record, nil
 }
 
+// leasesAtHeight calls fn for every lease existing at the given height, including cancelled ones.
+// Height must be in the retained part of the history.
+func (l *leases) leasesAtHeight(
+	scheme proto.Scheme, height proto.Height, fn func(*proto.LeaseDetails) error,
+) error {
+	return l.hs.iterateEntriesAtHeight([]byte{leaseKeyPrefix}, height, func(key, data []byte) error {
+		var k leaseKey
+		if err := k.unmarshal(key); err != nil {
+			return err
+		}
+		var r leasing
+		if err := r.unmarshalBinary(data); err != nil {
+			return errors.Wrapf(err, "failed to unmarshal data to %T", r)
+		}
+		d, err := r.details(scheme, k.leaseID)
+		if err != nil {
+			return err
+		}
+		return fn(d)
+	})
+}
+
 func (l *leases) isActive(id crypto.Digest) (bool, error) {
 	info, err := l.leasingInfo(id)
 	if err != nil {
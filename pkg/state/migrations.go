@@ -0,0 +1,132 @@
+package state
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/wavesplatform/gowaves/pkg/keyvalue"
+)
+
+// migration converts the database from the storage version `from` to the next one.
+type migration struct {
+	from        uint16
+	description string
+	apply       func(db keyvalue.IterableKeyVal) error
+}
+
+// migrations is the ordered list of available migrations. When StateVersion is increased, a migration from the
+// previous version should be added here if the old storage can be converted without re-importing blocks.
+var migrations = []migration{
+	{
+		from:        26,
+		description: "fix entity type of address to aliases history",
+		apply:       fixAddressToAliasesEntityType,
+	},
+}
+
+// MigrationParams control migrations of the outdated state database.
+type MigrationParams struct {
+	// DisableMigrations makes opening of the outdated state fail instead of migrating it.
+	DisableMigrations bool
+	// MigrationsBackupDir is a path to the directory to store a copy of the key-value database into before
+	// the migrations are applied. Backup is not made if the path is empty.
+	MigrationsBackupDir string
+}
+
+// migrationsPath returns the migrations needed to upgrade the storage from the given version to StateVersion.
+func migrationsPath(available []migration, version, target uint16) ([]migration, error) {
+	var path []migration
+	for v := version; v < target; v++ {
+		i := -1
+		for j := range available {
+			if available[j].from == v {
+				i = j
+				break
+			}
+		}
+		if i < 0 {
+			return nil, errors.Errorf("no migration from storage version %d, state must be re-imported", v)
+		}
+		path = append(path, available[i])
+	}
+	return path, nil
+}
+
+// migrateStateDB upgrades the state database to StateVersion if it has an older version.
+// Version is updated after each migration, so an interrupted upgrade continues from the last applied one.
+func migrateStateDB(db keyvalue.IterableKeyVal, sdb *stateDB, params StateParams) error {
+	return applyMigrations(db, sdb, migrations, StateVersion, params)
+}
+
+func applyMigrations(db keyvalue.IterableKeyVal, sdb *stateDB, available []migration, target uint16,
+	params StateParams) error {
+	info, err := sdb.stateInfo()
+	if err != nil {
+		return errors.Wrap(err, "failed to get state info")
+	}
+	if info.Version >= target {
+		return nil // incompatibility of newer versions is reported by checkCompatibility
+	}
+	path, err := migrationsPath(available, info.Version, target)
+	if err != nil {
+		return errors.Wrap(ErrIncompatibleStateParams, err.Error())
+	}
+	if params.DisableMigrations {
+		return errors.Wrapf(ErrIncompatibleStateParams,
+			"state has storage version %d, want %d, and migrations are disabled", info.Version, target)
+	}
+	if params.DbParams.ReadOnly {
+		return errors.Wrapf(ErrIncompatibleStateParams,
+			"state has storage version %d, want %d, and can't be migrated in read-only mode", info.Version, target)
+	}
+	if dir := params.MigrationsBackupDir; dir != "" {
+		zap.S().Infof("Backing up state database to '%s' before migrations", dir)
+		if bErr := copyKeyValue(db, dir, params.DbParams); bErr != nil {
+			return errors.Wrap(bErr, "failed to backup state database")
+		}
+	}
+	for _, m := range path {
+		zap.S().Infof("Migrating state from storage version %d to %d: %s", m.from, m.from+1, m.description)
+		if aErr := m.apply(db); aErr != nil {
+			return errors.Wrapf(aErr, "migration from storage version %d failed", m.from)
+		}
+		info.Version = m.from + 1
+		if pErr := putStateInfoToDB(db, &info); pErr != nil {
+			return errors.Wrap(pErr, "failed to update storage version")
+		}
+	}
+	return nil
+}
+
+// legacyAddressToAliasesEntity is the entity type the address to aliases history was stored with before storage
+// version 27, the size of the key was passed instead of the entity type by mistake.
+const legacyAddressToAliasesEntity = blockchainEntity(addressToAliasesKeySize)
+
+// fixAddressToAliasesEntityType rewrites the entity type of address to aliases history stored with the legacy type.
+// Both types are of variable size, so the records are left intact.
+func fixAddressToAliasesEntityType(db keyvalue.IterableKeyVal) error {
+	keys, err := collectKeys(db, addressToAliasesPrefix)
+	if err != nil {
+		return errors.Wrap(err, "failed to collect address to aliases keys")
+	}
+	fixed := 0
+	for _, key := range keys {
+		value, gErr := db.Get(key)
+		if gErr != nil {
+			return errors.Wrap(gErr, "failed to get address to aliases history")
+		}
+		if len(value) == 0 || blockchainEntity(value[0]) != legacyAddressToAliasesEntity {
+			continue
+		}
+		history := bytes.Clone(value)
+		history[0] = byte(addressToAliases)
+		if pErr := db.Put(key, history); pErr != nil {
+			return errors.Wrap(pErr, "failed to update address to aliases history")
+		}
+		fixed++
+	}
+	zap.S().Infof("Entity type of address to aliases history is fixed for %d addresses", fixed)
+	return nil
+}
@@ -0,0 +1,153 @@
+package state
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/keyvalue"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+var testMigrationKey = []byte("test-migration")
+
+func testMigrations(applied *[]uint16) []migration {
+	mk := func(from uint16) migration {
+		return migration{from: from, description: "test", apply: func(db keyvalue.IterableKeyVal) error {
+			*applied = append(*applied, from)
+			return db.Put(testMigrationKey, []byte{byte(from)})
+		}}
+	}
+	return []migration{mk(2), mk(1), mk(3)}
+}
+
+func setStorageVersion(t *testing.T, to *testStorageObjects, version uint16) {
+	info, err := to.stateDB.stateInfo()
+	require.NoError(t, err)
+	info.Version = version
+	require.NoError(t, putStateInfoToDB(to.db, &info))
+}
+
+func TestMigrationsPath(t *testing.T) {
+	var applied []uint16
+	available := testMigrations(&applied)
+
+	path, err := migrationsPath(available, 1, 4)
+	require.NoError(t, err)
+	require.Len(t, path, 3)
+	for i, m := range path {
+		assert.Equal(t, uint16(i+1), m.from)
+	}
+	path, err = migrationsPath(available, 4, 4)
+	require.NoError(t, err)
+	assert.Empty(t, path)
+	_, err = migrationsPath(available, 0, 4)
+	assert.Error(t, err)
+}
+
+func TestApplyMigrations(t *testing.T) {
+	to := createStorageObjects(t, true)
+	setStorageVersion(t, to, 2)
+	var applied []uint16
+
+	err := applyMigrations(to.db, to.stateDB, testMigrations(&applied), 4, DefaultTestingStateParams())
+	require.NoError(t, err)
+	assert.Equal(t, []uint16{2, 3}, applied)
+	version, err := to.stateDB.stateVersion()
+	require.NoError(t, err)
+	assert.Equal(t, 4, version)
+
+	// Nothing to do for the up-to-date state.
+	err = applyMigrations(to.db, to.stateDB, testMigrations(&applied), 4, DefaultTestingStateParams())
+	require.NoError(t, err)
+	assert.Equal(t, []uint16{2, 3}, applied)
+}
+
+func TestApplyMigrationsDisabled(t *testing.T) {
+	to := createStorageObjects(t, true)
+	setStorageVersion(t, to, 2)
+	var applied []uint16
+
+	params := DefaultTestingStateParams()
+	params.DisableMigrations = true
+	err := applyMigrations(to.db, to.stateDB, testMigrations(&applied), 4, params)
+	assert.ErrorIs(t, err, ErrIncompatibleStateParams)
+	assert.Empty(t, applied)
+
+	err = applyMigrations(to.db, to.stateDB, testMigrations(&applied), 5, DefaultTestingStateParams())
+	assert.ErrorIs(t, err, ErrIncompatibleStateParams)
+	assert.Empty(t, applied)
+}
+
+func TestApplyMigrationsBackup(t *testing.T) {
+	to := createStorageObjects(t, true)
+	setStorageVersion(t, to, 3)
+	require.NoError(t, to.db.Put(testMigrationKey, []byte{0}))
+	var applied []uint16
+
+	params := DefaultTestingStateParams()
+	params.MigrationsBackupDir = filepath.Join(t.TempDir(), "backup")
+	err := applyMigrations(to.db, to.stateDB, testMigrations(&applied), 4, params)
+	require.NoError(t, err)
+
+	backupParams := defaultTestKeyValParams()
+	backupParams.DisableBloomFilter = true
+	backup, err := keyvalue.NewKeyVal(params.MigrationsBackupDir, backupParams)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, backup.Close()) }()
+	v, err := backup.Get(testMigrationKey)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0}, v) // value before migration
+	bi, err := backup.Get(stateInfoKeyBytes)
+	require.NoError(t, err)
+	var info stateInfo
+	require.NoError(t, info.unmarshalBinary(bi))
+	assert.Equal(t, uint16(3), info.Version)
+
+	// Backup directory must be empty.
+	setStorageVersion(t, to, 3)
+	err = applyMigrations(to.db, to.stateDB, testMigrations(&applied), 4, params)
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, ErrIncompatibleStateParams))
+}
+
+func TestFixAddressToAliasesEntityType(t *testing.T) {
+	to := createStorageObjects(t, true)
+	addr, err := proto.NewAddressFromString(addr0)
+	require.NoError(t, err)
+	to.addBlockAndDo(t, blockID0, func(id proto.BlockID) {
+		require.NoError(t, to.entities.aliases.createAlias("alias1", addr, id))
+	})
+	to.flush(t)
+
+	// Write the history with the entity type used before storage version 27.
+	key := (&addressToAliasesKey{addressID: addr.ID()}).bytes()
+	history, err := to.db.Get(key)
+	require.NoError(t, err)
+	require.Equal(t, byte(addressToAliases), history[0])
+	history = bytes.Clone(history)
+	history[0] = byte(legacyAddressToAliasesEntity)
+	require.NoError(t, to.db.Put(key, history))
+	setStorageVersion(t, to, 26)
+
+	require.NoError(t, migrateStateDB(to.db, to.stateDB, DefaultTestingStateParams()))
+	version, err := to.stateDB.stateVersion()
+	require.NoError(t, err)
+	assert.Equal(t, StateVersion, version)
+	history, err = to.db.Get(key)
+	require.NoError(t, err)
+	assert.Equal(t, byte(addressToAliases), history[0])
+
+	// Alias change of the existing address is applied on top of the migrated history.
+	to.addBlockAndDo(t, blockID1, func(id proto.BlockID) {
+		require.NoError(t, to.entities.aliases.createAlias("alias2", addr, id))
+	})
+	to.flush(t)
+	aliases, err := to.entities.aliases.aliasesByAddr(addr)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alias1", "alias2"}, aliases)
+}
@@ -92,3 +92,20 @@ func (ov *ordersVolumes) newestFilled(orderID []byte) (uint64, uint64, error) {
 	}
 	return volume.amountFilled, volume.feeFilled, nil
 }
+
+// filled returns the stable filled amount and fee of the order.
+func (ov *ordersVolumes) filled(orderID []byte) (uint64, uint64, error) {
+	key := ordersVolumeKey{orderID}
+	recordBytes, err := ov.hs.topEntryData(key.bytes())
+	if err != nil {
+		if isNotFoundInHistoryOrDBErr(err) { // Order was not filled.
+			return 0, 0, nil
+		}
+		return 0, 0, errors.Wrapf(err, "failed to get filled for order %q", base58.Encode(orderID))
+	}
+	var record orderVolumeRecord
+	if uErr := record.unmarshalBinary(recordBytes); uErr != nil {
+		return 0, 0, errors.Wrap(uErr, "failed to unmarshal order volume record")
+	}
+	return record.amountFilled, record.feeFilled, nil
+}
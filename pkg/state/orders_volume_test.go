@@ -52,3 +52,28 @@ func TestIncreaseFilled(t *testing.T) {
 	assert.Equal(t, firstFee+secondFee, filledFee)
 	assert.Equal(t, firstAmount+secondAmount, filledAmount)
 }
+
+func TestFilled(t *testing.T) {
+	to := createOrdersVolumeStorageObjects(t)
+
+	to.stor.addBlock(t, blockID0)
+	orderID := bytes.Repeat([]byte{0xee}, crypto.DigestSize)
+
+	filledAmount, filledFee, err := to.ordersVolumes.filled(orderID)
+	assert.NoError(t, err)
+	assert.Zero(t, filledAmount)
+	assert.Zero(t, filledFee)
+
+	err = to.ordersVolumes.storeFilled(orderID, 111, 1, blockID0)
+	assert.NoError(t, err)
+	filledAmount, filledFee, err = to.ordersVolumes.filled(orderID)
+	assert.NoError(t, err)
+	assert.Zero(t, filledAmount, "not flushed records are not stable")
+	assert.Zero(t, filledFee)
+
+	to.stor.flush(t)
+	filledAmount, filledFee, err = to.ordersVolumes.filled(orderID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(111), filledAmount)
+	assert.Equal(t, uint64(1), filledFee)
+}
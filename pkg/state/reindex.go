@@ -0,0 +1,230 @@
+package state
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/wavesplatform/gowaves/pkg/keyvalue"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+)
+
+// aliasEvent is a change of alias ownership found in the alias history.
+// Event with nil owner means that the alias was disabled as stolen.
+type aliasEvent struct {
+	blockNum uint32
+	alias    string
+	owner    *proto.AddressID
+}
+
+func compareAliasEvents(a, b aliasEvent) int {
+	if c := cmp.Compare(a.blockNum, b.blockNum); c != 0 {
+		return c
+	}
+	// Stolen aliases are disabled before the transactions of the block are applied.
+	if (a.owner == nil) != (b.owner == nil) {
+		if a.owner == nil {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+// rebuildAddressToAliases replaces the stored reverse index of aliases with the one restored from the history of
+// alias records. The history of stolen aliases disabling is attributed to the block with number disabledAt.
+// Function works directly with the database and should be called only when there is no unflushed changes.
+func (a *aliases) rebuildAddressToAliases(disabledAt uint32) (int, error) {
+	events, err := a.collectAliasEvents(disabledAt)
+	if err != nil {
+		return 0, err
+	}
+	slices.SortStableFunc(events, compareAliasEvents)
+
+	owners := make(map[string]proto.AddressID)
+	current := make(map[proto.AddressID]*addressToAliasesRecord)
+	histories := make(map[proto.AddressID]*historyRecord)
+	touched := make(map[proto.AddressID]struct{})
+	remove := func(alias string) {
+		prev, ok := owners[alias]
+		if !ok {
+			return
+		}
+		delete(owners, alias)
+		if r, ok := current[prev]; ok && r.removeIfExists(alias) {
+			touched[prev] = struct{}{}
+		}
+	}
+	commit := func(blockNum uint32) error {
+		for id := range touched {
+			data, mErr := current[id].marshalBinary()
+			if mErr != nil {
+				return mErr
+			}
+			h, ok := histories[id]
+			if !ok {
+				h = newHistoryRecord(addressToAliases)
+				histories[id] = h
+			}
+			if aErr := h.appendEntry(historyEntry{data: data, blockNum: blockNum}); aErr != nil {
+				return aErr
+			}
+		}
+		clear(touched)
+		return nil
+	}
+	for i, e := range events {
+		if i > 0 && events[i-1].blockNum != e.blockNum {
+			if cErr := commit(events[i-1].blockNum); cErr != nil {
+				return 0, cErr
+			}
+		}
+		remove(e.alias)
+		if e.owner == nil {
+			continue
+		}
+		owners[e.alias] = *e.owner
+		r, ok := current[*e.owner]
+		if !ok {
+			r = &addressToAliasesRecord{}
+			current[*e.owner] = r
+		}
+		r.aliases = append(r.aliases, e.alias)
+		touched[*e.owner] = struct{}{}
+	}
+	if n := len(events); n > 0 {
+		if cErr := commit(events[n-1].blockNum); cErr != nil {
+			return 0, cErr
+		}
+	}
+
+	if err := a.dropAddressToAliases(); err != nil {
+		return 0, errors.Wrap(err, "failed to remove old address to aliases records")
+	}
+	for id, h := range histories {
+		key := addressToAliasesKey{addressID: id}
+		if err := a.hs.manageDbUpdate(key.bytes(), h); err != nil {
+			return 0, errors.Wrap(err, "failed to store address to aliases record")
+		}
+	}
+	return len(histories), nil
+}
+
+func (a *aliases) collectAliasEvents(disabledAt uint32) ([]aliasEvent, error) {
+	keys, err := collectKeys(a.db, aliasKeyPrefix)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to collect alias keys")
+	}
+	var events []aliasEvent
+	for _, keyBytes := range keys {
+		var key aliasKey
+		if err := key.unmarshal(keyBytes); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal alias key")
+		}
+		history, err := a.hs.getHistory(keyBytes, true)
+		if err != nil {
+			if errors.Is(err, errEmptyHist) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to get history of alias %q", key.alias)
+		}
+		for _, entry := range history.entries {
+			var record aliasRecord
+			if err := record.unmarshalBinary(entry.data); err != nil {
+				return nil, errors.Wrapf(err, "failed to unmarshal record of alias %q", key.alias)
+			}
+			owner := record.info.addressID
+			events = append(events, aliasEvent{blockNum: entry.blockNum, alias: key.alias, owner: &owner})
+		}
+		disabled, err := a.isDisabled(key.alias)
+		if err != nil {
+			return nil, err
+		}
+		if disabled {
+			events = append(events, aliasEvent{blockNum: disabledAt, alias: key.alias})
+		}
+	}
+	return events, nil
+}
+
+func (a *aliases) dropAddressToAliases() error {
+	keys, err := collectKeys(a.db, addressToAliasesPrefix)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := a.db.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func collectKeys(db keyvalue.IterableKeyVal, prefix byte) ([][]byte, error) {
+	iter, err := db.NewKeyIterator([]byte{prefix})
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Release()
+	var keys [][]byte
+	for iter.Next() {
+		keys = append(keys, keyvalue.SafeKey(iter))
+	}
+	return keys, iter.Error()
+}
+
+// stolenAliasesDisablingBlockNum returns the number of the block at which stolen aliases were disabled or zero if
+// it has not happened yet.
+func (s *stateManager) stolenAliasesDisablingBlockNum() (uint32, error) {
+	if s.settings.Type == settings.Custom {
+		return 0, nil
+	}
+	height, err := s.Height()
+	if err != nil {
+		return 0, err
+	}
+	if !s.stor.features.isActivatedAtHeight(int16(settings.DataTransaction), height) {
+		return 0, nil
+	}
+	activationHeight, err := s.stor.features.activationHeight(int16(settings.DataTransaction))
+	if err != nil {
+		return 0, err
+	}
+	return s.stateDB.blockNumByHeight(activationHeight)
+}
+
+// rebuildIndexes restores secondary indexes kept in the database from the stored primary records.
+func (s *stateManager) rebuildIndexes() error {
+	disabledAt, err := s.stolenAliasesDisablingBlockNum()
+	if err != nil {
+		return errors.Wrap(err, "failed to find the block of stolen aliases disabling")
+	}
+	n, err := s.stor.aliases.rebuildAddressToAliases(disabledAt)
+	if err != nil {
+		return errors.Wrap(err, "failed to rebuild address to aliases index")
+	}
+	zap.S().Infof("Address to aliases index rebuilt for %d addresses", n)
+	return nil
+}
+
+// RebuildIndexes opens the state located in dataDir and rebuilds its secondary indexes from the already stored
+// data without re-applying blocks.
+//
+// Only the address to aliases index is persisted in a form that can be restored offline. Transactions by address
+// index is built from the results of transactions execution and can't be restored without re-importing blocks.
+// Indexes of balances and asset names are kept in memory and built on demand, so they don't need to be rebuilt.
+func RebuildIndexes(dataDir string, params StateParams, settings *settings.BlockchainSettings) (err error) {
+	s, err := newStateManager(dataDir, false, params, settings, false)
+	if err != nil {
+		return errors.Wrap(err, "failed to open state")
+	}
+	defer func() {
+		if clErr := s.Close(); clErr != nil && err == nil {
+			err = errors.Wrap(clErr, "failed to close state")
+		}
+	}()
+	return s.rebuildIndexes()
+}
@@ -0,0 +1,257 @@
+package state
+
+import (
+	"bytes"
+	"cmp"
+	"math"
+	"slices"
+	"sync"
+
+	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
+
+	"github.com/wavesplatform/gowaves/pkg/keyvalue"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+type richlistEntry struct {
+	address proto.AddressID
+	balance uint64
+}
+
+// ranking holds non-zero balances of one asset. Balances are sorted lazily, only when the top is requested
+// after some changes.
+type ranking struct {
+	balances map[proto.AddressID]uint64
+	sorted   []richlistEntry
+	dirty    bool
+	used     uint64 // Value of the requests counter on the last request of the ranking.
+}
+
+func newRanking() *ranking {
+	return &ranking{balances: make(map[proto.AddressID]uint64), dirty: true}
+}
+
+func (r *ranking) set(addr proto.AddressID, balance uint64) {
+	if balance == 0 {
+		delete(r.balances, addr)
+	} else {
+		r.balances[addr] = balance
+	}
+	r.dirty = true
+}
+
+// top returns up to limit entries with the largest balances. Entries with equal balances are ordered by address.
+func (r *ranking) top(limit uint64) []richlistEntry {
+	if r.dirty {
+		r.sorted = r.sorted[:0]
+		for addr, balance := range r.balances {
+			r.sorted = append(r.sorted, richlistEntry{address: addr, balance: balance})
+		}
+		slices.SortFunc(r.sorted, func(a, b richlistEntry) int {
+			if c := cmp.Compare(b.balance, a.balance); c != 0 {
+				return c
+			}
+			return bytes.Compare(a.address[:], b.address[:])
+		})
+		r.dirty = false
+	}
+	return slices.Clone(r.sorted[:min(limit, uint64(len(r.sorted)))])
+}
+
+// maxRichlistAssets is the maximum number of assets which rankings are kept, the least recently requested
+// ranking is dropped to add a new one.
+const maxRichlistAssets = 100
+
+// richlist is an in-memory index of stored balances sorted by value. Index of an asset is built by the full scan
+// of the stored balances on the first request and then kept up to date with balance changes flushed to disk.
+// On rollback the balances changed by the removed blocks are read again, the index is rebuilt only if the
+// rollback is deeper than the changes journal.
+type richlist struct {
+	mu     sync.Mutex
+	waves  *ranking
+	assets map[proto.AssetID]*ranking
+	uses   uint64 // Counter of requests to find the least recently used asset ranking.
+	builds *rate.Limiter
+
+	// Changes since the last flush and journals of the flushed changes, they are accessed only by the state
+	// modifying goroutine.
+	changedWaves  map[proto.AddressID]struct{}
+	changedAssets map[assetBalanceKey]struct{}
+	wavesJournal  changesJournal[proto.AddressID]
+	assetsJournal changesJournal[assetBalanceKey]
+}
+
+func newRichlist() *richlist {
+	return &richlist{
+		assets:        make(map[proto.AssetID]*ranking),
+		builds:        newIndexBuildLimiter(),
+		changedWaves:  make(map[proto.AddressID]struct{}),
+		changedAssets: make(map[assetBalanceKey]struct{}),
+	}
+}
+
+func (r *richlist) wavesChanged(addr proto.AddressID) {
+	r.changedWaves[addr] = struct{}{}
+}
+
+func (r *richlist) assetChanged(key assetBalanceKey) {
+	r.changedAssets[key] = struct{}{}
+}
+
+// reset discards changes that were not flushed.
+func (r *richlist) reset() {
+	clear(r.changedWaves)
+	clear(r.changedAssets)
+}
+
+// updateRichlist applies balance changes flushed with the state moved from height from to height to
+// to already built indexes.
+func (s *balances) updateRichlist(from, to proto.Height) error {
+	r := s.richlist
+	defer r.reset()
+	r.wavesJournal.record(from, to, r.changedWaves)
+	r.assetsJournal.record(from, to, r.changedAssets)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return s.updateRankings(r.changedWaves, r.changedAssets)
+}
+
+// rollbackRichlist reads again the balances changed by the blocks above the height.
+func (s *balances) rollbackRichlist(height proto.Height) error {
+	r := s.richlist
+	r.reset()
+	waves, wOk := r.wavesJournal.rollback(height)
+	assets, aOk := r.assetsJournal.rollback(height)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !wOk || !aOk {
+		r.waves = nil
+		clear(r.assets)
+		return nil
+	}
+	return s.updateRankings(waves, assets)
+}
+
+func (s *balances) updateRankings(waves map[proto.AddressID]struct{}, assets map[assetBalanceKey]struct{}) error {
+	r := s.richlist
+	if r.waves != nil {
+		for addr := range waves {
+			profile, err := s.wavesBalance(addr)
+			if err != nil {
+				return err
+			}
+			r.waves.set(addr, profile.balance)
+		}
+	}
+	for key := range assets {
+		rk, ok := r.assets[key.asset]
+		if !ok {
+			continue
+		}
+		balance, err := s.assetBalance(key.address, key.asset)
+		if err != nil {
+			return err
+		}
+		rk.set(key.address, balance)
+	}
+	return nil
+}
+
+// wavesRichlist returns up to limit addresses with the largest stored Waves balances.
+func (s *balances) wavesRichlist(limit uint64) ([]richlistEntry, error) {
+	r := s.richlist
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.waves == nil {
+		if !r.builds.Allow() {
+			return nil, ErrIndexBuildLimited
+		}
+		rk := newRanking()
+		err := s.iterateTopEntries(wavesBalance, func(key, data []byte) error {
+			var k wavesBalanceKey
+			if err := k.unmarshal(key); err != nil {
+				return err
+			}
+			var rec wavesBalanceRecord
+			if err := rec.unmarshalBinary(data); err != nil {
+				return err
+			}
+			rk.set(k.address, rec.balance)
+			return nil
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to build Waves richlist")
+		}
+		r.waves = rk
+	}
+	return r.waves.top(limit), nil
+}
+
+// assetRichlist returns up to limit addresses with the largest stored balances of the asset.
+func (s *balances) assetRichlist(asset proto.AssetID, limit uint64) ([]richlistEntry, error) {
+	r := s.richlist
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rk, ok := r.assets[asset]
+	if !ok {
+		if !r.builds.Allow() {
+			return nil, ErrIndexBuildLimited
+		}
+		rk = newRanking()
+		err := s.iterateTopEntries(assetBalance, func(key, data []byte) error {
+			var k assetBalanceKey
+			if err := k.unmarshal(key); err != nil {
+				return err
+			}
+			if k.asset != asset {
+				return nil
+			}
+			var rec assetBalanceRecord
+			if err := rec.unmarshalBinary(data); err != nil {
+				return err
+			}
+			rk.set(k.address, rec.balance)
+			return nil
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to build asset richlist")
+		}
+		r.evictAssetRanking()
+		r.assets[asset] = rk
+	}
+	r.uses++
+	rk.used = r.uses
+	return rk.top(limit), nil
+}
+
+// evictAssetRanking drops the least recently used asset ranking if there is no room for a new one.
+func (r *richlist) evictAssetRanking() {
+	if len(r.assets) < maxRichlistAssets {
+		return
+	}
+	var (
+		lru  proto.AssetID
+		used uint64 = math.MaxUint64
+	)
+	for asset, rk := range r.assets {
+		if rk.used < used {
+			lru, used = asset, rk.used
+		}
+	}
+	delete(r.assets, lru)
+}
+
+func (s *balances) iterateTopEntries(entity blockchainEntity, fn func(key, data []byte) error) error {
+	iter, err := s.hs.newTopEntryIterator(entity)
+	if err != nil {
+		return err
+	}
+	defer iter.Release()
+	for iter.Next() {
+		if fErr := fn(keyvalue.SafeKey(iter), keyvalue.SafeValue(iter)); fErr != nil {
+			return fErr
+		}
+	}
+	return iter.Error()
+}
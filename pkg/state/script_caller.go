@@ -149,7 +149,7 @@ func (a *scriptCaller) callAccountScriptWithTx(tx proto.Transaction, params *app
 		return errors.Wrapf(err, "account script on transaction '%s' failed with error", base58.Encode(id))
 	}
 	if !r.Result() {
-		return errs.NewTransactionNotAllowedByScript("script failed", id)
+		return errs.NewTransactionNotAllowedByScript("script failed", nil)
 	}
 	// Increase complexity.
 	if params.rideV5Activated { // After activation of RideV5 add actual complexity
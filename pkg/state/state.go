@@ -436,6 +436,14 @@ type stateManager struct {
 	newBlocks *newBlocks
 
 	enableLightNode bool
+
+	// Parameters of the database used to create backups, mutex to allow only one backup at a time and guard
+	// preserving the data of block storage files captured for the backup.
+	dbParams    keyvalue.KeyValParams
+	backupMu    sync.Mutex
+	backupGuard backupGuard
+
+	recentBlocks *recentBlocks
 }
 
 func initDatabase(
@@ -472,10 +480,13 @@ func initDatabase(
 			}
 		}
 	}()
+	if mErr := migrateStateDB(db, sdb, params); mErr != nil {
+		return nil, nil, nil, false, wrapErr(stateerr.IncompatibilityError, mErr)
+	}
 	if cErr := checkCompatibility(sdb, params); cErr != nil {
 		return nil, nil, nil, false, wrapErr(stateerr.IncompatibilityError, cErr)
 	}
-	handledAmend, err := handleAmendFlag(sdb, amend)
+	handledAmend, err := handleAmendFlag(sdb, amend && !params.DbParams.ReadOnly)
 	if err != nil {
 		return nil, nil, nil, false, wrapErr(stateerr.Other, errors.Wrap(err, "failed to handle amend flag"))
 	}
@@ -524,14 +535,18 @@ func newStateManager(
 	if err := validateSettings(settings); err != nil {
 		return nil, err
 	}
-	if _, err := os.Stat(dataDir); errors.Is(err, fs.ErrNotExist) {
+	if params.DbParams.ReadOnly {
+		if _, err := os.Stat(dataDir); err != nil {
+			return nil, wrapErr(stateerr.Other, errors.Wrap(err, "state directory is not accessible in read-only mode"))
+		}
+	} else if _, err := os.Stat(dataDir); errors.Is(err, fs.ErrNotExist) {
 		if dirErr := os.Mkdir(dataDir, 0750); dirErr != nil {
 			wErr := errors.Wrap(dirErr, "failed to create state directory")
 			return nil, wrapErr(stateerr.Other, wErr)
 		}
 	}
 	blockStorageDir := filepath.Join(dataDir, blocksStorDir)
-	if _, err := os.Stat(blockStorageDir); errors.Is(err, fs.ErrNotExist) {
+	if _, err := os.Stat(blockStorageDir); errors.Is(err, fs.ErrNotExist) && !params.DbParams.ReadOnly {
 		if dirErr := os.Mkdir(blockStorageDir, 0750); dirErr != nil {
 			return nil, wrapErr(stateerr.Other, errors.Wrap(dirErr, "failed to create blocks directory"))
 		}
@@ -600,6 +615,8 @@ func newStateManager(
 		verificationGoroutinesNum: params.VerificationGoroutinesNum,
 		newBlocks:                 newNewBlocks(rw, settings),
 		enableLightNode:           enableLightNode,
+		dbParams:                  params.DbParams,
+		recentBlocks:              newRecentBlocks(params.RecentBlocksCacheSize, params.RecentBlocksCacheBytes),
 	}
 	// Set fields which depend on state.
 	// Consensus validator is needed to check block headers.
@@ -610,6 +627,8 @@ func newStateManager(
 	}
 	state.appender = appender
 	state.cv = consensus.NewValidator(state, settings, params.Time)
+	rw.backupGuard = &state.backupGuard
+	atx.backupGuard = &state.backupGuard
 
 	height, err := state.Height()
 	if err != nil {
@@ -864,6 +883,9 @@ func (s *stateManager) BlockRewards(generator proto.WavesAddress, height proto.H
 }
 
 func (s *stateManager) Header(blockID proto.BlockID) (*proto.BlockHeader, error) {
+	if header, ok := s.recentBlocks.header(blockID); ok {
+		return header, nil
+	}
 	header, err := s.rw.readBlockHeader(blockID)
 	if err != nil {
 		return nil, wrapErr(stateerr.RetrievalError, err)
@@ -888,6 +910,9 @@ func (s *stateManager) HeaderByHeight(height uint64) (*proto.BlockHeader, error)
 }
 
 func (s *stateManager) Block(blockID proto.BlockID) (*proto.Block, error) {
+	if block, ok := s.recentBlocks.block(blockID); ok {
+		return block, nil
+	}
 	block, err := s.rw.readBlock(blockID)
 	if err != nil {
 		return nil, wrapErr(stateerr.RetrievalError, err)
@@ -1061,6 +1086,44 @@ func (s *stateManager) NewestMinerGeneratingBalance(header *proto.BlockHeader, h
 	return minerGB, nil
 }
 
+func (s *stateManager) WavesBalanceHistory(account proto.Recipient) ([]proto.BalanceAtHeight, error) {
+	addr, err := s.recipientToAddress(account)
+	if err != nil {
+		return nil, errs.Extend(err, "failed convert recipient to address")
+	}
+	history, err := s.stor.balances.wavesBalanceHistory(addr.ID())
+	if err != nil {
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	return history, nil
+}
+
+func (s *stateManager) EffectiveBalancesAtHeight(
+	account proto.Recipient, height proto.Height,
+) (*proto.EffectiveBalances, error) {
+	// Generating balance is calculated over the range of blocks, all of them must be in the retained history.
+	startHeight, _ := s.settings.RangeForGeneratingBalanceByHeight(height)
+	if err := s.checkHeightInRetainedHistory(startHeight); err != nil {
+		return nil, err
+	}
+	if err := s.checkHeightInRetainedHistory(height); err != nil {
+		return nil, err
+	}
+	addr, err := s.recipientToAddress(account)
+	if err != nil {
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	effective, err := s.stor.balances.minEffectiveBalanceInRange(addr.ID(), height, height)
+	if err != nil {
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	generating, err := s.stor.balances.generatingBalance(addr.ID(), height)
+	if err != nil {
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	return &proto.EffectiveBalances{Height: height, Effective: effective, Generating: generating}, nil
+}
+
 func (s *stateManager) FullWavesBalance(account proto.Recipient) (*proto.FullWavesBalance, error) {
 	addr, err := s.recipientToAddress(account)
 	if err != nil {
@@ -1216,6 +1279,23 @@ func (s *stateManager) AssetBalance(account proto.Recipient, assetID proto.Asset
 	return balance, nil
 }
 
+func (s *stateManager) BalanceAtHeight(
+	account proto.Recipient, asset proto.OptionalAsset, height proto.Height,
+) (uint64, error) {
+	if err := s.checkHeightInRetainedHistory(height); err != nil {
+		return 0, err
+	}
+	addr, err := s.recipientToAddress(account)
+	if err != nil {
+		return 0, wrapErr(stateerr.RetrievalError, err)
+	}
+	balance, err := s.stor.balances.balanceAtHeight(addr.ID(), asset, height)
+	if err != nil {
+		return 0, wrapErr(stateerr.RetrievalError, err)
+	}
+	return balance, nil
+}
+
 func (s *stateManager) WavesAddressesNumber() (uint64, error) {
 	res, err := s.stor.balances.wavesAddressesNumber()
 	if err != nil {
@@ -1224,6 +1304,56 @@ func (s *stateManager) WavesAddressesNumber() (uint64, error) {
 	return res, nil
 }
 
+func (s *stateManager) BalancesAtHeight(height proto.Height, fn func(BalanceRecord) error) error {
+	if err := s.checkHeightInRetainedHistory(height); err != nil {
+		return err
+	}
+	if err := s.stor.balances.balancesAtHeight(height, fn); err != nil {
+		return wrapErr(stateerr.RetrievalError, err)
+	}
+	return nil
+}
+
+func (s *stateManager) Richlist(asset proto.OptionalAsset, limit uint64) ([]BalanceRecord, error) {
+	var (
+		entries []richlistEntry
+		err     error
+	)
+	if asset.Present {
+		entries, err = s.stor.balances.assetRichlist(proto.AssetIDFromDigest(asset.ID), limit)
+	} else {
+		entries, err = s.stor.balances.wavesRichlist(limit)
+	}
+	if err != nil {
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	res := make([]BalanceRecord, len(entries))
+	for i, e := range entries {
+		addr, aErr := e.address.ToWavesAddress(s.settings.AddressSchemeCharacter)
+		if aErr != nil {
+			return nil, wrapErr(stateerr.RetrievalError, aErr)
+		}
+		res[i] = BalanceRecord{Address: addr, Asset: asset, Balance: e.balance}
+	}
+	return res, nil
+}
+
+func (s *stateManager) SearchAssets(query string, limit uint64, afterAssetID *proto.AssetID) ([]crypto.Digest, error) {
+	ids, err := s.stor.assets.searchByName(query, limit, afterAssetID)
+	if err != nil {
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	res := make([]crypto.Digest, len(ids))
+	for i, id := range ids {
+		info, iErr := s.stor.assets.constInfo(id)
+		if iErr != nil {
+			return nil, wrapErr(stateerr.RetrievalError, iErr)
+		}
+		res[i] = proto.ReconstructDigest(id, info.Tail)
+	}
+	return res, nil
+}
+
 func (s *stateManager) topBlock() (*proto.Block, error) {
 	height, err := s.Height()
 	if err != nil {
@@ -1390,9 +1520,23 @@ func (s *stateManager) flush() error {
 	if err := s.atx.flush(); err != nil {
 		return err
 	}
+	from, err := s.stateDB.getHeight()
+	if err != nil {
+		return err
+	}
 	if err := s.stateDB.flush(); err != nil {
 		return err
 	}
+	to, err := s.stateDB.getHeight()
+	if err != nil {
+		return err
+	}
+	if err := s.stor.balances.updateRichlist(from, to); err != nil {
+		return errors.Wrap(err, "failed to update richlist")
+	}
+	if err := s.stor.assets.updateNamesIndex(from, to); err != nil {
+		return errors.Wrap(err, "failed to update asset names index")
+	}
 	return nil
 }
 
@@ -1885,9 +2029,11 @@ func (s *stateManager) addBlocks() (_ *proto.Block, retErr error) { //nolint:non
 
 	// Launch verifier that checks signatures of blocks and transactions.
 	chans := launchVerifier(ctx, s.verificationGoroutinesNum, s.settings.AddressSchemeCharacter)
+	deferred := new(deferredBlockSignatures)
 
 	var (
-		ids []proto.BlockID
+		ids    []proto.BlockID
+		blocks []*proto.Block
 	)
 	pos := 0
 	for s.newBlocks.next() {
@@ -1897,7 +2043,7 @@ func (s *stateManager) addBlocks() (_ *proto.Block, retErr error) { //nolint:non
 			return nil, wrapErr(stateerr.DeserializationError, errCurBlock)
 		}
 
-		pErr := s.processBlockInPack(block, optionalSnapshot, lastAppliedBlock, blockchainCurHeight, chans)
+		pErr := s.processBlockInPack(block, optionalSnapshot, lastAppliedBlock, blockchainCurHeight, chans, deferred)
 		if pErr != nil {
 			return nil, pErr
 		}
@@ -1906,8 +2052,15 @@ func (s *stateManager) addBlocks() (_ *proto.Block, retErr error) { //nolint:non
 		headers[pos] = block.BlockHeader
 		pos++
 		ids = append(ids, block.BlockID())
+		blocks = append(blocks, block)
 		lastAppliedBlock = block
 	}
+	// Signatures of blocks which are not followed by the checkpoint in the pack are verified.
+	for _, b := range deferred.blocks {
+		if err := chans.trySend(&verifyTask{taskType: verifyBlockSignature, block: b}); err != nil {
+			return nil, wrapErr(stateerr.ValidationError, err)
+		}
+	}
 	// Tasks chan can now be closed, since all the blocks and transactions have been already sent for verification.
 	// wait for all verifier goroutines
 	if verifyError := chans.closeAndWait(); verifyError != nil {
@@ -1926,6 +2079,9 @@ func (s *stateManager) addBlocks() (_ *proto.Block, retErr error) { //nolint:non
 	if fErr := s.flush(); fErr != nil {
 		return nil, wrapErr(stateerr.ModificationError, fErr)
 	}
+	for i, b := range blocks {
+		s.recentBlocks.add(b, height+uint64(i)+1)
+	}
 	zap.S().Infof(
 		"Height: %d; Block ID: %s, GenSig: %s, ts: %d",
 		height+uint64(blocksNumber),
@@ -1942,8 +2098,9 @@ func (s *stateManager) processBlockInPack(
 	lastAppliedBlock *proto.Block,
 	blockchainCurHeight uint64,
 	chans *verifierChans,
+	deferred *deferredBlockSignatures,
 ) error {
-	if badErr := s.beforeAddingBlock(block, lastAppliedBlock, blockchainCurHeight, chans); badErr != nil {
+	if badErr := s.beforeAddingBlock(block, lastAppliedBlock, blockchainCurHeight, chans, deferred); badErr != nil {
 		return badErr
 	}
 	sh, errSh := s.stor.stateHashes.newestSnapshotStateHash(blockchainCurHeight)
@@ -1992,7 +2149,11 @@ func (s *stateManager) beforeAddingBlock(
 	block, lastAppliedBlock *proto.Block,
 	blockchainCurHeight proto.Height,
 	chans *verifierChans,
+	deferred *deferredBlockSignatures,
 ) error {
+	if cpErr := s.checkCheckpoint(block, blockchainCurHeight+1); cpErr != nil {
+		return wrapErr(stateerr.ValidationError, cpErr)
+	}
 	// Assign unique block number for this block ID, add this number to the list of valid blocks.
 	if blErr := s.stateDB.addBlock(block.BlockID()); blErr != nil {
 		return wrapErr(stateerr.ModificationError, blErr)
@@ -2006,10 +2167,18 @@ func (s *stateManager) beforeAddingBlock(
 		return vhErr
 	}
 	// Send block for signature verification, which works in separate goroutine.
+	skipSig := s.belowCheckpoint(block, blockchainCurHeight+1)
+	if skipSig {
+		deferred.add(block)
+	}
+	if _, ok := s.settings.Checkpoints.At(blockchainCurHeight + 1); ok {
+		deferred.confirm() // the block is checked to be the one of the checkpoint
+	}
 	task := &verifyTask{
-		taskType: verifyBlock,
-		parentID: lastAppliedBlock.BlockID(),
-		block:    block,
+		taskType:     verifyBlock,
+		parentID:     lastAppliedBlock.BlockID(),
+		block:        block,
+		skipBlockSig: skipSig,
 	}
 	if err := chans.trySend(task); err != nil {
 		return err
@@ -2022,6 +2191,40 @@ func (s *stateManager) beforeAddingBlock(
 	return s.stor.hitSources.appendBlockHitSource(block, blockchainCurHeight+1, hs)
 }
 
+// checkCheckpoint returns an error if there is the checkpoint with another block at the height.
+func (s *stateManager) checkCheckpoint(block *proto.Block, height proto.Height) error {
+	id, ok := s.settings.Checkpoints.At(height)
+	if !ok || id == block.BlockID() {
+		return nil
+	}
+	return errors.Errorf("block '%s' at height %d contradicts checkpoint '%s'",
+		block.BlockID().String(), height, id.String())
+}
+
+// belowCheckpoint reports whether the verification of the block signature can be deferred until the checkpoint.
+// The ID of protobuf block is the hash of its header, so the headers below the checkpoint are fixed by the chain of
+// parent IDs leading to it. The ID of legacy block is its signature, the signature of such blocks is always verified.
+func (s *stateManager) belowCheckpoint(block *proto.Block, height proto.Height) bool {
+	return block.Version >= proto.ProtobufBlockVersion && height < s.settings.Checkpoints.LastHeight()
+}
+
+// deferredBlockSignatures are the blocks of the pack below the last checkpoint which signatures are not verified yet.
+// Once the block of the checkpoint is reached in the same pack, its trusted ID fixes the headers of all preceding
+// blocks through the chain of parent IDs, which is checked by the verifier, so their signatures are not verified.
+// Signatures of the blocks left at the end of the pack are verified as usual.
+type deferredBlockSignatures struct {
+	blocks []*proto.Block
+}
+
+func (d *deferredBlockSignatures) add(block *proto.Block) {
+	d.blocks = append(d.blocks, block)
+}
+
+func (d *deferredBlockSignatures) confirm() {
+	clear(d.blocks)
+	d.blocks = d.blocks[:0]
+}
+
 func (s *stateManager) checkRollbackHeight(height uint64) error {
 	maxHeight, err := s.Height()
 	if err != nil {
@@ -2034,6 +2237,12 @@ func (s *stateManager) checkRollbackHeight(height uint64) error {
 	if height < minRollbackHeight || height > maxHeight {
 		return errors.Errorf("invalid height; valid range is: [%d, %d]", minRollbackHeight, maxHeight)
 	}
+	for _, cp := range s.settings.Checkpoints {
+		if height < cp.Height && cp.Height <= maxHeight {
+			return errors.Errorf("rollback to height %d removes the block of checkpoint at height %d",
+				height, cp.Height)
+		}
+	}
 	return nil
 }
 
@@ -2073,10 +2282,21 @@ func (s *stateManager) rollbackToImpl(removalEdge proto.BlockID) error {
 	}
 	// Clear features cache
 	s.stor.features.clearCache()
-
+	// Drop rolled back blocks from the recent blocks cache.
+	height, hErr := s.Height()
+	if hErr != nil {
+		zap.S().Fatalf("Failed to get height after rollback: %v", hErr)
+	}
+	s.recentBlocks.removeAbove(height)
 	if err := s.stor.flush(); err != nil {
 		zap.S().Fatalf("Failed to flush history storage cache after rollback: %v", err)
 	}
+	if err := s.stor.balances.rollbackRichlist(height); err != nil {
+		zap.S().Fatalf("Failed to update richlist after rollback: %v", err)
+	}
+	if err := s.stor.assets.rollbackNamesIndex(height); err != nil {
+		zap.S().Fatalf("Failed to update asset names index after rollback: %v", err)
+	}
 
 	if err := s.loadLastBlock(); err != nil {
 		zap.S().Fatalf("Failed to load last block after rollback: %v", err)
@@ -2377,6 +2597,16 @@ func (s *stateManager) RetrieveEntries(account proto.Recipient) ([]proto.DataEnt
 	return entries, nil
 }
 
+func (s *stateManager) DataEntriesAtHeight(height proto.Height, fn func(DataEntryRecord) error) error {
+	if err := s.checkHeightInRetainedHistory(height); err != nil {
+		return err
+	}
+	if err := s.stor.accountsDataStor.entriesAtHeight(s.settings.AddressSchemeCharacter, height, fn); err != nil {
+		return wrapErr(stateerr.RetrievalError, err)
+	}
+	return nil
+}
+
 // IsStateUntouched returns true if the account has no data entries.
 // ATTENTION: Despite the name, this function operates on the newest state. The name is kept for compatibility.
 func (s *stateManager) IsStateUntouched(account proto.Recipient) (bool, error) {
@@ -2403,6 +2633,43 @@ func (s *stateManager) RetrieveEntry(account proto.Recipient, key string) (proto
 	return entry, nil
 }
 
+func (s *stateManager) RetrieveEntryAtHeight(
+	account proto.Recipient, key string, height proto.Height,
+) (proto.DataEntry, error) {
+	if err := s.checkHeightInRetainedHistory(height); err != nil {
+		return nil, err
+	}
+	addr, err := s.recipientToAddress(account)
+	if err != nil {
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	entry, err := s.stor.accountsDataStor.retrieveEntryAtHeight(addr, key, height)
+	if err != nil {
+		if stateerr.IsNotFound(err) {
+			return nil, wrapErr(stateerr.NotFoundError, err)
+		}
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	return entry, nil
+}
+
+// checkHeightInRetainedHistory checks that state keeps the history of changes at the height.
+func (s *stateManager) checkHeightInRetainedHistory(height proto.Height) error {
+	minHeight, err := s.stateDB.getRollbackMinHeight()
+	if err != nil {
+		return wrapErr(stateerr.RetrievalError, err)
+	}
+	maxHeight, err := s.Height()
+	if err != nil {
+		return wrapErr(stateerr.RetrievalError, err)
+	}
+	if height < minHeight || height > maxHeight {
+		return wrapErr(stateerr.InvalidInputError,
+			errors.Errorf("height %d is out of retained history range [%d, %d]", height, minHeight, maxHeight))
+	}
+	return nil
+}
+
 func (s *stateManager) RetrieveNewestIntegerEntry(account proto.Recipient, key string) (*proto.IntegerDataEntry, error) {
 	addr, err := s.NewestRecipientToAddress(account)
 	if err != nil {
@@ -2566,6 +2833,61 @@ func (s *stateManager) NewAddrTransactionsIterator(addr proto.Address) (Transact
 	return iter, nil
 }
 
+func (s *stateManager) ScriptHistoryByAccount(account proto.Recipient) ([]proto.ScriptChange, error) {
+	addr, err := s.recipientToAddress(account)
+	if err != nil {
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	iter, err := s.NewAddrTransactionsIterator(addr)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Release()
+	changes := make([]proto.ScriptChange, 0)
+	for iter.Next() {
+		tx, status, txErr := iter.Transaction()
+		if txErr != nil {
+			return nil, wrapErr(stateerr.RetrievalError, txErr)
+		}
+		ss, ok := tx.(*proto.SetScriptWithProofs)
+		if !ok || status != proto.TransactionSucceeded {
+			continue
+		}
+		sender, aErr := proto.NewAddressFromPublicKey(s.settings.AddressSchemeCharacter, ss.SenderPK)
+		if aErr != nil {
+			return nil, wrapErr(stateerr.Other, aErr)
+		}
+		if sender != addr {
+			continue
+		}
+		id, idErr := ss.GetID(s.settings.AddressSchemeCharacter)
+		if idErr != nil {
+			return nil, wrapErr(stateerr.Other, idErr)
+		}
+		txID, dErr := crypto.NewDigestFromBytes(id)
+		if dErr != nil {
+			return nil, wrapErr(stateerr.Other, dErr)
+		}
+		height, _, hErr := s.rw.transactionHeightByID(id)
+		if hErr != nil {
+			return nil, wrapErr(stateerr.RetrievalError, hErr)
+		}
+		change := proto.ScriptChange{Height: height, TransactionID: txID}
+		if !ss.Script.IsEmpty() {
+			hash, hErr := crypto.FastHash(ss.Script)
+			if hErr != nil {
+				return nil, wrapErr(stateerr.Other, hErr)
+			}
+			change.ScriptHash = &hash
+		}
+		changes = append(changes, change)
+	}
+	if err := iter.Error(); err != nil {
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	return changes, nil
+}
+
 func (s *stateManager) NewestAssetIsSponsored(asset crypto.Digest) (bool, error) {
 	assetID := proto.AssetIDFromDigest(asset)
 	sponsored, err := s.stor.sponsoredAssets.newestIsSponsored(assetID)
@@ -2739,6 +3061,37 @@ func (s *stateManager) AssetInfo(assetID proto.AssetID) (*proto.AssetInfo, error
 	}, nil
 }
 
+func (s *stateManager) OrderFilled(orderID []byte) (uint64, uint64, error) {
+	amount, fee, err := s.stor.ordersVolumes.filled(orderID)
+	if err != nil {
+		return 0, 0, wrapErr(stateerr.RetrievalError, err)
+	}
+	return amount, fee, nil
+}
+
+func (s *stateManager) SponsorshipStatus(assetID proto.AssetID) (*proto.SponsorshipStatus, error) {
+	ai, err := s.AssetInfo(assetID)
+	if err != nil {
+		return nil, err
+	}
+	res := &proto.SponsorshipStatus{AssetID: ai.ID, Sponsor: ai.Issuer}
+	if !ai.Sponsored {
+		return res, nil
+	}
+	assetCost, err := s.stor.sponsoredAssets.assetCost(assetID)
+	if err != nil {
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	balance, err := s.FullWavesBalance(proto.NewRecipientFromAddress(ai.Issuer))
+	if err != nil {
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	res.IsSponsored = true
+	res.MinSponsoredFee = assetCost
+	res.SponsorBalance = balance.Available
+	return res, nil
+}
+
 func (s *stateManager) FullAssetInfo(assetID proto.AssetID) (*proto.FullAssetInfo, error) {
 	ai, err := s.AssetInfo(assetID)
 	if err != nil {
@@ -2927,6 +3280,31 @@ func (s *stateManager) IsActiveLeasing(leaseID crypto.Digest) (bool, error) {
 	return isActive, nil
 }
 
+func (s *stateManager) LeaseInfo(leaseID crypto.Digest) (*proto.LeaseDetails, error) {
+	l, err := s.stor.leases.leasingInfo(leaseID)
+	if err != nil {
+		if stateerr.IsNotFound(err) {
+			return nil, wrapErr(stateerr.NotFoundError, err)
+		}
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	d, err := l.details(s.settings.AddressSchemeCharacter, leaseID)
+	if err != nil {
+		return nil, wrapErr(stateerr.Other, err)
+	}
+	return d, nil
+}
+
+func (s *stateManager) LeasesAtHeight(height proto.Height, fn func(*proto.LeaseDetails) error) error {
+	if err := s.checkHeightInRetainedHistory(height); err != nil {
+		return err
+	}
+	if err := s.stor.leases.leasesAtHeight(s.settings.AddressSchemeCharacter, height, fn); err != nil {
+		return wrapErr(stateerr.RetrievalError, err)
+	}
+	return nil
+}
+
 func (s *stateManager) InvokeResultByID(invokeID crypto.Digest) (*proto.ScriptResult, error) {
 	hasData, err := s.storesExtendedApiData()
 	if err != nil {
@@ -1019,3 +1019,33 @@ func TestIsStateUntouched(t *testing.T) {
 		})
 	})
 }
+
+func TestCheckpoints(t *testing.T) {
+	id := proto.NewBlockIDFromDigest(crypto.Digest{1})
+	s := &stateManager{settings: &settings.BlockchainSettings{
+		Checkpoints: settings.Checkpoints{{Height: 10, BlockID: id}},
+	}}
+	trusted := &proto.Block{BlockHeader: proto.BlockHeader{Version: proto.ProtobufBlockVersion, ID: id}}
+	other := &proto.Block{BlockHeader: proto.BlockHeader{
+		Version: proto.ProtobufBlockVersion, ID: proto.NewBlockIDFromDigest(crypto.Digest{2}),
+	}}
+	legacy := &proto.Block{BlockHeader: proto.BlockHeader{Version: proto.NgBlockVersion}}
+
+	assert.NoError(t, s.checkCheckpoint(trusted, 10))
+	assert.Error(t, s.checkCheckpoint(other, 10))
+	assert.NoError(t, s.checkCheckpoint(other, 9))
+
+	assert.True(t, s.belowCheckpoint(other, 9))
+	assert.False(t, s.belowCheckpoint(trusted, 10))
+	assert.False(t, s.belowCheckpoint(legacy, 9), "signatures of legacy blocks are always verified")
+}
+
+func TestDeferredBlockSignatures(t *testing.T) {
+	var d deferredBlockSignatures
+	b1, b2 := &proto.Block{}, &proto.Block{}
+	d.add(b1)
+	d.confirm()
+	assert.Empty(t, d.blocks, "blocks followed by the checkpoint must not be verified")
+	d.add(b2)
+	assert.Equal(t, []*proto.Block{b2}, d.blocks, "blocks not followed by the checkpoint must be verified")
+}
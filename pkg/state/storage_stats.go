@@ -0,0 +1,125 @@
+package state
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/wavesplatform/gowaves/pkg/keyvalue"
+	"github.com/wavesplatform/gowaves/pkg/state/stateerr"
+)
+
+const storageMetricsNamespace = "storage"
+
+type statsProvider interface {
+	Stats() (*keyvalue.Stats, error)
+}
+
+// StorageStats returns the statistics of the state database.
+func (s *stateManager) StorageStats() (*keyvalue.Stats, error) {
+	db, ok := s.stateDB.db.(statsProvider)
+	if !ok {
+		return nil, wrapErr(stateerr.Other, errors.New("state database doesn't provide statistics"))
+	}
+	st, err := db.Stats()
+	if err != nil {
+		return nil, wrapErr(stateerr.RetrievalError, err)
+	}
+	return st, nil
+}
+
+func newStorageDesc(name, help string, labels ...string) *prometheus.Desc {
+	return prometheus.NewDesc(prometheus.BuildFQName(storageMetricsNamespace, "", name), help, labels, nil)
+}
+
+var (
+	storageLevelTablesDesc = newStorageDesc("level_tables", "Number of tables on the level.", "level")
+	storageLevelSizeDesc   = newStorageDesc("level_size_bytes", "Size of tables on the level.", "level")
+	storageLevelReadDesc   = newStorageDesc("level_read_bytes_total",
+		"Bytes read by compactions of the level.", "level")
+	storageLevelWriteDesc = newStorageDesc("level_write_bytes_total",
+		"Bytes written by compactions of the level.", "level")
+	storageLevelDurationDesc = newStorageDesc("level_compaction_seconds_total",
+		"Time spent on compactions of the level.", "level")
+	storageCompactionsDesc  = newStorageDesc("compactions_total", "Counter of compactions by kinds.", "kind")
+	storageWriteDelaysDesc  = newStorageDesc("write_delays_total", "Counter of writes delayed by compactions.")
+	storageWriteDelayDesc   = newStorageDesc("write_delay_seconds_total", "Time writes were delayed by compactions.")
+	storageWritePausedDesc  = newStorageDesc("write_paused", "Writes are paused until compactions complete.")
+	storageIOReadDesc       = newStorageDesc("io_read_bytes_total", "Bytes read from database files.")
+	storageIOWriteDesc      = newStorageDesc("io_write_bytes_total", "Bytes written to database files.")
+	storageOpenedTablesDesc = newStorageDesc("opened_tables", "Number of opened table files.")
+	storageSnapshotsDesc    = newStorageDesc("alive_snapshots", "Number of unreleased database snapshots.")
+	storageIteratorsDesc    = newStorageDesc("alive_iterators", "Number of unreleased database iterators.")
+	storageBlockCacheDesc   = newStorageDesc("block_cache_bytes", "Size of the database block cache.")
+	storageCacheEntriesDesc = newStorageDesc("cache_entries", "Number of entries in the state cache.")
+	storageCacheHitsDesc    = newStorageDesc("cache_hits_total", "Counter of state cache hits.")
+	storageCacheMissesDesc  = newStorageDesc("cache_misses_total", "Counter of state cache misses.")
+	storageCacheEvictsDesc  = newStorageDesc("cache_evacuations_total", "Counter of entries evicted from state cache.")
+)
+
+type storageCollector struct {
+	s StateModifier
+}
+
+// NewStorageCollector returns the Prometheus collector of the state database statistics.
+// Statistics are requested from the state on every collection.
+func NewStorageCollector(s StateModifier) prometheus.Collector {
+	return &storageCollector{s: s}
+}
+
+func (c *storageCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{
+		storageLevelTablesDesc, storageLevelSizeDesc, storageLevelReadDesc, storageLevelWriteDesc,
+		storageLevelDurationDesc, storageCompactionsDesc, storageWriteDelaysDesc, storageWriteDelayDesc,
+		storageWritePausedDesc, storageIOReadDesc, storageIOWriteDesc, storageOpenedTablesDesc, storageSnapshotsDesc,
+		storageIteratorsDesc, storageBlockCacheDesc, storageCacheEntriesDesc, storageCacheHitsDesc,
+		storageCacheMissesDesc, storageCacheEvictsDesc,
+	} {
+		ch <- d
+	}
+}
+
+func (c *storageCollector) Collect(ch chan<- prometheus.Metric) {
+	st, err := c.s.StorageStats()
+	if err != nil {
+		zap.S().Warnf("Failed to collect storage statistics: %v", err)
+		return
+	}
+	gauge := func(d *prometheus.Desc, v float64, labels ...string) {
+		ch <- prometheus.MustNewConstMetric(d, prometheus.GaugeValue, v, labels...)
+	}
+	counter := func(d *prometheus.Desc, v float64, labels ...string) {
+		ch <- prometheus.MustNewConstMetric(d, prometheus.CounterValue, v, labels...)
+	}
+	for _, l := range st.Levels {
+		level := strconv.Itoa(l.Level)
+		gauge(storageLevelTablesDesc, float64(l.Tables), level)
+		gauge(storageLevelSizeDesc, float64(l.Size), level)
+		counter(storageLevelReadDesc, float64(l.Read), level)
+		counter(storageLevelWriteDesc, float64(l.Write), level)
+		counter(storageLevelDurationDesc, l.CompactionDuration.Seconds(), level)
+	}
+	counter(storageCompactionsDesc, float64(st.MemCompactions), "memory")
+	counter(storageCompactionsDesc, float64(st.Level0Compactions), "level0")
+	counter(storageCompactionsDesc, float64(st.NonLevel0Compactions), "non_level0")
+	counter(storageCompactionsDesc, float64(st.SeekCompactions), "seek")
+	counter(storageWriteDelaysDesc, float64(st.WriteDelayCount))
+	counter(storageWriteDelayDesc, st.WriteDelayDuration.Seconds())
+	var paused float64
+	if st.WritePaused {
+		paused = 1
+	}
+	gauge(storageWritePausedDesc, paused)
+	counter(storageIOReadDesc, float64(st.IORead))
+	counter(storageIOWriteDesc, float64(st.IOWrite))
+	gauge(storageOpenedTablesDesc, float64(st.OpenedTables))
+	gauge(storageSnapshotsDesc, float64(st.AliveSnapshots))
+	gauge(storageIteratorsDesc, float64(st.AliveIterators))
+	gauge(storageBlockCacheDesc, float64(st.BlockCacheSize))
+	gauge(storageCacheEntriesDesc, float64(st.Cache.Entries))
+	counter(storageCacheHitsDesc, float64(st.Cache.Hits))
+	counter(storageCacheMissesDesc, float64(st.Cache.Misses))
+	counter(storageCacheEvictsDesc, float64(st.Cache.Evacuations))
+}
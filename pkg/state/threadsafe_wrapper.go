@@ -1,11 +1,13 @@
 package state
 
 import (
+	"io"
 	"math/big"
 	"sync"
 	"sync/atomic"
 
 	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/keyvalue"
 	"github.com/wavesplatform/gowaves/pkg/proto"
 	"github.com/wavesplatform/gowaves/pkg/ride/ast"
 	"github.com/wavesplatform/gowaves/pkg/settings"
@@ -86,12 +88,26 @@ func (a *ThreadSafeReadWrapper) FullWavesBalance(account proto.Recipient) (*prot
 	return a.s.FullWavesBalance(account)
 }
 
+func (a *ThreadSafeReadWrapper) WavesBalanceHistory(account proto.Recipient) ([]proto.BalanceAtHeight, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.s.WavesBalanceHistory(account)
+}
+
 func (a *ThreadSafeReadWrapper) GeneratingBalance(account proto.Recipient, height proto.Height) (uint64, error) {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
 	return a.s.GeneratingBalance(account, height)
 }
 
+func (a *ThreadSafeReadWrapper) EffectiveBalancesAtHeight(
+	account proto.Recipient, height proto.Height,
+) (*proto.EffectiveBalances, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.s.EffectiveBalancesAtHeight(account, height)
+}
+
 func (a *ThreadSafeReadWrapper) WavesBalance(account proto.Recipient) (uint64, error) {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
@@ -110,6 +126,18 @@ func (a *ThreadSafeReadWrapper) WavesAddressesNumber() (uint64, error) {
 	return a.s.WavesAddressesNumber()
 }
 
+func (a *ThreadSafeReadWrapper) BalancesAtHeight(height proto.Height, fn func(BalanceRecord) error) error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.s.BalancesAtHeight(height, fn)
+}
+
+func (a *ThreadSafeReadWrapper) Richlist(asset proto.OptionalAsset, limit uint64) ([]BalanceRecord, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.s.Richlist(asset, limit)
+}
+
 func (a *ThreadSafeReadWrapper) ScoreAtHeight(height proto.Height) (*big.Int, error) {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
@@ -212,6 +240,22 @@ func (a *ThreadSafeReadWrapper) RetrieveEntry(account proto.Recipient, key strin
 	return a.s.RetrieveEntry(account, key)
 }
 
+func (a *ThreadSafeReadWrapper) BalanceAtHeight(
+	account proto.Recipient, asset proto.OptionalAsset, height proto.Height,
+) (uint64, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.s.BalanceAtHeight(account, asset, height)
+}
+
+func (a *ThreadSafeReadWrapper) RetrieveEntryAtHeight(
+	account proto.Recipient, key string, height proto.Height,
+) (proto.DataEntry, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.s.RetrieveEntryAtHeight(account, key, height)
+}
+
 func (a *ThreadSafeReadWrapper) RetrieveIntegerEntry(account proto.Recipient, key string) (*proto.IntegerDataEntry, error) {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
@@ -236,6 +280,12 @@ func (a *ThreadSafeReadWrapper) RetrieveBinaryEntry(account proto.Recipient, key
 	return a.s.RetrieveBinaryEntry(account, key)
 }
 
+func (a *ThreadSafeReadWrapper) DataEntriesAtHeight(height proto.Height, fn func(DataEntryRecord) error) error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.s.DataEntriesAtHeight(height, fn)
+}
+
 func (a *ThreadSafeReadWrapper) TransactionByID(id []byte) (proto.Transaction, error) {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
@@ -292,12 +342,32 @@ func (a *ThreadSafeReadWrapper) EnrichedFullAssetInfo(assetID proto.AssetID) (*p
 	return a.s.EnrichedFullAssetInfo(assetID)
 }
 
+func (a *ThreadSafeReadWrapper) OrderFilled(orderID []byte) (uint64, uint64, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.s.OrderFilled(orderID)
+}
+
+func (a *ThreadSafeReadWrapper) SponsorshipStatus(assetID proto.AssetID) (*proto.SponsorshipStatus, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.s.SponsorshipStatus(assetID)
+}
+
 func (a *ThreadSafeReadWrapper) NFTList(account proto.Recipient, limit uint64, afterAssetID *proto.AssetID) ([]*proto.FullAssetInfo, error) {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
 	return a.s.NFTList(account, limit, afterAssetID)
 }
 
+func (a *ThreadSafeReadWrapper) SearchAssets(
+	query string, limit uint64, afterAssetID *proto.AssetID,
+) ([]crypto.Digest, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.s.SearchAssets(query, limit, afterAssetID)
+}
+
 func (a *ThreadSafeReadWrapper) ScriptBasicInfoByAccount(account proto.Recipient) (*proto.ScriptBasicInfo, error) {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
@@ -328,12 +398,30 @@ func (a *ThreadSafeReadWrapper) NewestScriptBytesByAccount(recipient proto.Recip
 	return a.s.NewestScriptBytesByAccount(recipient)
 }
 
+func (a *ThreadSafeReadWrapper) ScriptHistoryByAccount(account proto.Recipient) ([]proto.ScriptChange, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.s.ScriptHistoryByAccount(account)
+}
+
 func (a *ThreadSafeReadWrapper) IsActiveLeasing(leaseID crypto.Digest) (bool, error) {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
 	return a.s.IsActiveLeasing(leaseID)
 }
 
+func (a *ThreadSafeReadWrapper) LeaseInfo(leaseID crypto.Digest) (*proto.LeaseDetails, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.s.LeaseInfo(leaseID)
+}
+
+func (a *ThreadSafeReadWrapper) LeasesAtHeight(height proto.Height, fn func(*proto.LeaseDetails) error) error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.s.LeasesAtHeight(height, fn)
+}
+
 func (a *ThreadSafeReadWrapper) InvokeResultByID(invokeID crypto.Digest) (*proto.ScriptResult, error) {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
@@ -518,6 +606,50 @@ func (a *ThreadSafeWriteWrapper) PersistAddressTransactions() error {
 	return a.s.PersistAddressTransactions()
 }
 
+// Backup blocks state modifications only while the consistent point of the backup is captured.
+// It doesn't use lock() because backups are made concurrently with the state modifying goroutine.
+func (a *ThreadSafeWriteWrapper) Backup(dir string) error {
+	return a.backup(
+		func(b *stateBackup) error { return b.writeDir(dir) },
+		func() error { return a.s.Backup(dir) },
+	)
+}
+
+// BackupTar blocks state modifications only while the consistent point of the backup is captured.
+func (a *ThreadSafeWriteWrapper) BackupTar(w io.Writer) error {
+	return a.backup(
+		func(b *stateBackup) error { return b.writeTar(w) },
+		func() error { return a.s.BackupTar(w) },
+	)
+}
+
+func (a *ThreadSafeWriteWrapper) backup(write func(b *stateBackup) error, fallback func() error) error {
+	src, ok := a.s.(backupSource)
+	if !ok {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		return fallback()
+	}
+	return writeBackup(lockedBackupSource{mu: a.mu, src: src}, write)
+}
+
+// lockedBackupSource captures the backup under the write lock of the state.
+type lockedBackupSource struct {
+	mu  *sync.RWMutex
+	src backupSource
+}
+
+func (l lockedBackupSource) captureBackup() (*stateBackup, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.src.captureBackup()
+}
+
+// StorageStats doesn't lock the state, the statistics are collected by the database concurrently.
+func (a *ThreadSafeWriteWrapper) StorageStats() (*keyvalue.Stats, error) {
+	return a.s.StorageStats()
+}
+
 func (a *ThreadSafeWriteWrapper) Close() error {
 	a.lock()
 	defer a.unlock()
@@ -18,6 +18,7 @@ type verifyTaskType byte
 const (
 	verifyBlock verifyTaskType = iota + 1
 	verifyTx
+	verifyBlockSignature
 )
 
 type verifierChans struct {
@@ -46,6 +47,7 @@ type verifyTask struct {
 	taskType     verifyTaskType
 	parentID     proto.BlockID
 	block        *proto.Block
+	skipBlockSig bool // signature is verified by the separate task or not verified if the block is checkpointed
 	tx           proto.Transaction
 	checkTxSig   bool
 	checkOrder1  bool
@@ -164,13 +166,13 @@ func handleTask(task *verifyTask, scheme proto.Scheme) error {
 			}
 		}
 		// Check block signature and transactions root hash if applied.
-		validSig, err := task.block.VerifySignature(scheme)
-		if err != nil {
-			return errors.Wrap(err, "State: handleTask: failed to verify block signature")
-		}
-		if !validSig {
-			return errors.Errorf("State: handleTask: invalid block signature (%s) of block '%s'",
-				task.block.BlockSignature.String(), task.block.ID.String())
+		if task.skipBlockSig {
+			// The block is trusted by its ID, so the ID must be the hash of the header.
+			if err := verifyBlockID(task.block, scheme); err != nil {
+				return err
+			}
+		} else if err := verifyBlockSig(task.block, scheme); err != nil {
+			return err
 		}
 		validRootHash, err := task.block.VerifyTransactionsRoot(scheme)
 		if err != nil {
@@ -180,6 +182,10 @@ func handleTask(task *verifyTask, scheme proto.Scheme) error {
 			return errors.Errorf("State: handleTask: invalid transaction root hash (%s) of block '%s'",
 				task.block.TransactionsRoot.String(), task.block.ID.String())
 		}
+	case verifyBlockSignature:
+		if err := verifyBlockSig(task.block, scheme); err != nil {
+			return err
+		}
 	case verifyTx:
 		params := proto.TransactionValidationParams{Scheme: scheme, CheckVersion: task.checkVersion}
 		if err := checkTx(task.tx, task.checkTxSig, task.checkOrder1, task.checkOrder2, params); err != nil {
@@ -195,6 +201,30 @@ func handleTask(task *verifyTask, scheme proto.Scheme) error {
 	return nil
 }
 
+func verifyBlockSig(block *proto.Block, scheme proto.Scheme) error {
+	validSig, err := block.VerifySignature(scheme)
+	if err != nil {
+		return errors.Wrap(err, "State: handleTask: failed to verify block signature")
+	}
+	if !validSig {
+		return errors.Errorf("State: handleTask: invalid block signature (%s) of block '%s'",
+			block.BlockSignature.String(), block.ID.String())
+	}
+	return nil
+}
+
+func verifyBlockID(block *proto.Block, scheme proto.Scheme) error {
+	h := block.BlockHeader
+	if err := h.GenerateBlockID(scheme); err != nil {
+		return errors.Wrap(err, "State: handleTask: failed to generate block ID")
+	}
+	if h.ID != block.ID {
+		return errors.Errorf("State: handleTask: invalid ID '%s' of block, expected '%s'",
+			block.ID.String(), h.ID.String())
+	}
+	return nil
+}
+
 func verify(ctx context.Context, tasks <-chan *verifyTask, scheme proto.Scheme) error {
 	for {
 		select {
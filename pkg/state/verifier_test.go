@@ -6,7 +6,9 @@ import (
 	"runtime"
 	"testing"
 
+	"github.com/mr-tron/base58"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/wavesplatform/gowaves/pkg/crypto"
 	"github.com/wavesplatform/gowaves/pkg/proto"
@@ -109,3 +111,59 @@ func TestVerifier(t *testing.T) {
 	err = verifyTransactions(txs, chans)
 	assert.Error(t, err, "verifyTransactions() did not fail with invalid tx")
 }
+
+// TestVerifierParallel checks that transactions are verified by many goroutines and the invalid one is reported.
+func TestVerifierParallel(t *testing.T) {
+	const txsCount = 522
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sk, pk, err := crypto.GenerateKeyPair([]byte("parallel verification seed"))
+	require.NoError(t, err)
+	recipient, err := proto.NewAddressFromPublicKey(proto.TestNetScheme, pk)
+	require.NoError(t, err)
+	txs := make([]proto.Transaction, txsCount)
+	for i := range txs {
+		waves := proto.NewOptionalAssetWaves()
+		rcp := proto.NewRecipientFromAddress(recipient)
+		var tx proto.Transaction
+		if i%2 == 0 {
+			tx = proto.NewUnsignedTransferWithSig(pk, waves, waves, uint64(i+1), 100, 100000, rcp, nil)
+		} else {
+			tx = proto.NewUnsignedTransferWithProofs(2, pk, waves, waves, uint64(i+1), 100, 100000, rcp, nil)
+		}
+		require.NoError(t, tx.Sign(proto.TestNetScheme, sk))
+		txs[i] = tx
+	}
+	for _, n := range []int{1, runtime.NumCPU()} {
+		err = verifyTransactions(txs, launchVerifier(ctx, n, proto.TestNetScheme))
+		assert.NoError(t, err)
+	}
+
+	// Spoil signature of one transaction in the middle.
+	spoiled := txs[txsCount/2].(*proto.TransferWithProofs)
+	spoiled.Amount++
+	id, err := spoiled.GetID(proto.TestNetScheme)
+	require.NoError(t, err)
+	err = verifyTransactions(txs, launchVerifier(ctx, 1, proto.TestNetScheme))
+	assert.ErrorContains(t, err, base58.Encode(id))
+	assert.ErrorContains(t, err, "signature verification failed")
+}
+
+func TestVerifyBlockID(t *testing.T) {
+	sk, pk, err := crypto.GenerateKeyPair([]byte("seed"))
+	require.NoError(t, err)
+	nxt := proto.NxtConsensus{BaseTarget: 100, GenSignature: make([]byte, 96)}
+	block, err := proto.CreateBlock(proto.Transactions{}, 1, proto.NewBlockIDFromDigest(crypto.Digest{1}), pk, nxt,
+		proto.ProtobufBlockVersion, nil, -1, proto.TestNetScheme, nil)
+	require.NoError(t, err)
+	require.NoError(t, block.Sign(proto.TestNetScheme, sk))
+	require.NoError(t, block.GenerateBlockID(proto.TestNetScheme))
+	assert.NoError(t, verifyBlockID(block, proto.TestNetScheme))
+
+	valid := block.ID
+	block.ID = proto.NewBlockIDFromDigest(crypto.Digest{2})
+	assert.Error(t, verifyBlockID(block, proto.TestNetScheme), "ID must be the hash of the header")
+	block.ID = valid
+	block.Timestamp++
+	assert.Error(t, verifyBlockID(block, proto.TestNetScheme), "header must not be changed after the ID is set")
+}
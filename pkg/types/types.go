@@ -192,6 +192,12 @@ type Time interface {
 	Now() time.Time
 }
 
+// ClockDrift reports the offset of the local clock from the reference time. Zero measurement time means that the
+// offset was never measured.
+type ClockDrift interface {
+	ClockOffset() (offset time.Duration, measured time.Time)
+}
+
 type ScoreSender interface {
 	Priority()
 	NonPriority()
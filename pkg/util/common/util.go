@@ -67,12 +67,10 @@ func GetStatePath() (string, error) {
 
 func ToBase58JSON(b []byte) []byte {
 	s := base58.Encode(b)
-	var sb bytes.Buffer
-	sb.Grow(2 + len(s))
-	sb.WriteRune('"')
-	sb.WriteString(s)
-	sb.WriteRune('"')
-	return sb.Bytes()
+	r := make([]byte, 0, 2+len(s))
+	r = append(r, '"')
+	r = append(r, s...)
+	return append(r, '"')
 }
 
 func ToBase64JSON(b []byte) []byte {
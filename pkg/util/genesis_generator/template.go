@@ -0,0 +1,192 @@
+package genesis_generator
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/wavesplatform/gowaves/pkg/crypto"
+	"github.com/wavesplatform/gowaves/pkg/proto"
+	"github.com/wavesplatform/gowaves/pkg/settings"
+)
+
+// Balance is an initial balance of the genesis template. Recipient is an address or a Base58 encoded account seed.
+type Balance struct {
+	Recipient string `json:"recipient"`
+	Amount    uint64 `json:"amount"`
+}
+
+// Template describes a private network genesis: initial balances and blockchain settings.
+// Zero values of settings mean that the values should be taken from other sources, e.g. command line.
+type Template struct {
+	Scheme               string    `json:"scheme,omitempty"`
+	BaseTarget           uint64    `json:"base_target,omitempty"`
+	Timestamp            uint64    `json:"timestamp,omitempty"`
+	AverageBlockDelay    uint64    `json:"average_block_delay_seconds,omitempty"`
+	PreactivatedFeatures []int16   `json:"preactivated_features,omitempty"`
+	Balances             []Balance `json:"balances"`
+}
+
+// ReadJSONTemplate reads the whole template from JSON.
+func ReadJSONTemplate(r io.Reader) (*Template, error) {
+	t := new(Template)
+	if err := json.NewDecoder(r).Decode(t); err != nil {
+		return nil, errors.Wrap(err, "failed to decode JSON template")
+	}
+	if len(t.Balances) == 0 {
+		return nil, errors.New("no balances in template")
+	}
+	return t, nil
+}
+
+// ReadCSVTemplate reads initial balances from CSV with 'recipient,amount' records.
+// Lines started with '#' are ignored, the first line is treated as header if its amount is not a number.
+func ReadCSVTemplate(r io.Reader) (*Template, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = 2
+	cr.TrimLeadingSpace = true
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read CSV template")
+	}
+	t := &Template{Balances: make([]Balance, 0, len(records))}
+	for i, rec := range records {
+		amount, pErr := ParseAmount(rec[1])
+		if pErr != nil {
+			if i == 0 {
+				continue // header
+			}
+			return nil, errors.Wrapf(pErr, "line %d", i+1)
+		}
+		t.Balances = append(t.Balances, Balance{Recipient: strings.TrimSpace(rec[0]), Amount: amount})
+	}
+	if len(t.Balances) == 0 {
+		return nil, errors.New("no balances in template")
+	}
+	return t, nil
+}
+
+// ParseAmount parses amount of wavelets, underscores could be used as digits separators.
+func ParseAmount(s string) (uint64, error) {
+	s = strings.TrimSpace(s)
+	a, err := strconv.ParseUint(strings.ReplaceAll(s, "_", ""), 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid amount '%s'", s)
+	}
+	return a, nil
+}
+
+// RecipientAddress converts the string to address. If the string is not a valid address it's treated
+// as Base58 encoded account seed.
+func RecipientAddress(s string, scheme proto.Scheme) (proto.WavesAddress, error) {
+	addr, err := proto.NewAddressFromString(s)
+	if err == nil {
+		return addr, nil
+	}
+	seed, err := crypto.NewDigestFromBase58(s)
+	if err != nil {
+		return proto.WavesAddress{}, errors.Wrapf(err, "failed to convert '%s' to address or account seed", s)
+	}
+	_, pk, err := crypto.GenerateKeyPair(seed[:])
+	if err != nil {
+		return proto.WavesAddress{}, errors.Wrapf(err, "failed to generate address from seed '%s'", seed.String())
+	}
+	addr, err = proto.NewAddressFromPublicKey(scheme, pk)
+	if err != nil {
+		return proto.WavesAddress{}, errors.Wrapf(err, "failed to generate address from seed '%s'", seed.String())
+	}
+	return addr, nil
+}
+
+// Transactions produces genesis transactions infos for the template balances.
+func (t *Template) Transactions(scheme proto.Scheme, timestamp uint64) ([]GenesisTransactionInfo, error) {
+	r := make([]GenesisTransactionInfo, 0, len(t.Balances))
+	total := uint64(0)
+	for _, b := range t.Balances {
+		addr, err := RecipientAddress(b.Recipient, scheme)
+		if err != nil {
+			return nil, err
+		}
+		if addr.Scheme() != scheme {
+			return nil, errors.Errorf("address '%s' belongs to other network", addr.String())
+		}
+		if total+b.Amount < total {
+			return nil, errors.New("total genesis balance overflows")
+		}
+		total += b.Amount
+		r = append(r, GenesisTransactionInfo{Address: addr, Amount: b.Amount, Timestamp: timestamp})
+	}
+	return r, nil
+}
+
+// GoSettings creates blockchain settings for Go node with the given genesis block.
+func (t *Template) GoSettings(block *proto.Block, scheme proto.Scheme) *settings.BlockchainSettings {
+	cfg := settings.MustDefaultCustomSettings()
+	cfg.Genesis = *block
+	cfg.AddressSchemeCharacter = scheme
+	if t.AverageBlockDelay != 0 {
+		cfg.AverageBlockDelaySeconds = t.AverageBlockDelay
+	}
+	if len(t.PreactivatedFeatures) != 0 {
+		cfg.PreactivatedFeatures = t.PreactivatedFeatures
+	}
+	return cfg
+}
+
+// ScalaSettings creates 'waves.blockchain' section of Scala node configuration (HOCON) from the settings of Go node,
+// so both nodes share the same genesis block and blockchain settings.
+func ScalaSettings(cfg *settings.BlockchainSettings) (string, error) {
+	block := &cfg.Genesis
+	var (
+		sb    strings.Builder
+		total uint64
+		txs   strings.Builder
+	)
+	for _, tx := range block.Transactions {
+		g, ok := tx.(*proto.Genesis)
+		if !ok {
+			return "", errors.Errorf("unexpected transaction type %T in genesis block", tx)
+		}
+		total += g.Amount
+		_, _ = fmt.Fprintf(&txs, "        {recipient = \"%s\", amount = %d}\n", g.Recipient.String(), g.Amount)
+	}
+	txTimestamp := block.Timestamp
+	if len(block.Transactions) > 0 {
+		txTimestamp = block.Transactions[0].GetTimestamp()
+	}
+	_, _ = fmt.Fprintf(&sb, "waves.blockchain {\n")
+	_, _ = fmt.Fprintf(&sb, "  type = CUSTOM\n")
+	_, _ = fmt.Fprintf(&sb, "  custom {\n")
+	_, _ = fmt.Fprintf(&sb, "    address-scheme-character = \"%c\"\n", cfg.AddressSchemeCharacter)
+	if len(cfg.PreactivatedFeatures) != 0 {
+		features := make([]int, len(cfg.PreactivatedFeatures))
+		for i, f := range cfg.PreactivatedFeatures {
+			features[i] = int(f)
+		}
+		sort.Ints(features)
+		_, _ = fmt.Fprintf(&sb, "    functionality.pre-activated-features {\n")
+		for _, f := range features {
+			_, _ = fmt.Fprintf(&sb, "      %d = 0\n", f)
+		}
+		_, _ = fmt.Fprintf(&sb, "    }\n")
+	}
+	_, _ = fmt.Fprintf(&sb, "    genesis {\n")
+	_, _ = fmt.Fprintf(&sb, "      average-block-delay = %ds\n", cfg.AverageBlockDelaySeconds)
+	_, _ = fmt.Fprintf(&sb, "      initial-base-target = %d\n", block.BaseTarget)
+	_, _ = fmt.Fprintf(&sb, "      timestamp = %d\n", txTimestamp)
+	_, _ = fmt.Fprintf(&sb, "      block-timestamp = %d\n", block.Timestamp)
+	_, _ = fmt.Fprintf(&sb, "      signature = \"%s\"\n", block.BlockSignature.String())
+	_, _ = fmt.Fprintf(&sb, "      initial-balance = %d\n", total)
+	_, _ = fmt.Fprintf(&sb, "      transactions = [\n%s      ]\n", txs.String())
+	_, _ = fmt.Fprintf(&sb, "    }\n")
+	_, _ = fmt.Fprintf(&sb, "  }\n")
+	_, _ = fmt.Fprintf(&sb, "}\n")
+	return sb.String(), nil
+}
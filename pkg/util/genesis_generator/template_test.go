@@ -0,0 +1,63 @@
+package genesis_generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wavesplatform/gowaves/pkg/proto"
+)
+
+func TestReadCSVTemplate(t *testing.T) {
+	a, err := proto.MustKeyPair([]byte("test")).Addr(proto.CustomNetScheme)
+	require.NoError(t, err)
+	csv := "recipient,amount\n# comment\n" + a.String() + ",100_000_000\n" +
+		"8GVECo9addsbFumLsmnAU3Cfz7UiF5TGm64zkZnfntdA, 200\n"
+	tmpl, err := ReadCSVTemplate(strings.NewReader(csv))
+	require.NoError(t, err)
+	require.Len(t, tmpl.Balances, 2)
+	assert.Equal(t, uint64(100_000_000), tmpl.Balances[0].Amount)
+	assert.Equal(t, uint64(200), tmpl.Balances[1].Amount)
+
+	txs, err := tmpl.Transactions(proto.CustomNetScheme, 1558516864282)
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+	assert.Equal(t, a, txs[0].Address)
+
+	_, err = tmpl.Transactions(proto.MainNetScheme, 1558516864282)
+	assert.Error(t, err)
+
+	_, err = ReadCSVTemplate(strings.NewReader("recipient,amount\n"))
+	assert.Error(t, err)
+	_, err = ReadCSVTemplate(strings.NewReader(a.String() + ",100\n" + a.String() + ",abc\n"))
+	assert.Error(t, err)
+}
+
+func TestTemplateSettings(t *testing.T) {
+	a, err := proto.MustKeyPair([]byte("test")).Addr(proto.CustomNetScheme)
+	require.NoError(t, err)
+	js := `{"average_block_delay_seconds": 10, "preactivated_features": [14, 2],` +
+		`"balances": [{"recipient": "` + a.String() + `", "amount": 9000000000000000}]}`
+	tmpl, err := ReadJSONTemplate(strings.NewReader(js))
+	require.NoError(t, err)
+	txs, err := tmpl.Transactions(proto.CustomNetScheme, 1558516864282)
+	require.NoError(t, err)
+	block, err := GenerateGenesisBlock(proto.CustomNetScheme, txs, 153722867, 1558516864282)
+	require.NoError(t, err)
+
+	cfg := tmpl.GoSettings(block, proto.CustomNetScheme)
+	assert.Equal(t, uint64(10), cfg.AverageBlockDelaySeconds)
+	assert.Equal(t, []int16{14, 2}, cfg.PreactivatedFeatures)
+	assert.Equal(t, block.BlockSignature, cfg.Genesis.BlockSignature)
+
+	scala, err := ScalaSettings(cfg)
+	require.NoError(t, err)
+	assert.Contains(t, scala, "address-scheme-character = \"E\"")
+	assert.Contains(t, scala, "average-block-delay = 10s")
+	assert.Contains(t, scala, "initial-balance = 9000000000000000")
+	assert.Contains(t, scala, "signature = \""+block.BlockSignature.String()+"\"")
+	assert.Contains(t, scala, "{recipient = \""+a.String()+"\", amount = 9000000000000000}")
+	assert.Less(t, strings.Index(scala, "2 = 0"), strings.Index(scala, "14 = 0"))
+}
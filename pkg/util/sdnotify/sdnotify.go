@@ -0,0 +1,116 @@
+// Package sdnotify implements the notification protocol of systemd service manager, see sd_notify(3).
+// Notifications are sent only if the process is started by systemd as the service of Type=notify,
+// otherwise they are silently ignored.
+package sdnotify
+
+import (
+	"context"
+	"net"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	// Ready tells the service manager that the startup is completed.
+	Ready = "READY=1"
+	// Reloading tells the service manager that the service is restarting, it must send Ready when it's done.
+	Reloading = "RELOADING=1"
+	// Stopping tells the service manager that the service is shutting down.
+	Stopping = "STOPPING=1"
+	// Watchdog is the keep-alive ping of the watchdog.
+	Watchdog = "WATCHDOG=1"
+)
+
+const (
+	notifySocketEnv = "NOTIFY_SOCKET"
+	watchdogUSecEnv = "WATCHDOG_USEC"
+	watchdogPIDEnv  = "WATCHDOG_PID"
+)
+
+// Status returns the notification with the free-form status of the service shown by 'systemctl status'.
+func Status(status string) string {
+	return "STATUS=" + status
+}
+
+// Notify sends the state to the service manager. It returns false if the notification socket is not set,
+// i.e. the process is not started by systemd.
+func Notify(state string) (bool, error) {
+	path := os.Getenv(notifySocketEnv)
+	if path == "" {
+		return false, nil
+	}
+	addr := &net.UnixAddr{Name: path, Net: "unixgram"} // names starting with '@' are abstract sockets
+	conn, err := net.DialUnix(addr.Net, nil, addr)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to connect to notification socket")
+	}
+	defer func() { _ = conn.Close() }()
+	if _, wErr := conn.Write([]byte(state)); wErr != nil {
+		return false, errors.Wrap(wErr, "failed to send notification")
+	}
+	return true, nil
+}
+
+// NotifyOrLog sends the state to the service manager and logs the failure.
+func NotifyOrLog(state string) {
+	if _, err := Notify(state); err != nil {
+		zap.S().Warnf("Failed to notify systemd with '%s': %v", state, err)
+	}
+}
+
+// WatchdogInterval returns the watchdog timeout set by WatchdogSec= of the service unit,
+// zero is returned if the watchdog is disabled or is set for the other process.
+func WatchdogInterval() (time.Duration, error) {
+	usec := os.Getenv(watchdogUSecEnv)
+	if usec == "" {
+		return 0, nil
+	}
+	if pid := os.Getenv(watchdogPIDEnv); pid != "" {
+		p, err := strconv.Atoi(pid)
+		if err != nil {
+			return 0, errors.Wrapf(err, "invalid %s value '%s'", watchdogPIDEnv, pid)
+		}
+		if p != os.Getpid() {
+			return 0, nil
+		}
+	}
+	us, err := strconv.ParseUint(usec, 10, 63)
+	if err != nil || us == 0 {
+		return 0, errors.Errorf("invalid %s value '%s'", watchdogUSecEnv, usec)
+	}
+	return time.Duration(us) * time.Microsecond, nil
+}
+
+// RunWatchdog sends the keep-alive pings twice per the watchdog interval until the context is done.
+// Before each ping the liveness of the service is checked with the function, the ping is skipped if the check
+// fails or the previous check hasn't returned yet. So the deadlocked service misses the pings and is restarted
+// by the service manager on the watchdog timeout.
+func RunWatchdog(ctx context.Context, interval time.Duration, alive func() error) {
+	ticker := time.NewTicker(interval / 2)
+	defer ticker.Stop()
+	var checking atomic.Bool
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if !checking.CompareAndSwap(false, true) {
+			zap.S().Warn("Liveness check is not completed in time, systemd watchdog ping is skipped")
+			continue
+		}
+		go func() {
+			defer checking.Store(false)
+			if err := alive(); err != nil {
+				zap.S().Warnf("Liveness check failed, systemd watchdog ping is skipped: %v", err)
+				return
+			}
+			NotifyOrLog(Watchdog)
+		}()
+	}
+}
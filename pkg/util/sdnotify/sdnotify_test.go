@@ -0,0 +1,105 @@
+package sdnotify
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func listenNotifications(t *testing.T) *net.UnixConn {
+	// Paths of unix sockets are limited to about a hundred bytes, the test temporary directory could be longer.
+	dir, err := os.MkdirTemp("", "sdnotify")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	path := filepath.Join(dir, "notify.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	t.Setenv(notifySocketEnv, path)
+	return conn
+}
+
+func receive(t *testing.T, conn *net.UnixConn, timeout time.Duration) (string, bool) {
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(timeout)))
+	buf := make([]byte, 1024)
+	n, err := conn.Read(buf)
+	if err != nil {
+		return "", false
+	}
+	return string(buf[:n]), true
+}
+
+func TestNotify(t *testing.T) {
+	t.Setenv(notifySocketEnv, "")
+	sent, err := Notify(Ready)
+	require.NoError(t, err)
+	assert.False(t, sent)
+
+	conn := listenNotifications(t)
+	sent, err = Notify(Ready + "\n" + Status("Running"))
+	require.NoError(t, err)
+	assert.True(t, sent)
+	msg, ok := receive(t, conn, time.Second)
+	require.True(t, ok)
+	assert.Equal(t, "READY=1\nSTATUS=Running", msg)
+}
+
+func TestWatchdogInterval(t *testing.T) {
+	pid := strconv.Itoa(os.Getpid())
+	for _, test := range []struct {
+		usec     string
+		pid      string
+		interval time.Duration
+		ok       bool
+	}{
+		{"", "", 0, true},
+		{"30000000", "", 30 * time.Second, true},
+		{"30000000", pid, 30 * time.Second, true},
+		{"30000000", "1", 0, true},
+		{"0", "", 0, false},
+		{"abc", "", 0, false},
+		{"30000000", "abc", 0, false},
+	} {
+		t.Setenv(watchdogUSecEnv, test.usec)
+		t.Setenv(watchdogPIDEnv, test.pid)
+		interval, err := WatchdogInterval()
+		if test.ok {
+			require.NoError(t, err, test)
+			assert.Equal(t, test.interval, interval, test)
+		} else {
+			assert.Error(t, err, test)
+		}
+	}
+}
+
+func TestRunWatchdog(t *testing.T) {
+	conn := listenNotifications(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	failing := make(chan struct{})
+	go RunWatchdog(ctx, 20*time.Millisecond, func() error {
+		select {
+		case <-failing:
+			return errors.New("deadlock")
+		default:
+			return nil
+		}
+	})
+	msg, ok := receive(t, conn, time.Second)
+	require.True(t, ok)
+	assert.Equal(t, Watchdog, msg)
+
+	close(failing)
+	// Drain the ping which could be sent before the liveness check started failing.
+	_, _ = receive(t, conn, 50*time.Millisecond)
+	_, ok = receive(t, conn, 100*time.Millisecond)
+	assert.False(t, ok, "watchdog must not be pinged while the liveness check fails")
+}
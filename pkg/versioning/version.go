@@ -1,5 +1,56 @@
 package versioning
 
+import (
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+// Variables are set at link time, see Makefile.
 var (
-	Version = "v0.0.0"
+	Version   = "v0.0.0"
+	Commit    = ""
+	BuildDate = ""
 )
+
+// BuildInfo describes the build of the running binary.
+type BuildInfo struct {
+	Version   string   `json:"version"`
+	Commit    string   `json:"commit"`
+	BuildDate string   `json:"buildDate"`
+	GoVersion string   `json:"goVersion"`
+	BuildTags []string `json:"buildTags"`
+}
+
+// Info returns information about the build. Commit and build date that were not set at link time are taken
+// from VCS information embedded by the Go toolchain if it's available.
+func Info() BuildInfo {
+	info := BuildInfo{
+		Version:   Version,
+		Commit:    Commit,
+		BuildDate: BuildDate,
+		GoVersion: runtime.Version(),
+		BuildTags: []string{},
+	}
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "-tags":
+			if s.Value != "" {
+				info.BuildTags = strings.Split(s.Value, ",")
+			}
+		case "vcs.revision":
+			if info.Commit == "" {
+				info.Commit = s.Value
+			}
+		case "vcs.time":
+			if info.BuildDate == "" {
+				info.BuildDate = s.Value
+			}
+		}
+	}
+	return info
+}